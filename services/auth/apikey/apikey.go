@@ -0,0 +1,241 @@
+// services/auth/apikey/apikey.go
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/secrethash"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Errors returned when a presented key can't be used
+var (
+	ErrInvalidKey  = errors.New("invalid api key")
+	ErrKeyRevoked  = errors.New("api key has been revoked")
+	ErrKeyExpired  = errors.New("api key has expired")
+	ErrKeyNotFound = errors.New("api key not found")
+)
+
+// keyPrefix marks secrets issued by this package so they are easy to spot in logs and config
+const keyPrefix = "bb_"
+
+// Manager issues and validates API keys for non-interactive integrations. The key is
+// shown to the admin once at creation and can't be recovered afterwards, only revoked.
+type Manager struct {
+	db *sql.DB
+}
+
+// APIKey describes an issued key. It never carries the secret itself.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // first characters of the key, to identify it without revealing it
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Allows reports whether the key was granted scope
+func (k *APIKey) Allows(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// NewManager creates a new API key manager
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{db: db}
+}
+
+// Create issues a new key and returns it along with the plaintext secret.
+// A nil expiresAt creates a key that is valid until revoked.
+func (m *Manager) Create(ctx context.Context, name string, scopes []string, expiresAt *time.Time, createdBy string) (*APIKey, string, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate key ID: %w", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	plaintext := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &APIKey{
+		ID:        id.String(),
+		Name:      name,
+		Prefix:    plaintext[:len(keyPrefix)+6],
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	query := `
+	INSERT INTO api_keys
+	(key_id, name, key_prefix, key_hash, scopes, created_by, created_at, expires_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err = m.db.ExecContext(ctx, query,
+		key.ID,
+		key.Name,
+		key.Prefix,
+		secrethash.Hash(plaintext),
+		strings.Join(key.Scopes, ","),
+		key.CreatedBy,
+		key.CreatedAt,
+		nullTime(key.ExpiresAt),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to store api key: %w", err)
+	}
+
+	return key, plaintext, nil
+}
+
+// Validate looks up the key matching plaintext and checks it is still usable,
+// recording the time it was last used
+func (m *Manager) Validate(ctx context.Context, plaintext string) (*APIKey, error) {
+	if !strings.HasPrefix(plaintext, keyPrefix) {
+		return nil, ErrInvalidKey
+	}
+
+	query := `
+	SELECT key_id, name, key_prefix, scopes, created_by, created_at, expires_at, revoked_at, last_used_at
+	FROM api_keys
+	WHERE key_hash = ?
+	LIMIT 1`
+
+	key, err := scanKey(m.db.QueryRowContext(ctx, query, secrethash.Hash(plaintext)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidKey
+		}
+		return nil, fmt.Errorf("failed to look up api key: %w", err)
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil {
+		return nil, ErrKeyRevoked
+	}
+	if key.ExpiresAt != nil && now.After(*key.ExpiresAt) {
+		return nil, ErrKeyExpired
+	}
+
+	if _, err := m.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE key_id = ?`, now, key.ID); err != nil {
+		return nil, fmt.Errorf("failed to record api key use: %w", err)
+	}
+	key.LastUsedAt = &now
+
+	return key, nil
+}
+
+// Revoke disables a key immediately. Revoking an already revoked key is not an error.
+func (m *Manager) Revoke(ctx context.Context, id string) error {
+	result, err := m.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE key_id = ?`, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+// List returns every issued key, newest first, including revoked and expired ones
+func (m *Manager) List(ctx context.Context) ([]*APIKey, error) {
+	query := `
+	SELECT key_id, name, key_prefix, scopes, created_by, created_at, expires_at, revoked_at, last_used_at
+	FROM api_keys
+	ORDER BY created_at DESC`
+
+	rows, err := m.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*APIKey{}
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Private helper methods
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanKey(row scanner) (*APIKey, error) {
+	key := &APIKey{}
+	var scopes string
+	var expiresAt, revokedAt, lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&scopes,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&expiresAt,
+		&revokedAt,
+		&lastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = []string{}
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, ",")
+	}
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return key, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
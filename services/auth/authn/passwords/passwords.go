@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"golang.org/x/crypto/argon2"
 )
@@ -122,3 +123,22 @@ func VerifyPassword(password string, encodedHash string) (bool, error) {	// To b
 	}
 	return false, nil // Passwords do not match
 }
+
+// dummyHash is a hash of a throwaway password, made once on first use
+var dummyHash = sync.OnceValue(func() string {
+	hash, err := HashPassword("not a real password")
+	if err != nil {
+		panic(fmt.Sprintf("passwords: failed to create dummy hash: %v", err))
+	}
+	return hash
+})
+
+// VerifyDummy does the work of VerifyPassword against a throwaway hash. Call it when
+// there is no real hash to check, such as for an unknown email, so that the response
+// takes as long as a wrong password would and doesn't reveal whether the account exists.
+func VerifyDummy(password string) {
+	if password == "" {
+		password = " "
+	}
+	VerifyPassword(password, dummyHash())
+}
@@ -2,9 +2,11 @@ module github.com/adammwaniki/bebabeba/services/auth
 
 go 1.24.2
 
-require golang.org/x/crypto v0.41.0
-
 require (
-	github.com/golang-jwt/jwt/v5 v5.3.0 // indirect
-	golang.org/x/sys v0.35.0 // indirect
+	github.com/golang-jwt/jwt/v5 v5.3.0
+	golang.org/x/crypto v0.41.0
 )
+
+require golang.org/x/sys v0.35.0 // indirect
+
+require github.com/DATA-DOG/go-sqlmock v1.5.2
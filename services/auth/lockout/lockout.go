@@ -0,0 +1,221 @@
+// services/auth/lockout/lockout.go
+package lockout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config sets when repeated failed logins lock an email out and for how long
+type Config struct {
+	// Threshold is the number of consecutive failures that locks the email
+	Threshold int
+	// Duration is the first lockout. Each failure after the threshold doubles it.
+	Duration time.Duration
+	// MaxDuration caps the lockout
+	MaxDuration time.Duration
+	// ResetAfter is how long without a failure before the count starts over
+	ResetAfter time.Duration
+}
+
+// DefaultConfig is used for any setting not configured in the environment
+var DefaultConfig = Config{
+	Threshold:   5,
+	Duration:    time.Minute,
+	MaxDuration: time.Hour,
+	ResetAfter:  24 * time.Hour,
+}
+
+// ConfigFromEnv reads the lockout settings from LOGIN_LOCKOUT_THRESHOLD,
+// LOGIN_LOCKOUT_DURATION, LOGIN_LOCKOUT_MAX_DURATION and LOGIN_LOCKOUT_RESET_AFTER.
+// Unset or malformed values fall back to the defaults.
+func ConfigFromEnv() Config {
+	config := DefaultConfig
+	if value := strings.TrimSpace(os.Getenv("LOGIN_LOCKOUT_THRESHOLD")); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			config.Threshold = n
+		} else {
+			log.Printf("Invalid LOGIN_LOCKOUT_THRESHOLD %q, using %d", value, config.Threshold)
+		}
+	}
+	config.Duration = durationFromEnv("LOGIN_LOCKOUT_DURATION", config.Duration)
+	config.MaxDuration = durationFromEnv("LOGIN_LOCKOUT_MAX_DURATION", config.MaxDuration)
+	config.ResetAfter = durationFromEnv("LOGIN_LOCKOUT_RESET_AFTER", config.ResetAfter)
+	if config.MaxDuration < config.Duration {
+		config.MaxDuration = config.Duration
+	}
+	return config
+}
+
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using %s", name, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+// lockoutDuration is how long an email with the given number of consecutive failures is
+// locked for: not at all below the threshold, then Duration, doubling with each further
+// failure up to MaxDuration
+func (c Config) lockoutDuration(failures int) time.Duration {
+	if failures < c.Threshold {
+		return 0
+	}
+	d := c.Duration
+	for i := c.Threshold; i < failures && d < c.MaxDuration; i++ {
+		d *= 2
+	}
+	return min(d, c.MaxDuration)
+}
+
+// Status is the failed login record of an email
+type Status struct {
+	Email          string     `json:"email"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LastFailedAt   time.Time  `json:"last_failed_at"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+}
+
+// Locked reports whether the email is locked out at now
+func (s Status) Locked(now time.Time) bool {
+	return s.LockedUntil != nil && now.Before(*s.LockedUntil)
+}
+
+// Manager counts consecutive failed logins per email in the login_lockouts table and
+// locks an email out once they reach the threshold. Failures are counted for any email,
+// whether or not it has an account, so a lockout doesn't reveal which emails are
+// registered. Callers pass emails already normalized.
+type Manager struct {
+	db     *sql.DB
+	config Config
+}
+
+// NewManager creates a lockout manager backed by db
+func NewManager(db *sql.DB, config Config) *Manager {
+	return &Manager{db: db, config: config}
+}
+
+// LockedUntil returns when the email's lockout ends, or the zero time when it isn't locked
+func (m *Manager) LockedUntil(ctx context.Context, email string) (time.Time, error) {
+	var lockedUntil sql.NullTime
+	err := m.db.QueryRowContext(ctx,
+		`SELECT locked_until FROM login_lockouts WHERE email = ?`, email).Scan(&lockedUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to look up login lockout: %w", err)
+	}
+	if !lockedUntil.Valid || !time.Now().Before(lockedUntil.Time) {
+		return time.Time{}, nil
+	}
+	return lockedUntil.Time, nil
+}
+
+// RecordFailure counts a failed login for email and returns when the lockout it triggers
+// ends, or the zero time when it stays unlocked. The increment and the lockout decision
+// run under the row lock, so concurrent failures are each counted once.
+func (m *Manager) RecordFailure(ctx context.Context, email string) (time.Time, error) {
+	now := time.Now()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	// A failure long after the last one starts the count over. failed_attempts is
+	// assigned before last_failed_at, so it still compares against the previous failure.
+	query := `
+	INSERT INTO login_lockouts (email, failed_attempts, last_failed_at)
+	VALUES (?, 1, ?)
+	ON DUPLICATE KEY UPDATE
+		failed_attempts = IF(last_failed_at < ?, 1, failed_attempts + 1),
+		last_failed_at = VALUES(last_failed_at)`
+
+	if _, err := tx.ExecContext(ctx, query, email, now, now.Add(-m.config.ResetAfter)); err != nil {
+		return time.Time{}, fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	var failures int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT failed_attempts FROM login_lockouts WHERE email = ?`, email).Scan(&failures); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read failed logins: %w", err)
+	}
+
+	var lockedUntil time.Time
+	if d := m.config.lockoutDuration(failures); d > 0 {
+		lockedUntil = now.Add(d)
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE login_lockouts SET locked_until = ? WHERE email = ?`, lockedUntil, email); err != nil {
+			return time.Time{}, fmt.Errorf("failed to lock login: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to commit failed login: %w", err)
+	}
+
+	return lockedUntil, nil
+}
+
+// Reset clears the failed logins and any lockout of email, after a successful login or
+// when support unlocks the account
+func (m *Manager) Reset(ctx context.Context, email string) error {
+	if _, err := m.db.ExecContext(ctx, `DELETE FROM login_lockouts WHERE email = ?`, email); err != nil {
+		return fmt.Errorf("failed to reset login lockout: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the failed login record of email. ok is false when it has none.
+func (m *Manager) GetStatus(ctx context.Context, email string) (Status, bool, error) {
+	status := Status{Email: email}
+	var lockedUntil sql.NullTime
+	err := m.db.QueryRowContext(ctx,
+		`SELECT failed_attempts, last_failed_at, locked_until FROM login_lockouts WHERE email = ?`, email).Scan(
+		&status.FailedAttempts,
+		&status.LastFailedAt,
+		&lockedUntil,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Status{}, false, nil
+		}
+		return Status{}, false, fmt.Errorf("failed to look up login lockout: %w", err)
+	}
+	if lockedUntil.Valid {
+		status.LockedUntil = &lockedUntil.Time
+	}
+	return status, true, nil
+}
+
+// CleanupExpired removes records that are no longer locked and whose count would start
+// over anyway
+func (m *Manager) CleanupExpired(ctx context.Context) error {
+	now := time.Now()
+	_, err := m.db.ExecContext(ctx,
+		`DELETE FROM login_lockouts WHERE last_failed_at < ? AND (locked_until IS NULL OR locked_until < ?)`,
+		now.Add(-m.config.ResetAfter), now)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup login lockouts: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// services/auth/oauthstate/oauthstate.go
+package oauthstate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/secrethash"
+)
+
+// DefaultTTL is how long a user has to finish signing in with the OAuth provider
+const DefaultTTL = 10 * time.Minute
+
+// MemoryStore keeps OAuth states in process. It only works with a single gateway
+// instance; run several behind a load balancer and the callback may land on an
+// instance that never saw the state. Use SQLStore there.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]memoryState
+	stop   chan struct{}
+	once   sync.Once
+}
+
+type memoryState struct {
+	redirect  string
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates an in-memory store that drops expired states every sweepInterval.
+// Call Close to stop the sweeper.
+func NewMemoryStore(sweepInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{
+		states: make(map[string]memoryState),
+		stop:   make(chan struct{}),
+	}
+	go s.sweep(sweepInterval)
+	return s
+}
+
+// Put stores state with the URL to redirect to once the callback arrives
+func (s *MemoryStore) Put(ctx context.Context, state, redirect string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state] = memoryState{redirect: redirect, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Consume removes state and returns its redirect. ok is false if the state is unknown,
+// expired or was already consumed, so a replayed callback is rejected.
+func (s *MemoryStore) Consume(ctx context.Context, state string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, exists := s.states[state]
+	if !exists {
+		return "", false, nil
+	}
+	delete(s.states, state)
+	if time.Now().After(stored.expiresAt) {
+		return "", false, nil
+	}
+	return stored.redirect, true, nil
+}
+
+// Close stops the sweeper
+func (s *MemoryStore) Close() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// sweep drops states whose callback never arrived, so abandoned logins don't pile up
+func (s *MemoryStore) sweep(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for state, stored := range s.states {
+				if now.After(stored.expiresAt) {
+					delete(s.states, state)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// SQLStore keeps OAuth states in the oauth_states table so every gateway instance sees
+// them, keyed by their hash so a read of the table can't be replayed as a callback.
+type SQLStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore creates a store backed by db
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+// Put stores state with the URL to redirect to once the callback arrives
+func (s *SQLStore) Put(ctx context.Context, state, redirect string, ttl time.Duration) error {
+	query := `
+	INSERT INTO oauth_states (state_hash, redirect_url, expires_at)
+	VALUES (?, ?, ?)`
+
+	if _, err := s.db.ExecContext(ctx, query, secrethash.Hash(state), redirect, time.Now().Add(ttl)); err != nil {
+		return fmt.Errorf("failed to store OAuth state: %w", err)
+	}
+	return nil
+}
+
+// Consume removes state and returns its redirect. ok is false if the state is unknown,
+// expired or was already consumed. Of two callbacks racing on the same state only the
+// one whose delete removes the row succeeds.
+func (s *SQLStore) Consume(ctx context.Context, state string) (string, bool, error) {
+	hash := secrethash.Hash(state)
+
+	var redirect string
+	var expiresAt time.Time
+	err := s.db.QueryRowContext(ctx,
+		`SELECT redirect_url, expires_at FROM oauth_states WHERE state_hash = ?`, hash).Scan(&redirect, &expiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to look up OAuth state: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM oauth_states WHERE state_hash = ?`, hash)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to consume OAuth state: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to consume OAuth state: %w", err)
+	}
+	if deleted == 0 || time.Now().After(expiresAt) {
+		return "", false, nil
+	}
+	return redirect, true, nil
+}
+
+// CleanupExpired removes states whose callback never arrived
+func (s *SQLStore) CleanupExpired(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM oauth_states WHERE expires_at < ?`, time.Now()); err != nil {
+		return fmt.Errorf("failed to cleanup expired OAuth states: %w", err)
+	}
+	return nil
+}
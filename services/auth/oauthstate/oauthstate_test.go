@@ -0,0 +1,233 @@
+// services/auth/oauthstate/oauthstate_test.go
+package oauthstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/auth/secrethash"
+)
+
+func TestMemoryStoreConsume(t *testing.T) {
+	s := NewMemoryStore(time.Hour)
+	defer s.Close()
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "state-1", "/dashboard", DefaultTTL); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	redirect, ok, err := s.Consume(ctx, "state-1")
+	if err != nil || !ok || redirect != "/dashboard" {
+		t.Fatalf("Consume = %q, %t, %v, want /dashboard, true, nil", redirect, ok, err)
+	}
+
+	// A replayed callback finds the state gone
+	if redirect, ok, err := s.Consume(ctx, "state-1"); err != nil || ok || redirect != "" {
+		t.Errorf("second Consume = %q, %t, %v, want rejected", redirect, ok, err)
+	}
+	if _, ok, _ := s.Consume(ctx, "never-issued"); ok {
+		t.Error("Consume accepted a state that was never issued")
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	s := NewMemoryStore(time.Hour)
+	defer s.Close()
+	ctx := context.Background()
+
+	s.Put(ctx, "state-1", "/dashboard", -time.Second)
+	if _, ok, _ := s.Consume(ctx, "state-1"); ok {
+		t.Error("Consume accepted an expired state")
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	s := NewMemoryStore(10 * time.Millisecond)
+	defer s.Close()
+
+	s.Put(context.Background(), "expired", "/a", -time.Second)
+	s.Put(context.Background(), "live", "/b", time.Hour)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		_, expired := s.states["expired"]
+		_, live := s.states["live"]
+		s.mu.Unlock()
+		if !live {
+			t.Fatal("sweeper dropped a live state")
+		}
+		if !expired {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("sweeper didn't drop the expired state")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMemoryStoreConcurrent(t *testing.T) {
+	const states = 50
+	const callbacks = 8 // callbacks racing on each state
+
+	s := NewMemoryStore(time.Millisecond) // sweep alongside the callbacks
+	defer s.Close()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := range states {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Put(ctx, fmt.Sprintf("state-%d", i), fmt.Sprintf("/page/%d", i), DefaultTTL)
+		}()
+	}
+	wg.Wait()
+
+	var consumed [states]atomic.Int32
+	for i := range states {
+		for range callbacks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				redirect, ok, err := s.Consume(ctx, fmt.Sprintf("state-%d", i))
+				if err != nil {
+					t.Errorf("Consume: %v", err)
+					return
+				}
+				if ok {
+					consumed[i].Add(1)
+					if want := fmt.Sprintf("/page/%d", i); redirect != want {
+						t.Errorf("redirect = %q, want %q", redirect, want)
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	// Exactly one callback per state gets through; every replay is rejected
+	for i := range states {
+		if n := consumed[i].Load(); n != 1 {
+			t.Errorf("state-%d consumed %d times, want once", i, n)
+		}
+	}
+}
+
+func TestMemoryStoreCloseTwice(t *testing.T) {
+	s := NewMemoryStore(time.Hour)
+	s.Close()
+	s.Close()
+}
+
+var (
+	selectStateQuery = regexp.QuoteMeta(`SELECT redirect_url, expires_at FROM oauth_states WHERE state_hash = ?`)
+	deleteStateQuery = regexp.QuoteMeta(`DELETE FROM oauth_states WHERE state_hash = ?`)
+)
+
+func TestSQLStoreConsume(t *testing.T) {
+	hash := secrethash.Hash("state-1")
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name         string
+		expiresAt    time.Time
+		found        bool
+		deleted      int64
+		wantRedirect string
+		wantOK       bool
+	}{
+		{name: "live state", expiresAt: future, found: true, deleted: 1, wantRedirect: "/dashboard", wantOK: true},
+		// Another callback deleted the row between our read and our delete
+		{name: "lost the race", expiresAt: future, found: true, deleted: 0},
+		{name: "expired state", expiresAt: past, found: true, deleted: 1},
+		{name: "unknown state"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			defer db.Close()
+			s := NewSQLStore(db)
+
+			rows := sqlmock.NewRows([]string{"redirect_url", "expires_at"})
+			if tt.found {
+				rows.AddRow("/dashboard", tt.expiresAt)
+			}
+			mock.ExpectQuery(selectStateQuery).WithArgs(hash).WillReturnRows(rows)
+			if tt.found {
+				mock.ExpectExec(deleteStateQuery).WithArgs(hash).WillReturnResult(sqlmock.NewResult(0, tt.deleted))
+			}
+
+			redirect, ok, err := s.Consume(context.Background(), "state-1")
+			if err != nil {
+				t.Fatalf("Consume: %v", err)
+			}
+			if ok != tt.wantOK || redirect != tt.wantRedirect {
+				t.Errorf("Consume = %q, %t, want %q, %t", redirect, ok, tt.wantRedirect, tt.wantOK)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSQLStoreConsumeErrors(t *testing.T) {
+	hash := secrethash.Hash("state-1")
+	dbErr := errors.New("connection reset")
+
+	t.Run("lookup fails", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+		mock.ExpectQuery(selectStateQuery).WithArgs(hash).WillReturnError(dbErr)
+
+		if _, ok, err := NewSQLStore(db).Consume(context.Background(), "state-1"); ok || !errors.Is(err, dbErr) {
+			t.Errorf("Consume = %t, %v, want false, %v", ok, err, dbErr)
+		}
+	})
+
+	t.Run("delete fails", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+		mock.ExpectQuery(selectStateQuery).WithArgs(hash).
+			WillReturnRows(sqlmock.NewRows([]string{"redirect_url", "expires_at"}).AddRow("/dashboard", time.Now().Add(time.Minute)))
+		mock.ExpectExec(deleteStateQuery).WithArgs(hash).WillReturnError(dbErr)
+
+		if _, ok, err := NewSQLStore(db).Consume(context.Background(), "state-1"); ok || !errors.Is(err, dbErr) {
+			t.Errorf("Consume = %t, %v, want false, %v", ok, err, dbErr)
+		}
+	})
+}
+
+func TestSQLStoreStoresOnlyTheHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO oauth_states")).
+		WithArgs(secrethash.Hash("state-1"), "/dashboard", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := NewSQLStore(db).Put(context.Background(), "state-1", "/dashboard", DefaultTTL); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
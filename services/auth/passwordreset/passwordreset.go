@@ -0,0 +1,157 @@
+// services/auth/passwordreset/passwordreset.go
+package passwordreset
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/secrethash"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Errors returned when a presented token can't be redeemed
+var (
+	ErrInvalidToken = errors.New("invalid password reset token")
+	ErrTokenExpired = errors.New("password reset token has expired")
+	ErrTokenUsed    = errors.New("password reset token has already been used")
+)
+
+// DefaultTTL is how long a reset token stays valid when no TTL is configured
+const DefaultTTL = 30 * time.Minute
+
+// Manager issues and redeems single-use password reset tokens. Issuing a token voids
+// the user's earlier ones, so only the latest link sent works.
+type Manager struct {
+	db  *sql.DB
+	ttl time.Duration
+}
+
+// NewManager creates a new password reset manager whose tokens live for ttl
+func NewManager(db *sql.DB, ttl time.Duration) *Manager {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Manager{db: db, ttl: ttl}
+}
+
+// Issue creates a reset token for the user, replacing any unused ones issued earlier,
+// and returns the plaintext token with its expiry
+func (m *Manager) Issue(ctx context.Context, userID string) (string, time.Time, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+	plaintext := base64.RawURLEncoding.EncodeToString(secret)
+
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	// Only the most recent link should work
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = ? WHERE user_id = ? AND used_at IS NULL`, now, userID); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to invalidate previous tokens: %w", err)
+	}
+
+	query := `
+	INSERT INTO password_reset_tokens
+	(token_id, user_id, token_hash, created_at, expires_at)
+	VALUES (?, ?, ?, ?, ?)`
+
+	if _, err := tx.ExecContext(ctx, query, id.String(), userID, secrethash.Hash(plaintext), now, expiresAt); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to store reset token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to commit reset token: %w", err)
+	}
+
+	return plaintext, expiresAt, nil
+}
+
+// Redeem checks the token and calls apply with the user it was issued to. The token is
+// only marked used when apply succeeds, so a rejected new password doesn't burn the link.
+// The token row stays locked while apply runs, so a token can't be redeemed twice.
+func (m *Manager) Redeem(ctx context.Context, plaintext string, apply func(ctx context.Context, userID string) error) error {
+	if plaintext == "" {
+		return ErrInvalidToken
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	query := `
+	SELECT token_id, user_id, expires_at, used_at
+	FROM password_reset_tokens
+	WHERE token_hash = ?
+	FOR UPDATE`
+
+	var tokenID, userID string
+	var expiresAt time.Time
+	var usedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, query, secrethash.Hash(plaintext)).Scan(&tokenID, &userID, &expiresAt, &usedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidToken
+		}
+		return fmt.Errorf("failed to look up reset token: %w", err)
+	}
+
+	if usedAt.Valid {
+		return ErrTokenUsed
+	}
+	if time.Now().After(expiresAt) {
+		return ErrTokenExpired
+	}
+
+	if err := apply(ctx, userID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE password_reset_tokens SET used_at = ? WHERE token_id = ?`, time.Now(), tokenID); err != nil {
+		return fmt.Errorf("failed to mark reset token used: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reset token: %w", err)
+	}
+
+	return nil
+}
+
+// CleanupExpiredTokens removes tokens that can no longer be redeemed
+func (m *Manager) CleanupExpiredTokens(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx,
+		`DELETE FROM password_reset_tokens WHERE expires_at < ? OR used_at IS NOT NULL`, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to cleanup reset tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+// services/auth/secrethash/secrethash.go
+package secrethash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash returns the hex SHA-256 of a generated secret, the form API keys, reset tokens
+// and OAuth states are stored and looked up in. Each is 32 random bytes, so a fast
+// unsalted hash already makes a leaked table useless; a slow password hash is only
+// needed for secrets people choose.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,15 @@
+// services/auth/secrethash/secrethash_test.go
+package secrethash
+
+import "testing"
+
+func TestHash(t *testing.T) {
+	// Stored hashes must not change, or every issued key, token and state stops matching
+	const want = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := Hash("abc"); got != want {
+		t.Errorf("Hash(abc) = %s, want %s", got, want)
+	}
+	if Hash("abc") == Hash("abd") {
+		t.Error("different secrets hash the same")
+	}
+}
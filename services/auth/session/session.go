@@ -190,6 +190,18 @@ func (sm *SessionManager) EndAllUserSessions(ctx context.Context, userID string)
 	return nil
 }
 
+// EndOtherUserSessions terminates every active session for a user except keepSessionID
+func (sm *SessionManager) EndOtherUserSessions(ctx context.Context, userID, keepSessionID string) error {
+	query := `UPDATE user_sessions SET is_active = false, updated_at = ? WHERE user_id = ? AND session_id <> ? AND is_active = true`
+
+	_, err := sm.db.ExecContext(ctx, query, time.Now(), userID, keepSessionID)
+	if err != nil {
+		return fmt.Errorf("failed to end other user sessions: %w", err)
+	}
+
+	return nil
+}
+
 // GetUserSessions returns all active sessions for a user
 func (sm *SessionManager) GetUserSessions(ctx context.Context, userID string) ([]*Session, error) {
 	query := `
@@ -0,0 +1,47 @@
+//services/common/actor/actor.go
+package actor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the gRPC metadata header carrying the ID of the user behind a request
+const MetadataKey = "x-actor-id"
+
+// System is recorded as the actor for changes that no user initiated,
+// such as scheduled jobs and reconciliation
+const System = "system"
+
+// NewOutgoingContext attaches the actor ID to the metadata of outgoing gRPC calls
+func NewOutgoingContext(ctx context.Context, actorID string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, actorID)
+}
+
+// FromIncomingContext returns the actor ID sent by the caller, falling back to
+// System when the request did not come from an authenticated user
+func FromIncomingContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return System
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return System
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// UnaryClientInterceptor forwards the actor found by lookup on every unary call.
+// Calls made without an actor in the context are sent unchanged.
+func UnaryClientInterceptor(lookup func(ctx context.Context) (string, bool)) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if actorID, ok := lookup(ctx); ok && actorID != "" {
+			ctx = NewOutgoingContext(ctx, actorID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
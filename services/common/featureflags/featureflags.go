@@ -0,0 +1,77 @@
+//services/common/featureflags/featureflags.go
+package featureflags
+
+import (
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Flag names shared between the gateway and the services that back them
+const (
+	VehicleCSVImport    = "vehicle_csv_import"
+	DispatchCandidates  = "dispatch_candidates"
+	AssignmentCSVImport = "assignment_csv_import"
+)
+
+// defaults lists every known flag and whether it is on when the environment says nothing.
+// New endpoints should be added here as false so they ship dark.
+var defaults = map[string]bool{
+	VehicleCSVImport:    true,
+	DispatchCandidates:  true,
+	AssignmentCSVImport: false,
+}
+
+// Flags is a read-only snapshot of which features are enabled
+type Flags struct {
+	enabled map[string]bool
+}
+
+// FromEnv builds the flag set from FEATURE_FLAGS, a comma separated list of flag names.
+// A bare name switches a flag on and a name prefixed with "-" switches it off,
+// e.g. FEATURE_FLAGS="dispatch_candidates,-vehicle_csv_import".
+func FromEnv() *Flags {
+	return Parse(os.Getenv("FEATURE_FLAGS"))
+}
+
+// Parse builds the flag set from a FEATURE_FLAGS style value on top of the defaults
+func Parse(value string) *Flags {
+	enabled := make(map[string]bool, len(defaults))
+	for name, on := range defaults {
+		enabled[name] = on
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name := strings.TrimSpace(entry)
+		if name == "" {
+			continue
+		}
+		on := true
+		if strings.HasPrefix(name, "-") {
+			on = false
+			name = strings.TrimPrefix(name, "-")
+		}
+		enabled[strings.ToLower(name)] = on
+	}
+
+	return &Flags{enabled: enabled}
+}
+
+// Enabled reports whether the named feature is on. Unknown flags are off.
+func (f *Flags) Enabled(name string) bool {
+	if f == nil {
+		return defaults[name]
+	}
+	return f.enabled[name]
+}
+
+// Require returns a codes.Unimplemented status when the named feature is off,
+// so RPC handlers can bail out with a single check
+func (f *Flags) Require(name string) error {
+	if !f.Enabled(name) {
+		return status.Errorf(codes.Unimplemented, "%s is not enabled", name)
+	}
+	return nil
+}
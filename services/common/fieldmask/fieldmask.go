@@ -0,0 +1,88 @@
+// services/common/fieldmask/fieldmask.go
+package fieldmask
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Parse builds a field mask for msg from a comma separated ?fields= value.
+// Names may use either the proto (license_plate) or JSON (licensePlate) spelling
+// and always refer to top-level fields of msg. An empty value yields a nil mask,
+// meaning "all fields"; unknown names are rejected with codes.InvalidArgument.
+func Parse(raw string, msg proto.Message) (*fieldmaskpb.FieldMask, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	paths := []string{}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		fd := fields.ByName(protoreflect.Name(name))
+		if fd == nil {
+			fd = fields.ByJSONName(name)
+		}
+		if fd == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown field %q in fields", name)
+		}
+
+		path := string(fd.Name())
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "fields must name at least one field")
+	}
+
+	mask, err := fieldmaskpb.New(msg, paths...)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid fields: %v", err)
+	}
+	return mask, nil
+}
+
+// Apply clears every top-level field of msg that is not in mask. A nil mask leaves msg untouched.
+func Apply(mask *fieldmaskpb.FieldMask, msg proto.Message) {
+	if mask == nil || msg == nil {
+		return
+	}
+
+	keep := make(map[protoreflect.Name]bool, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		keep[protoreflect.Name(path)] = true
+	}
+
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); !keep[fd.Name()] {
+			m.Clear(fd)
+		}
+	}
+}
+
+// JSONNames returns the JSON spelling of each field in mask, for filtering marshalled
+// responses of the message type described by desc
+func JSONNames(mask *fieldmaskpb.FieldMask, desc protoreflect.MessageDescriptor) []string {
+	fields := desc.Fields()
+	names := make([]string, 0, len(mask.GetPaths()))
+	for _, path := range mask.GetPaths() {
+		if fd := fields.ByName(protoreflect.Name(path)); fd != nil {
+			names = append(names, fd.JSONName())
+		}
+	}
+	return names
+}
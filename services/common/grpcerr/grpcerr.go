@@ -0,0 +1,78 @@
+// services/common/grpcerr/grpcerr.go
+package grpcerr
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FieldError is implemented by validation errors that concern a single request field
+type FieldError interface {
+	error
+	FieldViolation() (field, description string)
+}
+
+// InvalidArgument returns a codes.InvalidArgument status for err, with the message
+// "prefix: err". Every FieldError in err's chain, including errors joined with
+// errors.Join, is attached as a google.rpc.BadRequest field violation so clients
+// can tell which fields were rejected.
+func InvalidArgument(prefix string, err error) error {
+	msg := err.Error()
+	if prefix != "" {
+		msg = prefix + ": " + msg
+	}
+	st := status.New(codes.InvalidArgument, msg)
+
+	violations := fieldViolations(err)
+	if len(violations) == 0 {
+		return st.Err()
+	}
+
+	detailed, derr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if derr != nil {
+		// The plain message still tells the client what went wrong
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+		var fe FieldError
+		if errors.As(err, &fe) {
+			field, description := fe.FieldViolation()
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       field,
+				Description: description,
+			})
+		}
+	}
+	walk(err)
+	return violations
+}
+
+// Internal returns a codes.Internal status for err, with the message "prefix: err".
+// Errors caused by a context deadline, such as a store query that ran past its timeout,
+// get codes.DeadlineExceeded instead, so callers can tell a slow database from a failing
+// one and retry.
+func Internal(prefix string, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Errorf(codes.DeadlineExceeded, "%s: %v", prefix, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", prefix, err)
+}
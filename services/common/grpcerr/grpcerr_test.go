@@ -0,0 +1,42 @@
+// services/common/grpcerr/grpcerr_test.go
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInternal(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "plain error", err: errors.New("boom"), wantCode: codes.Internal},
+		{name: "deadline", err: context.DeadlineExceeded, wantCode: codes.DeadlineExceeded},
+		{
+			name:     "wrapped deadline",
+			err:      fmt.Errorf("failed to list vehicles: %w", context.DeadlineExceeded),
+			wantCode: codes.DeadlineExceeded,
+		},
+		{name: "canceled", err: context.Canceled, wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Internal("failed to list vehicles", tt.err)
+			st, _ := status.FromError(err)
+			if st.Code() != tt.wantCode {
+				t.Errorf("code = %s, want %s", st.Code(), tt.wantCode)
+			}
+			if want := "failed to list vehicles: " + tt.err.Error(); st.Message() != want {
+				t.Errorf("message = %q, want %q", st.Message(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// services/common/grpcserver/grpcserver.go
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/reflection"
+)
+
+// ShutdownTimeout is how long in-flight calls get to finish once a shutdown signal arrives
+const ShutdownTimeout = 15 * time.Second
+
+// Serve serves grpcServer on lis until SIGINT or SIGTERM. On shutdown healthServer
+// reports NOT_SERVING, then in-flight calls get ShutdownTimeout to finish before the
+// remaining connections are closed. name labels the server in the logs.
+//
+// Reflection lets grpcurl list and call the service's methods. It's off unless
+// GRPC_REFLECTION is set, so production doesn't advertise its API.
+func Serve(lis net.Listener, grpcServer *grpc.Server, healthServer *health.Server, name string) error {
+	if enabled, _ := strconv.ParseBool(os.Getenv("GRPC_REFLECTION")); enabled {
+		reflection.Register(grpcServer)
+		log.Println("gRPC reflection enabled")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Starting %s gRPC server on %s", name, lis.Addr())
+	return serve(ctx, lis, grpcServer, healthServer, ShutdownTimeout)
+}
+
+// serve runs grpcServer until ctx is done, then drains it within timeout
+func serve(ctx context.Context, lis net.Listener, grpcServer *grpc.Server, healthServer *health.Server, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- grpcServer.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("gRPC server failed: %w", err)
+	case <-ctx.Done():
+	}
+	log.Println("Server shutting down...")
+
+	// Fail health checks first so clients stop sending new calls here
+	healthServer.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Println("Graceful stop timed out, closing remaining connections")
+		grpcServer.Stop()
+	}
+	log.Println("Server stopped")
+	return nil
+}
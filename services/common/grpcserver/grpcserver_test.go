@@ -0,0 +1,119 @@
+// services/common/grpcserver/grpcserver_test.go
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// startServer serves a health service on a local port until the returned cancel is called.
+// serve's result arrives on the returned channel.
+func startServer(t *testing.T, timeout time.Duration) (healthpb.HealthClient, context.CancelFunc, <-chan error) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, lis, grpcServer, healthServer, timeout)
+	}()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn), cancel, done
+}
+
+// waitServe waits for serve to return, failing the test if it takes longer than limit
+func waitServe(t *testing.T, done <-chan error, limit time.Duration) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(limit):
+		t.Fatalf("serve didn't return within %s", limit)
+		return nil
+	}
+}
+
+func TestServeStopsOnShutdown(t *testing.T) {
+	client, cancel, done := startServer(t, time.Minute)
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status = %s, want SERVING", resp.Status)
+	}
+
+	cancel()
+	// Nothing is in flight, so the drain finishes long before the timeout
+	if err := waitServe(t, done, 5*time.Second); err != nil {
+		t.Errorf("serve = %v, want nil", err)
+	}
+}
+
+func TestServeReportsNotServingAndTimesOut(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+	client, cancel, done := startServer(t, timeout)
+
+	// A watch stream stays open until the server closes it, so it holds up GracefulStop
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil || first.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("first watch update = %v, %v, want SERVING", first, err)
+	}
+
+	start := time.Now()
+	cancel()
+
+	// Health checks fail before the drain starts
+	update, err := stream.Recv()
+	if err != nil || update.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("watch update after shutdown = %v, %v, want NOT_SERVING", update, err)
+	}
+
+	if err := waitServe(t, done, 5*time.Second); err != nil {
+		t.Errorf("serve = %v, want nil", err)
+	}
+	if elapsed := time.Since(start); elapsed < timeout {
+		t.Errorf("serve returned after %s, before the %s drain timeout", elapsed, timeout)
+	}
+	// The stream was cut off by the forced stop
+	if _, err := stream.Recv(); err == nil {
+		t.Error("watch stream is still open after the server stopped")
+	}
+}
+
+func TestServeFailure(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	lis.Close()
+
+	err = serve(context.Background(), lis, grpc.NewServer(), health.NewServer(), time.Minute)
+	if err == nil {
+		t.Error("serve on a closed listener = nil, want an error")
+	}
+}
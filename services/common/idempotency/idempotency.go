@@ -0,0 +1,69 @@
+// services/common/idempotency/idempotency.go
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderName is the HTTP header clients send an idempotency key in
+const HeaderName = "Idempotency-Key"
+
+// MetadataKey is the gRPC metadata header carrying the idempotency key of a request
+const MetadataKey = "x-idempotency-key"
+
+// MaxKeyLength is the longest key accepted, matching the idempotency_keys column
+const MaxKeyLength = 255
+
+// TTL is how long a key keeps returning the resource it first created. It only needs
+// to outlast a client's retries, after which the key can be used again.
+const TTL = 24 * time.Hour
+
+// Key is a client's idempotency key as stored. Keys are scoped to one endpoint and one
+// user, so the same key sent to different endpoints or by different users doesn't collide.
+type Key struct {
+	Scope   string // the endpoint, e.g. "CreateVehicle"
+	ActorID string
+	Value   string
+}
+
+// Validate checks that a key is short enough to store and made of printable ASCII
+func Validate(key string) error {
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("idempotency key must be at most %d characters", MaxKeyLength)
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return errors.New("idempotency key must be printable ASCII without spaces")
+		}
+	}
+	return nil
+}
+
+// NewOutgoingContext attaches the idempotency key to the metadata of outgoing gRPC calls.
+// An empty key leaves the context unchanged.
+func NewOutgoingContext(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, key)
+}
+
+// FromIncomingContext returns the idempotency key sent by the caller, or an empty
+// string when the request carries none
+func FromIncomingContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(MetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
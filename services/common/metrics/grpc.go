@@ -0,0 +1,36 @@
+// services/common/metrics/grpc.go
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcRequests = Default.NewCounter("grpc_server_requests_total",
+		"Unary gRPC requests handled, by method and status code.", "method", "code")
+	grpcErrors = Default.NewCounter("grpc_server_errors_total",
+		"Unary gRPC requests that returned an error, by method and status code.", "method", "code")
+	grpcDuration = Default.NewHistogram("grpc_server_request_duration_seconds",
+		"Time taken to handle unary gRPC requests, by method.", DefaultBuckets, "method")
+)
+
+// UnaryServerInterceptor records the count, status code and latency of every unary call.
+// Register it first in the chain so the time spent in later interceptors is included.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err).String()
+		grpcRequests.Inc(info.FullMethod, code)
+		if err != nil {
+			grpcErrors.Inc(info.FullMethod, code)
+		}
+		grpcDuration.Observe(time.Since(start).Seconds(), info.FullMethod)
+		return resp, err
+	}
+}
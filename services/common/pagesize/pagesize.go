@@ -0,0 +1,137 @@
+// services/common/pagesize/pagesize.go
+package pagesize
+
+import (
+	"context"
+	"crypto/subtle"
+	"os"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Limits are the page sizes listings serve. There are two tiers of caller. Public
+// callers, which includes everything that comes through the gateway, may ask for at most
+// PublicMax rows a page. Internal callers, such as batch jobs calling a service directly,
+// may ask for up to InternalMax by sending the shared internal token. Unset page sizes
+// get Default in both tiers.
+type Limits struct {
+	Default     int32
+	PublicMax   int32
+	InternalMax int32
+}
+
+// DefaultLimits is used for any limit not configured in the environment
+var DefaultLimits = Limits{
+	Default:     50,
+	PublicMax:   100,
+	InternalMax: 1000,
+}
+
+// limits is set once at startup, before the process serves requests
+var limits = DefaultLimits
+
+// LimitsFromEnv reads the limits from PAGE_SIZE_DEFAULT, PAGE_SIZE_MAX and
+// PAGE_SIZE_INTERNAL_MAX. Unset or invalid values fall back to the defaults, adjusted so
+// that Default <= PublicMax <= InternalMax still holds.
+func LimitsFromEnv() Limits {
+	l := DefaultLimits
+	if n, err := strconv.ParseInt(os.Getenv("PAGE_SIZE_MAX"), 10, 32); err == nil && n >= 1 {
+		l.PublicMax = int32(n)
+		l.Default = min(l.Default, l.PublicMax)
+		l.InternalMax = max(l.InternalMax, l.PublicMax)
+	}
+	if n, err := strconv.ParseInt(os.Getenv("PAGE_SIZE_INTERNAL_MAX"), 10, 32); err == nil && int32(n) >= l.PublicMax {
+		l.InternalMax = int32(n)
+	}
+	if n, err := strconv.ParseInt(os.Getenv("PAGE_SIZE_DEFAULT"), 10, 32); err == nil && n >= 1 && int32(n) <= l.PublicMax {
+		l.Default = int32(n)
+	}
+	return l
+}
+
+// SetLimits replaces the page size limits. It's meant to be called from main before the
+// server starts, and isn't safe to call while requests are being served.
+func SetLimits(l Limits) {
+	limits = l
+}
+
+// Default returns the page size served when a request leaves it unset
+func Default() int32 {
+	return limits.Default
+}
+
+// PublicMax returns the largest page a public caller may ask for
+func PublicMax() int32 {
+	return limits.PublicMax
+}
+
+// InternalMax returns the largest page an internal caller may ask for
+func InternalMax() int32 {
+	return limits.InternalMax
+}
+
+// MetadataKey is the gRPC metadata header internal callers send the shared token in.
+// The gateway builds its outgoing metadata itself, so public requests can't set it.
+const MetadataKey = "x-internal-token"
+
+type internalKey struct{}
+
+// UnaryServerInterceptor marks requests that carry token as internal. With an empty
+// token the internal tier is off and every caller is treated as public.
+func UnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if token != "" && presented(ctx, token) {
+			ctx = context.WithValue(ctx, internalKey{}, true)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func presented(ctx context.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get(MetadataKey) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// NewOutgoingContext attaches the internal token to the metadata of outgoing gRPC calls
+func NewOutgoingContext(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, token)
+}
+
+// IsInternal reports whether the request presented the internal token
+func IsInternal(ctx context.Context) bool {
+	internal, _ := ctx.Value(internalKey{}).(bool)
+	return internal
+}
+
+// Max returns the largest page the caller may ask for
+func Max(ctx context.Context) int32 {
+	if IsInternal(ctx) {
+		return limits.InternalMax
+	}
+	return limits.PublicMax
+}
+
+// Clamp returns the page size to serve for a requested one: Default when unset,
+// and never more than the caller's tier allows
+func Clamp(ctx context.Context, requested int32) int32 {
+	return ClampTo(requested, limits.Default, Max(ctx))
+}
+
+// ClampTo returns defaultSize when requested is unset, and otherwise requested capped
+// at maxSize
+func ClampTo(requested, defaultSize, maxSize int32) int32 {
+	if requested <= 0 {
+		return defaultSize
+	}
+	return min(requested, maxSize)
+}
@@ -0,0 +1,146 @@
+// services/common/pagesize/pagesize_test.go
+package pagesize
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const testToken = "internal-secret"
+
+// callerContext runs ctx through the interceptor, configured with testToken, and
+// returns the context the handler would see
+func callerContext(t *testing.T, ctx context.Context) context.Context {
+	t.Helper()
+	var seen context.Context
+	interceptor := UnaryServerInterceptor(testToken)
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		seen = ctx
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	return seen
+}
+
+func incoming(tokens ...string) context.Context {
+	md := metadata.MD{}
+	for _, token := range tokens {
+		md.Append(MetadataKey, token)
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestClamp(t *testing.T) {
+	SetLimits(DefaultLimits)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		requested int32
+		want      int32
+	}{
+		{name: "public unset", ctx: incoming(), requested: 0, want: 50},
+		{name: "public negative", ctx: incoming(), requested: -5, want: 50},
+		{name: "public within limit", ctx: incoming(), requested: 20, want: 20},
+		{name: "public at limit", ctx: incoming(), requested: 100, want: 100},
+		{name: "public over limit", ctx: incoming(), requested: 500, want: 100},
+		{name: "no metadata", ctx: context.Background(), requested: 500, want: 100},
+		{name: "wrong token", ctx: incoming("guess"), requested: 500, want: 100},
+		{name: "empty token", ctx: incoming(""), requested: 500, want: 100},
+		{name: "internal unset", ctx: incoming(testToken), requested: 0, want: 50},
+		{name: "internal over public limit", ctx: incoming(testToken), requested: 500, want: 500},
+		{name: "internal over internal limit", ctx: incoming(testToken), requested: 5000, want: 1000},
+		{name: "internal token among others", ctx: incoming("guess", testToken), requested: 500, want: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Clamp(callerContext(t, tt.ctx), tt.requested); got != tt.want {
+				t.Errorf("Clamp(%d) = %d, want %d", tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampPublicNeverExceedsPublicMax(t *testing.T) {
+	SetLimits(DefaultLimits)
+
+	for _, ctx := range []context.Context{context.Background(), incoming(), incoming("guess")} {
+		ctx = callerContext(t, ctx)
+		for _, requested := range []int32{101, 1000, 1001, math.MaxInt32} {
+			if got := Clamp(ctx, requested); got > 100 {
+				t.Errorf("public Clamp(%d) = %d, want at most 100", requested, got)
+			}
+		}
+	}
+}
+
+func TestInterceptorWithoutToken(t *testing.T) {
+	// An empty configured token turns the internal tier off, even for a caller that
+	// sends an empty token of its own
+	var internal bool
+	interceptor := UnaryServerInterceptor("")
+	interceptor(incoming(""), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		internal = IsInternal(ctx)
+		return nil, nil
+	})
+	if internal {
+		t.Error("caller treated as internal with no token configured")
+	}
+}
+
+func TestClampTo(t *testing.T) {
+	// The stores' backstop: whatever gets past the service, nothing over InternalMax is read
+	tests := []struct {
+		requested int32
+		want      int32
+	}{
+		{requested: 0, want: 50},
+		{requested: -1, want: 50},
+		{requested: 1, want: 1},
+		{requested: 1000, want: 1000},
+		{requested: 1001, want: 1000},
+		{requested: math.MaxInt32, want: 1000},
+	}
+
+	for _, tt := range tests {
+		if got := ClampTo(tt.requested, 50, 1000); got != tt.want {
+			t.Errorf("ClampTo(%d, 50, 1000) = %d, want %d", tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestLimitsFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		max         string
+		internalMax string
+		def         string
+		want        Limits
+	}{
+		{name: "unset", want: DefaultLimits},
+		{name: "all set", max: "200", internalMax: "2000", def: "25", want: Limits{Default: 25, PublicMax: 200, InternalMax: 2000}},
+		{name: "max below default lowers default", max: "20", want: Limits{Default: 20, PublicMax: 20, InternalMax: 1000}},
+		{name: "max above internal max raises it", max: "5000", want: Limits{Default: 50, PublicMax: 5000, InternalMax: 5000}},
+		{name: "internal max below max ignored", internalMax: "10", want: DefaultLimits},
+		{name: "default above max ignored", def: "500", want: DefaultLimits},
+		{name: "invalid values ignored", max: "lots", internalMax: "-1", def: "0", want: DefaultLimits},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAGE_SIZE_MAX", tt.max)
+			t.Setenv("PAGE_SIZE_INTERNAL_MAX", tt.internalMax)
+			t.Setenv("PAGE_SIZE_DEFAULT", tt.def)
+			if got := LimitsFromEnv(); got != tt.want {
+				t.Errorf("LimitsFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
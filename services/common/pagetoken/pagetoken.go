@@ -0,0 +1,327 @@
+// services/common/pagetoken/pagetoken.go
+package pagetoken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+)
+
+// ErrInvalidToken is returned for tokens that can't be decoded or were issued
+// for a different sort than the query they are being used with
+var ErrInvalidToken = errors.New("invalid page token")
+
+// Sort identifies the column a cursor is keyed on and the direction of the listing
+type Sort struct {
+	Column     string
+	Descending bool
+}
+
+// Sorts used by the list queries
+var (
+	CreatedAtDesc = Sort{Column: "created_at", Descending: true}
+	UpdatedAtDesc = Sort{Column: "updated_at", Descending: true}
+	ChangedAtDesc = Sort{Column: "changed_at", Descending: true}
+
+	// LicenseExpiryAsc pages soonest expiry first. Its cursor holds the expiry date;
+	// the keyset comparison flips to license_expiry >= ? AND (license_expiry > ? OR id > ?).
+	LicenseExpiryAsc = Sort{Column: "license_expiry", Descending: false}
+
+	// LicenseExpiryDesc pages most recently expired first, with the usual descending
+	// comparison on the expiry date
+	LicenseExpiryDesc = Sort{Column: "license_expiry", Descending: true}
+)
+
+// ErrUnknownSort is returned for an order_by value a listing doesn't offer
+var ErrUnknownSort = errors.New("unknown sort order")
+
+// Whitelist maps the order_by values a listing accepts to the sort behind each one.
+// Only whitelisted sorts ever reach a query, so column names never come from user input.
+// The empty name is the listing's default order.
+type Whitelist map[string]Sort
+
+// Lookup returns the sort for an order_by value
+func (w Whitelist) Lookup(name string) (Sort, error) {
+	sort, ok := w[name]
+	if !ok {
+		return Sort{}, fmt.Errorf("%w %q", ErrUnknownSort, name)
+	}
+	return sort, nil
+}
+
+func (s Sort) direction() string {
+	if s.Descending {
+		return "desc"
+	}
+	return "asc"
+}
+
+func (s Sort) String() string {
+	return s.Column + " " + s.direction()
+}
+
+// stamped reports whether the sort column is a write time taken from a node's clock,
+// rather than a date that can legitimately lie in the future like license_expiry
+func (s Sort) stamped() bool {
+	switch s.Column {
+	case "created_at", "updated_at", "changed_at":
+		return true
+	}
+	return false
+}
+
+// Cursor is the last row of a page: its value in the sort column plus its ID.
+//
+// Timestamps alone are not a safe cursor. Rows are stamped with the clock of whichever
+// node created them, so with a little skew two rows can share a timestamp, or a row can
+// land exactly on the boundary of a page that was already served. An exclusive
+// "created_at < cursor" comparison skips those rows. Listings therefore compare
+// inclusively on the timestamp and use the ID to break ties, i.e.
+//
+//	created_at <= cursor.At AND (created_at < cursor.At OR id < cursor.ID)
+//
+// ordered by (created_at, id), so every row is returned exactly once.
+//
+// A Backward cursor is the first row of a page and asks for the page before it. The
+// comparison flips (created_at >= cursor.At AND (created_at > cursor.At OR id > cursor.ID))
+// and the rows are fetched in the opposite order, then put back in listing order by Paginate.
+//
+// Listings sorted on some other column, e.g. vehicles by year, put the row's value in
+// that column in Key and keep created_at and the ID as tiebreakers after it.
+type Cursor struct {
+	At       time.Time
+	ID       string
+	Key      string
+	Backward bool
+}
+
+// IsZero reports whether the cursor is empty, meaning "start from the first page"
+func (c Cursor) IsZero() bool {
+	return c.At.IsZero() && c.ID == "" && c.Key == ""
+}
+
+// currentVersion is bumped whenever the payload layout changes
+const currentVersion = 1
+
+// signedPrefix starts a signed token, ahead of the HMAC-SHA256 of the JSON payload and
+// the payload itself. Unsigned tokens start with the payload's '{', or with a digit for
+// the bare timestamps issued before versioning, so the three can't be confused.
+const signedPrefix = 0x02
+
+// Signing configures the HMAC that stops clients forging cursors
+type Signing struct {
+	// Key signs issued tokens and verifies presented ones. Without a key tokens are
+	// issued unsigned, and signed ones can't be verified so they're rejected.
+	Key []byte
+	// AcceptUnsigned lets Decode take unsigned tokens while a key is set. Turn it on for
+	// the deploy that introduces the key, so clients mid-pagination aren't cut off, and
+	// off again once their tokens have aged out.
+	AcceptUnsigned bool
+}
+
+// signing is set once at startup, before the process serves requests
+var signing Signing
+
+// SigningFromEnv reads the key from PAGE_TOKEN_SECRET and the rollout flag from
+// PAGE_TOKEN_ACCEPT_UNSIGNED. Every replica of a service needs the same secret.
+func SigningFromEnv() Signing {
+	config := Signing{}
+	if secret := os.Getenv("PAGE_TOKEN_SECRET"); secret != "" {
+		config.Key = []byte(secret)
+	}
+	config.AcceptUnsigned, _ = strconv.ParseBool(os.Getenv("PAGE_TOKEN_ACCEPT_UNSIGNED"))
+	return config
+}
+
+// SetSigning replaces the signing config. It's meant to be called from main before the
+// server starts, and isn't safe to call while requests are being served.
+func SetSigning(config Signing) {
+	signing = config
+}
+
+func sign(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// DefaultClockLeeway is how far ahead of this node's clock a row's timestamp can be when
+// it was stamped by another node, for replicas kept in sync by NTP
+const DefaultClockLeeway = 5 * time.Second
+
+// clockLeeway is set once at startup, like signing. now is only replaced by tests.
+var (
+	clockLeeway             = DefaultClockLeeway
+	now         clock.Clock = clock.System
+)
+
+// ClockLeewayFromEnv reads PAGE_TOKEN_CLOCK_LEEWAY, a duration such as "5s". A missing
+// or invalid value gives DefaultClockLeeway.
+func ClockLeewayFromEnv() time.Duration {
+	leeway, err := time.ParseDuration(os.Getenv("PAGE_TOKEN_CLOCK_LEEWAY"))
+	if err != nil || leeway < 0 {
+		return DefaultClockLeeway
+	}
+	return leeway
+}
+
+// SetClockLeeway replaces the leeway Decode allows for cursors stamped by a clock running
+// ahead of this one. Like SetSigning it's meant to be called from main before serving.
+func SetClockLeeway(leeway time.Duration) {
+	clockLeeway = leeway
+}
+
+// checkSkew rejects a cursor on a write time that lies further in the future than clock
+// skew between nodes explains. No row carries such a timestamp, and a descending listing
+// would answer the token with its first page again.
+func checkSkew(sort Sort, cursor Cursor) (Cursor, error) {
+	if sort.stamped() && cursor.At.After(now.Now().Add(clockLeeway)) {
+		return Cursor{}, fmt.Errorf("%w: cursor is %s ahead of the server clock",
+			ErrInvalidToken, cursor.At.Sub(now.Now()).Round(time.Millisecond))
+	}
+	return cursor, nil
+}
+
+type payload struct {
+	Version   int       `json:"v"`
+	Column    string    `json:"col"`
+	Direction string    `json:"dir"`
+	Cursor    time.Time `json:"at"`
+	ID        string    `json:"id,omitempty"`   // absent in tokens issued before the ID tiebreaker
+	Backward  bool      `json:"back,omitempty"` // absent in forward tokens, including those issued before prev tokens
+	Key       string    `json:"key,omitempty"`  // only set by listings sorted on a column other than the timestamp
+}
+
+// Encode builds the page token for a cursor in the given sort, signed when a key is set
+func Encode(sort Sort, cursor Cursor) string {
+	data, _ := json.Marshal(payload{
+		Version:   currentVersion,
+		Column:    sort.Column,
+		Direction: sort.direction(),
+		Cursor:    cursor.At,
+		ID:        cursor.ID,
+		Backward:  cursor.Backward,
+		Key:       cursor.Key,
+	})
+	if signing.Key != nil {
+		signed := append([]byte{signedPrefix}, sign(signing.Key, data)...)
+		data = append(signed, data...)
+	}
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// Decode returns the cursor held in token, checking it was issued for sort.
+// An empty token yields the zero cursor, meaning "start from the first page".
+//
+// With a signing key set, a token must carry a valid signature unless AcceptUnsigned is
+// on; a tampered or unsigned token is rejected with ErrInvalidToken.
+//
+// Tokens from before versioning hold a bare created_at timestamp; they are still
+// accepted for created_at descending listings so clients mid-pagination survive a deploy.
+// Those, and tokens issued before IDs were added, decode with an empty ID.
+//
+// A cursor on a write time may be ahead of this node's clock by up to the clock leeway,
+// since the row may have been stamped by a node whose clock runs fast. Anything further
+// ahead is rejected with ErrInvalidToken.
+func Decode(token string, sort Sort) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+
+	data, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if len(data) > 0 && data[0] == signedPrefix {
+		data, err = verify(data[1:])
+		if err != nil {
+			return Cursor{}, err
+		}
+	} else if signing.Key != nil && !signing.AcceptUnsigned {
+		return Cursor{}, fmt.Errorf("%w: token is not signed", ErrInvalidToken)
+	} else if len(data) == 0 || data[0] != '{' {
+		return decodeLegacy(data, sort)
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if p.Version != currentVersion {
+		return Cursor{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidToken, p.Version)
+	}
+	if p.Column != sort.Column || p.Direction != sort.direction() {
+		return Cursor{}, fmt.Errorf("%w: token was issued for %s %s and cannot be used to page by %s",
+			ErrInvalidToken, p.Column, p.Direction, sort)
+	}
+
+	return checkSkew(sort, Cursor{At: p.Cursor, ID: p.ID, Key: p.Key, Backward: p.Backward})
+}
+
+// verify checks the signature at the start of data and returns the payload after it
+func verify(data []byte) ([]byte, error) {
+	if signing.Key == nil {
+		return nil, fmt.Errorf("%w: signed tokens are not accepted", ErrInvalidToken)
+	}
+	if len(data) < sha256.Size {
+		return nil, fmt.Errorf("%w: truncated signature", ErrInvalidToken)
+	}
+	mac, body := data[:sha256.Size], data[sha256.Size:]
+	if !hmac.Equal(mac, sign(signing.Key, body)) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+	return body, nil
+}
+
+func decodeLegacy(data []byte, sort Sort) (Cursor, error) {
+	if sort != CreatedAtDesc {
+		return Cursor{}, fmt.Errorf("%w: unversioned token cannot be used to page by %s", ErrInvalidToken, sort)
+	}
+
+	var at time.Time
+	if err := at.UnmarshalText(data); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return checkSkew(sort, Cursor{At: at})
+}
+
+// Paginate finishes a page that was fetched with LIMIT pageSize+1 in the direction of
+// cursor, so rows holds one row more than the page when there is more to read that way.
+// Backward pages are reversed into listing order. It returns the page with the tokens
+// for the pages after and before it; key gives a row's cursor in sort.
+//
+// A forward page has a previous page whenever it was reached with a cursor, and a
+// backward page always has the page it was reached from after it.
+func Paginate[T any](rows []T, pageSize int32, sort Sort, cursor Cursor, key func(T) Cursor) ([]T, string, string) {
+	more := int32(len(rows)) > pageSize
+	if more {
+		rows = rows[:pageSize]
+	}
+	if cursor.Backward {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, "", ""
+	}
+
+	var nextPageToken, prevPageToken string
+	if more || cursor.Backward {
+		nextPageToken = Encode(sort, key(rows[len(rows)-1]))
+	}
+	if (more && cursor.Backward) || (!cursor.Backward && !cursor.IsZero()) {
+		first := key(rows[0])
+		first.Backward = true
+		prevPageToken = Encode(sort, first)
+	}
+	return rows, nextPageToken, prevPageToken
+}
@@ -0,0 +1,404 @@
+// services/common/pagetoken/pagetoken_test.go
+package pagetoken
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+)
+
+type testRow struct {
+	id string
+	at time.Time
+}
+
+func (r testRow) cursor() Cursor {
+	return Cursor{At: r.at, ID: r.id}
+}
+
+var base = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// testRows is a created_at descending listing. d and c share a timestamp, so the ID
+// tiebreaker decides their order.
+var testRows = []testRow{
+	{id: "e", at: base.Add(4 * time.Minute)},
+	{id: "d", at: base.Add(2 * time.Minute)},
+	{id: "c", at: base.Add(2 * time.Minute)},
+	{id: "b", at: base.Add(time.Minute)},
+	{id: "a", at: base},
+}
+
+// fetch does what a list query does with the keyset comparison from the Cursor docs:
+// the rows past cursor in its direction, in fetch order, up to limit
+func fetch(cursor Cursor, limit int32) []testRow {
+	var rows []testRow
+	for _, r := range testRows {
+		switch {
+		case cursor.IsZero():
+		case cursor.Backward:
+			if !(r.at.After(cursor.At) || (r.at.Equal(cursor.At) && r.id > cursor.ID)) {
+				continue
+			}
+		default:
+			if !(r.at.Before(cursor.At) || (r.at.Equal(cursor.At) && r.id < cursor.ID)) {
+				continue
+			}
+		}
+		rows = append(rows, r)
+	}
+	if cursor.Backward {
+		// Backward pages are fetched in the opposite order
+		slices.Reverse(rows)
+	}
+	if int32(len(rows)) > limit {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func ids(rows []testRow) []string {
+	out := make([]string, len(rows))
+	for i, r := range rows {
+		out[i] = r.id
+	}
+	return out
+}
+
+func TestPaginate(t *testing.T) {
+	const pageSize = 2
+
+	// Each step follows the next or prev token of the step before it
+	tests := []struct {
+		name     string
+		follow   string
+		wantIDs  []string
+		wantNext bool
+		wantPrev bool
+	}{
+		{name: "first page", wantIDs: []string{"e", "d"}, wantNext: true},
+		{name: "middle page forward", follow: "next", wantIDs: []string{"c", "b"}, wantNext: true, wantPrev: true},
+		{name: "last page forward", follow: "next", wantIDs: []string{"a"}, wantPrev: true},
+		{name: "middle page backward", follow: "prev", wantIDs: []string{"c", "b"}, wantNext: true, wantPrev: true},
+		{name: "first page backward", follow: "prev", wantIDs: []string{"e", "d"}, wantNext: true},
+		{name: "forward again from a backward page", follow: "next", wantIDs: []string{"c", "b"}, wantNext: true, wantPrev: true},
+	}
+
+	var next, prev string
+	for _, tt := range tests {
+		token := map[string]string{"": "", "next": next, "prev": prev}[tt.follow]
+		if tt.follow != "" && token == "" {
+			t.Fatalf("%s: no %s token to follow", tt.name, tt.follow)
+		}
+
+		cursor, err := Decode(token, CreatedAtDesc)
+		if err != nil {
+			t.Fatalf("%s: failed to decode %s token: %v", tt.name, tt.follow, err)
+		}
+		rows, gotNext, gotPrev := Paginate(fetch(cursor, pageSize+1), pageSize, CreatedAtDesc, cursor, testRow.cursor)
+
+		if got := ids(rows); !slices.Equal(got, tt.wantIDs) {
+			t.Errorf("%s: rows = %v, want %v", tt.name, got, tt.wantIDs)
+		}
+		if (gotNext != "") != tt.wantNext {
+			t.Errorf("%s: next token = %q, want one: %t", tt.name, gotNext, tt.wantNext)
+		}
+		if (gotPrev != "") != tt.wantPrev {
+			t.Errorf("%s: prev token = %q, want one: %t", tt.name, gotPrev, tt.wantPrev)
+		}
+		next, prev = gotNext, gotPrev
+	}
+}
+
+func TestPaginateEmpty(t *testing.T) {
+	for _, cursor := range []Cursor{{}, {At: base, ID: "a"}, {At: base, ID: "a", Backward: true}} {
+		rows, next, prev := Paginate([]testRow{}, 2, CreatedAtDesc, cursor, testRow.cursor)
+		if len(rows) != 0 || next != "" || prev != "" {
+			t.Errorf("cursor %+v: got %v, %q, %q, want an empty page without tokens", cursor, rows, next, prev)
+		}
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 15, 123456789, time.UTC)
+
+	tests := []struct {
+		name   string
+		sort   Sort
+		cursor Cursor
+	}{
+		{name: "forward", sort: CreatedAtDesc, cursor: Cursor{At: at, ID: "8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90"}},
+		{name: "backward", sort: CreatedAtDesc, cursor: Cursor{At: at, ID: "8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90", Backward: true}},
+		{name: "keyed", sort: Sort{Column: "year", Descending: true}, cursor: Cursor{At: at, ID: "42", Key: "2019"}},
+		{name: "ascending", sort: LicenseExpiryAsc, cursor: Cursor{At: at, ID: "7", Backward: true}},
+	}
+
+	for _, signed := range []bool{false, true} {
+		for _, tt := range tests {
+			name := tt.name
+			if signed {
+				name += " signed"
+			}
+			t.Run(name, func(t *testing.T) {
+				if signed {
+					setTestSigning(t, Signing{Key: []byte("test secret")})
+				}
+				got, err := Decode(Encode(tt.sort, tt.cursor), tt.sort)
+				if err != nil {
+					t.Fatalf("Decode: %v", err)
+				}
+				if !got.At.Equal(tt.cursor.At) || got.ID != tt.cursor.ID || got.Key != tt.cursor.Key || got.Backward != tt.cursor.Backward {
+					t.Errorf("round trip = %+v, want %+v", got, tt.cursor)
+				}
+			})
+		}
+	}
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	cursor, err := Decode("", CreatedAtDesc)
+	if err != nil || !cursor.IsZero() {
+		t.Errorf("Decode(\"\") = %+v, %v, want the zero cursor", cursor, err)
+	}
+}
+
+// setTestSigning sets the signing config for the duration of the test
+func setTestSigning(t *testing.T, config Signing) {
+	t.Helper()
+	previous := signing
+	SetSigning(config)
+	t.Cleanup(func() { SetSigning(previous) })
+}
+
+func TestDecodeRejects(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	legacy, _ := at.MarshalText()
+
+	tests := []struct {
+		name    string
+		signing Signing
+		token   string
+		sort    Sort
+	}{
+		{
+			name:  "token for another column",
+			token: Encode(UpdatedAtDesc, Cursor{At: at, ID: "1"}),
+			sort:  CreatedAtDesc,
+		},
+		{
+			name:  "token for the other direction",
+			token: Encode(LicenseExpiryAsc, Cursor{At: at, ID: "1"}),
+			sort:  LicenseExpiryDesc,
+		},
+		{
+			name:  "keyed token for another sort column",
+			token: Encode(Sort{Column: "year", Descending: true}, Cursor{At: at, ID: "1", Key: "2019"}),
+			sort:  Sort{Column: "make", Descending: true},
+		},
+		{
+			name:  "legacy timestamp for another sort",
+			token: base64.URLEncoding.EncodeToString(legacy),
+			sort:  UpdatedAtDesc,
+		},
+		{
+			name:  "unsupported version",
+			token: base64.URLEncoding.EncodeToString([]byte(`{"v":2,"col":"created_at","dir":"desc","at":"2025-03-01T09:30:00Z"}`)),
+			sort:  CreatedAtDesc,
+		},
+		{name: "not base64", token: "not a token!", sort: CreatedAtDesc},
+		{name: "not JSON", token: base64.URLEncoding.EncodeToString([]byte("{garbage")), sort: CreatedAtDesc},
+		{
+			name:    "unsigned while signing is required",
+			signing: Signing{Key: []byte("test secret")},
+			token:   Encode(CreatedAtDesc, Cursor{At: at, ID: "1"}),
+			sort:    CreatedAtDesc,
+		},
+		{
+			name:    "legacy timestamp while signing is required",
+			signing: Signing{Key: []byte("test secret")},
+			token:   base64.URLEncoding.EncodeToString(legacy),
+			sort:    CreatedAtDesc,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestSigning(t, tt.signing)
+			if _, err := Decode(tt.token, tt.sort); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Decode = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsForgedSignature(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	setTestSigning(t, Signing{Key: []byte("other secret")})
+	forged := Encode(CreatedAtDesc, Cursor{At: at, ID: "1"})
+
+	setTestSigning(t, Signing{Key: []byte("test secret")})
+	if _, err := Decode(forged, CreatedAtDesc); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token signed with another key: Decode = %v, want ErrInvalidToken", err)
+	}
+
+	// Flip a byte of the payload, after the prefix and signature
+	data, _ := base64.URLEncoding.DecodeString(Encode(CreatedAtDesc, Cursor{At: at, ID: "1"}))
+	data[len(data)-3] ^= 1
+	if _, err := Decode(base64.URLEncoding.EncodeToString(data), CreatedAtDesc); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("tampered token: Decode = %v, want ErrInvalidToken", err)
+	}
+
+	// A signed token can't be verified, so isn't trusted, once the key is gone
+	signed := Encode(CreatedAtDesc, Cursor{At: at, ID: "1"})
+	setTestSigning(t, Signing{})
+	if _, err := Decode(signed, CreatedAtDesc); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("signed token without a key: Decode = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestDecodeAccepts(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	legacy, _ := at.MarshalText()
+
+	tests := []struct {
+		name    string
+		signing Signing
+		token   string
+	}{
+		{name: "legacy timestamp", token: base64.URLEncoding.EncodeToString(legacy)},
+		{
+			name:    "unsigned during rollout",
+			signing: Signing{Key: []byte("test secret"), AcceptUnsigned: true},
+			token:   Encode(CreatedAtDesc, Cursor{At: at}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestSigning(t, tt.signing)
+			cursor, err := Decode(tt.token, CreatedAtDesc)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if !cursor.At.Equal(at) || cursor.ID != "" {
+				t.Errorf("cursor = %+v, want the timestamp without an ID", cursor)
+			}
+		})
+	}
+}
+
+// freezeClock stops the clock Decode checks cursors against at at, with the given leeway
+func freezeClock(t *testing.T, at time.Time, leeway time.Duration) {
+	t.Helper()
+	prevNow, prevLeeway := now, clockLeeway
+	now, clockLeeway = clock.NewFrozen(at), leeway
+	t.Cleanup(func() { now, clockLeeway = prevNow, prevLeeway })
+}
+
+func TestDecodeClockSkew(t *testing.T) {
+	const leeway = 5 * time.Second
+	serverNow := base.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		sort    Sort
+		skew    time.Duration // how far the cursor is ahead of the server clock
+		wantErr bool
+	}{
+		{name: "in the past", sort: CreatedAtDesc, skew: -time.Hour},
+		{name: "at the server time", sort: CreatedAtDesc},
+		// Stamped by a node whose clock runs fast
+		{name: "just inside the leeway", sort: CreatedAtDesc, skew: leeway - time.Millisecond},
+		{name: "on the leeway", sort: CreatedAtDesc, skew: leeway},
+		{name: "just outside the leeway", sort: CreatedAtDesc, skew: leeway + time.Millisecond, wantErr: true},
+		{name: "updated_at outside the leeway", sort: UpdatedAtDesc, skew: leeway + time.Millisecond, wantErr: true},
+		{name: "changed_at outside the leeway", sort: ChangedAtDesc, skew: leeway + time.Millisecond, wantErr: true},
+		// Expiry dates are meant to be in the future
+		{name: "license expiry far ahead", sort: LicenseExpiryAsc, skew: 365 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			freezeClock(t, serverNow, leeway)
+			token := Encode(tt.sort, Cursor{At: serverNow.Add(tt.skew), ID: "c"})
+
+			cursor, err := Decode(token, tt.sort)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("err = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if !cursor.At.Equal(serverNow.Add(tt.skew)) || cursor.ID != "c" {
+				t.Errorf("cursor = %+v, want the encoded one", cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeLegacyClockSkew(t *testing.T) {
+	const leeway = 5 * time.Second
+	freezeClock(t, base, leeway)
+
+	for _, tt := range []struct {
+		skew    time.Duration
+		wantErr bool
+	}{
+		{skew: leeway - time.Millisecond},
+		{skew: leeway + time.Millisecond, wantErr: true},
+	} {
+		data, _ := base.Add(tt.skew).MarshalText()
+		_, err := Decode(base64.URLEncoding.EncodeToString(data), CreatedAtDesc)
+		if tt.wantErr && !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("skew %s: err = %v, want ErrInvalidToken", tt.skew, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("skew %s: Decode: %v", tt.skew, err)
+		}
+	}
+}
+
+func TestPaginateRowsFromFastClock(t *testing.T) {
+	// e and d were created on a node 3s ahead of the one serving the listing
+	freezeClock(t, base.Add(4*time.Minute-3*time.Second), DefaultClockLeeway)
+
+	rows, next, _ := Paginate(fetch(Cursor{}, 2), 1, CreatedAtDesc, Cursor{}, testRow.cursor)
+	if got := ids(rows); !slices.Equal(got, []string{"e"}) {
+		t.Fatalf("first page = %v, want [e]", got)
+	}
+
+	// The token holds e's timestamp, ahead of the server clock but within the leeway
+	cursor, err := Decode(next, CreatedAtDesc)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	rows, _, _ = Paginate(fetch(cursor, 2), 1, CreatedAtDesc, cursor, testRow.cursor)
+	if got := ids(rows); !slices.Equal(got, []string{"d"}) {
+		t.Errorf("second page = %v, want [d]", got)
+	}
+}
+
+func TestClockLeewayFromEnv(t *testing.T) {
+	for _, tt := range []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: DefaultClockLeeway},
+		{value: "2s", want: 2 * time.Second},
+		{value: "0s", want: 0},
+		{value: "soon", want: DefaultClockLeeway},
+		{value: "-1s", want: DefaultClockLeeway},
+	} {
+		t.Setenv("PAGE_TOKEN_CLOCK_LEEWAY", tt.value)
+		if got := ClockLeewayFromEnv(); got != tt.want {
+			t.Errorf("ClockLeewayFromEnv() with %q = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
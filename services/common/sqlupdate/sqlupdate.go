@@ -0,0 +1,68 @@
+// services/common/sqlupdate/sqlupdate.go
+package sqlupdate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Set collects the assignments of a partial UPDATE. Each masked column is written as
+//
+//	column = CASE WHEN ? THEN ? ELSE column END
+//
+// and its flag and value are appended to the arguments together, so the arguments can't
+// drift out of line with the placeholders the way hand-written argument lists can.
+//
+// Table and column names are spliced into the SQL and must be constants, never input.
+type Set struct {
+	clauses []string
+	args    []any
+}
+
+// Field assigns value to column when update is true and leaves the column unchanged otherwise
+func (s *Set) Field(column string, update bool, value any) *Set {
+	mustBeIdentifier(column)
+	s.clauses = append(s.clauses, fmt.Sprintf("%s = CASE WHEN ? THEN ? ELSE %s END", column, column))
+	s.args = append(s.args, update, value)
+	return s
+}
+
+// Value always assigns value to column, for bookkeeping columns such as updated_at
+func (s *Set) Value(column string, value any) *Set {
+	mustBeIdentifier(column)
+	s.clauses = append(s.clauses, column+" = ?")
+	s.args = append(s.args, value)
+	return s
+}
+
+// Statement builds "UPDATE table SET ... WHERE where". The arguments are the assignments'
+// in the order they were added, followed by whereArgs for the placeholders in where.
+func (s *Set) Statement(table, where string, whereArgs ...any) (string, []any) {
+	mustBeIdentifier(table)
+	if len(s.clauses) == 0 {
+		panic("sqlupdate: UPDATE " + table + " has no assignments")
+	}
+
+	query := "UPDATE " + table + "\nSET " + strings.Join(s.clauses, ",\n    ") + "\nWHERE " + where
+
+	args := make([]any, 0, len(s.args)+len(whereArgs))
+	args = append(args, s.args...)
+	args = append(args, whereArgs...)
+	return query, args
+}
+
+// mustBeIdentifier panics on names that aren't plain SQL identifiers. Names come from
+// constants in the stores, so a bad one is a programming error rather than bad input.
+func mustBeIdentifier(name string) {
+	if name == "" {
+		panic("sqlupdate: empty identifier")
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			panic(fmt.Sprintf("sqlupdate: %q is not a plain identifier", name))
+		}
+	}
+}
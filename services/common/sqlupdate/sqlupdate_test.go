@@ -0,0 +1,62 @@
+// services/common/sqlupdate/sqlupdate_test.go
+package sqlupdate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStatement(t *testing.T) {
+	var set Set
+	set.Field("make", true, "Toyota").
+		Field("model", false, "").
+		Field("year", true, 2019).
+		Value("updated_by", "admin-1")
+
+	query, args := set.Statement("vehicles", "external_id = ? AND status <> ?", "id-1", "RETIRED")
+
+	wantQuery := "UPDATE vehicles\n" +
+		"SET make = CASE WHEN ? THEN ? ELSE make END,\n" +
+		"    model = CASE WHEN ? THEN ? ELSE model END,\n" +
+		"    year = CASE WHEN ? THEN ? ELSE year END,\n" +
+		"    updated_by = ?\n" +
+		"WHERE external_id = ? AND status <> ?"
+	if query != wantQuery {
+		t.Errorf("query =\n%s\nwant\n%s", query, wantQuery)
+	}
+
+	// Each field's flag and value sit together, in the order the fields were added,
+	// followed by the WHERE arguments
+	wantArgs := []any{true, "Toyota", false, "", true, 2019, "admin-1", "id-1", "RETIRED"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+	if placeholders := strings.Count(query, "?"); placeholders != len(args) {
+		t.Errorf("query has %d placeholders for %d args", placeholders, len(args))
+	}
+}
+
+func TestStatementPanics(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func()
+	}{
+		{name: "no assignments", build: func() { new(Set).Statement("vehicles", "id = ?", 1) }},
+		{name: "empty column", build: func() { new(Set).Field("", true, 1) }},
+		{name: "column with SQL", build: func() { new(Set).Value("make = 'x', model", "y") }},
+		{name: "column starting with a digit", build: func() { new(Set).Value("1make", "y") }},
+		{name: "table with SQL", build: func() { new(Set).Value("make", "x").Statement("vehicles; DROP TABLE users", "id = ?", 1) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			tt.build()
+		})
+	}
+}
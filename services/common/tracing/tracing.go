@@ -0,0 +1,95 @@
+// services/common/tracing/tracing.go
+package tracing
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// instrumentationName names the tracer spans started through Start are recorded under
+const instrumentationName = "github.com/adammwaniki/bebabeba/services"
+
+// Init sets up tracing for serviceName. Spans are exported over OTLP/gRPC to the collector
+// named by OTEL_EXPORTER_OTLP_ENDPOINT (or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT); with neither
+// set, nothing is exported and spans cost next to nothing. Either way incoming trace context
+// is propagated, so a service without an exporter doesn't break the traces passing through it.
+// The returned function flushes pending spans and should be called on shutdown.
+func Init(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// The exporter reads the endpoint, headers and TLS settings from the standard OTEL_* variables
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	log.Printf("Tracing enabled for %s", serviceName)
+	return provider.Shutdown, nil
+}
+
+// ServerOption records a span for every call a gRPC server handles, continuing the
+// caller's trace when the call carries one
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// DialOption records a span for every call made on a gRPC client connection and sends
+// the trace context along with it
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// HTTPHandler records a span for every request next serves, continuing the caller's
+// trace when the request carries one. When next is a ServeMux, wrapped directly or
+// through middleware that passes the request on unchanged, spans are named after the
+// matched route pattern.
+func HTTPHandler(next http.Handler, operation string) http.Handler {
+	return otelhttp.NewHandler(next, operation, otelhttp.WithSpanNameFormatter(
+		func(operation string, r *http.Request) string {
+			if r.Pattern != "" {
+				return r.Pattern
+			}
+			return operation
+		},
+	))
+}
+
+// Start starts a span as a child of any span in ctx. The caller must End it.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,136 @@
+// services/common/utils/timeformat.go
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TimeFormat is how WriteProtoJSON renders google.protobuf.Timestamp fields
+type TimeFormat string
+
+const (
+	// TimeFormatRFC3339 is protojson's own rendering, e.g. "2025-09-13T10:00:00Z"
+	TimeFormatRFC3339 TimeFormat = "rfc3339"
+	// TimeFormatEpochMillis renders timestamps as milliseconds since the Unix epoch
+	TimeFormatEpochMillis TimeFormat = "epoch_ms"
+)
+
+// ParseTimeFormat reads a ?time_format= value; an empty value means RFC 3339
+func ParseTimeFormat(s string) (TimeFormat, error) {
+	switch TimeFormat(s) {
+	case "", TimeFormatRFC3339:
+		return TimeFormatRFC3339, nil
+	case TimeFormatEpochMillis:
+		return TimeFormatEpochMillis, nil
+	}
+	return "", fmt.Errorf("invalid time_format %q, expected %s or %s", s, TimeFormatRFC3339, TimeFormatEpochMillis)
+}
+
+// timeFormatWriter carries the requested TimeFormat down to WriteProtoJSON, which
+// only sees the ResponseWriter
+type timeFormatWriter struct {
+	http.ResponseWriter
+	format TimeFormat
+}
+
+// Flush keeps streaming responses streaming through the wrapper
+func (w *timeFormatWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *timeFormatWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// WithTimeFormat returns w set up so WriteProtoJSON renders timestamps in format
+func WithTimeFormat(w http.ResponseWriter, format TimeFormat) http.ResponseWriter {
+	return &timeFormatWriter{ResponseWriter: w, format: format}
+}
+
+// TimeFormatOf returns the TimeFormat set on w by WithTimeFormat, RFC 3339 if none was.
+// Writers wrapped around it by later middleware are unwrapped to find it.
+func TimeFormatOf(w http.ResponseWriter) TimeFormat {
+	for w != nil {
+		if tw, ok := w.(*timeFormatWriter); ok {
+			return tw.format
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			break
+		}
+		w = unwrapper.Unwrap()
+	}
+	return TimeFormatRFC3339
+}
+
+// RewriteTimestamps rewrites the Timestamp fields of body, the protojson encoding of a
+// message described by md decoded with json.Decoder.UseNumber, into format. Fields are
+// found through the descriptor rather than by looking at values, so a string that merely
+// looks like a date is never touched.
+func RewriteTimestamps(body map[string]any, md protoreflect.MessageDescriptor, format TimeFormat) {
+	if format != TimeFormatEpochMillis {
+		return
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		value, ok := body[fd.JSONName()]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case fd.IsMap():
+			entries, ok := value.(map[string]any)
+			if !ok || fd.MapValue().Message() == nil {
+				continue
+			}
+			for key, entry := range entries {
+				entries[key] = rewriteTimestampValue(entry, fd.MapValue().Message(), format)
+			}
+		case fd.Message() == nil:
+		case fd.IsList():
+			if items, ok := value.([]any); ok {
+				for j, item := range items {
+					items[j] = rewriteTimestampValue(item, fd.Message(), format)
+				}
+			}
+		default:
+			body[fd.JSONName()] = rewriteTimestampValue(value, fd.Message(), format)
+		}
+	}
+}
+
+func rewriteTimestampValue(value any, md protoreflect.MessageDescriptor, format TimeFormat) any {
+	if md.FullName() == "google.protobuf.Timestamp" {
+		s, ok := value.(string)
+		if !ok {
+			return value
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return value
+		}
+		return json.Number(strconv.FormatInt(t.UnixMilli(), 10))
+	}
+
+	// Other well-known types have their own JSON shapes that don't follow the descriptor
+	if md.ParentFile().Package() == "google.protobuf" {
+		return value
+	}
+
+	if obj, ok := value.(map[string]any); ok {
+		RewriteTimestamps(obj, md, format)
+	}
+	return value
+}
@@ -2,14 +2,22 @@
 package utils
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"math/rand/v2"
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
+	"bytes"
 	_ "github.com/joho/godotenv/autoload"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -30,14 +38,37 @@ func ReadJSON(r *http.Request, data any) error {
 	return json.NewDecoder(r.Body).Decode(data)
 }
 
+// ErrorResponse is the envelope every error response is written in:
+//
+//	{"error": {"code": 409, "status": "ALREADY_EXISTS", "message": "..."}}
+//
+// code is the HTTP status. status, the gRPC code name, and the remaining fields are only
+// set for errors that came back from a service.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes what went wrong in an ErrorResponse
+type ErrorBody struct {
+	Code            int               `json:"code"`
+	Status          string            `json:"status,omitempty"`
+	Message         string            `json:"message"`
+	Reason          string            `json:"reason,omitempty"`
+	Domain          string            `json:"domain,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	FieldViolations []FieldViolation  `json:"field_violations,omitempty"`
+}
+
 // Provide standard output for http error messages
 func WriteError(w http.ResponseWriter, status int, errorMessage error) {
-	WriteJSON(w, status, map[string]string{"error": errorMessage.Error()})
+	WriteJSON(w, status, ErrorResponse{Error: ErrorBody{Code: status, Message: errorMessage.Error()}})
 }
 
-// WriteProtoJSON handles protobuf message serialization with error handling
+// WriteProtoJSON handles protobuf message serialization with error handling.
+// Timestamps are rendered in the TimeFormat set on w, RFC 3339 by default.
 func WriteProtoJSON(w http.ResponseWriter, status int, msg proto.Message) {
-	// Configure protobuf JSON marshaler
+	// Configure protobuf JSON marshaler. EmitUnpopulated keeps empty repeated
+	// fields as [] so list responses always carry their items array.
 	marshaler := protojson.MarshalOptions{
 		UseProtoNames:     false,
 		EmitUnpopulated:   true,
@@ -51,12 +82,57 @@ func WriteProtoJSON(w http.ResponseWriter, status int, msg proto.Message) {
 		return
 	}
 
+	if format := TimeFormatOf(w); format != TimeFormatRFC3339 {
+		// UseNumber keeps numeric values exactly as protojson wrote them
+		var body map[string]any
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&body); err != nil {
+			WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to format response timestamps: %w", err))
+			return
+		}
+		RewriteTimestamps(body, msg.ProtoReflect().Descriptor(), format)
+		WriteJSON(w, status, body)
+		return
+	}
+
 	// Write successful response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	w.Write(data)
 }
 
+// HTTPStatusFromCode is the HTTP status a gRPC code is reported to REST clients as.
+// AlreadyExists (a duplicate) and FailedPrecondition (a request the resource's current
+// state doesn't allow) get statuses of their own, so clients can tell them apart from
+// a malformed request.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument: // gRPC for bad input (e.g., validation failed)
+		return http.StatusBadRequest
+	case codes.NotFound: // gRPC for resource not found
+		return http.StatusNotFound
+	case codes.AlreadyExists: // gRPC for resource already existing (e.g., duplicate email/ID)
+		return http.StatusConflict
+	case codes.FailedPrecondition: // gRPC for operations the resource's current state doesn't allow
+		return http.StatusUnprocessableEntity
+	case codes.Aborted: // gRPC for a concurrent request getting in the way, worth retrying
+		return http.StatusConflict
+	case codes.PermissionDenied: // gRPC for authorization issues
+		return http.StatusForbidden
+	case codes.Unauthenticated: // gRPC for authentication issues (e.g., missing/invalid token)
+		return http.StatusUnauthorized
+	case codes.Unimplemented: // gRPC for features that are switched off or not built yet
+		return http.StatusNotImplemented
+	case codes.Unavailable: // gRPC for temporary service unavailability
+		return http.StatusServiceUnavailable
+	default: // All other gRPC errors (e.g., Internal, Unknown, DataLoss)
+		return http.StatusInternalServerError
+	}
+}
+
 // HandleGRPCError translates a gRPC error to an appropriate HTTP response.
 func HandleGRPCError(w http.ResponseWriter, err error) {
 	// Use status.FromError to correctly extract the gRPC status from the error chain.
@@ -69,27 +145,60 @@ func HandleGRPCError(w http.ResponseWriter, err error) {
 		return
 	}
 
-	// Now 'st' contains the gRPC status, and we can switch on its code.
-	switch st.Code() {
-	case codes.InvalidArgument: // gRPC for bad input (e.g., validation failed)
-		WriteError(w, http.StatusBadRequest, errors.New(st.Message()))
-	case codes.NotFound: // gRPC for resource not found
-		WriteError(w, http.StatusNotFound, errors.New(st.Message()))
-	case codes.AlreadyExists: // gRPC for resource already existing (e.g., duplicate email/ID)
-		WriteError(w, http.StatusConflict, errors.New(st.Message())) // Use 409 Conflict
-	case codes.PermissionDenied: // gRPC for authorization issues
-		WriteError(w, http.StatusForbidden, errors.New(st.Message()))
-	case codes.Unauthenticated: // gRPC for authentication issues (e.g., missing/invalid token)
-		WriteError(w, http.StatusUnauthorized, errors.New(st.Message()))
-	case codes.Unavailable: // gRPC for temporary service unavailability
-		WriteError(w, http.StatusServiceUnavailable, errors.New("service unavailable, please try again later"))
-	default: // All other gRPC errors (e.g., Internal, Unknown, DataLoss)
+	httpStatus := HTTPStatusFromCode(st.Code())
+	switch {
+	case st.Code() == codes.Unavailable:
+		writeErrorBody(w, ErrorBody{Code: httpStatus, Status: statusName(st), Message: "service unavailable, please try again later"})
+	case httpStatus == http.StatusInternalServerError:
 		// Log the full gRPC error details on the server for debugging
 		fmt.Printf("Unhandled gRPC error: code=%s, message=%s, details=%v\n", st.Code(), st.Message(), st.Details())
-		WriteError(w, http.StatusInternalServerError, errors.New("internal server error"))
+		writeErrorBody(w, ErrorBody{Code: httpStatus, Status: statusName(st), Message: "internal server error"})
+	default:
+		writeStatusError(w, httpStatus, st)
 	}
 }
 
+// FieldViolation is one rejected request field in an error response
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// writeStatusError writes the status message in the error envelope, adding any structured
+// details the service attached: google.rpc.BadRequest becomes "field_violations" and
+// google.rpc.ErrorInfo becomes "reason", "domain" and "metadata".
+func writeStatusError(w http.ResponseWriter, httpStatus int, st *status.Status) {
+	body := ErrorBody{
+		Code:    httpStatus,
+		Status:  statusName(st),
+		Message: st.Message(),
+	}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				body.FieldViolations = append(body.FieldViolations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		case *errdetails.ErrorInfo:
+			body.Reason = d.GetReason()
+			body.Domain = d.GetDomain()
+			body.Metadata = d.GetMetadata()
+		}
+	}
+
+	writeErrorBody(w, body)
+}
+
+func writeErrorBody(w http.ResponseWriter, body ErrorBody) {
+	WriteJSON(w, body.Code, ErrorResponse{Error: body})
+}
+
+// statusName is the canonical name of the status code, e.g. ALREADY_EXISTS
+func statusName(st *status.Status) string {
+	return code.Code_name[int32(st.Code())]
+}
+
 // Making snowflake ID generation a utility
 // Fetch and validate unique nodeID (must be 0–1023 for 10-bit Snowflake machineID compatibility)
 // In the generators, the epoch starts from 2017-04-09T00:00:00Z
@@ -103,4 +212,180 @@ func GetSnowflakeNodeID() (uint64, error) {
 		return 0, fmt.Errorf("NODE_ID %d is out of valid range (0 - 1023)", unodeID)
 	}
 	return unodeID, nil
-}
\ No newline at end of file
+}
+
+// ResolveSnowflakeNodeID is meant to be called once at startup so a bad NODE_ID fails fast
+// instead of breaking every create. A NODE_ID that is set but invalid is an error. An unset
+// NODE_ID falls back to a random node ID, which is only safe for single-instance dev setups.
+func ResolveSnowflakeNodeID() (uint64, error) {
+	if os.Getenv("NODE_ID") == "" {
+		nodeID := uint64(rand.IntN(1024))
+		log.Printf("WARNING: NODE_ID is not set, falling back to random snowflake node ID %d. "+
+			"Clustered deployments MUST set a unique NODE_ID per instance or generated IDs can collide", nodeID)
+		return nodeID, nil
+	}
+	return GetSnowflakeNodeID()
+}
+
+// SearchTermLimits bounds free-text filters that end up in a LIKE '%...%' query.
+// Very short terms match most of the table and very long ones make the scan expensive.
+type SearchTermLimits struct {
+	MinLength int
+	MaxLength int
+}
+
+// DefaultSearchTermLimits is used when SEARCH_TERM_MIN_LENGTH / SEARCH_TERM_MAX_LENGTH are unset
+var DefaultSearchTermLimits = SearchTermLimits{MinLength: 2, MaxLength: 100}
+
+// SearchTermLimitsFromEnv reads the search term limits from the environment,
+// falling back to the defaults for unset or invalid values.
+func SearchTermLimitsFromEnv() SearchTermLimits {
+	limits := DefaultSearchTermLimits
+	if n, err := strconv.Atoi(os.Getenv("SEARCH_TERM_MIN_LENGTH")); err == nil && n >= 1 {
+		limits.MinLength = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("SEARCH_TERM_MAX_LENGTH")); err == nil && n >= limits.MinLength {
+		limits.MaxLength = n
+	}
+	return limits
+}
+
+// DBRetry is how long a service keeps trying to reach its database at startup.
+// sql.Open doesn't connect, so a service started before MySQL is accepting
+// connections would otherwise only find out when its first query fails.
+type DBRetry struct {
+	Timeout    time.Duration // total time to keep trying; 0 tries once
+	MaxBackoff time.Duration // cap on the delay between attempts, which doubles from 500ms
+}
+
+// DefaultDBRetry is used when DB_CONNECT_TIMEOUT / DB_CONNECT_MAX_BACKOFF are unset
+var DefaultDBRetry = DBRetry{Timeout: 60 * time.Second, MaxBackoff: 5 * time.Second}
+
+// DBRetryFromEnv reads the startup retry budget from the environment as durations
+// such as "90s" or "2m", falling back to the defaults for unset or invalid values.
+func DBRetryFromEnv() DBRetry {
+	retry := DefaultDBRetry
+	if d, err := time.ParseDuration(os.Getenv("DB_CONNECT_TIMEOUT")); err == nil && d >= 0 {
+		retry.Timeout = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("DB_CONNECT_MAX_BACKOFF")); err == nil && d > 0 {
+		retry.MaxBackoff = d
+	}
+	return retry
+}
+
+// WaitForDB pings the database until it answers, backing off between attempts,
+// and gives up with the last error once the retry budget is spent
+func WaitForDB(ctx context.Context, db *sql.DB, retry DBRetry) error {
+	deadline := time.Now().Add(retry.Timeout)
+	backoff := 500 * time.Millisecond
+
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		err := db.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("database unreachable after %d attempts: %w", attempt, err)
+		}
+
+		wait := min(backoff, remaining)
+		log.Printf("Database not ready (attempt %d): %v; retrying in %s", attempt, err, wait)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for database: %w", ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, retry.MaxBackoff)
+	}
+}
+
+// DBPool sizes a service's database connection pool. database/sql leaves open
+// connections unbounded and never recycles them, which under load can exhaust
+// MySQL's max_connections and keeps connections alive past proxy or failover limits.
+type DBPool struct {
+	MaxOpenConns    int           // 0 means unlimited
+	MaxIdleConns    int           // 0 keeps no idle connections
+	ConnMaxLifetime time.Duration // 0 means connections are reused forever
+	ConnMaxIdleTime time.Duration // 0 means idle connections aren't closed for being idle
+}
+
+// DefaultDBPool is used when the DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME
+// and DB_CONN_MAX_IDLE_TIME variables are unset
+var DefaultDBPool = DBPool{MaxOpenConns: 25, MaxIdleConns: 5, ConnMaxLifetime: 5 * time.Minute, ConnMaxIdleTime: time.Minute}
+
+// DBPoolFromEnv reads the pool settings from the environment, with counts as integers and
+// lifetimes as durations such as "5m", falling back to the defaults for unset or invalid values.
+func DBPoolFromEnv() DBPool {
+	pool := DefaultDBPool
+	if n, err := strconv.Atoi(os.Getenv("DB_MAX_OPEN_CONNS")); err == nil && n >= 0 {
+		pool.MaxOpenConns = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("DB_MAX_IDLE_CONNS")); err == nil && n >= 0 {
+		pool.MaxIdleConns = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("DB_CONN_MAX_LIFETIME")); err == nil && d >= 0 {
+		pool.ConnMaxLifetime = d
+	}
+	if d, err := time.ParseDuration(os.Getenv("DB_CONN_MAX_IDLE_TIME")); err == nil && d >= 0 {
+		pool.ConnMaxIdleTime = d
+	}
+	return pool
+}
+
+// Apply configures db's pool. database/sql caps idle connections at the open limit itself.
+func (p DBPool) Apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpenConns)
+	db.SetMaxIdleConns(p.MaxIdleConns)
+	db.SetConnMaxLifetime(p.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+}
+
+// DefaultDBQueryTimeout is used when DB_QUERY_TIMEOUT is unset
+const DefaultDBQueryTimeout = 5 * time.Second
+
+// DBQueryTimeoutFromEnv reads how long one store call may spend in the database from
+// DB_QUERY_TIMEOUT, a duration such as "3s". 0 turns the store's own timeout off, leaving
+// only the caller's deadline. Unset or invalid values fall back to the default.
+func DBQueryTimeoutFromEnv() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("DB_QUERY_TIMEOUT")); err == nil && d >= 0 {
+		return d
+	}
+	return DefaultDBQueryTimeout
+}
+
+// queryDeadlineHeadroom is how far ahead of the caller's deadline a store call gives up,
+// leaving the service time to answer DEADLINE_EXCEEDED before the caller stops waiting
+const queryDeadlineHeadroom = 250 * time.Millisecond
+
+type queryTimeoutKey struct{}
+
+// WithQueryTimeout bounds a store call's database work to timeout. When ctx has a
+// deadline, as gRPC requests from the gateway do, the store's deadline also lands
+// queryDeadlineHeadroom ahead of it, so a slow query fails with
+// context.DeadlineExceeded while the caller is still listening. Store calls made from
+// within another keep the outer call's deadline.
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if ctx.Value(queryTimeoutKey{}) != nil {
+		return ctx, func() {}
+	}
+	ctx = context.WithValue(ctx, queryTimeoutKey{}, true)
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if parent, ok := ctx.Deadline(); ok && time.Until(parent) > queryDeadlineHeadroom {
+		if shortened := parent.Add(-queryDeadlineHeadroom); deadline.IsZero() || shortened.Before(deadline) {
+			deadline = shortened
+		}
+	}
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline)
+}
@@ -0,0 +1,148 @@
+// services/common/utils/utils_test.go
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// decodeError checks the response is a JSON error envelope and returns its body
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) ErrorBody {
+	t.Helper()
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("response is not JSON: %v: %s", err, rec.Body)
+	}
+	if len(envelope) != 1 || envelope["error"] == nil {
+		t.Fatalf("response = %s, want a single \"error\" object", rec.Body)
+	}
+	var body ErrorBody
+	if err := json.Unmarshal(envelope["error"], &body); err != nil {
+		t.Fatalf("failed to decode error body: %v", err)
+	}
+	return body
+}
+
+func TestHandleGRPCError(t *testing.T) {
+	tests := []struct {
+		code        codes.Code
+		wantStatus  int
+		wantMessage string
+	}{
+		{code: codes.InvalidArgument, wantStatus: http.StatusBadRequest, wantMessage: "boom"},
+		{code: codes.NotFound, wantStatus: http.StatusNotFound, wantMessage: "boom"},
+		{code: codes.AlreadyExists, wantStatus: http.StatusConflict, wantMessage: "boom"},
+		{code: codes.FailedPrecondition, wantStatus: http.StatusUnprocessableEntity, wantMessage: "boom"},
+		{code: codes.Aborted, wantStatus: http.StatusConflict, wantMessage: "boom"},
+		{code: codes.PermissionDenied, wantStatus: http.StatusForbidden, wantMessage: "boom"},
+		{code: codes.Unauthenticated, wantStatus: http.StatusUnauthorized, wantMessage: "boom"},
+		{code: codes.Unimplemented, wantStatus: http.StatusNotImplemented, wantMessage: "boom"},
+		// Outages and server faults don't leak the service's own message
+		{code: codes.Unavailable, wantStatus: http.StatusServiceUnavailable, wantMessage: "service unavailable, please try again later"},
+		{code: codes.Internal, wantStatus: http.StatusInternalServerError, wantMessage: "internal server error"},
+		{code: codes.Unknown, wantStatus: http.StatusInternalServerError, wantMessage: "internal server error"},
+		{code: codes.DataLoss, wantStatus: http.StatusInternalServerError, wantMessage: "internal server error"},
+		{code: codes.DeadlineExceeded, wantStatus: http.StatusInternalServerError, wantMessage: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			HandleGRPCError(rec, status.Error(tt.code, "boom"))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			body := decodeError(t, rec)
+			if body.Code != tt.wantStatus {
+				t.Errorf("error.code = %d, want %d", body.Code, tt.wantStatus)
+			}
+			if body.Message != tt.wantMessage {
+				t.Errorf("error.message = %q, want %q", body.Message, tt.wantMessage)
+			}
+			if want := statusName(status.New(tt.code, "")); body.Status != want {
+				t.Errorf("error.status = %q, want %q", body.Status, want)
+			}
+		})
+	}
+}
+
+func TestHandleGRPCErrorWrapped(t *testing.T) {
+	// The status is found through the error chain
+	rec := httptest.NewRecorder()
+	HandleGRPCError(rec, fmt.Errorf("calling vehicle service: %w", status.Error(codes.NotFound, "vehicle not found")))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if body := decodeError(t, rec); body.Status != "NOT_FOUND" {
+		t.Errorf("error.status = %q, want NOT_FOUND", body.Status)
+	}
+}
+
+func TestHandleGRPCErrorNonStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	HandleGRPCError(rec, errors.New("dial tcp: connection refused"))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	want := ErrorBody{Code: http.StatusInternalServerError, Message: "internal server error"}
+	if body := decodeError(t, rec); !reflect.DeepEqual(body, want) {
+		t.Errorf("error = %+v, want %+v", body, want)
+	}
+}
+
+func TestHandleGRPCErrorDetails(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "validation failed").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "year", Description: "must be between 1980 and 2026"},
+		}},
+		&errdetails.ErrorInfo{Reason: "PLATE_RETIRED", Domain: "vehicle", Metadata: map[string]string{"plate": "KDA123A"}},
+	)
+	if err != nil {
+		t.Fatalf("failed to attach details: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	HandleGRPCError(rec, st.Err())
+
+	want := ErrorBody{
+		Code:            http.StatusBadRequest,
+		Status:          "INVALID_ARGUMENT",
+		Message:         "validation failed",
+		Reason:          "PLATE_RETIRED",
+		Domain:          "vehicle",
+		Metadata:        map[string]string{"plate": "KDA123A"},
+		FieldViolations: []FieldViolation{{Field: "year", Description: "must be between 1980 and 2026"}},
+	}
+	if body := decodeError(t, rec); !reflect.DeepEqual(body, want) {
+		t.Errorf("error = %+v, want %+v", body, want)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteError(rec, http.StatusTooManyRequests, errors.New("rate limit exceeded"))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	// Errors raised by the gateway itself carry just the code and message
+	want := `{"error":{"code":429,"message":"rate limit exceeded"}}` + "\n"
+	if rec.Body.String() != want {
+		t.Errorf("body = %s, want %s", rec.Body, want)
+	}
+}
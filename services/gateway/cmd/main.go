@@ -9,20 +9,29 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/adammwaniki/bebabeba/services/auth/apikey"
 	"github.com/adammwaniki/bebabeba/services/auth/authn/jwt"
+	"github.com/adammwaniki/bebabeba/services/auth/lockout"
+	"github.com/adammwaniki/bebabeba/services/auth/oauthstate"
+	"github.com/adammwaniki/bebabeba/services/auth/passwordreset"
 	"github.com/adammwaniki/bebabeba/services/auth/session"
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/featureflags"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/gateway/internal/handler"
 	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/redact"
 	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
 	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/joho/godotenv/autoload"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -34,10 +43,9 @@ var (
 	staffGRPCAddr   = os.Getenv("STAFF_GRPC_ADDR")
 	gatewayAddr     = os.Getenv("GATEWAY_HTTP_ADDR")
 
-	// Google OAuth2 credentials
-	googleClientID     = os.Getenv("GOOGLE_CLIENT_ID")
-	googleClientSecret = os.Getenv("GOOGLE_CLIENT_SECRET")
-	googleRedirectURL  = os.Getenv("GOOGLE_REDIRECT_URL")
+	// Where OAuth login states are kept: "memory" (default, single instance only)
+	// or "database" (the sessions DB, shared by every gateway instance)
+	oauthStateStore = os.Getenv("OAUTH_STATE_STORE")
 
 	// JWT configuration
 	jwtSecret = os.Getenv("JWT_SECRET")
@@ -70,15 +78,22 @@ func main() {
 		}
 	}
 
+	// Tracing stays off unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), "gateway")
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	// Initialize database connection for session management
 	db, err := sql.Open("mysql", dbDSN+"?parseTime=true&loc=Local")
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer db.Close()
+	utils.DBPoolFromEnv().Apply(db)
 
-	// Test database connection
-	if err := db.Ping(); err != nil {
+	// Wait for the database, it may still be starting when the gateway comes up
+	if err := utils.WaitForDB(context.Background(), db, utils.DBRetryFromEnv()); err != nil {
 		log.Fatalf("Failed to ping database: %v", err)
 	}
 
@@ -88,8 +103,24 @@ func main() {
 
 	// Initialize session manager
 	sessionManager := session.NewSessionManager(db, jwtService)
+	resetManager := passwordreset.NewManager(db, passwordreset.DefaultTTL)
+	loginLockouts := lockout.NewManager(db, lockout.ConfigFromEnv())
 
-	// Start cleanup goroutine for expired sessions
+	var oauthStates handler.OAuthStateStore
+	var sqlOAuthStates *oauthstate.SQLStore
+	switch oauthStateStore {
+	case "", "memory":
+		memoryOAuthStates := oauthstate.NewMemoryStore(time.Minute)
+		defer memoryOAuthStates.Close()
+		oauthStates = memoryOAuthStates
+	case "database":
+		sqlOAuthStates = oauthstate.NewSQLStore(db)
+		oauthStates = sqlOAuthStates
+	default:
+		log.Fatalf("Unknown OAUTH_STATE_STORE %q, expected memory or database", oauthStateStore)
+	}
+
+	// Start cleanup goroutine for expired sessions, password reset tokens and login lockouts
 	go func() {
 		ticker := time.NewTicker(1 * time.Hour) // Clean up every hour
 		defer ticker.Stop()
@@ -98,14 +129,31 @@ func main() {
 			if err := sessionManager.CleanupExpiredSessions(ctx); err != nil {
 				log.Printf("Failed to cleanup expired sessions: %v", err)
 			}
+			if err := resetManager.CleanupExpiredTokens(ctx); err != nil {
+				log.Printf("Failed to cleanup password reset tokens: %v", err)
+			}
+			if err := loginLockouts.CleanupExpired(ctx); err != nil {
+				log.Printf("Failed to cleanup login lockouts: %v", err)
+			}
+			if sqlOAuthStates != nil {
+				if err := sqlOAuthStates.CleanupExpired(ctx); err != nil {
+					log.Printf("Failed to cleanup OAuth states: %v", err)
+				}
+			}
 			cancel()
 		}
 	}()
 
+	// Forward the authenticated user to the services so they can record who made a change.
+	// Trace context travels with every call through the tracing dial option.
+	actorInterceptor := actor.UnaryClientInterceptor(middleware.GetUserIDFromContext)
+
 	// Create gRPC connection to User Service
 	userConn, err := grpc.NewClient(
 		userGRPCAddr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(actorInterceptor),
+		tracing.DialOption(),
 	)
 	if err != nil {
 		log.Fatal("Failed to dial user service: ", err)
@@ -116,6 +164,8 @@ func main() {
 	vehicleConn, err := grpc.NewClient(
 		vehicleGRPCAddr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(actorInterceptor),
+		tracing.DialOption(),
 	)
 	if err != nil {
 		log.Fatal("Failed to dial vehicle service: ", err)
@@ -126,6 +176,8 @@ func main() {
 	staffConn, err := grpc.NewClient(
 		staffGRPCAddr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(actorInterceptor),
+		tracing.DialOption(),
 	)
 	if err != nil {
 		log.Fatal("Failed to dial staff service: ", err)
@@ -138,28 +190,47 @@ func main() {
 	vehicleClient := vehicleproto.NewVehicleServiceClient(vehicleConn)
 	staffClient := staffproto.NewStaffServiceClient(staffConn)
 
-	// Configure Google OAuth2
-	googleOAuthConfig := &oauth2.Config{
-		ClientID:     googleClientID,
-		ClientSecret: googleClientSecret,
-		RedirectURL:  googleRedirectURL,
-		Scopes:       []string{"openid", "email", "profile"},
-		Endpoint:     google.Endpoint,
-	}
+	// Configure OAuth2 for each provider with credentials set, e.g. GOOGLE_CLIENT_ID,
+	// GOOGLE_CLIENT_SECRET and GOOGLE_REDIRECT_URL
+	oauthConfigs := handler.OAuthConfigsFromEnv()
+
+	// Exports page through listings at the services' public maximum, so the gateway
+	// reads the same limits they do
+	pagesize.SetLimits(pagesize.LimitsFromEnv())
 
 	// Initialize handlers with session management
 	healthHandler := handler.NewHealthHandler(userHealth)
-	userHandler := handler.NewUserHandler(userClient, googleOAuthConfig)
-	authHandler := handler.NewAuthHandler(userClient, sessionManager, jwtService)
-	vehicleHandler := handler.NewVehicleHandler(vehicleClient)
-	staffHandler := handler.NewStaffHandler(staffClient)
+	profileCache := handler.NewProfileCache(5 * time.Minute)
+	// Admins see driver and user PII in full, everyone else gets it redacted
+	redaction := redact.PolicyFromEnv(middleware.RoleAdmin)
+	userHandler := handler.NewUserHandler(userClient, staffClient, oauthConfigs, oauthStates, profileCache)
+	userHandler.SetRedaction(redaction)
+	authHandler := handler.NewAuthHandler(userClient, staffClient, sessionManager, jwtService, profileCache)
+	// Forgot/reset password stays disabled until there is a sender to deliver the links
+	if resetSender := handler.PasswordResetSenderFromEnv(); resetSender != nil {
+		authHandler.SetPasswordReset(resetManager, resetSender)
+	} else {
+		log.Println("Password reset disabled: no reset link sender configured")
+	}
+	authHandler.SetLoginLockout(loginLockouts)
+	resultCap := handler.ResultCapFromEnv()
+	vehicleHandler := handler.NewVehicleHandler(vehicleClient, resultCap)
+	vehicleHandler.SetImportLimits(handler.VehicleImportLimitsFromEnv())
+	staffHandler := handler.NewStaffHandler(staffClient, vehicleClient, resultCap)
+	staffHandler.SetRedaction(redaction)
+	assignmentHandler := handler.NewAssignmentHandler(vehicleClient, staffClient, resultCap)
+	apiKeyManager := apikey.NewManager(db)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyManager)
 	
 	// Initialize authentication middleware with session support
 	authMiddleware := middleware.NewAuthMiddleware(jwtService, sessionManager)
+	authMiddleware.SetAdminUserIDs(strings.Split(os.Getenv("GATEWAY_ADMIN_USER_IDS"), ","))
+	authMiddleware.SetAPIKeyManager(apiKeyManager)
 
 	// Configure server
 	mux := http.NewServeMux()
-	handler.SetupAPIRoutes(mux, userHandler, authHandler, vehicleHandler, staffHandler, healthHandler, authMiddleware, sessionManager)
+	handler.SetupAPIRoutes(mux, userHandler, authHandler, vehicleHandler, staffHandler, assignmentHandler, apiKeyHandler, healthHandler, authMiddleware, sessionManager, featureflags.FromEnv(),
+		middleware.NewRequestLogger(os.Stderr, middleware.LogFormatFromEnv()), middleware.RateLimitConfigFromEnv(), middleware.CORSConfigFromEnv())
 
 	server := &http.Server{
 		Addr:    gatewayAddr,
@@ -190,5 +261,8 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Printf("Server shutdown error: %v", err)
 	}
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Tracing shutdown error: %v", err)
+	}
 	log.Println("Server stopped")
 }
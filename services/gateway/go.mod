@@ -2,4 +2,8 @@ module github.com/adammwaniki/bebabeba/services/gateway
 
 go 1.24.2
 
+require golang.org/x/sync v0.16.0
+
 require golang.org/x/oauth2 v0.30.0 // indirect
+
+require github.com/DATA-DOG/go-sqlmock v1.5.2
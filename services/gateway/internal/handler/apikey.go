@@ -0,0 +1,143 @@
+// services/gateway/internal/handler/apikey.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/apikey"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+)
+
+// APIKeyHandler lets admins issue and revoke API keys for partner integrations
+type APIKeyHandler struct {
+	manager *apikey.Manager
+}
+
+// CreateAPIKeyRequest represents the request payload for issuing an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Optional, the key never expires when omitted
+}
+
+// CreateAPIKeyResponse returns the plaintext key. It is never retrievable again.
+type CreateAPIKeyResponse struct {
+	*apikey.APIKey
+	Key string `json:"key"`
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(manager *apikey.Manager) *APIKeyHandler {
+	return &APIKeyHandler{manager: manager}
+}
+
+// HandleCreateAPIKey handles POST requests to issue a scoped API key
+func (h *APIKeyHandler) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var req CreateAPIKeyRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > 100 {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("name is required and must be at most 100 characters"))
+		return
+	}
+	if len(req.Scopes) == 0 {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("at least one scope is required"))
+		return
+	}
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !slices.Contains(middleware.APIKeyScopes, scope) {
+			utils.WriteError(w, http.StatusBadRequest,
+				fmt.Errorf("unknown scope %q, must be one of %s", scope, strings.Join(middleware.APIKeyScopes, ", ")))
+			return
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("expires_at must be in the future"))
+		return
+	}
+
+	createdBy, _ := middleware.GetUserIDFromContext(r.Context())
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	key, plaintext, err := h.manager.Create(ctx, req.Name, scopes, req.ExpiresAt, createdBy)
+	if err != nil {
+		log.Printf("Failed to create API key: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to create api key"))
+		return
+	}
+
+	log.Printf("API key %s (%s) created by %s with scopes %v", key.ID, key.Name, createdBy, key.Scopes)
+
+	utils.WriteJSON(w, http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Key: plaintext})
+}
+
+// HandleListAPIKeys handles GET requests to list issued API keys
+func (h *APIKeyHandler) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	keys, err := h.manager.List(ctx)
+	if err != nil {
+		log.Printf("Failed to list API keys: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to list api keys"))
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, map[string]any{
+		"api_keys": keys,
+	})
+}
+
+// HandleRevokeAPIKey handles DELETE requests to revoke an API key
+func (h *APIKeyHandler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID := r.PathValue("id")
+	if keyID == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("api key ID is required"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.manager.Revoke(ctx, keyID); err != nil {
+		if errors.Is(err, apikey.ErrKeyNotFound) {
+			utils.WriteError(w, http.StatusNotFound, err)
+			return
+		}
+		log.Printf("Failed to revoke API key %s: %v", keyID, err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to revoke api key"))
+		return
+	}
+
+	revokedBy, _ := middleware.GetUserIDFromContext(r.Context())
+	log.Printf("API key %s revoked by %s", keyID, revokedBy)
+
+	w.WriteHeader(http.StatusNoContent)
+}
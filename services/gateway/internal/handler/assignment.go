@@ -0,0 +1,213 @@
+// services/gateway/internal/handler/assignment.go
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/grpc/status"
+)
+
+// AssignmentHandler handles HTTP requests that span drivers and vehicles, such as pairing
+// them, which need both the staff service (drivers) and the vehicle service (assignments)
+type AssignmentHandler struct {
+	vehicleClient vehicleproto.VehicleServiceClient
+	staffClient   staffproto.StaffServiceClient
+	resultCap     ResultCap
+}
+
+// NewAssignmentHandler creates a new assignment handler
+func NewAssignmentHandler(vehicleClient vehicleproto.VehicleServiceClient, staffClient staffproto.StaffServiceClient, resultCap ResultCap) *AssignmentHandler {
+	return &AssignmentHandler{
+		vehicleClient: vehicleClient,
+		staffClient:   staffClient,
+		resultCap:     resultCap,
+	}
+}
+
+// assignmentCSVColumns are the columns an assignment import file carries; both are required
+var assignmentCSVColumns = []string{
+	"driver_id",
+	"vehicle_id",
+}
+
+// assignmentImportResult is streamed back to the client once per CSV row
+type assignmentImportResult struct {
+	Line         int    `json:"line"`
+	DriverID     string `json:"driver_id,omitempty"`
+	VehicleID    string `json:"vehicle_id,omitempty"`
+	AssignmentID string `json:"assignment_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// assignmentImportSummary is written as the final line of an import response.
+// Truncated is set when the file had more rows than the gateway's result cap;
+// rows past the cap were not assigned.
+type assignmentImportSummary struct {
+	Total     int  `json:"total"`
+	Assigned  int  `json:"assigned"`
+	Failed    int  `json:"failed"`
+	Truncated bool `json:"truncated"`
+}
+
+// HandleImportAssignmentsCSV handles POST requests that assign vehicles to drivers from a
+// CSV of driver_id,vehicle_id rows, e.g. for a shift rotation. Each row is checked and
+// assigned on its own, and its outcome is streamed back as a line of newline-delimited
+// JSON; a failed row doesn't stop the rows after it.
+func (h *AssignmentHandler) HandleImportAssignmentsCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("missing request body"))
+		return
+	}
+	defer r.Body.Close()
+
+	reader := csv.NewReader(bufio.NewReader(r.Body))
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			utils.WriteError(w, http.StatusBadRequest, errors.New("csv file is empty"))
+			return
+		}
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read csv header: %w", err))
+		return
+	}
+
+	columns, err := parseCSVHeader(header, assignmentCSVColumns, assignmentCSVColumns)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	// Rows must match the header width; let the csv reader enforce it
+	reader.FieldsPerRecord = len(header)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	var summary assignmentImportSummary
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if h.resultCap.Exceeded(summary.Total + 1) {
+			summary.Truncated = true
+			break
+		}
+
+		var result assignmentImportResult
+		summary.Total++
+
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			// A malformed quote leaves the reader in an unknown state, so stop here
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				result.Line = parseErr.Line
+			}
+			result.Error = err.Error()
+			summary.Failed++
+			encoder.Encode(result)
+			break
+		}
+
+		result.Line, _ = reader.FieldPos(0)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.DriverID = strings.TrimSpace(record[columns["driver_id"]])
+			result.VehicleID = strings.TrimSpace(record[columns["vehicle_id"]])
+			result.AssignmentID, result.Error = h.assignRow(r.Context(), result.DriverID, result.VehicleID)
+		}
+
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Assigned++
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			// Client went away; nothing more we can report
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	encoder.Encode(struct {
+		Summary assignmentImportSummary `json:"summary"`
+	}{Summary: summary})
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// assignRow checks one driver-vehicle pair and assigns it, returning the new assignment
+// ID or a message describing why the row failed. The vehicle must be ACTIVE and the driver
+// eligible for its type; AssignVehicle then re-checks the vehicle under lock, so a vehicle
+// taken since the check (or by an earlier row) is still refused.
+func (h *AssignmentHandler) assignRow(ctx context.Context, driverID, vehicleID string) (string, string) {
+	if _, err := uuid.FromString(driverID); err != nil {
+		return "", fmt.Sprintf("invalid driver_id: %q", driverID)
+	}
+	if _, err := uuid.FromString(vehicleID); err != nil {
+		return "", fmt.Sprintf("invalid vehicle_id: %q", vehicleID)
+	}
+
+	rowCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	vehicleResp, err := h.vehicleClient.GetVehicle(rowCtx, &vehicleproto.GetVehicleRequest{VehicleId: vehicleID})
+	if err != nil {
+		return "", "vehicle: " + grpcErrorMessage(err)
+	}
+	vehicle := vehicleResp.GetVehicle()
+	if vehicle.GetStatus() != vehicleproto.VehicleStatus_ACTIVE {
+		return "", fmt.Sprintf("vehicle is %s, not available", vehicle.GetStatus().String())
+	}
+
+	eligibility, err := h.staffClient.CheckDriverEligibility(rowCtx, &staffproto.CheckDriverEligibilityRequest{
+		DriverId:    driverID,
+		VehicleType: vehicle.GetVehicleTypeName(),
+	})
+	if err != nil {
+		return "", "driver: " + grpcErrorMessage(err)
+	}
+	if !eligibility.GetEligible() {
+		return "", "driver is not eligible: " + strings.Join(eligibility.GetReasons(), "; ")
+	}
+
+	assignResp, err := h.vehicleClient.AssignVehicle(rowCtx, &vehicleproto.AssignVehicleRequest{
+		VehicleId: vehicleID,
+		DriverId:  driverID,
+	})
+	if err != nil {
+		return "", grpcErrorMessage(err)
+	}
+
+	return assignResp.GetAssignment().GetId(), ""
+}
+
+// grpcErrorMessage returns the status message of a gRPC error, or the error text otherwise
+func grpcErrorMessage(err error) string {
+	if st, ok := status.FromError(err); ok {
+		return st.Message()
+	}
+	return err.Error()
+}
@@ -13,9 +13,12 @@ import (
 
 	"github.com/adammwaniki/bebabeba/services/auth/authn/jwt"
 	"github.com/adammwaniki/bebabeba/services/auth/authn/passwords"
+	"github.com/adammwaniki/bebabeba/services/auth/lockout"
+	"github.com/adammwaniki/bebabeba/services/auth/passwordreset"
 	"github.com/adammwaniki/bebabeba/services/auth/session"
 	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,10 +28,23 @@ import (
 // AuthHandler handles authentication-related HTTP requests with session management
 type AuthHandler struct {
 	userClient     userproto.UserServiceClient
+	staffClient    staffproto.StaffServiceClient
 	sessionManager *session.SessionManager
 	jwtService     *jwt.JWTService
+	profileCache   *ProfileCache
+	resetManager   *passwordreset.Manager // nil leaves the forgot/reset password endpoints disabled
+	resetSender    PasswordResetSender
+	lockouts       *lockout.Manager // nil leaves password login without lockout
 }
 
+// errInvalidCredentials is the one answer given for an unknown email, an SSO account and a
+// wrong password, so a failed login doesn't reveal whether or how an account exists
+var errInvalidCredentials = errors.New("invalid email or password")
+
+// verifyDummy burns the time of a password check when there is no hash to check, so
+// unknown and SSO accounts answer as slowly as a wrong password. Tests replace it.
+var verifyDummy = passwords.VerifyDummy
+
 // LoginRequest represents the request payload for password-based login
 type LoginRequest struct {
 	Email    string `json:"email"`
@@ -49,13 +65,17 @@ type LogoutRequest struct {
 // NewAuthHandler creates a new authentication handler with session management
 func NewAuthHandler(
 	userClient userproto.UserServiceClient,
+	staffClient staffproto.StaffServiceClient,
 	sessionManager *session.SessionManager,
 	jwtService *jwt.JWTService,
+	profileCache *ProfileCache,
 ) *AuthHandler {
 	return &AuthHandler{
 		userClient:     userClient,
+		staffClient:    staffClient,
 		sessionManager: sessionManager,
 		jwtService:     jwtService,
+		profileCache:   profileCache,
 	}
 }
 
@@ -85,13 +105,28 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
+	// Refuse emails locked out after repeated failures, before any password is checked
+	if h.lockouts != nil {
+		lockedUntil, err := h.lockouts.LockedUntil(ctx, lockoutEmail(loginReq.Email))
+		if err != nil {
+			log.Printf("Failed to check login lockout: %v", err)
+			utils.WriteError(w, http.StatusInternalServerError, errors.New("authentication service unavailable"))
+			return
+		}
+		if !lockedUntil.IsZero() {
+			writeLockedOut(w, lockedUntil)
+			return
+		}
+	}
+
 	// Get user authentication data
 	authReq := &userproto.GetUserForAuthRequest{Email: loginReq.Email}
 	authResp, err := h.userClient.GetUserForAuth(ctx, authReq)
 	if err != nil {
 		st, ok := status.FromError(err)
 		if ok && st.Code() == codes.NotFound {
-			utils.WriteError(w, http.StatusUnauthorized, errors.New("invalid email or password"))
+			verifyDummy(loginReq.Password)
+			h.loginFailed(ctx, w, loginReq.Email)
 			return
 		}
 		log.Printf("GetUserForAuth failed: %v", err)
@@ -99,15 +134,10 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if user is active
-	if authResp.Status != userproto.UserStatusEnum_ACTIVE {
-		utils.WriteError(w, http.StatusForbidden, errors.New("user account is not active"))
-		return
-	}
-
-	// Check if user has password hash (not SSO user)
+	// SSO accounts have no password to check
 	if authResp.PasswordHash == "" {
-		utils.WriteError(w, http.StatusBadRequest, errors.New("this account uses SSO authentication. Please use Google login"))
+		verifyDummy(loginReq.Password)
+		h.loginFailed(ctx, w, loginReq.Email)
 		return
 	}
 
@@ -120,7 +150,21 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !passwordMatch {
-		utils.WriteError(w, http.StatusUnauthorized, errors.New("invalid email or password"))
+		h.loginFailed(ctx, w, loginReq.Email)
+		return
+	}
+
+	// The right password ends the run of failures
+	if h.lockouts != nil {
+		if err := h.lockouts.Reset(ctx, lockoutEmail(loginReq.Email)); err != nil {
+			log.Printf("Failed to reset login lockout: %v", err)
+		}
+	}
+
+	// Check if user is active. This comes after the password check so that only the
+	// account holder learns the account is suspended or closed.
+	if authResp.Status != userproto.UserStatusEnum_ACTIVE {
+		utils.WriteError(w, http.StatusForbidden, errors.New("user account is not active"))
 		return
 	}
 
@@ -148,6 +192,11 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Login tracking is best effort; a failure here must not block the login
+	if _, err := h.userClient.RecordLogin(ctx, &userproto.RecordLoginRequest{UserId: userResp.Id}); err != nil {
+		log.Printf("Failed to record login for user %s: %v", userResp.Id, err)
+	}
+
 	// Return successful login response with session info
 	response := struct {
 		User         *userproto.GetUserResponse `json:"user"`
@@ -174,11 +223,20 @@ func (h *AuthHandler) HandleRefresh(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
-	// Parse refresh request
+	// Parse refresh request. Browsers signed in through Google SSO send the refresh
+	// token as a cookie instead, so an empty body is allowed when the cookie is present.
 	var refreshReq RefreshRequest
-	if err := json.Unmarshal(body, &refreshReq); err != nil {
-		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
-		return
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &refreshReq); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+			return
+		}
+	}
+
+	fromCookie := false
+	if refreshReq.RefreshToken == "" {
+		refreshReq.RefreshToken = refreshTokenFromCookie(r)
+		fromCookie = refreshReq.RefreshToken != ""
 	}
 
 	if refreshReq.RefreshToken == "" {
@@ -193,10 +251,18 @@ func (h *AuthHandler) HandleRefresh(w http.ResponseWriter, r *http.Request) {
 	// Refresh session
 	sessionResp, err := h.sessionManager.RefreshSession(ctx, refreshReq.RefreshToken, r)
 	if err != nil {
+		if fromCookie {
+			clearRefreshTokenCookie(w)
+		}
 		utils.WriteError(w, http.StatusUnauthorized, fmt.Errorf("failed to refresh session: %w", err))
 		return
 	}
 
+	// Refreshing rotates the refresh token, so the cookie has to follow it
+	if fromCookie {
+		setRefreshTokenCookie(w, sessionResp.TokenData.RefreshToken, sessionResp.Session.ExpiresAt)
+	}
+
 	log.Printf("Session %s refreshed for user %s", sessionResp.Session.ID, sessionResp.Session.UserID)
 	utils.WriteJSON(w, http.StatusOK, sessionResp.TokenData)
 }
@@ -248,6 +314,7 @@ func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		log.Printf("All sessions ended for user %s", claims.UserID)
+		clearRefreshTokenCookie(w)
 		utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "Logged out from all devices successfully"})
 		return
 	}
@@ -260,10 +327,13 @@ func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Printf("Session ended for user %s", claims.UserID)
+	clearRefreshTokenCookie(w)
 	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "Logged out successfully"})
 }
 
-// HandleProfile handles GET requests to return current user's profile
+// HandleProfile handles GET requests to return current user's profile.
+// The profile is cached per session; ?expand=driver also reports whether the
+// user has a driver profile and includes it when present.
 func (h *AuthHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 	// Extract user claims from context (set by auth middleware)
 	claims, ok := middleware.GetClaimsFromContext(r.Context())
@@ -271,20 +341,63 @@ func (h *AuthHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
 		utils.WriteError(w, http.StatusUnauthorized, errors.New("user not authenticated"))
 		return
 	}
+	sessionID, _ := middleware.GetSessionIDFromContext(r.Context())
 
 	// Set context with timeout for gRPC call
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	// Get full user details
-	userReq := &userproto.GetUserRequest{UserId: claims.UserID}
-	userResp, err := h.userClient.GetUserByID(ctx, userReq)
+	// Get full user details, by ID straight from the token claims
+	userResp, cached := h.profileCache.Get(sessionID)
+	if !cached {
+		var err error
+		userResp, err = h.userClient.GetUserByID(ctx, &userproto.GetUserRequest{UserId: claims.UserID})
+		if err != nil {
+			utils.HandleGRPCError(w, err)
+			return
+		}
+		h.profileCache.Set(sessionID, claims.UserID, userResp)
+	}
+
+	if r.URL.Query().Get("expand") != "driver" {
+		utils.WriteProtoJSON(w, http.StatusOK, userResp)
+		return
+	}
+
+	// A missing driver profile is a normal answer here, not an error
+	var driver *staffproto.Driver
+	driverResp, err := h.staffClient.GetDriverByUserID(ctx, &staffproto.GetDriverByUserIDRequest{UserId: claims.UserID})
 	if err != nil {
-		utils.HandleGRPCError(w, err)
+		if status.Code(err) != codes.NotFound {
+			utils.HandleGRPCError(w, err)
+			return
+		}
+	} else {
+		driver = driverResp.GetDriver()
+	}
+
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+	userJSON, err := marshaler.Marshal(userResp)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal user profile: %w", err))
 		return
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, userResp)
+	profile := map[string]any{
+		"user":             json.RawMessage(userJSON),
+		"hasDriverProfile": driver != nil,
+		"driver":           nil,
+	}
+	if driver != nil {
+		driverJSON, err := marshaler.Marshal(driver)
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal driver profile: %w", err))
+			return
+		}
+		profile["driver"] = json.RawMessage(driverJSON)
+	}
+
+	utils.WriteJSON(w, http.StatusOK, profile)
 }
 
 // HandleGetSessions handles GET requests to return user's active sessions
@@ -308,6 +421,11 @@ func (h *AuthHandler) HandleGetSessions(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// A nil slice would encode as null; clients expect an empty array
+	if sessions == nil {
+		sessions = []*session.Session{}
+	}
+
 	utils.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"sessions": sessions,
 		"count":    len(sessions),
@@ -0,0 +1,258 @@
+// services/gateway/internal/handler/auth_test.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/auth/authn/jwt"
+	"github.com/adammwaniki/bebabeba/services/auth/authn/passwords"
+	"github.com/adammwaniki/bebabeba/services/auth/session"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeUserClient answers GetUserForAuth from a map of accounts keyed by email. Calls
+// to any other method panic on the nil embedded client.
+type fakeUserClient struct {
+	userproto.UserServiceClient
+	accounts map[string]*userproto.AuthUserResponse
+	authReqs []string
+}
+
+func (c *fakeUserClient) GetUserForAuth(ctx context.Context, req *userproto.GetUserForAuthRequest, opts ...grpc.CallOption) (*userproto.AuthUserResponse, error) {
+	c.authReqs = append(c.authReqs, req.Email)
+	account, ok := c.accounts[req.Email]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return account, nil
+}
+
+// erroringUserClient fails every GetUserForAuth call with err
+type erroringUserClient struct {
+	userproto.UserServiceClient
+	err error
+}
+
+func (c *erroringUserClient) GetUserForAuth(context.Context, *userproto.GetUserForAuthRequest, ...grpc.CallOption) (*userproto.AuthUserResponse, error) {
+	return nil, c.err
+}
+
+// countDummyVerifies swaps verifyDummy for a counter for the duration of the test
+func countDummyVerifies(t *testing.T) *int {
+	t.Helper()
+	calls := new(int)
+	original := verifyDummy
+	verifyDummy = func(string) { *calls++ }
+	t.Cleanup(func() { verifyDummy = original })
+	return calls
+}
+
+func postLogin(h *AuthHandler, email, password string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(LoginRequest{Email: email, Password: password})
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(string(body)))
+	rec := httptest.NewRecorder()
+	h.HandleLogin(rec, req)
+	return rec
+}
+
+func TestHandleLoginFailures(t *testing.T) {
+	hash, err := passwords.HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	client := &fakeUserClient{accounts: map[string]*userproto.AuthUserResponse{
+		"active@example.com":    {Id: "u1", PasswordHash: hash, Status: userproto.UserStatusEnum_ACTIVE},
+		"suspended@example.com": {Id: "u2", PasswordHash: hash, Status: userproto.UserStatusEnum_SUSPENDED},
+		"closed@example.com":    {Id: "u3", PasswordHash: hash, Status: userproto.UserStatusEnum_CLOSED},
+		"sso@example.com":       {Id: "u4", Status: userproto.UserStatusEnum_ACTIVE},
+	}}
+	h := &AuthHandler{userClient: client}
+
+	tests := []struct {
+		name        string
+		email       string
+		password    string
+		wantStatus  int
+		wantMessage string
+		wantDummy   bool
+	}{
+		{
+			name:        "wrong password",
+			email:       "active@example.com",
+			password:    "wrong",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: errInvalidCredentials.Error(),
+		},
+		{
+			name:        "unknown user does a dummy verify",
+			email:       "nobody@example.com",
+			password:    "wrong",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: errInvalidCredentials.Error(),
+			wantDummy:   true,
+		},
+		{
+			name:        "SSO account does a dummy verify",
+			email:       "sso@example.com",
+			password:    "wrong",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: errInvalidCredentials.Error(),
+			wantDummy:   true,
+		},
+		{
+			// The status check comes after the password check, so a stranger can't
+			// learn that the account is suspended
+			name:        "inactive user with wrong password",
+			email:       "suspended@example.com",
+			password:    "wrong",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: errInvalidCredentials.Error(),
+		},
+		{
+			name:        "inactive user with right password",
+			email:       "suspended@example.com",
+			password:    "correct horse battery staple",
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "user account is not active",
+		},
+		{
+			name:        "closed user with right password",
+			email:       "closed@example.com",
+			password:    "correct horse battery staple",
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "user account is not active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dummyCalls := countDummyVerifies(t)
+
+			rec := postLogin(h, tt.email, tt.password)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			var resp utils.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if resp.Error.Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", resp.Error.Message, tt.wantMessage)
+			}
+			if got := *dummyCalls == 1; got != tt.wantDummy {
+				t.Errorf("dummy verifies = %d, want dummy verify %t", *dummyCalls, tt.wantDummy)
+			}
+		})
+	}
+}
+
+func TestHandleLoginUserServiceError(t *testing.T) {
+	dummyCalls := countDummyVerifies(t)
+	client := &erroringUserClient{err: status.Error(codes.Unavailable, "connection refused")}
+	h := &AuthHandler{userClient: client}
+
+	rec := postLogin(h, "active@example.com", "whatever")
+
+	// An outage isn't reported as bad credentials
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if *dummyCalls != 0 {
+		t.Errorf("dummy verifies = %d, want 0", *dummyCalls)
+	}
+}
+
+func TestHandleLoginMissingFields(t *testing.T) {
+	client := &fakeUserClient{}
+	h := &AuthHandler{userClient: client}
+
+	for _, body := range []LoginRequest{{Email: "active@example.com"}, {Password: "secret"}} {
+		rec := postLogin(h, body.Email, body.Password)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%+v: status = %d, want %d", body, rec.Code, http.StatusBadRequest)
+		}
+	}
+	if len(client.authReqs) != 0 {
+		t.Errorf("user service was called for an incomplete request: %v", client.authReqs)
+	}
+}
+
+func TestHandleGetSessionsJSON(t *testing.T) {
+	sessionColumns := []string{"session_id", "user_id", "access_token_id", "refresh_token_id", "user_agent",
+		"ip_address", "created_at", "last_accessed_at", "expires_at", "is_active"}
+	now := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		rows         *sqlmock.Rows
+		wantSessions string // raw JSON of the sessions field
+		wantCount    int
+	}{
+		// Clients iterate over sessions, so no sessions must still be an array
+		{name: "no sessions", rows: sqlmock.NewRows(sessionColumns), wantSessions: "[]", wantCount: 0},
+		{
+			name: "one session",
+			rows: sqlmock.NewRows(sessionColumns).
+				AddRow("session-1", "user-1", "access-1", "refresh-1", "curl/8.5", "10.0.0.1", now, now, now.Add(time.Hour), true),
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			defer db.Close()
+			mock.ExpectQuery(regexp.QuoteMeta("FROM user_sessions WHERE user_id = ? AND is_active = true")).
+				WithArgs("user-1").
+				WillReturnRows(tt.rows)
+
+			h := &AuthHandler{sessionManager: session.NewSessionManager(db, nil)}
+			req := httptest.NewRequest(http.MethodGet, "/auth/sessions", nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.UserClaimsKey, &jwt.Claims{UserID: "user-1"}))
+			rec := httptest.NewRecorder()
+			h.HandleGetSessions(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var body struct {
+				Sessions json.RawMessage `json:"sessions"`
+				Count    int             `json:"count"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if tt.wantSessions != "" && string(body.Sessions) != tt.wantSessions {
+				t.Errorf("sessions = %s, want %s", body.Sessions, tt.wantSessions)
+			}
+			var sessions []map[string]any
+			if err := json.Unmarshal(body.Sessions, &sessions); err != nil || sessions == nil {
+				t.Errorf("sessions = %s, want an array", body.Sessions)
+			}
+			if len(sessions) != tt.wantCount || body.Count != tt.wantCount {
+				t.Errorf("got %d sessions with count %d, want %d", len(sessions), body.Count, tt.wantCount)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// services/gateway/internal/handler/cookie.go
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+// refreshTokenCookie carries the refresh token for browser sign-ins that end in a
+// redirect, such as Google SSO, where there is no response body for the client to read.
+// It is scoped to the auth endpoints, so it only travels with refresh and logout calls.
+const (
+	refreshTokenCookie     = "bebabeba_refresh_token"
+	refreshTokenCookiePath = "/api/v1/auth"
+)
+
+// setRefreshTokenCookie stores the refresh token in a Secure, HttpOnly cookie that lasts as long as the session
+func setRefreshTokenCookie(w http.ResponseWriter, token string, expiresAt time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    token,
+		Path:     refreshTokenCookiePath,
+		Expires:  expiresAt,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// clearRefreshTokenCookie tells the browser to drop the refresh token cookie
+func clearRefreshTokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    "",
+		Path:     refreshTokenCookiePath,
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// refreshTokenFromCookie returns the refresh token cookie's value, or "" if it wasn't sent
+func refreshTokenFromCookie(r *http.Request) string {
+	cookie, err := r.Cookie(refreshTokenCookie)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
@@ -0,0 +1,160 @@
+// services/gateway/internal/handler/driver_profile.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// driverProfileTimeout bounds the whole fan-out, so one slow service can't hold up the
+// response beyond it; whatever hasn't arrived by then is reported as unavailable
+const driverProfileTimeout = 5 * time.Second
+
+// driverProfileCertificationsPageSize is how many certifications the profile carries.
+// Any beyond it are paged through GET /transport/drivers/{id}/certifications.
+const driverProfileCertificationsPageSize = 50
+
+// HandleGetDriverProfile handles GET requests for everything a driver page shows: the
+// driver, their certifications and the vehicle they currently hold, fetched concurrently.
+// Only the driver is required. When the certifications or the assignment can't be fetched
+// the profile is still returned, with that section null and the reason under "errors".
+// A driver without a vehicle gets null vehicle and assignment and no error.
+func (h *AssignmentHandler) HandleGetDriverProfile(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	_, err := uuid.FromString(driverIDStr)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	// One timeout shared by every call
+	ctx, cancel := context.WithTimeout(r.Context(), driverProfileTimeout)
+	defer cancel()
+
+	var (
+		driverResp         *staffproto.GetDriverResponse
+		certificationsResp *staffproto.ListDriverCertificationsResponse
+		assignmentResp     *vehicleproto.GetDriverAssignmentResponse
+
+		mu            sync.Mutex
+		sectionErrors = make(map[string]string)
+	)
+	// Optional sections record their failure and return nil, so only a failed driver
+	// fetch fails the group and cancels the calls still running
+	degrade := func(section string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		sectionErrors[section] = grpcErrorMessage(err)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		resp, err := h.staffClient.GetDriver(gctx, &staffproto.GetDriverRequest{DriverId: driverIDStr})
+		if err != nil {
+			return err
+		}
+		driverResp = resp
+		return nil
+	})
+	g.Go(func() error {
+		resp, err := h.staffClient.ListDriverCertifications(gctx, &staffproto.ListDriverCertificationsRequest{
+			DriverId: driverIDStr,
+			PageSize: driverProfileCertificationsPageSize,
+		})
+		if err != nil {
+			degrade("certifications", err)
+			return nil
+		}
+		certificationsResp = resp
+		return nil
+	})
+	g.Go(func() error {
+		resp, err := h.vehicleClient.GetDriverAssignment(gctx, &vehicleproto.GetDriverAssignmentRequest{DriverId: driverIDStr})
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				degrade("vehicle", err)
+			}
+			return nil
+		}
+		assignmentResp = resp
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+	profile := map[string]any{
+		"driver":                      nil,
+		"certifications":              nil,
+		"certificationsNextPageToken": "",
+		"vehicle":                     nil,
+		"assignment":                  nil,
+		"errors":                      sectionErrors,
+	}
+
+	sections := map[string]proto.Message{"driver": driverResp.GetDriver()}
+	if certificationsResp != nil {
+		certificationsJSON, err := marshalRepeatedField(marshaler, certificationsResp, "certifications")
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal certifications: %w", err))
+			return
+		}
+		profile["certifications"] = certificationsJSON
+		profile["certificationsNextPageToken"] = certificationsResp.GetNextPageToken()
+	}
+	if assignmentResp != nil {
+		sections["vehicle"] = assignmentResp.GetVehicle()
+		sections["assignment"] = assignmentResp.GetAssignment()
+	}
+	for key, msg := range sections {
+		data, err := marshaler.Marshal(msg)
+		if err != nil {
+			utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal %s: %w", key, err))
+			return
+		}
+		profile[key] = json.RawMessage(data)
+	}
+
+	utils.WriteJSON(w, http.StatusOK, profile)
+}
+
+// marshalRepeatedField returns the JSON of the list in msg's field named key. An empty
+// list comes back as [] rather than null.
+func marshalRepeatedField(marshaler protojson.MarshalOptions, msg proto.Message, key string) (json.RawMessage, error) {
+	data, err := marshaler.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	list, ok := body[key]
+	if !ok {
+		return nil, fmt.Errorf("response has no field %q", key)
+	}
+	return list, nil
+}
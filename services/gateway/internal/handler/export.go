@@ -0,0 +1,271 @@
+// services/gateway/internal/handler/export.go
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// vehicleExportHeader is the column order of the vehicle CSV export. Columns may be
+// added at the end but never reordered, so spreadsheets built on the export keep working.
+var vehicleExportHeader = []string{
+	"id",
+	"license_plate",
+	"vehicle_type_id",
+	"vehicle_type_name",
+	"make",
+	"model",
+	"year",
+	"color",
+	"seating_capacity",
+	"fuel_type",
+	"engine_number",
+	"chassis_number",
+	"registration_date",
+	"insurance_expiry",
+	"status",
+	"created_at",
+	"updated_at",
+	"mileage_km",
+}
+
+// driverExportHeader is the column order of the driver CSV export, with the same
+// stability promise as vehicleExportHeader
+var driverExportHeader = []string{
+	"id",
+	"user_id",
+	"license_number",
+	"license_class",
+	"license_expiry",
+	"license_expired",
+	"experience_years",
+	"phone_number",
+	"emergency_contact_name",
+	"emergency_contact_phone",
+	"status",
+	"hire_date",
+	"rating_average",
+	"rating_count",
+	"handbook_version",
+	"created_at",
+	"updated_at",
+}
+
+// csvExport streams a CSV download. Rows are flushed to the client a page at a time,
+// so an export never sits in the gateway's memory as a whole.
+type csvExport struct {
+	writer  *csv.Writer
+	flusher http.Flusher
+}
+
+// newCSVExport sends the download headers, naming the file after name and the current
+// time, and writes the header row
+func newCSVExport(w http.ResponseWriter, name string, header []string) *csvExport {
+	filename := fmt.Sprintf("%s-%s.csv", name, time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	export := &csvExport{writer: csv.NewWriter(w), flusher: flusher}
+	export.writer.Write(header)
+	return export
+}
+
+// Write adds a row; an error means the client has gone away
+func (e *csvExport) Write(record []string) error {
+	return e.writer.Write(record)
+}
+
+// Flush sends the buffered rows to the client
+func (e *csvExport) Flush() error {
+	e.writer.Flush()
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+	return e.writer.Error()
+}
+
+// HandleExportVehiclesCSV handles GET requests for the vehicle inventory as a CSV download.
+// It takes the same filters as HandleListVehicles and pages through ListVehicles until
+// every matching vehicle has been written.
+func (h *VehicleHandler) HandleExportVehiclesCSV(w http.ResponseWriter, r *http.Request) {
+	grpcReq, err := listVehiclesFilters(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	grpcReq.PageSize = pagesize.PublicMax()
+
+	var export *csvExport
+	for {
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		resp, err := h.vehicleClient.ListVehicles(ctx, grpcReq)
+		cancel()
+		if err != nil {
+			if export == nil {
+				utils.HandleGRPCError(w, err)
+				return
+			}
+			// The status line has gone out already; all we can do is end the file early
+			log.Printf("Vehicle CSV export stopped after a failed page: %v", err)
+			export.Flush()
+			return
+		}
+
+		if export == nil {
+			if h.resultCap.Exceeded(int(resp.TotalCount)) {
+				h.resultCap.writeTooLarge(w, int(resp.TotalCount))
+				return
+			}
+			export = newCSVExport(w, "vehicles", vehicleExportHeader)
+		}
+
+		for _, vehicle := range resp.Vehicles {
+			if err := export.Write(vehicleCSVRecord(vehicle)); err != nil {
+				return
+			}
+		}
+		if err := export.Flush(); err != nil {
+			return
+		}
+
+		if resp.NextPageToken == "" {
+			return
+		}
+		grpcReq.PageToken = resp.NextPageToken
+	}
+}
+
+// HandleExportDriversCSV handles GET requests for the driver roster as a CSV download.
+// It takes the same filters as HandleListDrivers and pages through ListDrivers until
+// every matching driver has been written. Contact details are redacted unless the
+// caller is an admin.
+func (h *StaffHandler) HandleExportDriversCSV(w http.ResponseWriter, r *http.Request) {
+	role, _ := middleware.GetRoleFromContext(r.Context())
+	grpcReq := listDriversFilters(r)
+	grpcReq.PageSize = pagesize.PublicMax()
+
+	var export *csvExport
+	for {
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		resp, err := h.staffClient.ListDrivers(ctx, grpcReq)
+		cancel()
+		if err != nil {
+			if export == nil {
+				utils.HandleGRPCError(w, err)
+				return
+			}
+			// The status line has gone out already; all we can do is end the file early
+			log.Printf("Driver CSV export stopped after a failed page: %v", err)
+			export.Flush()
+			return
+		}
+
+		if export == nil {
+			if h.resultCap.Exceeded(int(resp.TotalCount)) {
+				h.resultCap.writeTooLarge(w, int(resp.TotalCount))
+				return
+			}
+			export = newCSVExport(w, "drivers", h.redaction.Columns(role, driverExportHeader))
+		}
+
+		for _, driver := range resp.Drivers {
+			record := h.redaction.Row(role, driverExportHeader, driverCSVRecord(driver))
+			if err := export.Write(record); err != nil {
+				return
+			}
+		}
+		if err := export.Flush(); err != nil {
+			return
+		}
+
+		if resp.NextPageToken == "" {
+			return
+		}
+		grpcReq.PageToken = resp.NextPageToken
+	}
+}
+
+// vehicleCSVRecord renders a vehicle in vehicleExportHeader order
+func vehicleCSVRecord(v *vehicleproto.Vehicle) []string {
+	return []string{
+		v.GetId(),
+		v.GetLicensePlate(),
+		v.GetVehicleTypeId(),
+		v.GetVehicleTypeName(),
+		v.GetMake(),
+		v.GetModel(),
+		strconv.Itoa(int(v.GetYear())),
+		v.GetColor(),
+		strconv.Itoa(int(v.GetSeatingCapacity())),
+		v.GetFuelType().String(),
+		v.GetEngineNumber(),
+		v.GetChassisNumber(),
+		csvDate(v.GetRegistrationDate()),
+		csvDate(v.GetInsuranceExpiry()),
+		v.GetStatus().String(),
+		csvTimestamp(v.GetCreatedAt()),
+		csvTimestamp(v.GetUpdatedAt()),
+		csvMileage(v),
+	}
+}
+
+// csvMileage formats the vehicle's latest odometer reading, empty until one is recorded
+func csvMileage(v *vehicleproto.Vehicle) string {
+	if v.MileageKm == nil {
+		return ""
+	}
+	return strconv.Itoa(int(v.GetMileageKm()))
+}
+
+// driverCSVRecord renders a driver in driverExportHeader order
+func driverCSVRecord(d *staffproto.Driver) []string {
+	return []string{
+		d.GetId(),
+		d.GetUserId(),
+		d.GetLicenseNumber(),
+		d.GetLicenseClass().String(),
+		csvDate(d.GetLicenseExpiry()),
+		strconv.FormatBool(d.GetLicenseExpired()),
+		strconv.Itoa(int(d.GetExperienceYears())),
+		d.GetPhoneNumber(),
+		d.GetEmergencyContactName(),
+		d.GetEmergencyContactPhone(),
+		d.GetStatus().String(),
+		csvDate(d.GetHireDate()),
+		strconv.FormatFloat(d.GetRatingAverage(), 'f', 2, 64),
+		strconv.Itoa(int(d.GetRatingCount())),
+		d.GetHandbookVersion(),
+		csvTimestamp(d.GetCreatedAt()),
+		csvTimestamp(d.GetUpdatedAt()),
+	}
+}
+
+// csvDate formats a calendar date the way the CSV import reads it, empty when unset
+func csvDate(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format("2006-01-02")
+}
+
+// csvTimestamp formats a point in time as RFC 3339 in UTC, empty when unset
+func csvTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,92 @@
+// services/gateway/internal/handler/export_test.go
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	"google.golang.org/grpc"
+)
+
+// rosterStaffClient serves a fixed driver roster as a single page
+type rosterStaffClient struct {
+	staffproto.StaffServiceClient
+	drivers []*staffproto.Driver
+}
+
+func (c *rosterStaffClient) ListDrivers(ctx context.Context, req *staffproto.ListDriversRequest, opts ...grpc.CallOption) (*staffproto.ListDriversResponse, error) {
+	return &staffproto.ListDriversResponse{Drivers: c.drivers, TotalCount: int32(len(c.drivers))}, nil
+}
+
+// testDriver has every contact field filled in
+func testDriver() *staffproto.Driver {
+	return &staffproto.Driver{
+		Id:                    "driver-1",
+		UserId:                "user-1",
+		LicenseNumber:         "DL-12345678",
+		PhoneNumber:           "+254712345678",
+		EmergencyContactName:  "Jane Wanjiru",
+		EmergencyContactPhone: "+254798765432",
+	}
+}
+
+func TestHandleExportDriversCSVRedaction(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		wantColumns int
+		wantPhone   string
+		wantHidden  bool // emergency contact columns left out
+	}{
+		{name: "admin", role: middleware.RoleAdmin, wantColumns: len(driverExportHeader), wantPhone: "+254712345678"},
+		{name: "staff", role: middleware.RoleStaff, wantColumns: len(driverExportHeader) - 2, wantPhone: "***678", wantHidden: true},
+		{name: "no role", wantColumns: len(driverExportHeader) - 2, wantPhone: "***678", wantHidden: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStaffHandler(&rosterStaffClient{drivers: []*staffproto.Driver{testDriver()}}, nil, DefaultResultCap)
+
+			req := httptest.NewRequest(http.MethodGet, "/transport/drivers/export", nil)
+			if tt.role != "" {
+				req = withRole(req, tt.role)
+			}
+			rec := httptest.NewRecorder()
+			h.HandleExportDriversCSV(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			records, err := csv.NewReader(rec.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("failed to read CSV: %v", err)
+			}
+			if len(records) != 2 {
+				t.Fatalf("got %d records, want a header and one driver", len(records))
+			}
+			header, row := records[0], records[1]
+			if len(header) != tt.wantColumns || len(row) != tt.wantColumns {
+				t.Fatalf("header has %d columns and row %d, want %d", len(header), len(row), tt.wantColumns)
+			}
+
+			phone := slices.Index(header, "phone_number")
+			if phone < 0 || row[phone] != tt.wantPhone {
+				t.Errorf("phone_number = %q, want %q", row[phone], tt.wantPhone)
+			}
+			for _, column := range []string{"emergency_contact_name", "emergency_contact_phone"} {
+				if hidden := !slices.Contains(header, column); hidden != tt.wantHidden {
+					t.Errorf("%s left out = %t, want %t", column, hidden, tt.wantHidden)
+				}
+			}
+			if row[slices.Index(header, "license_number")] != "DL-12345678" {
+				t.Errorf("license_number = %q, want it exported as-is", row[slices.Index(header, "license_number")])
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// services/gateway/internal/handler/fields.go
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/adammwaniki/bebabeba/services/common/fieldmask"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/redact"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// parseFields reads the ?fields= selection for responses carrying msg, e.g.
+// ?fields=id,license_plate,status. A missing parameter returns a nil mask.
+func parseFields(r *http.Request, msg proto.Message) (*fieldmaskpb.FieldMask, error) {
+	return fieldmask.Parse(r.URL.Query().Get("fields"), msg)
+}
+
+// writeProtoJSONFields writes resp like utils.WriteProtoJSON, projecting the message (or
+// list of messages) in its field named key down to mask. The rest of resp, such as
+// next_page_token, is left alone.
+//
+// WriteProtoJSON emits unpopulated fields, so clearing fields from the proto alone would
+// still send them as zero values; the cleared keys are dropped from the JSON as well.
+func writeProtoJSONFields(w http.ResponseWriter, status int, resp proto.Message, key string, mask *fieldmaskpb.FieldMask) {
+	writeRedactedProtoJSON(w, status, resp, key, mask, nil, "")
+}
+
+// writeRedactedProtoJSON is writeProtoJSONFields for responses carrying PII: after the
+// projection, policy redacts the JSON for a caller with role.
+func writeRedactedProtoJSON(w http.ResponseWriter, status int, resp proto.Message, key string, mask *fieldmaskpb.FieldMask, policy *redact.Policy, role string) {
+	m := resp.ProtoReflect()
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(key))
+	project := mask != nil && fd != nil && fd.Message() != nil
+	if !project && !policy.Applies(role) {
+		utils.WriteProtoJSON(w, status, resp)
+		return
+	}
+
+	if project {
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for i := 0; i < list.Len(); i++ {
+				fieldmask.Apply(mask, list.Get(i).Message().Interface())
+			}
+		} else if m.Has(fd) {
+			fieldmask.Apply(mask, m.Get(fd).Message().Interface())
+		}
+	}
+
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+	data, err := marshaler.Marshal(resp)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal protobuf response: %w", err))
+		return
+	}
+
+	// UseNumber keeps numeric values exactly as protojson wrote them
+	var body map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to project response fields: %w", err))
+		return
+	}
+
+	if project {
+		projectJSON(body[fd.JSONName()], fieldmask.JSONNames(mask, fd.Message()))
+	}
+	policy.Object(role, body)
+
+	utils.RewriteTimestamps(body, m.Descriptor(), utils.TimeFormatOf(w))
+	utils.WriteJSON(w, status, body)
+}
+
+// projectJSON drops every key but names from a decoded message, or from each message
+// in a decoded list
+func projectJSON(value any, names []string) {
+	keep := make(map[string]bool)
+	for _, name := range names {
+		keep[name] = true
+	}
+	project := func(item any) {
+		if obj, ok := item.(map[string]any); ok {
+			for name := range obj {
+				if !keep[name] {
+					delete(obj, name)
+				}
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			project(item)
+		}
+	default:
+		project(v)
+	}
+}
@@ -0,0 +1,21 @@
+// services/gateway/internal/handler/idempotency.go
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+)
+
+// withIdempotencyKey forwards the request's Idempotency-Key header, if any, to the
+// gRPC call made with the returned context. A retried create carrying the same key
+// gets back the resource the first attempt created instead of a duplicate.
+func withIdempotencyKey(ctx context.Context, r *http.Request) (context.Context, error) {
+	key := strings.TrimSpace(r.Header.Get(idempotency.HeaderName))
+	if err := idempotency.Validate(key); err != nil {
+		return nil, err
+	}
+	return idempotency.NewOutgoingContext(ctx, key), nil
+}
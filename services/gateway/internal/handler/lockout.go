@@ -0,0 +1,116 @@
+// services/gateway/internal/handler/lockout.go
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/lockout"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+)
+
+// loginLockoutResponse reports an email's failed logins to support
+type loginLockoutResponse struct {
+	lockout.Status
+	Locked bool `json:"locked"`
+}
+
+// SetLoginLockout enables locking emails out of password login after repeated failures
+func (h *AuthHandler) SetLoginLockout(manager *lockout.Manager) {
+	h.lockouts = manager
+}
+
+// lockoutEmail normalizes an email the way the user service does, so case variants of an
+// address share one failure count
+func lockoutEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// writeLockedOut answers a login for a locked out email with 429 Too Many Requests and
+// a Retry-After header
+func writeLockedOut(w http.ResponseWriter, lockedUntil time.Time) {
+	seconds := max(1, int(math.Ceil(time.Until(lockedUntil).Seconds())))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	utils.WriteError(w, http.StatusTooManyRequests, fmt.Errorf("too many failed login attempts, retry after %ds", seconds))
+}
+
+// loginFailed counts a failed login for email and writes the response: 401, or 429 when
+// this failure locked the email out
+func (h *AuthHandler) loginFailed(ctx context.Context, w http.ResponseWriter, email string) {
+	if h.lockouts != nil {
+		lockedUntil, err := h.lockouts.RecordFailure(ctx, lockoutEmail(email))
+		if err != nil {
+			log.Printf("Failed to record failed login: %v", err)
+		} else if !lockedUntil.IsZero() {
+			writeLockedOut(w, lockedUntil)
+			return
+		}
+	}
+	utils.WriteError(w, http.StatusUnauthorized, errInvalidCredentials)
+}
+
+// HandleGetLoginLockout handles GET requests from support for the failed logins and
+// lockout of the email given in the email query parameter
+func (h *AuthHandler) HandleGetLoginLockout(w http.ResponseWriter, r *http.Request) {
+	if h.lockouts == nil {
+		utils.WriteError(w, http.StatusServiceUnavailable, errors.New("login lockout is not available"))
+		return
+	}
+
+	email := lockoutEmail(r.URL.Query().Get("email"))
+	if email == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("email query parameter is required"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	status, found, err := h.lockouts.GetStatus(ctx, email)
+	if err != nil {
+		log.Printf("Failed to get login lockout: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to get login lockout"))
+		return
+	}
+	if !found {
+		status = lockout.Status{Email: email}
+	}
+
+	utils.WriteJSON(w, http.StatusOK, loginLockoutResponse{
+		Status: status,
+		Locked: status.Locked(time.Now()),
+	})
+}
+
+// HandleResetLoginLockout handles DELETE requests from support to clear the failed logins
+// and any lockout of the email given in the email query parameter
+func (h *AuthHandler) HandleResetLoginLockout(w http.ResponseWriter, r *http.Request) {
+	if h.lockouts == nil {
+		utils.WriteError(w, http.StatusServiceUnavailable, errors.New("login lockout is not available"))
+		return
+	}
+
+	email := lockoutEmail(r.URL.Query().Get("email"))
+	if email == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("email query parameter is required"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.lockouts.Reset(ctx, email); err != nil {
+		log.Printf("Failed to reset login lockout: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to reset login lockout"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
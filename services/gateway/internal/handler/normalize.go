@@ -0,0 +1,96 @@
+// services/gateway/internal/handler/normalize.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+)
+
+// normalizeTimeout bounds a legacy record normalization run, which walks a whole table
+const normalizeTimeout = 5 * time.Minute
+
+// normalizeRequest is the optional body of the normalization endpoints. dry_run defaults
+// to true, so a bare POST only previews the changes.
+type normalizeRequest struct {
+	DryRun    *bool `json:"dry_run"`
+	BatchSize int32 `json:"batch_size"`
+}
+
+// parseNormalizeRequest reads the body of a normalization request, returning whether it
+// is a dry run and the requested batch size
+func parseNormalizeRequest(r *http.Request) (bool, int32, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to read request body: %w", err)
+	}
+	defer r.Body.Close()
+
+	var normalize normalizeRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &normalize); err != nil {
+			return false, 0, fmt.Errorf("invalid request format: %w", err)
+		}
+	}
+
+	dryRun := true
+	if normalize.DryRun != nil {
+		dryRun = *normalize.DryRun
+	}
+	return dryRun, normalize.BatchSize, nil
+}
+
+// HandleNormalizeLegacyVehicles handles POST requests that re-apply the current
+// normalization rules to every stored vehicle
+func (h *VehicleHandler) HandleNormalizeLegacyVehicles(w http.ResponseWriter, r *http.Request) {
+	dryRun, batchSize, err := parseNormalizeRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), normalizeTimeout)
+	defer cancel()
+
+	resp, err := h.vehicleClient.NormalizeLegacyRecords(ctx, &vehicleproto.NormalizeLegacyRecordsRequest{
+		DryRun:    dryRun,
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleNormalizeLegacyDrivers handles POST requests that re-apply the current
+// normalization rules to every stored driver
+func (h *StaffHandler) HandleNormalizeLegacyDrivers(w http.ResponseWriter, r *http.Request) {
+	dryRun, batchSize, err := parseNormalizeRequest(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), normalizeTimeout)
+	defer cancel()
+
+	resp, err := h.staffClient.NormalizeLegacyRecords(ctx, &staffproto.NormalizeLegacyRecordsRequest{
+		DryRun:    dryRun,
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
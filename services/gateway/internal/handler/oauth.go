@@ -0,0 +1,415 @@
+// services/gateway/internal/handler/oauth.go
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/oauthstate"
+	"github.com/adammwaniki/bebabeba/services/auth/session"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/microsoft"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// oauthUser is what the gateway needs from a provider's profile of the signed-in user
+type oauthUser struct {
+	ID        string // the provider's stable ID for the user
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// oauthProvider describes how to sign a user in with one identity provider
+type oauthProvider struct {
+	displayName     string
+	scopes          []string
+	endpoint        func() oauth2.Endpoint
+	authCodeOptions []oauth2.AuthCodeOption
+	fetchUser       func(ctx context.Context, client *http.Client) (oauthUser, error)
+}
+
+// oauthProviders are the identity providers users can sign in with, keyed by the name
+// used in the /auth/{provider}/login and /auth/{provider}/callback paths
+var oauthProviders = map[string]oauthProvider{
+	"google": {
+		displayName:     "Google",
+		scopes:          []string{"openid", "email", "profile"},
+		endpoint:        func() oauth2.Endpoint { return google.Endpoint },
+		authCodeOptions: []oauth2.AuthCodeOption{oauth2.AccessTypeOffline, oauth2.ApprovalForce}, // Request refresh token
+		fetchUser:       fetchGoogleUser,
+	},
+	"microsoft": {
+		displayName: "Microsoft",
+		scopes:      []string{"openid", "email", "profile", "User.Read"},
+		endpoint:    func() oauth2.Endpoint { return microsoft.AzureADEndpoint(os.Getenv("MICROSOFT_TENANT")) },
+		fetchUser:   fetchMicrosoftUser,
+	},
+	"github": {
+		displayName: "GitHub",
+		scopes:      []string{"read:user", "user:email"},
+		endpoint:    func() oauth2.Endpoint { return github.Endpoint },
+		fetchUser:   fetchGitHubUser,
+	},
+}
+
+// OAuthConfigsFromEnv returns the OAuth config of every provider with a client ID set, read
+// from <PROVIDER>_CLIENT_ID, <PROVIDER>_CLIENT_SECRET and <PROVIDER>_REDIRECT_URL, e.g.
+// GOOGLE_CLIENT_ID. Microsoft also reads MICROSOFT_TENANT, which defaults to "common".
+func OAuthConfigsFromEnv() map[string]*oauth2.Config {
+	configs := make(map[string]*oauth2.Config)
+	for name, provider := range oauthProviders {
+		prefix := strings.ToUpper(name)
+		clientID := os.Getenv(prefix + "_CLIENT_ID")
+		if clientID == "" {
+			continue
+		}
+		configs[name] = &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: os.Getenv(prefix + "_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "_REDIRECT_URL"),
+			Scopes:       provider.scopes,
+			Endpoint:     provider.endpoint(),
+		}
+	}
+
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log.Printf("OAuth providers enabled: %v", names)
+	return configs
+}
+
+// oauthProvider returns the provider named in the request path with its config, or false
+// when it's unknown or not configured
+func (h *UserHandler) oauthProvider(r *http.Request) (string, oauthProvider, *oauth2.Config, bool) {
+	name := r.PathValue("provider")
+	provider, known := oauthProviders[name]
+	config, configured := h.oauthConfigs[name]
+	return name, provider, config, known && configured
+}
+
+// oauthStateKey ties a login's state to its provider, so the callback of one provider
+// can't complete a login started with another
+func oauthStateKey(provider, state string) string {
+	return provider + ":" + state
+}
+
+// oauthSSOID is the SSO ID stored for a provider's user. It's namespaced by provider so
+// two providers handing out the same ID don't share an account.
+func oauthSSOID(provider, id string) string {
+	return provider + ":" + id
+}
+
+// HandleOAuthLogin initiates the OAuth2 login flow with the provider in the path.
+func (h *UserHandler) HandleOAuthLogin(w http.ResponseWriter, r *http.Request) {
+	name, provider, config, ok := h.oauthProvider(r)
+	if !ok {
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("unknown OAuth provider %q", name))
+		return
+	}
+	log.Printf("DEBUG: HandleOAuthLogin initiated for %s.", provider.displayName)
+
+	// Generate a cryptographically secure random state to prevent CSRF attacks.
+	stateBytes := make([]byte, 32)
+	_, err := rand.Read(stateBytes)
+	if err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to generate state: %w", err))
+		return
+	}
+	state := base64.RawURLEncoding.EncodeToString(stateBytes)
+
+	// Store the state with where to send the user once they're signed in
+	if err := h.oauthStates.Put(r.Context(), oauthStateKey(name, state), postLoginRedirect(r.URL.Query().Get("redirect")), oauthstate.DefaultTTL); err != nil {
+		log.Printf("ERROR: Failed to store OAuth state: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to start OAuth login"))
+		return
+	}
+	log.Printf("DEBUG: Generated OAuth state: %s", state)
+
+	// Redirect the user to the provider's consent screen.
+	url := config.AuthCodeURL(state, provider.authCodeOptions...)
+	log.Printf("DEBUG: Redirecting to %s Auth URL: %s", provider.displayName, url)
+	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
+}
+
+// HandleOAuthCallback handles the redirect from the provider in the path after user
+// authorization, signing the user in with JWT and session management. Users signing in
+// for the first time get an account.
+func (h *UserHandler) HandleOAuthCallback(sessionManager *session.SessionManager, w http.ResponseWriter, r *http.Request) {
+	name, provider, config, ok := h.oauthProvider(r)
+	if !ok {
+		utils.WriteError(w, http.StatusNotFound, fmt.Errorf("unknown OAuth provider %q", name))
+		return
+	}
+	log.Printf("DEBUG: HandleOAuthCallback initiated for %s.", provider.displayName)
+
+	spanCtx, span := tracing.Start(r.Context(), "oauth."+name+".callback")
+	defer span.End()
+	r = r.WithContext(spanCtx)
+
+	// Verify the 'state' parameter to prevent CSRF
+	state := r.URL.Query().Get("state")
+	log.Printf("DEBUG: Received state parameter: %s", state)
+	redirectURL, ok, err := h.oauthStates.Consume(r.Context(), oauthStateKey(name, state))
+	if err != nil {
+		log.Printf("ERROR: Failed to consume OAuth state: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to verify OAuth state"))
+		return
+	}
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("invalid or missing OAuth state parameter"))
+		return
+	}
+	log.Println("DEBUG: OAuth state verified and removed.")
+
+	// Check for errors from the provider
+	if authErr := r.URL.Query().Get("error"); authErr != "" {
+		errorDescription := r.URL.Query().Get("error_description")
+		log.Printf("ERROR: OAuth authorization failed from %s: %s - %s", provider.displayName, authErr, errorDescription)
+		utils.WriteError(w, http.StatusUnauthorized, fmt.Errorf("OAuth authorization failed: %s - %s", authErr, errorDescription))
+		return
+	}
+
+	// Get the authorization code
+	code := r.URL.Query().Get("code")
+	log.Printf("DEBUG: Received authorization code (first 10 chars): %s...", code[:min(10, len(code))])
+	if code == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("missing authorization code"))
+		return
+	}
+
+	// Exchange the authorization code for an OAuth2 token
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	log.Println("DEBUG: Attempting to exchange authorization code for token...")
+	token, err := config.Exchange(ctx, code)
+	if err != nil {
+		log.Printf("ERROR: Failed to exchange authorization code for token: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to exchange authorization code for token: %w", err))
+		return
+	}
+	log.Println("DEBUG: Successfully exchanged code for token.")
+
+	// Get user information from the provider
+	log.Printf("DEBUG: Attempting to fetch user info from %s...", provider.displayName)
+	userInfo, err := provider.fetchUser(ctx, config.Client(ctx, token))
+	if err != nil {
+		log.Printf("ERROR: Failed to get user info from %s: %v", provider.displayName, err)
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to get user info from %s: %w", provider.displayName, err))
+		return
+	}
+	ssoID := oauthSSOID(name, userInfo.ID)
+	log.Printf("DEBUG: Successfully decoded %s user info. Email: %s, SSO ID: %s", provider.displayName, userInfo.Email, ssoID)
+
+	// Try to get existing user by SSO ID
+	getUserReq := &userproto.GetUserBySSOIDRequest{SsoId: ssoID}
+	userResp, err := h.userClient.GetUserBySSOID(ctx, getUserReq)
+
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			// User not found, create new user
+			log.Printf("DEBUG: User with SSO ID '%s' not found. Creating new user.", ssoID)
+			createReq := &userproto.CreateUserRequest{
+				User: &userproto.RegistrationRequest{
+					FirstName:  userInfo.FirstName,
+					LastName:   userInfo.LastName,
+					Email:      userInfo.Email,
+					AuthMethod: &userproto.RegistrationRequest_SsoId{SsoId: ssoID},
+				},
+			}
+
+			createResp, createErr := h.userClient.CreateUser(ctx, createReq)
+			if createErr != nil {
+				log.Printf("ERROR: Failed to create new SSO user: %v", createErr)
+				utils.HandleGRPCError(w, createErr)
+				return
+			}
+
+			// Convert to GetUserResponse format
+			userResp = &userproto.GetUserResponse{
+				Id:              createResp.Id,
+				FirstName:       createResp.FirstName,
+				LastName:        createResp.LastName,
+				Status:          createResp.Status,
+				Email:           createResp.Email,
+				TermsAcceptedAt: createResp.TermsAcceptedAt,
+				CreatedAt:       createResp.CreatedAt,
+			}
+		} else {
+			log.Printf("ERROR: GetUserBySSOID returned unexpected error: %v", err)
+			utils.HandleGRPCError(w, err)
+			return
+		}
+	}
+
+	span.SetAttributes(attribute.String("user.id", userResp.Id))
+
+	// Check user status
+	if userResp.GetStatus() != userproto.UserStatusEnum_ACTIVE {
+		utils.WriteError(w, http.StatusForbidden, errors.New("user account is not active"))
+		return
+	}
+
+	// Create session with JWT tokens
+	sessionResp, err := sessionManager.CreateSession(
+		ctx,
+		userResp.Id,
+		userResp.Email,
+		userResp.FirstName,
+		userResp.LastName,
+		r,
+	)
+	if err != nil {
+		log.Printf("Failed to create session for SSO user: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to create session"))
+		return
+	}
+
+	// Login tracking is best effort; a failure here must not block the login
+	if _, err := h.userClient.RecordLogin(ctx, &userproto.RecordLoginRequest{UserId: userResp.Id}); err != nil {
+		log.Printf("Failed to record login for SSO user %s: %v", userResp.Id, err)
+	}
+
+	// The browser arrives here from the provider, so there's no client to hand a JSON body to.
+	// The refresh token goes into an HttpOnly cookie and the frontend exchanges it for
+	// an access token through /auth/refresh once it has loaded.
+	setRefreshTokenCookie(w, sessionResp.TokenData.RefreshToken, sessionResp.Session.ExpiresAt)
+
+	log.Printf("User %s successfully authenticated via %s SSO with session %s", userResp.GetEmail(), provider.displayName, sessionResp.Session.ID)
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// getOAuthJSON fetches url with the provider's authorized client and decodes the JSON body into v
+func getOAuthJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		log.Printf("ERROR: %s returned non-200 status: %d, body: %s", url, resp.StatusCode, string(bodyBytes))
+		return fmt.Errorf("user info API returned non-200 status: %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse user info: %w", err)
+	}
+	return nil
+}
+
+// fetchGoogleUser reads the user from Google's OpenID Connect userinfo endpoint
+func fetchGoogleUser(ctx context.Context, client *http.Client) (oauthUser, error) {
+	var info struct {
+		ID        string `json:"sub"`
+		Email     string `json:"email"`
+		FirstName string `json:"given_name"`
+		LastName  string `json:"family_name"`
+	}
+	if err := getOAuthJSON(ctx, client, "https://www.googleapis.com/oauth2/v3/userinfo", &info); err != nil {
+		return oauthUser{}, err
+	}
+	if info.ID == "" {
+		return oauthUser{}, errors.New("user info has no subject")
+	}
+	return oauthUser{ID: info.ID, Email: info.Email, FirstName: info.FirstName, LastName: info.LastName}, nil
+}
+
+// fetchMicrosoftUser reads the user from Microsoft Graph. Work and school accounts may have
+// no mail address, in which case their sign-in name, which is an email, is used.
+func fetchMicrosoftUser(ctx context.Context, client *http.Client) (oauthUser, error) {
+	var info struct {
+		ID                string `json:"id"`
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+		GivenName         string `json:"givenName"`
+		Surname           string `json:"surname"`
+	}
+	if err := getOAuthJSON(ctx, client, "https://graph.microsoft.com/v1.0/me", &info); err != nil {
+		return oauthUser{}, err
+	}
+	if info.ID == "" {
+		return oauthUser{}, errors.New("user info has no ID")
+	}
+	email := info.Mail
+	if email == "" {
+		email = info.UserPrincipalName
+	}
+	return oauthUser{ID: info.ID, Email: email, FirstName: info.GivenName, LastName: info.Surname}, nil
+}
+
+// fetchGitHubUser reads the user from the GitHub API. GitHub only includes the email when
+// the user made it public, so otherwise the primary verified email is looked up. GitHub
+// has a single display name, split here at the first space.
+func fetchGitHubUser(ctx context.Context, client *http.Client) (oauthUser, error) {
+	var info struct {
+		ID    int64  `json:"id"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	if err := getOAuthJSON(ctx, client, "https://api.github.com/user", &info); err != nil {
+		return oauthUser{}, err
+	}
+	if info.ID == 0 {
+		return oauthUser{}, errors.New("user info has no ID")
+	}
+
+	email := info.Email
+	if email == "" {
+		var emails []struct {
+			Email    string `json:"email"`
+			Primary  bool   `json:"primary"`
+			Verified bool   `json:"verified"`
+		}
+		if err := getOAuthJSON(ctx, client, "https://api.github.com/user/emails", &emails); err != nil {
+			return oauthUser{}, err
+		}
+		for _, e := range emails {
+			if e.Primary && e.Verified {
+				email = e.Email
+				break
+			}
+		}
+		if email == "" {
+			return oauthUser{}, errors.New("account has no verified primary email")
+		}
+	}
+
+	firstName, lastName, _ := strings.Cut(strings.TrimSpace(info.Name), " ")
+	return oauthUser{
+		ID:        strconv.FormatInt(info.ID, 10),
+		Email:     email,
+		FirstName: firstName,
+		LastName:  strings.TrimSpace(lastName),
+	}, nil
+}
@@ -0,0 +1,196 @@
+// services/gateway/internal/handler/oauth_test.go
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/auth/authn/jwt"
+	"github.com/adammwaniki/bebabeba/services/auth/oauthstate"
+	"github.com/adammwaniki/bebabeba/services/auth/session"
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ssoUserClient serves SSO accounts keyed by SSO ID and records the accounts it creates
+// and the logins it's told about
+type ssoUserClient struct {
+	userproto.UserServiceClient
+	accounts map[string]*userproto.GetUserResponse
+	created  []*userproto.RegistrationRequest
+	logins   []string
+}
+
+func (c *ssoUserClient) GetUserBySSOID(ctx context.Context, req *userproto.GetUserBySSOIDRequest, opts ...grpc.CallOption) (*userproto.GetUserResponse, error) {
+	account, ok := c.accounts[req.SsoId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return account, nil
+}
+
+func (c *ssoUserClient) CreateUser(ctx context.Context, req *userproto.CreateUserRequest, opts ...grpc.CallOption) (*userproto.CreateUserResponse, error) {
+	c.created = append(c.created, req.User)
+	return &userproto.CreateUserResponse{
+		Id:        "user-new",
+		FirstName: req.User.FirstName,
+		LastName:  req.User.LastName,
+		Email:     req.User.Email,
+		Status:    userproto.UserStatusEnum_ACTIVE,
+	}, nil
+}
+
+func (c *ssoUserClient) RecordLogin(ctx context.Context, req *userproto.RecordLoginRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	c.logins = append(c.logins, req.UserId)
+	return &emptypb.Empty{}, nil
+}
+
+// stubGoogle points the Google provider at a token endpoint that accepts any code and
+// a profile that is always the given user, restoring the real provider when the test ends
+func stubGoogle(t *testing.T, user oauthUser) *oauth2.Config {
+	t.Helper()
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"google-access-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokens.Close)
+
+	google := oauthProviders["google"]
+	t.Cleanup(func() { oauthProviders["google"] = google })
+	stub := google
+	stub.fetchUser = func(ctx context.Context, client *http.Client) (oauthUser, error) {
+		return user, nil
+	}
+	oauthProviders["google"] = stub
+
+	return &oauth2.Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		Endpoint:     oauth2.Endpoint{TokenURL: tokens.URL},
+	}
+}
+
+func TestHandleOAuthCallbackGoogle(t *testing.T) {
+	googleUser := oauthUser{ID: "google-sub-1", Email: "jane@example.com", FirstName: "Jane", LastName: "Wanjiru"}
+
+	tests := []struct {
+		name        string
+		accounts    map[string]*userproto.GetUserResponse
+		wantStatus  int
+		wantUserID  string
+		wantCreated int // users created for the Google account
+	}{
+		{
+			name:        "new user",
+			wantStatus:  http.StatusSeeOther,
+			wantUserID:  "user-new",
+			wantCreated: 1,
+		},
+		{
+			name: "existing user",
+			accounts: map[string]*userproto.GetUserResponse{
+				"google:google-sub-1": {Id: "user-1", Email: "jane@example.com", Status: userproto.UserStatusEnum_ACTIVE},
+			},
+			wantStatus: http.StatusSeeOther,
+			wantUserID: "user-1",
+		},
+		{
+			name: "existing user suspended",
+			accounts: map[string]*userproto.GetUserResponse{
+				"google:google-sub-1": {Id: "user-1", Email: "jane@example.com", Status: userproto.UserStatusEnum_SUSPENDED},
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := stubGoogle(t, googleUser)
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			defer db.Close()
+			if tt.wantUserID != "" {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_sessions")).
+					WithArgs(sqlmock.AnyArg(), tt.wantUserID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), true).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			jwtService := jwt.NewJWTService("test-secret", "bebabeba")
+
+			states := oauthstate.NewMemoryStore(time.Minute)
+			defer states.Close()
+			if err := states.Put(context.Background(), oauthStateKey("google", "state-1"), "/dashboard", oauthstate.DefaultTTL); err != nil {
+				t.Fatalf("failed to store state: %v", err)
+			}
+
+			client := &ssoUserClient{accounts: tt.accounts}
+			h := NewUserHandler(client, nil, map[string]*oauth2.Config{"google": config}, states, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/google/callback?state=state-1&code=auth-code", nil)
+			req.SetPathValue("provider", "google")
+			rec := httptest.NewRecorder()
+			h.HandleOAuthCallback(session.NewSessionManager(db, jwtService), rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if len(client.created) != tt.wantCreated {
+				t.Fatalf("created %d users, want %d", len(client.created), tt.wantCreated)
+			}
+			if tt.wantCreated > 0 {
+				if sso := client.created[0].GetSsoId(); sso != "google:google-sub-1" {
+					t.Errorf("created user SSO ID = %q, want %q", sso, "google:google-sub-1")
+				}
+				if client.created[0].Email != googleUser.Email {
+					t.Errorf("created user email = %q, want %q", client.created[0].Email, googleUser.Email)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+
+			if tt.wantUserID == "" {
+				if cookies := rec.Result().Cookies(); len(cookies) != 0 {
+					t.Errorf("set cookies %v, want none for a refused sign-in", cookies)
+				}
+				return
+			}
+
+			if location := rec.Header().Get("Location"); location != "/dashboard" {
+				t.Errorf("redirected to %q, want the stored post-login path /dashboard", location)
+			}
+			if len(client.logins) != 1 || client.logins[0] != tt.wantUserID {
+				t.Errorf("logins recorded = %v, want [%s]", client.logins, tt.wantUserID)
+			}
+
+			cookies := rec.Result().Cookies()
+			if len(cookies) != 1 || cookies[0].Name != refreshTokenCookie {
+				t.Fatalf("cookies = %v, want only %s", cookies, refreshTokenCookie)
+			}
+			cookie := cookies[0]
+			if !cookie.HttpOnly || !cookie.Secure || cookie.Path != refreshTokenCookiePath {
+				t.Errorf("cookie HttpOnly = %t, Secure = %t, Path = %q, want an HttpOnly, Secure cookie on %s",
+					cookie.HttpOnly, cookie.Secure, cookie.Path, refreshTokenCookiePath)
+			}
+			claims, err := jwtService.ValidateToken(cookie.Value)
+			if err != nil {
+				t.Fatalf("cookie doesn't hold a valid token: %v", err)
+			}
+			if claims.UserID != tt.wantUserID {
+				t.Errorf("token user_id = %q, want %q", claims.UserID, tt.wantUserID)
+			}
+		})
+	}
+}
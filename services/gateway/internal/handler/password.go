@@ -0,0 +1,321 @@
+// services/gateway/internal/handler/password.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/auth/authn/passwords"
+	"github.com/adammwaniki/bebabeba/services/auth/passwordreset"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// errSSOPasswordChange is returned to SSO-only accounts, which have no password to change
+var errSSOPasswordChange = errors.New("this account signs in with Google and has no password to change. Please manage your credentials through your Google account")
+
+// forgotPasswordMessage is returned whether or not the email belongs to an account,
+// so the endpoint can't be used to discover who is registered
+const forgotPasswordMessage = "If an account with that email exists, a password reset link has been sent"
+
+// ChangePasswordRequest represents the request payload for changing the current user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ForgotPasswordRequest represents the request payload for requesting a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents the request payload for setting a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// PasswordResetSender delivers reset tokens to users
+type PasswordResetSender interface {
+	SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error
+}
+
+// LogPasswordResetSender writes reset links to the gateway log. It stands in for
+// outbound email, which the platform doesn't have yet, and is meant for development:
+// anyone who can read the log could use the links to take over accounts.
+type LogPasswordResetSender struct {
+	BaseURL string // reset page the token is appended to, e.g. https://app.example.com/reset-password
+}
+
+// PasswordResetSenderFromEnv returns the sender for reset links, or nil when none is
+// configured, which leaves forgot/reset password disabled. Until outbound email exists
+// the only sender is the log one, used only when PASSWORD_RESET_LOG_LINKS is set for
+// development.
+func PasswordResetSenderFromEnv() PasswordResetSender {
+	if enabled, _ := strconv.ParseBool(os.Getenv("PASSWORD_RESET_LOG_LINKS")); enabled {
+		return LogPasswordResetSender{BaseURL: os.Getenv("PASSWORD_RESET_URL")}
+	}
+	return nil
+}
+
+// SendPasswordReset logs the reset link for the user
+func (s LogPasswordResetSender) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	link := token
+	if s.BaseURL != "" {
+		link = s.BaseURL + "?token=" + url.QueryEscape(token)
+	}
+	log.Printf("Password reset link for %s (expires %s): %s", email, expiresAt.Format(time.RFC3339), link)
+	return nil
+}
+
+// SetPasswordReset enables the forgot/reset password endpoints
+func (h *AuthHandler) SetPasswordReset(manager *passwordreset.Manager, sender PasswordResetSender) {
+	h.resetManager = manager
+	h.resetSender = sender
+}
+
+// HandleChangePassword handles POST requests from a signed-in user to change their password.
+// The current password must be confirmed, and every other session is ended afterwards.
+func (h *AuthHandler) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	claims, ok := middleware.GetClaimsFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, errors.New("user not authenticated"))
+		return
+	}
+	sessionID, _ := middleware.GetSessionIDFromContext(r.Context())
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var req ChangePasswordRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("current_password and new_password are required"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Look the email up rather than trusting the token, it may have changed since sign in
+	userResp, err := h.userClient.GetUserByID(ctx, &userproto.GetUserRequest{UserId: claims.UserID})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	authResp, err := h.userClient.GetUserForAuth(ctx, &userproto.GetUserForAuthRequest{Email: userResp.Email})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	if authResp.PasswordHash == "" {
+		utils.WriteError(w, http.StatusBadRequest, errSSOPasswordChange)
+		return
+	}
+
+	passwordMatch, err := passwords.VerifyPassword(req.CurrentPassword, authResp.PasswordHash)
+	if err != nil {
+		log.Printf("Password verification error: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("authentication error"))
+		return
+	}
+	if !passwordMatch {
+		utils.WriteError(w, http.StatusUnauthorized, errors.New("current password is incorrect"))
+		return
+	}
+
+	if req.NewPassword == req.CurrentPassword {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("new password must be different from the current password"))
+		return
+	}
+
+	// The user service enforces the password policy and rehashes
+	if err := h.setPassword(ctx, authResp.Id, req.NewPassword); err != nil {
+		writeSetPasswordError(w, err)
+		return
+	}
+
+	if err := h.sessionManager.EndOtherUserSessions(ctx, claims.UserID, sessionID); err != nil {
+		log.Printf("Failed to end other sessions for user %s after password change: %v", claims.UserID, err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("password changed but other sessions could not be signed out"))
+		return
+	}
+	h.profileCache.InvalidateUser(claims.UserID)
+
+	log.Printf("Password changed for user %s, other sessions ended", claims.UserID)
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "Password changed successfully"})
+}
+
+// HandleForgotPassword handles POST requests for a password reset link.
+// The response is the same whether or not the email is registered.
+func (h *AuthHandler) HandleForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if h.resetManager == nil {
+		utils.WriteError(w, http.StatusServiceUnavailable, errors.New("password reset is not available"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var req ForgotPasswordRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("email is required"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	accepted := map[string]string{"message": forgotPasswordMessage}
+
+	authResp, err := h.userClient.GetUserForAuth(ctx, &userproto.GetUserForAuthRequest{Email: req.Email})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			utils.WriteJSON(w, http.StatusAccepted, accepted)
+			return
+		}
+		log.Printf("GetUserForAuth failed for password reset: %v", err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("password reset is temporarily unavailable"))
+		return
+	}
+
+	// SSO-only and inactive accounts get the same answer, there is nothing they could reset
+	if authResp.PasswordHash == "" || authResp.Status != userproto.UserStatusEnum_ACTIVE {
+		log.Printf("Password reset requested for user %s, which has no resettable password", authResp.Id)
+		utils.WriteJSON(w, http.StatusAccepted, accepted)
+		return
+	}
+
+	token, expiresAt, err := h.resetManager.Issue(ctx, authResp.Id)
+	if err != nil {
+		log.Printf("Failed to issue password reset token for user %s: %v", authResp.Id, err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("password reset is temporarily unavailable"))
+		return
+	}
+
+	if err := h.resetSender.SendPasswordReset(ctx, req.Email, token, expiresAt); err != nil {
+		log.Printf("Failed to send password reset for user %s: %v", authResp.Id, err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("password reset is temporarily unavailable"))
+		return
+	}
+
+	log.Printf("Password reset token issued for user %s", authResp.Id)
+	utils.WriteJSON(w, http.StatusAccepted, accepted)
+}
+
+// HandleResetPassword handles POST requests to set a new password with a reset token.
+// All of the user's sessions are ended once the password is replaced.
+func (h *AuthHandler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	if h.resetManager == nil {
+		utils.WriteError(w, http.StatusServiceUnavailable, errors.New("password reset is not available"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var req ResetPasswordRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if req.Token == "" || req.NewPassword == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("token and new_password are required"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	var resetUserID string
+	err = h.resetManager.Redeem(ctx, req.Token, func(ctx context.Context, userID string) error {
+		resetUserID = userID
+		return h.setPassword(ctx, userID, req.NewPassword)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, passwordreset.ErrInvalidToken),
+			errors.Is(err, passwordreset.ErrTokenExpired),
+			errors.Is(err, passwordreset.ErrTokenUsed):
+			utils.WriteError(w, http.StatusBadRequest, err)
+		default:
+			if _, ok := status.FromError(err); ok {
+				writeSetPasswordError(w, err)
+				return
+			}
+			log.Printf("Failed to redeem password reset token: %v", err)
+			utils.WriteError(w, http.StatusInternalServerError, errors.New("failed to reset password"))
+		}
+		return
+	}
+
+	if err := h.sessionManager.EndAllUserSessions(ctx, resetUserID); err != nil {
+		log.Printf("Failed to end sessions for user %s after password reset: %v", resetUserID, err)
+		utils.WriteError(w, http.StatusInternalServerError, errors.New("password reset but existing sessions could not be signed out"))
+		return
+	}
+	h.profileCache.InvalidateUser(resetUserID)
+
+	log.Printf("Password reset for user %s, all sessions ended", resetUserID)
+	utils.WriteJSON(w, http.StatusOK, map[string]string{"message": "Password reset successfully. Please log in with your new password"})
+}
+
+// setPassword replaces the user's password through the user service
+func (h *AuthHandler) setPassword(ctx context.Context, userID, password string) error {
+	_, err := h.userClient.UpdateUser(ctx, &userproto.UpdateUserRequest{
+		UserId: userID,
+		User: &userproto.UserInput{
+			AuthMethod: &userproto.UserInput_Password{Password: password},
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"password"}},
+	})
+	return err
+}
+
+// writeSetPasswordError maps user service errors from setPassword, giving SSO accounts a clear answer
+func writeSetPasswordError(w http.ResponseWriter, err error) {
+	if status.Code(err) == codes.PermissionDenied {
+		utils.WriteError(w, http.StatusBadRequest, errSSOPasswordChange)
+		return
+	}
+	utils.HandleGRPCError(w, err)
+}
@@ -0,0 +1,128 @@
+// services/gateway/internal/handler/password_test.go
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/auth/passwordreset"
+	"github.com/adammwaniki/bebabeba/services/auth/session"
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"google.golang.org/grpc"
+)
+
+// passwordUserClient accepts every password update
+type passwordUserClient struct {
+	userproto.UserServiceClient
+	updated []string
+}
+
+func (c *passwordUserClient) UpdateUser(ctx context.Context, req *userproto.UpdateUserRequest, opts ...grpc.CallOption) (*userproto.UpdateUserResponse, error) {
+	c.updated = append(c.updated, req.UserId)
+	return &userproto.UpdateUserResponse{}, nil
+}
+
+func TestPasswordResetSenderFromEnv(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		t.Setenv("PASSWORD_RESET_LOG_LINKS", "")
+		if sender := PasswordResetSenderFromEnv(); sender != nil {
+			t.Errorf("sender = %#v, want nil so password reset stays disabled", sender)
+		}
+	})
+
+	t.Run("log links for development", func(t *testing.T) {
+		t.Setenv("PASSWORD_RESET_LOG_LINKS", "true")
+		t.Setenv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+		want := LogPasswordResetSender{BaseURL: "http://localhost:3000/reset-password"}
+		if sender := PasswordResetSenderFromEnv(); sender != want {
+			t.Errorf("sender = %#v, want %#v", sender, want)
+		}
+	})
+}
+
+func TestPasswordResetDisabled(t *testing.T) {
+	h := &AuthHandler{}
+
+	for _, tt := range []struct {
+		name   string
+		handle http.HandlerFunc
+		body   string
+	}{
+		{name: "forgot", handle: h.HandleForgotPassword, body: `{"email":"jane@example.com"}`},
+		{name: "reset", handle: h.HandleResetPassword, body: `{"token":"abc","new_password":"n3w-Passw0rd!"}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handle(rec, httptest.NewRequest(http.MethodPost, "/auth/password", strings.NewReader(tt.body)))
+			if rec.Code != http.StatusServiceUnavailable {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+func TestHandleResetPasswordEndsSessions(t *testing.T) {
+	tests := []struct {
+		name       string
+		endErr     error
+		wantStatus int
+	}{
+		{name: "sessions ended", wantStatus: http.StatusOK},
+		// The password changed, but sessions an attacker may hold are still live
+		{name: "sessions not ended", endErr: errors.New("connection reset"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("FROM password_reset_tokens")).
+				WillReturnRows(sqlmock.NewRows([]string{"token_id", "user_id", "expires_at", "used_at"}).
+					AddRow("token-1", "user-1", time.Now().Add(time.Hour), nil))
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens SET used_at")).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			end := mock.ExpectExec(regexp.QuoteMeta("UPDATE user_sessions SET is_active = false")).
+				WithArgs(sqlmock.AnyArg(), "user-1")
+			if tt.endErr != nil {
+				end.WillReturnError(tt.endErr)
+			} else {
+				end.WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			client := &passwordUserClient{}
+			h := &AuthHandler{
+				userClient:     client,
+				sessionManager: session.NewSessionManager(db, nil),
+				profileCache:   NewProfileCache(time.Minute),
+			}
+			h.SetPasswordReset(passwordreset.NewManager(db, passwordreset.DefaultTTL), LogPasswordResetSender{})
+
+			rec := httptest.NewRecorder()
+			h.HandleResetPassword(rec, httptest.NewRequest(http.MethodPost, "/auth/password/reset",
+				strings.NewReader(`{"token":"abc","new_password":"n3w-Passw0rd!"}`)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if len(client.updated) != 1 || client.updated[0] != "user-1" {
+				t.Errorf("password updates = %v, want [user-1]", client.updated)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// services/gateway/internal/handler/profile_cache.go
+package handler
+
+import (
+	"sync"
+	"time"
+
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+)
+
+// ProfileCache keeps the authenticated user's profile per session so dashboard loads
+// don't hit the user service every time. Entries are dropped when the user is updated
+// or deleted through the gateway, and expire after the TTL to cover changes made elsewhere.
+type ProfileCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]profileCacheEntry // keyed by session ID
+}
+
+type profileCacheEntry struct {
+	userID    string
+	profile   *userproto.GetUserResponse
+	expiresAt time.Time
+}
+
+// NewProfileCache creates a profile cache whose entries live for ttl
+func NewProfileCache(ttl time.Duration) *ProfileCache {
+	return &ProfileCache{
+		ttl:     ttl,
+		entries: make(map[string]profileCacheEntry),
+	}
+}
+
+// Get returns the cached profile for a session, if present and still fresh
+func (c *ProfileCache) Get(sessionID string) (*userproto.GetUserResponse, bool) {
+	if c == nil || sessionID == "" {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[sessionID]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, sessionID)
+		return nil, false
+	}
+	return entry.profile, true
+}
+
+// Set caches a user's profile for a session
+func (c *ProfileCache) Set(sessionID, userID string, profile *userproto.GetUserResponse) {
+	if c == nil || sessionID == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Sweep expired entries so sessions that never come back don't pile up
+	now := time.Now()
+	for id, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, id)
+		}
+	}
+
+	c.entries[sessionID] = profileCacheEntry{
+		userID:    normalizeUserID(userID),
+		profile:   profile,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// InvalidateUser drops the cached profile from every session belonging to the user
+func (c *ProfileCache) InvalidateUser(userID string) {
+	if c == nil {
+		return
+	}
+
+	userID = normalizeUserID(userID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, entry := range c.entries {
+		if entry.userID == userID {
+			delete(c.entries, id)
+		}
+	}
+}
+
+// normalizeUserID lets hex and dashed UUID spellings of the same user match
+func normalizeUserID(userID string) string {
+	if parsed, err := uuid.FromString(userID); err == nil {
+		return parsed.String()
+	}
+	return userID
+}
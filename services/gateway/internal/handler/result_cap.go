@@ -0,0 +1,40 @@
+// services/gateway/internal/handler/result_cap.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+)
+
+// ResultCap bounds how many rows a bulk endpoint (CSV import/export, batch lookups)
+// handles in a single response, independently of page-size limits, so one oversized
+// request can't exhaust the gateway's memory or hold a connection open indefinitely.
+type ResultCap struct {
+	MaxRows int
+}
+
+// DefaultResultCap is used when GATEWAY_MAX_RESULT_ROWS is unset or invalid
+var DefaultResultCap = ResultCap{MaxRows: 10000}
+
+// ResultCapFromEnv reads the row cap from GATEWAY_MAX_RESULT_ROWS
+func ResultCapFromEnv() ResultCap {
+	if n, err := strconv.Atoi(os.Getenv("GATEWAY_MAX_RESULT_ROWS")); err == nil && n > 0 {
+		return ResultCap{MaxRows: n}
+	}
+	return DefaultResultCap
+}
+
+// Exceeded reports whether rows is over the cap
+func (c ResultCap) Exceeded(rows int) bool {
+	return c.MaxRows > 0 && rows > c.MaxRows
+}
+
+// writeTooLarge rejects a request that asks for more rows than the cap allows
+func (c ResultCap) writeTooLarge(w http.ResponseWriter, rows int) {
+	utils.WriteError(w, http.StatusRequestEntityTooLarge,
+		fmt.Errorf("request covers %d rows, which is over the limit of %d", rows, c.MaxRows))
+}
@@ -2,38 +2,59 @@
 package handler
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/adammwaniki/bebabeba/services/auth/session"
+	"github.com/adammwaniki/bebabeba/services/common/featureflags"
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
 	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
 )
 
-// SetupAPIRoutes configures the HTTP routes with JWT authentication and session management
+// SetupAPIRoutes configures the HTTP routes with JWT authentication and session management.
+// Routes behind a feature flag are only registered when the flag is on, so they 404 until enabled.
 func SetupAPIRoutes(
 	mux *http.ServeMux, 
 	userHandler *UserHandler, 
 	authHandler *AuthHandler,
 	vehicleHandler *VehicleHandler,
 	staffHandler *StaffHandler,
+	assignmentHandler *AssignmentHandler,
+	apiKeyHandler *APIKeyHandler,
 	healthHandler *HealthHandler,
 	authMiddleware *middleware.AuthMiddleware,
 	sessionManager *session.SessionManager,
+	flags *featureflags.Flags,
+	requestLogger *slog.Logger,
+	rateLimits middleware.RateLimitConfig,
+	cors middleware.CORSConfig,
 ) {
 	// API v1 subrouter - this handles requests AFTER /api/v1 is stripped
 	apiV1Router := http.NewServeMux()
 
-	// Wrapper for Google OAuth callback with session management
-	googleCallbackWithSessions := func(w http.ResponseWriter, r *http.Request) {
-		userHandler.HandleGoogleCallbackWithJWT(sessionManager, w, r)
+	// Each group of rate limited routes shares one limiter, so a client's requests to any
+	// route in the group count against the same limit
+	authLimiter := middleware.NewRateLimiter(rateLimits.Auth, nil)
+	createLimiter := middleware.NewRateLimiter(rateLimits.Create, nil)
+	limitAuth := func(h http.HandlerFunc) http.HandlerFunc { return middleware.RateLimitMiddleware(authLimiter, h) }
+	limitCreate := func(h http.HandlerFunc) http.HandlerFunc { return middleware.RateLimitMiddleware(createLimiter, h) }
+
+	// Wrapper for OAuth callbacks with session management
+	oauthCallbackWithSessions := func(w http.ResponseWriter, r *http.Request) {
+		userHandler.HandleOAuthCallback(sessionManager, w, r)
 	}
 
 	// ================= PUBLIC ENDPOINTS =================
 	// No authentication required - these paths are seen WITHOUT /api/v1
-	apiV1Router.HandleFunc("POST /users/register", authHandler.HandleCreateUserWithJWT)
-	apiV1Router.HandleFunc("POST /auth/login", authHandler.HandleLogin)
-	apiV1Router.HandleFunc("POST /auth/refresh", authHandler.HandleRefresh)
-	apiV1Router.HandleFunc("GET /auth/google/login", userHandler.HandleGoogleLogin)
-	apiV1Router.HandleFunc("GET /auth/google/callback", googleCallbackWithSessions)
+	// Rate limited by client IP
+	apiV1Router.HandleFunc("POST /users/register", limitAuth(authHandler.HandleCreateUserWithJWT))
+	apiV1Router.HandleFunc("POST /auth/login", limitAuth(authHandler.HandleLogin))
+	apiV1Router.HandleFunc("POST /auth/refresh", limitAuth(authHandler.HandleRefresh))
+	apiV1Router.HandleFunc("POST /auth/forgot-password", limitAuth(authHandler.HandleForgotPassword))
+	apiV1Router.HandleFunc("POST /auth/reset-password", limitAuth(authHandler.HandleResetPassword))
+	apiV1Router.HandleFunc("GET /auth/{provider}/login", limitAuth(userHandler.HandleOAuthLogin))
+	apiV1Router.HandleFunc("GET /auth/{provider}/callback", limitAuth(oauthCallbackWithSessions))
 	
 	// Health endpoints (public)
 	apiV1Router.HandleFunc("GET /healthz", healthHandler.LivenessCheck)
@@ -46,28 +67,72 @@ func SetupAPIRoutes(
 	apiV1Router.HandleFunc("GET /auth/profile", authMiddleware.RequireAuth(authHandler.HandleProfile))
 	apiV1Router.HandleFunc("GET /auth/sessions", authMiddleware.RequireAuth(authHandler.HandleGetSessions))
 	apiV1Router.HandleFunc("POST /auth/logout", authMiddleware.RequireAuth(authHandler.HandleLogout))
+	apiV1Router.HandleFunc("POST /auth/change-password", authMiddleware.RequireAuth(authHandler.HandleChangePassword))
+	apiV1Router.HandleFunc("GET /users/by-email", authMiddleware.RequireAuth(userHandler.HandleGetUserByEmail))
 	apiV1Router.HandleFunc("GET /users/{id}", authMiddleware.RequireAuth(userHandler.HandleGetUserByID))
 	apiV1Router.HandleFunc("GET /users", authMiddleware.RequireAuth(userHandler.HandleListUsers))
 	apiV1Router.HandleFunc("PUT /users/{id}", authMiddleware.RequireAuth(userHandler.HandleUpdateUserByID))
 	apiV1Router.HandleFunc("DELETE /users/{id}", authMiddleware.RequireAuth(userHandler.HandleDeleteUserByID))
 
+	// API keys for partner integrations (admin only)
+	apiV1Router.HandleFunc("POST /auth/api-keys", authMiddleware.RequireAdmin(apiKeyHandler.HandleCreateAPIKey))
+	apiV1Router.HandleFunc("GET /auth/api-keys", authMiddleware.RequireAdmin(apiKeyHandler.HandleListAPIKeys))
+	apiV1Router.HandleFunc("DELETE /auth/api-keys/{id}", authMiddleware.RequireAdmin(apiKeyHandler.HandleRevokeAPIKey))
+	apiV1Router.HandleFunc("GET /auth/login-lockouts", authMiddleware.RequireAdmin(authHandler.HandleGetLoginLockout))
+	apiV1Router.HandleFunc("DELETE /auth/login-lockouts", authMiddleware.RequireAdmin(authHandler.HandleResetLoginLockout))
+
 	// ================= TRANSPORT ENDPOINTS =================
+	// Routes using RequireAuthOrScope also accept an API key granted that scope.
+	// Creates are rate limited per user, inside RequireAuth where the user is known.
 	
 	// Vehicle Management
-	apiV1Router.HandleFunc("POST /transport/vehicles", authMiddleware.RequireAuth(vehicleHandler.HandleCreateVehicle))
-	apiV1Router.HandleFunc("GET /transport/vehicles/{id}", authMiddleware.RequireAuth(vehicleHandler.HandleGetVehicle))
-	apiV1Router.HandleFunc("GET /transport/vehicles", authMiddleware.RequireAuth(vehicleHandler.HandleListVehicles))
+	apiV1Router.HandleFunc("POST /transport/vehicles", authMiddleware.RequireAuth(limitCreate(vehicleHandler.HandleCreateVehicle)))
+	if flags.Enabled(featureflags.VehicleCSVImport) {
+		apiV1Router.HandleFunc("POST /transport/vehicles:importCsv", authMiddleware.RequireAuth(limitCreate(vehicleHandler.HandleImportVehiclesCSV)))
+		apiV1Router.HandleFunc("POST /transport/vehicles/import", authMiddleware.RequireAuth(limitCreate(vehicleHandler.HandleImportVehicles)))
+	}
+	apiV1Router.HandleFunc("POST /transport/vehicles:normalize", authMiddleware.RequireAdmin(vehicleHandler.HandleNormalizeLegacyVehicles))
+	apiV1Router.HandleFunc("GET /transport/vehicles/{id}", authMiddleware.RequireAuthOrScope(middleware.ScopeVehiclesRead, vehicleHandler.HandleGetVehicle))
+	apiV1Router.HandleFunc("GET /transport/vehicles", authMiddleware.RequireAuthOrScope(middleware.ScopeVehiclesRead, vehicleHandler.HandleListVehicles))
 	apiV1Router.HandleFunc("PUT /transport/vehicles/{id}", authMiddleware.RequireAuth(vehicleHandler.HandleUpdateVehicle))
 	apiV1Router.HandleFunc("DELETE /transport/vehicles/{id}", authMiddleware.RequireAuth(vehicleHandler.HandleDeleteVehicle))
 	apiV1Router.HandleFunc("PATCH /transport/vehicles/{id}/status", authMiddleware.RequireAuth(vehicleHandler.HandleUpdateVehicleStatus))
+	apiV1Router.HandleFunc("PATCH /transport/vehicles/{id}/mileage", authMiddleware.RequireAuth(vehicleHandler.HandleRecordVehicleMileage))
+	apiV1Router.HandleFunc("POST /transport/vehicles/{id}/service-records", authMiddleware.RequireAuth(vehicleHandler.HandleRecordService))
+	apiV1Router.HandleFunc("GET /transport/vehicles/maintenance-due", authMiddleware.RequireAuth(vehicleHandler.HandleGetVehiclesDueForMaintenance))
+	apiV1Router.HandleFunc("POST /transport/vehicles/{id}/status:validate", authMiddleware.RequireAuth(vehicleHandler.HandleValidateVehicleStatusChange))
+	apiV1Router.HandleFunc("GET /transport/vehicles/{id}/status-history", authMiddleware.RequireAuth(vehicleHandler.HandleGetVehicleStatusHistory))
+	apiV1Router.HandleFunc("POST /transport/vehicles/{id}/assign", authMiddleware.RequireAuth(vehicleHandler.HandleAssignVehicle))
+	if flags.Enabled(featureflags.AssignmentCSVImport) {
+		apiV1Router.HandleFunc("POST /transport/assignments:importCsv", authMiddleware.RequireAuth(limitCreate(assignmentHandler.HandleImportAssignmentsCSV)))
+	}
 	
 	// Vehicle queries
 	apiV1Router.HandleFunc("GET /transport/vehicles/types/{type_id}/vehicles", authMiddleware.RequireAuth(vehicleHandler.HandleGetVehiclesByType))
+	// Looked up by query parameter: a by-chassis/{chassis} path would conflict with
+	// {id}/status-history, as neither pattern is more specific than the other
+	apiV1Router.HandleFunc("GET /transport/vehicles/by-chassis", authMiddleware.RequireAuthOrScope(middleware.ScopeVehiclesRead, vehicleHandler.HandleGetVehicleByChassisNumber))
 	apiV1Router.HandleFunc("GET /transport/vehicles/available", authMiddleware.RequireAuth(vehicleHandler.HandleGetAvailableVehicles))
+	apiV1Router.HandleFunc("GET /transport/vehicles/recent", authMiddleware.RequireAuth(vehicleHandler.HandleListRecentlyUpdatedVehicles))
+	apiV1Router.HandleFunc("GET /transport/vehicles/utilization", authMiddleware.RequireAuth(vehicleHandler.HandleGetFleetUtilization))
+	apiV1Router.HandleFunc("GET /transport/vehicles/export", authMiddleware.RequireAuth(vehicleHandler.HandleExportVehiclesCSV))
+	if flags.Enabled(featureflags.DispatchCandidates) {
+		apiV1Router.HandleFunc("GET /transport/vehicles/dispatch-candidates", authMiddleware.RequireAuth(vehicleHandler.HandleGetDispatchCandidates))
+	}
 	
 	// Vehicle type management
-	apiV1Router.HandleFunc("POST /transport/vehicle-types", authMiddleware.RequireAuth(vehicleHandler.HandleCreateVehicleType))
+	apiV1Router.HandleFunc("POST /transport/vehicle-types", authMiddleware.RequireAuth(limitCreate(vehicleHandler.HandleCreateVehicleType)))
 	apiV1Router.HandleFunc("GET /transport/vehicle-types", authMiddleware.RequireAuth(vehicleHandler.HandleListVehicleTypes))
+	apiV1Router.HandleFunc("GET /transport/vehicle-types/by-name", authMiddleware.RequireAuth(vehicleHandler.HandleGetVehicleTypeByName))
+	apiV1Router.HandleFunc("GET /transport/vehicle-types/{id}", authMiddleware.RequireAuth(vehicleHandler.HandleGetVehicleType))
+	apiV1Router.HandleFunc("PUT /transport/vehicle-types/{id}", authMiddleware.RequireAuth(vehicleHandler.HandleUpdateVehicleType))
+	apiV1Router.HandleFunc("DELETE /transport/vehicle-types/{id}", authMiddleware.RequireAuth(vehicleHandler.HandleDeleteVehicleType))
+	apiV1Router.HandleFunc("GET /transport/vehicle-types/{type}/eligible-drivers", authMiddleware.RequireAuth(staffHandler.HandleGetEligibleDrivers))
+
+	// Format checks for live form feedback, nothing is stored
+	apiV1Router.HandleFunc("POST /validate/license-plate", authMiddleware.RequireAuth(vehicleHandler.HandleValidateLicensePlate))
+	apiV1Router.HandleFunc("POST /validate/phone", authMiddleware.RequireAuth(staffHandler.HandleValidatePhoneNumber))
+	apiV1Router.HandleFunc("POST /validate/license", authMiddleware.RequireAuth(staffHandler.HandleValidateLicenseNumber))
 
 	// ================= STAFF MANAGEMENT =================
 	// Restructured to group all literal paths together, then all parameterized paths to handle Go specificity errors
@@ -75,26 +140,48 @@ func SetupAPIRoutes(
 	// All literal/static driver endpoints first (no parameters)
 	apiV1Router.HandleFunc("GET /transport/drivers/active", authMiddleware.RequireAuth(staffHandler.HandleGetActiveDrivers))
 	apiV1Router.HandleFunc("GET /transport/drivers/expiring-licenses", authMiddleware.RequireAuth(staffHandler.HandleGetExpiringLicenses))
+	apiV1Router.HandleFunc("GET /transport/drivers/recently-expired-licenses", authMiddleware.RequireAuth(staffHandler.HandleGetRecentlyExpiredLicenses))
+	apiV1Router.HandleFunc("GET /transport/drivers/recent", authMiddleware.RequireAuth(staffHandler.HandleListRecentlyUpdatedDrivers))
+	apiV1Router.HandleFunc("GET /transport/drivers/export", authMiddleware.RequireAuth(staffHandler.HandleExportDriversCSV))
 	
 	// Base driver operations (collection-level)
-	apiV1Router.HandleFunc("POST /transport/drivers", authMiddleware.RequireAuth(staffHandler.HandleCreateDriver))
+	apiV1Router.HandleFunc("POST /transport/drivers", authMiddleware.RequireAuth(limitCreate(staffHandler.HandleCreateDriver)))
 	apiV1Router.HandleFunc("GET /transport/drivers", authMiddleware.RequireAuth(staffHandler.HandleListDrivers))
+	apiV1Router.HandleFunc("POST /transport/drivers:batchVerifyLicenses", authMiddleware.RequireAuthOrScope(middleware.ScopeDriversVerify, staffHandler.HandleBatchVerifyDriverLicenses))
+	apiV1Router.HandleFunc("POST /transport/drivers:normalize", authMiddleware.RequireAdmin(staffHandler.HandleNormalizeLegacyDrivers))
 	
 	// User lookup endpoint (moved to avoid conflicts with ID-based routes)
 	apiV1Router.HandleFunc("GET /users/{user_id}/driver", authMiddleware.RequireAuth(staffHandler.HandleGetDriverByUserID))
 	
 	// Individual driver operations (all ID-based routes together)
-	apiV1Router.HandleFunc("GET /transport/drivers/{id}", authMiddleware.RequireAuth(staffHandler.HandleGetDriver))
+	apiV1Router.HandleFunc("GET /transport/drivers/{id}", authMiddleware.RequireAuthOrScope(middleware.ScopeDriversRead, staffHandler.HandleGetDriver))
 	apiV1Router.HandleFunc("PATCH /transport/drivers/{id}/status", authMiddleware.RequireAuth(staffHandler.HandleUpdateDriverStatus))
-	apiV1Router.HandleFunc("POST /transport/drivers/{id}/verify-license", authMiddleware.RequireAuth(staffHandler.HandleVerifyDriverLicense))
+	apiV1Router.HandleFunc("POST /transport/drivers/{id}/status:validate", authMiddleware.RequireAuth(staffHandler.HandleValidateDriverStatusChange))
+	apiV1Router.HandleFunc("GET /transport/drivers/{id}/status-history", authMiddleware.RequireAuth(staffHandler.HandleListDriverStatusHistory))
+	apiV1Router.HandleFunc("GET /transport/drivers/{id}/profile", authMiddleware.RequireAuth(assignmentHandler.HandleGetDriverProfile))
+	apiV1Router.HandleFunc("POST /transport/drivers/{id}/renew-license", authMiddleware.RequireAuth(staffHandler.HandleRenewDriverLicense))
+	apiV1Router.HandleFunc("POST /transport/drivers/{id}/acknowledge-handbook", authMiddleware.RequireAuth(staffHandler.HandleAcknowledgeHandbook))
+	apiV1Router.HandleFunc("POST /transport/drivers/{id}/verify-license", authMiddleware.RequireAuthOrScope(middleware.ScopeDriversVerify, staffHandler.HandleVerifyDriverLicense))
+	apiV1Router.HandleFunc("POST /transport/drivers/{id}/restore", authMiddleware.RequireAuth(staffHandler.HandleRestoreDriver))
+	apiV1Router.HandleFunc("POST /transport/drivers/{id}/merge", authMiddleware.RequireAdmin(staffHandler.HandleMergeDrivers))
+	apiV1Router.HandleFunc("DELETE /transport/drivers/{id}/purge", authMiddleware.RequireAdmin(staffHandler.HandlePurgeDriver))
 	
 	// Driver certifications (sub-resource of driver)
 	apiV1Router.HandleFunc("POST /transport/drivers/{id}/certifications", authMiddleware.RequireAuth(staffHandler.HandleAddDriverCertification))
 	apiV1Router.HandleFunc("GET /transport/drivers/{id}/certifications", authMiddleware.RequireAuth(staffHandler.HandleListDriverCertifications))
+	apiV1Router.HandleFunc("GET /transport/certification-templates", authMiddleware.RequireAuth(staffHandler.HandleListCertificationTemplates))
 
 	// Mount the API router at /api/v1/ with prefix stripping
-	// The StripPrefix happens BEFORE routes are matched, so the apiV1Router sees clean paths
-	mux.Handle("/api/v1/", http.StripPrefix("/api/v1", apiV1Router))
+	// The StripPrefix happens BEFORE routes are matched, so the apiV1Router sees clean paths.
+	// Trailing slashes are trimmed after that, so /api/v1/transport/vehicles/ and
+	// /api/v1/transport/vehicles reach the same handler. ?time_format= applies to every route.
+	// Requests are logged outside StripPrefix so the log shows the full /api/v1 path, and
+	// traced and counted right around the router, which is where the matched route is known.
+	// The trace started here is carried by r.Context() into every gRPC call a handler makes.
+	// CORS preflights are answered before routing, since no route accepts OPTIONS.
+	mux.Handle("/api/v1/", middleware.LoggingMiddleware(requestLogger, middleware.CORSMiddleware(cors,
+		http.StripPrefix("/api/v1", middleware.TimeFormat(middleware.TrimTrailingSlash(
+			tracing.HTTPHandler(middleware.Metrics(apiV1Router), "gateway")))))))
 	
 	// Redirect requests at /api/v1 to /api/v1/
 	mux.HandleFunc("/api/v1", func(w http.ResponseWriter, r *http.Request) {
@@ -104,4 +191,7 @@ func SetupAPIRoutes(
 	// Gateway-level health for load balancers (public) - these see the full path
 	mux.HandleFunc("/healthz", healthHandler.LivenessCheck)
 	mux.HandleFunc("/readyz", healthHandler.ReadinessCheck)
+
+	// Prometheus scrape endpoint (public) - request counts and latencies by route
+	mux.Handle("GET /metrics", metrics.Default.Handler())
 }
\ No newline at end of file
@@ -7,27 +7,64 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/redact"
 	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	"github.com/gofrs/uuid/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // StaffHandler handles HTTP requests for the staff service
 type StaffHandler struct {
-	staffClient staffproto.StaffServiceClient
+	staffClient   staffproto.StaffServiceClient
+	vehicleClient vehicleproto.VehicleServiceClient // vehicle assignments held by drivers
+	resultCap     ResultCap
+	redaction     *redact.Policy // PII hidden from non-admin callers
 }
 
-// NewStaffHandler creates a new staff handler
-func NewStaffHandler(staffClient staffproto.StaffServiceClient) *StaffHandler {
+// NewStaffHandler creates a new staff handler. Driver PII is redacted for everyone but
+// admins under the default policy until SetRedaction replaces it.
+func NewStaffHandler(staffClient staffproto.StaffServiceClient, vehicleClient vehicleproto.VehicleServiceClient, resultCap ResultCap) *StaffHandler {
 	return &StaffHandler{
-		staffClient: staffClient,
+		staffClient:   staffClient,
+		vehicleClient: vehicleClient,
+		resultCap:     resultCap,
+		redaction:     redact.DefaultPolicy(middleware.RoleAdmin),
 	}
 }
 
+// SetRedaction sets the policy for driver PII shown to non-admin callers
+func (h *StaffHandler) SetRedaction(policy *redact.Policy) {
+	h.redaction = policy
+}
+
+// writeDrivers writes a response carrying drivers like writeProtoJSONFields, with their
+// PII redacted for the caller's role
+func (h *StaffHandler) writeDrivers(w http.ResponseWriter, r *http.Request, resp proto.Message, key string, fields *fieldmaskpb.FieldMask) {
+	role, _ := middleware.GetRoleFromContext(r.Context())
+	writeRedactedProtoJSON(w, http.StatusOK, resp, key, fields, h.redaction, role)
+}
+
+// openAssignment returns the vehicle the driver currently holds, nil when they hold none
+func (h *StaffHandler) openAssignment(ctx context.Context, driverID string) (*vehicleproto.GetDriverAssignmentResponse, error) {
+	resp, err := h.vehicleClient.GetDriverAssignment(ctx, &vehicleproto.GetDriverAssignmentRequest{DriverId: driverID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	return resp, err
+}
+
 // HandleCreateDriver handles POST requests to create a new driver
 func (h *StaffHandler) HandleCreateDriver(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
@@ -49,8 +86,14 @@ func (h *StaffHandler) HandleCreateDriver(w http.ResponseWriter, r *http.Request
 		Driver: &driverInput,
 	}
 
+	ctx, err := withIdempotencyKey(r.Context(), r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
 	// Set context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	// Call the gRPC service
@@ -83,6 +126,12 @@ func (h *StaffHandler) HandleGetDriver(w http.ResponseWriter, r *http.Request) {
 		DriverId: driverIDStr,
 	}
 
+	fields, err := parseFields(r, &staffproto.Driver{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
 	// Set context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -94,7 +143,7 @@ func (h *StaffHandler) HandleGetDriver(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	h.writeDrivers(w, r, resp, "driver", fields)
 }
 
 // HandleGetDriverByUserID handles GET requests to retrieve a driver by user ID
@@ -110,6 +159,12 @@ func (h *StaffHandler) HandleGetDriverByUserID(w http.ResponseWriter, r *http.Re
 		UserId: userIDStr,
 	}
 
+	fields, err := parseFields(r, &staffproto.Driver{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
 	// Set context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -121,7 +176,12 @@ func (h *StaffHandler) HandleGetDriverByUserID(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	// Drivers see their own contact details in full
+	if callerID, _ := middleware.GetUserIDFromContext(r.Context()); callerID == userIDStr {
+		writeProtoJSONFields(w, http.StatusOK, resp, "driver", fields)
+		return
+	}
+	h.writeDrivers(w, r, resp, "driver", fields)
 }
 
 // HandleListDrivers handles GET requests to list drivers
@@ -134,11 +194,35 @@ func (h *StaffHandler) HandleListDrivers(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Create gRPC request
-	grpcReq := &staffproto.ListDriversRequest{
-		PageSize:  pageSize,
-		PageToken: r.URL.Query().Get("page_token"),
+	grpcReq := listDriversFilters(r)
+	grpcReq.PageSize = pageSize
+	grpcReq.PageToken = r.URL.Query().Get("page_token")
+
+	fields, err := parseFields(r, &staffproto.Driver{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.staffClient.ListDrivers(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
 	}
 
+	h.writeDrivers(w, r, resp, "drivers", fields)
+}
+
+// listDriversFilters builds a ListDriversRequest from the filters and ordering in the
+// query string, shared by the driver listing and its CSV export
+func listDriversFilters(r *http.Request) *staffproto.ListDriversRequest {
+	grpcReq := &staffproto.ListDriversRequest{}
+
 	// Handle filters
 	if status := r.URL.Query().Get("status"); status != "" {
 		if statusVal, ok := staffproto.DriverStatus_value[status]; ok {
@@ -156,18 +240,10 @@ func (h *StaffHandler) HandleListDrivers(w http.ResponseWriter, r *http.Request)
 		grpcReq.LicenseExpiringSoon = &[]bool{true}[0]
 	}
 
-	// Set context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	// Call the gRPC service
-	resp, err := h.staffClient.ListDrivers(ctx, grpcReq)
-	if err != nil {
-		utils.HandleGRPCError(w, err)
-		return
-	}
+	// order_by=license_expiry pages soonest expiry first; the staff service rejects unknown orders
+	grpcReq.OrderBy = r.URL.Query().Get("order_by")
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	return grpcReq
 }
 
 // HandleUpdateDriverStatus handles PATCH requests to update driver status
@@ -196,6 +272,7 @@ func (h *StaffHandler) HandleUpdateDriverStatus(w http.ResponseWriter, r *http.R
 	var statusRequest struct {
 		Status string `json:"status"`
 		Reason string `json:"reason,omitempty"`
+		Force  bool   `json:"force,omitempty"` // suspend or deactivate a driver who still holds a vehicle
 	}
 
 	if err := json.Unmarshal(body, &statusRequest); err != nil {
@@ -221,6 +298,22 @@ func (h *StaffHandler) HandleUpdateDriverStatus(w http.ResponseWriter, r *http.R
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
+	// A suspended or inactive driver can't go on driving the vehicle they hold. The change
+	// needs force, and the forced change flags the assignment so dispatch recovers the vehicle.
+	var held *vehicleproto.GetDriverAssignmentResponse
+	if grpcReq.Status == staffproto.DriverStatus_SUSPENDED || grpcReq.Status == staffproto.DriverStatus_INACTIVE {
+		held, err = h.openAssignment(ctx, driverIDStr)
+		if err != nil {
+			utils.HandleGRPCError(w, err)
+			return
+		}
+		if held != nil && !statusRequest.Force {
+			utils.HandleGRPCError(w, status.Errorf(codes.FailedPrecondition,
+				"driver holds vehicle %s; set force to change their status to %s anyway", held.GetVehicle().GetLicensePlate(), grpcReq.Status))
+			return
+		}
+	}
+
 	// Call the gRPC service
 	resp, err := h.staffClient.UpdateDriverStatus(ctx, grpcReq)
 	if err != nil {
@@ -228,6 +321,250 @@ func (h *StaffHandler) HandleUpdateDriverStatus(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	if held != nil {
+		flagReq := &vehicleproto.FlagDriverAssignmentRequest{
+			DriverId: driverIDStr,
+			Reason:   fmt.Sprintf("driver %s while holding the vehicle", grpcReq.Status),
+		}
+		// The status change has gone through, so a failed flag is logged rather than undone
+		if _, err := h.vehicleClient.FlagDriverAssignment(ctx, flagReq); err != nil {
+			log.Printf("Warning: driver %s forced to %s but assignment %s was not flagged: %v",
+				driverIDStr, grpcReq.Status, held.GetAssignment().GetId(), err)
+		} else {
+			log.Printf("Driver %s forced to %s while holding vehicle %s, assignment %s flagged for dispatch",
+				driverIDStr, grpcReq.Status, held.GetVehicle().GetLicensePlate(), held.GetAssignment().GetId())
+		}
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleRenewDriverLicense handles POST requests recording a renewed license. new_expiry
+// accepts either a date or a full RFC3339 timestamp.
+func (h *StaffHandler) HandleRenewDriverLicense(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(driverIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	// Read and parse request body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var renewRequest struct {
+		NewExpiry        string  `json:"new_expiry"`
+		NewLicenseNumber *string `json:"new_license_number,omitempty"`
+	}
+
+	if err := json.Unmarshal(body, &renewRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if renewRequest.NewExpiry == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("new_expiry is required"))
+		return
+	}
+	newExpiry, err := time.Parse(time.RFC3339, renewRequest.NewExpiry)
+	if err != nil {
+		newExpiry, err = time.Parse("2006-01-02", renewRequest.NewExpiry)
+	}
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid new_expiry %q, expected YYYY-MM-DD or RFC3339", renewRequest.NewExpiry))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.RenewDriverLicense(ctx, &staffproto.RenewDriverLicenseRequest{
+		DriverId:         driverIDStr,
+		NewExpiry:        timestamppb.New(newExpiry),
+		NewLicenseNumber: renewRequest.NewLicenseNumber,
+	})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleListDriverStatusHistory handles GET requests for a driver's status transitions, most recent first
+func (h *StaffHandler) HandleListDriverStatusHistory(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(driverIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	grpcReq := &staffproto.ListDriverStatusHistoryRequest{
+		DriverId:  driverIDStr,
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.ListDriverStatusHistory(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleValidateDriverStatusChange handles POST requests asking whether a status change
+// would be allowed. It takes the same body as HandleUpdateDriverStatus and changes nothing.
+func (h *StaffHandler) HandleValidateDriverStatusChange(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(driverIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var statusRequest struct {
+		Status string `json:"status"`
+	}
+
+	if err := json.Unmarshal(body, &statusRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	// Validate status
+	statusVal, ok := staffproto.DriverStatus_value[statusRequest.Status]
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid status: %s", statusRequest.Status))
+		return
+	}
+
+	grpcReq := &staffproto.ValidateDriverStatusChangeRequest{
+		DriverId: driverIDStr,
+		Status:   staffproto.DriverStatus(statusVal),
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.ValidateDriverStatusChange(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleAcknowledgeHandbook handles POST requests recording a driver's acknowledgement of the
+// operating handbook. Drivers acknowledge for themselves; admins may record it on their behalf.
+func (h *StaffHandler) HandleAcknowledgeHandbook(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(driverIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	claims, ok := middleware.GetClaimsFromContext(r.Context())
+	if !ok {
+		utils.WriteError(w, http.StatusUnauthorized, errors.New("user not authenticated"))
+		return
+	}
+
+	// Read and parse request body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var ackRequest struct {
+		Version string `json:"version"`
+	}
+
+	if err := json.Unmarshal(body, &ackRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if ackRequest.Version == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("version is required"))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	if role, _ := middleware.GetRoleFromContext(r.Context()); role != middleware.RoleAdmin {
+		driverResp, err := h.staffClient.GetDriver(ctx, &staffproto.GetDriverRequest{DriverId: driverIDStr})
+		if err != nil {
+			utils.HandleGRPCError(w, err)
+			return
+		}
+		if driverResp.Driver.GetUserId() != claims.UserID {
+			utils.WriteError(w, http.StatusForbidden, errors.New("drivers can only acknowledge the handbook for themselves"))
+			return
+		}
+	}
+
+	// Call the gRPC service
+	resp, err := h.staffClient.AcknowledgeHandbook(ctx, &staffproto.AcknowledgeHandbookRequest{
+		DriverId: driverIDStr,
+		Version:  ackRequest.Version,
+	})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
 }
 
@@ -246,6 +583,16 @@ func (h *StaffHandler) HandleGetActiveDrivers(w http.ResponseWriter, r *http.Req
 		PageToken: r.URL.Query().Get("page_token"),
 	}
 
+	// Drivers with a lapsed license are excluded unless include_expired_license=true
+	if v := r.URL.Query().Get("include_expired_license"); v != "" {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid include_expired_license %q, expected true or false", v))
+			return
+		}
+		grpcReq.IncludeExpiredLicense = include
+	}
+
 	// Handle license class filter
 	if licenseClass := r.URL.Query().Get("license_class"); licenseClass != "" {
 		if classVal, ok := staffproto.LicenseClass_value[licenseClass]; ok {
@@ -264,7 +611,73 @@ func (h *StaffHandler) HandleGetActiveDrivers(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	h.writeDrivers(w, r, resp, "", nil)
+}
+
+// HandleListRecentlyUpdatedDrivers handles GET requests for the recently modified drivers feed
+func (h *StaffHandler) HandleListRecentlyUpdatedDrivers(w http.ResponseWriter, r *http.Request) {
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	// Create gRPC request
+	grpcReq := &staffproto.ListRecentlyUpdatedDriversRequest{
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.staffClient.ListRecentlyUpdatedDrivers(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	h.writeDrivers(w, r, resp, "", nil)
+}
+
+// HandleGetEligibleDrivers handles GET requests for active drivers whose license
+// class qualifies them to drive the given vehicle type
+func (h *StaffHandler) HandleGetEligibleDrivers(w http.ResponseWriter, r *http.Request) {
+	vehicleType := r.PathValue("type")
+	if vehicleType == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle type is required"))
+		return
+	}
+
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	// Create gRPC request
+	grpcReq := &staffproto.GetEligibleDriversForVehicleTypeRequest{
+		VehicleType: vehicleType,
+		PageSize:    pageSize,
+		PageToken:   r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.staffClient.GetEligibleDriversForVehicleType(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	h.writeDrivers(w, r, resp, "", nil)
 }
 
 // HandleAddDriverCertification handles POST requests to add driver certifications
@@ -370,6 +783,20 @@ func (h *StaffHandler) HandleListDriverCertifications(w http.ResponseWriter, r *
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
 }
 
+// HandleListCertificationTemplates handles GET requests for the standard certification list
+func (h *StaffHandler) HandleListCertificationTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.ListCertificationTemplates(ctx, &staffproto.ListCertificationTemplatesRequest{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
 // HandleVerifyDriverLicense handles POST requests to verify driver licenses
 func (h *StaffHandler) HandleVerifyDriverLicense(w http.ResponseWriter, r *http.Request) {
 	driverIDStr := r.PathValue("id")
@@ -418,6 +845,169 @@ func (h *StaffHandler) HandleVerifyDriverLicense(w http.ResponseWriter, r *http.
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
 }
 
+// HandleRestoreDriver handles POST requests that bring a soft deleted driver back,
+// pending verification
+func (h *StaffHandler) HandleRestoreDriver(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(driverIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.RestoreDriver(ctx, &staffproto.RestoreDriverRequest{DriverId: driverIDStr})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandlePurgeDriver handles DELETE requests that permanently remove a driver and their
+// certifications, for erasure requests. ACTIVE drivers are refused.
+func (h *StaffHandler) HandlePurgeDriver(w http.ResponseWriter, r *http.Request) {
+	driverIDStr := r.PathValue("id")
+	if driverIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(driverIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.HardDeleteDriver(ctx, &staffproto.HardDeleteDriverRequest{DriverId: driverIDStr})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleMergeDrivers handles POST requests that fold a duplicate driver into the driver in the path.
+// A duplicate that still holds a vehicle is refused.
+func (h *StaffHandler) HandleMergeDrivers(w http.ResponseWriter, r *http.Request) {
+	primaryIDStr := r.PathValue("id")
+	if primaryIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver ID is required"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var mergeRequest struct {
+		DuplicateDriverID string `json:"duplicate_driver_id"`
+	}
+	if err := json.Unmarshal(body, &mergeRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if mergeRequest.DuplicateDriverID == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("duplicate_driver_id is required"))
+		return
+	}
+
+	// Create gRPC request
+	grpcReq := &staffproto.MergeDriversRequest{
+		PrimaryDriverId:   primaryIDStr,
+		DuplicateDriverId: mergeRequest.DuplicateDriverID,
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// The merge soft deletes the duplicate, which would leave a vehicle it holds assigned
+	// to a deleted driver
+	held, err := h.openAssignment(ctx, grpcReq.DuplicateDriverId)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+	if held != nil {
+		utils.HandleGRPCError(w, status.Errorf(codes.FailedPrecondition,
+			"duplicate driver holds vehicle %s; its assignment must end before the merge", held.GetVehicle().GetLicensePlate()))
+		return
+	}
+
+	// Call the gRPC service
+	resp, err := h.staffClient.MergeDrivers(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleBatchVerifyDriverLicenses handles POST requests to verify licenses for many drivers at once
+func (h *StaffHandler) HandleBatchVerifyDriverLicenses(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var batchRequest struct {
+		DriverIDs []string `json:"driver_ids"`
+	}
+	if err := json.Unmarshal(body, &batchRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if len(batchRequest.DriverIDs) == 0 {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver_ids is required"))
+		return
+	}
+	if h.resultCap.Exceeded(len(batchRequest.DriverIDs)) {
+		h.resultCap.writeTooLarge(w, len(batchRequest.DriverIDs))
+		return
+	}
+
+	// Create gRPC request; the staff service enforces the per-call limit
+	grpcReq := &staffproto.BatchVerifyDriverLicensesRequest{
+		DriverIds: batchRequest.DriverIDs,
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.staffClient.BatchVerifyDriverLicenses(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
 // HandleGetExpiringLicenses handles GET requests to get drivers with expiring licenses
 func (h *StaffHandler) HandleGetExpiringLicenses(w http.ResponseWriter, r *http.Request) {
 	daysAhead := int32(30) // Default 30 days
@@ -452,5 +1042,43 @@ func (h *StaffHandler) HandleGetExpiringLicenses(w http.ResponseWriter, r *http.
 		return
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	h.writeDrivers(w, r, resp, "", nil)
+}
+
+// HandleGetRecentlyExpiredLicenses handles GET requests to get drivers whose licenses expired
+// in the past since_days, most recently expired first
+func (h *StaffHandler) HandleGetRecentlyExpiredLicenses(w http.ResponseWriter, r *http.Request) {
+	sinceDays := int32(30) // Default 30 days
+	if sd := r.URL.Query().Get("since_days"); sd != "" {
+		if n, err := strconv.Atoi(sd); err == nil && n > 0 {
+			sinceDays = int32(n)
+		}
+	}
+
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	// Create gRPC request
+	grpcReq := &staffproto.GetRecentlyExpiredLicensesRequest{
+		SinceDays: sinceDays,
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.staffClient.GetRecentlyExpiredLicenses(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	h.writeDrivers(w, r, resp, "", nil)
 }
\ No newline at end of file
@@ -0,0 +1,243 @@
+// services/gateway/internal/handler/staff_test.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// driverStaffClient returns testDriver for every driver lookup
+type driverStaffClient struct {
+	staffproto.StaffServiceClient
+}
+
+func (c *driverStaffClient) GetDriver(ctx context.Context, req *staffproto.GetDriverRequest, opts ...grpc.CallOption) (*staffproto.GetDriverResponse, error) {
+	return &staffproto.GetDriverResponse{Driver: testDriver()}, nil
+}
+
+func (c *driverStaffClient) GetDriverByUserID(ctx context.Context, req *staffproto.GetDriverByUserIDRequest, opts ...grpc.CallOption) (*staffproto.GetDriverResponse, error) {
+	driver := testDriver()
+	driver.UserId = req.UserId
+	return &staffproto.GetDriverResponse{Driver: driver}, nil
+}
+
+func (c *driverStaffClient) GetDriversByUserIDs(ctx context.Context, req *staffproto.GetDriversByUserIDsRequest, opts ...grpc.CallOption) (*staffproto.GetDriversByUserIDsResponse, error) {
+	drivers := make(map[string]*staffproto.Driver)
+	for _, userID := range req.UserIds {
+		driver := testDriver()
+		driver.UserId = userID
+		drivers[userID] = driver
+	}
+	return &staffproto.GetDriversByUserIDsResponse{Drivers: drivers}, nil
+}
+
+// withCaller returns r as the auth middleware would pass it on for userID signed in with role
+func withCaller(r *http.Request, userID, role string) *http.Request {
+	ctx := context.WithValue(r.Context(), middleware.UserIDKey, userID)
+	return withRole(r.WithContext(ctx), role)
+}
+
+// decodeObject decodes a JSON object response body
+func decodeObject(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body
+}
+
+func TestDriverResponsesRedacted(t *testing.T) {
+	const fullPhone, maskedPhone = "+254712345678", "***678"
+
+	tests := []struct {
+		name          string
+		target        string
+		userID        string // path user_id for the by-user lookup; empty looks the driver up by ID
+		caller        string
+		role          string
+		wantPhone     string
+		wantEmergency bool
+	}{
+		{name: "admin", target: "/transport/drivers/driver-1", caller: "admin-1", role: middleware.RoleAdmin, wantPhone: fullPhone, wantEmergency: true},
+		{name: "staff", target: "/transport/drivers/driver-1", caller: "staff-1", role: middleware.RoleStaff, wantPhone: maskedPhone},
+		{name: "staff with fields", target: "/transport/drivers/driver-1?fields=id,phone_number", caller: "staff-1", role: middleware.RoleStaff, wantPhone: maskedPhone},
+		{name: "staff by user", target: "/users/user-2/driver", userID: "user-2", caller: "staff-1", role: middleware.RoleStaff, wantPhone: maskedPhone},
+		// A driver reading their own profile isn't redacted
+		{name: "own driver profile", target: "/users/user-2/driver", userID: "user-2", caller: "user-2", role: middleware.RoleStaff, wantPhone: fullPhone, wantEmergency: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewStaffHandler(&driverStaffClient{}, nil, DefaultResultCap)
+			req := withCaller(httptest.NewRequest(http.MethodGet, tt.target, nil), tt.caller, tt.role)
+			rec := httptest.NewRecorder()
+			if tt.userID != "" {
+				req.SetPathValue("user_id", tt.userID)
+				h.HandleGetDriverByUserID(rec, req)
+			} else {
+				req.SetPathValue("id", "6b1f9a2c-7d3e-4f5a-8b9c-0d1e2f3a4b5c")
+				h.HandleGetDriver(rec, req)
+			}
+
+			driver, ok := decodeObject(t, rec)["driver"].(map[string]any)
+			if !ok {
+				t.Fatalf("response has no driver object: %s", rec.Body)
+			}
+			if driver["phoneNumber"] != tt.wantPhone {
+				t.Errorf("phoneNumber = %v, want %q", driver["phoneNumber"], tt.wantPhone)
+			}
+			for _, key := range []string{"emergencyContactName", "emergencyContactPhone"} {
+				if _, present := driver[key]; present != tt.wantEmergency {
+					t.Errorf("%s present = %t, want %t", key, present, tt.wantEmergency)
+				}
+			}
+		})
+	}
+}
+
+// statusStaffClient accepts every driver status change and merge
+type statusStaffClient struct {
+	staffproto.StaffServiceClient
+	updates []*staffproto.UpdateDriverStatusRequest
+	merges  []*staffproto.MergeDriversRequest
+}
+
+func (c *statusStaffClient) MergeDrivers(ctx context.Context, req *staffproto.MergeDriversRequest, opts ...grpc.CallOption) (*staffproto.MergeDriversResponse, error) {
+	c.merges = append(c.merges, req)
+	return &staffproto.MergeDriversResponse{Driver: &staffproto.Driver{Id: req.PrimaryDriverId}}, nil
+}
+
+func (c *statusStaffClient) UpdateDriverStatus(ctx context.Context, req *staffproto.UpdateDriverStatusRequest, opts ...grpc.CallOption) (*staffproto.UpdateDriverStatusResponse, error) {
+	c.updates = append(c.updates, req)
+	return &staffproto.UpdateDriverStatusResponse{Driver: &staffproto.Driver{Id: req.DriverId, Status: req.Status}}, nil
+}
+
+// assignmentVehicleClient knows the vehicle each driver in held holds
+type assignmentVehicleClient struct {
+	vehicleproto.VehicleServiceClient
+	held    map[string]string // driver ID -> license plate
+	flagged []*vehicleproto.FlagDriverAssignmentRequest
+}
+
+func (c *assignmentVehicleClient) GetDriverAssignment(ctx context.Context, req *vehicleproto.GetDriverAssignmentRequest, opts ...grpc.CallOption) (*vehicleproto.GetDriverAssignmentResponse, error) {
+	plate, ok := c.held[req.DriverId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "driver has no vehicle assigned")
+	}
+	return &vehicleproto.GetDriverAssignmentResponse{
+		Vehicle:    &vehicleproto.Vehicle{LicensePlate: plate},
+		Assignment: &vehicleproto.VehicleAssignment{Id: "7", DriverId: req.DriverId},
+	}, nil
+}
+
+func (c *assignmentVehicleClient) FlagDriverAssignment(ctx context.Context, req *vehicleproto.FlagDriverAssignmentRequest, opts ...grpc.CallOption) (*vehicleproto.FlagDriverAssignmentResponse, error) {
+	c.flagged = append(c.flagged, req)
+	return &vehicleproto.FlagDriverAssignmentResponse{Assignment: &vehicleproto.VehicleAssignment{Id: "7", AttentionReason: req.Reason}}, nil
+}
+
+func TestHandleUpdateDriverStatusAssignmentGuard(t *testing.T) {
+	const (
+		holding = "6b1f9a2c-7d3e-4f5a-8b9c-0d1e2f3a4b5c"
+		free    = "0e4d2c8a-1b3f-4a5d-9c7e-6f8a0b2d4c1e"
+	)
+
+	tests := []struct {
+		name        string
+		driverID    string
+		body        string
+		wantStatus  int
+		wantUpdated bool
+		wantFlagged bool
+	}{
+		{name: "suspend while holding a vehicle", driverID: holding, body: `{"status":"SUSPENDED","reason":"failed a random alcohol test"}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "deactivate while holding a vehicle", driverID: holding, body: `{"status":"INACTIVE","reason":"left the company last week"}`, wantStatus: http.StatusUnprocessableEntity},
+		{name: "forced suspension", driverID: holding, body: `{"status":"SUSPENDED","reason":"failed a random alcohol test","force":true}`, wantStatus: http.StatusOK, wantUpdated: true, wantFlagged: true},
+		{name: "forced deactivation", driverID: holding, body: `{"status":"INACTIVE","reason":"left the company last week","force":true}`, wantStatus: http.StatusOK, wantUpdated: true, wantFlagged: true},
+		{name: "suspend without a vehicle", driverID: free, body: `{"status":"SUSPENDED","reason":"failed a random alcohol test"}`, wantStatus: http.StatusOK, wantUpdated: true},
+		// Holding a vehicle is what ACTIVE drivers do
+		{name: "activate while holding a vehicle", driverID: holding, body: `{"status":"ACTIVE"}`, wantStatus: http.StatusOK, wantUpdated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staffClient := &statusStaffClient{}
+			vehicleClient := &assignmentVehicleClient{held: map[string]string{holding: "KDA 123A"}}
+			h := NewStaffHandler(staffClient, vehicleClient, DefaultResultCap)
+
+			req := httptest.NewRequest(http.MethodPatch, "/transport/drivers/"+tt.driverID+"/status", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.driverID)
+			rec := httptest.NewRecorder()
+			h.HandleUpdateDriverStatus(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if updated := len(staffClient.updates) == 1; updated != tt.wantUpdated {
+				t.Errorf("status changes = %d, want updated %t", len(staffClient.updates), tt.wantUpdated)
+			}
+			if flagged := len(vehicleClient.flagged) == 1; flagged != tt.wantFlagged {
+				t.Errorf("flagged assignments = %v, want flagged %t", vehicleClient.flagged, tt.wantFlagged)
+			}
+			if tt.wantStatus == http.StatusUnprocessableEntity && !strings.Contains(rec.Body.String(), "KDA 123A") {
+				t.Errorf("refusal doesn't name the vehicle: %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestHandleMergeDriversAssignmentGuard(t *testing.T) {
+	const (
+		primary   = "9a7c5e3b-1d2f-4a6b-8c0d-2e4f6a8b0c1d"
+		holding   = "6b1f9a2c-7d3e-4f5a-8b9c-0d1e2f3a4b5c"
+		duplicate = "0e4d2c8a-1b3f-4a5d-9c7e-6f8a0b2d4c1e"
+	)
+
+	tests := []struct {
+		name       string
+		duplicate  string
+		wantStatus int
+	}{
+		{name: "duplicate holds a vehicle", duplicate: holding, wantStatus: http.StatusUnprocessableEntity},
+		{name: "duplicate holds nothing", duplicate: duplicate, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staffClient := &statusStaffClient{}
+			vehicleClient := &assignmentVehicleClient{held: map[string]string{holding: "KDA 123A"}}
+			h := NewStaffHandler(staffClient, vehicleClient, DefaultResultCap)
+
+			body := `{"duplicate_driver_id":"` + tt.duplicate + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/transport/drivers/"+primary+"/merge", strings.NewReader(body))
+			req.SetPathValue("id", primary)
+			rec := httptest.NewRecorder()
+			h.HandleMergeDrivers(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			wantMerges := 0
+			if tt.wantStatus == http.StatusOK {
+				wantMerges = 1
+			}
+			if len(staffClient.merges) != wantMerges {
+				t.Errorf("merges = %d, want %d", len(staffClient.merges), wantMerges)
+			}
+		})
+	}
+}
@@ -2,38 +2,47 @@
 package handler
 
 import (
+	"bytes"
 	"context"
-	"crypto/rand"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"maps"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/adammwaniki/bebabeba/services/auth/authn/jwt"
-	"github.com/adammwaniki/bebabeba/services/auth/session"
 	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/redact"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
 	"github.com/gofrs/uuid/v5"
 	"golang.org/x/oauth2"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
 )
 
 // UserHandler handles HTTP requests for the user.UserService, including OAuth.
 type UserHandler struct {
 	userClient        userproto.UserServiceClient
-	googleOAuthConfig *oauth2.Config // Google OAuth2 configuration
-	// For simplicity, using an in-memory map for state.
-	// In production, we shall use a secure session store (e.g., Redis, database)
-	// to prevent CSRF and ensure state persistence across redirects.
-	oauthStates map[string]string // map[state]redirect_url
+	staffClient       staffproto.StaffServiceClient // driver profiles for ?expand=driver
+	oauthConfigs      map[string]*oauth2.Config // OAuth2 configuration of each enabled provider, keyed by name
+	oauthStates       OAuthStateStore           // CSRF states of logins waiting for the provider's callback
+	profileCache *ProfileCache
+	redaction    *redact.Policy // PII hidden from non-admin callers
+}
+
+// OAuthStateStore holds the state of each OAuth login between the redirect to the
+// provider and its callback, with the URL to send the user to afterwards.
+// Consume must be atomic: a state can only be consumed once, so a replayed callback is rejected.
+type OAuthStateStore interface {
+	Put(ctx context.Context, state, redirect string, ttl time.Duration) error
+	Consume(ctx context.Context, state string) (redirect string, ok bool, err error)
 }
 
 // LoginResponse for consistency across handlers
@@ -46,15 +55,26 @@ type LoginResponse struct {
 // NewUserHandler creates a new UserHandler.
 func NewUserHandler(
     userClient userproto.UserServiceClient,
-    googleOAuthConfig *oauth2.Config,
+    staffClient staffproto.StaffServiceClient,
+    oauthConfigs map[string]*oauth2.Config,
+    oauthStates OAuthStateStore,
+    profileCache *ProfileCache,
 ) *UserHandler {
     return &UserHandler{
         userClient:        userClient,
-        googleOAuthConfig: googleOAuthConfig,
-        oauthStates:       make(map[string]string),
+        staffClient:       staffClient,
+        oauthConfigs:      oauthConfigs,
+        oauthStates:       oauthStates,
+        profileCache:      profileCache,
+        redaction:         redact.DefaultPolicy(middleware.RoleAdmin),
     }
 }
 
+// SetRedaction sets the policy for user and driver PII shown to non-admin callers
+func (h *UserHandler) SetRedaction(policy *redact.Policy) {
+	h.redaction = policy
+}
+
 // HandleCreateUser handles POST requests to create a new user.
 func (h *UserHandler) HandleCreateUser(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
@@ -123,11 +143,41 @@ func (h *UserHandler) HandleGetUserByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Return the successful response.
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	// Users see their own details in full
+	if callerID, _ := middleware.GetUserIDFromContext(r.Context()); callerID == resp.GetId() {
+		utils.WriteProtoJSON(w, http.StatusOK, resp)
+		return
+	}
+	role, _ := middleware.GetRoleFromContext(r.Context())
+	writeRedactedProtoJSON(w, http.StatusOK, resp, "", nil, h.redaction, role)
+}
+
+// HandleGetUserByEmail handles GET requests to look a user up by email, given as the
+// email query parameter. Case is ignored.
+func (h *UserHandler) HandleGetUserByEmail(w http.ResponseWriter, r *http.Request) {
+	email := strings.TrimSpace(r.URL.Query().Get("email"))
+	if email == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("email query parameter is required"))
+		return
+	}
+
+	// Set a context with timeout for the gRPC call.
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// The user service validates the email format
+	resp, err := h.userClient.GetUserByEmail(ctx, &userproto.GetUserByEmailRequest{Email: email})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	role, _ := middleware.GetRoleFromContext(r.Context())
+	writeRedactedProtoJSON(w, http.StatusOK, resp, "", nil, h.redaction, role)
 }
 
 // HandleListUsers handles GET requests to list users with pagination.
+// ?expand=driver adds a "drivers" object mapping user IDs to their driver profiles.
 func (h *UserHandler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
 	pageSize := int32(50) // Default page size
 	if ps := r.URL.Query().Get("page_size"); ps != "" {
@@ -142,6 +192,19 @@ func (h *UserHandler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
 		PageToken: r.URL.Query().Get("page_token"),
 	}
 
+	// Dormant account filter accepts either a date or a full RFC3339 timestamp
+	if since := r.URL.Query().Get("inactive_since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", since)
+		}
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid inactive_since %q, expected YYYY-MM-DD or RFC3339", since))
+			return
+		}
+		grpcReq.InactiveSince = timestamppb.New(t)
+	}
+
 	// Set a context with timeout for the gRPC call.
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second) // Adjust timeout for potential large lists
 	defer cancel()
@@ -153,185 +216,86 @@ func (h *UserHandler) HandleListUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Return the successful response.
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
-}
-
-// HandleGoogleLogin initiates the Google OAuth2 login flow.
-func (h *UserHandler) HandleGoogleLogin(w http.ResponseWriter, r *http.Request) {
-	log.Println("DEBUG: HandleGoogleLogin initiated.")
-	// Generate a cryptographically secure random state to prevent CSRF attacks.
-	stateBytes := make([]byte, 32)
-	_, err := rand.Read(stateBytes)
-	if err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to generate state: %w", err))
-		return
-	}
-	state := base64.RawURLEncoding.EncodeToString(stateBytes)
-
-	// Store the state. In a real application, this should be stored securely
-	// in a session or a cookie, not in an in-memory map.
-	h.oauthStates[state] = "/" // Default redirect to root or a specified target
-	log.Printf("DEBUG: Generated OAuth state: %s", state)
-
-	// Redirect the user to Google's consent screen.
-	url := h.googleOAuthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce) // Request refresh token
-	log.Printf("DEBUG: Redirecting to Google Auth URL: %s", url)
-	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
-}
-
-// HandleGoogleCallbackWithJWT handles the redirect from Google after user authorization with JWT and session management
-func (h *UserHandler) HandleGoogleCallbackWithJWT(sessionManager *session.SessionManager, w http.ResponseWriter, r *http.Request) {
-	log.Println("DEBUG: HandleGoogleCallback initiated.")
-	
-	// Verify the 'state' parameter to prevent CSRF
-	state := r.URL.Query().Get("state")
-	log.Printf("DEBUG: Received state parameter: %s", state)
-	if _, ok := h.oauthStates[state]; !ok {
-		utils.WriteError(w, http.StatusBadRequest, errors.New("invalid or missing OAuth state parameter"))
+	role, _ := middleware.GetRoleFromContext(r.Context())
+	if r.URL.Query().Get("expand") != "driver" {
+		// Return the successful response.
+		writeRedactedProtoJSON(w, http.StatusOK, resp, "", nil, h.redaction, role)
 		return
 	}
-	delete(h.oauthStates, state)
-	log.Println("DEBUG: OAuth state verified and removed.")
 
-	// Check for errors from Google
-	if authErr := r.URL.Query().Get("error"); authErr != "" {
-		errorDescription := r.URL.Query().Get("error_description")
-		log.Printf("ERROR: OAuth authorization failed from Google: %s - %s", authErr, errorDescription)
-		utils.WriteError(w, http.StatusUnauthorized, fmt.Errorf("OAuth authorization failed: %s - %s", authErr, errorDescription))
-		return
+	// ?expand=driver adds a user ID -> driver map for the whole page
+	userIDs := make([]string, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		userIDs = append(userIDs, user.Id)
 	}
-
-	// Get the authorization code
-	code := r.URL.Query().Get("code")
-	log.Printf("DEBUG: Received authorization code (first 10 chars): %s...", code[:min(10, len(code))])
-	if code == "" {
-		utils.WriteError(w, http.StatusBadRequest, errors.New("missing authorization code"))
+	driversResp, err := h.driversByUserIDs(ctx, userIDs)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
 		return
 	}
 
-	// Exchange the authorization code for an OAuth2 token
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-	log.Println("DEBUG: Attempting to exchange authorization code for token...")
-	token, err := h.googleOAuthConfig.Exchange(ctx, code)
+	marshaler := protojson.MarshalOptions{EmitUnpopulated: true}
+	listJSON, err := marshaler.Marshal(resp)
 	if err != nil {
-		log.Printf("ERROR: Failed to exchange authorization code for token: %v", err)
-		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to exchange authorization code for token: %w", err))
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal users: %w", err))
 		return
 	}
-	log.Println("DEBUG: Successfully exchanged code for token.")
-
-	// Get user information from Google
-	userInfoClient := h.googleOAuthConfig.Client(ctx, token)
-	log.Println("DEBUG: Attempting to fetch user info from Google UserInfo endpoint...")
-	userInfoResp, err := userInfoClient.Get("https://www.googleapis.com/oauth2/v3/userinfo")
+	driversJSON, err := marshaler.Marshal(driversResp)
 	if err != nil {
-		log.Printf("ERROR: Failed to get user info from Google: %v", err)
-		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to get user info from Google: %w", err))
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal drivers: %w", err))
 		return
 	}
-	defer userInfoResp.Body.Close()
 
-	if userInfoResp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(userInfoResp.Body)
-		log.Printf("ERROR: Google user info API returned non-200 status: %d, body: %s", userInfoResp.StatusCode, string(bodyBytes))
-		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("google user info API returned non-200 status: %d", userInfoResp.StatusCode))
+	// UseNumber keeps numeric values exactly as protojson wrote them
+	var body map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(listJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to build response: %w", err))
 		return
 	}
-
-	var googleUserInfo struct {
-		ID        string `json:"sub"`
-		Email     string `json:"email"`
-		FirstName string `json:"given_name"`
-		LastName  string `json:"family_name"`
+	var drivers struct {
+		Drivers map[string]any `json:"drivers"`
 	}
-	
-	if err := json.NewDecoder(userInfoResp.Body).Decode(&googleUserInfo); err != nil {
-		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to parse Google user info: %w", err))
+	decoder = json.NewDecoder(bytes.NewReader(driversJSON))
+	decoder.UseNumber()
+	if err := decoder.Decode(&drivers); err != nil {
+		utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to build response: %w", err))
 		return
 	}
-	log.Printf("DEBUG: Successfully decoded Google user info. Email: %s, SSO ID: %s", googleUserInfo.Email, googleUserInfo.ID)
+	body["drivers"] = drivers.Drivers
+	h.redaction.Object(role, body)
 
-	// Try to get existing user by SSO ID
-	getUserReq := &userproto.GetUserBySSOIDRequest{SsoId: googleUserInfo.ID}
-	userResp, err := h.userClient.GetUserBySSOID(ctx, getUserReq)
+	utils.WriteJSON(w, http.StatusOK, body)
+}
 
-	if err != nil {
-		st, ok := status.FromError(err)
-		if ok && st.Code() == codes.NotFound {
-			// User not found, create new user
-			log.Printf("DEBUG: User with SSO ID '%s' not found. Creating new user.", googleUserInfo.ID)
-			createReq := &userproto.CreateUserRequest{
-				User: &userproto.RegistrationRequest{
-					FirstName: googleUserInfo.FirstName,
-					LastName:  googleUserInfo.LastName,
-					Email:     googleUserInfo.Email,
-					AuthMethod: &userproto.RegistrationRequest_SsoId{SsoId: googleUserInfo.ID},
-				},
-			}
-			
-			createResp, createErr := h.userClient.CreateUser(ctx, createReq)
-			if createErr != nil {
-				log.Printf("ERROR: Failed to create new SSO user: %v", createErr)
-				utils.HandleGRPCError(w, createErr)
-				return
-			}
-			
-			// Convert to GetUserResponse format
-			userResp = &userproto.GetUserResponse{
-				Id: createResp.Id,
-				FirstName: createResp.FirstName,
-				LastName: createResp.LastName,
-				Status: createResp.Status,
-				Email: createResp.Email,
-				TermsAcceptedAt: createResp.TermsAcceptedAt,
-				CreatedAt: createResp.CreatedAt,
-			}
-		} else {
-			log.Printf("ERROR: GetUserBySSOID returned unexpected error: %v", err)
-			utils.HandleGRPCError(w, err)
-			return
+// maxDriversPerLookup matches the staff service's cap on user IDs per GetDriversByUserIDs
+// call. PAGE_SIZE_MAX can raise the user page size past it, so larger pages are looked
+// up in several calls.
+const maxDriversPerLookup = 100
+
+// driversByUserIDs fetches the drivers for userIDs in as few staff service calls as the
+// per-call cap allows, merging the results
+func (h *UserHandler) driversByUserIDs(ctx context.Context, userIDs []string) (*staffproto.GetDriversByUserIDsResponse, error) {
+	merged := &staffproto.GetDriversByUserIDsResponse{Drivers: make(map[string]*staffproto.Driver, len(userIDs))}
+	for start := 0; start < len(userIDs); start += maxDriversPerLookup {
+		end := min(start+maxDriversPerLookup, len(userIDs))
+		resp, err := h.staffClient.GetDriversByUserIDs(ctx, &staffproto.GetDriversByUserIDsRequest{UserIds: userIDs[start:end]})
+		if err != nil {
+			return nil, err
 		}
+		maps.Copy(merged.Drivers, resp.Drivers)
 	}
+	return merged, nil
+}
 
-	// Check user status
-	if userResp.GetStatus() != userproto.UserStatusEnum_ACTIVE {
-		utils.WriteError(w, http.StatusForbidden, errors.New("user account is not active"))
-		return
-	}
-
-	// Create session with JWT tokens
-	sessionResp, err := sessionManager.CreateSession(
-		ctx,
-		userResp.Id,
-		userResp.Email,
-		userResp.FirstName,
-		userResp.LastName,
-		r,
-	)
-	if err != nil {
-		log.Printf("Failed to create session for SSO user: %v", err)
-		// Still return success but without session
-		utils.WriteProtoJSON(w, http.StatusOK, userResp)
-		return
-	}
-
-	// Return successful response with session and tokens
-	response := struct {
-		User         *userproto.GetUserResponse `json:"user"`
-		TokenData    *jwt.TokenPair             `json:"token_data"`
-		SessionID    string                     `json:"session_id"`
-		Message      string                     `json:"message"`
-	}{
-		User:      userResp,
-		TokenData: sessionResp.TokenData,
-		SessionID: sessionResp.Session.ID,
-		Message:   "SSO authentication successful",
+// postLoginRedirect returns where to send the user after signing in. Only paths on this
+// site are accepted, so the login flow can't be used to bounce users to another origin.
+func postLoginRedirect(target string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return "/"
 	}
-
-	log.Printf("User %s successfully authenticated via Google SSO with session %s", userResp.GetEmail(), sessionResp.Session.ID)
-	utils.WriteJSON(w, http.StatusOK, response)
+	return target
 }
 
 
@@ -399,6 +363,7 @@ func (h *UserHandler) HandleUpdateUserByID(w http.ResponseWriter, r *http.Reques
 		utils.HandleGRPCError(w, err)
 		return
 	}
+	h.profileCache.InvalidateUser(parsedUUID.String())
 
 	// Return the successful response
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
@@ -434,6 +399,7 @@ func (h *UserHandler) HandleDeleteUserByID(w http.ResponseWriter, r *http.Reques
 		utils.HandleGRPCError(w, err)
 		return
 	}
+	h.profileCache.InvalidateUser(parsedUUID.String())
 
 	// Return success with no content
 	w.WriteHeader(http.StatusNoContent)
@@ -0,0 +1,170 @@
+// services/gateway/internal/handler/user_test.go
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	userproto "github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// driverLookupClient answers GetDriversByUserIDs like the staff service: every user has a
+// driver except those in missing, and a call over the cap is refused
+type driverLookupClient struct {
+	staffproto.StaffServiceClient
+	missing map[string]bool
+	calls   []int // user IDs per call
+}
+
+func (c *driverLookupClient) GetDriversByUserIDs(ctx context.Context, req *staffproto.GetDriversByUserIDsRequest, opts ...grpc.CallOption) (*staffproto.GetDriversByUserIDsResponse, error) {
+	c.calls = append(c.calls, len(req.UserIds))
+	if len(req.UserIds) > maxDriversPerLookup {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d user IDs can be looked up per request", maxDriversPerLookup)
+	}
+	drivers := make(map[string]*staffproto.Driver)
+	for _, userID := range req.UserIds {
+		if !c.missing[userID] {
+			drivers[userID] = &staffproto.Driver{Id: "driver-" + userID, UserId: userID}
+		}
+	}
+	return &staffproto.GetDriversByUserIDsResponse{Drivers: drivers}, nil
+}
+
+func TestDriversByUserIDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		users     int
+		wantCalls []int
+	}{
+		{name: "empty page", users: 0},
+		{name: "one call", users: 40, wantCalls: []int{40}},
+		{name: "exactly the cap", users: maxDriversPerLookup, wantCalls: []int{100}},
+		// PAGE_SIZE_MAX allows pages past the staff service's cap
+		{name: "over the cap", users: 250, wantCalls: []int{100, 100, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &driverLookupClient{missing: map[string]bool{"user-7": true}}
+			h := &UserHandler{staffClient: client}
+
+			userIDs := make([]string, tt.users)
+			for i := range userIDs {
+				userIDs[i] = fmt.Sprintf("user-%d", i)
+			}
+
+			resp, err := h.driversByUserIDs(context.Background(), userIDs)
+			if err != nil {
+				t.Fatalf("driversByUserIDs: %v", err)
+			}
+			if fmt.Sprint(client.calls) != fmt.Sprint(tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", client.calls, tt.wantCalls)
+			}
+			for _, userID := range userIDs {
+				driver, ok := resp.Drivers[userID]
+				if userID == "user-7" {
+					if ok {
+						t.Errorf("user-7 has no driver profile but got %v", driver)
+					}
+					continue
+				}
+				if !ok || driver.UserId != userID {
+					t.Errorf("drivers[%s] = %v, want that user's driver", userID, driver)
+				}
+			}
+		})
+	}
+}
+
+// directoryUserClient knows one user per ID, each with an example.com email
+type directoryUserClient struct {
+	userproto.UserServiceClient
+}
+
+func testUser(id string) *userproto.GetUserResponse {
+	return &userproto.GetUserResponse{Id: id, FirstName: "Jane", Email: "jane." + id + "@example.com"}
+}
+
+func (c *directoryUserClient) GetUserByID(ctx context.Context, req *userproto.GetUserRequest, opts ...grpc.CallOption) (*userproto.GetUserResponse, error) {
+	return testUser(req.UserId), nil
+}
+
+func (c *directoryUserClient) ListUsers(ctx context.Context, req *userproto.ListUsersRequest, opts ...grpc.CallOption) (*userproto.ListUsersResponse, error) {
+	return &userproto.ListUsersResponse{Users: []*userproto.GetUserResponse{testUser("user-1"), testUser("user-2")}, TotalCount: 2}, nil
+}
+
+func TestHandleGetUserByIDRedaction(t *testing.T) {
+	const userID = "2c8e4b1a-9f3d-4e6a-b7c5-1d0f8e2a3b4c"
+
+	tests := []struct {
+		name      string
+		caller    string
+		role      string
+		wantEmail string
+	}{
+		{name: "admin", caller: "admin-1", role: middleware.RoleAdmin, wantEmail: "jane." + userID + "@example.com"},
+		{name: "staff", caller: "staff-1", role: middleware.RoleStaff, wantEmail: "j***@example.com"},
+		{name: "own record", caller: userID, role: middleware.RoleStaff, wantEmail: "jane." + userID + "@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewUserHandler(&directoryUserClient{}, nil, nil, nil, nil)
+			req := withCaller(httptest.NewRequest(http.MethodGet, "/users/"+userID, nil), tt.caller, tt.role)
+			req.SetPathValue("id", userID)
+			rec := httptest.NewRecorder()
+			h.HandleGetUserByID(rec, req)
+
+			if email := decodeObject(t, rec)["email"]; email != tt.wantEmail {
+				t.Errorf("email = %v, want %q", email, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestHandleListUsersRedaction(t *testing.T) {
+	for _, target := range []string{"/users", "/users?expand=driver"} {
+		t.Run(target, func(t *testing.T) {
+			h := NewUserHandler(&directoryUserClient{}, &driverStaffClient{}, nil, nil, nil)
+			req := withCaller(httptest.NewRequest(http.MethodGet, target, nil), "staff-1", middleware.RoleStaff)
+			rec := httptest.NewRecorder()
+			h.HandleListUsers(rec, req)
+
+			body := decodeObject(t, rec)
+			users, _ := body["users"].([]any)
+			if len(users) != 2 {
+				t.Fatalf("users = %v, want two", body["users"])
+			}
+			for _, item := range users {
+				if email := item.(map[string]any)["email"]; email != "j***@example.com" {
+					t.Errorf("email = %v, want it masked", email)
+				}
+			}
+
+			if target == "/users" {
+				return
+			}
+			drivers, _ := body["drivers"].(map[string]any)
+			if len(drivers) != 2 {
+				t.Fatalf("drivers = %v, want one per user", body["drivers"])
+			}
+			for userID, item := range drivers {
+				driver := item.(map[string]any)
+				if driver["phoneNumber"] != "***678" {
+					t.Errorf("drivers[%s].phoneNumber = %v, want it masked", userID, driver["phoneNumber"])
+				}
+				if _, ok := driver["emergencyContactPhone"]; ok {
+					t.Errorf("drivers[%s] still carries emergencyContactPhone", userID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// services/gateway/internal/handler/validate.go
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	staffproto "github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+)
+
+// ValidateRequest is the payload of the /validate endpoints
+type ValidateRequest struct {
+	Value string `json:"value"`
+}
+
+// readValidateRequest reads the value to check. An empty value is left to the
+// validator, which reports it like any other invalid input.
+func readValidateRequest(w http.ResponseWriter, r *http.Request) (string, bool) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return "", false
+	}
+	defer r.Body.Close()
+
+	var req ValidateRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return "", false
+	}
+	if len(req.Value) > 64 {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("value cannot be more than 64 characters"))
+		return "", false
+	}
+	return req.Value, true
+}
+
+// HandleValidateLicensePlate handles POST requests to check a license plate's format.
+// Nothing is stored, and a well-formed plate may still belong to another vehicle.
+func (h *VehicleHandler) HandleValidateLicensePlate(w http.ResponseWriter, r *http.Request) {
+	value, ok := readValidateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.ValidateLicensePlate(ctx, &vehicleproto.ValidateLicensePlateRequest{LicensePlate: value})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleValidatePhoneNumber handles POST requests to check a phone number's format
+func (h *StaffHandler) HandleValidatePhoneNumber(w http.ResponseWriter, r *http.Request) {
+	value, ok := readValidateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.ValidatePhoneNumber(ctx, &staffproto.ValidatePhoneNumberRequest{PhoneNumber: value})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleValidateLicenseNumber handles POST requests to check a driving license number's format
+func (h *StaffHandler) HandleValidateLicenseNumber(w http.ResponseWriter, r *http.Request) {
+	value, ok := readValidateRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.staffClient.ValidateLicenseNumber(ctx, &staffproto.ValidateLicenseNumberRequest{LicenseNumber: value})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
@@ -2,30 +2,40 @@
 package handler
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
 	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	"github.com/gofrs/uuid/v5"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // VehicleHandler handles HTTP requests for the vehicle service
 type VehicleHandler struct {
 	vehicleClient vehicleproto.VehicleServiceClient
+	resultCap     ResultCap
+	importLimits  VehicleImportLimits
 }
 
 // NewVehicleHandler creates a new vehicle handler
-func NewVehicleHandler(vehicleClient vehicleproto.VehicleServiceClient) *VehicleHandler {
+func NewVehicleHandler(vehicleClient vehicleproto.VehicleServiceClient, resultCap ResultCap) *VehicleHandler {
 	return &VehicleHandler{
 		vehicleClient: vehicleClient,
+		resultCap:     resultCap,
+		importLimits:  DefaultVehicleImportLimits,
 	}
 }
 
@@ -45,13 +55,29 @@ func (h *VehicleHandler) HandleCreateVehicle(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ?admin_override=true reuses the plate of a recently retired vehicle, admins only
+	adminOverride := r.URL.Query().Get("admin_override") == "true"
+	if adminOverride {
+		if role, _ := middleware.GetRoleFromContext(r.Context()); role != middleware.RoleAdmin {
+			utils.WriteError(w, http.StatusForbidden, errors.New("admin_override requires admin access"))
+			return
+		}
+	}
+
 	// Create the gRPC request
 	grpcReq := &vehicleproto.CreateVehicleRequest{
-		Vehicle: &vehicleInput,
+		Vehicle:       &vehicleInput,
+		AdminOverride: adminOverride,
+	}
+
+	ctx, err := withIdempotencyKey(r.Context(), r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
 	}
 
 	// Set context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	// Call the gRPC service
@@ -84,6 +110,12 @@ func (h *VehicleHandler) HandleGetVehicle(w http.ResponseWriter, r *http.Request
 		VehicleId: vehicleIDStr,
 	}
 
+	fields, err := parseFields(r, &vehicleproto.Vehicle{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
 	// Set context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -95,7 +127,40 @@ func (h *VehicleHandler) HandleGetVehicle(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	writeProtoJSONFields(w, http.StatusOK, resp, "vehicle", fields)
+}
+
+// HandleGetVehicleByChassisNumber handles GET requests to look a vehicle up by the
+// chassis number in its chassis_number query parameter
+func (h *VehicleHandler) HandleGetVehicleByChassisNumber(w http.ResponseWriter, r *http.Request) {
+	chassisNumber := strings.TrimSpace(r.URL.Query().Get("chassis_number"))
+	if chassisNumber == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("chassis_number is required"))
+		return
+	}
+
+	grpcReq := &vehicleproto.GetVehicleByChassisNumberRequest{
+		ChassisNumber: chassisNumber,
+	}
+
+	fields, err := parseFields(r, &vehicleproto.Vehicle{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.GetVehicleByChassisNumber(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	writeProtoJSONFields(w, http.StatusOK, resp, "vehicle", fields)
 }
 
 // HandleListVehicles handles GET requests to list vehicles
@@ -108,11 +173,39 @@ func (h *VehicleHandler) HandleListVehicles(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Create gRPC request
-	grpcReq := &vehicleproto.ListVehiclesRequest{
-		PageSize:  pageSize,
-		PageToken: r.URL.Query().Get("page_token"),
+	grpcReq, err := listVehiclesFilters(r)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	grpcReq.PageSize = pageSize
+	grpcReq.PageToken = r.URL.Query().Get("page_token")
+
+	fields, err := parseFields(r, &vehicleproto.Vehicle{})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.ListVehicles(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
 	}
 
+	writeProtoJSONFields(w, http.StatusOK, resp, "vehicles", fields)
+}
+
+// listVehiclesFilters builds a ListVehiclesRequest from the filters in the query string,
+// shared by the vehicle listing and its CSV export
+func listVehiclesFilters(r *http.Request) (*vehicleproto.ListVehiclesRequest, error) {
+	grpcReq := &vehicleproto.ListVehiclesRequest{}
+
 	// Handle filters
 	if status := r.URL.Query().Get("status"); status != "" {
 		if statusVal, ok := vehicleproto.VehicleStatus_value[status]; ok {
@@ -128,18 +221,25 @@ func (h *VehicleHandler) HandleListVehicles(w http.ResponseWriter, r *http.Reque
 		grpcReq.MakeFilter = &make
 	}
 
-	// Set context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
+	// search matches make, model or license plate
+	if search := r.URL.Query().Get("search"); search != "" {
+		grpcReq.Search = &search
+	}
 
-	// Call the gRPC service
-	resp, err := h.vehicleClient.ListVehicles(ctx, grpcReq)
-	if err != nil {
-		utils.HandleGRPCError(w, err)
-		return
+	// make_match is exact, prefix (default) or contains
+	if makeMatch := r.URL.Query().Get("make_match"); makeMatch != "" {
+		matchVal, ok := vehicleproto.MakeMatch_value["MAKE_"+strings.ToUpper(makeMatch)]
+		if !ok {
+			return nil, fmt.Errorf("invalid make_match %q, expected exact, prefix or contains", makeMatch)
+		}
+		grpcReq.MakeMatch = vehicleproto.MakeMatch(matchVal)
 	}
 
-	utils.WriteProtoJSON(w, http.StatusOK, resp)
+	// sort_by and sort_order are checked against the vehicle service's allowlist there
+	grpcReq.SortBy = r.URL.Query().Get("sort_by")
+	grpcReq.SortOrder = r.URL.Query().Get("sort_order")
+
+	return grpcReq, nil
 }
 
 // HandleUpdateVehicle handles PUT requests to update a vehicle
@@ -320,6 +420,87 @@ func (h *VehicleHandler) HandleGetAvailableVehicles(w http.ResponseWriter, r *ht
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
 }
 
+// HandleListRecentlyUpdatedVehicles handles GET requests for the recently modified vehicles feed
+func (h *VehicleHandler) HandleListRecentlyUpdatedVehicles(w http.ResponseWriter, r *http.Request) {
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	// Create gRPC request
+	grpcReq := &vehicleproto.ListRecentlyUpdatedVehiclesRequest{
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.ListRecentlyUpdatedVehicles(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleGetDispatchCandidates handles GET requests for vehicles dispatch can send out:
+// active, of the requested type, seating at least min_seats and insured on the given date
+func (h *VehicleHandler) HandleGetDispatchCandidates(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	pageSize := int32(50) // Default page size
+	if ps := query.Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	grpcReq := &vehicleproto.GetDispatchCandidatesRequest{
+		PageSize:  pageSize,
+		PageToken: query.Get("page_token"),
+	}
+
+	if vehicleType := query.Get("vehicle_type"); vehicleType != "" {
+		grpcReq.VehicleTypeId = &vehicleType
+	}
+
+	if ms := query.Get("min_seats"); ms != "" {
+		n, err := strconv.Atoi(ms)
+		if err != nil || n < 0 {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid min_seats: %s", ms))
+			return
+		}
+		grpcReq.MinSeats = int32(n)
+	}
+
+	if on := query.Get("insurance_valid_on"); on != "" {
+		date, err := time.Parse("2006-01-02", on)
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid insurance_valid_on, expected YYYY-MM-DD: %w", err))
+			return
+		}
+		grpcReq.InsuranceValidOn = timestamppb.New(date)
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.GetDispatchCandidates(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
 // HandleUpdateVehicleStatus handles PATCH requests to update vehicle status
 func (h *VehicleHandler) HandleUpdateVehicleStatus(w http.ResponseWriter, r *http.Request) {
 	vehicleIDStr := r.PathValue("id")
@@ -344,7 +525,9 @@ func (h *VehicleHandler) HandleUpdateVehicleStatus(w http.ResponseWriter, r *htt
 	defer r.Body.Close()
 
 	var statusRequest struct {
-		Status string `json:"status"`
+		Status        string `json:"status"`
+		AdminOverride bool   `json:"admin_override"`
+		Reason        string `json:"reason,omitempty"`
 	}
 
 	if err := json.Unmarshal(body, &statusRequest); err != nil {
@@ -359,10 +542,20 @@ func (h *VehicleHandler) HandleUpdateVehicleStatus(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// admin_override skips the transition rules, admins only
+	if statusRequest.AdminOverride {
+		if role, _ := middleware.GetRoleFromContext(r.Context()); role != middleware.RoleAdmin {
+			utils.WriteError(w, http.StatusForbidden, errors.New("admin_override requires admin access"))
+			return
+		}
+	}
+
 	// Create gRPC request
 	grpcReq := &vehicleproto.UpdateVehicleStatusRequest{
-		VehicleId: vehicleIDStr,
-		Status:    vehicleproto.VehicleStatus(statusVal),
+		VehicleId:     vehicleIDStr,
+		Status:        vehicleproto.VehicleStatus(statusVal),
+		AdminOverride: statusRequest.AdminOverride,
+		Reason:        statusRequest.Reason,
 	}
 
 	// Set context with timeout
@@ -379,10 +572,20 @@ func (h *VehicleHandler) HandleUpdateVehicleStatus(w http.ResponseWriter, r *htt
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
 }
 
-// Vehicle type management
+// HandleRecordVehicleMileage handles PATCH requests to record a vehicle's odometer reading
+func (h *VehicleHandler) HandleRecordVehicleMileage(w http.ResponseWriter, r *http.Request) {
+	vehicleIDStr := r.PathValue("id")
+	if vehicleIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(vehicleIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid vehicle ID format: %w", err))
+		return
+	}
 
-// HandleCreateVehicleType handles POST requests to create a vehicle type
-func (h *VehicleHandler) HandleCreateVehicleType(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
@@ -390,28 +593,81 @@ func (h *VehicleHandler) HandleCreateVehicleType(w http.ResponseWriter, r *http.
 	}
 	defer r.Body.Close()
 
-	var typeRequest struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+	var mileageRequest struct {
+		MileageKm *int32 `json:"mileage_km"`
 	}
-
-	if err := json.Unmarshal(body, &typeRequest); err != nil {
+	if err := json.Unmarshal(body, &mileageRequest); err != nil {
 		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
 		return
 	}
+	if mileageRequest.MileageKm == nil {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("mileage_km is required"))
+		return
+	}
 
-	// Create gRPC request
-	grpcReq := &vehicleproto.CreateVehicleTypeRequest{
-		Name:        typeRequest.Name,
-		Description: typeRequest.Description,
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.RecordVehicleMileage(ctx, &vehicleproto.RecordVehicleMileageRequest{
+		VehicleId: vehicleIDStr,
+		MileageKm: *mileageRequest.MileageKm,
+	})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleRecordService handles POST requests recording a service of a vehicle. Every field
+// is optional: mileage_km defaults to the vehicle's current mileage and serviced_at, an
+// RFC 3339 time, to now.
+func (h *VehicleHandler) HandleRecordService(w http.ResponseWriter, r *http.Request) {
+	vehicleIDStr := r.PathValue("id")
+	if vehicleIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(vehicleIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid vehicle ID format: %w", err))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var serviceRequest struct {
+		MileageKm  *int32     `json:"mileage_km,omitempty"`
+		ServicedAt *time.Time `json:"serviced_at,omitempty"`
+		Notes      string     `json:"notes,omitempty"`
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &serviceRequest); err != nil {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+			return
+		}
+	}
+
+	grpcReq := &vehicleproto.RecordServiceRequest{
+		VehicleId: vehicleIDStr,
+		MileageKm: serviceRequest.MileageKm,
+		Notes:     serviceRequest.Notes,
+	}
+	if serviceRequest.ServicedAt != nil {
+		grpcReq.ServicedAt = timestamppb.New(*serviceRequest.ServicedAt)
 	}
 
-	// Set context with timeout
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
-	// Call the gRPC service
-	resp, err := h.vehicleClient.CreateVehicleType(ctx, grpcReq)
+	resp, err := h.vehicleClient.RecordService(ctx, grpcReq)
 	if err != nil {
 		utils.HandleGRPCError(w, err)
 		return
@@ -420,31 +676,698 @@ func (h *VehicleHandler) HandleCreateVehicleType(w http.ResponseWriter, r *http.
 	utils.WriteProtoJSON(w, http.StatusCreated, resp)
 }
 
-// HandleListVehicleTypes handles GET requests to list vehicle types
-func (h *VehicleHandler) HandleListVehicleTypes(w http.ResponseWriter, r *http.Request) {
+// HandleGetVehiclesDueForMaintenance handles GET requests for the vehicles due for
+// maintenance. km_threshold and days_threshold override the configured interval.
+func (h *VehicleHandler) HandleGetVehiclesDueForMaintenance(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
 	pageSize := int32(50) // Default page size
-	if ps := r.URL.Query().Get("page_size"); ps != "" {
+	if ps := query.Get("page_size"); ps != "" {
 		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
 			pageSize = int32(n)
 		}
 	}
 
-	// Create gRPC request
-	grpcReq := &vehicleproto.ListVehicleTypesRequest{
+	grpcReq := &vehicleproto.GetVehiclesDueForMaintenanceRequest{
 		PageSize:  pageSize,
-		PageToken: r.URL.Query().Get("page_token"),
+		PageToken: query.Get("page_token"),
+	}
+	var err error
+	if grpcReq.KmThreshold, err = optionalInt32Query(query, "km_threshold"); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	if grpcReq.DaysThreshold, err = optionalInt32Query(query, "days_threshold"); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
 	}
 
-	// Set context with timeout
-	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	// Call the gRPC service
-	resp, err := h.vehicleClient.ListVehicleTypes(ctx, grpcReq)
+	resp, err := h.vehicleClient.GetVehiclesDueForMaintenance(ctx, grpcReq)
 	if err != nil {
 		utils.HandleGRPCError(w, err)
 		return
 	}
 
 	utils.WriteProtoJSON(w, http.StatusOK, resp)
-}
\ No newline at end of file
+}
+
+// optionalInt32Query parses the named query parameter, returning nil when it's absent
+func optionalInt32Query(query url.Values, name string) (*int32, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q", name, value)
+	}
+	parsed := int32(n)
+	return &parsed, nil
+}
+
+// HandleValidateVehicleStatusChange handles POST requests asking whether a status change
+// would be allowed. It takes the same body as HandleUpdateVehicleStatus and changes nothing.
+func (h *VehicleHandler) HandleValidateVehicleStatusChange(w http.ResponseWriter, r *http.Request) {
+	vehicleIDStr := r.PathValue("id")
+	if vehicleIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(vehicleIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid vehicle ID format: %w", err))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var statusRequest struct {
+		Status        string `json:"status"`
+		AdminOverride bool   `json:"admin_override"`
+	}
+
+	if err := json.Unmarshal(body, &statusRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	// Validate status
+	statusVal, ok := vehicleproto.VehicleStatus_value[statusRequest.Status]
+	if !ok {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid status: %s", statusRequest.Status))
+		return
+	}
+
+	// admin_override skips the transition rules, admins only
+	if statusRequest.AdminOverride {
+		if role, _ := middleware.GetRoleFromContext(r.Context()); role != middleware.RoleAdmin {
+			utils.WriteError(w, http.StatusForbidden, errors.New("admin_override requires admin access"))
+			return
+		}
+	}
+
+	grpcReq := &vehicleproto.ValidateVehicleStatusChangeRequest{
+		VehicleId:     vehicleIDStr,
+		Status:        vehicleproto.VehicleStatus(statusVal),
+		AdminOverride: statusRequest.AdminOverride,
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.ValidateVehicleStatusChange(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleAssignVehicle handles POST requests to hand an ACTIVE vehicle to a driver
+func (h *VehicleHandler) HandleAssignVehicle(w http.ResponseWriter, r *http.Request) {
+	vehicleIDStr := r.PathValue("id")
+	if vehicleIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(vehicleIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid vehicle ID format: %w", err))
+		return
+	}
+
+	// Read and parse request body
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var assignRequest struct {
+		DriverID string `json:"driver_id"`
+	}
+
+	if err := json.Unmarshal(body, &assignRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	if assignRequest.DriverID == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("driver_id is required"))
+		return
+	}
+	if _, err := uuid.FromString(assignRequest.DriverID); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid driver ID format: %w", err))
+		return
+	}
+
+	grpcReq := &vehicleproto.AssignVehicleRequest{
+		VehicleId: vehicleIDStr,
+		DriverId:  assignRequest.DriverID,
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.AssignVehicle(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleGetVehicleStatusHistory handles GET requests for a vehicle's status transitions, most recent first
+func (h *VehicleHandler) HandleGetVehicleStatusHistory(w http.ResponseWriter, r *http.Request) {
+	vehicleIDStr := r.PathValue("id")
+	if vehicleIDStr == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle ID is required"))
+		return
+	}
+
+	// Validate UUID format
+	if _, err := uuid.FromString(vehicleIDStr); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid vehicle ID format: %w", err))
+		return
+	}
+
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	grpcReq := &vehicleproto.GetVehicleStatusHistoryRequest{
+		VehicleId: vehicleIDStr,
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.GetVehicleStatusHistory(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleGetFleetUtilization handles GET requests for the fleet utilization series.
+// from and to are YYYY-MM-DD dates (to is inclusive); granularity is daily (default) or weekly.
+func (h *VehicleHandler) HandleGetFleetUtilization(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, err := time.Parse("2006-01-02", query.Get("from"))
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid from, expected YYYY-MM-DD: %w", err))
+		return
+	}
+	to, err := time.Parse("2006-01-02", query.Get("to"))
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid to, expected YYYY-MM-DD: %w", err))
+		return
+	}
+
+	grpcReq := &vehicleproto.GetFleetUtilizationRequest{
+		From: timestamppb.New(from),
+		To:   timestamppb.New(to.AddDate(0, 0, 1)),
+	}
+
+	switch granularity := strings.ToLower(query.Get("granularity")); granularity {
+	case "", "daily":
+		grpcReq.Granularity = vehicleproto.UtilizationGranularity_GRANULARITY_DAILY
+	case "weekly":
+		grpcReq.Granularity = vehicleproto.UtilizationGranularity_GRANULARITY_WEEKLY
+	default:
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid granularity %q, expected daily or weekly", granularity))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.GetFleetUtilization(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// Vehicle type management
+
+// HandleCreateVehicleType handles POST requests to create a vehicle type
+func (h *VehicleHandler) HandleCreateVehicleType(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var typeRequest struct {
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		EnsureExists bool   `json:"ensure_exists"`
+	}
+
+	if err := json.Unmarshal(body, &typeRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	// Create gRPC request
+	grpcReq := &vehicleproto.CreateVehicleTypeRequest{
+		Name:         typeRequest.Name,
+		Description:  typeRequest.Description,
+		EnsureExists: typeRequest.EnsureExists,
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.CreateVehicleType(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	// ensure_exists hands back an existing type with 200 rather than 201
+	statusCode := http.StatusCreated
+	if !resp.Created {
+		statusCode = http.StatusOK
+	}
+	utils.WriteProtoJSON(w, statusCode, resp)
+}
+
+// HandleListVehicleTypes handles GET requests to list vehicle types
+func (h *VehicleHandler) HandleListVehicleTypes(w http.ResponseWriter, r *http.Request) {
+	pageSize := int32(50) // Default page size
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 {
+			pageSize = int32(n)
+		}
+	}
+
+	// Create gRPC request
+	grpcReq := &vehicleproto.ListVehicleTypesRequest{
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.ListVehicleTypes(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleGetVehicleType handles GET requests for a single vehicle type by ID
+func (h *VehicleHandler) HandleGetVehicleType(w http.ResponseWriter, r *http.Request) {
+	typeID := r.PathValue("id")
+	if typeID == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle type ID is required"))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.GetVehicleType(ctx, &vehicleproto.GetVehicleTypeRequest{Id: typeID})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleGetVehicleTypeByName handles GET requests for a vehicle type by its exact name,
+// given as the name query parameter
+func (h *VehicleHandler) HandleGetVehicleTypeByName(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("name query parameter is required"))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.GetVehicleTypeByName(ctx, &vehicleproto.GetVehicleTypeByNameRequest{Name: name})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleUpdateVehicleType handles PUT requests to rename a vehicle type or change its
+// description. Fields left out of the body are not changed.
+func (h *VehicleHandler) HandleUpdateVehicleType(w http.ResponseWriter, r *http.Request) {
+	typeID := r.PathValue("id")
+	if typeID == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle type ID is required"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+	defer r.Body.Close()
+
+	var typeRequest struct {
+		Name        *string `json:"name"`
+		Description *string `json:"description"`
+	}
+
+	if err := json.Unmarshal(body, &typeRequest); err != nil {
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid request format: %w", err))
+		return
+	}
+
+	// Create gRPC request
+	grpcReq := &vehicleproto.UpdateVehicleTypeRequest{
+		Id:          typeID,
+		Name:        typeRequest.Name,
+		Description: typeRequest.Description,
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	resp, err := h.vehicleClient.UpdateVehicleType(ctx, grpcReq)
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	utils.WriteProtoJSON(w, http.StatusOK, resp)
+}
+
+// HandleDeleteVehicleType handles DELETE requests for a vehicle type. A type that any
+// vehicle still refers to is refused with 422.
+func (h *VehicleHandler) HandleDeleteVehicleType(w http.ResponseWriter, r *http.Request) {
+	typeID := r.PathValue("id")
+	if typeID == "" {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("vehicle type ID is required"))
+		return
+	}
+
+	// Set context with timeout
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	// Call the gRPC service
+	_, err := h.vehicleClient.DeleteVehicleType(ctx, &vehicleproto.DeleteVehicleTypeRequest{Id: typeID})
+	if err != nil {
+		utils.HandleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Bulk import
+
+// vehicleCSVHeader is the column layout expected by HandleImportVehiclesCSV.
+// Columns may appear in any order but every name must be known and the
+// required ones must be present.
+var vehicleCSVHeader = []string{
+	"vehicle_type_id",
+	"license_plate",
+	"make",
+	"model",
+	"year",
+	"color",
+	"seating_capacity",
+	"fuel_type",
+	"engine_number",
+	"chassis_number",
+	"registration_date",
+	"insurance_expiry",
+}
+
+// vehicleCSVRequired lists the columns every import file must carry
+var vehicleCSVRequired = []string{
+	"vehicle_type_id",
+	"license_plate",
+	"make",
+	"model",
+	"year",
+	"seating_capacity",
+}
+
+// vehicleImportResult is streamed back to the client once per CSV row
+type vehicleImportResult struct {
+	Line      int    `json:"line"`
+	VehicleID string `json:"vehicle_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// vehicleImportSummary is written as the final line of an import response.
+// Truncated is set when the file had more rows than the gateway's result cap;
+// rows past the cap were not imported.
+type vehicleImportSummary struct {
+	Total     int  `json:"total"`
+	Created   int  `json:"created"`
+	Failed    int  `json:"failed"`
+	Truncated bool `json:"truncated"`
+}
+
+// HandleImportVehiclesCSV handles POST requests that import vehicles from a CSV upload.
+// Rows are read and created one at a time and each outcome is streamed back as a
+// line of newline-delimited JSON, so large files are never held in memory.
+func (h *VehicleHandler) HandleImportVehiclesCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("missing request body"))
+		return
+	}
+	defer r.Body.Close()
+
+	reader := csv.NewReader(bufio.NewReader(r.Body))
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			utils.WriteError(w, http.StatusBadRequest, errors.New("csv file is empty"))
+			return
+		}
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read csv header: %w", err))
+		return
+	}
+
+	columns, err := parseVehicleCSVHeader(header)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	// Rows must match the header width; let the csv reader enforce it
+	reader.FieldsPerRecord = len(header)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	var summary vehicleImportSummary
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if h.resultCap.Exceeded(summary.Total + 1) {
+			summary.Truncated = true
+			break
+		}
+
+		var result vehicleImportResult
+		summary.Total++
+
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			// A malformed quote leaves the reader in an unknown state, so stop here
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				result.Line = parseErr.Line
+			}
+			result.Error = err.Error()
+			summary.Failed++
+			encoder.Encode(result)
+			break
+		}
+
+		result.Line, _ = reader.FieldPos(0)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.VehicleID, result.Error = h.importVehicleRow(r.Context(), columns, record)
+		}
+
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Created++
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			// Client went away; nothing more we can report
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	encoder.Encode(struct {
+		Summary vehicleImportSummary `json:"summary"`
+	}{Summary: summary})
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// parseVehicleCSVHeader maps column names to their position, stripping any
+// UTF-8 byte order mark and rejecting unknown or missing columns.
+func parseVehicleCSVHeader(header []string) (map[string]int, error) {
+	return parseCSVHeader(header, vehicleCSVHeader, vehicleCSVRequired)
+}
+
+// parseCSVHeader maps the column names of an import file to their position. Only the
+// columns in known are accepted and every column in required must be present.
+func parseCSVHeader(header, knownColumns, required []string) (map[string]int, error) {
+	known := make(map[string]bool, len(knownColumns))
+	for _, name := range knownColumns {
+		known[name] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return nil, fmt.Errorf("unknown csv column %q, expected columns: %s", name, strings.Join(knownColumns, ","))
+		}
+		if _, dup := columns[name]; dup {
+			return nil, fmt.Errorf("duplicate csv column %q", name)
+		}
+		columns[name] = i
+	}
+
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing required csv column %q", name)
+		}
+	}
+
+	return columns, nil
+}
+
+// importVehicleRow converts a CSV record into a VehicleInput and creates it,
+// returning the new vehicle ID or a message describing why the row failed.
+func (h *VehicleHandler) importVehicleRow(ctx context.Context, columns map[string]int, record []string) (string, string) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	input := &vehicleproto.VehicleInput{
+		VehicleTypeId: field("vehicle_type_id"),
+		LicensePlate:  field("license_plate"),
+		Make:          field("make"),
+		Model:         field("model"),
+		Color:         field("color"),
+		EngineNumber:  field("engine_number"),
+		ChassisNumber: field("chassis_number"),
+	}
+
+	year, err := strconv.Atoi(field("year"))
+	if err != nil {
+		return "", fmt.Sprintf("invalid year: %q", field("year"))
+	}
+	input.Year = int32(year)
+
+	seats, err := strconv.Atoi(field("seating_capacity"))
+	if err != nil {
+		return "", fmt.Sprintf("invalid seating_capacity: %q", field("seating_capacity"))
+	}
+	input.SeatingCapacity = int32(seats)
+
+	if fuel := strings.ToUpper(field("fuel_type")); fuel != "" {
+		fuelVal, ok := vehicleproto.FuelType_value[fuel]
+		if !ok {
+			return "", fmt.Sprintf("invalid fuel_type: %q", fuel)
+		}
+		input.FuelType = vehicleproto.FuelType(fuelVal)
+	}
+
+	if date := field("registration_date"); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return "", fmt.Sprintf("invalid registration_date %q, expected YYYY-MM-DD", date)
+		}
+		input.RegistrationDate = timestamppb.New(parsed)
+	}
+
+	if date := field("insurance_expiry"); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return "", fmt.Sprintf("invalid insurance_expiry %q, expected YYYY-MM-DD", date)
+		}
+		input.InsuranceExpiry = timestamppb.New(parsed)
+	}
+
+	rowCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := h.vehicleClient.CreateVehicle(rowCtx, &vehicleproto.CreateVehicleRequest{Vehicle: input})
+	if err != nil {
+		return "", grpcErrorMessage(err)
+	}
+
+	return resp.Vehicle.Id, ""
+}
@@ -0,0 +1,166 @@
+// services/gateway/internal/handler/vehicle_import.go
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+)
+
+// VehicleImportLimits caps the files accepted by HandleImportVehicles
+type VehicleImportLimits struct {
+	// MaxBytes is the largest upload accepted, including the multipart framing
+	MaxBytes int64
+	// MaxRows is the most vehicles one file may carry, not counting the header
+	MaxRows int
+}
+
+// DefaultVehicleImportLimits is used for any limit not configured in the environment
+var DefaultVehicleImportLimits = VehicleImportLimits{
+	MaxBytes: 5 << 20,
+	MaxRows:  1000,
+}
+
+// VehicleImportLimitsFromEnv reads the limits from VEHICLE_IMPORT_MAX_BYTES and
+// VEHICLE_IMPORT_MAX_ROWS. Unset or malformed values fall back to the defaults.
+func VehicleImportLimitsFromEnv() VehicleImportLimits {
+	limits := DefaultVehicleImportLimits
+	if value := strings.TrimSpace(os.Getenv("VEHICLE_IMPORT_MAX_BYTES")); value != "" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil && n > 0 {
+			limits.MaxBytes = n
+		} else {
+			log.Printf("Invalid VEHICLE_IMPORT_MAX_BYTES %q, using %d", value, limits.MaxBytes)
+		}
+	}
+	if value := strings.TrimSpace(os.Getenv("VEHICLE_IMPORT_MAX_ROWS")); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			limits.MaxRows = n
+		} else {
+			log.Printf("Invalid VEHICLE_IMPORT_MAX_ROWS %q, using %d", value, limits.MaxRows)
+		}
+	}
+	return limits
+}
+
+// SetImportLimits sets the size and row caps for multipart vehicle imports
+func (h *VehicleHandler) SetImportLimits(limits VehicleImportLimits) {
+	h.importLimits = limits
+}
+
+// vehicleImportRowResult reports what happened to one row of a multipart import.
+// Row 1 is the first row after the header.
+type vehicleImportRowResult struct {
+	Row       int    `json:"row"`
+	Status    string `json:"status"`
+	VehicleID string `json:"vehicle_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// vehicleImportReport is the response to a multipart import
+type vehicleImportReport struct {
+	Results []vehicleImportRowResult `json:"results"`
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Failed  int                      `json:"failed"`
+}
+
+// HandleImportVehicles handles POST requests that import vehicles from a CSV uploaded as
+// the multipart form field "file", in the column layout of HandleImportVehiclesCSV. The
+// whole file is read and checked against the limits before anything is created, so a
+// file that is too large or malformed is rejected outright. After that every row is
+// created on its own: a row that fails validation is reported and the import carries on.
+// The vehicle service normalizes plates and engine numbers as each row is created.
+func (h *VehicleHandler) HandleImportVehicles(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.importLimits.MaxBytes)
+	if err := r.ParseMultipartForm(h.importLimits.MaxBytes); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.WriteError(w, http.StatusRequestEntityTooLarge,
+				fmt.Errorf("file is over the limit of %d bytes", h.importLimits.MaxBytes))
+			return
+		}
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid multipart form: %w", err))
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, errors.New("multipart form field \"file\" is required"))
+		return
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			utils.WriteError(w, http.StatusBadRequest, errors.New("csv file is empty"))
+			return
+		}
+		utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("failed to read csv header: %w", err))
+		return
+	}
+
+	columns, err := parseVehicleCSVHeader(header)
+	if err != nil {
+		utils.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	// Rows must match the header width; let the csv reader enforce it
+	reader.FieldsPerRecord = len(header)
+
+	// A row with the wrong number of fields is reported against that row. Any other
+	// read error means the file is malformed, and nothing is imported.
+	var records [][]string
+	var rowErrors []error
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			utils.WriteError(w, http.StatusBadRequest, fmt.Errorf("malformed csv file: %w", err))
+			return
+		}
+		if len(records) == h.importLimits.MaxRows {
+			utils.WriteError(w, http.StatusRequestEntityTooLarge,
+				fmt.Errorf("file has more than %d rows", h.importLimits.MaxRows))
+			return
+		}
+		records = append(records, record)
+		rowErrors = append(rowErrors, err)
+	}
+
+	report := vehicleImportReport{Results: make([]vehicleImportRowResult, 0, len(records))}
+	for i, record := range records {
+		result := vehicleImportRowResult{Row: i + 1}
+		if rowErrors[i] != nil {
+			result.Error = rowErrors[i].Error()
+		} else {
+			result.VehicleID, result.Error = h.importVehicleRow(r.Context(), columns, record)
+		}
+
+		if result.Error != "" {
+			result.Status = "failed"
+			report.Failed++
+		} else {
+			result.Status = "created"
+			report.Created++
+		}
+		report.Results = append(report.Results, result)
+	}
+	report.Total = len(records)
+
+	utils.WriteJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,215 @@
+// services/gateway/internal/handler/vehicle_import_test.go
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeVehicleClient creates vehicles in memory, rejecting plates already taken. Calls to
+// any other method panic on the nil embedded client.
+type fakeVehicleClient struct {
+	vehicleproto.VehicleServiceClient
+	mu      sync.Mutex
+	plates  map[string]bool
+	created []*vehicleproto.VehicleInput
+}
+
+func newFakeVehicleClient(takenPlates ...string) *fakeVehicleClient {
+	c := &fakeVehicleClient{plates: make(map[string]bool)}
+	for _, plate := range takenPlates {
+		c.plates[plate] = true
+	}
+	return c
+}
+
+func (c *fakeVehicleClient) CreateVehicle(ctx context.Context, req *vehicleproto.CreateVehicleRequest, opts ...grpc.CallOption) (*vehicleproto.CreateVehicleResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plates[req.Vehicle.LicensePlate] {
+		return nil, status.Error(codes.AlreadyExists, "license plate is already registered")
+	}
+	c.plates[req.Vehicle.LicensePlate] = true
+	c.created = append(c.created, req.Vehicle)
+	return &vehicleproto.CreateVehicleResponse{Vehicle: &vehicleproto.Vehicle{Id: "vehicle-" + req.Vehicle.LicensePlate}}, nil
+}
+
+// importRequest builds a multipart upload carrying content in the given form field
+func importRequest(t *testing.T, field, content string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, "vehicles.csv")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write([]byte(content))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/transport/vehicles/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+const importHeader = "vehicle_type_id,license_plate,make,model,year,color,seating_capacity,fuel_type\n"
+
+func TestHandleImportVehicles(t *testing.T) {
+	client := newFakeVehicleClient("KDA999Z")
+	h := NewVehicleHandler(client, DefaultResultCap)
+
+	csv := "\ufeff" + importHeader + // spreadsheet exports start with a byte order mark
+		"1,KDA123A,Toyota,Hiace,2019,White,14,DIESEL\n" +
+		"1,KDB456B,Nissan,Caravan,not-a-year,Silver,14,PETROL\n" +
+		"1,KDC789C,Isuzu\n" +
+		"1,KDA999Z,Toyota,Probox,2018,Grey,5,PETROL\n" +
+		"2,KDD012D,Mitsubishi,Rosa,2020,Blue,29,diesel\n"
+
+	rec := httptest.NewRecorder()
+	h.HandleImportVehicles(rec, importRequest(t, "file", csv))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var report vehicleImportReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+
+	if report.Total != 5 || report.Created != 2 || report.Failed != 3 {
+		t.Errorf("total/created/failed = %d/%d/%d, want 5/2/3", report.Total, report.Created, report.Failed)
+	}
+	want := []struct {
+		status    string
+		vehicleID string
+		error     string
+	}{
+		{status: "created", vehicleID: "vehicle-KDA123A"},
+		{status: "failed", error: `invalid year: "not-a-year"`},
+		{status: "failed", error: "wrong number of fields"},
+		{status: "failed", error: "license plate is already registered"},
+		{status: "created", vehicleID: "vehicle-KDD012D"},
+	}
+	if len(report.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(report.Results), len(want))
+	}
+	for i, w := range want {
+		got := report.Results[i]
+		if got.Row != i+1 || got.Status != w.status || got.VehicleID != w.vehicleID || !strings.Contains(got.Error, w.error) {
+			t.Errorf("row %d = %+v, want status %s, vehicle %q, error containing %q", i+1, got, w.status, w.vehicleID, w.error)
+		}
+	}
+
+	if len(client.created) != 2 {
+		t.Fatalf("created %d vehicles, want 2", len(client.created))
+	}
+	if got := client.created[1].FuelType; got != vehicleproto.FuelType_DIESEL {
+		t.Errorf("fuel type = %s, want DIESEL", got)
+	}
+	if got := client.created[0]; got.Year != 2019 || got.SeatingCapacity != 14 || got.VehicleTypeId != "1" {
+		t.Errorf("first vehicle = %+v", got)
+	}
+}
+
+func TestHandleImportVehiclesRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      string
+		content    string
+		limits     VehicleImportLimits
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "missing file field",
+			field:      "upload",
+			content:    importHeader,
+			wantStatus: http.StatusBadRequest,
+			wantError:  `multipart form field "file" is required`,
+		},
+		{
+			name:       "empty file",
+			content:    "",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "csv file is empty",
+		},
+		{
+			name:       "unknown column",
+			content:    "vehicle_type_id,license_plate,owner\n1,KDA123A,Jane\n",
+			wantStatus: http.StatusBadRequest,
+			wantError:  `unknown csv column "owner"`,
+		},
+		{
+			name:       "missing required column",
+			content:    "vehicle_type_id,license_plate,make,model,year\n1,KDA123A,Toyota,Hiace,2019\n",
+			wantStatus: http.StatusBadRequest,
+			wantError:  `missing required csv column "seating_capacity"`,
+		},
+		{
+			name:       "malformed csv",
+			content:    importHeader + "1,KDA123A,\"Toyota,Hiace,2019,White,14,DIESEL\n",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "malformed csv file",
+		},
+		{
+			name:       "too many rows",
+			content:    importHeader + strings.Repeat("1,KDA123A,Toyota,Hiace,2019,White,14,DIESEL\n", 3),
+			limits:     VehicleImportLimits{MaxBytes: DefaultVehicleImportLimits.MaxBytes, MaxRows: 2},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantError:  "file has more than 2 rows",
+		},
+		{
+			name:       "too many bytes",
+			content:    importHeader + strings.Repeat("1,KDA123A,Toyota,Hiace,2019,White,14,DIESEL\n", 100),
+			limits:     VehicleImportLimits{MaxBytes: 1024, MaxRows: 1000},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantError:  "file is over the limit of 1024 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeVehicleClient()
+			h := NewVehicleHandler(client, DefaultResultCap)
+			if tt.limits != (VehicleImportLimits{}) {
+				h.SetImportLimits(tt.limits)
+			}
+			field := tt.field
+			if field == "" {
+				field = "file"
+			}
+
+			rec := httptest.NewRecorder()
+			h.HandleImportVehicles(rec, importRequest(t, field, tt.content))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			var body struct {
+				Error utils.ErrorBody `json:"error"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not JSON: %v: %s", err, rec.Body)
+			}
+			if !strings.Contains(body.Error.Message, tt.wantError) {
+				t.Errorf("error = %q, want one containing %q", body.Error.Message, tt.wantError)
+			}
+			// A rejected file imports nothing, not even its valid rows
+			if len(client.created) != 0 {
+				t.Errorf("created %d vehicles from a rejected file", len(client.created))
+			}
+		})
+	}
+}
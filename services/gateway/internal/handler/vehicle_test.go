@@ -0,0 +1,117 @@
+// services/gateway/internal/handler/vehicle_test.go
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/gateway/internal/middleware"
+	vehicleproto "github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/grpc"
+)
+
+// statusVehicleClient records the status requests that reach the vehicle service
+type statusVehicleClient struct {
+	vehicleproto.VehicleServiceClient
+	updates     []*vehicleproto.UpdateVehicleStatusRequest
+	validations []*vehicleproto.ValidateVehicleStatusChangeRequest
+}
+
+func (c *statusVehicleClient) UpdateVehicleStatus(ctx context.Context, req *vehicleproto.UpdateVehicleStatusRequest, opts ...grpc.CallOption) (*vehicleproto.UpdateVehicleStatusResponse, error) {
+	c.updates = append(c.updates, req)
+	return &vehicleproto.UpdateVehicleStatusResponse{Vehicle: &vehicleproto.Vehicle{Id: req.VehicleId, Status: req.Status}}, nil
+}
+
+func (c *statusVehicleClient) ValidateVehicleStatusChange(ctx context.Context, req *vehicleproto.ValidateVehicleStatusChangeRequest, opts ...grpc.CallOption) (*vehicleproto.ValidateVehicleStatusChangeResponse, error) {
+	c.validations = append(c.validations, req)
+	return &vehicleproto.ValidateVehicleStatusChangeResponse{Allowed: true}, nil
+}
+
+const testVehicleID = "8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90"
+
+// withRole returns r as the auth middleware would pass it on for a caller with role
+func withRole(r *http.Request, role string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), middleware.RoleKey, role))
+}
+
+func TestVehicleStatusAdminOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		body       string
+		wantStatus int
+	}{
+		{name: "admin override", role: middleware.RoleAdmin, body: `{"status":"ACTIVE","admin_override":true}`, wantStatus: http.StatusOK},
+		{name: "staff override", role: middleware.RoleStaff, body: `{"status":"ACTIVE","admin_override":true}`, wantStatus: http.StatusForbidden},
+		{name: "integration override", role: middleware.RoleIntegration, body: `{"status":"ACTIVE","admin_override":true}`, wantStatus: http.StatusForbidden},
+		{name: "no role override", body: `{"status":"ACTIVE","admin_override":true}`, wantStatus: http.StatusForbidden},
+		{name: "staff without override", role: middleware.RoleStaff, body: `{"status":"ACTIVE"}`, wantStatus: http.StatusOK},
+	}
+
+	handlers := []struct {
+		name   string
+		path   string
+		handle func(h *VehicleHandler, w http.ResponseWriter, r *http.Request)
+		calls  func(c *statusVehicleClient) []bool // the AdminOverride of each call that got through
+	}{
+		{
+			name:   "update",
+			path:   "/transport/vehicles/" + testVehicleID + "/status",
+			handle: (*VehicleHandler).HandleUpdateVehicleStatus,
+			calls: func(c *statusVehicleClient) []bool {
+				var overrides []bool
+				for _, req := range c.updates {
+					overrides = append(overrides, req.AdminOverride)
+				}
+				return overrides
+			},
+		},
+		{
+			name:   "validate",
+			path:   "/transport/vehicles/" + testVehicleID + "/status/validate",
+			handle: (*VehicleHandler).HandleValidateVehicleStatusChange,
+			calls: func(c *statusVehicleClient) []bool {
+				var overrides []bool
+				for _, req := range c.validations {
+					overrides = append(overrides, req.AdminOverride)
+				}
+				return overrides
+			},
+		},
+	}
+
+	for _, handler := range handlers {
+		for _, tt := range tests {
+			t.Run(handler.name+"/"+tt.name, func(t *testing.T) {
+				client := &statusVehicleClient{}
+				h := NewVehicleHandler(client, DefaultResultCap)
+
+				req := httptest.NewRequest(http.MethodPatch, handler.path, strings.NewReader(tt.body))
+				req.SetPathValue("id", testVehicleID)
+				if tt.role != "" {
+					req = withRole(req, tt.role)
+				}
+				rec := httptest.NewRecorder()
+				handler.handle(h, rec, req)
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+				}
+				calls := handler.calls(client)
+				if tt.wantStatus == http.StatusForbidden {
+					if len(calls) != 0 {
+						t.Errorf("refused override still reached the vehicle service: %v", calls)
+					}
+					return
+				}
+				wantOverride := strings.Contains(tt.body, "admin_override")
+				if len(calls) != 1 || calls[0] != wantOverride {
+					t.Errorf("vehicle service calls = %v, want one with admin_override %t", calls, wantOverride)
+				}
+			})
+		}
+	}
+}
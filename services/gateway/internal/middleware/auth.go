@@ -3,12 +3,14 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/adammwaniki/bebabeba/services/auth/apikey"
 	"github.com/adammwaniki/bebabeba/services/auth/authn/jwt"
 	"github.com/adammwaniki/bebabeba/services/auth/session"
 	"github.com/adammwaniki/bebabeba/services/common/utils"
@@ -19,6 +21,8 @@ type AuthMiddleware struct {
 	jwtService     *jwt.JWTService
 	sessionManager *session.SessionManager
 	skipPaths      map[string]bool // Paths that don't require authentication
+	adminUserIDs   map[string]bool // Users granted the admin role
+	apiKeys        *apikey.Manager // Validates "ApiKey" credentials; nil disables them
 }
 
 // AuthContext key type for context values
@@ -28,8 +32,29 @@ const (
 	UserClaimsKey authContextKey = "user_claims"
 	UserIDKey     authContextKey = "user_id"
 	SessionIDKey  authContextKey = "session_id"
+	RoleKey       authContextKey = "role"
+	APIKeyIDKey   authContextKey = "api_key_id"
 )
 
+// Roles attached to authenticated requests
+const (
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+	// RoleIntegration is attached to requests authenticated with an API key
+	RoleIntegration = "integration"
+)
+
+// Scopes that can be granted to API keys. Each one unlocks a small set of read or
+// verification endpoints; keys never reach anything else.
+const (
+	ScopeVehiclesRead  = "vehicles:read"
+	ScopeDriversRead   = "drivers:read"
+	ScopeDriversVerify = "drivers:verify"
+)
+
+// APIKeyScopes lists every scope an API key may be granted
+var APIKeyScopes = []string{ScopeVehiclesRead, ScopeDriversRead, ScopeDriversVerify}
+
 // NewAuthMiddleware creates a new authentication middleware with session management
 func NewAuthMiddleware(jwtService *jwt.JWTService, sessionManager *session.SessionManager) *AuthMiddleware {
 	// These paths must match EXACTLY what the middleware sees
@@ -38,8 +63,14 @@ func NewAuthMiddleware(jwtService *jwt.JWTService, sessionManager *session.Sessi
 		"/api/v1/users/register":        true,  
 		"/api/v1/auth/google/login":     true,  
 		"/api/v1/auth/google/callback":  true,  
+		"/api/v1/auth/microsoft/login":     true,
+		"/api/v1/auth/microsoft/callback":  true,
+		"/api/v1/auth/github/login":        true,
+		"/api/v1/auth/github/callback":     true,
 		"/api/v1/auth/login":            true,  
 		"/api/v1/auth/refresh":          true,  
+		"/api/v1/auth/forgot-password":  true,
+		"/api/v1/auth/reset-password":   true,
 		"/api/v1/auth/logout":           true, //(logout needs token but handles it specially)
 		"/api/v1/healthz":               true,  
 		"/api/v1/readyz":                true,  
@@ -54,6 +85,28 @@ func NewAuthMiddleware(jwtService *jwt.JWTService, sessionManager *session.Sessi
 	}
 }
 
+// SetAdminUserIDs configures which users are treated as admins. Everyone else is staff.
+func (m *AuthMiddleware) SetAdminUserIDs(userIDs []string) {
+	m.adminUserIDs = make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			m.adminUserIDs[id] = true
+		}
+	}
+}
+
+// SetAPIKeyManager enables "Authorization: ApiKey <key>" on routes protected by RequireAuthOrScope
+func (m *AuthMiddleware) SetAPIKeyManager(manager *apikey.Manager) {
+	m.apiKeys = manager
+}
+
+func (m *AuthMiddleware) roleFor(userID string) string {
+	if m.adminUserIDs[userID] {
+		return RoleAdmin
+	}
+	return RoleStaff
+}
+
 // HTTPAuthMiddleware is the main authentication middleware with session validation
 func (m *AuthMiddleware) HTTPAuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -118,6 +171,7 @@ func (m *AuthMiddleware) HTTPAuthMiddleware(next http.Handler) http.Handler {
 		// Add claims and session info to request context
 		ctx = context.WithValue(r.Context(), UserClaimsKey, claims)
 		ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, RoleKey, m.roleFor(claims.UserID))
 		if sessionID != "" {
 			ctx = context.WithValue(ctx, SessionIDKey, sessionID)
 		}
@@ -164,6 +218,18 @@ func GetUserIDFromContext(ctx context.Context) (string, bool) {
 	return userID, ok
 }
 
+// GetRoleFromContext extracts the caller's role from the request context
+func GetRoleFromContext(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(RoleKey).(string)
+	return role, ok
+}
+
+// GetAPIKeyIDFromContext extracts the API key ID from the request context
+func GetAPIKeyIDFromContext(ctx context.Context) (string, bool) {
+	keyID, ok := ctx.Value(APIKeyIDKey).(string)
+	return keyID, ok
+}
+
 // GetSessionIDFromContext extracts session ID from the request context
 func GetSessionIDFromContext(ctx context.Context) (string, bool) {
 	sessionID, ok := ctx.Value(SessionIDKey).(string)
@@ -222,6 +288,7 @@ func (m *AuthMiddleware) RequireAuth(handler http.HandlerFunc) http.HandlerFunc
 		// Add claims and session info to request context
 		ctx = context.WithValue(r.Context(), UserClaimsKey, claims)
 		ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, RoleKey, m.roleFor(claims.UserID))
 		if sessionID != "" {
 			ctx = context.WithValue(ctx, SessionIDKey, sessionID)
 		}
@@ -231,4 +298,61 @@ func (m *AuthMiddleware) RequireAuth(handler http.HandlerFunc) http.HandlerFunc
 		// Call the protected handler
 		handler.ServeHTTP(w, r.WithContext(ctx))
 	}
-}
\ No newline at end of file
+}
+
+// RequireAdmin protects a handler so only authenticated admins can reach it
+func (m *AuthMiddleware) RequireAdmin(handler http.HandlerFunc) http.HandlerFunc {
+	return m.RequireAuth(func(w http.ResponseWriter, r *http.Request) {
+		if role, _ := GetRoleFromContext(r.Context()); role != RoleAdmin {
+			userID, _ := GetUserIDFromContext(r.Context())
+			log.Printf("Admin access denied for user %s on %s", userID, r.URL.Path)
+			utils.WriteError(w, http.StatusForbidden, fmt.Errorf("admin access required"))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// RequireAuthOrScope protects a handler that integrations may also call. Requests using the
+// "ApiKey" scheme must present a valid key granted scope; anything else goes through RequireAuth.
+func (m *AuthMiddleware) RequireAuthOrScope(scope string, handler http.HandlerFunc) http.HandlerFunc {
+	userAuth := m.RequireAuth(handler)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		scheme, key, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "apikey") || m.apiKeys == nil {
+			userAuth(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+		defer cancel()
+
+		apiKey, err := m.apiKeys.Validate(ctx, strings.TrimSpace(key))
+		if err != nil {
+			if errors.Is(err, apikey.ErrInvalidKey) || errors.Is(err, apikey.ErrKeyRevoked) || errors.Is(err, apikey.ErrKeyExpired) {
+				log.Printf("API key rejected for %s %s: %v", r.Method, r.URL.Path, err)
+				utils.WriteError(w, http.StatusUnauthorized, err)
+				return
+			}
+			log.Printf("API key validation failed for %s: %v", r.URL.Path, err)
+			utils.WriteError(w, http.StatusInternalServerError, fmt.Errorf("failed to validate api key"))
+			return
+		}
+
+		if !apiKey.Allows(scope) {
+			log.Printf("API key %s (%s) denied %s %s: missing scope %s", apiKey.ID, apiKey.Name, r.Method, r.URL.Path, scope)
+			utils.WriteError(w, http.StatusForbidden, fmt.Errorf("api key is not granted the %s scope", scope))
+			return
+		}
+
+		log.Printf("API key %s (%s) used for %s %s", apiKey.ID, apiKey.Name, r.Method, r.URL.Path)
+
+		// The key stands in for a user so audit columns downstream record which integration acted
+		reqCtx := context.WithValue(r.Context(), UserIDKey, "apikey:"+apiKey.ID)
+		reqCtx = context.WithValue(reqCtx, RoleKey, RoleIntegration)
+		reqCtx = context.WithValue(reqCtx, APIKeyIDKey, apiKey.ID)
+
+		handler.ServeHTTP(w, r.WithContext(reqCtx))
+	}
+}
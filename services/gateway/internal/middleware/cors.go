@@ -0,0 +1,112 @@
+// services/gateway/internal/middleware/cors.go
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig lists the browser origins allowed to call the API and what they may send
+type CORSConfig struct {
+	// AllowedOrigins are exact origins such as https://app.bebabeba.com. "*" allows any
+	// other origin too, but only without credentials: those origins get a literal "*",
+	// which browsers won't send cookies with, so an arbitrary site can't call the API as
+	// the signed-in user.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders are response headers scripts may read besides the CORS-safelisted ones
+	ExposedHeaders []string
+	// AllowCredentials lets browsers send cookies, which the session flow depends on
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// DefaultCORSConfig allows no origins. Everything else is what the API's routes use.
+var DefaultCORSConfig = CORSConfig{
+	AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+	AllowedHeaders:   []string{"Authorization", "Content-Type", "Idempotency-Key"},
+	ExposedHeaders:   []string{"Retry-After", "Content-Disposition"},
+	AllowCredentials: true,
+	MaxAge:           10 * time.Minute,
+}
+
+// CORSConfigFromEnv reads the allowed origins from GATEWAY_CORS_ALLOWED_ORIGINS, a
+// comma separated list. Unset, no browser origin is allowed.
+func CORSConfigFromEnv() CORSConfig {
+	config := DefaultCORSConfig
+	for _, origin := range strings.Split(os.Getenv("GATEWAY_CORS_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			config.AllowedOrigins = append(config.AllowedOrigins, origin)
+		}
+	}
+	return config
+}
+
+// CORSMiddleware adds CORS headers for requests from allowed origins and answers their
+// preflight requests itself, before they reach routing. Requests from any other origin
+// get no CORS headers, so the browser blocks them; they're not rejected here, since
+// non-browser clients don't send Origin and aren't subject to CORS at all.
+func CORSMiddleware(config CORSConfig, next http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(config.AllowedOrigins))
+	allowAny := false
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		allowed[strings.ToLower(origin)] = true
+	}
+	allowMethods := strings.Join(config.AllowedMethods, ", ")
+	allowHeaders := strings.Join(config.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(config.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(config.MaxAge.Seconds()))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		// The response depends on Origin, so caches must not share it across origins
+		w.Header().Add("Vary", "Origin")
+
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		listed := allowed[strings.ToLower(origin)]
+		if origin == "" || !(allowAny || listed) {
+			if preflight {
+				// No CORS headers, so the browser refuses the real request
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if listed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if config.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			if config.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
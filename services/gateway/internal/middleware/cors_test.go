@@ -0,0 +1,164 @@
+// services/gateway/internal/middleware/cors_test.go
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func corsTestHandler(config CORSConfig) (http.Handler, *bool) {
+	reached := new(bool)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*reached = true
+		w.WriteHeader(http.StatusOK)
+	})
+	return CORSMiddleware(config, next), reached
+}
+
+func corsConfig(origins ...string) CORSConfig {
+	config := DefaultCORSConfig
+	config.AllowedOrigins = origins
+	return config
+}
+
+func TestCORSAllowedOrigin(t *testing.T) {
+	handler, reached := corsTestHandler(corsConfig("https://app.bebabeba.com"))
+
+	req := httptest.NewRequest(http.MethodGet, "/transport/vehicles", nil)
+	req.Header.Set("Origin", "https://app.bebabeba.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !*reached {
+		t.Fatal("request did not reach the next handler")
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.bebabeba.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want the request's origin", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+	if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "Retry-After, Content-Disposition" {
+		t.Errorf("Access-Control-Expose-Headers = %q", got)
+	}
+	if got := rec.Header().Values("Vary"); len(got) == 0 || got[0] != "Origin" {
+		t.Errorf("Vary = %q, want Origin", got)
+	}
+}
+
+func TestCORSDisallowedOrigin(t *testing.T) {
+	handler, reached := corsTestHandler(corsConfig("https://app.bebabeba.com"))
+
+	req := httptest.NewRequest(http.MethodGet, "/transport/vehicles", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	// Not rejected here; the browser blocks the response for lack of CORS headers
+	if !*reached {
+		t.Fatal("request did not reach the next handler")
+	}
+	for _, header := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials", "Access-Control-Expose-Headers"} {
+		if got := rec.Header().Get(header); got != "" {
+			t.Errorf("%s = %q, want unset", header, got)
+		}
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	tests := []struct {
+		name        string
+		origin      string
+		wantHeaders bool
+	}{
+		{name: "allowed origin", origin: "https://app.bebabeba.com", wantHeaders: true},
+		{name: "disallowed origin", origin: "https://evil.example", wantHeaders: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, reached := corsTestHandler(corsConfig("https://app.bebabeba.com"))
+
+			req := httptest.NewRequest(http.MethodOptions, "/transport/vehicles", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "Content-Type, Idempotency-Key")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if *reached {
+				t.Error("preflight reached the next handler")
+			}
+			if rec.Code != http.StatusNoContent {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusNoContent)
+			}
+
+			want := map[string]string{
+				"Access-Control-Allow-Origin":      tt.origin,
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, POST, PUT, PATCH, DELETE",
+				"Access-Control-Allow-Headers":     "Authorization, Content-Type, Idempotency-Key",
+				"Access-Control-Max-Age":           "600",
+			}
+			for header, value := range want {
+				if !tt.wantHeaders {
+					value = ""
+				}
+				if got := rec.Header().Get(header); got != value {
+					t.Errorf("%s = %q, want %q", header, got, value)
+				}
+			}
+		})
+	}
+}
+
+func TestCORSWildcardWithCredentials(t *testing.T) {
+	config := corsConfig("*", "https://app.bebabeba.com")
+	if !config.AllowCredentials {
+		t.Fatal("default config should allow credentials")
+	}
+
+	tests := []struct {
+		name            string
+		origin          string
+		wantOrigin      string
+		wantCredentials string
+	}{
+		{
+			name:            "listed origin keeps credentials",
+			origin:          "https://app.bebabeba.com",
+			wantOrigin:      "https://app.bebabeba.com",
+			wantCredentials: "true",
+		},
+		{
+			name:            "any other origin gets a literal wildcard without credentials",
+			origin:          "https://evil.example",
+			wantOrigin:      "*",
+			wantCredentials: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodOptions} {
+				handler, _ := corsTestHandler(config)
+
+				req := httptest.NewRequest(method, "/auth/profile", nil)
+				req.Header.Set("Origin", tt.origin)
+				if method == http.MethodOptions {
+					req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+				}
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+
+				if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+					t.Errorf("%s: Access-Control-Allow-Origin = %q, want %q", method, got, tt.wantOrigin)
+				}
+				if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+					t.Errorf("%s: Access-Control-Allow-Credentials = %q, want %q", method, got, tt.wantCredentials)
+				}
+			}
+		})
+	}
+}
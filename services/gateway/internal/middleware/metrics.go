@@ -0,0 +1,38 @@
+// services/gateway/internal/middleware/metrics.go
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+)
+
+var (
+	httpRequests = metrics.Default.NewCounter("http_requests_total",
+		"HTTP requests handled by the gateway, by route and status code.", "route", "status")
+	httpDuration = metrics.Default.NewHistogram("http_request_duration_seconds",
+		"Time taken to handle HTTP requests, by route.", metrics.DefaultBuckets, "route")
+)
+
+// Metrics counts requests by the route pattern that served them, e.g.
+// "GET /transport/vehicles/{id}", so IDs don't each become a series. It has to wrap
+// the ServeMux directly: the mux records the matched pattern on the request it is
+// handed, and middleware in between would hand it a copy. Requests that match no
+// route are counted as "unmatched".
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w}
+
+		next.ServeHTTP(rw, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.Inc(route, strconv.Itoa(rw.statusCode()))
+		httpDuration.Observe(time.Since(start).Seconds(), route)
+	})
+}
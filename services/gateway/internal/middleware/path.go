@@ -0,0 +1,41 @@
+// services/gateway/internal/middleware/path.go
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TrimTrailingSlash serves /transport/vehicles/ exactly like /transport/vehicles.
+//
+// The path is rewritten in place rather than redirected: no route in the API gives
+// the two spellings different meanings, a redirect costs clients a round trip, and
+// many HTTP clients drop the body when following a redirect on POST or PUT.
+// The root path "/" is left alone.
+func TrimTrailingSlash(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Path) <= 1 || !strings.HasSuffix(r.URL.Path, "/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Shallow copy like http.StripPrefix so the caller's request is untouched
+		r2 := new(http.Request)
+		*r2 = *r
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = trimSlashes(r.URL.Path)
+		if r.URL.RawPath != "" {
+			r2.URL.RawPath = trimSlashes(r.URL.RawPath)
+		}
+		next.ServeHTTP(w, r2)
+	})
+}
+
+func trimSlashes(path string) string {
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
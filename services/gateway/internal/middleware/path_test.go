@@ -0,0 +1,64 @@
+// services/gateway/internal/middleware/path_test.go
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// pathTestHandler routes like the API mux, answering with the route reached, its path
+// value and the query string it saw
+func pathTestHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "root?%s", r.URL.RawQuery)
+	})
+	mux.HandleFunc("GET /x", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "x?%s", r.URL.RawQuery)
+	})
+	mux.HandleFunc("GET /x/{id}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "x/%s?%s", r.PathValue("id"), r.URL.RawQuery)
+	})
+	return TrimTrailingSlash(mux)
+}
+
+func TestTrimTrailingSlash(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "/x", want: "x?"},
+		{target: "/x/", want: "x?"},
+		{target: "/x//", want: "x?"},
+		{target: "/x/abc", want: "x/abc?"},
+		{target: "/x/abc/", want: "x/abc?"},
+		{target: "/", want: "root?"},
+		{target: "/?page_size=5", want: "root?page_size=5"},
+		{target: "/x?page_size=5", want: "x?page_size=5"},
+		{target: "/x/?page_size=5&status=ACTIVE", want: "x?page_size=5&status=ACTIVE"},
+		// An escaped slash stays part of the path value
+		{target: "/x/a%2Fb/", want: "x/a/b?"},
+	}
+
+	handler := pathTestHandler()
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			path := req.URL.Path
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if got := rec.Body.String(); got != tt.want {
+				t.Errorf("reached %q, want %q", got, tt.want)
+			}
+			if req.URL.Path != path {
+				t.Errorf("caller's request path changed to %q", req.URL.Path)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// services/gateway/internal/middleware/ratelimit.go
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+)
+
+// RateLimit lets each client make Requests requests per Per, in bursts of up to Requests.
+// The zero value doesn't limit anything.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// enabled reports whether the limit restricts anything
+func (l RateLimit) enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// RateLimitConfig holds the limit for each group of rate limited routes
+type RateLimitConfig struct {
+	// Auth covers the public sign-in endpoints: registration, login, token refresh,
+	// password reset and OAuth sign-in
+	Auth RateLimit
+	// Create covers the endpoints that create records, such as POST /transport/vehicles
+	Create RateLimit
+}
+
+// DefaultRateLimitConfig is used for any group not configured in the environment
+var DefaultRateLimitConfig = RateLimitConfig{
+	Auth:   RateLimit{Requests: 10, Per: time.Minute},
+	Create: RateLimit{Requests: 60, Per: time.Minute},
+}
+
+// RateLimitConfigFromEnv reads the limits from GATEWAY_RATE_LIMIT_AUTH and
+// GATEWAY_RATE_LIMIT_CREATE, written as requests per duration, e.g. "10/1m". "off"
+// removes a group's limit. Unset or malformed values fall back to the defaults.
+func RateLimitConfigFromEnv() RateLimitConfig {
+	return RateLimitConfig{
+		Auth:   rateLimitFromEnv("GATEWAY_RATE_LIMIT_AUTH", DefaultRateLimitConfig.Auth),
+		Create: rateLimitFromEnv("GATEWAY_RATE_LIMIT_CREATE", DefaultRateLimitConfig.Create),
+	}
+}
+
+func rateLimitFromEnv(name string, fallback RateLimit) RateLimit {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return fallback
+	}
+	limit, err := ParseRateLimit(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %d/%s: %v", name, value, fallback.Requests, fallback.Per, err)
+		return fallback
+	}
+	return limit
+}
+
+// ParseRateLimit parses a limit written as requests per duration, e.g. "10/1m" or
+// "100/1h". "off" is the zero limit.
+func ParseRateLimit(value string) (RateLimit, error) {
+	if strings.EqualFold(value, "off") {
+		return RateLimit{}, nil
+	}
+	requestsStr, perStr, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("expected <requests>/<duration>")
+	}
+	requests, err := strconv.Atoi(strings.TrimSpace(requestsStr))
+	if err != nil || requests <= 0 {
+		return RateLimit{}, fmt.Errorf("requests must be a positive whole number")
+	}
+	per, err := time.ParseDuration(strings.TrimSpace(perStr))
+	if err != nil || per <= 0 {
+		return RateLimit{}, fmt.Errorf("duration must be positive, e.g. 30s or 1m")
+	}
+	return RateLimit{Requests: requests, Per: per}, nil
+}
+
+// bucket is one client's token bucket. Tokens are topped up lazily from the time
+// elapsed since the bucket was last touched.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter keeps a token bucket per client. Each bucket holds up to Requests
+// tokens and refills at Requests per Per; a request spends one token.
+type RateLimiter struct {
+	limit RateLimit
+	clock clock.Clock
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a limiter enforcing limit. A nil clock means the system clock.
+func NewRateLimiter(limit RateLimit, clk clock.Clock) *RateLimiter {
+	if clk == nil {
+		clk = clock.System
+	}
+	return &RateLimiter{limit: limit, clock: clk, buckets: make(map[string]*bucket)}
+}
+
+// Allow spends a token from key's bucket. When the bucket is empty it returns false
+// and how long until the next token arrives.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	if !l.limit.enabled() {
+		return true, 0
+	}
+
+	capacity := float64(l.limit.Requests)
+	perToken := l.limit.Per / time.Duration(l.limit.Requests)
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		l.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed)/float64(perToken))
+		b.updated = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) * float64(perToken))
+}
+
+// sweep drops the buckets of clients idle long enough to have refilled completely,
+// which behave exactly like a new bucket. It runs at most once per limit period.
+// l.mu must be held.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.limit.Per {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.limit.Per {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// rateLimitKey identifies the client behind a request: the authenticated user, then
+// the API key, then the remote IP for requests that carry neither
+func rateLimitKey(r *http.Request) string {
+	if userID, ok := GetUserIDFromContext(r.Context()); ok && userID != "" {
+		return "user:" + userID
+	}
+	if keyID, ok := GetAPIKeyIDFromContext(r.Context()); ok && keyID != "" {
+		return "apikey:" + keyID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// RateLimitMiddleware rejects requests from clients that have used up their limit with
+// 429 Too Many Requests and a Retry-After header. Clients are told apart by user, so
+// wrap it inside RequireAuth on protected routes; on public routes it falls back to the
+// remote IP.
+func RateLimitMiddleware(limiter *RateLimiter, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		allowed, retryAfter := limiter.Allow(rateLimitKey(r))
+		if !allowed {
+			seconds := max(1, int(math.Ceil(retryAfter.Seconds())))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			utils.WriteError(w, http.StatusTooManyRequests, fmt.Errorf("rate limit exceeded, retry after %ds", seconds))
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
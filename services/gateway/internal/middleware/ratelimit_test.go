@@ -0,0 +1,74 @@
+// services/gateway/internal/middleware/ratelimit_test.go
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+)
+
+func TestRateLimitRefill(t *testing.T) {
+	clk := clock.NewFrozen(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	limiter := NewRateLimiter(RateLimit{Requests: 2, Per: time.Minute}, clk)
+	handler := RateLimitMiddleware(limiter, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+		req.RemoteAddr = "203.0.113.7:51234"
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	// Drain the bucket
+	for i := range 2 {
+		if rec := send(); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, rec.Code, http.StatusOK)
+		}
+	}
+
+	rec := send()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	// One token every 30s
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Retry-After = %q, want 30", got)
+	}
+
+	// Not yet refilled
+	clk.Advance(29 * time.Second)
+	if rec := send(); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("after 29s: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	} else if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("after 29s: Retry-After = %q, want 1", got)
+	}
+
+	clk.Advance(time.Second)
+	if rec := send(); rec.Code != http.StatusOK {
+		t.Fatalf("after 30s: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec := send(); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("refill granted more than one token: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+}
+
+func TestRateLimitKeysAreSeparate(t *testing.T) {
+	clk := clock.NewFrozen(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	limiter := NewRateLimiter(RateLimit{Requests: 1, Per: time.Minute}, clk)
+
+	if ok, _ := limiter.Allow("ip:203.0.113.7"); !ok {
+		t.Fatal("first request from 203.0.113.7 was limited")
+	}
+	if ok, _ := limiter.Allow("ip:203.0.113.7"); ok {
+		t.Fatal("second request from 203.0.113.7 was allowed")
+	}
+	if ok, _ := limiter.Allow("ip:198.51.100.2"); !ok {
+		t.Error("another client was limited by 203.0.113.7's bucket")
+	}
+}
@@ -0,0 +1,25 @@
+// services/gateway/internal/middleware/timeformat.go
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+)
+
+// TimeFormat honours ?time_format= on every request, so any protobuf response can
+// render its timestamps as epoch_ms instead of the default RFC 3339 strings.
+// An unknown format is rejected rather than silently ignored.
+func TimeFormat(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format, err := utils.ParseTimeFormat(r.URL.Query().Get("time_format"))
+		if err != nil {
+			utils.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+		if format != utils.TimeFormatRFC3339 {
+			w = utils.WithTimeFormat(w, format)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,220 @@
+// services/gateway/internal/redact/redact.go
+package redact
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Action describes what happens to a sensitive field for callers without full access
+type Action string
+
+const (
+	Keep    Action = "keep"    // value is exported as-is
+	Omit    Action = "omit"    // column/key is dropped entirely
+	Mask    Action = "mask"    // value is replaced with a fixed placeholder
+	Partial Action = "partial" // enough of the value is kept to recognise it
+)
+
+const maskPlaceholder = "***"
+
+// defaultActions covers the driver and user PII that non-admins should not download.
+// Field names use the proto (snake_case) spelling.
+var defaultActions = map[string]Action{
+	"phone_number":            Partial,
+	"emergency_contact_name":  Omit,
+	"emergency_contact_phone": Omit,
+	"email":                   Partial,
+}
+
+// Policy decides, per field, how exports are redacted for a given role.
+// Exempt roles (typically admins) receive unredacted data.
+type Policy struct {
+	actions     map[string]Action
+	exemptRoles map[string]bool
+}
+
+// NewPolicy builds a policy from field actions. Roles listed in exemptRoles see raw values.
+func NewPolicy(actions map[string]Action, exemptRoles ...string) *Policy {
+	p := &Policy{
+		actions:     make(map[string]Action, len(actions)),
+		exemptRoles: make(map[string]bool, len(exemptRoles)),
+	}
+	for field, action := range actions {
+		p.actions[field] = action
+	}
+	for _, role := range exemptRoles {
+		p.exemptRoles[role] = true
+	}
+	return p
+}
+
+// DefaultPolicy returns the policy for the driver and user PII listed in defaultActions
+func DefaultPolicy(exemptRoles ...string) *Policy {
+	return NewPolicy(defaultActions, exemptRoles...)
+}
+
+// PolicyFromEnv returns the default policy with overrides from PII_REDACTION_POLICY,
+// a comma separated list of field=action pairs, e.g. "phone_number=omit,email=mask".
+// Invalid entries are logged and ignored so a typo never leaks more than the defaults.
+func PolicyFromEnv(exemptRoles ...string) *Policy {
+	p := DefaultPolicy(exemptRoles...)
+	overrides, err := ParseActions(os.Getenv("PII_REDACTION_POLICY"))
+	if err != nil {
+		log.Printf("Warning: ignoring invalid PII_REDACTION_POLICY: %v", err)
+		return p
+	}
+	for field, action := range overrides {
+		p.actions[field] = action
+	}
+	return p
+}
+
+// ParseActions parses a "field=action,field=action" list
+func ParseActions(raw string) (map[string]Action, error) {
+	actions := make(map[string]Action)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		field, action, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be in field=action form", entry)
+		}
+		field = strings.TrimSpace(field)
+		switch a := Action(strings.ToLower(strings.TrimSpace(action))); a {
+		case Keep, Omit, Mask, Partial:
+			actions[field] = a
+		default:
+			return nil, fmt.Errorf("unknown action %q for field %q", action, field)
+		}
+	}
+	return actions, nil
+}
+
+// Applies reports whether exports for this role need redacting
+func (p *Policy) Applies(role string) bool {
+	return p != nil && !p.exemptRoles[role]
+}
+
+func (p *Policy) actionFor(role, field string) Action {
+	if !p.Applies(role) {
+		return Keep
+	}
+	if action, ok := p.actions[field]; ok {
+		return action
+	}
+	// JSON output uses lowerCamelCase names
+	if action, ok := p.actions[snakeCase(field)]; ok {
+		return action
+	}
+	return Keep
+}
+
+// Columns returns the CSV header with omitted fields removed
+func (p *Policy) Columns(role string, header []string) []string {
+	columns := make([]string, 0, len(header))
+	for _, name := range header {
+		if p.actionFor(role, name) != Omit {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// Row redacts a CSV record laid out according to header. The result lines up with Columns.
+func (p *Policy) Row(role string, header, record []string) []string {
+	row := make([]string, 0, len(record))
+	for i, value := range record {
+		if i >= len(header) {
+			row = append(row, value)
+			continue
+		}
+		if redacted, keep := p.value(role, header[i], value); keep {
+			row = append(row, redacted)
+		}
+	}
+	return row
+}
+
+// Object redacts a decoded JSON object in place, descending into nested objects and arrays
+func (p *Policy) Object(role string, obj map[string]any) {
+	if !p.Applies(role) {
+		return
+	}
+	for key, raw := range obj {
+		switch v := raw.(type) {
+		case string:
+			if redacted, keep := p.value(role, key, v); keep {
+				obj[key] = redacted
+			} else {
+				delete(obj, key)
+			}
+		case map[string]any:
+			p.Object(role, v)
+		case []any:
+			for _, item := range v {
+				if nested, ok := item.(map[string]any); ok {
+					p.Object(role, nested)
+				}
+			}
+		default:
+			if p.actionFor(role, key) == Omit {
+				delete(obj, key)
+			}
+		}
+	}
+}
+
+// value applies the field's action; keep is false when the field must be dropped
+func (p *Policy) value(role, field, value string) (string, bool) {
+	switch p.actionFor(role, field) {
+	case Omit:
+		return "", false
+	case Mask:
+		if value == "" {
+			return "", true
+		}
+		return maskPlaceholder, true
+	case Partial:
+		return partial(value), true
+	default:
+		return value, true
+	}
+}
+
+// partial keeps the first character of an email's local part and its domain
+// (j***@example.com); anything else keeps only its last three characters (***678).
+func partial(value string) string {
+	if value == "" {
+		return ""
+	}
+	if local, domain, ok := strings.Cut(value, "@"); ok && local != "" {
+		first, _ := utf8.DecodeRuneInString(local)
+		return string(first) + maskPlaceholder + "@" + domain
+	}
+	runes := []rune(value)
+	if len(runes) <= 3 {
+		return maskPlaceholder
+	}
+	return maskPlaceholder + string(runes[len(runes)-3:])
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/types"
 	"github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	"google.golang.org/grpc"
@@ -20,8 +21,9 @@ type grpcHandler struct {
 	healthServer *health.Server
 }
 
-// NewGRPCHandler creates and registers the gRPC staff service handler
-func NewGRPCHandler(grpcServer *grpc.Server, service types.StaffService) {
+// NewGRPCHandler creates and registers the gRPC staff service handler. It returns the
+// health server, so shutdown can report NOT_SERVING before draining.
+func NewGRPCHandler(grpcServer *grpc.Server, service types.StaffService) *health.Server {
 	handler := &grpcHandler{
 		service:      service,
 		healthServer: health.NewServer(),
@@ -38,6 +40,7 @@ func NewGRPCHandler(grpcServer *grpc.Server, service types.StaffService) {
 	)
 
 	log.Println("gRPC Staff and Health services registered")
+	return handler.healthServer
 }
 
 // Driver CRUD operations
@@ -82,13 +85,26 @@ func (h *grpcHandler) GetDriverByUserID(ctx context.Context, req *genproto.GetDr
 	return resp, nil
 }
 
+func (h *grpcHandler) GetDriversByUserIDs(ctx context.Context, req *genproto.GetDriversByUserIDsRequest) (*genproto.GetDriversByUserIDsResponse, error) {
+	log.Printf("Handling GetDriversByUserIDs gRPC request for %d users", len(req.UserIds))
+
+	resp, err := h.service.GetDriversByUserIDs(ctx, req)
+	if err != nil {
+		log.Printf("GetDriversByUserIDs failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetDriversByUserIDs successful, found %d drivers", len(resp.Drivers))
+	return resp, nil
+}
+
 func (h *grpcHandler) ListDrivers(ctx context.Context, req *genproto.ListDriversRequest) (*genproto.ListDriversResponse, error) {
 	log.Println("Handling ListDrivers gRPC request")
 	
 	// Validate page size
-	if req.GetPageSize() > 100 {
-		log.Printf("ListDrivers: page size %d exceeds maximum of 100", req.GetPageSize())
-		req.PageSize = 100
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("ListDrivers: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
 	}
 
 	resp, err := h.service.ListDrivers(ctx, req)
@@ -127,6 +143,71 @@ func (h *grpcHandler) DeleteDriver(ctx context.Context, req *genproto.DeleteDriv
 	return &emptypb.Empty{}, nil
 }
 
+func (h *grpcHandler) RestoreDriver(ctx context.Context, req *genproto.RestoreDriverRequest) (*genproto.RestoreDriverResponse, error) {
+	log.Printf("Handling RestoreDriver gRPC request for ID: %s", req.DriverId)
+
+	resp, err := h.service.RestoreDriver(ctx, req)
+	if err != nil {
+		log.Printf("RestoreDriver failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("RestoreDriver successful for driver ID: %s", req.DriverId)
+	return resp, nil
+}
+
+func (h *grpcHandler) HardDeleteDriver(ctx context.Context, req *genproto.HardDeleteDriverRequest) (*genproto.HardDeleteDriverResponse, error) {
+	log.Printf("Handling HardDeleteDriver gRPC request for ID: %s", req.DriverId)
+
+	resp, err := h.service.HardDeleteDriver(ctx, req)
+	if err != nil {
+		log.Printf("HardDeleteDriver failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("HardDeleteDriver successful for driver ID: %s, %d certifications deleted", req.DriverId, resp.CertificationsDeleted)
+	return resp, nil
+}
+
+func (h *grpcHandler) MergeDrivers(ctx context.Context, req *genproto.MergeDriversRequest) (*genproto.MergeDriversResponse, error) {
+	log.Printf("Handling MergeDrivers gRPC request: %s into %s", req.DuplicateDriverId, req.PrimaryDriverId)
+	
+	resp, err := h.service.MergeDrivers(ctx, req)
+	if err != nil {
+		log.Printf("MergeDrivers failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("MergeDrivers successful for primary driver %s", req.PrimaryDriverId)
+	return resp, nil
+}
+
+func (h *grpcHandler) UpdateDriverRating(ctx context.Context, req *genproto.UpdateDriverRatingRequest) (*genproto.UpdateDriverRatingResponse, error) {
+	log.Printf("Handling UpdateDriverRating gRPC request for driver %s", req.DriverId)
+
+	resp, err := h.service.UpdateDriverRating(ctx, req)
+	if err != nil {
+		log.Printf("UpdateDriverRating failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("UpdateDriverRating successful for driver %s (%d ratings)", req.DriverId, resp.Driver.RatingCount)
+	return resp, nil
+}
+
+func (h *grpcHandler) AcknowledgeHandbook(ctx context.Context, req *genproto.AcknowledgeHandbookRequest) (*genproto.AcknowledgeHandbookResponse, error) {
+	log.Printf("Handling AcknowledgeHandbook gRPC request for driver %s", req.DriverId)
+
+	resp, err := h.service.AcknowledgeHandbook(ctx, req)
+	if err != nil {
+		log.Printf("AcknowledgeHandbook failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("AcknowledgeHandbook successful for driver %s (version %s)", req.DriverId, resp.Driver.HandbookVersion)
+	return resp, nil
+}
+
 // Driver status management
 
 func (h *grpcHandler) UpdateDriverStatus(ctx context.Context, req *genproto.UpdateDriverStatusRequest) (*genproto.UpdateDriverStatusResponse, error) {
@@ -143,13 +224,67 @@ func (h *grpcHandler) UpdateDriverStatus(ctx context.Context, req *genproto.Upda
 	return resp, nil
 }
 
+func (h *grpcHandler) RenewDriverLicense(ctx context.Context, req *genproto.RenewDriverLicenseRequest) (*genproto.RenewDriverLicenseResponse, error) {
+	log.Printf("Handling RenewDriverLicense gRPC request for ID: %s", req.DriverId)
+
+	resp, err := h.service.RenewDriverLicense(ctx, req)
+	if err != nil {
+		log.Printf("RenewDriverLicense failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("RenewDriverLicense successful for driver ID: %s, reactivated: %t", req.DriverId, resp.Reactivated)
+	return resp, nil
+}
+
+func (h *grpcHandler) ValidateDriverStatusChange(ctx context.Context, req *genproto.ValidateDriverStatusChangeRequest) (*genproto.ValidateDriverStatusChangeResponse, error) {
+	log.Printf("Handling ValidateDriverStatusChange gRPC request for driver %s to status %s",
+		req.DriverId, req.Status.String())
+
+	resp, err := h.service.ValidateDriverStatusChange(ctx, req)
+	if err != nil {
+		log.Printf("ValidateDriverStatusChange failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("ValidateDriverStatusChange successful for driver %s, allowed: %t", req.DriverId, resp.Allowed)
+	return resp, nil
+}
+
+func (h *grpcHandler) ListDriverStatusHistory(ctx context.Context, req *genproto.ListDriverStatusHistoryRequest) (*genproto.ListDriverStatusHistoryResponse, error) {
+	log.Printf("Handling ListDriverStatusHistory gRPC request for driver %s", req.DriverId)
+
+	resp, err := h.service.ListDriverStatusHistory(ctx, req)
+	if err != nil {
+		log.Printf("ListDriverStatusHistory failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("ListDriverStatusHistory successful, returned %d entries", len(resp.Entries))
+	return resp, nil
+}
+
+func (h *grpcHandler) CheckDriverEligibility(ctx context.Context, req *genproto.CheckDriverEligibilityRequest) (*genproto.CheckDriverEligibilityResponse, error) {
+	log.Printf("Handling CheckDriverEligibility gRPC request for driver %s and vehicle type %s",
+		req.DriverId, req.VehicleType)
+
+	resp, err := h.service.CheckDriverEligibility(ctx, req)
+	if err != nil {
+		log.Printf("CheckDriverEligibility failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("CheckDriverEligibility successful for driver %s, eligible: %t", req.DriverId, resp.Eligible)
+	return resp, nil
+}
+
 func (h *grpcHandler) GetActiveDrivers(ctx context.Context, req *genproto.GetActiveDriversRequest) (*genproto.ListDriversResponse, error) {
 	log.Println("Handling GetActiveDrivers gRPC request")
 	
 	// Validate page size
-	if req.GetPageSize() > 100 {
-		log.Printf("GetActiveDrivers: page size %d exceeds maximum of 100", req.GetPageSize())
-		req.PageSize = 100
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("GetActiveDrivers: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
 	}
 
 	resp, err := h.service.GetActiveDrivers(ctx, req)
@@ -162,6 +297,44 @@ func (h *grpcHandler) GetActiveDrivers(ctx context.Context, req *genproto.GetAct
 	return resp, nil
 }
 
+func (h *grpcHandler) GetEligibleDriversForVehicleType(ctx context.Context, req *genproto.GetEligibleDriversForVehicleTypeRequest) (*genproto.ListDriversResponse, error) {
+	log.Printf("Handling GetEligibleDriversForVehicleType gRPC request for type %s", req.VehicleType)
+	
+	// Validate page size
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("GetEligibleDriversForVehicleType: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
+	}
+
+	resp, err := h.service.GetEligibleDriversForVehicleType(ctx, req)
+	if err != nil {
+		log.Printf("GetEligibleDriversForVehicleType failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetEligibleDriversForVehicleType successful, returned %d drivers", len(resp.Drivers))
+	return resp, nil
+}
+
+func (h *grpcHandler) ListRecentlyUpdatedDrivers(ctx context.Context, req *genproto.ListRecentlyUpdatedDriversRequest) (*genproto.ListDriversResponse, error) {
+	log.Println("Handling ListRecentlyUpdatedDrivers gRPC request")
+	
+	// Validate page size
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("ListRecentlyUpdatedDrivers: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
+	}
+
+	resp, err := h.service.ListRecentlyUpdatedDrivers(ctx, req)
+	if err != nil {
+		log.Printf("ListRecentlyUpdatedDrivers failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("ListRecentlyUpdatedDrivers successful, returned %d drivers", len(resp.Drivers))
+	return resp, nil
+}
+
 // Driver certification management
 
 func (h *grpcHandler) AddDriverCertification(ctx context.Context, req *genproto.AddDriverCertificationRequest) (*genproto.AddDriverCertificationResponse, error) {
@@ -216,6 +389,19 @@ func (h *grpcHandler) DeleteCertification(ctx context.Context, req *genproto.Del
 	return &emptypb.Empty{}, nil
 }
 
+func (h *grpcHandler) ListCertificationTemplates(ctx context.Context, req *genproto.ListCertificationTemplatesRequest) (*genproto.ListCertificationTemplatesResponse, error) {
+	log.Println("Handling ListCertificationTemplates gRPC request")
+
+	resp, err := h.service.ListCertificationTemplates(ctx, req)
+	if err != nil {
+		log.Printf("ListCertificationTemplates failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("ListCertificationTemplates successful, returned %d templates", len(resp.Templates))
+	return resp, nil
+}
+
 // Driver verification and compliance
 
 func (h *grpcHandler) VerifyDriverLicense(ctx context.Context, req *genproto.VerifyDriverLicenseRequest) (*genproto.VerifyDriverLicenseResponse, error) {
@@ -231,6 +417,19 @@ func (h *grpcHandler) VerifyDriverLicense(ctx context.Context, req *genproto.Ver
 	return resp, nil
 }
 
+func (h *grpcHandler) BatchVerifyDriverLicenses(ctx context.Context, req *genproto.BatchVerifyDriverLicensesRequest) (*genproto.BatchVerifyDriverLicensesResponse, error) {
+	log.Printf("Handling BatchVerifyDriverLicenses gRPC request for %d drivers", len(req.DriverIds))
+	
+	resp, err := h.service.BatchVerifyDriverLicenses(ctx, req)
+	if err != nil {
+		log.Printf("BatchVerifyDriverLicenses failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("BatchVerifyDriverLicenses successful for %d drivers", len(resp.Results))
+	return resp, nil
+}
+
 func (h *grpcHandler) GetExpiringLicenses(ctx context.Context, req *genproto.GetExpiringLicensesRequest) (*genproto.ListDriversResponse, error) {
 	log.Printf("Handling GetExpiringLicenses gRPC request for %d days ahead", req.DaysAhead)
 	
@@ -244,6 +443,19 @@ func (h *grpcHandler) GetExpiringLicenses(ctx context.Context, req *genproto.Get
 	return resp, nil
 }
 
+func (h *grpcHandler) GetRecentlyExpiredLicenses(ctx context.Context, req *genproto.GetRecentlyExpiredLicensesRequest) (*genproto.ListDriversResponse, error) {
+	log.Printf("Handling GetRecentlyExpiredLicenses gRPC request for the past %d days", req.SinceDays)
+
+	resp, err := h.service.GetRecentlyExpiredLicenses(ctx, req)
+	if err != nil {
+		log.Printf("GetRecentlyExpiredLicenses failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetRecentlyExpiredLicenses successful, returned %d drivers", len(resp.Drivers))
+	return resp, nil
+}
+
 func (h *grpcHandler) GetExpiredCertifications(ctx context.Context, req *genproto.GetExpiredCertificationsRequest) (*genproto.ListDriverCertificationsResponse, error) {
 	log.Println("Handling GetExpiredCertifications gRPC request")
 	
@@ -256,3 +468,24 @@ func (h *grpcHandler) GetExpiredCertifications(ctx context.Context, req *genprot
 	log.Printf("GetExpiredCertifications successful, returned %d certifications", len(resp.Certifications))
 	return resp, nil
 }
+
+func (h *grpcHandler) ValidatePhoneNumber(ctx context.Context, req *genproto.ValidatePhoneNumberRequest) (*genproto.FieldValidationResponse, error) {
+	return h.service.ValidatePhoneNumber(ctx, req)
+}
+
+func (h *grpcHandler) ValidateLicenseNumber(ctx context.Context, req *genproto.ValidateLicenseNumberRequest) (*genproto.FieldValidationResponse, error) {
+	return h.service.ValidateLicenseNumber(ctx, req)
+}
+
+func (h *grpcHandler) NormalizeLegacyRecords(ctx context.Context, req *genproto.NormalizeLegacyRecordsRequest) (*genproto.NormalizeLegacyRecordsResponse, error) {
+	log.Printf("Handling NormalizeLegacyRecords gRPC request, dry run: %t", req.DryRun)
+
+	resp, err := h.service.NormalizeLegacyRecords(ctx, req)
+	if err != nil {
+		log.Printf("NormalizeLegacyRecords failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("NormalizeLegacyRecords successful, scanned %d, changed %d, failed %d", resp.Scanned, resp.Changed, resp.Failed)
+	return resp, nil
+}
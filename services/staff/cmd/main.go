@@ -2,49 +2,104 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+	"github.com/adammwaniki/bebabeba/services/common/grpcserver"
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/staff/api"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/service"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/store"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/types"
+	"github.com/influxdata/influxdb/v2/pkg/snowflake"
 	_ "github.com/joho/godotenv/autoload"
 	"google.golang.org/grpc"
 )
 
 var (
-	grpcAddr = os.Getenv("STAFF_GRPC_ADDR")
+	grpcAddr      = os.Getenv("STAFF_GRPC_ADDR")
+	internalToken = os.Getenv("INTERNAL_SERVICE_TOKEN")
+	metricsAddr   = os.Getenv("STAFF_METRICS_ADDR") // /metrics listener; unset leaves metrics unexposed
 )
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run starts the service and serves until a shutdown signal has drained it. Failures are
+// returned rather than fatal, so the deferred closes still run.
+func run() error {
+	// Resolve the snowflake node ID once so a bad NODE_ID stops startup
+	// instead of failing every create request
+	nodeID, err := utils.ResolveSnowflakeNodeID()
+	if err != nil {
+		return fmt.Errorf("snowflake node ID resolution failed: %w", err)
+	}
+
+	// Tracing stays off unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), "staff")
+	if err != nil {
+		return fmt.Errorf("tracing initialization failed: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize database store
-	staffStore, err := store.NewStore(os.Getenv("DRIVER_DB_DSN"))
+	staffStore, err := store.NewStore(os.Getenv("DRIVER_DB_DSN"), utils.DBRetryFromEnv(), utils.DBPoolFromEnv(), clock.System)
 	if err != nil {
-		log.Fatal("Store initialization failed: ", err)
+		return fmt.Errorf("store initialization failed: %w", err)
 	}
+	defer staffStore.Close()
+	staffStore.SetQueryTimeout(utils.DBQueryTimeoutFromEnv())
+	pagesize.SetLimits(pagesize.LimitsFromEnv())
+	pagetoken.SetSigning(pagetoken.SigningFromEnv())
+	pagetoken.SetClockLeeway(pagetoken.ClockLeewayFromEnv())
+
+	// Strict hire date checks are opt-in so legacy imports keep loading
+	strictHireDates, _ := strconv.ParseBool(os.Getenv("STAFF_STRICT_HIRE_DATES"))
+
+	// Drivers must acknowledge this handbook version before they are offered for assignments
+	handbookVersion := strings.TrimSpace(os.Getenv("DRIVER_HANDBOOK_VERSION"))
+	staffStore.SetHandbookVersion(handbookVersion)
 
 	// Initialize service business logic
-	svc := service.NewService(staffStore)
+	svc := service.NewService(staffStore, snowflake.New(int(nodeID)), types.Config{
+		StrictHireDates: strictHireDates,
+		Clock:           clock.System,
+		HandbookVersion: handbookVersion,
+	})
 
 	// Start gRPC server
-	startGRPCServer(svc)
-}
-
-func startGRPCServer(svc types.StaffService) {
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
-		log.Fatal("gRPC listener failed: ", err)
+		return fmt.Errorf("gRPC listener failed: %w", err)
 	}
 	defer lis.Close()
 
-	grpcServer := grpc.NewServer()
-	api.NewGRPCHandler(grpcServer, svc)
-
-	log.Printf("Starting Staff gRPC server on %s", grpcAddr)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatal("gRPC server failed: ", err)
-	}
+	return serveGRPC(lis, svc)
 }
 
+// serveGRPC serves svc on lis until SIGINT or SIGTERM, then drains it
+func serveGRPC(lis net.Listener, svc types.StaffService) error {
+	// Metrics come first so they time the whole call; callers presenting the
+	// internal token may request larger pages
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		metrics.UnaryServerInterceptor(),
+		pagesize.UnaryServerInterceptor(internalToken),
+	))
+	healthServer := api.NewGRPCHandler(grpcServer, svc)
+	metrics.Serve(metricsAddr)
+
+	return grpcserver.Serve(lis, grpcServer, healthServer, "Staff")
+}
@@ -0,0 +1,3 @@
+-- services/staff/cmd/migrate/migrations/20250911101530_add-drivers-updated-by.down.sql
+ALTER TABLE drivers
+    DROP COLUMN updated_by;
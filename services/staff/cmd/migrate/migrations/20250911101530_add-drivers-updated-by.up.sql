@@ -0,0 +1,3 @@
+-- services/staff/cmd/migrate/migrations/20250911101530_add-drivers-updated-by.up.sql
+ALTER TABLE drivers
+    ADD COLUMN updated_by VARCHAR(64) NULL DEFAULT NULL;
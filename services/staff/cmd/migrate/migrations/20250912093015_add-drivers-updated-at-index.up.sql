@@ -0,0 +1,2 @@
+-- services/staff/cmd/migrate/migrations/20250912093015_add-drivers-updated-at-index.up.sql
+CREATE INDEX idx_drivers_updated_at ON drivers (updated_at);
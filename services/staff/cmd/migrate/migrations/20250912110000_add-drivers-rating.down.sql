@@ -0,0 +1,4 @@
+-- services/staff/cmd/migrate/migrations/20250912110000_add-drivers-rating.down.sql
+ALTER TABLE drivers
+    DROP COLUMN rating_count,
+    DROP COLUMN rating_average;
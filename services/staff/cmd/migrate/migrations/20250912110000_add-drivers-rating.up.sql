@@ -0,0 +1,4 @@
+-- services/staff/cmd/migrate/migrations/20250912110000_add-drivers-rating.up.sql
+ALTER TABLE drivers
+    ADD COLUMN rating_average DECIMAL(3,2) NOT NULL DEFAULT 0.00,
+    ADD COLUMN rating_count INT UNSIGNED NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- services/staff/cmd/migrate/migrations/20250912143000_create-certification_templates.down.sql
+DROP TABLE IF EXISTS certification_templates;
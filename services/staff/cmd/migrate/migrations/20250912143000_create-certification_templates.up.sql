@@ -0,0 +1,8 @@
+-- services/staff/cmd/migrate/migrations/20250912143000_create-certification_templates.up.sql
+-- Certifications offered alongside the built-in standard list (types.StandardCertifications)
+CREATE TABLE IF NOT EXISTS certification_templates (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    certification_name VARCHAR(100) UNIQUE NOT NULL,
+    issued_by VARCHAR(100) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
@@ -0,0 +1,4 @@
+-- services/staff/cmd/migrate/migrations/20250913100000_add-drivers-handbook-acknowledgement.down.sql
+ALTER TABLE drivers
+    DROP COLUMN handbook_acknowledged_at,
+    DROP COLUMN handbook_version;
@@ -0,0 +1,4 @@
+-- services/staff/cmd/migrate/migrations/20250913100000_add-drivers-handbook-acknowledgement.up.sql
+ALTER TABLE drivers
+    ADD COLUMN handbook_version VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN handbook_acknowledged_at DATETIME(6) NULL DEFAULT NULL;
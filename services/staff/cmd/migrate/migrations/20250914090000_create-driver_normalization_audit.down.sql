@@ -0,0 +1,2 @@
+-- services/staff/cmd/migrate/migrations/20250914090000_create-driver_normalization_audit.down.sql
+DROP TABLE IF EXISTS driver_normalization_audit;
@@ -0,0 +1,17 @@
+-- services/staff/cmd/migrate/migrations/20250914090000_create-driver_normalization_audit.up.sql
+-- Field values rewritten by NormalizeLegacyRecords, one row per changed field
+CREATE TABLE IF NOT EXISTS driver_normalization_audit (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    driver_id BINARY(16) NOT NULL,
+    field VARCHAR(50) NOT NULL,
+    old_value VARCHAR(255) NOT NULL,
+    new_value VARCHAR(255) NOT NULL,
+    changed_by VARCHAR(64),    -- User ID who ran the job, or "system"
+    changed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+    INDEX idx_driver_normalization_audit_driver (driver_id, changed_at),
+
+    CONSTRAINT fk_driver_normalization_audit_driver
+        FOREIGN KEY (driver_id) REFERENCES drivers(external_id)
+        ON DELETE CASCADE
+);
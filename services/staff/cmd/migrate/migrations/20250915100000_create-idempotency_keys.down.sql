@@ -0,0 +1,2 @@
+-- services/staff/cmd/migrate/migrations/20250915100000_create-idempotency_keys.down.sql
+DROP TABLE IF EXISTS idempotency_keys;
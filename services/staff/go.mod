@@ -1,3 +1,5 @@
 module github.com/adammwaniki/bebabeba/services/staff
 
 go 1.24.2
+
+require github.com/DATA-DOG/go-sqlmock v1.5.2
@@ -0,0 +1,128 @@
+// services/staff/internal/service/normalize.go
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/staff/internal/types"
+	"github.com/adammwaniki/bebabeba/services/staff/internal/validator"
+	"github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NormalizeLegacyRecords walks every driver in batches and re-applies NormalizeDriverFields,
+// updating the drivers whose stored values differ from their normalized form. A driver
+// that can't be updated is reported and skipped; the job carries on with the rest.
+func (s *service) NormalizeLegacyRecords(ctx context.Context, req *genproto.NormalizeLegacyRecordsRequest) (*genproto.NormalizeLegacyRecordsResponse, error) {
+	batchSize := req.GetBatchSize()
+	if batchSize <= 0 {
+		batchSize = types.DefaultNormalizeBatchSize
+	}
+	if batchSize > types.MaxNormalizeBatchSize {
+		batchSize = types.MaxNormalizeBatchSize
+	}
+
+	actorID := actor.FromIncomingContext(ctx)
+	resp := &genproto.NormalizeLegacyRecordsResponse{DryRun: req.GetDryRun()}
+
+	var afterID uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		batch, err := s.store.ListNormalizableDrivers(ctx, afterID, batchSize)
+		if err != nil {
+			return nil, grpcerr.Internal("failed to list drivers", err)
+		}
+
+		for _, driver := range batch {
+			resp.Scanned++
+
+			changes := driverNormalizationChanges(driver)
+			if len(changes) == 0 {
+				continue
+			}
+
+			record := &genproto.NormalizedRecord{Id: driver.ExternalID}
+			for _, change := range changes {
+				record.Fields = append(record.Fields, &genproto.NormalizedField{
+					Field:    change.Field,
+					OldValue: change.OldValue,
+					NewValue: change.NewValue,
+				})
+			}
+
+			if !req.GetDryRun() {
+				err := s.store.ApplyDriverNormalization(ctx, driver, changes, actorID)
+				switch {
+				case err == nil:
+				case errors.Is(err, types.ErrDuplicateEntry):
+					record.Error = "normalized value is already used by another driver"
+				case errors.Is(err, types.ErrStaleRecord):
+					record.Error = "driver was changed while the job ran"
+				default:
+					return nil, status.Errorf(codes.Internal, "failed to normalize driver %s: %v", driver.ExternalID, err)
+				}
+			}
+
+			if record.Error != "" {
+				resp.Failed++
+			} else {
+				resp.Changed++
+			}
+
+			if len(resp.Records) < types.MaxReportedNormalizations {
+				resp.Records = append(resp.Records, record)
+			} else {
+				resp.RecordsTruncated = true
+			}
+		}
+
+		if int32(len(batch)) < batchSize {
+			return resp, nil
+		}
+		afterID = batch[len(batch)-1].InternalID
+	}
+}
+
+// driverNormalizationChanges runs the stored values through the same normalization new
+// drivers get and returns the fields that come out different
+func driverNormalizationChanges(driver types.NormalizableDriver) []types.FieldChange {
+	input := &genproto.DriverInput{
+		UserId:                driver.UserID,
+		LicenseNumber:         driver.LicenseNumber,
+		PhoneNumber:           driver.PhoneNumber,
+		EmergencyContactName:  driver.EmergencyContactName,
+		EmergencyContactPhone: driver.EmergencyContactPhone,
+	}
+	validator.NormalizeDriverFields(input)
+
+	fields := []struct {
+		name       string
+		stored     string
+		normalized string
+	}{
+		{"user_id", driver.UserID, input.UserId},
+		{"license_number", driver.LicenseNumber, input.LicenseNumber},
+		{"phone_number", driver.PhoneNumber, input.PhoneNumber},
+		{"emergency_contact_name", driver.EmergencyContactName, input.EmergencyContactName},
+		{"emergency_contact_phone", driver.EmergencyContactPhone, input.EmergencyContactPhone},
+	}
+
+	var changes []types.FieldChange
+	for _, field := range fields {
+		if field.stored != field.normalized {
+			changes = append(changes, types.FieldChange{
+				Field:    field.name,
+				OldValue: field.stored,
+				NewValue: field.normalized,
+			})
+		}
+	}
+	return changes
+}
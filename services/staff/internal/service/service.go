@@ -6,43 +6,122 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
-	"time"
-
-	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"strings"
+
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/types"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/validator"
 	"github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	"github.com/gofrs/uuid/v5"
 	"github.com/influxdata/influxdb/v2/pkg/snowflake"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type service struct {
-	store types.StaffStore
+	store  types.StaffStore
+	ids    *snowflake.Generator
+	config types.Config
+	clock  clock.Clock
 }
 
 // NewService creates a new staff service instance
-func NewService(store types.StaffStore) *service {
-	return &service{store: store}
+func NewService(store types.StaffStore, ids *snowflake.Generator, config types.Config) *service {
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.System
+	}
+	return &service{store: store, ids: ids, config: config, clock: clk}
 }
 
 // Driver CRUD operations
 
+// createDriverScope keeps CreateDriver's idempotency keys apart from other endpoints'
+const createDriverScope = "CreateDriver"
+
 func (s *service) CreateDriver(ctx context.Context, req *genproto.CreateDriverRequest) (*genproto.CreateDriverResponse, error) {
+	ctx, span := tracing.Start(ctx, "staff.CreateDriver")
+	defer span.End()
+
 	// Validate the request
-	if err := validator.ValidateCreateDriverRequest(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	if err := validator.ValidateCreateDriverRequest(req, s.clock); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	externalID, err := uuid.NewV4()
+	if err != nil {
+		return nil, grpcerr.Internal("failed to generate UUID", err)
+	}
+
+	key := idempotency.FromIncomingContext(ctx)
+	if key == "" {
+		span.SetAttributes(attribute.String("driver.id", externalID.String()))
+		return s.createDriver(ctx, req, externalID)
+	}
+	if err := idempotency.Validate(key); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// The key is claimed before the duplicate checks, so a retry gets the driver back
+	// rather than being told the license number is taken
+	idemKey := idempotency.Key{Scope: createDriverScope, ActorID: actor.FromIncomingContext(ctx), Value: key}
+	boundID, err := s.store.ClaimIdempotencyKey(ctx, idemKey, externalID, idempotency.TTL)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to claim idempotency key", err)
+	}
+	span.SetAttributes(attribute.String("driver.id", boundID.String()))
+
+	if boundID != externalID {
+		driver, err := s.store.GetDriverByID(ctx, boundID)
+		if err != nil {
+			if errors.Is(err, types.ErrDriverNotFound) {
+				return nil, status.Errorf(codes.Aborted, "a request with this idempotency key is still in progress, or its driver was deleted")
+			}
+			return nil, grpcerr.Internal("failed to get driver for idempotency key", err)
+		}
+		log.Printf("Replaying CreateDriver for idempotency key %s: driver %s", key, boundID)
+		return &genproto.CreateDriverResponse{Driver: driver}, nil
 	}
 
+	resp, err := s.createDriver(ctx, req, externalID)
+	if err != nil {
+		// Free the key so the client can retry the failed request with it. The request's
+		// context may already be done, which mustn't stop the release.
+		if rerr := s.store.ReleaseIdempotencyKey(context.WithoutCancel(ctx), idemKey, externalID); rerr != nil {
+			log.Printf("Failed to release idempotency key %s: %v", key, rerr)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// createDriver creates the driver requested under externalID
+func (s *service) createDriver(ctx context.Context, req *genproto.CreateDriverRequest, externalID uuid.UUID) (*genproto.CreateDriverResponse, error) {
 	driver := req.Driver
 
+	if s.config.StrictHireDates && driver.HireDate != nil {
+		if err := validator.ValidateHireDateAgainstLicense("hire_date", driver.HireDate.AsTime(),
+			driver.LicenseExpiry.AsTime(), driver.LicenseClass, driver.ExperienceYears, s.clock); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+	}
+
 	// Check for duplicate license number
 	existing, err := s.store.GetDriverByLicenseNumber(ctx, driver.LicenseNumber)
 	if err != nil && !errors.Is(err, types.ErrDriverNotFound) {
-		return nil, status.Errorf(codes.Internal, "failed to check license uniqueness: %v", err)
+		return nil, grpcerr.Internal("failed to check license uniqueness", err)
 	}
 	if existing != nil {
 		return nil, status.Errorf(codes.AlreadyExists, "driver with license number %s already exists", driver.LicenseNumber)
@@ -51,7 +130,7 @@ func (s *service) CreateDriver(ctx context.Context, req *genproto.CreateDriverRe
 	// Check for duplicate user ID
 	existingByUser, err := s.store.GetDriverByUserID(ctx, driver.UserId)
 	if err != nil && !errors.Is(err, types.ErrDriverNotFound) {
-		return nil, status.Errorf(codes.Internal, "failed to check user ID uniqueness: %v", err)
+		return nil, grpcerr.Internal("failed to check user ID uniqueness", err)
 	}
 	if existingByUser != nil {
 		return nil, status.Errorf(codes.AlreadyExists, "driver profile already exists for user %s", driver.UserId)
@@ -59,23 +138,12 @@ func (s *service) CreateDriver(ctx context.Context, req *genproto.CreateDriverRe
 
 	// Check if license is expired
 	licenseExpiry := driver.LicenseExpiry.AsTime()
-	if licenseExpiry.Before(time.Now()) {
+	if licenseExpiry.Before(s.clock.Now()) {
 		return nil, status.Errorf(codes.InvalidArgument, "cannot create driver with expired license")
 	}
 
 	// Generate unique IDs
-	nodeID, err := utils.GetSnowflakeNodeID()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get snowflake node ID: %v", err)
-	}
-
-	generator := snowflake.New(int(nodeID))
-	internalID := generator.Next()
-
-	externalID, err := uuid.NewV4()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate UUID: %v", err)
-	}
+	internalID := s.ids.Next()
 
 	// Prepare driver data
 	driverData := &types.DriverData{
@@ -100,13 +168,13 @@ func (s *service) CreateDriver(ctx context.Context, req *genproto.CreateDriverRe
 		if errors.Is(err, types.ErrDuplicateEntry) {
 			return nil, status.Errorf(codes.AlreadyExists, "driver with this license number or user ID already exists")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to create driver: %v", err)
+		return nil, grpcerr.Internal("failed to create driver", err)
 	}
 
 	// Retrieve the created driver
 	createdDriver, err := s.store.GetDriverByID(ctx, externalID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve created driver: %v", err)
+		return nil, grpcerr.Internal("failed to retrieve created driver", err)
 	}
 
 	log.Printf("Driver created successfully for user %s with license %s", driver.UserId, driver.LicenseNumber)
@@ -133,7 +201,7 @@ func (s *service) GetDriver(ctx context.Context, req *genproto.GetDriverRequest)
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get driver: %v", err)
+		return nil, grpcerr.Internal("failed to get driver", err)
 	}
 
 	return &genproto.GetDriverResponse{
@@ -152,7 +220,7 @@ func (s *service) GetDriverByUserID(ctx context.Context, req *genproto.GetDriver
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found for user")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get driver by user ID: %v", err)
+		return nil, grpcerr.Internal("failed to get driver by user ID", err)
 	}
 
 	return &genproto.GetDriverResponse{
@@ -160,16 +228,39 @@ func (s *service) GetDriverByUserID(ctx context.Context, req *genproto.GetDriver
 	}, nil
 }
 
-func (s *service) ListDrivers(ctx context.Context, req *genproto.ListDriversRequest) (*genproto.ListDriversResponse, error) {
-	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
+// GetDriversByUserIDs looks up the driver profiles of many users at once so callers
+// aggregating user lists don't need a GetDriverByUserID round trip per user
+func (s *service) GetDriversByUserIDs(ctx context.Context, req *genproto.GetDriversByUserIDsRequest) (*genproto.GetDriversByUserIDsResponse, error) {
+	if len(req.UserIds) > types.MaxDriversByUserIDs {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d user IDs can be looked up per request, got %d", types.MaxDriversByUserIDs, len(req.UserIds))
+	}
+
+	// Drop blanks and duplicates before building the IN list
+	userIDs := make([]string, 0, len(req.UserIds))
+	seen := make(map[string]bool, len(req.UserIds))
+	for _, id := range req.UserIds {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		userIDs = append(userIDs, id)
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	drivers, err := s.store.GetDriversByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to get drivers by user IDs", err)
 	}
 
+	return &genproto.GetDriversByUserIDsResponse{
+		Drivers: drivers,
+	}, nil
+}
+
+func (s *service) ListDrivers(ctx context.Context, req *genproto.ListDriversRequest) (*genproto.ListDriversResponse, error) {
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
 	// Prepare parameters
 	params := types.ListDriversParams{
 		PageSize:  pageSize,
@@ -186,16 +277,26 @@ func (s *service) ListDrivers(ctx context.Context, req *genproto.ListDriversRequ
 		params.LicenseExpiringSoon = req.LicenseExpiringSoon
 	}
 
+	sort, err := types.DriverSorts.Lookup(req.GetOrderBy())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_by: %v", err)
+	}
+	params.Sort = sort
+
 	// Get drivers from store
-	drivers, nextPageToken, err := s.store.ListDrivers(ctx, params)
+	drivers, nextPageToken, prevPageToken, totalCount, err := s.store.ListDrivers(ctx, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list drivers: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list drivers", err)
 	}
 
 	return &genproto.ListDriversResponse{
 		Drivers:       drivers,
 		NextPageToken: nextPageToken,
-		TotalCount:    int32(len(drivers)),
+		PrevPageToken: prevPageToken,
+		TotalCount:    totalCount,
 	}, nil
 }
 
@@ -206,7 +307,10 @@ func (s *service) UpdateDriverStatus(ctx context.Context, req *genproto.UpdateDr
 
 	// Validate status
 	if err := validator.ValidateDriverStatus("status", req.Status); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+	if err := validator.ValidateStatusChangeReason("reason", req.Status, req.Reason); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Parse driver ID
@@ -221,28 +325,21 @@ func (s *service) UpdateDriverStatus(ctx context.Context, req *genproto.UpdateDr
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get current driver: %v", err)
+		return nil, grpcerr.Internal("failed to get current driver", err)
 	}
 
-	// Check if status transition is valid
-	if !types.IsValidDriverStatusTransition(currentDriver.Status, req.Status) {
-		return nil, status.Errorf(codes.InvalidArgument,
-			"invalid status transition from %s to %s",
-			currentDriver.Status.String(), req.Status.String())
-	}
-
-	// Business rule: Cannot activate driver with expired license
-	if req.Status == genproto.DriverStatus_ACTIVE && currentDriver.LicenseExpired {
-		return nil, status.Errorf(codes.FailedPrecondition, "cannot activate driver with expired license")
+	// Check the status transition and business rules
+	if blocks := s.statusChangeBlocks(currentDriver, req.Status); len(blocks) > 0 {
+		return nil, blocks[0].Err()
 	}
 
 	// Update status
-	updatedDriver, err := s.store.UpdateDriverStatus(ctx, driverID, req.Status, req.Reason)
+	updatedDriver, err := s.store.UpdateDriverStatus(ctx, driverID, req.Status, req.Reason, actor.FromIncomingContext(ctx))
 	if err != nil {
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update driver status: %v", err)
+		return nil, grpcerr.Internal("failed to update driver status", err)
 	}
 
 	log.Printf("Driver %s status updated from %s to %s. Reason: %s",
@@ -253,31 +350,269 @@ func (s *service) UpdateDriverStatus(ctx context.Context, req *genproto.UpdateDr
 	}, nil
 }
 
+// RenewDriverLicense records a renewed license, reactivating the driver when they were
+// suspended only because the old license expired
+func (s *service) RenewDriverLicense(ctx context.Context, req *genproto.RenewDriverLicenseRequest) (*genproto.RenewDriverLicenseResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	if err := validator.ValidateRequiredTimestamp("new_expiry", req.NewExpiry); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+	newExpiry := req.NewExpiry.AsTime()
+	if err := validator.ValidateRenewedLicenseExpiry("new_expiry", newExpiry, s.clock); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	var licenseNumber *string
+	if req.NewLicenseNumber != nil {
+		if err := validator.ValidateKenyanLicense("new_license_number", req.GetNewLicenseNumber()); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+		normalized := validator.NormalizeLicense(req.GetNewLicenseNumber())
+		licenseNumber = &normalized
+	}
+
+	driver, reactivated, err := s.store.RenewDriverLicense(ctx, driverID, newExpiry.Format("2006-01-02"), licenseNumber, actor.FromIncomingContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrDriverNotFound):
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		case errors.Is(err, types.ErrDuplicateEntry):
+			return nil, status.Errorf(codes.AlreadyExists, "license number is already used by another driver")
+		}
+		return nil, grpcerr.Internal("failed to renew driver license", err)
+	}
+
+	log.Printf("Driver %s license renewed until %s (reactivated: %t)", req.DriverId, newExpiry.Format("2006-01-02"), reactivated)
+	return &genproto.RenewDriverLicenseResponse{
+		Driver:      driver,
+		Reactivated: reactivated,
+	}, nil
+}
+
+func (s *service) ValidateDriverStatusChange(ctx context.Context, req *genproto.ValidateDriverStatusChangeRequest) (*genproto.ValidateDriverStatusChangeResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	// Validate status
+	if err := validator.ValidateDriverStatus("status", req.Status); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	currentDriver, err := s.store.GetDriverByID(ctx, driverID)
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to get current driver", err)
+	}
+
+	blocks := s.statusChangeBlocks(currentDriver, req.Status)
+	reasons := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		reasons = append(reasons, block.Message())
+	}
+
+	return &genproto.ValidateDriverStatusChangeResponse{
+		Allowed:       len(blocks) == 0,
+		Reasons:       reasons,
+		CurrentStatus: currentDriver.Status,
+	}, nil
+}
+
+func (s *service) ListDriverStatusHistory(ctx context.Context, req *genproto.ListDriverStatusHistoryRequest) (*genproto.ListDriverStatusHistoryResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	// Distinguish an unknown driver from one that has never changed status
+	if _, err := s.store.GetDriverByID(ctx, driverID); err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to get driver", err)
+	}
+
+	entries, nextPageToken, err := s.store.ListDriverStatusHistory(ctx, driverID, pageSize, req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list status history", err)
+	}
+
+	return &genproto.ListDriverStatusHistoryResponse{
+		Entries:       entries,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// statusChangeBlocks returns everything that stops driver moving to the target status,
+// as the errors UpdateDriverStatus fails with, in the order it checks them.
+// UpdateDriverStatus and its dry run ValidateDriverStatusChange both go through here
+// so they can't disagree.
+func (s *service) statusChangeBlocks(driver *genproto.Driver, target genproto.DriverStatus) []*status.Status {
+	var blocks []*status.Status
+
+	if !types.IsValidDriverStatusTransition(driver.Status, target) {
+		blocks = append(blocks, status.Newf(codes.InvalidArgument,
+			"invalid status transition from %s to %s",
+			driver.Status.String(), target.String()))
+	}
+
+	// Business rule: Cannot activate driver with expired license
+	if target == genproto.DriverStatus_ACTIVE && driver.LicenseExpiry.AsTime().Before(s.clock.Now()) {
+		blocks = append(blocks, status.New(codes.FailedPrecondition, "cannot activate driver with expired license"))
+	}
+
+	return blocks
+}
+
 func (s *service) GetActiveDrivers(ctx context.Context, req *genproto.GetActiveDriversRequest) (*genproto.ListDriversResponse, error) {
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	params := types.ListDriversParams{
+		PageSize:              pageSize,
+		PageToken:             req.GetPageToken(),
+		LicenseClassFilter:    req.LicenseClassFilter,
+		IncludeExpiredLicense: req.IncludeExpiredLicense,
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	drivers, nextPageToken, totalCount, err := s.store.GetActiveDrivers(ctx, params)
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get active drivers", err)
 	}
 
+	return &genproto.ListDriversResponse{
+		Drivers:       drivers,
+		NextPageToken: nextPageToken,
+		TotalCount:    totalCount,
+	}, nil
+}
+
+func (s *service) GetEligibleDriversForVehicleType(ctx context.Context, req *genproto.GetEligibleDriversForVehicleTypeRequest) (*genproto.ListDriversResponse, error) {
+	if req.GetVehicleType() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle type is required")
+	}
+
+	licenseClasses, ok := validator.LicenseClassesForVehicleType(req.GetVehicleType())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no license rules for vehicle type %s", req.GetVehicleType())
+	}
+
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
 	params := types.ListDriversParams{
-		PageSize:           pageSize,
-		PageToken:          req.GetPageToken(),
-		LicenseClassFilter: req.LicenseClassFilter,
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
+	}
+
+	drivers, nextPageToken, totalCount, err := s.store.GetEligibleDrivers(ctx, licenseClasses, params)
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get eligible drivers", err)
+	}
+
+	return &genproto.ListDriversResponse{
+		Drivers:       drivers,
+		NextPageToken: nextPageToken,
+		TotalCount:    totalCount,
+	}, nil
+}
+
+// CheckDriverEligibility applies the rules GetEligibleDriversForVehicleType filters by
+// to a single driver, reporting every rule the driver fails
+func (s *service) CheckDriverEligibility(ctx context.Context, req *genproto.CheckDriverEligibilityRequest) (*genproto.CheckDriverEligibilityResponse, error) {
+	if req.GetDriverId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+	if req.GetVehicleType() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle type is required")
+	}
+
+	driverID, err := uuid.FromString(req.GetDriverId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	driver, err := s.store.GetDriverByID(ctx, driverID)
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to get driver", err)
+	}
+
+	var reasons []string
+	if driver.Status != genproto.DriverStatus_ACTIVE {
+		reasons = append(reasons, fmt.Sprintf("driver is %s, not ACTIVE", driver.Status.String()))
+	}
+	if !driver.LicenseExpiry.AsTime().After(s.clock.Now()) {
+		reasons = append(reasons, fmt.Sprintf("driver license expired on %s", driver.LicenseExpiry.AsTime().Format("2006-01-02")))
+	}
+	if err := validator.ValidateLicenseClassForVehicle("license_class", driver.LicenseClass, req.GetVehicleType()); err != nil {
+		reasons = append(reasons, err.Error())
+	}
+	if !driver.HandbookCurrent {
+		reasons = append(reasons, "driver has not acknowledged the current operating handbook")
 	}
 
-	drivers, nextPageToken, err := s.store.GetActiveDrivers(ctx, params)
+	return &genproto.CheckDriverEligibilityResponse{
+		Eligible: len(reasons) == 0,
+		Reasons:  reasons,
+		Driver:   driver,
+	}, nil
+}
+
+func (s *service) ListRecentlyUpdatedDrivers(ctx context.Context, req *genproto.ListRecentlyUpdatedDriversRequest) (*genproto.ListDriversResponse, error) {
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	params := types.ListDriversParams{
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
+	}
+
+	drivers, nextPageToken, totalCount, err := s.store.ListRecentlyUpdatedDrivers(ctx, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get active drivers: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list recently updated drivers", err)
 	}
 
 	return &genproto.ListDriversResponse{
 		Drivers:       drivers,
 		NextPageToken: nextPageToken,
-		TotalCount:    int32(len(drivers)),
+		TotalCount:    totalCount,
 	}, nil
 }
 
@@ -285,8 +620,8 @@ func (s *service) GetActiveDrivers(ctx context.Context, req *genproto.GetActiveD
 
 func (s *service) AddDriverCertification(ctx context.Context, req *genproto.AddDriverCertificationRequest) (*genproto.AddDriverCertificationResponse, error) {
 	// Validate the request
-	if err := validator.ValidateAddCertificationRequest(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	if err := validator.ValidateAddCertificationRequest(req, s.clock); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Parse driver ID
@@ -301,17 +636,11 @@ func (s *service) AddDriverCertification(ctx context.Context, req *genproto.AddD
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to verify driver: %v", err)
+		return nil, grpcerr.Internal("failed to verify driver", err)
 	}
 
 	// Generate certification ID
-	nodeID, err := utils.GetSnowflakeNodeID()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get snowflake node ID: %v", err)
-	}
-
-	generator := snowflake.New(int(nodeID))
-	certID := generator.Next()
+	certID := s.ids.Next()
 
 	cert := req.Certification
 
@@ -326,7 +655,14 @@ func (s *service) AddDriverCertification(ctx context.Context, req *genproto.AddD
 	// Add certification
 	certification, err := s.store.AddDriverCertification(ctx, certID, driverID, certData)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to add certification: %v", err)
+		var duplicate *types.DuplicateCertificationError
+		if errors.As(err, &duplicate) {
+			return nil, duplicateCertificationStatus(duplicate.ExistingID)
+		}
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to add certification", err)
 	}
 
 	log.Printf("Certification %s added for driver %s", cert.CertificationName, req.DriverId)
@@ -336,6 +672,24 @@ func (s *service) AddDriverCertification(ctx context.Context, req *genproto.AddD
 	}, nil
 }
 
+// duplicateCertificationStatus rejects adding a certification the driver already holds.
+// The existing certification's ID is attached so clients can offer to update it instead.
+func duplicateCertificationStatus(existingID string) error {
+	st := status.Newf(codes.AlreadyExists,
+		"driver already holds a valid certification with this name from this issuer (certification %s); "+
+			"update that certification instead, or add it again once it has expired or been revoked", existingID)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "DUPLICATE_CERTIFICATION",
+		Metadata: map[string]string{"existing_certification_id": existingID},
+	})
+	if err != nil {
+		// The message still names the existing certification
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 // Verification and compliance
 
 func (s *service) VerifyDriverLicense(ctx context.Context, req *genproto.VerifyDriverLicenseRequest) (*genproto.VerifyDriverLicenseResponse, error) {
@@ -355,39 +709,101 @@ func (s *service) VerifyDriverLicense(ctx context.Context, req *genproto.VerifyD
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get driver: %v", err)
+		return nil, grpcerr.Internal("failed to get driver", err)
 	}
 
-	// Verify license number matches if provided
-	if req.LicenseNumber != "" && driver.LicenseNumber != req.LicenseNumber {
-		return &genproto.VerifyDriverLicenseResponse{
-			IsValid:            false,
-			IsExpired:          false,
-			VerificationSource: "internal_check",
-			VerifiedAt:         timestamppb.New(time.Now()),
-			Notes:              "License number mismatch",
-		}, nil
+	isValid, isExpired, notes := verifyLicense(driver, req.LicenseNumber)
+	return &genproto.VerifyDriverLicenseResponse{
+		IsValid:            isValid,
+		IsExpired:          isExpired,
+		VerificationSource: licenseVerificationSource,
+		VerifiedAt:         timestamppb.New(s.clock.Now()),
+		Notes:              notes,
+	}, nil
+}
+
+func (s *service) BatchVerifyDriverLicenses(ctx context.Context, req *genproto.BatchVerifyDriverLicensesRequest) (*genproto.BatchVerifyDriverLicensesResponse, error) {
+	if len(req.DriverIds) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one driver ID is required")
+	}
+	if len(req.DriverIds) > types.MaxBatchVerifyDrivers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d driver IDs can be verified per request, got %d", types.MaxBatchVerifyDrivers, len(req.DriverIds))
 	}
 
-	// Check if license is expired
-	isExpired := driver.LicenseExpired
+	// Parse all IDs up front so a typo fails the whole batch instead of a silent "not found"
+	driverIDs := make([]uuid.UUID, 0, len(req.DriverIds))
+	for i, idStr := range req.DriverIds {
+		driverID, err := uuid.FromString(idStr)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format at index %d: %v", i, err)
+		}
+		driverIDs = append(driverIDs, driverID)
+	}
 
-	// In a real implementation, this would integrate with external systems
-	// like NTSA (National Transport and Safety Authority) in Kenya
-	return &genproto.VerifyDriverLicenseResponse{
-		IsValid:            !isExpired,
-		IsExpired:          isExpired,
-		VerificationSource: "internal_check",
-		VerifiedAt:         timestamppb.New(time.Now()),
-		Notes:              fmt.Sprintf("License status verified. Days until expiry: %d", driver.DaysUntilLicenseExpiry),
+	drivers, err := s.store.GetDriversByIDs(ctx, driverIDs)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to get drivers", err)
+	}
+
+	// The store returns hex IDs without dashes, so key on the parsed UUID
+	driversByID := make(map[uuid.UUID]*genproto.Driver, len(drivers))
+	for _, driver := range drivers {
+		if id, err := uuid.FromString(driver.Id); err == nil {
+			driversByID[id] = driver
+		}
+	}
+
+	// Results follow the request order, duplicates included
+	results := make([]*genproto.DriverLicenseVerification, 0, len(driverIDs))
+	for i, driverID := range driverIDs {
+		driver, ok := driversByID[driverID]
+		if !ok {
+			results = append(results, &genproto.DriverLicenseVerification{
+				DriverId: req.DriverIds[i],
+				Notes:    "Driver not found",
+			})
+			continue
+		}
+
+		isValid, isExpired, notes := verifyLicense(driver, "")
+		results = append(results, &genproto.DriverLicenseVerification{
+			DriverId:               req.DriverIds[i],
+			Found:                  true,
+			IsValid:                isValid,
+			IsExpired:              isExpired,
+			LicenseExpiry:          driver.LicenseExpiry,
+			DaysUntilLicenseExpiry: driver.DaysUntilLicenseExpiry,
+			Notes:                  notes,
+		})
+	}
+
+	return &genproto.BatchVerifyDriverLicensesResponse{
+		Results:            results,
+		VerificationSource: licenseVerificationSource,
+		VerifiedAt:         timestamppb.New(s.clock.Now()),
 	}, nil
 }
 
+const licenseVerificationSource = "internal_check"
+
+// verifyLicense checks a driver's license, optionally against a claimed license number.
+// In a real implementation, this would integrate with external systems
+// like NTSA (National Transport and Safety Authority) in Kenya
+func verifyLicense(driver *genproto.Driver, licenseNumber string) (isValid, isExpired bool, notes string) {
+	if licenseNumber != "" && driver.LicenseNumber != licenseNumber {
+		return false, false, "License number mismatch"
+	}
+
+	isExpired = driver.LicenseExpired
+	return !isExpired, isExpired, fmt.Sprintf("License status verified. Days until expiry: %d", driver.DaysUntilLicenseExpiry)
+}
+
 // UpdateDriver handles driver information updates
 func (s *service) UpdateDriver(ctx context.Context, req *genproto.UpdateDriverRequest) (*genproto.UpdateDriverResponse, error) {
 	// Validate the request
-	if err := validator.ValidateUpdateDriverRequest(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	warnings, err := validator.ValidateUpdateDriverRequest(req, s.clock)
+	if err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Parse driver ID
@@ -402,16 +818,22 @@ func (s *service) UpdateDriver(ctx context.Context, req *genproto.UpdateDriverRe
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get existing driver: %v", err)
+		return nil, grpcerr.Internal("failed to get existing driver", err)
 	}
 
 	driver := req.Driver
 
+	if s.config.StrictHireDates {
+		if err := checkHireDateAgainstLicense(existingDriver, driver, req.UpdateMask, s.clock); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+	}
+
 	// Check license number uniqueness if being updated
 	if driver.LicenseNumber != "" && driver.LicenseNumber != existingDriver.LicenseNumber {
 		existing, err := s.store.GetDriverByLicenseNumber(ctx, driver.LicenseNumber)
 		if err != nil && !errors.Is(err, types.ErrDriverNotFound) {
-			return nil, status.Errorf(codes.Internal, "failed to check license uniqueness: %v", err)
+			return nil, grpcerr.Internal("failed to check license uniqueness", err)
 		}
 		if existing != nil && existing.Id != existingDriver.Id {
 			return nil, status.Errorf(codes.AlreadyExists, "driver with license number %s already exists", driver.LicenseNumber)
@@ -422,7 +844,7 @@ func (s *service) UpdateDriver(ctx context.Context, req *genproto.UpdateDriverRe
 	if driver.UserId != "" && driver.UserId != existingDriver.UserId {
 		existing, err := s.store.GetDriverByUserID(ctx, driver.UserId)
 		if err != nil && !errors.Is(err, types.ErrDriverNotFound) {
-			return nil, status.Errorf(codes.Internal, "failed to check user ID uniqueness: %v", err)
+			return nil, grpcerr.Internal("failed to check user ID uniqueness", err)
 		}
 		if existing != nil && existing.Id != existingDriver.Id {
 			return nil, status.Errorf(codes.AlreadyExists, "driver profile already exists for user %s", driver.UserId)
@@ -462,7 +884,7 @@ func (s *service) UpdateDriver(ctx context.Context, req *genproto.UpdateDriverRe
 	}
 
 	// Update driver in store
-	updatedDriver, err := s.store.UpdateDriver(ctx, driverID, updates, req.UpdateMask)
+	updatedDriver, err := s.store.UpdateDriver(ctx, driverID, updates, req.UpdateMask, actor.FromIncomingContext(ctx))
 	if err != nil {
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
@@ -470,11 +892,12 @@ func (s *service) UpdateDriver(ctx context.Context, req *genproto.UpdateDriverRe
 		if errors.Is(err, types.ErrDuplicateEntry) {
 			return nil, status.Errorf(codes.AlreadyExists, "duplicate license number or user ID")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update driver: %v", err)
+		return nil, grpcerr.Internal("failed to update driver", err)
 	}
 
 	return &genproto.UpdateDriverResponse{
-		Driver: updatedDriver,
+		Driver:                updatedDriver,
+		NormalizationWarnings: warnings,
 	}, nil
 }
 
@@ -496,7 +919,7 @@ func (s *service) DeleteDriver(ctx context.Context, req *genproto.DeleteDriverRe
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return status.Errorf(codes.NotFound, "driver not found")
 		}
-		return status.Errorf(codes.Internal, "failed to get driver: %v", err)
+		return grpcerr.Internal("failed to get driver", err)
 	}
 
 	// Business rule: Cannot delete active drivers with recent activity
@@ -507,17 +930,211 @@ func (s *service) DeleteDriver(ctx context.Context, req *genproto.DeleteDriverRe
 	}
 
 	// Soft delete by setting status to INACTIVE
-	if err := s.store.DeleteDriver(ctx, driverID); err != nil {
+	if err := s.store.DeleteDriver(ctx, driverID, actor.FromIncomingContext(ctx)); err != nil {
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return status.Errorf(codes.NotFound, "driver not found")
 		}
-		return status.Errorf(codes.Internal, "failed to delete driver: %v", err)
+		return grpcerr.Internal("failed to delete driver", err)
 	}
 
 	log.Printf("Driver %s marked as inactive (soft deleted)", req.DriverId)
 	return nil
 }
 
+// RestoreDriver brings a soft deleted driver back. They return as PENDING_VERIFICATION
+// rather than ACTIVE so they are vetted again, and not at all while their license is expired.
+func (s *service) RestoreDriver(ctx context.Context, req *genproto.RestoreDriverRequest) (*genproto.RestoreDriverResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	currentDriver, err := s.store.GetDriverByID(ctx, driverID)
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to get current driver", err)
+	}
+	if currentDriver.Status != genproto.DriverStatus_INACTIVE {
+		return nil, status.Errorf(codes.FailedPrecondition, "only inactive drivers can be restored, driver is %s", currentDriver.Status.String())
+	}
+
+	// Business rule: Cannot restore driver with expired license
+	if currentDriver.LicenseExpiry.AsTime().Before(s.clock.Now()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "cannot restore driver with expired license; renew the license first")
+	}
+
+	restoredDriver, err := s.store.RestoreDriver(ctx, driverID, actor.FromIncomingContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrDriverNotFound):
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		case errors.Is(err, types.ErrDriverNotInactive):
+			return nil, status.Errorf(codes.FailedPrecondition, "only inactive drivers can be restored")
+		}
+		return nil, grpcerr.Internal("failed to restore driver", err)
+	}
+
+	log.Printf("Driver %s restored by %s, pending verification", req.DriverId, actor.FromIncomingContext(ctx))
+	return &genproto.RestoreDriverResponse{
+		Driver: restoredDriver,
+	}, nil
+}
+
+// HardDeleteDriver permanently removes a driver and their certifications, for erasure
+// requests. Drivers still ACTIVE must be suspended or deactivated first.
+func (s *service) HardDeleteDriver(ctx context.Context, req *genproto.HardDeleteDriverRequest) (*genproto.HardDeleteDriverResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	certificationsDeleted, err := s.store.HardDeleteDriver(ctx, driverID)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrDriverNotFound):
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		case errors.Is(err, types.ErrDriverActive):
+			return nil, status.Errorf(codes.FailedPrecondition, "active drivers cannot be purged; suspend or deactivate the driver first")
+		}
+		return nil, grpcerr.Internal("failed to purge driver", err)
+	}
+
+	log.Printf("Driver %s purged by %s with %d certifications", req.DriverId, actor.FromIncomingContext(ctx), certificationsDeleted)
+	return &genproto.HardDeleteDriverResponse{
+		DriverId:              req.DriverId,
+		CertificationsDeleted: int32(certificationsDeleted),
+	}, nil
+}
+
+func (s *service) MergeDrivers(ctx context.Context, req *genproto.MergeDriversRequest) (*genproto.MergeDriversResponse, error) {
+	if req.PrimaryDriverId == "" || req.DuplicateDriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "primary and duplicate driver IDs are required")
+	}
+
+	primaryID, err := uuid.FromString(req.PrimaryDriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid primary driver ID format: %v", err)
+	}
+	duplicateID, err := uuid.FromString(req.DuplicateDriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid duplicate driver ID format: %v", err)
+	}
+	if primaryID == duplicateID {
+		return nil, status.Errorf(codes.InvalidArgument, "a driver cannot be merged into itself")
+	}
+
+	// Check both drivers exist up front so the caller learns which one is missing
+	primary, err := s.store.GetDriverByID(ctx, primaryID)
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "primary driver not found")
+		}
+		return nil, grpcerr.Internal("failed to get primary driver", err)
+	}
+	if _, err := s.store.GetDriverByID(ctx, duplicateID); err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "duplicate driver not found")
+		}
+		return nil, grpcerr.Internal("failed to get duplicate driver", err)
+	}
+
+	// Merging into a soft-deleted record would hide the combined history
+	if primary.Status == genproto.DriverStatus_INACTIVE {
+		return nil, status.Errorf(codes.FailedPrecondition, "primary driver is inactive; merge into the active record instead")
+	}
+
+	result, err := s.store.MergeDrivers(ctx, primaryID, duplicateID, actor.FromIncomingContext(ctx))
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to merge drivers", err)
+	}
+
+	log.Printf("Driver %s merged into %s (%d certifications, %d status history entries, %d normalization audit entries moved)",
+		req.DuplicateDriverId, req.PrimaryDriverId, result.CertificationsMoved, result.StatusHistoryMoved, result.NormalizationAuditMoved)
+
+	return &genproto.MergeDriversResponse{
+		Driver:              result.Driver,
+		CertificationsMoved: int32(result.CertificationsMoved),
+		StatusHistoryMoved:  int32(result.StatusHistoryMoved),
+	}, nil
+}
+
+// UpdateDriverRating records one trip rating against the driver's running average
+func (s *service) UpdateDriverRating(ctx context.Context, req *genproto.UpdateDriverRatingRequest) (*genproto.UpdateDriverRatingResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	if err := validator.ValidateRating("rating", req.Rating); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	driver, err := s.store.UpdateDriverRating(ctx, driverID, req.Rating, actor.FromIncomingContext(ctx))
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to update driver rating", err)
+	}
+
+	return &genproto.UpdateDriverRatingResponse{
+		Driver: driver,
+	}, nil
+}
+
+// AcknowledgeHandbook records a driver's acknowledgement of the operating handbook.
+// Until the current version is acknowledged the driver is not offered for assignments.
+func (s *service) AcknowledgeHandbook(ctx context.Context, req *genproto.AcknowledgeHandbookRequest) (*genproto.AcknowledgeHandbookResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	version := strings.TrimSpace(req.Version)
+	if version == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "handbook version is required")
+	}
+	if len(version) > types.MaxHandbookVersionLength {
+		return nil, status.Errorf(codes.InvalidArgument, "handbook version cannot be more than %d characters", types.MaxHandbookVersionLength)
+	}
+	if s.config.HandbookVersion != "" && version != s.config.HandbookVersion {
+		return nil, status.Errorf(codes.FailedPrecondition, "handbook version %s is not the current version %s", version, s.config.HandbookVersion)
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	driver, err := s.store.AcknowledgeHandbook(ctx, driverID, version, actor.FromIncomingContext(ctx))
+	if err != nil {
+		if errors.Is(err, types.ErrDriverNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver not found")
+		}
+		return nil, grpcerr.Internal("failed to acknowledge handbook", err)
+	}
+
+	return &genproto.AcknowledgeHandbookResponse{
+		Driver: driver,
+	}, nil
+}
+
 // ListDriverCertifications handles listing certifications for a driver
 func (s *service) ListDriverCertifications(ctx context.Context, req *genproto.ListDriverCertificationsRequest) (*genproto.ListDriverCertificationsResponse, error) {
 	if req.DriverId == "" {
@@ -536,17 +1153,11 @@ func (s *service) ListDriverCertifications(ctx context.Context, req *genproto.Li
 		if errors.Is(err, types.ErrDriverNotFound) {
 			return nil, status.Errorf(codes.NotFound, "driver not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to verify driver: %v", err)
+		return nil, grpcerr.Internal("failed to verify driver", err)
 	}
 
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
 
 	params := types.ListCertificationsParams{
 		PageSize:     pageSize,
@@ -557,7 +1168,10 @@ func (s *service) ListDriverCertifications(ctx context.Context, req *genproto.Li
 
 	certifications, nextPageToken, err := s.store.GetDriverCertifications(ctx, driverID, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list driver certifications: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list driver certifications", err)
 	}
 
 	return &genproto.ListDriverCertificationsResponse{
@@ -588,13 +1202,13 @@ func (s *service) UpdateCertification(ctx context.Context, req *genproto.UpdateC
 	// Validate certification data if provided
 	if cert.CertificationName != "" {
 		if err := validator.ValidateCertificationName("certification_name", cert.CertificationName); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+			return nil, grpcerr.InvalidArgument("validation failed", err)
 		}
 	}
 
 	if cert.IssuedBy != "" {
 		if err := validator.ValidateIssuingAuthority("issued_by", cert.IssuedBy); err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+			return nil, grpcerr.InvalidArgument("validation failed", err)
 		}
 	}
 
@@ -631,7 +1245,7 @@ func (s *service) UpdateCertification(ctx context.Context, req *genproto.UpdateC
 		if errors.Is(err, types.ErrCertificationNotFound) {
 			return nil, status.Errorf(codes.NotFound, "certification not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update certification: %v", err)
+		return nil, grpcerr.Internal("failed to update certification", err)
 	}
 
 	return &genproto.UpdateCertificationResponse{
@@ -657,13 +1271,44 @@ func (s *service) DeleteCertification(ctx context.Context, req *genproto.DeleteC
 		if errors.Is(err, types.ErrCertificationNotFound) {
 			return status.Errorf(codes.NotFound, "certification not found")
 		}
-		return status.Errorf(codes.Internal, "failed to delete certification: %v", err)
+		return grpcerr.Internal("failed to delete certification", err)
 	}
 
 	log.Printf("Certification %s marked as revoked (soft deleted)", req.CertificationId)
 	return nil
 }
 
+// ListCertificationTemplates returns the standard certifications followed by any
+// added to the database. Database entries never override a standard name.
+func (s *service) ListCertificationTemplates(ctx context.Context, req *genproto.ListCertificationTemplatesRequest) (*genproto.ListCertificationTemplatesResponse, error) {
+	stored, err := s.store.ListCertificationTemplates(ctx)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to list certification templates", err)
+	}
+
+	templates := make([]*genproto.CertificationTemplate, 0, len(types.StandardCertifications)+len(stored))
+	seen := make(map[string]bool, cap(templates))
+	for _, cert := range types.StandardCertifications {
+		templates = append(templates, &genproto.CertificationTemplate{
+			CertificationName: cert.Name,
+			IssuedBy:          cert.IssuedBy,
+		})
+		seen[strings.ToLower(cert.Name)] = true
+	}
+	for _, template := range stored {
+		key := strings.ToLower(strings.TrimSpace(template.CertificationName))
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		templates = append(templates, template)
+	}
+
+	return &genproto.ListCertificationTemplatesResponse{
+		Templates: templates,
+	}, nil
+}
+
 // GetExpiringLicenses handles getting drivers with expiring licenses
 func (s *service) GetExpiringLicenses(ctx context.Context, req *genproto.GetExpiringLicensesRequest) (*genproto.ListDriversResponse, error) {
 	daysAhead := req.GetDaysAhead()
@@ -672,41 +1317,68 @@ func (s *service) GetExpiringLicenses(ctx context.Context, req *genproto.GetExpi
 	}
 
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	params := types.ListDriversParams{
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
 	}
-	if pageSize > 100 {
-		pageSize = 100
+
+	drivers, nextPageToken, totalCount, err := s.store.GetExpiringLicenses(ctx, daysAhead, params)
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get expiring licenses", err)
+	}
+
+	return &genproto.ListDriversResponse{
+		Drivers:       drivers,
+		NextPageToken: nextPageToken,
+		TotalCount:    totalCount,
+	}, nil
+}
+
+// maxRecentlyExpiredDays bounds how far back GetRecentlyExpiredLicenses looks
+const maxRecentlyExpiredDays = 365
+
+// GetRecentlyExpiredLicenses handles getting drivers whose licenses expired recently
+func (s *service) GetRecentlyExpiredLicenses(ctx context.Context, req *genproto.GetRecentlyExpiredLicensesRequest) (*genproto.ListDriversResponse, error) {
+	sinceDays := req.GetSinceDays()
+	if sinceDays <= 0 {
+		sinceDays = 30 // Default to 30 days
+	}
+	if sinceDays > maxRecentlyExpiredDays {
+		sinceDays = maxRecentlyExpiredDays
 	}
 
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
 	params := types.ListDriversParams{
 		PageSize:  pageSize,
 		PageToken: req.GetPageToken(),
 	}
 
-	drivers, nextPageToken, err := s.store.GetExpiringLicenses(ctx, daysAhead, params)
+	drivers, nextPageToken, totalCount, err := s.store.GetRecentlyExpiredLicenses(ctx, sinceDays, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get expiring licenses: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get recently expired licenses", err)
 	}
 
 	return &genproto.ListDriversResponse{
 		Drivers:       drivers,
 		NextPageToken: nextPageToken,
-		TotalCount:    int32(len(drivers)),
+		TotalCount:    totalCount,
 	}, nil
 }
 
 // GetExpiredCertifications handles getting expired certifications
 func (s *service) GetExpiredCertifications(ctx context.Context, req *genproto.GetExpiredCertificationsRequest) (*genproto.ListDriverCertificationsResponse, error) {
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
 
 	params := types.ListCertificationsParams{
 		PageSize:  pageSize,
@@ -715,7 +1387,10 @@ func (s *service) GetExpiredCertifications(ctx context.Context, req *genproto.Ge
 
 	certifications, nextPageToken, err := s.store.GetExpiredCertifications(ctx, req.ExpiredSinceDays, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get expired certifications: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get expired certifications", err)
 	}
 
 	return &genproto.ListDriverCertificationsResponse{
@@ -723,3 +1398,60 @@ func (s *service) GetExpiredCertifications(ctx context.Context, req *genproto.Ge
 		NextPageToken:  nextPageToken,
 	}, nil
 }
+
+// checkHireDateAgainstLicense runs the hire date cross-check on the driver as it
+// will look after the update, taking untouched fields from the stored record
+func checkHireDateAgainstLicense(existing *genproto.Driver, input *genproto.DriverInput, mask *fieldmaskpb.FieldMask, clk clock.Clock) error {
+	updating := func(path string, provided bool) bool {
+		if mask != nil {
+			return slices.Contains(mask.Paths, path)
+		}
+		return provided
+	}
+
+	hireDate := existing.HireDate
+	if updating("hire_date", input.HireDate != nil) {
+		hireDate = input.HireDate
+	}
+	licenseExpiry := existing.LicenseExpiry
+	if updating("license_expiry", input.LicenseExpiry != nil) {
+		licenseExpiry = input.LicenseExpiry
+	}
+	if hireDate == nil || licenseExpiry == nil {
+		return nil
+	}
+
+	licenseClass := existing.LicenseClass
+	if updating("license_class", input.LicenseClass != genproto.LicenseClass_LICENSE_UNSPECIFIED) {
+		licenseClass = input.LicenseClass
+	}
+	experienceYears := existing.ExperienceYears
+	if updating("experience_years", input.ExperienceYears != 0) {
+		experienceYears = input.ExperienceYears
+	}
+
+	return validator.ValidateHireDateAgainstLicense("hire_date", hireDate.AsTime(),
+		licenseExpiry.AsTime(), licenseClass, experienceYears, clk)
+}
+
+// ValidatePhoneNumber runs the driver phone number checks without touching the database,
+// so clients can give feedback while the number is typed
+func (s *service) ValidatePhoneNumber(ctx context.Context, req *genproto.ValidatePhoneNumberRequest) (*genproto.FieldValidationResponse, error) {
+	normalized := validator.NormalizePhoneNumber(req.GetPhoneNumber())
+	return fieldValidationResponse(normalized, validator.ValidatePhoneNumber("phone_number", normalized)), nil
+}
+
+// ValidateLicenseNumber runs the driving license format checks without touching the database.
+// It doesn't check whether another driver already holds the license.
+func (s *service) ValidateLicenseNumber(ctx context.Context, req *genproto.ValidateLicenseNumberRequest) (*genproto.FieldValidationResponse, error) {
+	normalized := validator.NormalizeLicense(req.GetLicenseNumber())
+	return fieldValidationResponse(normalized, validator.ValidateKenyanLicense("license_number", normalized)), nil
+}
+
+func fieldValidationResponse(normalized string, err error) *genproto.FieldValidationResponse {
+	resp := &genproto.FieldValidationResponse{Valid: err == nil, Normalized: normalized, Errors: []string{}}
+	if err != nil {
+		resp.Errors = append(resp.Errors, err.Error())
+	}
+	return resp
+}
@@ -2,13 +2,22 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/sqlupdate"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/staff/internal/types"
 	"github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	"github.com/go-sql-driver/mysql"
@@ -18,7 +27,10 @@ import (
 )
 
 type store struct {
-	db *sql.DB
+	db              *sql.DB
+	clock           clock.Clock   // "now" for expiry checks; writes still stamp the real time
+	handbookVersion string        // handbook version drivers must have acknowledged, empty if none is required
+	queryTimeout    time.Duration // cap on each call's database work, see utils.WithQueryTimeout
 }
 
 // Returns a raw *sql.DB for use in migrations
@@ -26,15 +38,38 @@ func NewRawDB(cfg mysql.Config) (*sql.DB, error) {
 	return sql.Open("mysql", cfg.FormatDSN())
 }
 
-// NewStore creates a new staff store
-func NewStore(dsn string) (*store, error) {
+// NewStore creates a new staff store, waiting up to the retry budget for the database to come up.
+// Expiry filters and computed fields are evaluated against clk.
+func NewStore(dsn string, retry utils.DBRetry, pool utils.DBPool, clk clock.Clock) (*store, error) {
 	// Ensure conversion of DATETIME columns to Go's time.Time
 	dsn += "?parseTime=true&loc=Local"
-	db, err := sql.Open("mysql", dsn)
+	db, err := metrics.OpenDB("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
-	return &store{db: db}, nil
+	pool.Apply(db)
+	if err := utils.WaitForDB(context.Background(), db, retry); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &store{db: db, clock: clk, queryTimeout: utils.DefaultDBQueryTimeout}, nil
+}
+
+// SetHandbookVersion sets the operating handbook version drivers must have acknowledged
+// to count as ready. Empty, the default, requires no acknowledgement.
+func (s *store) SetHandbookVersion(version string) {
+	s.handbookVersion = version
+}
+
+// SetQueryTimeout sets how long one store call may spend in the database. 0 leaves calls
+// bounded only by the caller's deadline.
+func (s *store) SetQueryTimeout(timeout time.Duration) {
+	s.queryTimeout = timeout
+}
+
+// Close closes the store's database connections, once the server has stopped using them
+func (s *store) Close() error {
+	return s.db.Close()
 }
 
 // Driver operations
@@ -47,6 +82,9 @@ INSERT INTO drivers (
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 func (s *store) CreateDriver(ctx context.Context, internalID uint64, externalID uuid.UUID, driver *types.DriverData) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -121,12 +159,20 @@ SELECT
 	status,
 	hire_date,
 	created_at,
-	updated_at
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
 FROM drivers
 WHERE external_id = ?
 LIMIT 1`
 
 func (s *store) GetDriverByID(ctx context.Context, externalID uuid.UUID) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	driver, err := s.scanDriver(ctx, getDriverByIDQuery, externalID.Bytes())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -137,6 +183,132 @@ func (s *store) GetDriverByID(ctx context.Context, externalID uuid.UUID) (*genpr
 	return driver, nil
 }
 
+const getDriversByIDsQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE external_id IN (%s)`
+
+// GetDriversByIDs fetches all the given drivers in a single query. Missing IDs are
+// simply absent from the result and the returned order is not guaranteed.
+func (s *store) GetDriversByIDs(ctx context.Context, externalIDs []uuid.UUID) ([]*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(externalIDs))
+	args := make([]interface{}, len(externalIDs))
+	for i, id := range externalIDs {
+		placeholders[i] = "?"
+		args[i] = id.Bytes()
+	}
+
+	query := fmt.Sprintf(getDriversByIDsQuery, strings.Join(placeholders, ", "))
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drivers by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []*genproto.Driver
+	for rows.Next() {
+		driver, err := s.scanDriverFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan driver: %w", err)
+		}
+		drivers = append(drivers, driver)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating drivers: %w", err)
+	}
+
+	return drivers, nil
+}
+
+const getDriversByUserIDsQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE user_id IN (%s)`
+
+// GetDriversByUserIDs fetches the drivers for the given users in a single query,
+// keyed by user ID. Users without a driver profile are absent from the map.
+func (s *store) GetDriversByUserIDs(ctx context.Context, userIDs []string) (map[string]*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	drivers := make(map[string]*genproto.Driver, len(userIDs))
+	if len(userIDs) == 0 {
+		return drivers, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(getDriversByUserIDsQuery, strings.Join(placeholders, ", "))
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drivers by user IDs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		driver, err := s.scanDriverFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan driver: %w", err)
+		}
+		drivers[driver.UserId] = driver
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating drivers: %w", err)
+	}
+
+	return drivers, nil
+}
+
 const getDriverByUserIDQuery = `
 SELECT 
 	LOWER(HEX(external_id)) as external_id,
@@ -151,12 +323,20 @@ SELECT
 	status,
 	hire_date,
 	created_at,
-	updated_at
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
 FROM drivers
 WHERE user_id = ?
 LIMIT 1`
 
 func (s *store) GetDriverByUserID(ctx context.Context, userID string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	driver, err := s.scanDriver(ctx, getDriverByUserIDQuery, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -181,12 +361,20 @@ SELECT
 	status,
 	hire_date,
 	created_at,
-	updated_at
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
 FROM drivers
 WHERE license_number = ?
 LIMIT 1`
 
 func (s *store) GetDriverByLicenseNumber(ctx context.Context, licenseNumber string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	driver, err := s.scanDriver(ctx, getDriverByLicenseQuery, licenseNumber)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -211,30 +399,141 @@ SELECT
 	status,
 	hire_date,
 	created_at,
-	updated_at
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
 FROM drivers
 WHERE (?='' OR status = ?)
   AND (?='' OR license_class = ?)
-  AND (? = 0 OR (? = 1 AND license_expiry BETWEEN NOW() AND DATE_ADD(NOW(), INTERVAL 30 DAY)))
-  AND (?='' OR created_at > ?)
-ORDER BY created_at DESC
+  AND (? = 0 OR (? = 1 AND license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL 30 DAY)))
+  AND (?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+ORDER BY created_at DESC, external_id DESC
+LIMIT ?`
+
+// listDriversBackwardQuery reads the page before a backward cursor, nearest row first
+const listDriversBackwardQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE (?='' OR status = ?)
+  AND (?='' OR license_class = ?)
+  AND (? = 0 OR (? = 1 AND license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL 30 DAY)))
+  AND (?='' OR (created_at >= ? AND (created_at > ? OR external_id > ?)))
+ORDER BY created_at ASC, external_id ASC
+LIMIT ?`
+
+// listDriversByLicenseExpiryQuery is listDriversQuery paged soonest expiry first
+const listDriversByLicenseExpiryQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE (?='' OR status = ?)
+  AND (?='' OR license_class = ?)
+  AND (? = 0 OR (? = 1 AND license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL 30 DAY)))
+  AND (?='' OR (license_expiry >= ? AND (license_expiry > ? OR external_id > ?)))
+ORDER BY license_expiry ASC, external_id ASC
+LIMIT ?`
+
+// listDriversByLicenseExpiryBackwardQuery reads the page before a backward license_expiry cursor
+const listDriversByLicenseExpiryBackwardQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE (?='' OR status = ?)
+  AND (?='' OR license_class = ?)
+  AND (? = 0 OR (? = 1 AND license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL 30 DAY)))
+  AND (?='' OR (license_expiry <= ? AND (license_expiry < ? OR external_id < ?)))
+ORDER BY license_expiry DESC, external_id DESC
 LIMIT ?`
 
-func (s *store) ListDrivers(ctx context.Context, params types.ListDriversParams) ([]*genproto.Driver, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
+const countDriversQuery = `
+SELECT COUNT(*)
+FROM drivers
+WHERE (?='' OR status = ?)
+  AND (?='' OR license_class = ?)
+  AND (? = 0 OR (? = 1 AND license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL 30 DAY)))`
+
+func (s *store) ListDrivers(ctx context.Context, params types.ListDriversParams) ([]*genproto.Driver, string, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	sort := params.Sort
+	if sort == (pagetoken.Sort{}) {
+		sort = types.DriverSorts[""]
 	}
 
 	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(params.PageToken, sort)
+	if err != nil {
+		return nil, "", "", 0, err
+	}
+
+	var query string
+	switch {
+	case sort == pagetoken.LicenseExpiryAsc && cursor.Backward:
+		query = listDriversByLicenseExpiryBackwardQuery
+	case sort == pagetoken.LicenseExpiryAsc:
+		query = listDriversByLicenseExpiryQuery
+	case cursor.Backward:
+		query = listDriversBackwardQuery
+	default:
+		query = listDriversQuery
 	}
 
 	// Prepare filter parameters
@@ -253,215 +552,960 @@ func (s *store) ListDrivers(ctx context.Context, params types.ListDriversParams)
 		expiringSoon = 1
 	}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	cursorStr, cursorID, err := uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", "", 0, err
+	}
+	if sort == pagetoken.LicenseExpiryAsc && cursorStr != "" {
+		// license_expiry is a DATE read back in local time (loc=Local), so compare on the local date
+		cursorStr = cursor.At.In(time.Local).Format("2006-01-02")
 	}
 
-	rows, err := s.db.QueryContext(ctx, listDriversQuery,
+	now := s.clock.Now()
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, countDriversQuery,
 		statusStr, statusStr,
 		licenseClassStr, licenseClassStr,
-		expiringSoon, expiringSoon,
-		cursorStr, cursorStr,
+		expiringSoon, expiringSoon, now, now,
+	).Scan(&total); err != nil {
+		return nil, "", "", 0, fmt.Errorf("failed to count drivers: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, query,
+		statusStr, statusStr,
+		licenseClassStr, licenseClassStr,
+		expiringSoon, expiringSoon, now, now,
+		cursorStr, cursorStr, cursorStr, cursorID,
 		params.PageSize+1,
 	)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to list drivers: %w", err)
+		return nil, "", "", 0, fmt.Errorf("failed to list drivers: %w", err)
 	}
 	defer rows.Close()
 
 	var drivers []*genproto.Driver
-	var lastCreatedAt time.Time
-
 	for rows.Next() {
 		driver, err := s.scanDriverFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan driver: %w", err)
+			return nil, "", "", 0, fmt.Errorf("failed to scan driver: %w", err)
 		}
 		drivers = append(drivers, driver)
-		lastCreatedAt = driver.CreatedAt.AsTime()
 	}
 
-	// Determine next page token
-	var nextPageToken string
-	if int32(len(drivers)) > params.PageSize {
-		drivers = drivers[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
-		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
-	}
+	// Trim the extra driver we fetched and build the tokens either side
+	drivers, nextPageToken, prevPageToken := pagetoken.Paginate(drivers, params.PageSize, sort, cursor,
+		func(d *genproto.Driver) pagetoken.Cursor {
+			if sort == pagetoken.LicenseExpiryAsc {
+				return pagetoken.Cursor{At: d.LicenseExpiry.AsTime(), ID: d.Id}
+			}
+			return pagetoken.Cursor{At: d.CreatedAt.AsTime(), ID: d.Id}
+		})
 
-	return drivers, nextPageToken, nil
+	return drivers, nextPageToken, prevPageToken, total, nil
 }
 
+const lockDriverStatusQuery = `
+SELECT status
+FROM drivers
+WHERE external_id = ?
+FOR UPDATE`
+
 const updateDriverStatusQuery = `
 UPDATE drivers 
-SET status = ?, updated_at = ?
+SET status = ?, updated_at = ?, updated_by = ?
 WHERE external_id = ?`
 
-func (s *store) UpdateDriverStatus(ctx context.Context, externalID uuid.UUID, status genproto.DriverStatus, reason string) (*genproto.Driver, error) {
-	result, err := s.db.ExecContext(ctx, updateDriverStatusQuery,
+const insertDriverStatusHistoryQuery = `
+INSERT INTO driver_status_history (driver_id, previous_status, new_status, reason, changed_by, changed_at)
+VALUES (?, ?, ?, ?, ?, ?)`
+
+// UpdateDriverStatus sets the status and records the transition in driver_status_history
+// in the same transaction, so the history never disagrees with the driver
+func (s *store) UpdateDriverStatus(ctx context.Context, externalID uuid.UUID, status genproto.DriverStatus, reason, actorID string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	previousStatus, err := lockDriverStatus(ctx, tx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, updateDriverStatusQuery,
 		status.String(),
-		time.Now(),
+		now,
+		actorID,
 		externalID.Bytes(),
-	)
-	if err != nil {
+	); err != nil {
 		return nil, fmt.Errorf("failed to update driver status: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return nil, fmt.Errorf("failed to check affected rows: %w", err)
+	if err := insertDriverStatusHistory(ctx, tx, externalID, previousStatus, status.String(), reason, actorID, now); err != nil {
+		return nil, err
 	}
-	if rowsAffected == 0 {
-		return nil, types.ErrDriverNotFound
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return s.GetDriverByID(ctx, externalID)
 }
 
-const getActiveDriversQuery = `
-SELECT 
-	LOWER(HEX(external_id)) as external_id,
-	user_id,
-	license_number,
-	license_class,
-	license_expiry,
-	experience_years,
-	phone_number,
-	emergency_contact_name,
-	emergency_contact_phone,
-	status,
-	hire_date,
-	created_at,
-	updated_at
-FROM drivers
-WHERE status = 'ACTIVE'
-  AND license_expiry > NOW()
-  AND (?='' OR license_class = ?)
-  AND (?='' OR created_at > ?)
-ORDER BY created_at DESC
-LIMIT ?`
-
-func (s *store) GetActiveDrivers(ctx context.Context, params types.ListDriversParams) ([]*genproto.Driver, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
-	}
-
-	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
+// lockDriverStatus reads the current status, locking the row until the transaction ends
+func lockDriverStatus(ctx context.Context, tx *sql.Tx, externalID uuid.UUID) (string, error) {
+	var status string
+	err := tx.QueryRowContext(ctx, lockDriverStatusQuery, externalID.Bytes()).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", types.ErrDriverNotFound
 		}
+		return "", fmt.Errorf("failed to read driver status: %w", err)
 	}
+	return status, nil
+}
 
-	licenseClassStr := ""
-	if params.LicenseClassFilter != nil {
-		licenseClassStr = params.LicenseClassFilter.String()
+func insertDriverStatusHistory(ctx context.Context, tx *sql.Tx, externalID uuid.UUID, previousStatus, newStatus, reason, actorID string, changedAt time.Time) error {
+	_, err := tx.ExecContext(ctx, insertDriverStatusHistoryQuery,
+		externalID.Bytes(),
+		previousStatus,
+		newStatus,
+		sql.NullString{String: reason, Valid: reason != ""},
+		actorID,
+		changedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record status history: %w", err)
 	}
+	return nil
+}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
-	}
+const renewDriverLicenseQuery = `
+UPDATE drivers
+SET license_expiry = ?, license_number = COALESCE(?, license_number), updated_at = ?, updated_by = ?
+WHERE external_id = ?`
 
-	rows, err := s.db.QueryContext(ctx, getActiveDriversQuery,
-		licenseClassStr, licenseClassStr,
-		cursorStr, cursorStr,
-		params.PageSize+1,
-	)
+const latestSuspensionReasonQuery = `
+SELECT reason
+FROM driver_status_history
+WHERE driver_id = ? AND new_status = 'SUSPENDED'
+ORDER BY changed_at DESC, id DESC
+LIMIT 1`
+
+// RenewDriverLicense sets the new license expiry, and license number when one is given.
+// A driver SUSPENDED with types.LicenseExpiredReason is moved back to ACTIVE in the same
+// transaction, with the transition recorded in driver_status_history; reactivated reports
+// whether that happened.
+func (s *store) RenewDriverLicense(ctx context.Context, externalID uuid.UUID, licenseExpiry string, licenseNumber *string, actorID string) (*genproto.Driver, bool, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	expiry, err := time.Parse("2006-01-02", licenseExpiry)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get active drivers: %w", err)
+		return nil, false, fmt.Errorf("invalid license expiry %q: %w", licenseExpiry, err)
 	}
-	defer rows.Close()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	previousStatus, err := lockDriverStatus(ctx, tx, externalID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, renewDriverLicenseQuery,
+		expiry,
+		licenseNumber,
+		now,
+		actorID,
+		externalID.Bytes(),
+	); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return nil, false, types.ErrDuplicateEntry
+		}
+		return nil, false, fmt.Errorf("failed to renew driver license: %w", err)
+	}
+
+	reactivated := false
+	if previousStatus == genproto.DriverStatus_SUSPENDED.String() {
+		var reason sql.NullString
+		err := tx.QueryRowContext(ctx, latestSuspensionReasonQuery, externalID.Bytes()).Scan(&reason)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, fmt.Errorf("failed to read suspension reason: %w", err)
+		}
+
+		if strings.EqualFold(strings.TrimSpace(reason.String), types.LicenseExpiredReason) {
+			active := genproto.DriverStatus_ACTIVE.String()
+			if _, err := tx.ExecContext(ctx, updateDriverStatusQuery, active, now, actorID, externalID.Bytes()); err != nil {
+				return nil, false, fmt.Errorf("failed to update driver status: %w", err)
+			}
+			if err := insertDriverStatusHistory(ctx, tx, externalID, previousStatus, active, "license renewed", actorID, now); err != nil {
+				return nil, false, err
+			}
+			reactivated = true
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	driver, err := s.GetDriverByID(ctx, externalID)
+	if err != nil {
+		return nil, false, err
+	}
+	return driver, reactivated, nil
+}
+
+const listDriverStatusHistoryQuery = `
+SELECT id, LOWER(HEX(driver_id)), previous_status, new_status, reason, changed_by, changed_at
+FROM driver_status_history
+WHERE driver_id = ?
+AND (?='' OR (changed_at <= ? AND (changed_at < ? OR id < ?)))
+ORDER BY changed_at DESC, id DESC
+LIMIT ?`
+
+// ListDriverStatusHistory pages through a driver's status transitions, most recent first
+func (s *store) ListDriverStatusHistory(ctx context.Context, externalID uuid.UUID, pageSize int32, pageToken string) ([]*genproto.DriverStatusHistoryEntry, string, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	cursor, err := pagetoken.Decode(pageToken, pagetoken.ChangedAtDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursorStr, cursorID, err := numericCursorArgs(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := s.db.QueryContext(ctx, listDriverStatusHistoryQuery,
+		externalID.Bytes(),
+		cursorStr, cursorStr, cursorStr, cursorID,
+		pageSize+1, // Fetch one extra to determine if there are more pages
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list status history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*genproto.DriverStatusHistoryEntry
+	for rows.Next() {
+		var entry genproto.DriverStatusHistoryEntry
+		var previousStatus, newStatus string
+		var reason, changedBy sql.NullString
+		var changedAt time.Time
+
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.DriverId,
+			&previousStatus,
+			&newStatus,
+			&reason,
+			&changedBy,
+			&changedAt,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan status history: %w", err)
+		}
+
+		entry.PreviousStatus = genproto.DriverStatus(genproto.DriverStatus_value[previousStatus])
+		entry.NewStatus = genproto.DriverStatus(genproto.DriverStatus_value[newStatus])
+		entry.Reason = reason.String
+		entry.ChangedBy = changedBy.String
+		entry.ChangedAt = timestamppb.New(changedAt)
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to iterate status history: %w", err)
+	}
+
+	// Determine next page token
+	var nextPageToken string
+	if int32(len(entries)) > pageSize {
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.ChangedAtDesc, pagetoken.Cursor{At: last.ChangedAt.AsTime(), ID: last.Id})
+	}
+
+	return entries, nextPageToken, nil
+}
+
+const getActiveDriversQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE status = 'ACTIVE'
+  AND (? = 1 OR license_expiry > ?)
+  AND (?='' OR license_class = ?)
+  AND (?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+ORDER BY created_at DESC, external_id DESC
+LIMIT ?`
+
+const countActiveDriversQuery = `
+SELECT COUNT(*)
+FROM drivers
+WHERE status = 'ACTIVE'
+  AND (? = 1 OR license_expiry > ?)
+  AND (?='' OR license_class = ?)`
+
+func (s *store) GetActiveDrivers(ctx context.Context, params types.ListDriversParams) ([]*genproto.Driver, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	licenseClassStr := ""
+	if params.LicenseClassFilter != nil {
+		licenseClassStr = params.LicenseClassFilter.String()
+	}
+
+	cursorStr, cursorID, err := uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	// Expired licenses are filtered out unless the caller asked to see them
+	includeExpired := 0
+	if params.IncludeExpiredLicense {
+		includeExpired = 1
+	}
+
+	now := s.clock.Now()
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, countActiveDriversQuery,
+		includeExpired, now,
+		licenseClassStr, licenseClassStr,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count active drivers: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, getActiveDriversQuery,
+		includeExpired, now,
+		licenseClassStr, licenseClassStr,
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
+	)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to get active drivers: %w", err)
+	}
+	defer rows.Close()
 
 	var drivers []*genproto.Driver
-	var lastCreatedAt time.Time
+	for rows.Next() {
+		driver, err := s.scanDriverFromRows(rows)
+		if err != nil {
+			return nil, "", 0, fmt.Errorf("failed to scan driver: %w", err)
+		}
+		drivers = append(drivers, driver)
+	}
 
+	// Determine next page token
+	var nextPageToken string
+	if int32(len(drivers)) > params.PageSize {
+		drivers = drivers[:params.PageSize]
+		last := drivers[len(drivers)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
+	}
+
+	return drivers, nextPageToken, total, nil
+}
+
+const getEligibleDriversQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE status = 'ACTIVE'
+  AND license_expiry > ?
+  AND FIND_IN_SET(license_class, ?) > 0
+  AND (?='' OR handbook_version = ?)
+  AND (?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+ORDER BY created_at DESC, external_id DESC
+LIMIT ?`
+
+const countEligibleDriversQuery = `
+SELECT COUNT(*)
+FROM drivers
+WHERE status = 'ACTIVE'
+  AND license_expiry > ?
+  AND FIND_IN_SET(license_class, ?) > 0
+  AND (?='' OR handbook_version = ?)`
+
+func (s *store) GetEligibleDrivers(ctx context.Context, licenseClasses []genproto.LicenseClass, params types.ListDriversParams) ([]*genproto.Driver, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	// FIND_IN_SET takes the allowed classes as one comma separated value
+	classNames := make([]string, 0, len(licenseClasses))
+	for _, class := range licenseClasses {
+		classNames = append(classNames, class.String())
+	}
+
+	cursorStr, cursorID, err := uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	now := s.clock.Now()
+	classList := strings.Join(classNames, ",")
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, countEligibleDriversQuery,
+		now,
+		classList,
+		s.handbookVersion, s.handbookVersion,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count eligible drivers: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, getEligibleDriversQuery,
+		now,
+		classList,
+		s.handbookVersion, s.handbookVersion,
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
+	)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to get eligible drivers: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []*genproto.Driver
 	for rows.Next() {
 		driver, err := s.scanDriverFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan driver: %w", err)
+			return nil, "", 0, fmt.Errorf("failed to scan driver: %w", err)
 		}
 		drivers = append(drivers, driver)
-		lastCreatedAt = driver.CreatedAt.AsTime()
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to iterate eligible drivers: %w", err)
 	}
 
 	// Determine next page token
 	var nextPageToken string
 	if int32(len(drivers)) > params.PageSize {
 		drivers = drivers[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
+		last := drivers[len(drivers)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
+	}
+
+	return drivers, nextPageToken, total, nil
+}
+
+const listRecentlyUpdatedDriversQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE updated_at IS NOT NULL
+  AND (?='' OR (updated_at <= ? AND (updated_at < ? OR external_id < ?)))
+ORDER BY updated_at DESC, external_id DESC
+LIMIT ?`
+
+const countRecentlyUpdatedDriversQuery = `
+SELECT COUNT(*)
+FROM drivers
+WHERE updated_at IS NOT NULL`
+
+// ListRecentlyUpdatedDrivers pages through drivers by updated_at, newest first.
+// Drivers that were never modified have a NULL updated_at and are left out.
+func (s *store) ListRecentlyUpdatedDrivers(ctx context.Context, params types.ListDriversParams) ([]*genproto.Driver, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.UpdatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	cursorStr, cursorID, err := uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, countRecentlyUpdatedDriversQuery).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count recently updated drivers: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, listRecentlyUpdatedDriversQuery,
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
+	)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to list recently updated drivers: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []*genproto.Driver
+	for rows.Next() {
+		driver, err := s.scanDriverFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
+			return nil, "", 0, fmt.Errorf("failed to scan driver: %w", err)
 		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
+		drivers = append(drivers, driver)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, fmt.Errorf("error iterating drivers: %w", err)
+	}
+
+	// The cursor is the updated_at of the last row we actually return
+	var nextPageToken string
+	if int32(len(drivers)) > params.PageSize {
+		drivers = drivers[:params.PageSize]
+		last := drivers[len(drivers)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.UpdatedAtDesc, pagetoken.Cursor{At: last.UpdatedAt.AsTime(), ID: last.Id})
 	}
 
-	return drivers, nextPageToken, nil
+	return drivers, nextPageToken, total, nil
 }
 
 // Certification operations
 
+const lockDriverForCertificationQuery = `
+SELECT 1 FROM drivers WHERE external_id = ? FOR UPDATE`
+
+// Certifications that block adding the same one again: anything not expired or revoked
+// whose expiry date hasn't passed. Suspended certifications still block, since they
+// may be reinstated.
+const getBlockingCertificationsQuery = `
+SELECT id, certification_name, issued_by
+FROM driver_certifications
+WHERE driver_id = ?
+	AND status NOT IN ('CERT_EXPIRED', 'CERT_REVOKED')
+	AND expiry_date >= ?`
+
 const addCertificationQuery = `
 INSERT INTO driver_certifications (
 	id, driver_id, certification_name, issued_by, issue_date, expiry_date, created_at
 ) VALUES (?, ?, ?, ?, ?, ?, ?)`
 
-func (s *store) AddDriverCertification(ctx context.Context, certID uint64, driverID uuid.UUID, cert *types.CertificationData) (*genproto.DriverCertification, error) {
-	now := time.Now()
+// AddDriverCertification adds a certification unless the driver already holds a valid one
+// with the same name from the same issuer, compared case- and whitespace-insensitively,
+// in which case a *types.DuplicateCertificationError is returned. Once the earlier one
+// has expired or been revoked the certification can be added again as a renewal.
+func (s *store) AddDriverCertification(ctx context.Context, certID uint64, driverID uuid.UUID, cert *types.CertificationData) (*genproto.DriverCertification, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	now := time.Now()
+
+	// Parse dates
+	issueDate, err := time.Parse("2006-01-02", cert.IssueDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue date: %w", err)
+	}
+
+	expiryDate, err := time.Parse("2006-01-02", cert.ExpiryDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry date: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	// Lock the driver so two concurrent adds of the same certification can't both pass the check
+	var locked int
+	if err := tx.QueryRowContext(ctx, lockDriverForCertificationQuery, driverID.Bytes()).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrDriverNotFound
+		}
+		return nil, fmt.Errorf("failed to lock driver: %w", err)
+	}
+
+	if existingID, err := findBlockingCertification(ctx, tx, driverID, cert, s.clock.Now()); err != nil {
+		return nil, err
+	} else if existingID != "" {
+		return nil, &types.DuplicateCertificationError{ExistingID: existingID}
+	}
+
+	_, err = tx.ExecContext(ctx, addCertificationQuery,
+		certID,
+		driverID.Bytes(),
+		cert.CertificationName,
+		cert.IssuedBy,
+		issueDate,
+		expiryDate,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add certification: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit certification: %w", err)
+	}
+
+	// Calculate computed fields
+	isExpired := expiryDate.Before(s.clock.Now())
+	daysUntilExpiry := clock.DaysUntil(s.clock, expiryDate)
+
+	return &genproto.DriverCertification{
+		Id:                fmt.Sprintf("%d", certID),
+		DriverId:          driverID.String(),
+		CertificationName: cert.CertificationName,
+		IssuedBy:          cert.IssuedBy,
+		IssueDate:         timestamppb.New(issueDate),
+		ExpiryDate:        timestamppb.New(expiryDate),
+		Status:            genproto.CertificationStatus_CERT_ACTIVE,
+		CreatedAt:         timestamppb.New(now),
+		IsExpired:         isExpired,
+		DaysUntilExpiry:   daysUntilExpiry,
+	}, nil
+}
+
+// findBlockingCertification returns the ID of a valid certification the driver already
+// holds with the same normalized name and issuer as cert, or "" if there is none
+func findBlockingCertification(ctx context.Context, tx *sql.Tx, driverID uuid.UUID, cert *types.CertificationData, now time.Time) (string, error) {
+	rows, err := tx.QueryContext(ctx, getBlockingCertificationsQuery, driverID.Bytes(), now.Format("2006-01-02"))
+	if err != nil {
+		return "", fmt.Errorf("failed to check existing certifications: %w", err)
+	}
+	defer rows.Close()
+
+	name, issuer := normalizeCertificationField(cert.CertificationName), normalizeCertificationField(cert.IssuedBy)
+	for rows.Next() {
+		var id uint64
+		var existingName, existingIssuer string
+		if err := rows.Scan(&id, &existingName, &existingIssuer); err != nil {
+			return "", fmt.Errorf("failed to scan certification: %w", err)
+		}
+		if normalizeCertificationField(existingName) == name && normalizeCertificationField(existingIssuer) == issuer {
+			return strconv.FormatUint(id, 10), nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating certifications: %w", err)
+	}
+	return "", nil
+}
+
+// normalizeCertificationField folds case and runs of whitespace, so "First Aid  certificate"
+// and "first aid certificate" count as the same certification
+func normalizeCertificationField(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+const listNormalizableDriversQuery = `
+SELECT internal_id, LOWER(HEX(external_id)), user_id, license_number, phone_number, emergency_contact_name, emergency_contact_phone
+FROM drivers
+WHERE internal_id > ?
+ORDER BY internal_id
+LIMIT ?`
+
+// ListNormalizableDrivers returns the next batch of drivers after afterInternalID, in
+// internal ID order, with the stored values of the fields normalization rewrites
+func (s *store) ListNormalizableDrivers(ctx context.Context, afterInternalID uint64, limit int32) ([]types.NormalizableDriver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, listNormalizableDriversQuery, afterInternalID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query drivers: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []types.NormalizableDriver
+	for rows.Next() {
+		var d types.NormalizableDriver
+		if err := rows.Scan(
+			&d.InternalID,
+			&d.ExternalID,
+			&d.UserID,
+			&d.LicenseNumber,
+			&d.PhoneNumber,
+			&d.EmergencyContactName,
+			&d.EmergencyContactPhone,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan driver: %w", err)
+		}
+		drivers = append(drivers, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate drivers: %w", err)
+	}
+
+	return drivers, nil
+}
 
-	// Parse dates
-	issueDate, err := time.Parse("2006-01-02", cert.IssueDate)
+// The stored values are matched again so a driver edited since it was read is left alone
+const applyDriverNormalizationQuery = `
+UPDATE drivers
+SET user_id = ?, license_number = ?, phone_number = ?, emergency_contact_name = ?, emergency_contact_phone = ?,
+	updated_at = ?, updated_by = ?
+WHERE internal_id = ?
+  AND user_id = ? AND license_number = ? AND phone_number = ?
+  AND emergency_contact_name = ? AND emergency_contact_phone = ?`
+
+const insertDriverNormalizationAuditQuery = `
+INSERT INTO driver_normalization_audit (driver_id, field, old_value, new_value, changed_by, changed_at)
+VALUES (?, ?, ?, ?, ?, ?)`
+
+// ApplyDriverNormalization writes the changed fields of one driver and records each
+// change in driver_normalization_audit, in one transaction. It fails with ErrStaleRecord
+// if the driver no longer holds the values it was read with, and ErrDuplicateEntry if a
+// normalized value collides with another driver's.
+func (s *store) ApplyDriverNormalization(ctx context.Context, driver types.NormalizableDriver, changes []types.FieldChange, actorID string) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	externalID, err := uuid.FromString(driver.ExternalID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid issue date: %w", err)
+		return fmt.Errorf("invalid driver ID %q: %w", driver.ExternalID, err)
+	}
+
+	normalized := driver
+	for _, change := range changes {
+		switch change.Field {
+		case "user_id":
+			normalized.UserID = change.NewValue
+		case "license_number":
+			normalized.LicenseNumber = change.NewValue
+		case "phone_number":
+			normalized.PhoneNumber = change.NewValue
+		case "emergency_contact_name":
+			normalized.EmergencyContactName = change.NewValue
+		case "emergency_contact_phone":
+			normalized.EmergencyContactPhone = change.NewValue
+		default:
+			return fmt.Errorf("unknown normalized field %q", change.Field)
+		}
 	}
 
-	expiryDate, err := time.Parse("2006-01-02", cert.ExpiryDate)
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("invalid expiry date: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
 
-	_, err = s.db.ExecContext(ctx, addCertificationQuery,
-		certID,
-		driverID.Bytes(),
-		cert.CertificationName,
-		cert.IssuedBy,
-		issueDate,
-		expiryDate,
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, applyDriverNormalizationQuery,
+		normalized.UserID,
+		normalized.LicenseNumber,
+		normalized.PhoneNumber,
+		normalized.EmergencyContactName,
+		normalized.EmergencyContactPhone,
 		now,
+		actorID,
+		driver.InternalID,
+		driver.UserID,
+		driver.LicenseNumber,
+		driver.PhoneNumber,
+		driver.EmergencyContactName,
+		driver.EmergencyContactPhone,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add certification: %w", err)
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return types.ErrDuplicateEntry
+		}
+		return fmt.Errorf("failed to normalize driver: %w", err)
 	}
 
-	// Calculate computed fields
-	isExpired := expiryDate.Before(now)
-	daysUntilExpiry := int32(time.Until(expiryDate).Hours() / 24)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return types.ErrStaleRecord
+	}
+
+	for _, change := range changes {
+		if _, err := tx.ExecContext(ctx, insertDriverNormalizationAuditQuery,
+			externalID.Bytes(),
+			change.Field,
+			change.OldValue,
+			change.NewValue,
+			actorID,
+			now,
+		); err != nil {
+			return fmt.Errorf("failed to record normalization: %w", err)
+		}
+	}
 
-	return &genproto.DriverCertification{
-		Id:                fmt.Sprintf("%d", certID),
-		DriverId:          driverID.String(),
-		CertificationName: cert.CertificationName,
-		IssuedBy:          cert.IssuedBy,
-		IssueDate:         timestamppb.New(issueDate),
-		ExpiryDate:        timestamppb.New(expiryDate),
-		Status:            genproto.CertificationStatus_CERT_ACTIVE,
-		CreatedAt:         timestamppb.New(now),
-		IsExpired:         isExpired,
-		DaysUntilExpiry:   daysUntilExpiry,
-	}, nil
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 // Helper functions
 
+// beginSnapshot opens a read-only transaction for a listing, so its total count and
+// its page are read from the same snapshot
+func (s *store) beginSnapshot(ctx context.Context) (*sql.Tx, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// endSnapshot releases a transaction from beginSnapshot; nothing was written, so it is rolled back
+func endSnapshot(tx *sql.Tx) {
+	if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+		fmt.Printf("rollback failed: %v\n", rerr)
+	}
+}
+
+// uuidCursorArgs expands a page cursor into the arguments of the keyset filter
+//
+//	(?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+//
+// Tokens issued without an ID compare against the nil UUID, which nothing sorts
+// below, so they fall back to paging on the timestamp alone.
+func uuidCursorArgs(cursor pagetoken.Cursor) (string, []byte, error) {
+	if cursor.IsZero() {
+		return "", uuid.Nil.Bytes(), nil
+	}
+	id := uuid.Nil
+	if cursor.ID != "" {
+		parsed, err := uuid.FromString(cursor.ID)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", pagetoken.ErrInvalidToken, err)
+		}
+		id = parsed
+	}
+	return cursor.At.Format(time.RFC3339Nano), id.Bytes(), nil
+}
+
+// numericCursorArgs is uuidCursorArgs for tables keyed by a numeric ID
+func numericCursorArgs(cursor pagetoken.Cursor) (string, uint64, error) {
+	if cursor.IsZero() {
+		return "", 0, nil
+	}
+	var id uint64
+	if cursor.ID != "" {
+		parsed, err := strconv.ParseUint(cursor.ID, 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("%w: %v", pagetoken.ErrInvalidToken, err)
+		}
+		id = parsed
+	}
+	return cursor.At.Format(time.RFC3339Nano), id, nil
+}
+
 func (s *store) scanDriver(ctx context.Context, query string, args ...interface{}) (*genproto.Driver, error) {
 	row := s.db.QueryRowContext(ctx, query, args...)
 	return s.scanDriverFromRow(row)
@@ -473,6 +1517,8 @@ func (s *store) scanDriverFromRow(row *sql.Row) (*genproto.Driver, error) {
 	var licenseExpiry time.Time
 	var hireDate sql.NullTime
 	var createdAt, updatedAt time.Time
+	var updatedBy sql.NullString
+	var handbookAcknowledgedAt sql.NullTime
 
 	err := row.Scan(
 		&driver.Id,
@@ -488,12 +1534,17 @@ func (s *store) scanDriverFromRow(row *sql.Row) (*genproto.Driver, error) {
 		&hireDate,
 		&createdAt,
 		&updatedAt,
+		&updatedBy,
+		&driver.RatingAverage,
+		&driver.RatingCount,
+		&driver.HandbookVersion,
+		&handbookAcknowledgedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.populateDriver(&driver, statusStr, licenseClassStr, licenseExpiry, hireDate, createdAt, updatedAt)
+	return s.populateDriver(&driver, statusStr, licenseClassStr, licenseExpiry, hireDate, createdAt, updatedAt, updatedBy, handbookAcknowledgedAt)
 }
 
 func (s *store) scanDriverFromRows(rows *sql.Rows) (*genproto.Driver, error) {
@@ -502,6 +1553,8 @@ func (s *store) scanDriverFromRows(rows *sql.Rows) (*genproto.Driver, error) {
 	var licenseExpiry time.Time
 	var hireDate sql.NullTime
 	var createdAt, updatedAt time.Time
+	var updatedBy sql.NullString
+	var handbookAcknowledgedAt sql.NullTime
 
 	err := rows.Scan(
 		&driver.Id,
@@ -517,15 +1570,20 @@ func (s *store) scanDriverFromRows(rows *sql.Rows) (*genproto.Driver, error) {
 		&hireDate,
 		&createdAt,
 		&updatedAt,
+		&updatedBy,
+		&driver.RatingAverage,
+		&driver.RatingCount,
+		&driver.HandbookVersion,
+		&handbookAcknowledgedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.populateDriver(&driver, statusStr, licenseClassStr, licenseExpiry, hireDate, createdAt, updatedAt)
+	return s.populateDriver(&driver, statusStr, licenseClassStr, licenseExpiry, hireDate, createdAt, updatedAt, updatedBy, handbookAcknowledgedAt)
 }
 
-func (s *store) populateDriver(driver *genproto.Driver, statusStr, licenseClassStr string, licenseExpiry time.Time, hireDate sql.NullTime, createdAt, updatedAt time.Time) (*genproto.Driver, error) {
+func (s *store) populateDriver(driver *genproto.Driver, statusStr, licenseClassStr string, licenseExpiry time.Time, hireDate sql.NullTime, createdAt, updatedAt time.Time, updatedBy sql.NullString, handbookAcknowledgedAt sql.NullTime) (*genproto.Driver, error) {
 	// Convert status string to enum
 	statusVal, ok := genproto.DriverStatus_value[statusStr]
 	if !ok {
@@ -542,9 +1600,8 @@ func (s *store) populateDriver(driver *genproto.Driver, statusStr, licenseClassS
 
 	// Set license expiry and computed fields
 	driver.LicenseExpiry = timestamppb.New(licenseExpiry)
-	now := time.Now()
-	driver.LicenseExpired = licenseExpiry.Before(now)
-	driver.DaysUntilLicenseExpiry = int32(time.Until(licenseExpiry).Hours() / 24)
+	driver.LicenseExpired = licenseExpiry.Before(s.clock.Now())
+	driver.DaysUntilLicenseExpiry = clock.DaysUntil(s.clock, licenseExpiry)
 
 	// Set hire date if valid
 	if hireDate.Valid {
@@ -554,26 +1611,24 @@ func (s *store) populateDriver(driver *genproto.Driver, statusStr, licenseClassS
 	// Set timestamps
 	driver.CreatedAt = timestamppb.New(createdAt)
 	driver.UpdatedAt = timestamppb.New(updatedAt)
+	if updatedBy.Valid {
+		driver.UpdatedBy = &updatedBy.String
+	}
+
+	// Handbook acknowledgement
+	if handbookAcknowledgedAt.Valid {
+		driver.HandbookAcknowledgedAt = timestamppb.New(handbookAcknowledgedAt.Time)
+	}
+	driver.HandbookCurrent = s.handbookVersion == "" || driver.HandbookVersion == s.handbookVersion
 
 	return driver, nil
 }
 
 // UpdateDriver updates driver information based on the provided field mask
-const updateDriverQuery = `
-UPDATE drivers 
-SET user_id = CASE WHEN ? THEN ? ELSE user_id END,
-    license_number = CASE WHEN ? THEN ? ELSE license_number END,
-    license_class = CASE WHEN ? THEN ? ELSE license_class END,
-    license_expiry = CASE WHEN ? THEN ? ELSE license_expiry END,
-    experience_years = CASE WHEN ? THEN ? ELSE experience_years END,
-    phone_number = CASE WHEN ? THEN ? ELSE phone_number END,
-    emergency_contact_name = CASE WHEN ? THEN ? ELSE emergency_contact_name END,
-    emergency_contact_phone = CASE WHEN ? THEN ? ELSE emergency_contact_phone END,
-    hire_date = CASE WHEN ? THEN ? ELSE hire_date END,
-    updated_at = ?
-WHERE external_id = ?`
+func (s *store) UpdateDriver(ctx context.Context, externalID uuid.UUID, updates types.DriverUpdateFields, updateMask *fieldmaskpb.FieldMask, actorID string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
 
-func (s *store) UpdateDriver(ctx context.Context, externalID uuid.UUID, updates types.DriverUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.Driver, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -620,118 +1675,428 @@ func (s *store) UpdateDriver(ctx context.Context, externalID uuid.UUID, updates
 				updateHireDate = true
 			}
 		}
-	} else {
-		// Update all provided fields
-		updateUserID = updates.UserID != nil
-		updateLicenseNumber = updates.LicenseNumber != nil
-		updateLicenseClass = updates.LicenseClass != nil
-		updateLicenseExpiry = updates.LicenseExpiry != nil
-		updateExperienceYears = updates.ExperienceYears != nil
-		updatePhoneNumber = updates.PhoneNumber != nil
-		updateEmergencyContactName = updates.EmergencyContactName != nil
-		updateEmergencyContactPhone = updates.EmergencyContactPhone != nil
-		updateHireDate = updates.HireDate != nil
+	} else {
+		// Update all provided fields
+		updateUserID = updates.UserID != nil
+		updateLicenseNumber = updates.LicenseNumber != nil
+		updateLicenseClass = updates.LicenseClass != nil
+		updateLicenseExpiry = updates.LicenseExpiry != nil
+		updateExperienceYears = updates.ExperienceYears != nil
+		updatePhoneNumber = updates.PhoneNumber != nil
+		updateEmergencyContactName = updates.EmergencyContactName != nil
+		updateEmergencyContactPhone = updates.EmergencyContactPhone != nil
+		updateHireDate = updates.HireDate != nil
+	}
+
+	// Prepare update values
+	var userID, licenseNumber, licenseClass, phoneNumber, emergencyContactName, emergencyContactPhone string
+	var experienceYears int32
+	var licenseExpiry, hireDate sql.NullTime
+
+	if updateUserID && updates.UserID != nil {
+		userID = *updates.UserID
+	}
+	if updateLicenseNumber && updates.LicenseNumber != nil {
+		licenseNumber = *updates.LicenseNumber
+	}
+	if updateLicenseClass && updates.LicenseClass != nil {
+		licenseClass = updates.LicenseClass.String()
+	}
+	if updateLicenseExpiry && updates.LicenseExpiry != nil {
+		if parsed, err := time.Parse("2006-01-02", *updates.LicenseExpiry); err == nil {
+			licenseExpiry = sql.NullTime{Time: parsed, Valid: true}
+		}
+	}
+	if updateExperienceYears && updates.ExperienceYears != nil {
+		experienceYears = *updates.ExperienceYears
+	}
+	if updatePhoneNumber && updates.PhoneNumber != nil {
+		phoneNumber = *updates.PhoneNumber
+	}
+	if updateEmergencyContactName && updates.EmergencyContactName != nil {
+		emergencyContactName = *updates.EmergencyContactName
+	}
+	if updateEmergencyContactPhone && updates.EmergencyContactPhone != nil {
+		emergencyContactPhone = *updates.EmergencyContactPhone
+	}
+	if updateHireDate && updates.HireDate != nil {
+		if parsed, err := time.Parse("2006-01-02", *updates.HireDate); err == nil {
+			hireDate = sql.NullTime{Time: parsed, Valid: true}
+		}
+	}
+
+	// Execute update
+	var set sqlupdate.Set
+	set.Field("user_id", updateUserID, userID).
+		Field("license_number", updateLicenseNumber, licenseNumber).
+		Field("license_class", updateLicenseClass, licenseClass).
+		Field("license_expiry", updateLicenseExpiry, licenseExpiry).
+		Field("experience_years", updateExperienceYears, experienceYears).
+		Field("phone_number", updatePhoneNumber, phoneNumber).
+		Field("emergency_contact_name", updateEmergencyContactName, emergencyContactName).
+		Field("emergency_contact_phone", updateEmergencyContactPhone, emergencyContactPhone).
+		Field("hire_date", updateHireDate, hireDate).
+		Value("updated_at", now).
+		Value("updated_by", actorID)
+	query, args := set.Statement("drivers", "external_id = ?", externalID.Bytes())
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return nil, types.ErrDuplicateEntry
+		}
+		return nil, fmt.Errorf("failed to update driver: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, types.ErrDriverNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Return updated driver
+	return s.GetDriverByID(ctx, externalID)
+}
+
+// DeleteDriver performs a soft delete by setting status to INACTIVE
+const softDeleteDriverQuery = `
+UPDATE drivers 
+SET status = 'INACTIVE', updated_at = ?, updated_by = ?
+WHERE external_id = ? AND status != 'INACTIVE'`
+
+func (s *store) DeleteDriver(ctx context.Context, externalID uuid.UUID, actorID string) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, softDeleteDriverQuery,
+		time.Now(),
+		actorID,
+		externalID.Bytes(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete driver: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return types.ErrDriverNotFound
+	}
+
+	return nil
+}
+
+// RestoreDriver moves a soft deleted driver from INACTIVE to PENDING_VERIFICATION and
+// records the transition in driver_status_history, in one transaction. A driver in any
+// other status is refused with ErrDriverNotInactive.
+func (s *store) RestoreDriver(ctx context.Context, externalID uuid.UUID, actorID string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	previousStatus, err := lockDriverStatus(ctx, tx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if previousStatus != genproto.DriverStatus_INACTIVE.String() {
+		return nil, types.ErrDriverNotInactive
+	}
+
+	now := time.Now()
+	pending := genproto.DriverStatus_PENDING_VERIFICATION.String()
+	if _, err := tx.ExecContext(ctx, updateDriverStatusQuery, pending, now, actorID, externalID.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to update driver status: %w", err)
+	}
+	if err := insertDriverStatusHistory(ctx, tx, externalID, previousStatus, pending, "driver restored", actorID, now); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetDriverByID(ctx, externalID)
+}
+
+const purgeDriverCertificationsQuery = `
+DELETE FROM driver_certifications
+WHERE driver_id = ?`
+
+const purgeDriverQuery = `
+DELETE FROM drivers
+WHERE external_id = ?`
+
+// HardDeleteDriver permanently removes the driver and their certifications in one
+// transaction, returning how many certifications went with them. Status history and
+// normalization audit rows go too, through their ON DELETE CASCADE foreign keys.
+// ACTIVE drivers are refused with ErrDriverActive.
+func (s *store) HardDeleteDriver(ctx context.Context, externalID uuid.UUID) (int64, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	driverStatus, err := lockDriverStatus(ctx, tx, externalID)
+	if err != nil {
+		return 0, err
+	}
+	if driverStatus == genproto.DriverStatus_ACTIVE.String() {
+		return 0, types.ErrDriverActive
+	}
+
+	result, err := tx.ExecContext(ctx, purgeDriverCertificationsQuery, externalID.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete certifications: %w", err)
+	}
+	certificationsDeleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, purgeDriverQuery, externalID.Bytes()); err != nil {
+		return 0, fmt.Errorf("failed to delete driver: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return certificationsDeleted, nil
+}
+
+const lockDriversForMergeQuery = `
+SELECT external_id, status
+FROM drivers
+WHERE external_id IN (?, ?)
+FOR UPDATE`
+
+const reassignCertificationsQuery = `
+UPDATE driver_certifications
+SET driver_id = ?
+WHERE driver_id = ?`
+
+const reassignStatusHistoryQuery = `
+UPDATE driver_status_history
+SET driver_id = ?
+WHERE driver_id = ?`
+
+const reassignNormalizationAuditQuery = `
+UPDATE driver_normalization_audit
+SET driver_id = ?
+WHERE driver_id = ?`
+
+// The primary keeps its own values; only a missing hire date is filled in from the duplicate
+const mergeIntoPrimaryQuery = `
+UPDATE drivers primary_driver
+JOIN drivers duplicate_driver ON duplicate_driver.external_id = ?
+SET primary_driver.hire_date = COALESCE(primary_driver.hire_date, duplicate_driver.hire_date),
+	primary_driver.updated_at = ?,
+	primary_driver.updated_by = ?
+WHERE primary_driver.external_id = ?`
+
+const retireMergedDriverQuery = `
+UPDATE drivers
+SET status = 'INACTIVE', updated_at = ?, updated_by = ?
+WHERE external_id = ?`
+
+// MergeDrivers moves the duplicate's certifications, status history and normalization
+// audit onto the primary and soft deletes the duplicate, all in one transaction. The
+// duplicate keeps one history entry, recording its move to INACTIVE by the merge.
+func (s *store) MergeDrivers(ctx context.Context, primaryID, duplicateID uuid.UUID, actorID string) (*types.DriverMergeResult, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	// Lock both rows so a concurrent update can't slip in mid-merge
+	rows, err := tx.QueryContext(ctx, lockDriversForMergeQuery, primaryID.Bytes(), duplicateID.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock drivers: %w", err)
+	}
+	found := 0
+	var duplicateStatus string
+	for rows.Next() {
+		var id []byte
+		var driverStatus string
+		if err := rows.Scan(&id, &driverStatus); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to lock drivers: %w", err)
+		}
+		if bytes.Equal(id, duplicateID.Bytes()) {
+			duplicateStatus = driverStatus
+		}
+		found++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to lock drivers: %w", err)
+	}
+	if found != 2 {
+		return nil, types.ErrDriverNotFound
 	}
 
-	// Prepare update values
-	var userID, licenseNumber, licenseClass, phoneNumber, emergencyContactName, emergencyContactPhone string
-	var experienceYears int32
-	var licenseExpiry, hireDate sql.NullTime
+	result := &types.DriverMergeResult{}
 
-	if updateUserID && updates.UserID != nil {
-		userID = *updates.UserID
+	certs, err := tx.ExecContext(ctx, reassignCertificationsQuery, primaryID.Bytes(), duplicateID.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign certifications: %w", err)
 	}
-	if updateLicenseNumber && updates.LicenseNumber != nil {
-		licenseNumber = *updates.LicenseNumber
+	if result.CertificationsMoved, err = certs.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to check affected rows: %w", err)
 	}
-	if updateLicenseClass && updates.LicenseClass != nil {
-		licenseClass = updates.LicenseClass.String()
+
+	history, err := tx.ExecContext(ctx, reassignStatusHistoryQuery, primaryID.Bytes(), duplicateID.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign status history: %w", err)
 	}
-	if updateLicenseExpiry && updates.LicenseExpiry != nil {
-		if parsed, err := time.Parse("2006-01-02", *updates.LicenseExpiry); err == nil {
-			licenseExpiry = sql.NullTime{Time: parsed, Valid: true}
-		}
+	if result.StatusHistoryMoved, err = history.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to check affected rows: %w", err)
 	}
-	if updateExperienceYears && updates.ExperienceYears != nil {
-		experienceYears = *updates.ExperienceYears
+
+	audit, err := tx.ExecContext(ctx, reassignNormalizationAuditQuery, primaryID.Bytes(), duplicateID.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign normalization audit: %w", err)
 	}
-	if updatePhoneNumber && updates.PhoneNumber != nil {
-		phoneNumber = *updates.PhoneNumber
+	if result.NormalizationAuditMoved, err = audit.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to check affected rows: %w", err)
 	}
-	if updateEmergencyContactName && updates.EmergencyContactName != nil {
-		emergencyContactName = *updates.EmergencyContactName
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, mergeIntoPrimaryQuery, duplicateID.Bytes(), now, actorID, primaryID.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to update primary driver: %w", err)
 	}
-	if updateEmergencyContactPhone && updates.EmergencyContactPhone != nil {
-		emergencyContactPhone = *updates.EmergencyContactPhone
+
+	if _, err := tx.ExecContext(ctx, retireMergedDriverQuery, now, actorID, duplicateID.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to soft delete duplicate driver: %w", err)
 	}
-	if updateHireDate && updates.HireDate != nil {
-		if parsed, err := time.Parse("2006-01-02", *updates.HireDate); err == nil {
-			hireDate = sql.NullTime{Time: parsed, Valid: true}
-		}
+
+	// Recorded after the history moved, so the entry stays with the duplicate
+	reason := fmt.Sprintf("merged into %s", primaryID)
+	inactive := genproto.DriverStatus_INACTIVE.String()
+	if err := insertDriverStatusHistory(ctx, tx, duplicateID, duplicateStatus, inactive, reason, actorID, now); err != nil {
+		return nil, err
 	}
 
-	// Execute update
-	result, err := tx.ExecContext(ctx, updateDriverQuery,
-		updateUserID, userID,
-		updateLicenseNumber, licenseNumber,
-		updateLicenseClass, licenseClass,
-		updateLicenseExpiry, licenseExpiry,
-		updateExperienceYears, experienceYears,
-		updatePhoneNumber, phoneNumber,
-		updateEmergencyContactName, emergencyContactName,
-		updateEmergencyContactPhone, emergencyContactPhone,
-		updateHireDate, hireDate,
-		now,
-		externalID.Bytes(),
-	)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if result.Driver, err = s.GetDriverByID(ctx, primaryID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+const lockDriverRatingQuery = `
+SELECT rating_average, rating_count
+FROM drivers
+WHERE external_id = ?
+FOR UPDATE`
+
+const updateDriverRatingQuery = `
+UPDATE drivers
+SET rating_average = ?, rating_count = ?, updated_at = ?, updated_by = ?
+WHERE external_id = ?`
+
+// UpdateDriverRating folds a new rating into the driver's running average. The row is
+// locked while the new average is computed so concurrent ratings aren't lost.
+func (s *store) UpdateDriverRating(ctx context.Context, externalID uuid.UUID, rating float64, actorID string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
-			return nil, types.ErrDuplicateEntry
-		}
-		return nil, fmt.Errorf("failed to update driver: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
 
-	rowsAffected, err := result.RowsAffected()
+	var average float64
+	var count int64
+	err = tx.QueryRowContext(ctx, lockDriverRatingQuery, externalID.Bytes()).Scan(&average, &count)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check affected rows: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrDriverNotFound
+		}
+		return nil, fmt.Errorf("failed to lock driver rating: %w", err)
 	}
-	if rowsAffected == 0 {
-		return nil, types.ErrDriverNotFound
+
+	count++
+	average += (rating - average) / float64(count)
+
+	if _, err := tx.ExecContext(ctx, updateDriverRatingQuery, average, count, time.Now(), actorID, externalID.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to update driver rating: %w", err)
 	}
 
-	if err = tx.Commit(); err != nil {
+	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// Return updated driver
 	return s.GetDriverByID(ctx, externalID)
 }
 
-// DeleteDriver performs a soft delete by setting status to INACTIVE
-const softDeleteDriverQuery = `
-UPDATE drivers 
-SET status = 'INACTIVE', updated_at = ?
-WHERE external_id = ? AND status != 'INACTIVE'`
+const acknowledgeHandbookQuery = `
+UPDATE drivers
+SET handbook_version = ?, handbook_acknowledged_at = ?, updated_at = ?, updated_by = ?
+WHERE external_id = ?`
 
-func (s *store) DeleteDriver(ctx context.Context, externalID uuid.UUID) error {
-	result, err := s.db.ExecContext(ctx, softDeleteDriverQuery,
-		time.Now(),
-		externalID.Bytes(),
-	)
+// AcknowledgeHandbook records that the driver has acknowledged the given handbook version
+func (s *store) AcknowledgeHandbook(ctx context.Context, externalID uuid.UUID, version, actorID string) (*genproto.Driver, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	now := time.Now()
+	result, err := s.db.ExecContext(ctx, acknowledgeHandbookQuery, version, now, now, actorID, externalID.Bytes())
 	if err != nil {
-		return fmt.Errorf("failed to soft delete driver: %w", err)
+		return nil, fmt.Errorf("failed to acknowledge handbook: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to check affected rows: %w", err)
+		return nil, fmt.Errorf("failed to check affected rows: %w", err)
 	}
 	if rowsAffected == 0 {
-		return types.ErrDriverNotFound
+		return nil, types.ErrDriverNotFound
 	}
 
-	return nil
+	return s.GetDriverByID(ctx, externalID)
 }
 
 // GetDriverCertifications retrieves certifications for a specific driver
@@ -749,26 +2114,21 @@ SELECT
 FROM driver_certifications
 WHERE driver_id = ?
   AND (?='' OR status = ?)
-  AND (? = 0 OR (? = 1 AND expiry_date BETWEEN NOW() AND DATE_ADD(NOW(), INTERVAL 30 DAY)))
-  AND (?='' OR created_at > ?)
-ORDER BY created_at DESC
+  AND (? = 0 OR (? = 1 AND expiry_date BETWEEN ? AND DATE_ADD(?, INTERVAL 30 DAY)))
+  AND (?='' OR (created_at <= ? AND (created_at < ? OR id < ?)))
+ORDER BY created_at DESC, id DESC
 LIMIT ?`
 
 func (s *store) GetDriverCertifications(ctx context.Context, driverID uuid.UUID, params types.ListCertificationsParams) ([]*genproto.DriverCertification, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
-	}
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
 
 	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", err
 	}
 
 	// Prepare filter parameters
@@ -782,16 +2142,17 @@ func (s *store) GetDriverCertifications(ctx context.Context, driverID uuid.UUID,
 		expiringSoon = 1
 	}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	cursorStr, cursorID, err := numericCursorArgs(cursor)
+	if err != nil {
+		return nil, "", err
 	}
 
+	now := s.clock.Now()
 	rows, err := s.db.QueryContext(ctx, getDriverCertificationsQuery,
 		driverID.Bytes(),
 		statusStr, statusStr,
-		expiringSoon, expiringSoon,
-		cursorStr, cursorStr,
+		expiringSoon, expiringSoon, now, now,
+		cursorStr, cursorStr, cursorStr, cursorID,
 		params.PageSize+1,
 	)
 	if err != nil {
@@ -800,42 +2161,30 @@ func (s *store) GetDriverCertifications(ctx context.Context, driverID uuid.UUID,
 	defer rows.Close()
 
 	var certifications []*genproto.DriverCertification
-	var lastCreatedAt time.Time
-
 	for rows.Next() {
 		cert, err := s.scanCertificationFromRows(rows)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan certification: %w", err)
 		}
 		certifications = append(certifications, cert)
-		lastCreatedAt = cert.CreatedAt.AsTime()
 	}
 
 	// Determine next page token
 	var nextPageToken string
 	if int32(len(certifications)) > params.PageSize {
 		certifications = certifications[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
-		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
+		last := certifications[len(certifications)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
 	}
 
 	return certifications, nextPageToken, nil
 }
 
 // UpdateCertification updates certification information
-const updateCertificationQuery = `
-UPDATE driver_certifications 
-SET certification_name = CASE WHEN ? THEN ? ELSE certification_name END,
-    issued_by = CASE WHEN ? THEN ? ELSE issued_by END,
-    issue_date = CASE WHEN ? THEN ? ELSE issue_date END,
-    expiry_date = CASE WHEN ? THEN ? ELSE expiry_date END,
-    updated_at = ?
-WHERE id = ?`
-
 func (s *store) UpdateCertification(ctx context.Context, certID uint64, updates types.CertificationUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.DriverCertification, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	now := time.Now()
 
 	// Determine which fields to update
@@ -886,15 +2235,16 @@ func (s *store) UpdateCertification(ctx context.Context, certID uint64, updates
 		}
 	}
 
+	var set sqlupdate.Set
+	set.Field("certification_name", updateCertificationName, certificationName).
+		Field("issued_by", updateIssuedBy, issuedBy).
+		Field("issue_date", updateIssueDate, issueDate).
+		Field("expiry_date", updateExpiryDate, expiryDate).
+		Value("updated_at", now)
+	query, args := set.Statement("driver_certifications", "id = ?", certID)
+
 	// Execute update
-	result, err := s.db.ExecContext(ctx, updateCertificationQuery,
-		updateCertificationName, certificationName,
-		updateIssuedBy, issuedBy,
-		updateIssueDate, issueDate,
-		updateExpiryDate, expiryDate,
-		now,
-		certID,
-	)
+	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update certification: %w", err)
 	}
@@ -918,6 +2268,9 @@ SET status = 'CERT_REVOKED', updated_at = ?
 WHERE id = ? AND status != 'CERT_REVOKED'`
 
 func (s *store) DeleteCertification(ctx context.Context, certID uint64) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	result, err := s.db.ExecContext(ctx, softDeleteCertificationQuery,
 		time.Now(),
 		certID,
@@ -937,6 +2290,37 @@ func (s *store) DeleteCertification(ctx context.Context, certID uint64) error {
 	return nil
 }
 
+const listCertificationTemplatesQuery = `
+SELECT certification_name, issued_by
+FROM certification_templates
+ORDER BY certification_name`
+
+// ListCertificationTemplates returns the certification templates added to the database
+func (s *store) ListCertificationTemplates(ctx context.Context) ([]*genproto.CertificationTemplate, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, listCertificationTemplatesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certification templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*genproto.CertificationTemplate
+	for rows.Next() {
+		var template genproto.CertificationTemplate
+		if err := rows.Scan(&template.CertificationName, &template.IssuedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan certification template: %w", err)
+		}
+		templates = append(templates, &template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate certification templates: %w", err)
+	}
+
+	return templates, nil
+}
+
 // GetExpiringLicenses retrieves drivers with licenses expiring within specified days
 const getExpiringLicensesQuery = `
 SELECT 
@@ -952,47 +2336,67 @@ SELECT
 	status,
 	hire_date,
 	created_at,
-	updated_at
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
 FROM drivers
-WHERE license_expiry BETWEEN NOW() AND DATE_ADD(NOW(), INTERVAL ? DAY)
+WHERE license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL ? DAY)
   AND status = 'ACTIVE'
   AND (?='' OR created_at > ?)
 ORDER BY license_expiry ASC, created_at DESC
 LIMIT ?`
 
-func (s *store) GetExpiringLicenses(ctx context.Context, daysAhead int32, params types.ListDriversParams) ([]*genproto.Driver, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
-	}
+const countExpiringLicensesQuery = `
+SELECT COUNT(*)
+FROM drivers
+WHERE license_expiry BETWEEN ? AND DATE_ADD(?, INTERVAL ? DAY)
+  AND status = 'ACTIVE'`
+
+func (s *store) GetExpiringLicenses(ctx context.Context, daysAhead int32, params types.ListDriversParams) ([]*genproto.Driver, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
 
 	if daysAhead <= 0 {
 		daysAhead = 30 // Default to 30 days
 	}
 
 	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
 	}
 
 	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	if !cursor.At.IsZero() {
+		cursorStr = cursor.At.Format(time.RFC3339Nano)
+	}
+
+	now := s.clock.Now()
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
 	}
+	defer endSnapshot(tx)
 
-	rows, err := s.db.QueryContext(ctx, getExpiringLicensesQuery,
-		daysAhead,
+	var total int32
+	if err := tx.QueryRowContext(ctx, countExpiringLicensesQuery,
+		now, now, daysAhead,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count expiring licenses: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, getExpiringLicensesQuery,
+		now, now, daysAhead,
 		cursorStr, cursorStr,
 		params.PageSize+1,
 	)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get expiring licenses: %w", err)
+		return nil, "", 0, fmt.Errorf("failed to get expiring licenses: %w", err)
 	}
 	defer rows.Close()
 
@@ -1002,7 +2406,7 @@ func (s *store) GetExpiringLicenses(ctx context.Context, daysAhead int32, params
 	for rows.Next() {
 		driver, err := s.scanDriverFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan driver: %w", err)
+			return nil, "", 0, fmt.Errorf("failed to scan driver: %w", err)
 		}
 		drivers = append(drivers, driver)
 		lastCreatedAt = driver.CreatedAt.AsTime()
@@ -1012,14 +2416,117 @@ func (s *store) GetExpiringLicenses(ctx context.Context, daysAhead int32, params
 	var nextPageToken string
 	if int32(len(drivers)) > params.PageSize {
 		drivers = drivers[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: lastCreatedAt})
+	}
+
+	return drivers, nextPageToken, total, nil
+}
+
+// GetRecentlyExpiredLicenses retrieves drivers whose licenses expired within the past specified days.
+// Suspended drivers are included since they are usually the ones to chase; deleted ones are not.
+const getRecentlyExpiredLicensesQuery = `
+SELECT 
+	LOWER(HEX(external_id)) as external_id,
+	user_id,
+	license_number,
+	license_class,
+	license_expiry,
+	experience_years,
+	phone_number,
+	emergency_contact_name,
+	emergency_contact_phone,
+	status,
+	hire_date,
+	created_at,
+	updated_at,
+	updated_by,
+	rating_average,
+	rating_count,
+	handbook_version,
+	handbook_acknowledged_at
+FROM drivers
+WHERE license_expiry BETWEEN DATE_SUB(?, INTERVAL ? DAY) AND ?
+  AND status <> 'INACTIVE'
+  AND (?='' OR (license_expiry <= ? AND (license_expiry < ? OR external_id < ?)))
+ORDER BY license_expiry DESC, external_id DESC
+LIMIT ?`
+
+const countRecentlyExpiredLicensesQuery = `
+SELECT COUNT(*)
+FROM drivers
+WHERE license_expiry BETWEEN DATE_SUB(?, INTERVAL ? DAY) AND ?
+  AND status <> 'INACTIVE'`
+
+func (s *store) GetRecentlyExpiredLicenses(ctx context.Context, sinceDays int32, params types.ListDriversParams) ([]*genproto.Driver, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	if sinceDays <= 0 {
+		sinceDays = 30 // Default to 30 days
+	}
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.LicenseExpiryDesc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	cursorStr, cursorID, err := uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if cursorStr != "" {
+		// license_expiry is a DATE read back in local time (loc=Local), so compare on the local date
+		cursorStr = cursor.At.In(time.Local).Format("2006-01-02")
+	}
+
+	now := s.clock.Now()
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, countRecentlyExpiredLicensesQuery,
+		now, sinceDays, now,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count recently expired licenses: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, getRecentlyExpiredLicensesQuery,
+		now, sinceDays, now,
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
+	)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to get recently expired licenses: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []*genproto.Driver
+	for rows.Next() {
+		driver, err := s.scanDriverFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
+			return nil, "", 0, fmt.Errorf("failed to scan driver: %w", err)
 		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
+		drivers = append(drivers, driver)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to iterate drivers: %w", err)
 	}
 
-	return drivers, nextPageToken, nil
+	// Determine next page token
+	var nextPageToken string
+	if int32(len(drivers)) > params.PageSize {
+		drivers = drivers[:params.PageSize]
+		last := drivers[len(drivers)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.LicenseExpiryDesc, pagetoken.Cursor{At: last.LicenseExpiry.AsTime(), ID: last.Id})
+	}
+
+	return drivers, nextPageToken, total, nil
 }
 
 // GetExpiredCertifications retrieves expired certifications
@@ -1035,17 +2542,18 @@ SELECT
 	created_at,
 	updated_at
 FROM driver_certifications
-WHERE expiry_date < NOW()
-  AND (? = 0 OR expiry_date >= DATE_SUB(NOW(), INTERVAL ? DAY))
+WHERE expiry_date < ?
+  AND (? = 0 OR expiry_date >= DATE_SUB(?, INTERVAL ? DAY))
   AND status IN ('CERT_ACTIVE', 'CERT_EXPIRED')
   AND (?='' OR created_at > ?)
 ORDER BY expiry_date DESC, created_at DESC
 LIMIT ?`
 
 func (s *store) GetExpiredCertifications(ctx context.Context, expiredSinceDays *int32, params types.ListCertificationsParams) ([]*genproto.DriverCertification, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
-	}
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
 
 	expiredSince := int32(0)
 	useExpiredSince := 0
@@ -1055,24 +2563,20 @@ func (s *store) GetExpiredCertifications(ctx context.Context, expiredSinceDays *
 	}
 
 	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", err
 	}
 
 	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	if !cursor.At.IsZero() {
+		cursorStr = cursor.At.Format(time.RFC3339Nano)
 	}
 
+	now := s.clock.Now()
 	rows, err := s.db.QueryContext(ctx, getExpiredCertificationsQuery,
-		useExpiredSince, expiredSince,
+		now,
+		useExpiredSince, now, expiredSince,
 		cursorStr, cursorStr,
 		params.PageSize+1,
 	)
@@ -1097,11 +2601,7 @@ func (s *store) GetExpiredCertifications(ctx context.Context, expiredSinceDays *
 	var nextPageToken string
 	if int32(len(certifications)) > params.PageSize {
 		certifications = certifications[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
-		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: lastCreatedAt})
 	}
 
 	return certifications, nextPageToken, nil
@@ -1190,9 +2690,8 @@ func (s *store) populateCertification(cert *genproto.DriverCertification, status
 	cert.ExpiryDate = timestamppb.New(expiryDate)
 
 	// Calculate computed fields
-	now := time.Now()
-	cert.IsExpired = expiryDate.Before(now)
-	cert.DaysUntilExpiry = int32(time.Until(expiryDate).Hours() / 24)
+	cert.IsExpired = expiryDate.Before(s.clock.Now())
+	cert.DaysUntilExpiry = clock.DaysUntil(s.clock, expiryDate)
 
 	// Set timestamps
 	if createdAt.Valid {
@@ -1204,3 +2703,83 @@ func (s *store) populateCertification(cert *genproto.DriverCertification, status
 
 	return cert, nil
 }
+
+// Idempotency
+
+const purgeExpiredIdempotencyKeysQuery = `
+DELETE FROM idempotency_keys
+WHERE expires_at <= ?
+ORDER BY expires_at
+LIMIT 100`
+
+// An expired key is taken over by the new claim; a live one keeps its resource.
+// expires_at is assigned last since MySQL applies the assignments in order.
+const claimIdempotencyKeyQuery = `
+INSERT INTO idempotency_keys (scope, actor_id, idempotency_key, external_id, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+	external_id = IF(expires_at <= VALUES(created_at), VALUES(external_id), external_id),
+	created_at = IF(expires_at <= VALUES(created_at), VALUES(created_at), created_at),
+	expires_at = IF(expires_at <= VALUES(created_at), VALUES(expires_at), expires_at)`
+
+const getIdempotencyKeyQuery = `
+SELECT external_id
+FROM idempotency_keys
+WHERE scope = ? AND actor_id = ? AND idempotency_key = ?`
+
+// ClaimIdempotencyKey binds key to externalID for ttl, unless the key is already bound
+// and hasn't expired. It returns the ID the key is bound to afterwards; anything other
+// than externalID means an earlier request claimed the key first.
+func (s *store) ClaimIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID, ttl time.Duration) (uuid.UUID, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	now := time.Now()
+
+	// Expired keys are cleared a few at a time as new ones are claimed, so the table
+	// stays close to the keys still live
+	if _, err := s.db.ExecContext(ctx, purgeExpiredIdempotencyKeysQuery, now); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+
+	_, err := s.db.ExecContext(ctx, claimIdempotencyKeyQuery,
+		key.Scope,
+		key.ActorID,
+		key.Value,
+		externalID.Bytes(),
+		now,
+		now.Add(ttl),
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	var boundID []byte
+	err = s.db.QueryRowContext(ctx, getIdempotencyKeyQuery, key.Scope, key.ActorID, key.Value).Scan(&boundID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+	return uuid.FromBytes(boundID)
+}
+
+const releaseIdempotencyKeyQuery = `
+DELETE FROM idempotency_keys
+WHERE scope = ? AND actor_id = ? AND idempotency_key = ? AND external_id = ?`
+
+// ReleaseIdempotencyKey frees a key claimed for externalID, for when the request that
+// claimed it failed. A key since taken over by another claim is left alone.
+func (s *store) ReleaseIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, releaseIdempotencyKeyQuery,
+		key.Scope,
+		key.ActorID,
+		key.Value,
+		externalID.Bytes(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// services/staff/internal/store/store_test.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/staff/internal/types"
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestClose(t *testing.T) {
+	tests := []struct {
+		name     string
+		closeErr error
+	}{
+		{name: "closes the database"},
+		{name: "returns the close error", closeErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			s := &store{db: db, clock: clock.System, queryTimeout: utils.DefaultDBQueryTimeout}
+
+			mock.ExpectClose().WillReturnError(tt.closeErr)
+
+			if err := s.Close(); !errors.Is(err, tt.closeErr) {
+				t.Errorf("Close = %v, want %v", err, tt.closeErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+var driverColumns = []string{
+	"external_id", "user_id", "license_number", "license_class", "license_expiry", "experience_years",
+	"phone_number", "emergency_contact_name", "emergency_contact_phone", "status", "hire_date",
+	"created_at", "updated_at", "updated_by", "rating_average", "rating_count",
+	"handbook_version", "handbook_acknowledged_at",
+}
+
+func TestMergeDrivers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := &store{db: db, clock: clock.System, queryTimeout: utils.DefaultDBQueryTimeout}
+
+	primaryID := uuid.Must(uuid.FromString("3f1c2a9e-5b7d-4e21-8c6a-0d9e4b2f7a13"))
+	duplicateID := uuid.Must(uuid.FromString("a84e0c6b-91d2-4f3a-b5e7-2c8d1f6a9b40"))
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockDriversForMergeQuery)).
+		WithArgs(primaryID.Bytes(), duplicateID.Bytes()).
+		WillReturnRows(sqlmock.NewRows([]string{"external_id", "status"}).
+			AddRow(primaryID.Bytes(), "ACTIVE").
+			AddRow(duplicateID.Bytes(), "SUSPENDED"))
+	mock.ExpectExec(regexp.QuoteMeta(reassignCertificationsQuery)).
+		WithArgs(primaryID.Bytes(), duplicateID.Bytes()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(reassignStatusHistoryQuery)).
+		WithArgs(primaryID.Bytes(), duplicateID.Bytes()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(reassignNormalizationAuditQuery)).
+		WithArgs(primaryID.Bytes(), duplicateID.Bytes()).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(regexp.QuoteMeta(mergeIntoPrimaryQuery)).
+		WithArgs(duplicateID.Bytes(), sqlmock.AnyArg(), "admin-1", primaryID.Bytes()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(retireMergedDriverQuery)).
+		WithArgs(sqlmock.AnyArg(), "admin-1", duplicateID.Bytes()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The duplicate's own move to INACTIVE is recorded after its history moved, so it stays behind
+	mock.ExpectExec(regexp.QuoteMeta(insertDriverStatusHistoryQuery)).
+		WithArgs(duplicateID.Bytes(), "SUSPENDED", "INACTIVE",
+			sql.NullString{String: "merged into " + primaryID.String(), Valid: true}, "admin-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(getDriverByIDQuery)).
+		WithArgs(primaryID.Bytes()).
+		WillReturnRows(sqlmock.NewRows(driverColumns).AddRow(
+			primaryID.String(), "user-1", "DL1234589", "CLASS_B", now.AddDate(1, 0, 0), 5,
+			"+254701234567", "Jane Doe", "+254701234568", "ACTIVE", nil,
+			now, now, "admin-1", 4.5, 10, "", nil,
+		))
+
+	result, err := s.MergeDrivers(context.Background(), primaryID, duplicateID, "admin-1")
+	if err != nil {
+		t.Fatalf("MergeDrivers: %v", err)
+	}
+	if result.CertificationsMoved != 2 || result.StatusHistoryMoved != 3 || result.NormalizationAuditMoved != 4 {
+		t.Errorf("moved certifications/history/audit = %d/%d/%d, want 2/3/4",
+			result.CertificationsMoved, result.StatusHistoryMoved, result.NormalizationAuditMoved)
+	}
+	if result.Driver.Id != primaryID.String() {
+		t.Errorf("driver = %s, want the primary %s", result.Driver.Id, primaryID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMergeDriversNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := &store{db: db, clock: clock.System, queryTimeout: utils.DefaultDBQueryTimeout}
+
+	primaryID := uuid.Must(uuid.NewV4())
+	duplicateID := uuid.Must(uuid.NewV4())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockDriversForMergeQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"external_id", "status"}).AddRow(primaryID.Bytes(), "ACTIVE"))
+	mock.ExpectRollback()
+
+	if _, err := s.MergeDrivers(context.Background(), primaryID, duplicateID, "admin-1"); !errors.Is(err, types.ErrDriverNotFound) {
+		t.Errorf("MergeDrivers = %v, want %v", err, types.ErrDriverNotFound)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetDriversByUserIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+	s := &store{db: db, clock: clock.System, queryTimeout: utils.DefaultDBQueryTimeout}
+
+	now := time.Now()
+	userIDs := []string{"user-1", "user-2", "user-3", "user-4"}
+	rows := sqlmock.NewRows(driverColumns)
+	for i, userID := range userIDs[:3] { // user-4 has no driver profile
+		rows.AddRow(uuid.Must(uuid.NewV4()).String(), userID, fmt.Sprintf("DL%07d", i), "CLASS_B", now.AddDate(1, 0, 0), 5,
+			"+254701234567", "Jane Doe", "+254701234568", "ACTIVE", nil, now, now, nil, 0.0, 0, "", nil)
+	}
+	// One row per user: nothing may cap the batch at the first match
+	mock.ExpectQuery(regexp.QuoteMeta("FROM drivers WHERE user_id IN (?, ?, ?, ?)") + "$").
+		WithArgs("user-1", "user-2", "user-3", "user-4").
+		WillReturnRows(rows)
+
+	drivers, err := s.GetDriversByUserIDs(context.Background(), userIDs)
+	if err != nil {
+		t.Fatalf("GetDriversByUserIDs: %v", err)
+	}
+	if len(drivers) != 3 {
+		t.Errorf("got %d drivers, want 3", len(drivers))
+	}
+	for _, userID := range userIDs[:3] {
+		if driver, ok := drivers[userID]; !ok || driver.UserId != userID {
+			t.Errorf("drivers[%s] = %v, want that user's driver", userID, driver)
+		}
+	}
+	if _, ok := drivers["user-4"]; ok {
+		t.Error("user without a driver profile has a driver")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -4,7 +4,12 @@ package types
 import (
 	"context"
 	"errors"
+	"time"
 
+	"fmt"
+	"github.com/adammwaniki/bebabeba/services/common/clock"
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
 	"github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
 	"github.com/gofrs/uuid/v5"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -16,24 +21,46 @@ type StaffService interface {
 	CreateDriver(ctx context.Context, req *genproto.CreateDriverRequest) (*genproto.CreateDriverResponse, error)
 	GetDriver(ctx context.Context, req *genproto.GetDriverRequest) (*genproto.GetDriverResponse, error)
 	GetDriverByUserID(ctx context.Context, req *genproto.GetDriverByUserIDRequest) (*genproto.GetDriverResponse, error)
+	GetDriversByUserIDs(ctx context.Context, req *genproto.GetDriversByUserIDsRequest) (*genproto.GetDriversByUserIDsResponse, error)
 	ListDrivers(ctx context.Context, req *genproto.ListDriversRequest) (*genproto.ListDriversResponse, error)
 	UpdateDriver(ctx context.Context, req *genproto.UpdateDriverRequest) (*genproto.UpdateDriverResponse, error)
 	DeleteDriver(ctx context.Context, req *genproto.DeleteDriverRequest) error
+	RestoreDriver(ctx context.Context, req *genproto.RestoreDriverRequest) (*genproto.RestoreDriverResponse, error)
+	HardDeleteDriver(ctx context.Context, req *genproto.HardDeleteDriverRequest) (*genproto.HardDeleteDriverResponse, error)
+	MergeDrivers(ctx context.Context, req *genproto.MergeDriversRequest) (*genproto.MergeDriversResponse, error)
+	UpdateDriverRating(ctx context.Context, req *genproto.UpdateDriverRatingRequest) (*genproto.UpdateDriverRatingResponse, error)
+	AcknowledgeHandbook(ctx context.Context, req *genproto.AcknowledgeHandbookRequest) (*genproto.AcknowledgeHandbookResponse, error)
 
 	// Driver status management
 	UpdateDriverStatus(ctx context.Context, req *genproto.UpdateDriverStatusRequest) (*genproto.UpdateDriverStatusResponse, error)
+	RenewDriverLicense(ctx context.Context, req *genproto.RenewDriverLicenseRequest) (*genproto.RenewDriverLicenseResponse, error)
+	ValidateDriverStatusChange(ctx context.Context, req *genproto.ValidateDriverStatusChangeRequest) (*genproto.ValidateDriverStatusChangeResponse, error)
+	ListDriverStatusHistory(ctx context.Context, req *genproto.ListDriverStatusHistoryRequest) (*genproto.ListDriverStatusHistoryResponse, error)
 	GetActiveDrivers(ctx context.Context, req *genproto.GetActiveDriversRequest) (*genproto.ListDriversResponse, error)
+	GetEligibleDriversForVehicleType(ctx context.Context, req *genproto.GetEligibleDriversForVehicleTypeRequest) (*genproto.ListDriversResponse, error)
+	CheckDriverEligibility(ctx context.Context, req *genproto.CheckDriverEligibilityRequest) (*genproto.CheckDriverEligibilityResponse, error)
+	ListRecentlyUpdatedDrivers(ctx context.Context, req *genproto.ListRecentlyUpdatedDriversRequest) (*genproto.ListDriversResponse, error)
 
 	// Driver certification management
 	AddDriverCertification(ctx context.Context, req *genproto.AddDriverCertificationRequest) (*genproto.AddDriverCertificationResponse, error)
 	ListDriverCertifications(ctx context.Context, req *genproto.ListDriverCertificationsRequest) (*genproto.ListDriverCertificationsResponse, error)
 	UpdateCertification(ctx context.Context, req *genproto.UpdateCertificationRequest) (*genproto.UpdateCertificationResponse, error)
 	DeleteCertification(ctx context.Context, req *genproto.DeleteCertificationRequest) error
+	ListCertificationTemplates(ctx context.Context, req *genproto.ListCertificationTemplatesRequest) (*genproto.ListCertificationTemplatesResponse, error)
 
 	// Driver verification and compliance
 	VerifyDriverLicense(ctx context.Context, req *genproto.VerifyDriverLicenseRequest) (*genproto.VerifyDriverLicenseResponse, error)
+	BatchVerifyDriverLicenses(ctx context.Context, req *genproto.BatchVerifyDriverLicensesRequest) (*genproto.BatchVerifyDriverLicensesResponse, error)
 	GetExpiringLicenses(ctx context.Context, req *genproto.GetExpiringLicensesRequest) (*genproto.ListDriversResponse, error)
+	GetRecentlyExpiredLicenses(ctx context.Context, req *genproto.GetRecentlyExpiredLicensesRequest) (*genproto.ListDriversResponse, error)
 	GetExpiredCertifications(ctx context.Context, req *genproto.GetExpiredCertificationsRequest) (*genproto.ListDriverCertificationsResponse, error)
+
+	// Format checks
+	ValidatePhoneNumber(ctx context.Context, req *genproto.ValidatePhoneNumberRequest) (*genproto.FieldValidationResponse, error)
+	ValidateLicenseNumber(ctx context.Context, req *genproto.ValidateLicenseNumberRequest) (*genproto.FieldValidationResponse, error)
+
+	// Maintenance
+	NormalizeLegacyRecords(ctx context.Context, req *genproto.NormalizeLegacyRecordsRequest) (*genproto.NormalizeLegacyRecordsResponse, error)
 }
 
 // Data store interface
@@ -41,25 +68,50 @@ type StaffStore interface {
 	// Driver CRUD
 	CreateDriver(ctx context.Context, internalID uint64, externalID uuid.UUID, driver *DriverData) error
 	GetDriverByID(ctx context.Context, externalID uuid.UUID) (*genproto.Driver, error)
+	GetDriversByIDs(ctx context.Context, externalIDs []uuid.UUID) ([]*genproto.Driver, error)
 	GetDriverByUserID(ctx context.Context, userID string) (*genproto.Driver, error)
+	GetDriversByUserIDs(ctx context.Context, userIDs []string) (map[string]*genproto.Driver, error)
 	GetDriverByLicenseNumber(ctx context.Context, licenseNumber string) (*genproto.Driver, error)
-	ListDrivers(ctx context.Context, params ListDriversParams) ([]*genproto.Driver, string, error)
-	UpdateDriver(ctx context.Context, externalID uuid.UUID, updates DriverUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.Driver, error)
-	DeleteDriver(ctx context.Context, externalID uuid.UUID) error
+	// Listings return a page, the next page token, and the number of rows matching the filters across all pages
+	ListDrivers(ctx context.Context, params ListDriversParams) (drivers []*genproto.Driver, nextPageToken, prevPageToken string, total int32, err error)
+	UpdateDriver(ctx context.Context, externalID uuid.UUID, updates DriverUpdateFields, updateMask *fieldmaskpb.FieldMask, actorID string) (*genproto.Driver, error)
+	DeleteDriver(ctx context.Context, externalID uuid.UUID, actorID string) error
+	RestoreDriver(ctx context.Context, externalID uuid.UUID, actorID string) (*genproto.Driver, error)
+	HardDeleteDriver(ctx context.Context, externalID uuid.UUID) (certificationsDeleted int64, err error)
+	MergeDrivers(ctx context.Context, primaryID, duplicateID uuid.UUID, actorID string) (*DriverMergeResult, error)
+	UpdateDriverRating(ctx context.Context, externalID uuid.UUID, rating float64, actorID string) (*genproto.Driver, error)
+	AcknowledgeHandbook(ctx context.Context, externalID uuid.UUID, version, actorID string) (*genproto.Driver, error)
 
 	// Driver status management
-	UpdateDriverStatus(ctx context.Context, externalID uuid.UUID, status genproto.DriverStatus, reason string) (*genproto.Driver, error)
-	GetActiveDrivers(ctx context.Context, params ListDriversParams) ([]*genproto.Driver, string, error)
+	UpdateDriverStatus(ctx context.Context, externalID uuid.UUID, status genproto.DriverStatus, reason, actorID string) (*genproto.Driver, error)
+	RenewDriverLicense(ctx context.Context, externalID uuid.UUID, licenseExpiry string, licenseNumber *string, actorID string) (driver *genproto.Driver, reactivated bool, err error)
+	ListDriverStatusHistory(ctx context.Context, externalID uuid.UUID, pageSize int32, pageToken string) ([]*genproto.DriverStatusHistoryEntry, string, error)
+	GetActiveDrivers(ctx context.Context, params ListDriversParams) ([]*genproto.Driver, string, int32, error)
+	GetEligibleDrivers(ctx context.Context, licenseClasses []genproto.LicenseClass, params ListDriversParams) ([]*genproto.Driver, string, int32, error)
+	ListRecentlyUpdatedDrivers(ctx context.Context, params ListDriversParams) ([]*genproto.Driver, string, int32, error)
 
 	// Driver certification management
 	AddDriverCertification(ctx context.Context, certID uint64, driverID uuid.UUID, cert *CertificationData) (*genproto.DriverCertification, error)
 	GetDriverCertifications(ctx context.Context, driverID uuid.UUID, params ListCertificationsParams) ([]*genproto.DriverCertification, string, error)
 	UpdateCertification(ctx context.Context, certID uint64, updates CertificationUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.DriverCertification, error)
 	DeleteCertification(ctx context.Context, certID uint64) error
+	ListCertificationTemplates(ctx context.Context) ([]*genproto.CertificationTemplate, error)
 
 	// Compliance queries
-	GetExpiringLicenses(ctx context.Context, daysAhead int32, params ListDriversParams) ([]*genproto.Driver, string, error)
+	GetExpiringLicenses(ctx context.Context, daysAhead int32, params ListDriversParams) ([]*genproto.Driver, string, int32, error)
+	GetRecentlyExpiredLicenses(ctx context.Context, sinceDays int32, params ListDriversParams) ([]*genproto.Driver, string, int32, error)
 	GetExpiredCertifications(ctx context.Context, expiredSinceDays *int32, params ListCertificationsParams) ([]*genproto.DriverCertification, string, error)
+
+	// Idempotency
+	ClaimIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID, ttl time.Duration) (uuid.UUID, error)
+	ReleaseIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID) error
+
+	// Maintenance
+	ListNormalizableDrivers(ctx context.Context, afterInternalID uint64, limit int32) ([]NormalizableDriver, error)
+	ApplyDriverNormalization(ctx context.Context, driver NormalizableDriver, changes []FieldChange, actorID string) error
+
+	// Close releases the database connections on shutdown
+	Close() error
 }
 
 // DriverData represents the data needed to create a driver
@@ -75,7 +127,16 @@ type DriverData struct {
 	HireDate               *string // ISO date string, optional
 }
 
-// DriverUpdateFields represents fields that can be updated
+// DriverMergeResult reports what a driver merge moved onto the primary record
+type DriverMergeResult struct {
+	Driver                  *genproto.Driver
+	CertificationsMoved     int64
+	StatusHistoryMoved      int64
+	NormalizationAuditMoved int64
+}
+
+// DriverUpdateFields represents fields that can be updated.
+// With an update mask, a masked HireDate left nil clears the stored date.
 type DriverUpdateFields struct {
 	UserID                 *string
 	LicenseNumber          *string
@@ -111,6 +172,15 @@ type ListDriversParams struct {
 	StatusFilter          *genproto.DriverStatus
 	LicenseClassFilter    *genproto.LicenseClass
 	LicenseExpiringSoon   *bool
+	IncludeExpiredLicense bool // GetActiveDrivers only
+	Sort                  pagetoken.Sort // ListDrivers only; zero means DriverSorts[""]
+}
+
+// DriverSorts are the orders ListDrivers can page in, keyed by order_by
+var DriverSorts = pagetoken.Whitelist{
+	"":               pagetoken.CreatedAtDesc,
+	"created_at":     pagetoken.CreatedAtDesc,
+	"license_expiry": pagetoken.LicenseExpiryAsc,
 }
 
 // ListCertificationsParams encapsulates list parameters for certifications
@@ -121,16 +191,89 @@ type ListCertificationsParams struct {
 	ExpiringSoon  *bool
 }
 
+// Config holds opt-in behaviour for the staff service
+type Config struct {
+	// StrictHireDates rejects hire dates that contradict the driver's license
+	// details. Off by default so legacy imports with patchy history still load.
+	StrictHireDates bool
+
+	// Clock is "now" for expiry rules such as refusing to activate a driver whose
+	// license has expired. Nil means the system clock.
+	Clock clock.Clock
+
+	// HandbookVersion is the operating handbook version drivers must acknowledge.
+	// Acknowledgements of any other version are refused. Empty means no handbook is required.
+	HandbookVersion string
+}
+
+// MaxHandbookVersionLength matches the drivers.handbook_version column
+const MaxHandbookVersionLength = 32
+
+// MaxBatchVerifyDrivers caps how many drivers a single BatchVerifyDriverLicenses call may check
+const MaxBatchVerifyDrivers = 100
+
+// NormalizableDriver holds the stored values of the driver fields that normalization rewrites
+type NormalizableDriver struct {
+	InternalID            uint64
+	ExternalID            string
+	UserID                string
+	LicenseNumber         string
+	PhoneNumber           string
+	EmergencyContactName  string
+	EmergencyContactPhone string
+}
+
+// FieldChange is one field NormalizeLegacyRecords rewrote, as recorded in the audit table
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// Legacy record normalization runs in batches of DefaultNormalizeBatchSize rows unless the
+// caller asks otherwise, and reports at most MaxReportedNormalizations changed records
+const (
+	DefaultNormalizeBatchSize = 200
+	MaxNormalizeBatchSize     = 1000
+	MaxReportedNormalizations = 500
+)
+
+// LicenseExpiredReason is the status change reason that marks a suspension as caused by an
+// expired license. RenewDriverLicense only reactivates drivers suspended with this reason.
+const LicenseExpiredReason = "LICENSE_EXPIRED"
+
+// MaxDriversByUserIDs caps how many users a single GetDriversByUserIDs call may look up.
+// The gateway splits user pages larger than this into several calls.
+const MaxDriversByUserIDs = 100
+
 // Error types
 var (
-	ErrDriverNotFound        = errors.New("driver not found")
-	ErrCertificationNotFound = errors.New("certification not found")
-	ErrDuplicateEntry        = errors.New("duplicate entry")
-	ErrInvalidStatus         = errors.New("invalid status transition")
-	ErrDriverHasAssignments  = errors.New("driver has active vehicle assignments")
-	ErrLicenseExpired        = errors.New("driver license is expired")
+	ErrDriverNotFound         = errors.New("driver not found")
+	ErrCertificationNotFound  = errors.New("certification not found")
+	ErrDuplicateEntry         = errors.New("duplicate entry")
+	ErrInvalidStatus          = errors.New("invalid status transition")
+	ErrDriverHasAssignments   = errors.New("driver has active vehicle assignments")
+	ErrLicenseExpired         = errors.New("driver license is expired")
+	ErrDuplicateCertification = errors.New("driver already holds this certification")
+	ErrStaleRecord            = errors.New("record changed since it was read")
+	ErrDriverActive           = errors.New("driver is active")
+	ErrDriverNotInactive      = errors.New("driver is not inactive")
 )
 
+// DuplicateCertificationError names the certification that stops the same one being
+// added again, so callers can point the user at updating it instead
+type DuplicateCertificationError struct {
+	ExistingID string
+}
+
+func (e *DuplicateCertificationError) Error() string {
+	return fmt.Sprintf("%v (certification %s)", ErrDuplicateCertification, e.ExistingID)
+}
+
+func (e *DuplicateCertificationError) Is(target error) bool {
+	return target == ErrDuplicateCertification
+}
+
 // Driver status transition rules
 var ValidDriverStatusTransitions = map[genproto.DriverStatus][]genproto.DriverStatus{
 	genproto.DriverStatus_PENDING_VERIFICATION: {
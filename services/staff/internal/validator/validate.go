@@ -3,13 +3,19 @@ package validator
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/adammwaniki/bebabeba/services/common/clock"
 	"github.com/adammwaniki/bebabeba/services/staff/proto/genproto"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ValidationError struct {
@@ -21,6 +27,11 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// FieldViolation reports the rejected field, so the error reaches clients as a structured violation
+func (e ValidationError) FieldViolation() (string, string) {
+	return e.Field, e.Message
+}
+
 // Kenyan driving license patterns
 var (
 	// Modern format: DL followed by numbers/letters
@@ -134,6 +145,18 @@ func ValidateExperienceYears(field string, years int32) error {
 	return nil
 }
 
+// ValidateRating validates a single trip rating on the 0-5 scale
+func ValidateRating(field string, rating float64) error {
+	if math.IsNaN(rating) || rating < 0 || rating > 5 {
+		return ValidationError{
+			Field:   field,
+			Message: "must be between 0 and 5",
+		}
+	}
+
+	return nil
+}
+
 // ValidateEmergencyContact validates emergency contact information
 func ValidateEmergencyContact(nameField, phoneField, name, phone string) error {
 	if name == "" {
@@ -262,9 +285,54 @@ func ValidateDriverStatus(field string, status genproto.DriverStatus) error {
 	return nil
 }
 
+// MinStatusChangeReasonLength is the shortest reason accepted for taking a driver off duty
+const MinStatusChangeReasonLength = 10
+
+// ValidateStatusChangeReason requires a reason of at least MinStatusChangeReasonLength
+// characters when a driver is moved to SUSPENDED or INACTIVE, so the status history
+// records why they were taken off duty. Other statuses don't need one.
+func ValidateStatusChangeReason(field string, status genproto.DriverStatus, reason string) error {
+	if status != genproto.DriverStatus_SUSPENDED && status != genproto.DriverStatus_INACTIVE {
+		return nil
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("is required when changing status to %s", status.String()),
+		}
+	}
+	if utf8.RuneCountInString(reason) < MinStatusChangeReasonLength {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at least %d characters", MinStatusChangeReasonLength),
+		}
+	}
+
+	return nil
+}
+
+// ValidateRequiredTimestamp checks that a required date was actually sent.
+// Clients that leave a timestamp unset often still send the zero value, which AsTime
+// turns into 1970-01-01; that exact value is treated as unset rather than stored.
+// Any other past date is a real date and is left to the field's own range checks.
+func ValidateRequiredTimestamp(field string, ts *timestamppb.Timestamp) error {
+	if ts == nil {
+		return ValidationError{Field: field, Message: "is required"}
+	}
+	if ts.GetSeconds() == 0 && ts.GetNanos() == 0 {
+		return ValidationError{Field: field, Message: "is required (received the zero timestamp)"}
+	}
+	if err := ts.CheckValid(); err != nil {
+		return ValidationError{Field: field, Message: "is not a valid timestamp"}
+	}
+	return nil
+}
+
 // ValidateLicenseExpiry validates license expiry date
-func ValidateLicenseExpiry(field string, expiry time.Time) error {
-	now := time.Now()
+func ValidateLicenseExpiry(field string, expiry time.Time, clk clock.Clock) error {
+	now := clk.Now()
 
 	// License shouldn't be expired by more than 1 year (grace period)
 	if expiry.Before(now.AddDate(-1, 0, 0)) {
@@ -285,9 +353,24 @@ func ValidateLicenseExpiry(field string, expiry time.Time) error {
 	return nil
 }
 
+// ValidateRenewedLicenseExpiry validates the expiry of a renewed license, which on top of
+// the usual range checks must lie in the future
+func ValidateRenewedLicenseExpiry(field string, expiry time.Time, clk clock.Clock) error {
+	if err := ValidateLicenseExpiry(field, expiry, clk); err != nil {
+		return err
+	}
+	if !expiry.After(clk.Now()) {
+		return ValidationError{
+			Field:   field,
+			Message: "renewed license must expire in the future",
+		}
+	}
+	return nil
+}
+
 // ValidateHireDate validates driver hire date
-func ValidateHireDate(field string, hireDate time.Time) error {
-	now := time.Now()
+func ValidateHireDate(field string, hireDate time.Time, clk clock.Clock) error {
+	now := clk.Now()
 
 	// Hire date cannot be in the future
 	if hireDate.After(now) {
@@ -321,8 +404,77 @@ func NormalizeDriverFields(input *genproto.DriverInput) {
 	input.UserId = strings.TrimSpace(input.UserId)
 }
 
+// vehicleTypeLicenseClasses maps vehicle type names to the license classes that may drive them.
+// This is the single source of truth for driver eligibility.
+var vehicleTypeLicenseClasses = map[string][]genproto.LicenseClass{
+	"bodaboda": {genproto.LicenseClass_CLASS_A},
+	"cab":      {genproto.LicenseClass_CLASS_B, genproto.LicenseClass_CLASS_E},
+	"van":      {genproto.LicenseClass_CLASS_B, genproto.LicenseClass_CLASS_E},
+	"pickup":   {genproto.LicenseClass_CLASS_B, genproto.LicenseClass_CLASS_C},
+	"matatu":   {genproto.LicenseClass_CLASS_C, genproto.LicenseClass_CLASS_D, genproto.LicenseClass_CLASS_E},
+	"bus":      {genproto.LicenseClass_CLASS_D, genproto.LicenseClass_CLASS_E},
+	"truck":    {genproto.LicenseClass_CLASS_C, genproto.LicenseClass_CLASS_D},
+}
+
+// LicenseClassesForVehicleType returns the license classes that qualify a driver for a vehicle type
+func LicenseClassesForVehicleType(vehicleType string) ([]genproto.LicenseClass, bool) {
+	classes, ok := vehicleTypeLicenseClasses[strings.ToLower(strings.TrimSpace(vehicleType))]
+	return classes, ok
+}
+
+// ValidateLicenseClassForVehicle validates that a license class qualifies a driver for a vehicle type
+func ValidateLicenseClassForVehicle(field string, licenseClass genproto.LicenseClass, vehicleType string) error {
+	classes, ok := LicenseClassesForVehicleType(vehicleType)
+	if !ok {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("no license rules for vehicle type %q", vehicleType),
+		}
+	}
+
+	for _, allowed := range classes {
+		if allowed == licenseClass {
+			return nil
+		}
+	}
+
+	return ValidationError{
+		Field:   field,
+		Message: fmt.Sprintf("%s does not qualify for vehicle type %q", licenseClass.String(), vehicleType),
+	}
+}
+
+// ValidateHireDateAgainstLicense cross-checks a hire date with the license on file.
+// Experience years count time spent licensed, so the driver cannot have been hired
+// before now minus that experience, nor after the current license had already expired.
+// The check is skipped while the license class is unknown.
+func ValidateHireDateAgainstLicense(field string, hireDate, licenseExpiry time.Time, licenseClass genproto.LicenseClass, experienceYears int32, clk clock.Clock) error {
+	if licenseClass == genproto.LicenseClass_LICENSE_UNSPECIFIED || licenseExpiry.IsZero() {
+		return nil
+	}
+
+	if hireDate.After(licenseExpiry) {
+		return ValidationError{
+			Field:   field,
+			Message: "hire date cannot be after the license expired",
+		}
+	}
+
+	// Experience is recorded in whole years, so allow for the part-year on top
+	licensedSince := clk.Now().AddDate(-int(experienceYears)-1, 0, 0)
+	if hireDate.Before(licensedSince) {
+		return ValidationError{
+			Field: field,
+			Message: fmt.Sprintf("hire date is before the driver could have held a %s license (%d years of experience)",
+				licenseClass.String(), experienceYears),
+		}
+	}
+
+	return nil
+}
+
 // ValidateCreateDriverRequest validates driver creation request
-func ValidateCreateDriverRequest(req *genproto.CreateDriverRequest) error {
+func ValidateCreateDriverRequest(req *genproto.CreateDriverRequest, clk clock.Clock) error {
 	if req == nil {
 		return ValidationError{Field: "request", Message: "cannot be nil"}
 	}
@@ -350,15 +502,11 @@ func ValidateCreateDriverRequest(req *genproto.CreateDriverRequest) error {
 	}
 
 	// Validate license expiry
-	if driver.LicenseExpiry != nil {
-		if err := ValidateLicenseExpiry("license_expiry", driver.LicenseExpiry.AsTime()); err != nil {
-			return err
-		}
-	} else {
-		return ValidationError{
-			Field:   "license_expiry",
-			Message: "is required",
-		}
+	if err := ValidateRequiredTimestamp("license_expiry", driver.LicenseExpiry); err != nil {
+		return err
+	}
+	if err := ValidateLicenseExpiry("license_expiry", driver.LicenseExpiry.AsTime(), clk); err != nil {
+		return err
 	}
 
 	if err := ValidateExperienceYears("experience_years", driver.ExperienceYears); err != nil {
@@ -376,7 +524,7 @@ func ValidateCreateDriverRequest(req *genproto.CreateDriverRequest) error {
 
 	// Validate hire date if provided
 	if driver.HireDate != nil {
-		if err := ValidateHireDate("hire_date", driver.HireDate.AsTime()); err != nil {
+		if err := ValidateHireDate("hire_date", driver.HireDate.AsTime(), clk); err != nil {
 			return err
 		}
 	}
@@ -384,88 +532,122 @@ func ValidateCreateDriverRequest(req *genproto.CreateDriverRequest) error {
 	return nil
 }
 
-// ValidateUpdateDriverRequest validates driver update request
-func ValidateUpdateDriverRequest(req *genproto.UpdateDriverRequest) error {
+// ValidateUpdateDriverRequest validates driver update request. It also returns a warning
+// for every submitted value that normalization changed, for the update response.
+func ValidateUpdateDriverRequest(req *genproto.UpdateDriverRequest, clk clock.Clock) ([]*genproto.NormalizationWarning, error) {
 	if req == nil {
-		return ValidationError{Field: "request", Message: "cannot be nil"}
+		return nil, ValidationError{Field: "request", Message: "cannot be nil"}
 	}
 
 	if req.DriverId == "" {
-		return ValidationError{Field: "driver_id", Message: "cannot be empty"}
+		return nil, ValidationError{Field: "driver_id", Message: "cannot be empty"}
 	}
 
 	if req.Driver == nil {
-		return ValidationError{Field: "driver", Message: "cannot be nil"}
+		return nil, ValidationError{Field: "driver", Message: "cannot be nil"}
 	}
 
-	// Normalize fields first
+	// Normalize fields first, keeping what the client sent for the warnings
+	submitted := proto.Clone(req.Driver).(*genproto.DriverInput)
 	NormalizeDriverFields(req.Driver)
 
 	driver := req.Driver
 
 	// If update mask is provided, only validate specified fields
 	if req.UpdateMask != nil {
-		return validateMaskedDriverFields(driver, req.UpdateMask)
+		if err := validateMaskedDriverFields(driver, req.UpdateMask, clk); err != nil {
+			return nil, err
+		}
+	} else if err := validateAllProvidedDriverFields(driver, clk); err != nil {
+		// If no mask, validate all non-empty fields
+		return nil, err
 	}
 
-	// If no mask, validate all non-empty fields
-	return validateAllProvidedDriverFields(driver)
+	return driverNormalizationWarnings(submitted, driver, req.UpdateMask), nil
+}
+
+// driverNormalizationWarnings compares the text fields NormalizeDriverFields rewrites.
+// Empty values and fields outside the update mask are skipped since nothing is stored for them.
+func driverNormalizationWarnings(submitted, normalized *genproto.DriverInput, mask *fieldmaskpb.FieldMask) []*genproto.NormalizationWarning {
+	fields := []struct {
+		name, submitted, stored string
+	}{
+		{"user_id", submitted.UserId, normalized.UserId},
+		{"license_number", submitted.LicenseNumber, normalized.LicenseNumber},
+		{"phone_number", submitted.PhoneNumber, normalized.PhoneNumber},
+		{"emergency_contact_name", submitted.EmergencyContactName, normalized.EmergencyContactName},
+		{"emergency_contact_phone", submitted.EmergencyContactPhone, normalized.EmergencyContactPhone},
+	}
+
+	warnings := []*genproto.NormalizationWarning{}
+	for _, f := range fields {
+		if f.submitted == "" || f.submitted == f.stored {
+			continue
+		}
+		if mask != nil && !slices.Contains(mask.Paths, f.name) {
+			continue
+		}
+		warnings = append(warnings, &genproto.NormalizationWarning{
+			Field:     f.name,
+			Submitted: f.submitted,
+			Stored:    f.stored,
+		})
+	}
+	return warnings
 }
 
-// validateMaskedDriverFields validates only fields specified in the update mask
-func validateMaskedDriverFields(driver *genproto.DriverInput, mask *fieldmaskpb.FieldMask) error {
+// validateMaskedDriverFields validates only fields specified in the update mask.
+// A masked field is always written, so required fields must not be empty;
+// hire_date is the only nullable column and may be cleared by omitting it.
+func validateMaskedDriverFields(driver *genproto.DriverInput, mask *fieldmaskpb.FieldMask, clk clock.Clock) error {
 	for _, path := range mask.Paths {
 		switch path {
 		case "user_id":
-			if driver.UserId != "" {
-				if err := ValidateUserID("user_id", driver.UserId); err != nil {
-					return err
-				}
+			if err := ValidateUserID("user_id", driver.UserId); err != nil {
+				return err
 			}
 		case "license_number":
-			if driver.LicenseNumber != "" {
-				if err := ValidateKenyanLicense("license_number", driver.LicenseNumber); err != nil {
-					return err
-				}
+			if err := ValidateKenyanLicense("license_number", driver.LicenseNumber); err != nil {
+				return err
 			}
 		case "license_class":
-			if driver.LicenseClass != genproto.LicenseClass_LICENSE_UNSPECIFIED {
-				if err := ValidateLicenseClass("license_class", driver.LicenseClass); err != nil {
-					return err
-				}
+			if err := ValidateLicenseClass("license_class", driver.LicenseClass); err != nil {
+				return err
 			}
 		case "license_expiry":
-			if driver.LicenseExpiry != nil {
-				if err := ValidateLicenseExpiry("license_expiry", driver.LicenseExpiry.AsTime()); err != nil {
-					return err
-				}
+			if driver.LicenseExpiry == nil {
+				return ValidationError{Field: "license_expiry", Message: "cannot be cleared"}
+			}
+			if err := ValidateRequiredTimestamp("license_expiry", driver.LicenseExpiry); err != nil {
+				return err
+			}
+			if err := ValidateLicenseExpiry("license_expiry", driver.LicenseExpiry.AsTime(), clk); err != nil {
+				return err
 			}
 		case "experience_years":
 			if err := ValidateExperienceYears("experience_years", driver.ExperienceYears); err != nil {
 				return err
 			}
 		case "phone_number":
-			if driver.PhoneNumber != "" {
-				if err := ValidatePhoneNumber("phone_number", driver.PhoneNumber); err != nil {
-					return err
-				}
+			if err := ValidatePhoneNumber("phone_number", driver.PhoneNumber); err != nil {
+				return err
 			}
 		case "emergency_contact_name":
-			if driver.EmergencyContactName != "" {
-				if err := ValidateEmergencyContact("emergency_contact_name", "emergency_contact_phone", 
-					driver.EmergencyContactName, driver.EmergencyContactPhone); err != nil {
-					return err
-				}
+			if driver.EmergencyContactName == "" {
+				return ValidationError{Field: "emergency_contact_name", Message: "cannot be cleared"}
+			}
+			if err := ValidateEmergencyContact("emergency_contact_name", "emergency_contact_phone", 
+				driver.EmergencyContactName, driver.EmergencyContactPhone); err != nil {
+				return err
 			}
 		case "emergency_contact_phone":
-			if driver.EmergencyContactPhone != "" {
-				if err := ValidatePhoneNumber("emergency_contact_phone", driver.EmergencyContactPhone); err != nil {
-					return err
-				}
+			if err := ValidatePhoneNumber("emergency_contact_phone", driver.EmergencyContactPhone); err != nil {
+				return err
 			}
 		case "hire_date":
+			// Optional column - omitting the timestamp clears it
 			if driver.HireDate != nil {
-				if err := ValidateHireDate("hire_date", driver.HireDate.AsTime()); err != nil {
+				if err := ValidateHireDate("hire_date", driver.HireDate.AsTime(), clk); err != nil {
 					return err
 				}
 			}
@@ -481,7 +663,7 @@ func validateMaskedDriverFields(driver *genproto.DriverInput, mask *fieldmaskpb.
 }
 
 // validateAllProvidedDriverFields validates all non-empty fields
-func validateAllProvidedDriverFields(driver *genproto.DriverInput) error {
+func validateAllProvidedDriverFields(driver *genproto.DriverInput, clk clock.Clock) error {
 	if driver.UserId != "" {
 		if err := ValidateUserID("user_id", driver.UserId); err != nil {
 			return err
@@ -501,7 +683,10 @@ func validateAllProvidedDriverFields(driver *genproto.DriverInput) error {
 	}
 
 	if driver.LicenseExpiry != nil {
-		if err := ValidateLicenseExpiry("license_expiry", driver.LicenseExpiry.AsTime()); err != nil {
+		if err := ValidateRequiredTimestamp("license_expiry", driver.LicenseExpiry); err != nil {
+			return err
+		}
+		if err := ValidateLicenseExpiry("license_expiry", driver.LicenseExpiry.AsTime(), clk); err != nil {
 			return err
 		}
 	}
@@ -524,7 +709,7 @@ func validateAllProvidedDriverFields(driver *genproto.DriverInput) error {
 	}
 
 	if driver.HireDate != nil {
-		if err := ValidateHireDate("hire_date", driver.HireDate.AsTime()); err != nil {
+		if err := ValidateHireDate("hire_date", driver.HireDate.AsTime(), clk); err != nil {
 			return err
 		}
 	}
@@ -533,7 +718,7 @@ func validateAllProvidedDriverFields(driver *genproto.DriverInput) error {
 }
 
 // ValidateAddCertificationRequest validates certification addition request
-func ValidateAddCertificationRequest(req *genproto.AddDriverCertificationRequest) error {
+func ValidateAddCertificationRequest(req *genproto.AddDriverCertificationRequest, clk clock.Clock) error {
 	if req == nil {
 		return ValidationError{Field: "request", Message: "cannot be nil"}
 	}
@@ -557,19 +742,19 @@ func ValidateAddCertificationRequest(req *genproto.AddDriverCertificationRequest
 	}
 
 	// Validate dates
-	if cert.IssueDate == nil {
-		return ValidationError{Field: "issue_date", Message: "is required"}
+	if err := ValidateRequiredTimestamp("issue_date", cert.IssueDate); err != nil {
+		return err
 	}
 
-	if cert.ExpiryDate == nil {
-		return ValidationError{Field: "expiry_date", Message: "is required"}
+	if err := ValidateRequiredTimestamp("expiry_date", cert.ExpiryDate); err != nil {
+		return err
 	}
 
 	issueDate := cert.IssueDate.AsTime()
 	expiryDate := cert.ExpiryDate.AsTime()
 
 	// Issue date cannot be in the future
-	if issueDate.After(time.Now()) {
+	if issueDate.After(clk.Now()) {
 		return ValidationError{
 			Field:   "issue_date",
 			Message: "cannot be in the future",
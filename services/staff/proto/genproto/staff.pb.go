@@ -197,24 +197,30 @@ func (CertificationStatus) EnumDescriptor() ([]byte, []int) {
 
 // ================= Core Driver Messages =================
 type Driver struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // external_id
-	UserId                string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // reference to user service
-	LicenseNumber         string                 `protobuf:"bytes,3,opt,name=license_number,json=licenseNumber,proto3" json:"license_number,omitempty"`
-	LicenseClass          LicenseClass           `protobuf:"varint,4,opt,name=license_class,json=licenseClass,proto3,enum=staff.LicenseClass" json:"license_class,omitempty"`
-	LicenseExpiry         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=license_expiry,json=licenseExpiry,proto3" json:"license_expiry,omitempty"`
-	ExperienceYears       int32                  `protobuf:"varint,6,opt,name=experience_years,json=experienceYears,proto3" json:"experience_years,omitempty"`
-	PhoneNumber           string                 `protobuf:"bytes,7,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
-	EmergencyContactName  string                 `protobuf:"bytes,8,opt,name=emergency_contact_name,json=emergencyContactName,proto3" json:"emergency_contact_name,omitempty"`
-	EmergencyContactPhone string                 `protobuf:"bytes,9,opt,name=emergency_contact_phone,json=emergencyContactPhone,proto3" json:"emergency_contact_phone,omitempty"`
-	Status                DriverStatus           `protobuf:"varint,10,opt,name=status,proto3,enum=staff.DriverStatus" json:"status,omitempty"`
-	HireDate              *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=hire_date,json=hireDate,proto3" json:"hire_date,omitempty"`
-	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // external_id
+	UserId                 string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // reference to user service
+	LicenseNumber          string                 `protobuf:"bytes,3,opt,name=license_number,json=licenseNumber,proto3" json:"license_number,omitempty"`
+	LicenseClass           LicenseClass           `protobuf:"varint,4,opt,name=license_class,json=licenseClass,proto3,enum=staff.LicenseClass" json:"license_class,omitempty"`
+	LicenseExpiry          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=license_expiry,json=licenseExpiry,proto3" json:"license_expiry,omitempty"`
+	ExperienceYears        int32                  `protobuf:"varint,6,opt,name=experience_years,json=experienceYears,proto3" json:"experience_years,omitempty"`
+	PhoneNumber            string                 `protobuf:"bytes,7,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	EmergencyContactName   string                 `protobuf:"bytes,8,opt,name=emergency_contact_name,json=emergencyContactName,proto3" json:"emergency_contact_name,omitempty"`
+	EmergencyContactPhone  string                 `protobuf:"bytes,9,opt,name=emergency_contact_phone,json=emergencyContactPhone,proto3" json:"emergency_contact_phone,omitempty"`
+	Status                 DriverStatus           `protobuf:"varint,10,opt,name=status,proto3,enum=staff.DriverStatus" json:"status,omitempty"`
+	HireDate               *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=hire_date,json=hireDate,proto3" json:"hire_date,omitempty"`
+	CreatedAt              *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt              *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	UpdatedBy              *string                `protobuf:"bytes,17,opt,name=updated_by,json=updatedBy,proto3,oneof" json:"updated_by,omitempty"`         // user ID of the last editor, or "system"
+	RatingAverage          float64                `protobuf:"fixed64,18,opt,name=rating_average,json=ratingAverage,proto3" json:"rating_average,omitempty"` // 0-5, maintained by UpdateDriverRating
+	RatingCount            int32                  `protobuf:"varint,19,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	HandbookVersion        string                 `protobuf:"bytes,20,opt,name=handbook_version,json=handbookVersion,proto3" json:"handbook_version,omitempty"` // last operating handbook version acknowledged, empty if never
+	HandbookAcknowledgedAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=handbook_acknowledged_at,json=handbookAcknowledgedAt,proto3,oneof" json:"handbook_acknowledged_at,omitempty"`
 	// Computed fields for convenience
 	LicenseExpired         bool                   `protobuf:"varint,14,opt,name=license_expired,json=licenseExpired,proto3" json:"license_expired,omitempty"`
 	DaysUntilLicenseExpiry int32                  `protobuf:"varint,15,opt,name=days_until_license_expiry,json=daysUntilLicenseExpiry,proto3" json:"days_until_license_expiry,omitempty"`
 	Certifications         []*DriverCertification `protobuf:"bytes,16,rep,name=certifications,proto3" json:"certifications,omitempty"`
+	HandbookCurrent        bool                   `protobuf:"varint,22,opt,name=handbook_current,json=handbookCurrent,proto3" json:"handbook_current,omitempty"` // acknowledged the handbook version currently required
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -340,6 +346,41 @@ func (x *Driver) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Driver) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Driver) GetRatingAverage() float64 {
+	if x != nil {
+		return x.RatingAverage
+	}
+	return 0
+}
+
+func (x *Driver) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Driver) GetHandbookVersion() string {
+	if x != nil {
+		return x.HandbookVersion
+	}
+	return ""
+}
+
+func (x *Driver) GetHandbookAcknowledgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HandbookAcknowledgedAt
+	}
+	return nil
+}
+
 func (x *Driver) GetLicenseExpired() bool {
 	if x != nil {
 		return x.LicenseExpired
@@ -361,6 +402,13 @@ func (x *Driver) GetCertifications() []*DriverCertification {
 	return nil
 }
 
+func (x *Driver) GetHandbookCurrent() bool {
+	if x != nil {
+		return x.HandbookCurrent
+	}
+	return false
+}
+
 type DriverInput struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	UserId                string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -689,6 +737,95 @@ func (x *GetDriverResponse) GetDriver() *Driver {
 	return nil
 }
 
+type GetDriversByUserIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriversByUserIDsRequest) Reset() {
+	*x = GetDriversByUserIDsRequest{}
+	mi := &file_staff_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriversByUserIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriversByUserIDsRequest) ProtoMessage() {}
+
+func (x *GetDriversByUserIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriversByUserIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetDriversByUserIDsRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetDriversByUserIDsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// Users without a driver profile are absent from the map
+type GetDriversByUserIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drivers       map[string]*Driver     `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // keyed by user_id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriversByUserIDsResponse) Reset() {
+	*x = GetDriversByUserIDsResponse{}
+	mi := &file_staff_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriversByUserIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriversByUserIDsResponse) ProtoMessage() {}
+
+func (x *GetDriversByUserIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriversByUserIDsResponse.ProtoReflect.Descriptor instead.
+func (*GetDriversByUserIDsResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDriversByUserIDsResponse) GetDrivers() map[string]*Driver {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
 type ListDriversRequest struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	PageSize            int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
@@ -696,13 +833,14 @@ type ListDriversRequest struct {
 	StatusFilter        *DriverStatus          `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=staff.DriverStatus,oneof" json:"status_filter,omitempty"`
 	LicenseClassFilter  *LicenseClass          `protobuf:"varint,4,opt,name=license_class_filter,json=licenseClassFilter,proto3,enum=staff.LicenseClass,oneof" json:"license_class_filter,omitempty"`
 	LicenseExpiringSoon *bool                  `protobuf:"varint,5,opt,name=license_expiring_soon,json=licenseExpiringSoon,proto3,oneof" json:"license_expiring_soon,omitempty"` // Within 30 days
+	OrderBy             string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`                                              // "created_at" (default, newest first) or "license_expiry" (soonest first)
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
 func (x *ListDriversRequest) Reset() {
 	*x = ListDriversRequest{}
-	mi := &file_staff_proto_msgTypes[7]
+	mi := &file_staff_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -714,7 +852,7 @@ func (x *ListDriversRequest) String() string {
 func (*ListDriversRequest) ProtoMessage() {}
 
 func (x *ListDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[7]
+	mi := &file_staff_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,7 +865,7 @@ func (x *ListDriversRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDriversRequest.ProtoReflect.Descriptor instead.
 func (*ListDriversRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{7}
+	return file_staff_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListDriversRequest) GetPageSize() int32 {
@@ -765,18 +903,26 @@ func (x *ListDriversRequest) GetLicenseExpiringSoon() bool {
 	return false
 }
 
+func (x *ListDriversRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
 type ListDriversResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Drivers       []*Driver              `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty"`
 	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // drivers matching the filters across all pages
+	PrevPageToken string                 `protobuf:"bytes,4,opt,name=prev_page_token,json=prevPageToken,proto3" json:"prev_page_token,omitempty"` // set by ListDrivers past the first page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListDriversResponse) Reset() {
 	*x = ListDriversResponse{}
-	mi := &file_staff_proto_msgTypes[8]
+	mi := &file_staff_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -788,7 +934,7 @@ func (x *ListDriversResponse) String() string {
 func (*ListDriversResponse) ProtoMessage() {}
 
 func (x *ListDriversResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[8]
+	mi := &file_staff_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,7 +947,7 @@ func (x *ListDriversResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDriversResponse.ProtoReflect.Descriptor instead.
 func (*ListDriversResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{8}
+	return file_staff_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListDriversResponse) GetDrivers() []*Driver {
@@ -825,18 +971,25 @@ func (x *ListDriversResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *ListDriversResponse) GetPrevPageToken() string {
+	if x != nil {
+		return x.PrevPageToken
+	}
+	return ""
+}
+
 type UpdateDriverRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
 	Driver        *DriverInput           `protobuf:"bytes,2,opt,name=driver,proto3" json:"driver,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // optional fields masked but left empty are cleared
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateDriverRequest) Reset() {
 	*x = UpdateDriverRequest{}
-	mi := &file_staff_proto_msgTypes[9]
+	mi := &file_staff_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -848,7 +1001,7 @@ func (x *UpdateDriverRequest) String() string {
 func (*UpdateDriverRequest) ProtoMessage() {}
 
 func (x *UpdateDriverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[9]
+	mi := &file_staff_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -861,7 +1014,7 @@ func (x *UpdateDriverRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDriverRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{9}
+	return file_staff_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateDriverRequest) GetDriverId() string {
@@ -885,16 +1038,19 @@ func (x *UpdateDriverRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	return nil
 }
 
+// normalization_warnings lists submitted values that were stored in a different
+// form (e.g. a phone number rewritten to 2547XXXXXXXX) so clients can resync.
 type UpdateDriverResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState  `protogen:"open.v1"`
+	Driver                *Driver                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	NormalizationWarnings []*NormalizationWarning `protobuf:"bytes,2,rep,name=normalization_warnings,json=normalizationWarnings,proto3" json:"normalization_warnings,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *UpdateDriverResponse) Reset() {
 	*x = UpdateDriverResponse{}
-	mi := &file_staff_proto_msgTypes[10]
+	mi := &file_staff_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -906,7 +1062,7 @@ func (x *UpdateDriverResponse) String() string {
 func (*UpdateDriverResponse) ProtoMessage() {}
 
 func (x *UpdateDriverResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[10]
+	mi := &file_staff_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,7 +1075,7 @@ func (x *UpdateDriverResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDriverResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{10}
+	return file_staff_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateDriverResponse) GetDriver() *Driver {
@@ -929,6 +1085,73 @@ func (x *UpdateDriverResponse) GetDriver() *Driver {
 	return nil
 }
 
+func (x *UpdateDriverResponse) GetNormalizationWarnings() []*NormalizationWarning {
+	if x != nil {
+		return x.NormalizationWarnings
+	}
+	return nil
+}
+
+type NormalizationWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`         // proto field name, e.g. phone_number
+	Submitted     string                 `protobuf:"bytes,2,opt,name=submitted,proto3" json:"submitted,omitempty"` // value as sent by the client
+	Stored        string                 `protobuf:"bytes,3,opt,name=stored,proto3" json:"stored,omitempty"`       // value after normalization
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NormalizationWarning) Reset() {
+	*x = NormalizationWarning{}
+	mi := &file_staff_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NormalizationWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NormalizationWarning) ProtoMessage() {}
+
+func (x *NormalizationWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NormalizationWarning.ProtoReflect.Descriptor instead.
+func (*NormalizationWarning) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NormalizationWarning) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *NormalizationWarning) GetSubmitted() string {
+	if x != nil {
+		return x.Submitted
+	}
+	return ""
+}
+
+func (x *NormalizationWarning) GetStored() string {
+	if x != nil {
+		return x.Stored
+	}
+	return ""
+}
+
 type DeleteDriverRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
@@ -938,7 +1161,7 @@ type DeleteDriverRequest struct {
 
 func (x *DeleteDriverRequest) Reset() {
 	*x = DeleteDriverRequest{}
-	mi := &file_staff_proto_msgTypes[11]
+	mi := &file_staff_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -950,7 +1173,7 @@ func (x *DeleteDriverRequest) String() string {
 func (*DeleteDriverRequest) ProtoMessage() {}
 
 func (x *DeleteDriverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[11]
+	mi := &file_staff_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +1186,7 @@ func (x *DeleteDriverRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteDriverRequest.ProtoReflect.Descriptor instead.
 func (*DeleteDriverRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{11}
+	return file_staff_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteDriverRequest) GetDriverId() string {
@@ -973,30 +1196,32 @@ func (x *DeleteDriverRequest) GetDriverId() string {
 	return ""
 }
 
-type UpdateDriverStatusRequest struct {
+// Permanently removes a driver with their certifications and history. Unlike
+// DeleteDriver this can't be undone, and ACTIVE drivers are refused.
+// Brings a soft deleted (INACTIVE) driver back as PENDING_VERIFICATION, so they
+// are vetted again before driving. Refused while the license is expired.
+type RestoreDriverRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	Status        DriverStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=staff.DriverStatus" json:"status,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Optional reason for status change
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDriverStatusRequest) Reset() {
-	*x = UpdateDriverStatusRequest{}
-	mi := &file_staff_proto_msgTypes[12]
+func (x *RestoreDriverRequest) Reset() {
+	*x = RestoreDriverRequest{}
+	mi := &file_staff_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDriverStatusRequest) String() string {
+func (x *RestoreDriverRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDriverStatusRequest) ProtoMessage() {}
+func (*RestoreDriverRequest) ProtoMessage() {}
 
-func (x *UpdateDriverStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[12]
+func (x *RestoreDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1007,54 +1232,40 @@ func (x *UpdateDriverStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDriverStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDriverStatusRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use RestoreDriverRequest.ProtoReflect.Descriptor instead.
+func (*RestoreDriverRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *UpdateDriverStatusRequest) GetDriverId() string {
+func (x *RestoreDriverRequest) GetDriverId() string {
 	if x != nil {
 		return x.DriverId
 	}
 	return ""
 }
 
-func (x *UpdateDriverStatusRequest) GetStatus() DriverStatus {
-	if x != nil {
-		return x.Status
-	}
-	return DriverStatus_STATUS_UNSPECIFIED
-}
-
-func (x *UpdateDriverStatusRequest) GetReason() string {
-	if x != nil {
-		return x.Reason
-	}
-	return ""
-}
-
-type UpdateDriverStatusResponse struct {
+type RestoreDriverResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateDriverStatusResponse) Reset() {
-	*x = UpdateDriverStatusResponse{}
-	mi := &file_staff_proto_msgTypes[13]
+func (x *RestoreDriverResponse) Reset() {
+	*x = RestoreDriverResponse{}
+	mi := &file_staff_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDriverStatusResponse) String() string {
+func (x *RestoreDriverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDriverStatusResponse) ProtoMessage() {}
+func (*RestoreDriverResponse) ProtoMessage() {}
 
-func (x *UpdateDriverStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[13]
+func (x *RestoreDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1065,42 +1276,40 @@ func (x *UpdateDriverStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDriverStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateDriverStatusResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use RestoreDriverResponse.ProtoReflect.Descriptor instead.
+func (*RestoreDriverResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *UpdateDriverStatusResponse) GetDriver() *Driver {
+func (x *RestoreDriverResponse) GetDriver() *Driver {
 	if x != nil {
 		return x.Driver
 	}
 	return nil
 }
 
-type GetActiveDriversRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	PageSize           int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken          string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	LicenseClassFilter *LicenseClass          `protobuf:"varint,3,opt,name=license_class_filter,json=licenseClassFilter,proto3,enum=staff.LicenseClass,oneof" json:"license_class_filter,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type HardDeleteDriverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetActiveDriversRequest) Reset() {
-	*x = GetActiveDriversRequest{}
-	mi := &file_staff_proto_msgTypes[14]
+func (x *HardDeleteDriverRequest) Reset() {
+	*x = HardDeleteDriverRequest{}
+	mi := &file_staff_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetActiveDriversRequest) String() string {
+func (x *HardDeleteDriverRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetActiveDriversRequest) ProtoMessage() {}
+func (*HardDeleteDriverRequest) ProtoMessage() {}
 
-func (x *GetActiveDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[14]
+func (x *HardDeleteDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1111,66 +1320,41 @@ func (x *GetActiveDriversRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetActiveDriversRequest.ProtoReflect.Descriptor instead.
-func (*GetActiveDriversRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *GetActiveDriversRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+// Deprecated: Use HardDeleteDriverRequest.ProtoReflect.Descriptor instead.
+func (*HardDeleteDriverRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetActiveDriversRequest) GetPageToken() string {
+func (x *HardDeleteDriverRequest) GetDriverId() string {
 	if x != nil {
-		return x.PageToken
+		return x.DriverId
 	}
 	return ""
 }
 
-func (x *GetActiveDriversRequest) GetLicenseClassFilter() LicenseClass {
-	if x != nil && x.LicenseClassFilter != nil {
-		return *x.LicenseClassFilter
-	}
-	return LicenseClass_LICENSE_UNSPECIFIED
-}
-
-// ================= Driver Certification Messages =================
-type DriverCertification struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // certification ID
-	DriverId          string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	CertificationName string                 `protobuf:"bytes,3,opt,name=certification_name,json=certificationName,proto3" json:"certification_name,omitempty"`
-	IssuedBy          string                 `protobuf:"bytes,4,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
-	IssueDate         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issue_date,json=issueDate,proto3" json:"issue_date,omitempty"`
-	ExpiryDate        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
-	Status            CertificationStatus    `protobuf:"varint,7,opt,name=status,proto3,enum=staff.CertificationStatus" json:"status,omitempty"`
-	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
-	// Computed fields
-	IsExpired       bool  `protobuf:"varint,10,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
-	DaysUntilExpiry int32 `protobuf:"varint,11,opt,name=days_until_expiry,json=daysUntilExpiry,proto3" json:"days_until_expiry,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type HardDeleteDriverResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DriverId              string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	CertificationsDeleted int32                  `protobuf:"varint,2,opt,name=certifications_deleted,json=certificationsDeleted,proto3" json:"certifications_deleted,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
-func (x *DriverCertification) Reset() {
-	*x = DriverCertification{}
-	mi := &file_staff_proto_msgTypes[15]
+func (x *HardDeleteDriverResponse) Reset() {
+	*x = HardDeleteDriverResponse{}
+	mi := &file_staff_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DriverCertification) String() string {
+func (x *HardDeleteDriverResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DriverCertification) ProtoMessage() {}
+func (*HardDeleteDriverResponse) ProtoMessage() {}
 
-func (x *DriverCertification) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[15]
+func (x *HardDeleteDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1181,113 +1365,103 @@ func (x *DriverCertification) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DriverCertification.ProtoReflect.Descriptor instead.
-func (*DriverCertification) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *DriverCertification) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use HardDeleteDriverResponse.ProtoReflect.Descriptor instead.
+func (*HardDeleteDriverResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *DriverCertification) GetDriverId() string {
+func (x *HardDeleteDriverResponse) GetDriverId() string {
 	if x != nil {
 		return x.DriverId
 	}
 	return ""
 }
 
-func (x *DriverCertification) GetCertificationName() string {
+func (x *HardDeleteDriverResponse) GetCertificationsDeleted() int32 {
 	if x != nil {
-		return x.CertificationName
+		return x.CertificationsDeleted
 	}
-	return ""
+	return 0
 }
 
-func (x *DriverCertification) GetIssuedBy() string {
-	if x != nil {
-		return x.IssuedBy
-	}
-	return ""
+// Folds a duplicate driver record into the primary one. The primary keeps its
+// license and user_id; the duplicate's history moves over and it is soft deleted.
+type MergeDriversRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PrimaryDriverId   string                 `protobuf:"bytes,1,opt,name=primary_driver_id,json=primaryDriverId,proto3" json:"primary_driver_id,omitempty"`
+	DuplicateDriverId string                 `protobuf:"bytes,2,opt,name=duplicate_driver_id,json=duplicateDriverId,proto3" json:"duplicate_driver_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *DriverCertification) GetIssueDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.IssueDate
-	}
-	return nil
+func (x *MergeDriversRequest) Reset() {
+	*x = MergeDriversRequest{}
+	mi := &file_staff_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DriverCertification) GetExpiryDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.ExpiryDate
-	}
-	return nil
+func (x *MergeDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *DriverCertification) GetStatus() CertificationStatus {
-	if x != nil {
-		return x.Status
-	}
-	return CertificationStatus_CERT_STATUS_UNSPECIFIED
-}
+func (*MergeDriversRequest) ProtoMessage() {}
 
-func (x *DriverCertification) GetCreatedAt() *timestamppb.Timestamp {
+func (x *MergeDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[19]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *DriverCertification) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
+// Deprecated: Use MergeDriversRequest.ProtoReflect.Descriptor instead.
+func (*MergeDriversRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DriverCertification) GetIsExpired() bool {
+func (x *MergeDriversRequest) GetPrimaryDriverId() string {
 	if x != nil {
-		return x.IsExpired
+		return x.PrimaryDriverId
 	}
-	return false
+	return ""
 }
 
-func (x *DriverCertification) GetDaysUntilExpiry() int32 {
+func (x *MergeDriversRequest) GetDuplicateDriverId() string {
 	if x != nil {
-		return x.DaysUntilExpiry
+		return x.DuplicateDriverId
 	}
-	return 0
+	return ""
 }
 
-type CertificationInput struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	CertificationName string                 `protobuf:"bytes,1,opt,name=certification_name,json=certificationName,proto3" json:"certification_name,omitempty"`
-	IssuedBy          string                 `protobuf:"bytes,2,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
-	IssueDate         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issue_date,json=issueDate,proto3" json:"issue_date,omitempty"`
-	ExpiryDate        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type MergeDriversResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Driver              *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"` // the primary driver after the merge
+	CertificationsMoved int32                  `protobuf:"varint,2,opt,name=certifications_moved,json=certificationsMoved,proto3" json:"certifications_moved,omitempty"`
+	StatusHistoryMoved  int32                  `protobuf:"varint,3,opt,name=status_history_moved,json=statusHistoryMoved,proto3" json:"status_history_moved,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *CertificationInput) Reset() {
-	*x = CertificationInput{}
-	mi := &file_staff_proto_msgTypes[16]
+func (x *MergeDriversResponse) Reset() {
+	*x = MergeDriversResponse{}
+	mi := &file_staff_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CertificationInput) String() string {
+func (x *MergeDriversResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CertificationInput) ProtoMessage() {}
+func (*MergeDriversResponse) ProtoMessage() {}
 
-func (x *CertificationInput) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[16]
+func (x *MergeDriversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,62 +1472,56 @@ func (x *CertificationInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CertificationInput.ProtoReflect.Descriptor instead.
-func (*CertificationInput) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *CertificationInput) GetCertificationName() string {
-	if x != nil {
-		return x.CertificationName
-	}
-	return ""
+// Deprecated: Use MergeDriversResponse.ProtoReflect.Descriptor instead.
+func (*MergeDriversResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CertificationInput) GetIssuedBy() string {
+func (x *MergeDriversResponse) GetDriver() *Driver {
 	if x != nil {
-		return x.IssuedBy
+		return x.Driver
 	}
-	return ""
+	return nil
 }
 
-func (x *CertificationInput) GetIssueDate() *timestamppb.Timestamp {
+func (x *MergeDriversResponse) GetCertificationsMoved() int32 {
 	if x != nil {
-		return x.IssueDate
+		return x.CertificationsMoved
 	}
-	return nil
+	return 0
 }
 
-func (x *CertificationInput) GetExpiryDate() *timestamppb.Timestamp {
+func (x *MergeDriversResponse) GetStatusHistoryMoved() int32 {
 	if x != nil {
-		return x.ExpiryDate
+		return x.StatusHistoryMoved
 	}
-	return nil
+	return 0
 }
 
-type AddDriverCertificationRequest struct {
+// Folds one new trip rating into the driver's running average
+type UpdateDriverRatingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	Certification *CertificationInput    `protobuf:"bytes,2,opt,name=certification,proto3" json:"certification,omitempty"`
+	Rating        float64                `protobuf:"fixed64,2,opt,name=rating,proto3" json:"rating,omitempty"` // 0-5
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddDriverCertificationRequest) Reset() {
-	*x = AddDriverCertificationRequest{}
-	mi := &file_staff_proto_msgTypes[17]
+func (x *UpdateDriverRatingRequest) Reset() {
+	*x = UpdateDriverRatingRequest{}
+	mi := &file_staff_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddDriverCertificationRequest) String() string {
+func (x *UpdateDriverRatingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddDriverCertificationRequest) ProtoMessage() {}
+func (*UpdateDriverRatingRequest) ProtoMessage() {}
 
-func (x *AddDriverCertificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[17]
+func (x *UpdateDriverRatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,47 +1532,47 @@ func (x *AddDriverCertificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddDriverCertificationRequest.ProtoReflect.Descriptor instead.
-func (*AddDriverCertificationRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use UpdateDriverRatingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDriverRatingRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *AddDriverCertificationRequest) GetDriverId() string {
+func (x *UpdateDriverRatingRequest) GetDriverId() string {
 	if x != nil {
 		return x.DriverId
 	}
 	return ""
 }
 
-func (x *AddDriverCertificationRequest) GetCertification() *CertificationInput {
+func (x *UpdateDriverRatingRequest) GetRating() float64 {
 	if x != nil {
-		return x.Certification
+		return x.Rating
 	}
-	return nil
+	return 0
 }
 
-type AddDriverCertificationResponse struct {
+type UpdateDriverRatingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Certification *DriverCertification   `protobuf:"bytes,1,opt,name=certification,proto3" json:"certification,omitempty"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddDriverCertificationResponse) Reset() {
-	*x = AddDriverCertificationResponse{}
-	mi := &file_staff_proto_msgTypes[18]
+func (x *UpdateDriverRatingResponse) Reset() {
+	*x = UpdateDriverRatingResponse{}
+	mi := &file_staff_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddDriverCertificationResponse) String() string {
+func (x *UpdateDriverRatingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddDriverCertificationResponse) ProtoMessage() {}
+func (*UpdateDriverRatingResponse) ProtoMessage() {}
 
-func (x *AddDriverCertificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[18]
+func (x *UpdateDriverRatingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1415,44 +1583,41 @@ func (x *AddDriverCertificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddDriverCertificationResponse.ProtoReflect.Descriptor instead.
-func (*AddDriverCertificationResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use UpdateDriverRatingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDriverRatingResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *AddDriverCertificationResponse) GetCertification() *DriverCertification {
+func (x *UpdateDriverRatingResponse) GetDriver() *Driver {
 	if x != nil {
-		return x.Certification
+		return x.Driver
 	}
 	return nil
 }
 
-type ListDriverCertificationsRequest struct {
+type AcknowledgeHandbookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	StatusFilter  *CertificationStatus   `protobuf:"varint,4,opt,name=status_filter,json=statusFilter,proto3,enum=staff.CertificationStatus,oneof" json:"status_filter,omitempty"`
-	ExpiringSoon  *bool                  `protobuf:"varint,5,opt,name=expiring_soon,json=expiringSoon,proto3,oneof" json:"expiring_soon,omitempty"` // Within 30 days
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"` // must match the current handbook version when one is configured
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDriverCertificationsRequest) Reset() {
-	*x = ListDriverCertificationsRequest{}
-	mi := &file_staff_proto_msgTypes[19]
+func (x *AcknowledgeHandbookRequest) Reset() {
+	*x = AcknowledgeHandbookRequest{}
+	mi := &file_staff_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDriverCertificationsRequest) String() string {
+func (x *AcknowledgeHandbookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDriverCertificationsRequest) ProtoMessage() {}
+func (*AcknowledgeHandbookRequest) ProtoMessage() {}
 
-func (x *ListDriverCertificationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[19]
+func (x *AcknowledgeHandbookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1463,69 +1628,1758 @@ func (x *ListDriverCertificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDriverCertificationsRequest.ProtoReflect.Descriptor instead.
-func (*ListDriverCertificationsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use AcknowledgeHandbookRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeHandbookRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ListDriverCertificationsRequest) GetDriverId() string {
+func (x *AcknowledgeHandbookRequest) GetDriverId() string {
 	if x != nil {
 		return x.DriverId
 	}
 	return ""
 }
 
-func (x *ListDriverCertificationsRequest) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
-}
-
-func (x *ListDriverCertificationsRequest) GetPageToken() string {
+func (x *AcknowledgeHandbookRequest) GetVersion() string {
 	if x != nil {
-		return x.PageToken
+		return x.Version
 	}
 	return ""
 }
 
-func (x *ListDriverCertificationsRequest) GetStatusFilter() CertificationStatus {
-	if x != nil && x.StatusFilter != nil {
-		return *x.StatusFilter
-	}
-	return CertificationStatus_CERT_STATUS_UNSPECIFIED
-}
-
-func (x *ListDriverCertificationsRequest) GetExpiringSoon() bool {
-	if x != nil && x.ExpiringSoon != nil {
-		return *x.ExpiringSoon
-	}
-	return false
-}
-
-type ListDriverCertificationsResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Certifications []*DriverCertification `protobuf:"bytes,1,rep,name=certifications,proto3" json:"certifications,omitempty"`
-	NextPageToken  string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type AcknowledgeHandbookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDriverCertificationsResponse) Reset() {
-	*x = ListDriverCertificationsResponse{}
-	mi := &file_staff_proto_msgTypes[20]
+func (x *AcknowledgeHandbookResponse) Reset() {
+	*x = AcknowledgeHandbookResponse{}
+	mi := &file_staff_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDriverCertificationsResponse) String() string {
+func (x *AcknowledgeHandbookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDriverCertificationsResponse) ProtoMessage() {}
+func (*AcknowledgeHandbookResponse) ProtoMessage() {}
+
+func (x *AcknowledgeHandbookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeHandbookResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeHandbookResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AcknowledgeHandbookResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+type UpdateDriverStatusRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Status   DriverStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=staff.DriverStatus" json:"status,omitempty"`
+	// Reason for the status change, required (at least 10 characters) when moving to
+	// SUSPENDED or INACTIVE; LICENSE_EXPIRED marks an expiry suspension
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDriverStatusRequest) Reset() {
+	*x = UpdateDriverStatusRequest{}
+	mi := &file_staff_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDriverStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDriverStatusRequest) ProtoMessage() {}
+
+func (x *UpdateDriverStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDriverStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDriverStatusRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateDriverStatusRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *UpdateDriverStatusRequest) GetStatus() DriverStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DriverStatus_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateDriverStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateDriverStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDriverStatusResponse) Reset() {
+	*x = UpdateDriverStatusResponse{}
+	mi := &file_staff_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDriverStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDriverStatusResponse) ProtoMessage() {}
+
+func (x *UpdateDriverStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDriverStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDriverStatusResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateDriverStatusResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+// Records a renewed license. A driver SUSPENDED with reason LICENSE_EXPIRED
+// is moved back to ACTIVE in the same update.
+type RenewDriverLicenseRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DriverId         string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	NewExpiry        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=new_expiry,json=newExpiry,proto3" json:"new_expiry,omitempty"`                              // must be in the future
+	NewLicenseNumber *string                `protobuf:"bytes,3,opt,name=new_license_number,json=newLicenseNumber,proto3,oneof" json:"new_license_number,omitempty"` // set when the renewal issued a new number
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RenewDriverLicenseRequest) Reset() {
+	*x = RenewDriverLicenseRequest{}
+	mi := &file_staff_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewDriverLicenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewDriverLicenseRequest) ProtoMessage() {}
+
+func (x *RenewDriverLicenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewDriverLicenseRequest.ProtoReflect.Descriptor instead.
+func (*RenewDriverLicenseRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RenewDriverLicenseRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RenewDriverLicenseRequest) GetNewExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NewExpiry
+	}
+	return nil
+}
+
+func (x *RenewDriverLicenseRequest) GetNewLicenseNumber() string {
+	if x != nil && x.NewLicenseNumber != nil {
+		return *x.NewLicenseNumber
+	}
+	return ""
+}
+
+type RenewDriverLicenseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	Reactivated   bool                   `protobuf:"varint,2,opt,name=reactivated,proto3" json:"reactivated,omitempty"` // true when the driver was moved from SUSPENDED to ACTIVE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewDriverLicenseResponse) Reset() {
+	*x = RenewDriverLicenseResponse{}
+	mi := &file_staff_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewDriverLicenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewDriverLicenseResponse) ProtoMessage() {}
+
+func (x *RenewDriverLicenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewDriverLicenseResponse.ProtoReflect.Descriptor instead.
+func (*RenewDriverLicenseResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RenewDriverLicenseResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+func (x *RenewDriverLicenseResponse) GetReactivated() bool {
+	if x != nil {
+		return x.Reactivated
+	}
+	return false
+}
+
+// One status transition. A previous_status of STATUS_UNSPECIFIED marks a
+// transition out of an unrecognized status.
+type DriverStatusHistoryEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DriverId       string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	PreviousStatus DriverStatus           `protobuf:"varint,3,opt,name=previous_status,json=previousStatus,proto3,enum=staff.DriverStatus" json:"previous_status,omitempty"`
+	NewStatus      DriverStatus           `protobuf:"varint,4,opt,name=new_status,json=newStatus,proto3,enum=staff.DriverStatus" json:"new_status,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedBy      string                 `protobuf:"bytes,6,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"` // user ID, or "system"
+	ChangedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DriverStatusHistoryEntry) Reset() {
+	*x = DriverStatusHistoryEntry{}
+	mi := &file_staff_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverStatusHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverStatusHistoryEntry) ProtoMessage() {}
+
+func (x *DriverStatusHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverStatusHistoryEntry.ProtoReflect.Descriptor instead.
+func (*DriverStatusHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DriverStatusHistoryEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DriverStatusHistoryEntry) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverStatusHistoryEntry) GetPreviousStatus() DriverStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return DriverStatus_STATUS_UNSPECIFIED
+}
+
+func (x *DriverStatusHistoryEntry) GetNewStatus() DriverStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return DriverStatus_STATUS_UNSPECIFIED
+}
+
+func (x *DriverStatusHistoryEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DriverStatusHistoryEntry) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *DriverStatusHistoryEntry) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+// Status transitions of a driver, most recent first
+type ListDriverStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriverStatusHistoryRequest) Reset() {
+	*x = ListDriverStatusHistoryRequest{}
+	mi := &file_staff_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriverStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriverStatusHistoryRequest) ProtoMessage() {}
+
+func (x *ListDriverStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriverStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListDriverStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListDriverStatusHistoryRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ListDriverStatusHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDriverStatusHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDriverStatusHistoryResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Entries       []*DriverStatusHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriverStatusHistoryResponse) Reset() {
+	*x = ListDriverStatusHistoryResponse{}
+	mi := &file_staff_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriverStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriverStatusHistoryResponse) ProtoMessage() {}
+
+func (x *ListDriverStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriverStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListDriverStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListDriverStatusHistoryResponse) GetEntries() []*DriverStatusHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListDriverStatusHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Dry run of UpdateDriverStatus: reports whether the change would be allowed
+// without making it
+type ValidateDriverStatusChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Status        DriverStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=staff.DriverStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateDriverStatusChangeRequest) Reset() {
+	*x = ValidateDriverStatusChangeRequest{}
+	mi := &file_staff_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateDriverStatusChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateDriverStatusChangeRequest) ProtoMessage() {}
+
+func (x *ValidateDriverStatusChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateDriverStatusChangeRequest.ProtoReflect.Descriptor instead.
+func (*ValidateDriverStatusChangeRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ValidateDriverStatusChangeRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ValidateDriverStatusChangeRequest) GetStatus() DriverStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DriverStatus_STATUS_UNSPECIFIED
+}
+
+type ValidateDriverStatusChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"` // why the change is blocked; empty when allowed
+	CurrentStatus DriverStatus           `protobuf:"varint,3,opt,name=current_status,json=currentStatus,proto3,enum=staff.DriverStatus" json:"current_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateDriverStatusChangeResponse) Reset() {
+	*x = ValidateDriverStatusChangeResponse{}
+	mi := &file_staff_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateDriverStatusChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateDriverStatusChangeResponse) ProtoMessage() {}
+
+func (x *ValidateDriverStatusChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateDriverStatusChangeResponse.ProtoReflect.Descriptor instead.
+func (*ValidateDriverStatusChangeResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ValidateDriverStatusChangeResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *ValidateDriverStatusChangeResponse) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ValidateDriverStatusChangeResponse) GetCurrentStatus() DriverStatus {
+	if x != nil {
+		return x.CurrentStatus
+	}
+	return DriverStatus_STATUS_UNSPECIFIED
+}
+
+// By default only ACTIVE drivers with a valid license are returned. Set
+// include_expired_license to list every ACTIVE driver; those whose license has
+// lapsed come back with license_expired set.
+type GetActiveDriversRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	PageSize              int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken             string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	LicenseClassFilter    *LicenseClass          `protobuf:"varint,3,opt,name=license_class_filter,json=licenseClassFilter,proto3,enum=staff.LicenseClass,oneof" json:"license_class_filter,omitempty"`
+	IncludeExpiredLicense bool                   `protobuf:"varint,4,opt,name=include_expired_license,json=includeExpiredLicense,proto3" json:"include_expired_license,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetActiveDriversRequest) Reset() {
+	*x = GetActiveDriversRequest{}
+	mi := &file_staff_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveDriversRequest) ProtoMessage() {}
+
+func (x *GetActiveDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveDriversRequest.ProtoReflect.Descriptor instead.
+func (*GetActiveDriversRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetActiveDriversRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetActiveDriversRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetActiveDriversRequest) GetLicenseClassFilter() LicenseClass {
+	if x != nil && x.LicenseClassFilter != nil {
+		return *x.LicenseClassFilter
+	}
+	return LicenseClass_LICENSE_UNSPECIFIED
+}
+
+func (x *GetActiveDriversRequest) GetIncludeExpiredLicense() bool {
+	if x != nil {
+		return x.IncludeExpiredLicense
+	}
+	return false
+}
+
+type GetEligibleDriversForVehicleTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleType   string                 `protobuf:"bytes,1,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"` // vehicle type name, e.g. "matatu"
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEligibleDriversForVehicleTypeRequest) Reset() {
+	*x = GetEligibleDriversForVehicleTypeRequest{}
+	mi := &file_staff_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEligibleDriversForVehicleTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEligibleDriversForVehicleTypeRequest) ProtoMessage() {}
+
+func (x *GetEligibleDriversForVehicleTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEligibleDriversForVehicleTypeRequest.ProtoReflect.Descriptor instead.
+func (*GetEligibleDriversForVehicleTypeRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetEligibleDriversForVehicleTypeRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *GetEligibleDriversForVehicleTypeRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetEligibleDriversForVehicleTypeRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Whether one driver may be given a vehicle of the type: ACTIVE, with an unexpired
+// license whose class qualifies for the type
+type CheckDriverEligibilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	VehicleType   string                 `protobuf:"bytes,2,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDriverEligibilityRequest) Reset() {
+	*x = CheckDriverEligibilityRequest{}
+	mi := &file_staff_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDriverEligibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDriverEligibilityRequest) ProtoMessage() {}
+
+func (x *CheckDriverEligibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDriverEligibilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckDriverEligibilityRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CheckDriverEligibilityRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *CheckDriverEligibilityRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+type CheckDriverEligibilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Eligible      bool                   `protobuf:"varint,1,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"` // why the driver is ineligible; empty when eligible
+	Driver        *Driver                `protobuf:"bytes,3,opt,name=driver,proto3" json:"driver,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDriverEligibilityResponse) Reset() {
+	*x = CheckDriverEligibilityResponse{}
+	mi := &file_staff_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDriverEligibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDriverEligibilityResponse) ProtoMessage() {}
+
+func (x *CheckDriverEligibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDriverEligibilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckDriverEligibilityResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CheckDriverEligibilityResponse) GetEligible() bool {
+	if x != nil {
+		return x.Eligible
+	}
+	return false
+}
+
+func (x *CheckDriverEligibilityResponse) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *CheckDriverEligibilityResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+// Drivers that have been modified since creation, most recently updated first
+type ListRecentlyUpdatedDriversRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // keyed on updated_at, not interchangeable with ListDrivers tokens
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentlyUpdatedDriversRequest) Reset() {
+	*x = ListRecentlyUpdatedDriversRequest{}
+	mi := &file_staff_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentlyUpdatedDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentlyUpdatedDriversRequest) ProtoMessage() {}
+
+func (x *ListRecentlyUpdatedDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentlyUpdatedDriversRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentlyUpdatedDriversRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListRecentlyUpdatedDriversRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRecentlyUpdatedDriversRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ================= Driver Certification Messages =================
+type DriverCertification struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // certification ID
+	DriverId          string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	CertificationName string                 `protobuf:"bytes,3,opt,name=certification_name,json=certificationName,proto3" json:"certification_name,omitempty"`
+	IssuedBy          string                 `protobuf:"bytes,4,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
+	IssueDate         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=issue_date,json=issueDate,proto3" json:"issue_date,omitempty"`
+	ExpiryDate        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	Status            CertificationStatus    `protobuf:"varint,7,opt,name=status,proto3,enum=staff.CertificationStatus" json:"status,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	// Computed fields
+	IsExpired       bool  `protobuf:"varint,10,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
+	DaysUntilExpiry int32 `protobuf:"varint,11,opt,name=days_until_expiry,json=daysUntilExpiry,proto3" json:"days_until_expiry,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DriverCertification) Reset() {
+	*x = DriverCertification{}
+	mi := &file_staff_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverCertification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverCertification) ProtoMessage() {}
+
+func (x *DriverCertification) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverCertification.ProtoReflect.Descriptor instead.
+func (*DriverCertification) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DriverCertification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DriverCertification) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverCertification) GetCertificationName() string {
+	if x != nil {
+		return x.CertificationName
+	}
+	return ""
+}
+
+func (x *DriverCertification) GetIssuedBy() string {
+	if x != nil {
+		return x.IssuedBy
+	}
+	return ""
+}
+
+func (x *DriverCertification) GetIssueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueDate
+	}
+	return nil
+}
+
+func (x *DriverCertification) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+func (x *DriverCertification) GetStatus() CertificationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CertificationStatus_CERT_STATUS_UNSPECIFIED
+}
+
+func (x *DriverCertification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DriverCertification) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *DriverCertification) GetIsExpired() bool {
+	if x != nil {
+		return x.IsExpired
+	}
+	return false
+}
+
+func (x *DriverCertification) GetDaysUntilExpiry() int32 {
+	if x != nil {
+		return x.DaysUntilExpiry
+	}
+	return 0
+}
+
+type CertificationInput struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CertificationName string                 `protobuf:"bytes,1,opt,name=certification_name,json=certificationName,proto3" json:"certification_name,omitempty"`
+	IssuedBy          string                 `protobuf:"bytes,2,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
+	IssueDate         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issue_date,json=issueDate,proto3" json:"issue_date,omitempty"`
+	ExpiryDate        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiry_date,json=expiryDate,proto3" json:"expiry_date,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CertificationInput) Reset() {
+	*x = CertificationInput{}
+	mi := &file_staff_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CertificationInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertificationInput) ProtoMessage() {}
+
+func (x *CertificationInput) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertificationInput.ProtoReflect.Descriptor instead.
+func (*CertificationInput) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CertificationInput) GetCertificationName() string {
+	if x != nil {
+		return x.CertificationName
+	}
+	return ""
+}
+
+func (x *CertificationInput) GetIssuedBy() string {
+	if x != nil {
+		return x.IssuedBy
+	}
+	return ""
+}
+
+func (x *CertificationInput) GetIssueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssueDate
+	}
+	return nil
+}
+
+func (x *CertificationInput) GetExpiryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiryDate
+	}
+	return nil
+}
+
+type AddDriverCertificationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Certification *CertificationInput    `protobuf:"bytes,2,opt,name=certification,proto3" json:"certification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDriverCertificationRequest) Reset() {
+	*x = AddDriverCertificationRequest{}
+	mi := &file_staff_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDriverCertificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDriverCertificationRequest) ProtoMessage() {}
+
+func (x *AddDriverCertificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDriverCertificationRequest.ProtoReflect.Descriptor instead.
+func (*AddDriverCertificationRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AddDriverCertificationRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *AddDriverCertificationRequest) GetCertification() *CertificationInput {
+	if x != nil {
+		return x.Certification
+	}
+	return nil
+}
+
+type AddDriverCertificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Certification *DriverCertification   `protobuf:"bytes,1,opt,name=certification,proto3" json:"certification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDriverCertificationResponse) Reset() {
+	*x = AddDriverCertificationResponse{}
+	mi := &file_staff_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDriverCertificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDriverCertificationResponse) ProtoMessage() {}
+
+func (x *AddDriverCertificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDriverCertificationResponse.ProtoReflect.Descriptor instead.
+func (*AddDriverCertificationResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AddDriverCertificationResponse) GetCertification() *DriverCertification {
+	if x != nil {
+		return x.Certification
+	}
+	return nil
+}
+
+type ListDriverCertificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	StatusFilter  *CertificationStatus   `protobuf:"varint,4,opt,name=status_filter,json=statusFilter,proto3,enum=staff.CertificationStatus,oneof" json:"status_filter,omitempty"`
+	ExpiringSoon  *bool                  `protobuf:"varint,5,opt,name=expiring_soon,json=expiringSoon,proto3,oneof" json:"expiring_soon,omitempty"` // Within 30 days
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriverCertificationsRequest) Reset() {
+	*x = ListDriverCertificationsRequest{}
+	mi := &file_staff_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriverCertificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriverCertificationsRequest) ProtoMessage() {}
+
+func (x *ListDriverCertificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriverCertificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListDriverCertificationsRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListDriverCertificationsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ListDriverCertificationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDriverCertificationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDriverCertificationsRequest) GetStatusFilter() CertificationStatus {
+	if x != nil && x.StatusFilter != nil {
+		return *x.StatusFilter
+	}
+	return CertificationStatus_CERT_STATUS_UNSPECIFIED
+}
+
+func (x *ListDriverCertificationsRequest) GetExpiringSoon() bool {
+	if x != nil && x.ExpiringSoon != nil {
+		return *x.ExpiringSoon
+	}
+	return false
+}
+
+type ListDriverCertificationsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Certifications []*DriverCertification `protobuf:"bytes,1,rep,name=certifications,proto3" json:"certifications,omitempty"`
+	NextPageToken  string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDriverCertificationsResponse) Reset() {
+	*x = ListDriverCertificationsResponse{}
+	mi := &file_staff_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriverCertificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriverCertificationsResponse) ProtoMessage() {}
 
 func (x *ListDriverCertificationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[20]
+	mi := &file_staff_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriverCertificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListDriverCertificationsResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListDriverCertificationsResponse) GetCertifications() []*DriverCertification {
+	if x != nil {
+		return x.Certifications
+	}
+	return nil
+}
+
+func (x *ListDriverCertificationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateCertificationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CertificationId string                 `protobuf:"bytes,1,opt,name=certification_id,json=certificationId,proto3" json:"certification_id,omitempty"`
+	Certification   *CertificationInput    `protobuf:"bytes,2,opt,name=certification,proto3" json:"certification,omitempty"`
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateCertificationRequest) Reset() {
+	*x = UpdateCertificationRequest{}
+	mi := &file_staff_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCertificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCertificationRequest) ProtoMessage() {}
+
+func (x *UpdateCertificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCertificationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCertificationRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdateCertificationRequest) GetCertificationId() string {
+	if x != nil {
+		return x.CertificationId
+	}
+	return ""
+}
+
+func (x *UpdateCertificationRequest) GetCertification() *CertificationInput {
+	if x != nil {
+		return x.Certification
+	}
+	return nil
+}
+
+func (x *UpdateCertificationRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateCertificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Certification *DriverCertification   `protobuf:"bytes,1,opt,name=certification,proto3" json:"certification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCertificationResponse) Reset() {
+	*x = UpdateCertificationResponse{}
+	mi := &file_staff_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCertificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCertificationResponse) ProtoMessage() {}
+
+func (x *UpdateCertificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCertificationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCertificationResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateCertificationResponse) GetCertification() *DriverCertification {
+	if x != nil {
+		return x.Certification
+	}
+	return nil
+}
+
+type DeleteCertificationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CertificationId string                 `protobuf:"bytes,1,opt,name=certification_id,json=certificationId,proto3" json:"certification_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteCertificationRequest) Reset() {
+	*x = DeleteCertificationRequest{}
+	mi := &file_staff_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCertificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCertificationRequest) ProtoMessage() {}
+
+func (x *DeleteCertificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCertificationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCertificationRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteCertificationRequest) GetCertificationId() string {
+	if x != nil {
+		return x.CertificationId
+	}
+	return ""
+}
+
+// A standard certification and the body that issues it, used to pre-fill the issuer
+type CertificationTemplate struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CertificationName string                 `protobuf:"bytes,1,opt,name=certification_name,json=certificationName,proto3" json:"certification_name,omitempty"`
+	IssuedBy          string                 `protobuf:"bytes,2,opt,name=issued_by,json=issuedBy,proto3" json:"issued_by,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CertificationTemplate) Reset() {
+	*x = CertificationTemplate{}
+	mi := &file_staff_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CertificationTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CertificationTemplate) ProtoMessage() {}
+
+func (x *CertificationTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CertificationTemplate.ProtoReflect.Descriptor instead.
+func (*CertificationTemplate) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CertificationTemplate) GetCertificationName() string {
+	if x != nil {
+		return x.CertificationName
+	}
+	return ""
+}
+
+func (x *CertificationTemplate) GetIssuedBy() string {
+	if x != nil {
+		return x.IssuedBy
+	}
+	return ""
+}
+
+type ListCertificationTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCertificationTemplatesRequest) Reset() {
+	*x = ListCertificationTemplatesRequest{}
+	mi := &file_staff_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCertificationTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCertificationTemplatesRequest) ProtoMessage() {}
+
+func (x *ListCertificationTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCertificationTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListCertificationTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{49}
+}
+
+type ListCertificationTemplatesResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Templates     []*CertificationTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCertificationTemplatesResponse) Reset() {
+	*x = ListCertificationTemplatesResponse{}
+	mi := &file_staff_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCertificationTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCertificationTemplatesResponse) ProtoMessage() {}
+
+func (x *ListCertificationTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCertificationTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListCertificationTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListCertificationTemplatesResponse) GetTemplates() []*CertificationTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// ================= Verification and Compliance Messages =================
+type VerifyDriverLicenseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	LicenseNumber string                 `protobuf:"bytes,2,opt,name=license_number,json=licenseNumber,proto3" json:"license_number,omitempty"` // For verification against external systems
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDriverLicenseRequest) Reset() {
+	*x = VerifyDriverLicenseRequest{}
+	mi := &file_staff_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDriverLicenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDriverLicenseRequest) ProtoMessage() {}
+
+func (x *VerifyDriverLicenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDriverLicenseRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDriverLicenseRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *VerifyDriverLicenseRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *VerifyDriverLicenseRequest) GetLicenseNumber() string {
+	if x != nil {
+		return x.LicenseNumber
+	}
+	return ""
+}
+
+type VerifyDriverLicenseResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	IsValid            bool                   `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	IsExpired          bool                   `protobuf:"varint,2,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
+	VerificationSource string                 `protobuf:"bytes,3,opt,name=verification_source,json=verificationSource,proto3" json:"verification_source,omitempty"`
+	VerifiedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	Notes              string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *VerifyDriverLicenseResponse) Reset() {
+	*x = VerifyDriverLicenseResponse{}
+	mi := &file_staff_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDriverLicenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDriverLicenseResponse) ProtoMessage() {}
+
+func (x *VerifyDriverLicenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDriverLicenseResponse.ProtoReflect.Descriptor instead.
+func (*VerifyDriverLicenseResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *VerifyDriverLicenseResponse) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *VerifyDriverLicenseResponse) GetIsExpired() bool {
+	if x != nil {
+		return x.IsExpired
+	}
+	return false
+}
+
+func (x *VerifyDriverLicenseResponse) GetVerificationSource() string {
+	if x != nil {
+		return x.VerificationSource
+	}
+	return ""
+}
+
+func (x *VerifyDriverLicenseResponse) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+func (x *VerifyDriverLicenseResponse) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type BatchVerifyDriverLicensesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverIds     []string               `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"` // Max 100 per call
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchVerifyDriverLicensesRequest) Reset() {
+	*x = BatchVerifyDriverLicensesRequest{}
+	mi := &file_staff_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchVerifyDriverLicensesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchVerifyDriverLicensesRequest) ProtoMessage() {}
+
+func (x *BatchVerifyDriverLicensesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1536,49 +3390,315 @@ func (x *ListDriverCertificationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDriverCertificationsResponse.ProtoReflect.Descriptor instead.
-func (*ListDriverCertificationsResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use BatchVerifyDriverLicensesRequest.ProtoReflect.Descriptor instead.
+func (*BatchVerifyDriverLicensesRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *ListDriverCertificationsResponse) GetCertifications() []*DriverCertification {
+func (x *BatchVerifyDriverLicensesRequest) GetDriverIds() []string {
 	if x != nil {
-		return x.Certifications
+		return x.DriverIds
 	}
 	return nil
 }
 
-func (x *ListDriverCertificationsResponse) GetNextPageToken() string {
+type DriverLicenseVerification struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	DriverId               string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Found                  bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	IsValid                bool                   `protobuf:"varint,3,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	IsExpired              bool                   `protobuf:"varint,4,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
+	LicenseExpiry          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=license_expiry,json=licenseExpiry,proto3" json:"license_expiry,omitempty"`
+	DaysUntilLicenseExpiry int32                  `protobuf:"varint,6,opt,name=days_until_license_expiry,json=daysUntilLicenseExpiry,proto3" json:"days_until_license_expiry,omitempty"`
+	Notes                  string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DriverLicenseVerification) Reset() {
+	*x = DriverLicenseVerification{}
+	mi := &file_staff_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverLicenseVerification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverLicenseVerification) ProtoMessage() {}
+
+func (x *DriverLicenseVerification) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[54]
 	if x != nil {
-		return x.NextPageToken
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverLicenseVerification.ProtoReflect.Descriptor instead.
+func (*DriverLicenseVerification) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DriverLicenseVerification) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
 	}
 	return ""
 }
 
-type UpdateCertificationRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	CertificationId string                 `protobuf:"bytes,1,opt,name=certification_id,json=certificationId,proto3" json:"certification_id,omitempty"`
-	Certification   *CertificationInput    `protobuf:"bytes,2,opt,name=certification,proto3" json:"certification,omitempty"`
-	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *DriverLicenseVerification) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *DriverLicenseVerification) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *DriverLicenseVerification) GetIsExpired() bool {
+	if x != nil {
+		return x.IsExpired
+	}
+	return false
+}
+
+func (x *DriverLicenseVerification) GetLicenseExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LicenseExpiry
+	}
+	return nil
+}
+
+func (x *DriverLicenseVerification) GetDaysUntilLicenseExpiry() int32 {
+	if x != nil {
+		return x.DaysUntilLicenseExpiry
+	}
+	return 0
+}
+
+func (x *DriverLicenseVerification) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type BatchVerifyDriverLicensesResponse struct {
+	state              protoimpl.MessageState       `protogen:"open.v1"`
+	Results            []*DriverLicenseVerification `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // Same order as the requested driver_ids
+	VerificationSource string                       `protobuf:"bytes,2,opt,name=verification_source,json=verificationSource,proto3" json:"verification_source,omitempty"`
+	VerifiedAt         *timestamppb.Timestamp       `protobuf:"bytes,3,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchVerifyDriverLicensesResponse) Reset() {
+	*x = BatchVerifyDriverLicensesResponse{}
+	mi := &file_staff_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchVerifyDriverLicensesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchVerifyDriverLicensesResponse) ProtoMessage() {}
+
+func (x *BatchVerifyDriverLicensesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchVerifyDriverLicensesResponse.ProtoReflect.Descriptor instead.
+func (*BatchVerifyDriverLicensesResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *BatchVerifyDriverLicensesResponse) GetResults() []*DriverLicenseVerification {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchVerifyDriverLicensesResponse) GetVerificationSource() string {
+	if x != nil {
+		return x.VerificationSource
+	}
+	return ""
+}
+
+func (x *BatchVerifyDriverLicensesResponse) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
+type GetExpiringLicensesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DaysAhead     int32                  `protobuf:"varint,1,opt,name=days_ahead,json=daysAhead,proto3" json:"days_ahead,omitempty"` // Default 30 days
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExpiringLicensesRequest) Reset() {
+	*x = GetExpiringLicensesRequest{}
+	mi := &file_staff_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExpiringLicensesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExpiringLicensesRequest) ProtoMessage() {}
+
+func (x *GetExpiringLicensesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExpiringLicensesRequest.ProtoReflect.Descriptor instead.
+func (*GetExpiringLicensesRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetExpiringLicensesRequest) GetDaysAhead() int32 {
+	if x != nil {
+		return x.DaysAhead
+	}
+	return 0
+}
+
+func (x *GetExpiringLicensesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetExpiringLicensesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Drivers whose licenses expired in the past since_days, most recently expired first
+type GetRecentlyExpiredLicensesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SinceDays     int32                  `protobuf:"varint,1,opt,name=since_days,json=sinceDays,proto3" json:"since_days,omitempty"` // Default 30 days, at most 365
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentlyExpiredLicensesRequest) Reset() {
+	*x = GetRecentlyExpiredLicensesRequest{}
+	mi := &file_staff_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentlyExpiredLicensesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentlyExpiredLicensesRequest) ProtoMessage() {}
+
+func (x *GetRecentlyExpiredLicensesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentlyExpiredLicensesRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentlyExpiredLicensesRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetRecentlyExpiredLicensesRequest) GetSinceDays() int32 {
+	if x != nil {
+		return x.SinceDays
+	}
+	return 0
+}
+
+func (x *GetRecentlyExpiredLicensesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetRecentlyExpiredLicensesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetExpiredCertificationsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PageSize         int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken        string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	ExpiredSinceDays *int32                 `protobuf:"varint,3,opt,name=expired_since_days,json=expiredSinceDays,proto3,oneof" json:"expired_since_days,omitempty"` // Expired within X days
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *UpdateCertificationRequest) Reset() {
-	*x = UpdateCertificationRequest{}
-	mi := &file_staff_proto_msgTypes[21]
+func (x *GetExpiredCertificationsRequest) Reset() {
+	*x = GetExpiredCertificationsRequest{}
+	mi := &file_staff_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCertificationRequest) String() string {
+func (x *GetExpiredCertificationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCertificationRequest) ProtoMessage() {}
+func (*GetExpiredCertificationsRequest) ProtoMessage() {}
 
-func (x *UpdateCertificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[21]
+func (x *GetExpiredCertificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1589,54 +3709,54 @@ func (x *UpdateCertificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCertificationRequest.ProtoReflect.Descriptor instead.
-func (*UpdateCertificationRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetExpiredCertificationsRequest.ProtoReflect.Descriptor instead.
+func (*GetExpiredCertificationsRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *UpdateCertificationRequest) GetCertificationId() string {
+func (x *GetExpiredCertificationsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.CertificationId
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateCertificationRequest) GetCertification() *CertificationInput {
+func (x *GetExpiredCertificationsRequest) GetPageToken() string {
 	if x != nil {
-		return x.Certification
+		return x.PageToken
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateCertificationRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
-	if x != nil {
-		return x.UpdateMask
+func (x *GetExpiredCertificationsRequest) GetExpiredSinceDays() int32 {
+	if x != nil && x.ExpiredSinceDays != nil {
+		return *x.ExpiredSinceDays
 	}
-	return nil
+	return 0
 }
 
-type UpdateCertificationResponse struct {
+type ValidatePhoneNumberRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Certification *DriverCertification   `protobuf:"bytes,1,opt,name=certification,proto3" json:"certification,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,1,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateCertificationResponse) Reset() {
-	*x = UpdateCertificationResponse{}
-	mi := &file_staff_proto_msgTypes[22]
+func (x *ValidatePhoneNumberRequest) Reset() {
+	*x = ValidatePhoneNumberRequest{}
+	mi := &file_staff_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCertificationResponse) String() string {
+func (x *ValidatePhoneNumberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCertificationResponse) ProtoMessage() {}
+func (*ValidatePhoneNumberRequest) ProtoMessage() {}
 
-func (x *UpdateCertificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[22]
+func (x *ValidatePhoneNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1647,40 +3767,40 @@ func (x *UpdateCertificationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCertificationResponse.ProtoReflect.Descriptor instead.
-func (*UpdateCertificationResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ValidatePhoneNumberRequest.ProtoReflect.Descriptor instead.
+func (*ValidatePhoneNumberRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *UpdateCertificationResponse) GetCertification() *DriverCertification {
+func (x *ValidatePhoneNumberRequest) GetPhoneNumber() string {
 	if x != nil {
-		return x.Certification
+		return x.PhoneNumber
 	}
-	return nil
+	return ""
 }
 
-type DeleteCertificationRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	CertificationId string                 `protobuf:"bytes,1,opt,name=certification_id,json=certificationId,proto3" json:"certification_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ValidateLicenseNumberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LicenseNumber string                 `protobuf:"bytes,1,opt,name=license_number,json=licenseNumber,proto3" json:"license_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteCertificationRequest) Reset() {
-	*x = DeleteCertificationRequest{}
-	mi := &file_staff_proto_msgTypes[23]
+func (x *ValidateLicenseNumberRequest) Reset() {
+	*x = ValidateLicenseNumberRequest{}
+	mi := &file_staff_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteCertificationRequest) String() string {
+func (x *ValidateLicenseNumberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCertificationRequest) ProtoMessage() {}
+func (*ValidateLicenseNumberRequest) ProtoMessage() {}
 
-func (x *DeleteCertificationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[23]
+func (x *ValidateLicenseNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1691,42 +3811,43 @@ func (x *DeleteCertificationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCertificationRequest.ProtoReflect.Descriptor instead.
-func (*DeleteCertificationRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ValidateLicenseNumberRequest.ProtoReflect.Descriptor instead.
+func (*ValidateLicenseNumberRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *DeleteCertificationRequest) GetCertificationId() string {
+func (x *ValidateLicenseNumberRequest) GetLicenseNumber() string {
 	if x != nil {
-		return x.CertificationId
+		return x.LicenseNumber
 	}
 	return ""
 }
 
-// ================= Verification and Compliance Messages =================
-type VerifyDriverLicenseRequest struct {
+// Result of a format check. Invalid input is reported here rather than as an error.
+type FieldValidationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	LicenseNumber string                 `protobuf:"bytes,2,opt,name=license_number,json=licenseNumber,proto3" json:"license_number,omitempty"` // For verification against external systems
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Normalized    string                 `protobuf:"bytes,2,opt,name=normalized,proto3" json:"normalized,omitempty"` // value as it would be stored
+	Errors        []string               `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`         // empty when valid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyDriverLicenseRequest) Reset() {
-	*x = VerifyDriverLicenseRequest{}
-	mi := &file_staff_proto_msgTypes[24]
+func (x *FieldValidationResponse) Reset() {
+	*x = FieldValidationResponse{}
+	mi := &file_staff_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyDriverLicenseRequest) String() string {
+func (x *FieldValidationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyDriverLicenseRequest) ProtoMessage() {}
+func (*FieldValidationResponse) ProtoMessage() {}
 
-func (x *VerifyDriverLicenseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[24]
+func (x *FieldValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,51 +3858,57 @@ func (x *VerifyDriverLicenseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyDriverLicenseRequest.ProtoReflect.Descriptor instead.
-func (*VerifyDriverLicenseRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use FieldValidationResponse.ProtoReflect.Descriptor instead.
+func (*FieldValidationResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *VerifyDriverLicenseRequest) GetDriverId() string {
+func (x *FieldValidationResponse) GetValid() bool {
 	if x != nil {
-		return x.DriverId
+		return x.Valid
 	}
-	return ""
+	return false
 }
 
-func (x *VerifyDriverLicenseRequest) GetLicenseNumber() string {
+func (x *FieldValidationResponse) GetNormalized() string {
 	if x != nil {
-		return x.LicenseNumber
+		return x.Normalized
 	}
 	return ""
 }
 
-type VerifyDriverLicenseResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	IsValid            bool                   `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
-	IsExpired          bool                   `protobuf:"varint,2,opt,name=is_expired,json=isExpired,proto3" json:"is_expired,omitempty"`
-	VerificationSource string                 `protobuf:"bytes,3,opt,name=verification_source,json=verificationSource,proto3" json:"verification_source,omitempty"`
-	VerifiedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"`
-	Notes              string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+func (x *FieldValidationResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
 }
 
-func (x *VerifyDriverLicenseResponse) Reset() {
-	*x = VerifyDriverLicenseResponse{}
-	mi := &file_staff_proto_msgTypes[25]
+// Re-applies the current normalization rules to every stored driver, for records
+// saved before the rules tightened. Drivers are read in batches of batch_size.
+type NormalizeLegacyRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`          // report what would change without writing anything
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // default 200, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NormalizeLegacyRecordsRequest) Reset() {
+	*x = NormalizeLegacyRecordsRequest{}
+	mi := &file_staff_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyDriverLicenseResponse) String() string {
+func (x *NormalizeLegacyRecordsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyDriverLicenseResponse) ProtoMessage() {}
+func (*NormalizeLegacyRecordsRequest) ProtoMessage() {}
 
-func (x *VerifyDriverLicenseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[25]
+func (x *NormalizeLegacyRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1792,70 +3919,109 @@ func (x *VerifyDriverLicenseResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyDriverLicenseResponse.ProtoReflect.Descriptor instead.
-func (*VerifyDriverLicenseResponse) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use NormalizeLegacyRecordsRequest.ProtoReflect.Descriptor instead.
+func (*NormalizeLegacyRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *VerifyDriverLicenseResponse) GetIsValid() bool {
+func (x *NormalizeLegacyRecordsRequest) GetDryRun() bool {
 	if x != nil {
-		return x.IsValid
+		return x.DryRun
 	}
 	return false
 }
 
-func (x *VerifyDriverLicenseResponse) GetIsExpired() bool {
+func (x *NormalizeLegacyRecordsRequest) GetBatchSize() int32 {
 	if x != nil {
-		return x.IsExpired
+		return x.BatchSize
 	}
-	return false
+	return 0
 }
 
-func (x *VerifyDriverLicenseResponse) GetVerificationSource() string {
+type NormalizedField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NormalizedField) Reset() {
+	*x = NormalizedField{}
+	mi := &file_staff_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NormalizedField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NormalizedField) ProtoMessage() {}
+
+func (x *NormalizedField) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[63]
 	if x != nil {
-		return x.VerificationSource
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NormalizedField.ProtoReflect.Descriptor instead.
+func (*NormalizedField) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *NormalizedField) GetField() string {
+	if x != nil {
+		return x.Field
 	}
 	return ""
 }
 
-func (x *VerifyDriverLicenseResponse) GetVerifiedAt() *timestamppb.Timestamp {
+func (x *NormalizedField) GetOldValue() string {
 	if x != nil {
-		return x.VerifiedAt
+		return x.OldValue
 	}
-	return nil
+	return ""
 }
 
-func (x *VerifyDriverLicenseResponse) GetNotes() string {
+func (x *NormalizedField) GetNewValue() string {
 	if x != nil {
-		return x.Notes
+		return x.NewValue
 	}
 	return ""
 }
 
-type GetExpiringLicensesRequest struct {
+type NormalizedRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DaysAhead     int32                  `protobuf:"varint,1,opt,name=days_ahead,json=daysAhead,proto3" json:"days_ahead,omitempty"` // Default 30 days
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fields        []*NormalizedField     `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // set when the record could not be updated, e.g. its normalized license number is taken
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetExpiringLicensesRequest) Reset() {
-	*x = GetExpiringLicensesRequest{}
-	mi := &file_staff_proto_msgTypes[26]
+func (x *NormalizedRecord) Reset() {
+	*x = NormalizedRecord{}
+	mi := &file_staff_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetExpiringLicensesRequest) String() string {
+func (x *NormalizedRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetExpiringLicensesRequest) ProtoMessage() {}
+func (*NormalizedRecord) ProtoMessage() {}
 
-func (x *GetExpiringLicensesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[26]
+func (x *NormalizedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1866,56 +4032,59 @@ func (x *GetExpiringLicensesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetExpiringLicensesRequest.ProtoReflect.Descriptor instead.
-func (*GetExpiringLicensesRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use NormalizedRecord.ProtoReflect.Descriptor instead.
+func (*NormalizedRecord) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetExpiringLicensesRequest) GetDaysAhead() int32 {
+func (x *NormalizedRecord) GetId() string {
 	if x != nil {
-		return x.DaysAhead
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *GetExpiringLicensesRequest) GetPageSize() int32 {
+func (x *NormalizedRecord) GetFields() []*NormalizedField {
 	if x != nil {
-		return x.PageSize
+		return x.Fields
 	}
-	return 0
+	return nil
 }
 
-func (x *GetExpiringLicensesRequest) GetPageToken() string {
+func (x *NormalizedRecord) GetError() string {
 	if x != nil {
-		return x.PageToken
+		return x.Error
 	}
 	return ""
 }
 
-type GetExpiredCertificationsRequest struct {
+type NormalizeLegacyRecordsResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	PageSize         int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken        string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	ExpiredSinceDays *int32                 `protobuf:"varint,3,opt,name=expired_since_days,json=expiredSinceDays,proto3,oneof" json:"expired_since_days,omitempty"` // Expired within X days
+	DryRun           bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Scanned          int32                  `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Changed          int32                  `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"` // records updated, or that would be on a dry run
+	Failed           int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Records          []*NormalizedRecord    `protobuf:"bytes,5,rep,name=records,proto3" json:"records,omitempty"`                                            // changed and failed records, at most 500
+	RecordsTruncated bool                   `protobuf:"varint,6,opt,name=records_truncated,json=recordsTruncated,proto3" json:"records_truncated,omitempty"` // more records changed than are listed
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetExpiredCertificationsRequest) Reset() {
-	*x = GetExpiredCertificationsRequest{}
-	mi := &file_staff_proto_msgTypes[27]
+func (x *NormalizeLegacyRecordsResponse) Reset() {
+	*x = NormalizeLegacyRecordsResponse{}
+	mi := &file_staff_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetExpiredCertificationsRequest) String() string {
+func (x *NormalizeLegacyRecordsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetExpiredCertificationsRequest) ProtoMessage() {}
+func (*NormalizeLegacyRecordsResponse) ProtoMessage() {}
 
-func (x *GetExpiredCertificationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_staff_proto_msgTypes[27]
+func (x *NormalizeLegacyRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_staff_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1926,37 +4095,58 @@ func (x *GetExpiredCertificationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetExpiredCertificationsRequest.ProtoReflect.Descriptor instead.
-func (*GetExpiredCertificationsRequest) Descriptor() ([]byte, []int) {
-	return file_staff_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use NormalizeLegacyRecordsResponse.ProtoReflect.Descriptor instead.
+func (*NormalizeLegacyRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_staff_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetExpiredCertificationsRequest) GetPageSize() int32 {
+func (x *NormalizeLegacyRecordsResponse) GetDryRun() bool {
 	if x != nil {
-		return x.PageSize
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *NormalizeLegacyRecordsResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
 	}
 	return 0
 }
 
-func (x *GetExpiredCertificationsRequest) GetPageToken() string {
+func (x *NormalizeLegacyRecordsResponse) GetChanged() int32 {
 	if x != nil {
-		return x.PageToken
+		return x.Changed
 	}
-	return ""
+	return 0
 }
 
-func (x *GetExpiredCertificationsRequest) GetExpiredSinceDays() int32 {
-	if x != nil && x.ExpiredSinceDays != nil {
-		return *x.ExpiredSinceDays
+func (x *NormalizeLegacyRecordsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
 	}
 	return 0
 }
 
+func (x *NormalizeLegacyRecordsResponse) GetRecords() []*NormalizedRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *NormalizeLegacyRecordsResponse) GetRecordsTruncated() bool {
+	if x != nil {
+		return x.RecordsTruncated
+	}
+	return false
+}
+
 var File_staff_proto protoreflect.FileDescriptor
 
 const file_staff_proto_rawDesc = "" +
 	"\n" +
-	"\vstaff.proto\x12\x05staff\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xa9\x06\n" +
+	"\vstaff.proto\x12\x05staff\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"\xf4\b\n" +
 	"\x06Driver\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12%\n" +
@@ -1973,11 +4163,20 @@ const file_staff_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01\x12'\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"updated_by\x18\x11 \x01(\tH\x01R\tupdatedBy\x88\x01\x01\x12%\n" +
+	"\x0erating_average\x18\x12 \x01(\x01R\rratingAverage\x12!\n" +
+	"\frating_count\x18\x13 \x01(\x05R\vratingCount\x12)\n" +
+	"\x10handbook_version\x18\x14 \x01(\tR\x0fhandbookVersion\x12Y\n" +
+	"\x18handbook_acknowledged_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x16handbookAcknowledgedAt\x88\x01\x01\x12'\n" +
 	"\x0flicense_expired\x18\x0e \x01(\bR\x0elicenseExpired\x129\n" +
 	"\x19days_until_license_expiry\x18\x0f \x01(\x05R\x16daysUntilLicenseExpiry\x12B\n" +
-	"\x0ecertifications\x18\x10 \x03(\v2\x1a.staff.DriverCertificationR\x0ecertificationsB\r\n" +
-	"\v_updated_at\"\xbf\x03\n" +
+	"\x0ecertifications\x18\x10 \x03(\v2\x1a.staff.DriverCertificationR\x0ecertifications\x12)\n" +
+	"\x10handbook_current\x18\x16 \x01(\bR\x0fhandbookCurrentB\r\n" +
+	"\v_updated_atB\r\n" +
+	"\v_updated_byB\x1b\n" +
+	"\x19_handbook_acknowledged_at\"\xbf\x03\n" +
 	"\vDriverInput\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
 	"\x0elicense_number\x18\x02 \x01(\tR\rlicenseNumber\x128\n" +
@@ -1997,43 +4196,135 @@ const file_staff_proto_rawDesc = "" +
 	"\x18GetDriverByUserIDRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\":\n" +
 	"\x11GetDriverResponse\x12%\n" +
-	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"\xd9\x02\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"7\n" +
+	"\x1aGetDriversByUserIDsRequest\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\xb3\x01\n" +
+	"\x1bGetDriversByUserIDsResponse\x12I\n" +
+	"\adrivers\x18\x01 \x03(\v2/.staff.GetDriversByUserIDsResponse.DriversEntryR\adrivers\x1aI\n" +
+	"\fDriversEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12#\n" +
+	"\x05value\x18\x02 \x01(\v2\r.staff.DriverR\x05value:\x028\x01\"\xf4\x02\n" +
 	"\x12ListDriversRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\x12=\n" +
 	"\rstatus_filter\x18\x03 \x01(\x0e2\x13.staff.DriverStatusH\x00R\fstatusFilter\x88\x01\x01\x12J\n" +
 	"\x14license_class_filter\x18\x04 \x01(\x0e2\x13.staff.LicenseClassH\x01R\x12licenseClassFilter\x88\x01\x01\x127\n" +
-	"\x15license_expiring_soon\x18\x05 \x01(\bH\x02R\x13licenseExpiringSoon\x88\x01\x01B\x10\n" +
+	"\x15license_expiring_soon\x18\x05 \x01(\bH\x02R\x13licenseExpiringSoon\x88\x01\x01\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderByB\x10\n" +
 	"\x0e_status_filterB\x17\n" +
 	"\x15_license_class_filterB\x18\n" +
-	"\x16_license_expiring_soon\"\x87\x01\n" +
+	"\x16_license_expiring_soon\"\xaf\x01\n" +
 	"\x13ListDriversResponse\x12'\n" +
 	"\adrivers\x18\x01 \x03(\v2\r.staff.DriverR\adrivers\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"\x9b\x01\n" +
+	"totalCount\x12&\n" +
+	"\x0fprev_page_token\x18\x04 \x01(\tR\rprevPageToken\"\x9b\x01\n" +
 	"\x13UpdateDriverRequest\x12\x1b\n" +
 	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12*\n" +
 	"\x06driver\x18\x02 \x01(\v2\x12.staff.DriverInputR\x06driver\x12;\n" +
 	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\"=\n" +
+	"updateMask\"\x91\x01\n" +
 	"\x14UpdateDriverResponse\x12%\n" +
-	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"2\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\x12R\n" +
+	"\x16normalization_warnings\x18\x02 \x03(\v2\x1b.staff.NormalizationWarningR\x15normalizationWarnings\"b\n" +
+	"\x14NormalizationWarning\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1c\n" +
+	"\tsubmitted\x18\x02 \x01(\tR\tsubmitted\x12\x16\n" +
+	"\x06stored\x18\x03 \x01(\tR\x06stored\"2\n" +
 	"\x13DeleteDriverRequest\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"}\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"3\n" +
+	"\x14RestoreDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\">\n" +
+	"\x15RestoreDriverResponse\x12%\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"6\n" +
+	"\x17HardDeleteDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"n\n" +
+	"\x18HardDeleteDriverResponse\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x125\n" +
+	"\x16certifications_deleted\x18\x02 \x01(\x05R\x15certificationsDeleted\"q\n" +
+	"\x13MergeDriversRequest\x12*\n" +
+	"\x11primary_driver_id\x18\x01 \x01(\tR\x0fprimaryDriverId\x12.\n" +
+	"\x13duplicate_driver_id\x18\x02 \x01(\tR\x11duplicateDriverId\"\xa2\x01\n" +
+	"\x14MergeDriversResponse\x12%\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\x121\n" +
+	"\x14certifications_moved\x18\x02 \x01(\x05R\x13certificationsMoved\x120\n" +
+	"\x14status_history_moved\x18\x03 \x01(\x05R\x12statusHistoryMoved\"P\n" +
+	"\x19UpdateDriverRatingRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06rating\x18\x02 \x01(\x01R\x06rating\"C\n" +
+	"\x1aUpdateDriverRatingResponse\x12%\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"S\n" +
+	"\x1aAcknowledgeHandbookRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"D\n" +
+	"\x1bAcknowledgeHandbookResponse\x12%\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"}\n" +
 	"\x19UpdateDriverStatusRequest\x12\x1b\n" +
 	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12+\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x13.staff.DriverStatusR\x06status\x12\x16\n" +
 	"\x06reason\x18\x03 \x01(\tR\x06reason\"C\n" +
 	"\x1aUpdateDriverStatusResponse\x12%\n" +
-	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"\xba\x01\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\"\xbd\x01\n" +
+	"\x19RenewDriverLicenseRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x129\n" +
+	"\n" +
+	"new_expiry\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tnewExpiry\x121\n" +
+	"\x12new_license_number\x18\x03 \x01(\tH\x00R\x10newLicenseNumber\x88\x01\x01B\x15\n" +
+	"\x13_new_license_number\"e\n" +
+	"\x1aRenewDriverLicenseResponse\x12%\n" +
+	"\x06driver\x18\x01 \x01(\v2\r.staff.DriverR\x06driver\x12 \n" +
+	"\vreactivated\x18\x02 \x01(\bR\vreactivated\"\xab\x02\n" +
+	"\x18DriverStatusHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12<\n" +
+	"\x0fprevious_status\x18\x03 \x01(\x0e2\x13.staff.DriverStatusR\x0epreviousStatus\x122\n" +
+	"\n" +
+	"new_status\x18\x04 \x01(\x0e2\x13.staff.DriverStatusR\tnewStatus\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x06 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"y\n" +
+	"\x1eListDriverStatusHistoryRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x84\x01\n" +
+	"\x1fListDriverStatusHistoryResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.staff.DriverStatusHistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"m\n" +
+	"!ValidateDriverStatusChangeRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12+\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.staff.DriverStatusR\x06status\"\x94\x01\n" +
+	"\"ValidateDriverStatusChangeResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\x12:\n" +
+	"\x0ecurrent_status\x18\x03 \x01(\x0e2\x13.staff.DriverStatusR\rcurrentStatus\"\xf2\x01\n" +
 	"\x17GetActiveDriversRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\x12J\n" +
-	"\x14license_class_filter\x18\x03 \x01(\x0e2\x13.staff.LicenseClassH\x00R\x12licenseClassFilter\x88\x01\x01B\x17\n" +
-	"\x15_license_class_filter\"\x8f\x04\n" +
+	"\x14license_class_filter\x18\x03 \x01(\x0e2\x13.staff.LicenseClassH\x00R\x12licenseClassFilter\x88\x01\x01\x126\n" +
+	"\x17include_expired_license\x18\x04 \x01(\bR\x15includeExpiredLicenseB\x17\n" +
+	"\x15_license_class_filter\"\x88\x01\n" +
+	"'GetEligibleDriversForVehicleTypeRequest\x12!\n" +
+	"\fvehicle_type\x18\x01 \x01(\tR\vvehicleType\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"_\n" +
+	"\x1dCheckDriverEligibilityRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12!\n" +
+	"\fvehicle_type\x18\x02 \x01(\tR\vvehicleType\"}\n" +
+	"\x1eCheckDriverEligibilityResponse\x12\x1a\n" +
+	"\beligible\x18\x01 \x01(\bR\beligible\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\x12%\n" +
+	"\x06driver\x18\x03 \x01(\v2\r.staff.DriverR\x06driver\"_\n" +
+	"!ListRecentlyUpdatedDriversRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x8f\x04\n" +
 	"\x13DriverCertification\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12-\n" +
@@ -2085,7 +4376,13 @@ const file_staff_proto_rawDesc = "" +
 	"\x1bUpdateCertificationResponse\x12@\n" +
 	"\rcertification\x18\x01 \x01(\v2\x1a.staff.DriverCertificationR\rcertification\"G\n" +
 	"\x1aDeleteCertificationRequest\x12)\n" +
-	"\x10certification_id\x18\x01 \x01(\tR\x0fcertificationId\"`\n" +
+	"\x10certification_id\x18\x01 \x01(\tR\x0fcertificationId\"c\n" +
+	"\x15CertificationTemplate\x12-\n" +
+	"\x12certification_name\x18\x01 \x01(\tR\x11certificationName\x12\x1b\n" +
+	"\tissued_by\x18\x02 \x01(\tR\bissuedBy\"#\n" +
+	"!ListCertificationTemplatesRequest\"`\n" +
+	"\"ListCertificationTemplatesResponse\x12:\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x1c.staff.CertificationTemplateR\ttemplates\"`\n" +
 	"\x1aVerifyDriverLicenseRequest\x12\x1b\n" +
 	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12%\n" +
 	"\x0elicense_number\x18\x02 \x01(\tR\rlicenseNumber\"\xdb\x01\n" +
@@ -2096,19 +4393,71 @@ const file_staff_proto_rawDesc = "" +
 	"\x13verification_source\x18\x03 \x01(\tR\x12verificationSource\x12;\n" +
 	"\vverified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"verifiedAt\x12\x14\n" +
-	"\x05notes\x18\x05 \x01(\tR\x05notes\"w\n" +
+	"\x05notes\x18\x05 \x01(\tR\x05notes\"A\n" +
+	" BatchVerifyDriverLicensesRequest\x12\x1d\n" +
+	"\n" +
+	"driver_ids\x18\x01 \x03(\tR\tdriverIds\"\x9c\x02\n" +
+	"\x19DriverLicenseVerification\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12\x19\n" +
+	"\bis_valid\x18\x03 \x01(\bR\aisValid\x12\x1d\n" +
+	"\n" +
+	"is_expired\x18\x04 \x01(\bR\tisExpired\x12A\n" +
+	"\x0elicense_expiry\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlicenseExpiry\x129\n" +
+	"\x19days_until_license_expiry\x18\x06 \x01(\x05R\x16daysUntilLicenseExpiry\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\"\xcd\x01\n" +
+	"!BatchVerifyDriverLicensesResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .staff.DriverLicenseVerificationR\aresults\x12/\n" +
+	"\x13verification_source\x18\x02 \x01(\tR\x12verificationSource\x12;\n" +
+	"\vverified_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\"w\n" +
 	"\x1aGetExpiringLicensesRequest\x12\x1d\n" +
 	"\n" +
 	"days_ahead\x18\x01 \x01(\x05R\tdaysAhead\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"~\n" +
+	"!GetRecentlyExpiredLicensesRequest\x12\x1d\n" +
+	"\n" +
+	"since_days\x18\x01 \x01(\x05R\tsinceDays\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
 	"page_token\x18\x03 \x01(\tR\tpageToken\"\xa7\x01\n" +
 	"\x1fGetExpiredCertificationsRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\x121\n" +
 	"\x12expired_since_days\x18\x03 \x01(\x05H\x00R\x10expiredSinceDays\x88\x01\x01B\x15\n" +
-	"\x13_expired_since_days*i\n" +
+	"\x13_expired_since_days\"?\n" +
+	"\x1aValidatePhoneNumberRequest\x12!\n" +
+	"\fphone_number\x18\x01 \x01(\tR\vphoneNumber\"E\n" +
+	"\x1cValidateLicenseNumberRequest\x12%\n" +
+	"\x0elicense_number\x18\x01 \x01(\tR\rlicenseNumber\"g\n" +
+	"\x17FieldValidationResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x1e\n" +
+	"\n" +
+	"normalized\x18\x02 \x01(\tR\n" +
+	"normalized\x12\x16\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors\"W\n" +
+	"\x1dNormalizeLegacyRecordsRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\"a\n" +
+	"\x0fNormalizedField\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"h\n" +
+	"\x10NormalizedRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x06fields\x18\x02 \x03(\v2\x16.staff.NormalizedFieldR\x06fields\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xe5\x01\n" +
+	"\x1eNormalizeLegacyRecordsResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\x05R\ascanned\x12\x18\n" +
+	"\achanged\x18\x03 \x01(\x05R\achanged\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x121\n" +
+	"\arecords\x18\x05 \x03(\v2\x17.staff.NormalizedRecordR\arecords\x12+\n" +
+	"\x11records_truncated\x18\x06 \x01(\bR\x10recordsTruncated*i\n" +
 	"\fDriverStatus\x12\x16\n" +
 	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14PENDING_VERIFICATION\x10\x01\x12\n" +
@@ -2128,24 +4477,41 @@ const file_staff_proto_rawDesc = "" +
 	"\vCERT_ACTIVE\x10\x01\x12\x10\n" +
 	"\fCERT_EXPIRED\x10\x02\x12\x12\n" +
 	"\x0eCERT_SUSPENDED\x10\x03\x12\x10\n" +
-	"\fCERT_REVOKED\x10\x042\x8a\n" +
-	"\n" +
+	"\fCERT_REVOKED\x10\x042\xe4\x17\n" +
 	"\fStaffService\x12G\n" +
 	"\fCreateDriver\x12\x1a.staff.CreateDriverRequest\x1a\x1b.staff.CreateDriverResponse\x12>\n" +
 	"\tGetDriver\x12\x17.staff.GetDriverRequest\x1a\x18.staff.GetDriverResponse\x12N\n" +
-	"\x11GetDriverByUserID\x12\x1f.staff.GetDriverByUserIDRequest\x1a\x18.staff.GetDriverResponse\x12D\n" +
+	"\x11GetDriverByUserID\x12\x1f.staff.GetDriverByUserIDRequest\x1a\x18.staff.GetDriverResponse\x12\\\n" +
+	"\x13GetDriversByUserIDs\x12!.staff.GetDriversByUserIDsRequest\x1a\".staff.GetDriversByUserIDsResponse\x12D\n" +
 	"\vListDrivers\x12\x19.staff.ListDriversRequest\x1a\x1a.staff.ListDriversResponse\x12G\n" +
 	"\fUpdateDriver\x12\x1a.staff.UpdateDriverRequest\x1a\x1b.staff.UpdateDriverResponse\x12B\n" +
-	"\fDeleteDriver\x12\x1a.staff.DeleteDriverRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
-	"\x12UpdateDriverStatus\x12 .staff.UpdateDriverStatusRequest\x1a!.staff.UpdateDriverStatusResponse\x12N\n" +
-	"\x10GetActiveDrivers\x12\x1e.staff.GetActiveDriversRequest\x1a\x1a.staff.ListDriversResponse\x12e\n" +
+	"\fDeleteDriver\x12\x1a.staff.DeleteDriverRequest\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\rRestoreDriver\x12\x1b.staff.RestoreDriverRequest\x1a\x1c.staff.RestoreDriverResponse\x12S\n" +
+	"\x10HardDeleteDriver\x12\x1e.staff.HardDeleteDriverRequest\x1a\x1f.staff.HardDeleteDriverResponse\x12G\n" +
+	"\fMergeDrivers\x12\x1a.staff.MergeDriversRequest\x1a\x1b.staff.MergeDriversResponse\x12Y\n" +
+	"\x12UpdateDriverRating\x12 .staff.UpdateDriverRatingRequest\x1a!.staff.UpdateDriverRatingResponse\x12\\\n" +
+	"\x13AcknowledgeHandbook\x12!.staff.AcknowledgeHandbookRequest\x1a\".staff.AcknowledgeHandbookResponse\x12Y\n" +
+	"\x12UpdateDriverStatus\x12 .staff.UpdateDriverStatusRequest\x1a!.staff.UpdateDriverStatusResponse\x12Y\n" +
+	"\x12RenewDriverLicense\x12 .staff.RenewDriverLicenseRequest\x1a!.staff.RenewDriverLicenseResponse\x12q\n" +
+	"\x1aValidateDriverStatusChange\x12(.staff.ValidateDriverStatusChangeRequest\x1a).staff.ValidateDriverStatusChangeResponse\x12h\n" +
+	"\x17ListDriverStatusHistory\x12%.staff.ListDriverStatusHistoryRequest\x1a&.staff.ListDriverStatusHistoryResponse\x12N\n" +
+	"\x10GetActiveDrivers\x12\x1e.staff.GetActiveDriversRequest\x1a\x1a.staff.ListDriversResponse\x12n\n" +
+	" GetEligibleDriversForVehicleType\x12..staff.GetEligibleDriversForVehicleTypeRequest\x1a\x1a.staff.ListDriversResponse\x12e\n" +
+	"\x16CheckDriverEligibility\x12$.staff.CheckDriverEligibilityRequest\x1a%.staff.CheckDriverEligibilityResponse\x12b\n" +
+	"\x1aListRecentlyUpdatedDrivers\x12(.staff.ListRecentlyUpdatedDriversRequest\x1a\x1a.staff.ListDriversResponse\x12e\n" +
 	"\x16AddDriverCertification\x12$.staff.AddDriverCertificationRequest\x1a%.staff.AddDriverCertificationResponse\x12k\n" +
 	"\x18ListDriverCertifications\x12&.staff.ListDriverCertificationsRequest\x1a'.staff.ListDriverCertificationsResponse\x12\\\n" +
 	"\x13UpdateCertification\x12!.staff.UpdateCertificationRequest\x1a\".staff.UpdateCertificationResponse\x12P\n" +
-	"\x13DeleteCertification\x12!.staff.DeleteCertificationRequest\x1a\x16.google.protobuf.Empty\x12\\\n" +
-	"\x13VerifyDriverLicense\x12!.staff.VerifyDriverLicenseRequest\x1a\".staff.VerifyDriverLicenseResponse\x12T\n" +
-	"\x13GetExpiringLicenses\x12!.staff.GetExpiringLicensesRequest\x1a\x1a.staff.ListDriversResponse\x12k\n" +
-	"\x18GetExpiredCertifications\x12&.staff.GetExpiredCertificationsRequest\x1a'.staff.ListDriverCertificationsResponseB9Z7github.com/adammwaniki/bebabeba/services/staff/genprotob\x06proto3"
+	"\x13DeleteCertification\x12!.staff.DeleteCertificationRequest\x1a\x16.google.protobuf.Empty\x12q\n" +
+	"\x1aListCertificationTemplates\x12(.staff.ListCertificationTemplatesRequest\x1a).staff.ListCertificationTemplatesResponse\x12\\\n" +
+	"\x13VerifyDriverLicense\x12!.staff.VerifyDriverLicenseRequest\x1a\".staff.VerifyDriverLicenseResponse\x12n\n" +
+	"\x19BatchVerifyDriverLicenses\x12'.staff.BatchVerifyDriverLicensesRequest\x1a(.staff.BatchVerifyDriverLicensesResponse\x12T\n" +
+	"\x13GetExpiringLicenses\x12!.staff.GetExpiringLicensesRequest\x1a\x1a.staff.ListDriversResponse\x12b\n" +
+	"\x1aGetRecentlyExpiredLicenses\x12(.staff.GetRecentlyExpiredLicensesRequest\x1a\x1a.staff.ListDriversResponse\x12k\n" +
+	"\x18GetExpiredCertifications\x12&.staff.GetExpiredCertificationsRequest\x1a'.staff.ListDriverCertificationsResponse\x12X\n" +
+	"\x13ValidatePhoneNumber\x12!.staff.ValidatePhoneNumberRequest\x1a\x1e.staff.FieldValidationResponse\x12\\\n" +
+	"\x15ValidateLicenseNumber\x12#.staff.ValidateLicenseNumberRequest\x1a\x1e.staff.FieldValidationResponse\x12e\n" +
+	"\x16NormalizeLegacyRecords\x12$.staff.NormalizeLegacyRecordsRequest\x1a%.staff.NormalizeLegacyRecordsResponseB9Z7github.com/adammwaniki/bebabeba/services/staff/genprotob\x06proto3"
 
 var (
 	file_staff_proto_rawDescOnce sync.Once
@@ -2160,116 +4526,214 @@ func file_staff_proto_rawDescGZIP() []byte {
 }
 
 var file_staff_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_staff_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_staff_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_staff_proto_goTypes = []any{
-	(DriverStatus)(0),                        // 0: staff.DriverStatus
-	(LicenseClass)(0),                        // 1: staff.LicenseClass
-	(CertificationStatus)(0),                 // 2: staff.CertificationStatus
-	(*Driver)(nil),                           // 3: staff.Driver
-	(*DriverInput)(nil),                      // 4: staff.DriverInput
-	(*CreateDriverRequest)(nil),              // 5: staff.CreateDriverRequest
-	(*CreateDriverResponse)(nil),             // 6: staff.CreateDriverResponse
-	(*GetDriverRequest)(nil),                 // 7: staff.GetDriverRequest
-	(*GetDriverByUserIDRequest)(nil),         // 8: staff.GetDriverByUserIDRequest
-	(*GetDriverResponse)(nil),                // 9: staff.GetDriverResponse
-	(*ListDriversRequest)(nil),               // 10: staff.ListDriversRequest
-	(*ListDriversResponse)(nil),              // 11: staff.ListDriversResponse
-	(*UpdateDriverRequest)(nil),              // 12: staff.UpdateDriverRequest
-	(*UpdateDriverResponse)(nil),             // 13: staff.UpdateDriverResponse
-	(*DeleteDriverRequest)(nil),              // 14: staff.DeleteDriverRequest
-	(*UpdateDriverStatusRequest)(nil),        // 15: staff.UpdateDriverStatusRequest
-	(*UpdateDriverStatusResponse)(nil),       // 16: staff.UpdateDriverStatusResponse
-	(*GetActiveDriversRequest)(nil),          // 17: staff.GetActiveDriversRequest
-	(*DriverCertification)(nil),              // 18: staff.DriverCertification
-	(*CertificationInput)(nil),               // 19: staff.CertificationInput
-	(*AddDriverCertificationRequest)(nil),    // 20: staff.AddDriverCertificationRequest
-	(*AddDriverCertificationResponse)(nil),   // 21: staff.AddDriverCertificationResponse
-	(*ListDriverCertificationsRequest)(nil),  // 22: staff.ListDriverCertificationsRequest
-	(*ListDriverCertificationsResponse)(nil), // 23: staff.ListDriverCertificationsResponse
-	(*UpdateCertificationRequest)(nil),       // 24: staff.UpdateCertificationRequest
-	(*UpdateCertificationResponse)(nil),      // 25: staff.UpdateCertificationResponse
-	(*DeleteCertificationRequest)(nil),       // 26: staff.DeleteCertificationRequest
-	(*VerifyDriverLicenseRequest)(nil),       // 27: staff.VerifyDriverLicenseRequest
-	(*VerifyDriverLicenseResponse)(nil),      // 28: staff.VerifyDriverLicenseResponse
-	(*GetExpiringLicensesRequest)(nil),       // 29: staff.GetExpiringLicensesRequest
-	(*GetExpiredCertificationsRequest)(nil),  // 30: staff.GetExpiredCertificationsRequest
-	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),            // 32: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),                    // 33: google.protobuf.Empty
+	(DriverStatus)(0),                               // 0: staff.DriverStatus
+	(LicenseClass)(0),                               // 1: staff.LicenseClass
+	(CertificationStatus)(0),                        // 2: staff.CertificationStatus
+	(*Driver)(nil),                                  // 3: staff.Driver
+	(*DriverInput)(nil),                             // 4: staff.DriverInput
+	(*CreateDriverRequest)(nil),                     // 5: staff.CreateDriverRequest
+	(*CreateDriverResponse)(nil),                    // 6: staff.CreateDriverResponse
+	(*GetDriverRequest)(nil),                        // 7: staff.GetDriverRequest
+	(*GetDriverByUserIDRequest)(nil),                // 8: staff.GetDriverByUserIDRequest
+	(*GetDriverResponse)(nil),                       // 9: staff.GetDriverResponse
+	(*GetDriversByUserIDsRequest)(nil),              // 10: staff.GetDriversByUserIDsRequest
+	(*GetDriversByUserIDsResponse)(nil),             // 11: staff.GetDriversByUserIDsResponse
+	(*ListDriversRequest)(nil),                      // 12: staff.ListDriversRequest
+	(*ListDriversResponse)(nil),                     // 13: staff.ListDriversResponse
+	(*UpdateDriverRequest)(nil),                     // 14: staff.UpdateDriverRequest
+	(*UpdateDriverResponse)(nil),                    // 15: staff.UpdateDriverResponse
+	(*NormalizationWarning)(nil),                    // 16: staff.NormalizationWarning
+	(*DeleteDriverRequest)(nil),                     // 17: staff.DeleteDriverRequest
+	(*RestoreDriverRequest)(nil),                    // 18: staff.RestoreDriverRequest
+	(*RestoreDriverResponse)(nil),                   // 19: staff.RestoreDriverResponse
+	(*HardDeleteDriverRequest)(nil),                 // 20: staff.HardDeleteDriverRequest
+	(*HardDeleteDriverResponse)(nil),                // 21: staff.HardDeleteDriverResponse
+	(*MergeDriversRequest)(nil),                     // 22: staff.MergeDriversRequest
+	(*MergeDriversResponse)(nil),                    // 23: staff.MergeDriversResponse
+	(*UpdateDriverRatingRequest)(nil),               // 24: staff.UpdateDriverRatingRequest
+	(*UpdateDriverRatingResponse)(nil),              // 25: staff.UpdateDriverRatingResponse
+	(*AcknowledgeHandbookRequest)(nil),              // 26: staff.AcknowledgeHandbookRequest
+	(*AcknowledgeHandbookResponse)(nil),             // 27: staff.AcknowledgeHandbookResponse
+	(*UpdateDriverStatusRequest)(nil),               // 28: staff.UpdateDriverStatusRequest
+	(*UpdateDriverStatusResponse)(nil),              // 29: staff.UpdateDriverStatusResponse
+	(*RenewDriverLicenseRequest)(nil),               // 30: staff.RenewDriverLicenseRequest
+	(*RenewDriverLicenseResponse)(nil),              // 31: staff.RenewDriverLicenseResponse
+	(*DriverStatusHistoryEntry)(nil),                // 32: staff.DriverStatusHistoryEntry
+	(*ListDriverStatusHistoryRequest)(nil),          // 33: staff.ListDriverStatusHistoryRequest
+	(*ListDriverStatusHistoryResponse)(nil),         // 34: staff.ListDriverStatusHistoryResponse
+	(*ValidateDriverStatusChangeRequest)(nil),       // 35: staff.ValidateDriverStatusChangeRequest
+	(*ValidateDriverStatusChangeResponse)(nil),      // 36: staff.ValidateDriverStatusChangeResponse
+	(*GetActiveDriversRequest)(nil),                 // 37: staff.GetActiveDriversRequest
+	(*GetEligibleDriversForVehicleTypeRequest)(nil), // 38: staff.GetEligibleDriversForVehicleTypeRequest
+	(*CheckDriverEligibilityRequest)(nil),           // 39: staff.CheckDriverEligibilityRequest
+	(*CheckDriverEligibilityResponse)(nil),          // 40: staff.CheckDriverEligibilityResponse
+	(*ListRecentlyUpdatedDriversRequest)(nil),       // 41: staff.ListRecentlyUpdatedDriversRequest
+	(*DriverCertification)(nil),                     // 42: staff.DriverCertification
+	(*CertificationInput)(nil),                      // 43: staff.CertificationInput
+	(*AddDriverCertificationRequest)(nil),           // 44: staff.AddDriverCertificationRequest
+	(*AddDriverCertificationResponse)(nil),          // 45: staff.AddDriverCertificationResponse
+	(*ListDriverCertificationsRequest)(nil),         // 46: staff.ListDriverCertificationsRequest
+	(*ListDriverCertificationsResponse)(nil),        // 47: staff.ListDriverCertificationsResponse
+	(*UpdateCertificationRequest)(nil),              // 48: staff.UpdateCertificationRequest
+	(*UpdateCertificationResponse)(nil),             // 49: staff.UpdateCertificationResponse
+	(*DeleteCertificationRequest)(nil),              // 50: staff.DeleteCertificationRequest
+	(*CertificationTemplate)(nil),                   // 51: staff.CertificationTemplate
+	(*ListCertificationTemplatesRequest)(nil),       // 52: staff.ListCertificationTemplatesRequest
+	(*ListCertificationTemplatesResponse)(nil),      // 53: staff.ListCertificationTemplatesResponse
+	(*VerifyDriverLicenseRequest)(nil),              // 54: staff.VerifyDriverLicenseRequest
+	(*VerifyDriverLicenseResponse)(nil),             // 55: staff.VerifyDriverLicenseResponse
+	(*BatchVerifyDriverLicensesRequest)(nil),        // 56: staff.BatchVerifyDriverLicensesRequest
+	(*DriverLicenseVerification)(nil),               // 57: staff.DriverLicenseVerification
+	(*BatchVerifyDriverLicensesResponse)(nil),       // 58: staff.BatchVerifyDriverLicensesResponse
+	(*GetExpiringLicensesRequest)(nil),              // 59: staff.GetExpiringLicensesRequest
+	(*GetRecentlyExpiredLicensesRequest)(nil),       // 60: staff.GetRecentlyExpiredLicensesRequest
+	(*GetExpiredCertificationsRequest)(nil),         // 61: staff.GetExpiredCertificationsRequest
+	(*ValidatePhoneNumberRequest)(nil),              // 62: staff.ValidatePhoneNumberRequest
+	(*ValidateLicenseNumberRequest)(nil),            // 63: staff.ValidateLicenseNumberRequest
+	(*FieldValidationResponse)(nil),                 // 64: staff.FieldValidationResponse
+	(*NormalizeLegacyRecordsRequest)(nil),           // 65: staff.NormalizeLegacyRecordsRequest
+	(*NormalizedField)(nil),                         // 66: staff.NormalizedField
+	(*NormalizedRecord)(nil),                        // 67: staff.NormalizedRecord
+	(*NormalizeLegacyRecordsResponse)(nil),          // 68: staff.NormalizeLegacyRecordsResponse
+	nil,                                             // 69: staff.GetDriversByUserIDsResponse.DriversEntry
+	(*timestamppb.Timestamp)(nil),                   // 70: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),                   // 71: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                           // 72: google.protobuf.Empty
 }
 var file_staff_proto_depIdxs = []int32{
 	1,  // 0: staff.Driver.license_class:type_name -> staff.LicenseClass
-	31, // 1: staff.Driver.license_expiry:type_name -> google.protobuf.Timestamp
+	70, // 1: staff.Driver.license_expiry:type_name -> google.protobuf.Timestamp
 	0,  // 2: staff.Driver.status:type_name -> staff.DriverStatus
-	31, // 3: staff.Driver.hire_date:type_name -> google.protobuf.Timestamp
-	31, // 4: staff.Driver.created_at:type_name -> google.protobuf.Timestamp
-	31, // 5: staff.Driver.updated_at:type_name -> google.protobuf.Timestamp
-	18, // 6: staff.Driver.certifications:type_name -> staff.DriverCertification
-	1,  // 7: staff.DriverInput.license_class:type_name -> staff.LicenseClass
-	31, // 8: staff.DriverInput.license_expiry:type_name -> google.protobuf.Timestamp
-	31, // 9: staff.DriverInput.hire_date:type_name -> google.protobuf.Timestamp
-	4,  // 10: staff.CreateDriverRequest.driver:type_name -> staff.DriverInput
-	3,  // 11: staff.CreateDriverResponse.driver:type_name -> staff.Driver
-	3,  // 12: staff.GetDriverResponse.driver:type_name -> staff.Driver
-	0,  // 13: staff.ListDriversRequest.status_filter:type_name -> staff.DriverStatus
-	1,  // 14: staff.ListDriversRequest.license_class_filter:type_name -> staff.LicenseClass
-	3,  // 15: staff.ListDriversResponse.drivers:type_name -> staff.Driver
-	4,  // 16: staff.UpdateDriverRequest.driver:type_name -> staff.DriverInput
-	32, // 17: staff.UpdateDriverRequest.update_mask:type_name -> google.protobuf.FieldMask
-	3,  // 18: staff.UpdateDriverResponse.driver:type_name -> staff.Driver
-	0,  // 19: staff.UpdateDriverStatusRequest.status:type_name -> staff.DriverStatus
-	3,  // 20: staff.UpdateDriverStatusResponse.driver:type_name -> staff.Driver
-	1,  // 21: staff.GetActiveDriversRequest.license_class_filter:type_name -> staff.LicenseClass
-	31, // 22: staff.DriverCertification.issue_date:type_name -> google.protobuf.Timestamp
-	31, // 23: staff.DriverCertification.expiry_date:type_name -> google.protobuf.Timestamp
-	2,  // 24: staff.DriverCertification.status:type_name -> staff.CertificationStatus
-	31, // 25: staff.DriverCertification.created_at:type_name -> google.protobuf.Timestamp
-	31, // 26: staff.DriverCertification.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 27: staff.CertificationInput.issue_date:type_name -> google.protobuf.Timestamp
-	31, // 28: staff.CertificationInput.expiry_date:type_name -> google.protobuf.Timestamp
-	19, // 29: staff.AddDriverCertificationRequest.certification:type_name -> staff.CertificationInput
-	18, // 30: staff.AddDriverCertificationResponse.certification:type_name -> staff.DriverCertification
-	2,  // 31: staff.ListDriverCertificationsRequest.status_filter:type_name -> staff.CertificationStatus
-	18, // 32: staff.ListDriverCertificationsResponse.certifications:type_name -> staff.DriverCertification
-	19, // 33: staff.UpdateCertificationRequest.certification:type_name -> staff.CertificationInput
-	32, // 34: staff.UpdateCertificationRequest.update_mask:type_name -> google.protobuf.FieldMask
-	18, // 35: staff.UpdateCertificationResponse.certification:type_name -> staff.DriverCertification
-	31, // 36: staff.VerifyDriverLicenseResponse.verified_at:type_name -> google.protobuf.Timestamp
-	5,  // 37: staff.StaffService.CreateDriver:input_type -> staff.CreateDriverRequest
-	7,  // 38: staff.StaffService.GetDriver:input_type -> staff.GetDriverRequest
-	8,  // 39: staff.StaffService.GetDriverByUserID:input_type -> staff.GetDriverByUserIDRequest
-	10, // 40: staff.StaffService.ListDrivers:input_type -> staff.ListDriversRequest
-	12, // 41: staff.StaffService.UpdateDriver:input_type -> staff.UpdateDriverRequest
-	14, // 42: staff.StaffService.DeleteDriver:input_type -> staff.DeleteDriverRequest
-	15, // 43: staff.StaffService.UpdateDriverStatus:input_type -> staff.UpdateDriverStatusRequest
-	17, // 44: staff.StaffService.GetActiveDrivers:input_type -> staff.GetActiveDriversRequest
-	20, // 45: staff.StaffService.AddDriverCertification:input_type -> staff.AddDriverCertificationRequest
-	22, // 46: staff.StaffService.ListDriverCertifications:input_type -> staff.ListDriverCertificationsRequest
-	24, // 47: staff.StaffService.UpdateCertification:input_type -> staff.UpdateCertificationRequest
-	26, // 48: staff.StaffService.DeleteCertification:input_type -> staff.DeleteCertificationRequest
-	27, // 49: staff.StaffService.VerifyDriverLicense:input_type -> staff.VerifyDriverLicenseRequest
-	29, // 50: staff.StaffService.GetExpiringLicenses:input_type -> staff.GetExpiringLicensesRequest
-	30, // 51: staff.StaffService.GetExpiredCertifications:input_type -> staff.GetExpiredCertificationsRequest
-	6,  // 52: staff.StaffService.CreateDriver:output_type -> staff.CreateDriverResponse
-	9,  // 53: staff.StaffService.GetDriver:output_type -> staff.GetDriverResponse
-	9,  // 54: staff.StaffService.GetDriverByUserID:output_type -> staff.GetDriverResponse
-	11, // 55: staff.StaffService.ListDrivers:output_type -> staff.ListDriversResponse
-	13, // 56: staff.StaffService.UpdateDriver:output_type -> staff.UpdateDriverResponse
-	33, // 57: staff.StaffService.DeleteDriver:output_type -> google.protobuf.Empty
-	16, // 58: staff.StaffService.UpdateDriverStatus:output_type -> staff.UpdateDriverStatusResponse
-	11, // 59: staff.StaffService.GetActiveDrivers:output_type -> staff.ListDriversResponse
-	21, // 60: staff.StaffService.AddDriverCertification:output_type -> staff.AddDriverCertificationResponse
-	23, // 61: staff.StaffService.ListDriverCertifications:output_type -> staff.ListDriverCertificationsResponse
-	25, // 62: staff.StaffService.UpdateCertification:output_type -> staff.UpdateCertificationResponse
-	33, // 63: staff.StaffService.DeleteCertification:output_type -> google.protobuf.Empty
-	28, // 64: staff.StaffService.VerifyDriverLicense:output_type -> staff.VerifyDriverLicenseResponse
-	11, // 65: staff.StaffService.GetExpiringLicenses:output_type -> staff.ListDriversResponse
-	23, // 66: staff.StaffService.GetExpiredCertifications:output_type -> staff.ListDriverCertificationsResponse
-	52, // [52:67] is the sub-list for method output_type
-	37, // [37:52] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	70, // 3: staff.Driver.hire_date:type_name -> google.protobuf.Timestamp
+	70, // 4: staff.Driver.created_at:type_name -> google.protobuf.Timestamp
+	70, // 5: staff.Driver.updated_at:type_name -> google.protobuf.Timestamp
+	70, // 6: staff.Driver.handbook_acknowledged_at:type_name -> google.protobuf.Timestamp
+	42, // 7: staff.Driver.certifications:type_name -> staff.DriverCertification
+	1,  // 8: staff.DriverInput.license_class:type_name -> staff.LicenseClass
+	70, // 9: staff.DriverInput.license_expiry:type_name -> google.protobuf.Timestamp
+	70, // 10: staff.DriverInput.hire_date:type_name -> google.protobuf.Timestamp
+	4,  // 11: staff.CreateDriverRequest.driver:type_name -> staff.DriverInput
+	3,  // 12: staff.CreateDriverResponse.driver:type_name -> staff.Driver
+	3,  // 13: staff.GetDriverResponse.driver:type_name -> staff.Driver
+	69, // 14: staff.GetDriversByUserIDsResponse.drivers:type_name -> staff.GetDriversByUserIDsResponse.DriversEntry
+	0,  // 15: staff.ListDriversRequest.status_filter:type_name -> staff.DriverStatus
+	1,  // 16: staff.ListDriversRequest.license_class_filter:type_name -> staff.LicenseClass
+	3,  // 17: staff.ListDriversResponse.drivers:type_name -> staff.Driver
+	4,  // 18: staff.UpdateDriverRequest.driver:type_name -> staff.DriverInput
+	71, // 19: staff.UpdateDriverRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 20: staff.UpdateDriverResponse.driver:type_name -> staff.Driver
+	16, // 21: staff.UpdateDriverResponse.normalization_warnings:type_name -> staff.NormalizationWarning
+	3,  // 22: staff.RestoreDriverResponse.driver:type_name -> staff.Driver
+	3,  // 23: staff.MergeDriversResponse.driver:type_name -> staff.Driver
+	3,  // 24: staff.UpdateDriverRatingResponse.driver:type_name -> staff.Driver
+	3,  // 25: staff.AcknowledgeHandbookResponse.driver:type_name -> staff.Driver
+	0,  // 26: staff.UpdateDriverStatusRequest.status:type_name -> staff.DriverStatus
+	3,  // 27: staff.UpdateDriverStatusResponse.driver:type_name -> staff.Driver
+	70, // 28: staff.RenewDriverLicenseRequest.new_expiry:type_name -> google.protobuf.Timestamp
+	3,  // 29: staff.RenewDriverLicenseResponse.driver:type_name -> staff.Driver
+	0,  // 30: staff.DriverStatusHistoryEntry.previous_status:type_name -> staff.DriverStatus
+	0,  // 31: staff.DriverStatusHistoryEntry.new_status:type_name -> staff.DriverStatus
+	70, // 32: staff.DriverStatusHistoryEntry.changed_at:type_name -> google.protobuf.Timestamp
+	32, // 33: staff.ListDriverStatusHistoryResponse.entries:type_name -> staff.DriverStatusHistoryEntry
+	0,  // 34: staff.ValidateDriverStatusChangeRequest.status:type_name -> staff.DriverStatus
+	0,  // 35: staff.ValidateDriverStatusChangeResponse.current_status:type_name -> staff.DriverStatus
+	1,  // 36: staff.GetActiveDriversRequest.license_class_filter:type_name -> staff.LicenseClass
+	3,  // 37: staff.CheckDriverEligibilityResponse.driver:type_name -> staff.Driver
+	70, // 38: staff.DriverCertification.issue_date:type_name -> google.protobuf.Timestamp
+	70, // 39: staff.DriverCertification.expiry_date:type_name -> google.protobuf.Timestamp
+	2,  // 40: staff.DriverCertification.status:type_name -> staff.CertificationStatus
+	70, // 41: staff.DriverCertification.created_at:type_name -> google.protobuf.Timestamp
+	70, // 42: staff.DriverCertification.updated_at:type_name -> google.protobuf.Timestamp
+	70, // 43: staff.CertificationInput.issue_date:type_name -> google.protobuf.Timestamp
+	70, // 44: staff.CertificationInput.expiry_date:type_name -> google.protobuf.Timestamp
+	43, // 45: staff.AddDriverCertificationRequest.certification:type_name -> staff.CertificationInput
+	42, // 46: staff.AddDriverCertificationResponse.certification:type_name -> staff.DriverCertification
+	2,  // 47: staff.ListDriverCertificationsRequest.status_filter:type_name -> staff.CertificationStatus
+	42, // 48: staff.ListDriverCertificationsResponse.certifications:type_name -> staff.DriverCertification
+	43, // 49: staff.UpdateCertificationRequest.certification:type_name -> staff.CertificationInput
+	71, // 50: staff.UpdateCertificationRequest.update_mask:type_name -> google.protobuf.FieldMask
+	42, // 51: staff.UpdateCertificationResponse.certification:type_name -> staff.DriverCertification
+	51, // 52: staff.ListCertificationTemplatesResponse.templates:type_name -> staff.CertificationTemplate
+	70, // 53: staff.VerifyDriverLicenseResponse.verified_at:type_name -> google.protobuf.Timestamp
+	70, // 54: staff.DriverLicenseVerification.license_expiry:type_name -> google.protobuf.Timestamp
+	57, // 55: staff.BatchVerifyDriverLicensesResponse.results:type_name -> staff.DriverLicenseVerification
+	70, // 56: staff.BatchVerifyDriverLicensesResponse.verified_at:type_name -> google.protobuf.Timestamp
+	66, // 57: staff.NormalizedRecord.fields:type_name -> staff.NormalizedField
+	67, // 58: staff.NormalizeLegacyRecordsResponse.records:type_name -> staff.NormalizedRecord
+	3,  // 59: staff.GetDriversByUserIDsResponse.DriversEntry.value:type_name -> staff.Driver
+	5,  // 60: staff.StaffService.CreateDriver:input_type -> staff.CreateDriverRequest
+	7,  // 61: staff.StaffService.GetDriver:input_type -> staff.GetDriverRequest
+	8,  // 62: staff.StaffService.GetDriverByUserID:input_type -> staff.GetDriverByUserIDRequest
+	10, // 63: staff.StaffService.GetDriversByUserIDs:input_type -> staff.GetDriversByUserIDsRequest
+	12, // 64: staff.StaffService.ListDrivers:input_type -> staff.ListDriversRequest
+	14, // 65: staff.StaffService.UpdateDriver:input_type -> staff.UpdateDriverRequest
+	17, // 66: staff.StaffService.DeleteDriver:input_type -> staff.DeleteDriverRequest
+	18, // 67: staff.StaffService.RestoreDriver:input_type -> staff.RestoreDriverRequest
+	20, // 68: staff.StaffService.HardDeleteDriver:input_type -> staff.HardDeleteDriverRequest
+	22, // 69: staff.StaffService.MergeDrivers:input_type -> staff.MergeDriversRequest
+	24, // 70: staff.StaffService.UpdateDriverRating:input_type -> staff.UpdateDriverRatingRequest
+	26, // 71: staff.StaffService.AcknowledgeHandbook:input_type -> staff.AcknowledgeHandbookRequest
+	28, // 72: staff.StaffService.UpdateDriverStatus:input_type -> staff.UpdateDriverStatusRequest
+	30, // 73: staff.StaffService.RenewDriverLicense:input_type -> staff.RenewDriverLicenseRequest
+	35, // 74: staff.StaffService.ValidateDriverStatusChange:input_type -> staff.ValidateDriverStatusChangeRequest
+	33, // 75: staff.StaffService.ListDriverStatusHistory:input_type -> staff.ListDriverStatusHistoryRequest
+	37, // 76: staff.StaffService.GetActiveDrivers:input_type -> staff.GetActiveDriversRequest
+	38, // 77: staff.StaffService.GetEligibleDriversForVehicleType:input_type -> staff.GetEligibleDriversForVehicleTypeRequest
+	39, // 78: staff.StaffService.CheckDriverEligibility:input_type -> staff.CheckDriverEligibilityRequest
+	41, // 79: staff.StaffService.ListRecentlyUpdatedDrivers:input_type -> staff.ListRecentlyUpdatedDriversRequest
+	44, // 80: staff.StaffService.AddDriverCertification:input_type -> staff.AddDriverCertificationRequest
+	46, // 81: staff.StaffService.ListDriverCertifications:input_type -> staff.ListDriverCertificationsRequest
+	48, // 82: staff.StaffService.UpdateCertification:input_type -> staff.UpdateCertificationRequest
+	50, // 83: staff.StaffService.DeleteCertification:input_type -> staff.DeleteCertificationRequest
+	52, // 84: staff.StaffService.ListCertificationTemplates:input_type -> staff.ListCertificationTemplatesRequest
+	54, // 85: staff.StaffService.VerifyDriverLicense:input_type -> staff.VerifyDriverLicenseRequest
+	56, // 86: staff.StaffService.BatchVerifyDriverLicenses:input_type -> staff.BatchVerifyDriverLicensesRequest
+	59, // 87: staff.StaffService.GetExpiringLicenses:input_type -> staff.GetExpiringLicensesRequest
+	60, // 88: staff.StaffService.GetRecentlyExpiredLicenses:input_type -> staff.GetRecentlyExpiredLicensesRequest
+	61, // 89: staff.StaffService.GetExpiredCertifications:input_type -> staff.GetExpiredCertificationsRequest
+	62, // 90: staff.StaffService.ValidatePhoneNumber:input_type -> staff.ValidatePhoneNumberRequest
+	63, // 91: staff.StaffService.ValidateLicenseNumber:input_type -> staff.ValidateLicenseNumberRequest
+	65, // 92: staff.StaffService.NormalizeLegacyRecords:input_type -> staff.NormalizeLegacyRecordsRequest
+	6,  // 93: staff.StaffService.CreateDriver:output_type -> staff.CreateDriverResponse
+	9,  // 94: staff.StaffService.GetDriver:output_type -> staff.GetDriverResponse
+	9,  // 95: staff.StaffService.GetDriverByUserID:output_type -> staff.GetDriverResponse
+	11, // 96: staff.StaffService.GetDriversByUserIDs:output_type -> staff.GetDriversByUserIDsResponse
+	13, // 97: staff.StaffService.ListDrivers:output_type -> staff.ListDriversResponse
+	15, // 98: staff.StaffService.UpdateDriver:output_type -> staff.UpdateDriverResponse
+	72, // 99: staff.StaffService.DeleteDriver:output_type -> google.protobuf.Empty
+	19, // 100: staff.StaffService.RestoreDriver:output_type -> staff.RestoreDriverResponse
+	21, // 101: staff.StaffService.HardDeleteDriver:output_type -> staff.HardDeleteDriverResponse
+	23, // 102: staff.StaffService.MergeDrivers:output_type -> staff.MergeDriversResponse
+	25, // 103: staff.StaffService.UpdateDriverRating:output_type -> staff.UpdateDriverRatingResponse
+	27, // 104: staff.StaffService.AcknowledgeHandbook:output_type -> staff.AcknowledgeHandbookResponse
+	29, // 105: staff.StaffService.UpdateDriverStatus:output_type -> staff.UpdateDriverStatusResponse
+	31, // 106: staff.StaffService.RenewDriverLicense:output_type -> staff.RenewDriverLicenseResponse
+	36, // 107: staff.StaffService.ValidateDriverStatusChange:output_type -> staff.ValidateDriverStatusChangeResponse
+	34, // 108: staff.StaffService.ListDriverStatusHistory:output_type -> staff.ListDriverStatusHistoryResponse
+	13, // 109: staff.StaffService.GetActiveDrivers:output_type -> staff.ListDriversResponse
+	13, // 110: staff.StaffService.GetEligibleDriversForVehicleType:output_type -> staff.ListDriversResponse
+	40, // 111: staff.StaffService.CheckDriverEligibility:output_type -> staff.CheckDriverEligibilityResponse
+	13, // 112: staff.StaffService.ListRecentlyUpdatedDrivers:output_type -> staff.ListDriversResponse
+	45, // 113: staff.StaffService.AddDriverCertification:output_type -> staff.AddDriverCertificationResponse
+	47, // 114: staff.StaffService.ListDriverCertifications:output_type -> staff.ListDriverCertificationsResponse
+	49, // 115: staff.StaffService.UpdateCertification:output_type -> staff.UpdateCertificationResponse
+	72, // 116: staff.StaffService.DeleteCertification:output_type -> google.protobuf.Empty
+	53, // 117: staff.StaffService.ListCertificationTemplates:output_type -> staff.ListCertificationTemplatesResponse
+	55, // 118: staff.StaffService.VerifyDriverLicense:output_type -> staff.VerifyDriverLicenseResponse
+	58, // 119: staff.StaffService.BatchVerifyDriverLicenses:output_type -> staff.BatchVerifyDriverLicensesResponse
+	13, // 120: staff.StaffService.GetExpiringLicenses:output_type -> staff.ListDriversResponse
+	13, // 121: staff.StaffService.GetRecentlyExpiredLicenses:output_type -> staff.ListDriversResponse
+	47, // 122: staff.StaffService.GetExpiredCertifications:output_type -> staff.ListDriverCertificationsResponse
+	64, // 123: staff.StaffService.ValidatePhoneNumber:output_type -> staff.FieldValidationResponse
+	64, // 124: staff.StaffService.ValidateLicenseNumber:output_type -> staff.FieldValidationResponse
+	68, // 125: staff.StaffService.NormalizeLegacyRecords:output_type -> staff.NormalizeLegacyRecordsResponse
+	93, // [93:126] is the sub-list for method output_type
+	60, // [60:93] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_staff_proto_init() }
@@ -2278,18 +4742,19 @@ func file_staff_proto_init() {
 		return
 	}
 	file_staff_proto_msgTypes[0].OneofWrappers = []any{}
-	file_staff_proto_msgTypes[7].OneofWrappers = []any{}
-	file_staff_proto_msgTypes[14].OneofWrappers = []any{}
-	file_staff_proto_msgTypes[15].OneofWrappers = []any{}
-	file_staff_proto_msgTypes[19].OneofWrappers = []any{}
+	file_staff_proto_msgTypes[9].OneofWrappers = []any{}
 	file_staff_proto_msgTypes[27].OneofWrappers = []any{}
+	file_staff_proto_msgTypes[34].OneofWrappers = []any{}
+	file_staff_proto_msgTypes[39].OneofWrappers = []any{}
+	file_staff_proto_msgTypes[43].OneofWrappers = []any{}
+	file_staff_proto_msgTypes[58].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_staff_proto_rawDesc), len(file_staff_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   28,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,21 +22,39 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StaffService_CreateDriver_FullMethodName             = "/staff.StaffService/CreateDriver"
-	StaffService_GetDriver_FullMethodName                = "/staff.StaffService/GetDriver"
-	StaffService_GetDriverByUserID_FullMethodName        = "/staff.StaffService/GetDriverByUserID"
-	StaffService_ListDrivers_FullMethodName              = "/staff.StaffService/ListDrivers"
-	StaffService_UpdateDriver_FullMethodName             = "/staff.StaffService/UpdateDriver"
-	StaffService_DeleteDriver_FullMethodName             = "/staff.StaffService/DeleteDriver"
-	StaffService_UpdateDriverStatus_FullMethodName       = "/staff.StaffService/UpdateDriverStatus"
-	StaffService_GetActiveDrivers_FullMethodName         = "/staff.StaffService/GetActiveDrivers"
-	StaffService_AddDriverCertification_FullMethodName   = "/staff.StaffService/AddDriverCertification"
-	StaffService_ListDriverCertifications_FullMethodName = "/staff.StaffService/ListDriverCertifications"
-	StaffService_UpdateCertification_FullMethodName      = "/staff.StaffService/UpdateCertification"
-	StaffService_DeleteCertification_FullMethodName      = "/staff.StaffService/DeleteCertification"
-	StaffService_VerifyDriverLicense_FullMethodName      = "/staff.StaffService/VerifyDriverLicense"
-	StaffService_GetExpiringLicenses_FullMethodName      = "/staff.StaffService/GetExpiringLicenses"
-	StaffService_GetExpiredCertifications_FullMethodName = "/staff.StaffService/GetExpiredCertifications"
+	StaffService_CreateDriver_FullMethodName                     = "/staff.StaffService/CreateDriver"
+	StaffService_GetDriver_FullMethodName                        = "/staff.StaffService/GetDriver"
+	StaffService_GetDriverByUserID_FullMethodName                = "/staff.StaffService/GetDriverByUserID"
+	StaffService_GetDriversByUserIDs_FullMethodName              = "/staff.StaffService/GetDriversByUserIDs"
+	StaffService_ListDrivers_FullMethodName                      = "/staff.StaffService/ListDrivers"
+	StaffService_UpdateDriver_FullMethodName                     = "/staff.StaffService/UpdateDriver"
+	StaffService_DeleteDriver_FullMethodName                     = "/staff.StaffService/DeleteDriver"
+	StaffService_RestoreDriver_FullMethodName                    = "/staff.StaffService/RestoreDriver"
+	StaffService_HardDeleteDriver_FullMethodName                 = "/staff.StaffService/HardDeleteDriver"
+	StaffService_MergeDrivers_FullMethodName                     = "/staff.StaffService/MergeDrivers"
+	StaffService_UpdateDriverRating_FullMethodName               = "/staff.StaffService/UpdateDriverRating"
+	StaffService_AcknowledgeHandbook_FullMethodName              = "/staff.StaffService/AcknowledgeHandbook"
+	StaffService_UpdateDriverStatus_FullMethodName               = "/staff.StaffService/UpdateDriverStatus"
+	StaffService_RenewDriverLicense_FullMethodName               = "/staff.StaffService/RenewDriverLicense"
+	StaffService_ValidateDriverStatusChange_FullMethodName       = "/staff.StaffService/ValidateDriverStatusChange"
+	StaffService_ListDriverStatusHistory_FullMethodName          = "/staff.StaffService/ListDriverStatusHistory"
+	StaffService_GetActiveDrivers_FullMethodName                 = "/staff.StaffService/GetActiveDrivers"
+	StaffService_GetEligibleDriversForVehicleType_FullMethodName = "/staff.StaffService/GetEligibleDriversForVehicleType"
+	StaffService_CheckDriverEligibility_FullMethodName           = "/staff.StaffService/CheckDriverEligibility"
+	StaffService_ListRecentlyUpdatedDrivers_FullMethodName       = "/staff.StaffService/ListRecentlyUpdatedDrivers"
+	StaffService_AddDriverCertification_FullMethodName           = "/staff.StaffService/AddDriverCertification"
+	StaffService_ListDriverCertifications_FullMethodName         = "/staff.StaffService/ListDriverCertifications"
+	StaffService_UpdateCertification_FullMethodName              = "/staff.StaffService/UpdateCertification"
+	StaffService_DeleteCertification_FullMethodName              = "/staff.StaffService/DeleteCertification"
+	StaffService_ListCertificationTemplates_FullMethodName       = "/staff.StaffService/ListCertificationTemplates"
+	StaffService_VerifyDriverLicense_FullMethodName              = "/staff.StaffService/VerifyDriverLicense"
+	StaffService_BatchVerifyDriverLicenses_FullMethodName        = "/staff.StaffService/BatchVerifyDriverLicenses"
+	StaffService_GetExpiringLicenses_FullMethodName              = "/staff.StaffService/GetExpiringLicenses"
+	StaffService_GetRecentlyExpiredLicenses_FullMethodName       = "/staff.StaffService/GetRecentlyExpiredLicenses"
+	StaffService_GetExpiredCertifications_FullMethodName         = "/staff.StaffService/GetExpiredCertifications"
+	StaffService_ValidatePhoneNumber_FullMethodName              = "/staff.StaffService/ValidatePhoneNumber"
+	StaffService_ValidateLicenseNumber_FullMethodName            = "/staff.StaffService/ValidateLicenseNumber"
+	StaffService_NormalizeLegacyRecords_FullMethodName           = "/staff.StaffService/NormalizeLegacyRecords"
 )
 
 // StaffServiceClient is the client API for StaffService service.
@@ -47,21 +65,41 @@ type StaffServiceClient interface {
 	CreateDriver(ctx context.Context, in *CreateDriverRequest, opts ...grpc.CallOption) (*CreateDriverResponse, error)
 	GetDriver(ctx context.Context, in *GetDriverRequest, opts ...grpc.CallOption) (*GetDriverResponse, error)
 	GetDriverByUserID(ctx context.Context, in *GetDriverByUserIDRequest, opts ...grpc.CallOption) (*GetDriverResponse, error)
+	GetDriversByUserIDs(ctx context.Context, in *GetDriversByUserIDsRequest, opts ...grpc.CallOption) (*GetDriversByUserIDsResponse, error)
 	ListDrivers(ctx context.Context, in *ListDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
 	UpdateDriver(ctx context.Context, in *UpdateDriverRequest, opts ...grpc.CallOption) (*UpdateDriverResponse, error)
 	DeleteDriver(ctx context.Context, in *DeleteDriverRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreDriver(ctx context.Context, in *RestoreDriverRequest, opts ...grpc.CallOption) (*RestoreDriverResponse, error)
+	HardDeleteDriver(ctx context.Context, in *HardDeleteDriverRequest, opts ...grpc.CallOption) (*HardDeleteDriverResponse, error)
+	MergeDrivers(ctx context.Context, in *MergeDriversRequest, opts ...grpc.CallOption) (*MergeDriversResponse, error)
+	UpdateDriverRating(ctx context.Context, in *UpdateDriverRatingRequest, opts ...grpc.CallOption) (*UpdateDriverRatingResponse, error)
+	AcknowledgeHandbook(ctx context.Context, in *AcknowledgeHandbookRequest, opts ...grpc.CallOption) (*AcknowledgeHandbookResponse, error)
 	// Driver status management
 	UpdateDriverStatus(ctx context.Context, in *UpdateDriverStatusRequest, opts ...grpc.CallOption) (*UpdateDriverStatusResponse, error)
+	RenewDriverLicense(ctx context.Context, in *RenewDriverLicenseRequest, opts ...grpc.CallOption) (*RenewDriverLicenseResponse, error)
+	ValidateDriverStatusChange(ctx context.Context, in *ValidateDriverStatusChangeRequest, opts ...grpc.CallOption) (*ValidateDriverStatusChangeResponse, error)
+	ListDriverStatusHistory(ctx context.Context, in *ListDriverStatusHistoryRequest, opts ...grpc.CallOption) (*ListDriverStatusHistoryResponse, error)
 	GetActiveDrivers(ctx context.Context, in *GetActiveDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
+	GetEligibleDriversForVehicleType(ctx context.Context, in *GetEligibleDriversForVehicleTypeRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
+	CheckDriverEligibility(ctx context.Context, in *CheckDriverEligibilityRequest, opts ...grpc.CallOption) (*CheckDriverEligibilityResponse, error)
+	ListRecentlyUpdatedDrivers(ctx context.Context, in *ListRecentlyUpdatedDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
 	// Driver certification management
 	AddDriverCertification(ctx context.Context, in *AddDriverCertificationRequest, opts ...grpc.CallOption) (*AddDriverCertificationResponse, error)
 	ListDriverCertifications(ctx context.Context, in *ListDriverCertificationsRequest, opts ...grpc.CallOption) (*ListDriverCertificationsResponse, error)
 	UpdateCertification(ctx context.Context, in *UpdateCertificationRequest, opts ...grpc.CallOption) (*UpdateCertificationResponse, error)
 	DeleteCertification(ctx context.Context, in *DeleteCertificationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCertificationTemplates(ctx context.Context, in *ListCertificationTemplatesRequest, opts ...grpc.CallOption) (*ListCertificationTemplatesResponse, error)
 	// Driver verification and compliance
 	VerifyDriverLicense(ctx context.Context, in *VerifyDriverLicenseRequest, opts ...grpc.CallOption) (*VerifyDriverLicenseResponse, error)
+	BatchVerifyDriverLicenses(ctx context.Context, in *BatchVerifyDriverLicensesRequest, opts ...grpc.CallOption) (*BatchVerifyDriverLicensesResponse, error)
 	GetExpiringLicenses(ctx context.Context, in *GetExpiringLicensesRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
+	GetRecentlyExpiredLicenses(ctx context.Context, in *GetRecentlyExpiredLicensesRequest, opts ...grpc.CallOption) (*ListDriversResponse, error)
 	GetExpiredCertifications(ctx context.Context, in *GetExpiredCertificationsRequest, opts ...grpc.CallOption) (*ListDriverCertificationsResponse, error)
+	// Format checks, no database access
+	ValidatePhoneNumber(ctx context.Context, in *ValidatePhoneNumberRequest, opts ...grpc.CallOption) (*FieldValidationResponse, error)
+	ValidateLicenseNumber(ctx context.Context, in *ValidateLicenseNumberRequest, opts ...grpc.CallOption) (*FieldValidationResponse, error)
+	// Maintenance, admin only
+	NormalizeLegacyRecords(ctx context.Context, in *NormalizeLegacyRecordsRequest, opts ...grpc.CallOption) (*NormalizeLegacyRecordsResponse, error)
 }
 
 type staffServiceClient struct {
@@ -102,6 +140,16 @@ func (c *staffServiceClient) GetDriverByUserID(ctx context.Context, in *GetDrive
 	return out, nil
 }
 
+func (c *staffServiceClient) GetDriversByUserIDs(ctx context.Context, in *GetDriversByUserIDsRequest, opts ...grpc.CallOption) (*GetDriversByUserIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriversByUserIDsResponse)
+	err := c.cc.Invoke(ctx, StaffService_GetDriversByUserIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) ListDrivers(ctx context.Context, in *ListDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListDriversResponse)
@@ -132,6 +180,56 @@ func (c *staffServiceClient) DeleteDriver(ctx context.Context, in *DeleteDriverR
 	return out, nil
 }
 
+func (c *staffServiceClient) RestoreDriver(ctx context.Context, in *RestoreDriverRequest, opts ...grpc.CallOption) (*RestoreDriverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreDriverResponse)
+	err := c.cc.Invoke(ctx, StaffService_RestoreDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) HardDeleteDriver(ctx context.Context, in *HardDeleteDriverRequest, opts ...grpc.CallOption) (*HardDeleteDriverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HardDeleteDriverResponse)
+	err := c.cc.Invoke(ctx, StaffService_HardDeleteDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) MergeDrivers(ctx context.Context, in *MergeDriversRequest, opts ...grpc.CallOption) (*MergeDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeDriversResponse)
+	err := c.cc.Invoke(ctx, StaffService_MergeDrivers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) UpdateDriverRating(ctx context.Context, in *UpdateDriverRatingRequest, opts ...grpc.CallOption) (*UpdateDriverRatingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateDriverRatingResponse)
+	err := c.cc.Invoke(ctx, StaffService_UpdateDriverRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) AcknowledgeHandbook(ctx context.Context, in *AcknowledgeHandbookRequest, opts ...grpc.CallOption) (*AcknowledgeHandbookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeHandbookResponse)
+	err := c.cc.Invoke(ctx, StaffService_AcknowledgeHandbook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) UpdateDriverStatus(ctx context.Context, in *UpdateDriverStatusRequest, opts ...grpc.CallOption) (*UpdateDriverStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateDriverStatusResponse)
@@ -142,6 +240,36 @@ func (c *staffServiceClient) UpdateDriverStatus(ctx context.Context, in *UpdateD
 	return out, nil
 }
 
+func (c *staffServiceClient) RenewDriverLicense(ctx context.Context, in *RenewDriverLicenseRequest, opts ...grpc.CallOption) (*RenewDriverLicenseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewDriverLicenseResponse)
+	err := c.cc.Invoke(ctx, StaffService_RenewDriverLicense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ValidateDriverStatusChange(ctx context.Context, in *ValidateDriverStatusChangeRequest, opts ...grpc.CallOption) (*ValidateDriverStatusChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateDriverStatusChangeResponse)
+	err := c.cc.Invoke(ctx, StaffService_ValidateDriverStatusChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ListDriverStatusHistory(ctx context.Context, in *ListDriverStatusHistoryRequest, opts ...grpc.CallOption) (*ListDriverStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDriverStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, StaffService_ListDriverStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) GetActiveDrivers(ctx context.Context, in *GetActiveDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListDriversResponse)
@@ -152,6 +280,36 @@ func (c *staffServiceClient) GetActiveDrivers(ctx context.Context, in *GetActive
 	return out, nil
 }
 
+func (c *staffServiceClient) GetEligibleDriversForVehicleType(ctx context.Context, in *GetEligibleDriversForVehicleTypeRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDriversResponse)
+	err := c.cc.Invoke(ctx, StaffService_GetEligibleDriversForVehicleType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) CheckDriverEligibility(ctx context.Context, in *CheckDriverEligibilityRequest, opts ...grpc.CallOption) (*CheckDriverEligibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDriverEligibilityResponse)
+	err := c.cc.Invoke(ctx, StaffService_CheckDriverEligibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ListRecentlyUpdatedDrivers(ctx context.Context, in *ListRecentlyUpdatedDriversRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDriversResponse)
+	err := c.cc.Invoke(ctx, StaffService_ListRecentlyUpdatedDrivers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) AddDriverCertification(ctx context.Context, in *AddDriverCertificationRequest, opts ...grpc.CallOption) (*AddDriverCertificationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddDriverCertificationResponse)
@@ -192,6 +350,16 @@ func (c *staffServiceClient) DeleteCertification(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *staffServiceClient) ListCertificationTemplates(ctx context.Context, in *ListCertificationTemplatesRequest, opts ...grpc.CallOption) (*ListCertificationTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCertificationTemplatesResponse)
+	err := c.cc.Invoke(ctx, StaffService_ListCertificationTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) VerifyDriverLicense(ctx context.Context, in *VerifyDriverLicenseRequest, opts ...grpc.CallOption) (*VerifyDriverLicenseResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(VerifyDriverLicenseResponse)
@@ -202,6 +370,16 @@ func (c *staffServiceClient) VerifyDriverLicense(ctx context.Context, in *Verify
 	return out, nil
 }
 
+func (c *staffServiceClient) BatchVerifyDriverLicenses(ctx context.Context, in *BatchVerifyDriverLicensesRequest, opts ...grpc.CallOption) (*BatchVerifyDriverLicensesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchVerifyDriverLicensesResponse)
+	err := c.cc.Invoke(ctx, StaffService_BatchVerifyDriverLicenses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) GetExpiringLicenses(ctx context.Context, in *GetExpiringLicensesRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListDriversResponse)
@@ -212,6 +390,16 @@ func (c *staffServiceClient) GetExpiringLicenses(ctx context.Context, in *GetExp
 	return out, nil
 }
 
+func (c *staffServiceClient) GetRecentlyExpiredLicenses(ctx context.Context, in *GetRecentlyExpiredLicensesRequest, opts ...grpc.CallOption) (*ListDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDriversResponse)
+	err := c.cc.Invoke(ctx, StaffService_GetRecentlyExpiredLicenses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *staffServiceClient) GetExpiredCertifications(ctx context.Context, in *GetExpiredCertificationsRequest, opts ...grpc.CallOption) (*ListDriverCertificationsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListDriverCertificationsResponse)
@@ -222,6 +410,36 @@ func (c *staffServiceClient) GetExpiredCertifications(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *staffServiceClient) ValidatePhoneNumber(ctx context.Context, in *ValidatePhoneNumberRequest, opts ...grpc.CallOption) (*FieldValidationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FieldValidationResponse)
+	err := c.cc.Invoke(ctx, StaffService_ValidatePhoneNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ValidateLicenseNumber(ctx context.Context, in *ValidateLicenseNumberRequest, opts ...grpc.CallOption) (*FieldValidationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FieldValidationResponse)
+	err := c.cc.Invoke(ctx, StaffService_ValidateLicenseNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) NormalizeLegacyRecords(ctx context.Context, in *NormalizeLegacyRecordsRequest, opts ...grpc.CallOption) (*NormalizeLegacyRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NormalizeLegacyRecordsResponse)
+	err := c.cc.Invoke(ctx, StaffService_NormalizeLegacyRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StaffServiceServer is the server API for StaffService service.
 // All implementations must embed UnimplementedStaffServiceServer
 // for forward compatibility.
@@ -230,21 +448,41 @@ type StaffServiceServer interface {
 	CreateDriver(context.Context, *CreateDriverRequest) (*CreateDriverResponse, error)
 	GetDriver(context.Context, *GetDriverRequest) (*GetDriverResponse, error)
 	GetDriverByUserID(context.Context, *GetDriverByUserIDRequest) (*GetDriverResponse, error)
+	GetDriversByUserIDs(context.Context, *GetDriversByUserIDsRequest) (*GetDriversByUserIDsResponse, error)
 	ListDrivers(context.Context, *ListDriversRequest) (*ListDriversResponse, error)
 	UpdateDriver(context.Context, *UpdateDriverRequest) (*UpdateDriverResponse, error)
 	DeleteDriver(context.Context, *DeleteDriverRequest) (*emptypb.Empty, error)
+	RestoreDriver(context.Context, *RestoreDriverRequest) (*RestoreDriverResponse, error)
+	HardDeleteDriver(context.Context, *HardDeleteDriverRequest) (*HardDeleteDriverResponse, error)
+	MergeDrivers(context.Context, *MergeDriversRequest) (*MergeDriversResponse, error)
+	UpdateDriverRating(context.Context, *UpdateDriverRatingRequest) (*UpdateDriverRatingResponse, error)
+	AcknowledgeHandbook(context.Context, *AcknowledgeHandbookRequest) (*AcknowledgeHandbookResponse, error)
 	// Driver status management
 	UpdateDriverStatus(context.Context, *UpdateDriverStatusRequest) (*UpdateDriverStatusResponse, error)
+	RenewDriverLicense(context.Context, *RenewDriverLicenseRequest) (*RenewDriverLicenseResponse, error)
+	ValidateDriverStatusChange(context.Context, *ValidateDriverStatusChangeRequest) (*ValidateDriverStatusChangeResponse, error)
+	ListDriverStatusHistory(context.Context, *ListDriverStatusHistoryRequest) (*ListDriverStatusHistoryResponse, error)
 	GetActiveDrivers(context.Context, *GetActiveDriversRequest) (*ListDriversResponse, error)
+	GetEligibleDriversForVehicleType(context.Context, *GetEligibleDriversForVehicleTypeRequest) (*ListDriversResponse, error)
+	CheckDriverEligibility(context.Context, *CheckDriverEligibilityRequest) (*CheckDriverEligibilityResponse, error)
+	ListRecentlyUpdatedDrivers(context.Context, *ListRecentlyUpdatedDriversRequest) (*ListDriversResponse, error)
 	// Driver certification management
 	AddDriverCertification(context.Context, *AddDriverCertificationRequest) (*AddDriverCertificationResponse, error)
 	ListDriverCertifications(context.Context, *ListDriverCertificationsRequest) (*ListDriverCertificationsResponse, error)
 	UpdateCertification(context.Context, *UpdateCertificationRequest) (*UpdateCertificationResponse, error)
 	DeleteCertification(context.Context, *DeleteCertificationRequest) (*emptypb.Empty, error)
+	ListCertificationTemplates(context.Context, *ListCertificationTemplatesRequest) (*ListCertificationTemplatesResponse, error)
 	// Driver verification and compliance
 	VerifyDriverLicense(context.Context, *VerifyDriverLicenseRequest) (*VerifyDriverLicenseResponse, error)
+	BatchVerifyDriverLicenses(context.Context, *BatchVerifyDriverLicensesRequest) (*BatchVerifyDriverLicensesResponse, error)
 	GetExpiringLicenses(context.Context, *GetExpiringLicensesRequest) (*ListDriversResponse, error)
+	GetRecentlyExpiredLicenses(context.Context, *GetRecentlyExpiredLicensesRequest) (*ListDriversResponse, error)
 	GetExpiredCertifications(context.Context, *GetExpiredCertificationsRequest) (*ListDriverCertificationsResponse, error)
+	// Format checks, no database access
+	ValidatePhoneNumber(context.Context, *ValidatePhoneNumberRequest) (*FieldValidationResponse, error)
+	ValidateLicenseNumber(context.Context, *ValidateLicenseNumberRequest) (*FieldValidationResponse, error)
+	// Maintenance, admin only
+	NormalizeLegacyRecords(context.Context, *NormalizeLegacyRecordsRequest) (*NormalizeLegacyRecordsResponse, error)
 	mustEmbedUnimplementedStaffServiceServer()
 }
 
@@ -264,6 +502,9 @@ func (UnimplementedStaffServiceServer) GetDriver(context.Context, *GetDriverRequ
 func (UnimplementedStaffServiceServer) GetDriverByUserID(context.Context, *GetDriverByUserIDRequest) (*GetDriverResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDriverByUserID not implemented")
 }
+func (UnimplementedStaffServiceServer) GetDriversByUserIDs(context.Context, *GetDriversByUserIDsRequest) (*GetDriversByUserIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriversByUserIDs not implemented")
+}
 func (UnimplementedStaffServiceServer) ListDrivers(context.Context, *ListDriversRequest) (*ListDriversResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDrivers not implemented")
 }
@@ -273,12 +514,45 @@ func (UnimplementedStaffServiceServer) UpdateDriver(context.Context, *UpdateDriv
 func (UnimplementedStaffServiceServer) DeleteDriver(context.Context, *DeleteDriverRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteDriver not implemented")
 }
+func (UnimplementedStaffServiceServer) RestoreDriver(context.Context, *RestoreDriverRequest) (*RestoreDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreDriver not implemented")
+}
+func (UnimplementedStaffServiceServer) HardDeleteDriver(context.Context, *HardDeleteDriverRequest) (*HardDeleteDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HardDeleteDriver not implemented")
+}
+func (UnimplementedStaffServiceServer) MergeDrivers(context.Context, *MergeDriversRequest) (*MergeDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeDrivers not implemented")
+}
+func (UnimplementedStaffServiceServer) UpdateDriverRating(context.Context, *UpdateDriverRatingRequest) (*UpdateDriverRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDriverRating not implemented")
+}
+func (UnimplementedStaffServiceServer) AcknowledgeHandbook(context.Context, *AcknowledgeHandbookRequest) (*AcknowledgeHandbookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeHandbook not implemented")
+}
 func (UnimplementedStaffServiceServer) UpdateDriverStatus(context.Context, *UpdateDriverStatusRequest) (*UpdateDriverStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDriverStatus not implemented")
 }
+func (UnimplementedStaffServiceServer) RenewDriverLicense(context.Context, *RenewDriverLicenseRequest) (*RenewDriverLicenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewDriverLicense not implemented")
+}
+func (UnimplementedStaffServiceServer) ValidateDriverStatusChange(context.Context, *ValidateDriverStatusChangeRequest) (*ValidateDriverStatusChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateDriverStatusChange not implemented")
+}
+func (UnimplementedStaffServiceServer) ListDriverStatusHistory(context.Context, *ListDriverStatusHistoryRequest) (*ListDriverStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDriverStatusHistory not implemented")
+}
 func (UnimplementedStaffServiceServer) GetActiveDrivers(context.Context, *GetActiveDriversRequest) (*ListDriversResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetActiveDrivers not implemented")
 }
+func (UnimplementedStaffServiceServer) GetEligibleDriversForVehicleType(context.Context, *GetEligibleDriversForVehicleTypeRequest) (*ListDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEligibleDriversForVehicleType not implemented")
+}
+func (UnimplementedStaffServiceServer) CheckDriverEligibility(context.Context, *CheckDriverEligibilityRequest) (*CheckDriverEligibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDriverEligibility not implemented")
+}
+func (UnimplementedStaffServiceServer) ListRecentlyUpdatedDrivers(context.Context, *ListRecentlyUpdatedDriversRequest) (*ListDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentlyUpdatedDrivers not implemented")
+}
 func (UnimplementedStaffServiceServer) AddDriverCertification(context.Context, *AddDriverCertificationRequest) (*AddDriverCertificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddDriverCertification not implemented")
 }
@@ -291,15 +565,33 @@ func (UnimplementedStaffServiceServer) UpdateCertification(context.Context, *Upd
 func (UnimplementedStaffServiceServer) DeleteCertification(context.Context, *DeleteCertificationRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCertification not implemented")
 }
+func (UnimplementedStaffServiceServer) ListCertificationTemplates(context.Context, *ListCertificationTemplatesRequest) (*ListCertificationTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCertificationTemplates not implemented")
+}
 func (UnimplementedStaffServiceServer) VerifyDriverLicense(context.Context, *VerifyDriverLicenseRequest) (*VerifyDriverLicenseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifyDriverLicense not implemented")
 }
+func (UnimplementedStaffServiceServer) BatchVerifyDriverLicenses(context.Context, *BatchVerifyDriverLicensesRequest) (*BatchVerifyDriverLicensesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchVerifyDriverLicenses not implemented")
+}
 func (UnimplementedStaffServiceServer) GetExpiringLicenses(context.Context, *GetExpiringLicensesRequest) (*ListDriversResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExpiringLicenses not implemented")
 }
+func (UnimplementedStaffServiceServer) GetRecentlyExpiredLicenses(context.Context, *GetRecentlyExpiredLicensesRequest) (*ListDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentlyExpiredLicenses not implemented")
+}
 func (UnimplementedStaffServiceServer) GetExpiredCertifications(context.Context, *GetExpiredCertificationsRequest) (*ListDriverCertificationsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExpiredCertifications not implemented")
 }
+func (UnimplementedStaffServiceServer) ValidatePhoneNumber(context.Context, *ValidatePhoneNumberRequest) (*FieldValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePhoneNumber not implemented")
+}
+func (UnimplementedStaffServiceServer) ValidateLicenseNumber(context.Context, *ValidateLicenseNumberRequest) (*FieldValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateLicenseNumber not implemented")
+}
+func (UnimplementedStaffServiceServer) NormalizeLegacyRecords(context.Context, *NormalizeLegacyRecordsRequest) (*NormalizeLegacyRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalizeLegacyRecords not implemented")
+}
 func (UnimplementedStaffServiceServer) mustEmbedUnimplementedStaffServiceServer() {}
 func (UnimplementedStaffServiceServer) testEmbeddedByValue()                      {}
 
@@ -375,6 +667,24 @@ func _StaffService_GetDriverByUserID_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_GetDriversByUserIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriversByUserIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).GetDriversByUserIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_GetDriversByUserIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).GetDriversByUserIDs(ctx, req.(*GetDriversByUserIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_ListDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListDriversRequest)
 	if err := dec(in); err != nil {
@@ -429,6 +739,96 @@ func _StaffService_DeleteDriver_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_RestoreDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).RestoreDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_RestoreDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).RestoreDriver(ctx, req.(*RestoreDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_HardDeleteDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HardDeleteDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).HardDeleteDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_HardDeleteDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).HardDeleteDriver(ctx, req.(*HardDeleteDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_MergeDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeDriversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).MergeDrivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_MergeDrivers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).MergeDrivers(ctx, req.(*MergeDriversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_UpdateDriverRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDriverRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).UpdateDriverRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_UpdateDriverRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).UpdateDriverRating(ctx, req.(*UpdateDriverRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_AcknowledgeHandbook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeHandbookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).AcknowledgeHandbook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_AcknowledgeHandbook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).AcknowledgeHandbook(ctx, req.(*AcknowledgeHandbookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_UpdateDriverStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateDriverStatusRequest)
 	if err := dec(in); err != nil {
@@ -447,6 +847,60 @@ func _StaffService_UpdateDriverStatus_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_RenewDriverLicense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewDriverLicenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).RenewDriverLicense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_RenewDriverLicense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).RenewDriverLicense(ctx, req.(*RenewDriverLicenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ValidateDriverStatusChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateDriverStatusChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ValidateDriverStatusChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ValidateDriverStatusChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ValidateDriverStatusChange(ctx, req.(*ValidateDriverStatusChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ListDriverStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDriverStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ListDriverStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ListDriverStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ListDriverStatusHistory(ctx, req.(*ListDriverStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_GetActiveDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetActiveDriversRequest)
 	if err := dec(in); err != nil {
@@ -465,6 +919,60 @@ func _StaffService_GetActiveDrivers_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_GetEligibleDriversForVehicleType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEligibleDriversForVehicleTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).GetEligibleDriversForVehicleType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_GetEligibleDriversForVehicleType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).GetEligibleDriversForVehicleType(ctx, req.(*GetEligibleDriversForVehicleTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_CheckDriverEligibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDriverEligibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).CheckDriverEligibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_CheckDriverEligibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).CheckDriverEligibility(ctx, req.(*CheckDriverEligibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ListRecentlyUpdatedDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentlyUpdatedDriversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ListRecentlyUpdatedDrivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ListRecentlyUpdatedDrivers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ListRecentlyUpdatedDrivers(ctx, req.(*ListRecentlyUpdatedDriversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_AddDriverCertification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddDriverCertificationRequest)
 	if err := dec(in); err != nil {
@@ -537,6 +1045,24 @@ func _StaffService_DeleteCertification_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_ListCertificationTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCertificationTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ListCertificationTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ListCertificationTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ListCertificationTemplates(ctx, req.(*ListCertificationTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_VerifyDriverLicense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VerifyDriverLicenseRequest)
 	if err := dec(in); err != nil {
@@ -555,6 +1081,24 @@ func _StaffService_VerifyDriverLicense_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_BatchVerifyDriverLicenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchVerifyDriverLicensesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).BatchVerifyDriverLicenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_BatchVerifyDriverLicenses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).BatchVerifyDriverLicenses(ctx, req.(*BatchVerifyDriverLicensesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_GetExpiringLicenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetExpiringLicensesRequest)
 	if err := dec(in); err != nil {
@@ -573,6 +1117,24 @@ func _StaffService_GetExpiringLicenses_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_GetRecentlyExpiredLicenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentlyExpiredLicensesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).GetRecentlyExpiredLicenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_GetRecentlyExpiredLicenses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).GetRecentlyExpiredLicenses(ctx, req.(*GetRecentlyExpiredLicensesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StaffService_GetExpiredCertifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetExpiredCertificationsRequest)
 	if err := dec(in); err != nil {
@@ -591,6 +1153,60 @@ func _StaffService_GetExpiredCertifications_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_ValidatePhoneNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePhoneNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ValidatePhoneNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ValidatePhoneNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ValidatePhoneNumber(ctx, req.(*ValidatePhoneNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ValidateLicenseNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateLicenseNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ValidateLicenseNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ValidateLicenseNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ValidateLicenseNumber(ctx, req.(*ValidateLicenseNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_NormalizeLegacyRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormalizeLegacyRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).NormalizeLegacyRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_NormalizeLegacyRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).NormalizeLegacyRecords(ctx, req.(*NormalizeLegacyRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StaffService_ServiceDesc is the grpc.ServiceDesc for StaffService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -610,6 +1226,10 @@ var StaffService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDriverByUserID",
 			Handler:    _StaffService_GetDriverByUserID_Handler,
 		},
+		{
+			MethodName: "GetDriversByUserIDs",
+			Handler:    _StaffService_GetDriversByUserIDs_Handler,
+		},
 		{
 			MethodName: "ListDrivers",
 			Handler:    _StaffService_ListDrivers_Handler,
@@ -622,14 +1242,58 @@ var StaffService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDriver",
 			Handler:    _StaffService_DeleteDriver_Handler,
 		},
+		{
+			MethodName: "RestoreDriver",
+			Handler:    _StaffService_RestoreDriver_Handler,
+		},
+		{
+			MethodName: "HardDeleteDriver",
+			Handler:    _StaffService_HardDeleteDriver_Handler,
+		},
+		{
+			MethodName: "MergeDrivers",
+			Handler:    _StaffService_MergeDrivers_Handler,
+		},
+		{
+			MethodName: "UpdateDriverRating",
+			Handler:    _StaffService_UpdateDriverRating_Handler,
+		},
+		{
+			MethodName: "AcknowledgeHandbook",
+			Handler:    _StaffService_AcknowledgeHandbook_Handler,
+		},
 		{
 			MethodName: "UpdateDriverStatus",
 			Handler:    _StaffService_UpdateDriverStatus_Handler,
 		},
+		{
+			MethodName: "RenewDriverLicense",
+			Handler:    _StaffService_RenewDriverLicense_Handler,
+		},
+		{
+			MethodName: "ValidateDriverStatusChange",
+			Handler:    _StaffService_ValidateDriverStatusChange_Handler,
+		},
+		{
+			MethodName: "ListDriverStatusHistory",
+			Handler:    _StaffService_ListDriverStatusHistory_Handler,
+		},
 		{
 			MethodName: "GetActiveDrivers",
 			Handler:    _StaffService_GetActiveDrivers_Handler,
 		},
+		{
+			MethodName: "GetEligibleDriversForVehicleType",
+			Handler:    _StaffService_GetEligibleDriversForVehicleType_Handler,
+		},
+		{
+			MethodName: "CheckDriverEligibility",
+			Handler:    _StaffService_CheckDriverEligibility_Handler,
+		},
+		{
+			MethodName: "ListRecentlyUpdatedDrivers",
+			Handler:    _StaffService_ListRecentlyUpdatedDrivers_Handler,
+		},
 		{
 			MethodName: "AddDriverCertification",
 			Handler:    _StaffService_AddDriverCertification_Handler,
@@ -646,18 +1310,42 @@ var StaffService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCertification",
 			Handler:    _StaffService_DeleteCertification_Handler,
 		},
+		{
+			MethodName: "ListCertificationTemplates",
+			Handler:    _StaffService_ListCertificationTemplates_Handler,
+		},
 		{
 			MethodName: "VerifyDriverLicense",
 			Handler:    _StaffService_VerifyDriverLicense_Handler,
 		},
+		{
+			MethodName: "BatchVerifyDriverLicenses",
+			Handler:    _StaffService_BatchVerifyDriverLicenses_Handler,
+		},
 		{
 			MethodName: "GetExpiringLicenses",
 			Handler:    _StaffService_GetExpiringLicenses_Handler,
 		},
+		{
+			MethodName: "GetRecentlyExpiredLicenses",
+			Handler:    _StaffService_GetRecentlyExpiredLicenses_Handler,
+		},
 		{
 			MethodName: "GetExpiredCertifications",
 			Handler:    _StaffService_GetExpiredCertifications_Handler,
 		},
+		{
+			MethodName: "ValidatePhoneNumber",
+			Handler:    _StaffService_ValidatePhoneNumber_Handler,
+		},
+		{
+			MethodName: "ValidateLicenseNumber",
+			Handler:    _StaffService_ValidateLicenseNumber_Handler,
+		},
+		{
+			MethodName: "NormalizeLegacyRecords",
+			Handler:    _StaffService_NormalizeLegacyRecords_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "staff.proto",
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"log"
 
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
 	"github.com/adammwaniki/bebabeba/services/user/internal/types"
 	"github.com/adammwaniki/bebabeba/services/user/internal/validator"
 	"github.com/adammwaniki/bebabeba/services/user/proto/genproto"
@@ -28,7 +30,8 @@ type grpcHandler struct{
 }
 
 // NewGRPCHandler registers the gRPC User Service and Health Service with the given gRPC server.
-func NewGRPCHandler(grpcServer *grpc.Server, service types.UserService) {
+// It returns the health server, so shutdown can report NOT_SERVING before draining.
+func NewGRPCHandler(grpcServer *grpc.Server, service types.UserService) *health.Server {
     handler := &grpcHandler{
         service:      service,
         healthServer: health.NewServer(), // Initialize gRPC health server
@@ -44,6 +47,7 @@ func NewGRPCHandler(grpcServer *grpc.Server, service types.UserService) {
         grpc_health_v1.HealthCheckResponse_SERVING,
     )
     log.Println("gRPC User and Health services registered.")
+    return handler.healthServer
 }
 
 // CreateUser handles the gRPC request to create a new user.
@@ -52,7 +56,7 @@ func (h *grpcHandler) CreateUser(ctx context.Context, req *genproto.CreateUserRe
     // Validate request input using the validator package.
     if err := validator.ValidateAndNormalizeRegistrationInput(req.User); err != nil {
         log.Printf("CreateUser validation failed: %v", err)
-        return nil, status.Error(codes.InvalidArgument, err.Error())
+        return nil, grpcerr.InvalidArgument("", err)
     }
 
     // Call the business logic layer to create the user.
@@ -145,13 +149,33 @@ func (h *grpcHandler) GetUserBySSOID(ctx context.Context, req *genproto.GetUserB
     return user, nil
 }
 
+// GetUserByEmail handles the gRPC request to retrieve a user by email
+func (h *grpcHandler) GetUserByEmail(ctx context.Context, req *genproto.GetUserByEmailRequest) (*genproto.GetUserResponse, error) {
+    log.Println("Handling GetUserByEmail gRPC request.")
+
+    // Call the service layer to get the user by email.
+    user, err := h.service.GetUserByEmail(ctx, req)
+    if err != nil {
+        // If the error from the service layer is already a gRPC status error, return it directly.
+        if st, ok := status.FromError(err); ok {
+            log.Printf("GetUserByEmail failed from service layer with gRPC status: %v", st.Code())
+            return nil, st.Err()
+        }
+        // For any other unexpected errors from the service layer, log and return Internal.
+        log.Printf("GetUserByEmail failed from service layer with unexpected error: %v", err)
+        return nil, status.Error(codes.Internal, "failed to retrieve user by email")
+    }
+    log.Printf("GetUserByEmail successful for user %s", user.GetId())
+    return user, nil
+}
+
 func (h *grpcHandler) ListUsers(ctx context.Context, req *genproto.ListUsersRequest) (*genproto.ListUsersResponse, error) {
 	log.Println("Handling ListUsers gRPC request.")
 
 	// Validate page size limits
-	if req.GetPageSize() > 100 {
-		log.Printf("ListUsers: page size %d exceeds maximum of 100", req.GetPageSize())
-		return nil, status.Error(codes.InvalidArgument, "page size cannot exceed 100")
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("ListUsers: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		return nil, status.Errorf(codes.InvalidArgument, "page size cannot exceed %d", limit)
 	}
 
 	// Call the service layer to list users
@@ -183,4 +207,13 @@ func (s *grpcHandler) DeleteUser(ctx context.Context, req *genproto.DeleteUserRe
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
-}
\ No newline at end of file
+}
+
+// RecordLogin implements the gRPC RecordLogin method
+func (s *grpcHandler) RecordLogin(ctx context.Context, req *genproto.RecordLoginRequest) (*emptypb.Empty, error) {
+	if err := s.service.RecordLogin(ctx, req); err != nil {
+		log.Printf("RecordLogin failed for user %s: %v", req.GetUserId(), err)
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
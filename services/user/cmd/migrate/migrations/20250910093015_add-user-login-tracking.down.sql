@@ -0,0 +1,5 @@
+-- services/user/cmd/migrate/migrations/20250910093015_add-user-login-tracking.down.sql
+ALTER TABLE users
+    DROP INDEX idx_users_last_login_at,
+    DROP COLUMN login_count,
+    DROP COLUMN last_login_at;
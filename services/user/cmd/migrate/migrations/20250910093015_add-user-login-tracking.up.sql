@@ -0,0 +1,5 @@
+-- services/user/cmd/migrate/migrations/20250910093015_add-user-login-tracking.up.sql
+ALTER TABLE users
+    ADD COLUMN last_login_at DATETIME(6) NULL DEFAULT NULL,
+    ADD COLUMN login_count INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD INDEX idx_users_last_login_at (last_login_at);
@@ -0,0 +1,3 @@
+-- services/user/cmd/migrate/migrations/20250911101500_add-users-updated-by.down.sql
+ALTER TABLE users
+    DROP COLUMN updated_by;
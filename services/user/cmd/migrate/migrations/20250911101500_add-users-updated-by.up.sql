@@ -0,0 +1,3 @@
+-- services/user/cmd/migrate/migrations/20250911101500_add-users-updated-by.up.sql
+ALTER TABLE users
+    ADD COLUMN updated_by VARCHAR(64) NULL DEFAULT NULL;
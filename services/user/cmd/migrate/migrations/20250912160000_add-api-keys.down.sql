@@ -0,0 +1,2 @@
+-- services/user/cmd/migrate/migrations/20250912160000_add-api-keys.down.sql
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,16 @@
+-- services/user/cmd/migrate/migrations/20250912160000_add-api-keys.up.sql
+-- API keys for partner integrations, managed by the gateway alongside user_sessions
+CREATE TABLE IF NOT EXISTS api_keys (
+    key_id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 of the key, the plaintext is never stored
+    scopes VARCHAR(500) NOT NULL,      -- comma separated
+    created_by VARCHAR(64) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    expires_at DATETIME(6) NULL DEFAULT NULL,
+    revoked_at DATETIME(6) NULL DEFAULT NULL,
+    last_used_at DATETIME(6) NULL DEFAULT NULL,
+
+    INDEX idx_api_keys_created_at (created_at)
+);
@@ -0,0 +1,2 @@
+-- services/user/cmd/migrate/migrations/20250912180000_add-password-reset-tokens.down.sql
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,13 @@
+-- services/user/cmd/migrate/migrations/20250912180000_add-password-reset-tokens.up.sql
+-- Single-use tokens for the forgot/reset password flow, managed by the gateway
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    token_id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 of the token, the plaintext is never stored
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    expires_at DATETIME(6) NOT NULL,
+    used_at DATETIME(6) NULL DEFAULT NULL,
+
+    INDEX idx_password_reset_tokens_user_id (user_id),
+    INDEX idx_password_reset_tokens_expires_at (expires_at)
+);
@@ -0,0 +1,3 @@
+-- services/user/cmd/migrate/migrations/20250913090000_add-users-email-lower-unique.down.sql
+ALTER TABLE users
+    DROP INDEX uq_users_email_lower;
@@ -0,0 +1,7 @@
+-- services/user/cmd/migrate/migrations/20250913090000_add-users-email-lower-unique.up.sql
+-- Emails are stored lowercased from now on; bring existing rows in line first.
+-- This fails if two accounts differ only by email case, which must be merged by hand.
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+ALTER TABLE users
+    ADD UNIQUE INDEX uq_users_email_lower ((LOWER(email)));
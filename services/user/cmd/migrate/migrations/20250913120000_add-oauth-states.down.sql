@@ -0,0 +1,2 @@
+-- services/user/cmd/migrate/migrations/20250913120000_add-oauth-states.down.sql
+DROP TABLE IF EXISTS oauth_states;
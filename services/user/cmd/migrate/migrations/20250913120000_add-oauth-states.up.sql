@@ -0,0 +1,10 @@
+-- services/user/cmd/migrate/migrations/20250913120000_add-oauth-states.up.sql
+-- OAuth login states shared by all gateway instances between the redirect and the callback
+CREATE TABLE IF NOT EXISTS oauth_states (
+    state_hash CHAR(64) PRIMARY KEY, -- SHA-256 of the state, the plaintext is never stored
+    redirect_url VARCHAR(2048) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    expires_at DATETIME(6) NOT NULL,
+
+    INDEX idx_oauth_states_expires_at (expires_at)
+);
@@ -0,0 +1,6 @@
+-- services/user/cmd/migrate/migrations/20250915110000_add-users-email-case-insensitive-collation.down.sql
+ALTER TABLE users
+    ADD UNIQUE INDEX uq_users_email_lower ((LOWER(email)));
+
+ALTER TABLE users
+    MODIFY email VARCHAR(320) NOT NULL;
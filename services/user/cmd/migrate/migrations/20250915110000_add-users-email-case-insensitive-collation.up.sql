@@ -0,0 +1,9 @@
+-- services/user/cmd/migrate/migrations/20250915110000_add-users-email-case-insensitive-collation.up.sql
+-- Compare emails case-insensitively (but accent-sensitively) whatever the server's default
+-- collation, so the plain unique key on email rejects case variants by itself and the
+-- LOWER(email) index is no longer needed.
+ALTER TABLE users
+    MODIFY email VARCHAR(320) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_as_ci NOT NULL;
+
+ALTER TABLE users
+    DROP INDEX uq_users_email_lower;
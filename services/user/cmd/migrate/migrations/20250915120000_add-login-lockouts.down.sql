@@ -0,0 +1,2 @@
+-- services/user/cmd/migrate/migrations/20250915120000_add-login-lockouts.down.sql
+DROP TABLE IF EXISTS login_lockouts;
@@ -0,0 +1,11 @@
+-- services/user/cmd/migrate/migrations/20250915120000_add-login-lockouts.up.sql
+-- Consecutive failed password logins per email, managed by the gateway. Emails without
+-- an account are counted too, so a lockout doesn't reveal which emails are registered.
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    email VARCHAR(320) PRIMARY KEY, -- normalized, as sent to the user service
+    failed_attempts INT UNSIGNED NOT NULL,
+    last_failed_at DATETIME(6) NOT NULL,
+    locked_until DATETIME(6) NULL DEFAULT NULL,
+
+    INDEX idx_login_lockouts_last_failed_at (last_failed_at)
+);
@@ -0,0 +1,6 @@
+-- services/user/cmd/migrate/migrations/20250915130000_namespace-users-sso-id.down.sql
+-- Accounts from other providers can't be told apart without the prefix, so only Google's
+-- are unwrapped
+UPDATE users
+SET sso_id = SUBSTRING(sso_id, CHAR_LENGTH('google:') + 1)
+WHERE sso_id LIKE 'google:%';
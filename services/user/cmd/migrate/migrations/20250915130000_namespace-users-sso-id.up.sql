@@ -0,0 +1,6 @@
+-- services/user/cmd/migrate/migrations/20250915130000_namespace-users-sso-id.up.sql
+-- SSO IDs are now stored as <provider>:<provider's user ID>. Every SSO account so far
+-- signed in with Google.
+UPDATE users
+SET sso_id = CONCAT('google:', sso_id)
+WHERE sso_id IS NOT NULL AND sso_id NOT LIKE '%:%';
@@ -10,6 +10,10 @@ import (
 	"time"
 
 	"github.com/adammwaniki/bebabeba/services/auth/authn/passwords"
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
 	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/user/internal/types"
 	"github.com/adammwaniki/bebabeba/services/user/internal/validator"
@@ -23,21 +27,32 @@ import (
 
 // Service contains business logic pertaining to the user
 type service struct {
-	store types.UserStore
+	store        types.UserStore
+	ids          *snowflake.Generator
+	searchLimits utils.SearchTermLimits
 }
 
-// NewService creates a new instance of the user service
-func NewService(store types.UserStore) *service {
-	return &service{store: store}
+// NewService creates a new instance of the user service.
+// The ID generator is built once at startup from the resolved snowflake node ID.
+func NewService(store types.UserStore, ids *snowflake.Generator, searchLimits utils.SearchTermLimits) *service {
+	return &service{store: store, ids: ids, searchLimits: searchLimits}
 }
 
 // CreateUser handles the creation of a new user, supporting both password and SSO authentication
 func (s *service) CreateUser(ctx context.Context, user *genproto.RegistrationRequest) (*genproto.CreateUserResponse, error) {
 	// Validate incoming registration request based on business rules
     if err := validator.ValidateAndNormalizeRegistrationInput(user); err != nil {
-        return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+        return nil, grpcerr.InvalidArgument("validation failed", err)
     }
 
+	// Check for an existing account up front so the caller gets a clear answer. The
+	// unique key on email still catches registrations racing past this check.
+	if _, err := s.store.GetUserByEmail(ctx, user.Email); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "an account with email %s already exists", user.Email)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, grpcerr.Internal("failed to check email", err)
+	}
+
 	// Prepare variables for the hashed password and SSO ID.
 	// These will be pointers to strings, allowing them to be nil if not used.
 	var hashedPassword *string
@@ -49,7 +64,7 @@ func (s *service) CreateUser(ctx context.Context, user *genproto.RegistrationReq
 		// If a password is provided, hash it using the authn package
 		hash, err := passwords.HashPassword(authMethod.Password)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+			return nil, grpcerr.Internal("failed to hash password", err)
 		}
 		hashedPassword = &hash // Assign the address of the hashed string to hashedPassword
 	case *genproto.RegistrationRequest_SsoId:
@@ -62,19 +77,12 @@ func (s *service) CreateUser(ctx context.Context, user *genproto.RegistrationReq
 	}
 
     // Generate a unique internal_id using Snowflake
-	nodeID, err := utils.GetSnowflakeNodeID()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get snowflake node ID: %v", err)
-	}
-
-	// Generate new internal snowflakeID
-	generator := snowflake.New(int(nodeID))
-	inID := generator.Next() 
+	inID := s.ids.Next()
 
 	// Generate new external UUIDV4
 	exID, err := uuid.NewV4()
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate UUID: %v", err)
+		return nil, grpcerr.Internal("failed to generate UUID", err)
 	}
 	log.Printf("Generated UUID: %s, Bytes: %x", exID.String(), exID.Bytes())
 
@@ -98,7 +106,7 @@ func (s *service) CreateUser(ctx context.Context, user *genproto.RegistrationReq
 			return nil, status.Errorf(codes.AlreadyExists, "email is already in use")
 		}
 		// For any other unexpected store error, return an Internal gRPC error
-		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+		return nil, grpcerr.Internal("failed to create user", err)
 	}
 
     // Prepare and return the CreateUserResponse
@@ -130,7 +138,7 @@ func (s *service) GetUserByID(ctx context.Context, req *genproto.GetUserRequest)
         if errors.Is(err, sql.ErrNoRows) { // Check if the error is due to no rows found in the database
             return nil, status.Errorf(codes.NotFound, "user not found")
         }
-        return nil, status.Errorf(codes.Internal, "failed to get user from store: %v", err)
+        return nil, grpcerr.Internal("failed to get user from store", err)
     }
     return user, nil
 }
@@ -146,19 +154,35 @@ func (s *service) GetUserBySSOID(ctx context.Context, req *genproto.GetUserBySSO
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "user not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get user by SSO ID from store: %v", err)
+		return nil, grpcerr.Internal("failed to get user by SSO ID from store", err)
+	}
+	return user, nil
+}
+
+// GetUserByEmail retrieves a user by email, ignoring case
+func (s *service) GetUserByEmail(ctx context.Context, req *genproto.GetUserByEmailRequest) (*genproto.GetUserResponse, error) {
+	if err := validator.ValidateEmails("email", req.GetEmail()); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	user, err := s.store.GetUserByEmail(ctx, validator.NormalizeEmail(req.GetEmail()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, grpcerr.Internal("failed to get user by email from store", err)
 	}
 	return user, nil
 }
 
 // Authentication service method
 func (s *service) GetUserForAuth(ctx context.Context, req *genproto.GetUserForAuthRequest) (*genproto.AuthUserResponse, error) {
-    user, err := s.store.GetUserForAuth(ctx, req.Email)
+    user, err := s.store.GetUserForAuth(ctx, validator.NormalizeEmail(req.Email))
     if err != nil {
         if errors.Is(err, sql.ErrNoRows) {
             return nil, status.Error(codes.NotFound, "user not found")
         }
-        return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+        return nil, grpcerr.Internal("failed to get user", err)
     }
     return user, nil
 }
@@ -166,29 +190,45 @@ func (s *service) GetUserForAuth(ctx context.Context, req *genproto.GetUserForAu
 // ListUsers returns a list of users from the db
 func (s *service) ListUsers(ctx context.Context, req *genproto.ListUsersRequest) (*genproto.ListUsersResponse, error) {
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50 // Default
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	// Short or huge name filters turn into expensive LIKE scans
+	nameFilter := strings.TrimSpace(req.GetNameFilter())
+	if err := validator.ValidateSearchTerm("name_filter", nameFilter, s.searchLimits); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
-	if pageSize > 100 {
-		pageSize = 100 // Maximum limit
+
+	// Dormant account filter
+	var inactiveSince *time.Time
+	if req.InactiveSince != nil {
+		if err := req.InactiveSince.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid inactive_since: %v", err)
+		}
+		t := req.InactiveSince.AsTime()
+		inactiveSince = &t
 	}
 
 	// Call store layer
-	users, nextPageToken, err := s.store.ListUsers(
+	users, nextPageToken, prevPageToken, totalCount, err := s.store.ListUsers(
 		ctx,
 		pageSize,
 		req.GetPageToken(),
 		req.StatusFilter,
-		req.GetNameFilter(),
+		nameFilter,
+		inactiveSince,
 	)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list users", err)
 	}
 
 	return &genproto.ListUsersResponse{
 		Users:         users,
 		NextPageToken: nextPageToken,
+		PrevPageToken: prevPageToken,
+		TotalCount:    totalCount,
 	}, nil
 }
 
@@ -239,13 +279,13 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 					if errors.Is(err, sql.ErrNoRows) {
 						return nil, status.Errorf(codes.NotFound, "user not found")
 					}
-					return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+					return nil, grpcerr.Internal("failed to get current user", err)
 				}
 				
 				// Now get auth info by email
 				userAuthResp, err = s.store.GetUserForAuth(ctx, currentUser.Email)
 				if err != nil {
-					return nil, status.Errorf(codes.Internal, "failed to get user auth info: %v", err)
+					return nil, grpcerr.Internal("failed to get user auth info", err)
 				}
 			}
 			
@@ -264,39 +304,8 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 	}
 
 	// Validate and normalize fields that are being updated (only if they pass business logic checks)
-	if err := validator.ValidateUserInput(userInput, updateMask); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
-	}
-
-	// Apply normalization to fields that passed validation
-	if updateMask != nil {
-		for _, path := range updateMask.Paths {
-			switch path {
-			case "first_name":
-				if userInput.FirstName != "" {
-					userInput.FirstName = validator.NormalizeName(userInput.FirstName)
-				}
-			case "last_name":
-				if userInput.LastName != "" {
-					userInput.LastName = validator.NormalizeName(userInput.LastName)
-				}
-			case "email":
-				if userInput.Email != "" {
-					userInput.Email = strings.TrimSpace(userInput.Email)
-				}
-			}
-		}
-	} else {
-		// Normalize all provided fields
-		if userInput.FirstName != "" {
-			userInput.FirstName = validator.NormalizeName(userInput.FirstName)
-		}
-		if userInput.LastName != "" {
-			userInput.LastName = validator.NormalizeName(userInput.LastName)
-		}
-		if userInput.Email != "" {
-			userInput.Email = strings.TrimSpace(userInput.Email)
-		}
+	if err := validator.ValidateAndNormalizeUserInput(userInput, updateMask); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Prepare update fields
@@ -324,7 +333,7 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 					if passwordAuth, ok := authMethod.(*genproto.UserInput_Password); ok {
 						hashedPassword, err := passwords.HashPassword(passwordAuth.Password)
 						if err != nil {
-							return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+							return nil, grpcerr.Internal("failed to hash password", err)
 						}
 						updates.HashedPassword = &hashedPassword
 					}
@@ -358,12 +367,12 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 				if errors.Is(err, sql.ErrNoRows) {
 					return nil, status.Errorf(codes.NotFound, "user not found")
 				}
-				return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+				return nil, grpcerr.Internal("failed to get current user", err)
 			}
 			
 			userAuthResp, err := s.store.GetUserForAuth(ctx, currentUser.Email)
 			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get user auth info: %v", err)
+				return nil, grpcerr.Internal("failed to get user auth info", err)
 			}
 			
 			isCurrentlyPasswordUser := userAuthResp.PasswordHash != ""
@@ -376,7 +385,7 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 				}
 				hashedPassword, err := passwords.HashPassword(auth.Password)
 				if err != nil {
-					return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+					return nil, grpcerr.Internal("failed to hash password", err)
 				}
 				updates.HashedPassword = &hashedPassword
 			case *genproto.UserInput_SsoId:
@@ -390,7 +399,7 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 	}
 
 	// Call the store layer to perform the update (simplified since we prevent auth method switching)
-	updatedUser, err := s.store.Update(ctx, userID, updates, updateMask)
+	updatedUser, err := s.store.Update(ctx, userID, updates, updateMask, actor.FromIncomingContext(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.NotFound, "user not found")
@@ -398,7 +407,7 @@ func (s *service) UpdateUser(ctx context.Context, req *genproto.UpdateUserReques
 		if errors.Is(err, types.ErrDuplicateEntry) {
 			return nil, status.Errorf(codes.AlreadyExists, "email is already in use")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+		return nil, grpcerr.Internal("failed to update user", err)
 	}
 
 	return updatedUser, nil
@@ -418,12 +427,33 @@ func (s *service) DeleteUser(ctx context.Context, req *genproto.DeleteUserReques
 	}
 
 	// Call the store layer to perform the soft delete
-	err = s.store.Delete(ctx, userID)
+	err = s.store.Delete(ctx, userID, actor.FromIncomingContext(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return status.Errorf(codes.NotFound, "user not found or already deleted")
 		}
-		return status.Errorf(codes.Internal, "failed to delete user: %v", err)
+		return grpcerr.Internal("failed to delete user", err)
+	}
+
+	return nil
+}
+
+// RecordLogin updates the login tracking fields after a successful authentication
+func (s *service) RecordLogin(ctx context.Context, req *genproto.RecordLoginRequest) error {
+	if req.GetUserId() == "" {
+		return status.Errorf(codes.InvalidArgument, "user ID is required")
+	}
+
+	userID, err := uuid.FromString(req.GetUserId())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+
+	if err := s.store.RecordLogin(ctx, userID, time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Errorf(codes.NotFound, "user not found")
+		}
+		return grpcerr.Internal("failed to record login", err)
 	}
 
 	return nil
@@ -0,0 +1,131 @@
+// services/user/internal/service/service_test.go
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/user/internal/types"
+	"github.com/adammwaniki/bebabeba/services/user/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+	"github.com/influxdata/influxdb/v2/pkg/snowflake"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStore keeps users in memory, keyed by email exactly as given, so the service has to
+// normalize emails itself for case variants to find the same account. Calls to any other
+// method panic on the nil embedded store.
+type fakeStore struct {
+	types.UserStore
+	users map[string]*genproto.GetUserResponse
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{users: make(map[string]*genproto.GetUserResponse)}
+}
+
+func (f *fakeStore) Create(ctx context.Context, internalID uint64, externalID uuid.UUID, firstName, lastName, email string, hashedPassword, ssoID *string) error {
+	if _, ok := f.users[email]; ok {
+		return types.ErrDuplicateEntry
+	}
+	f.users[email] = &genproto.GetUserResponse{Id: externalID.String(), FirstName: firstName, LastName: lastName, Email: email}
+	return nil
+}
+
+func (f *fakeStore) GetUserByEmail(ctx context.Context, email string) (*genproto.GetUserResponse, error) {
+	user, ok := f.users[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (f *fakeStore) GetUserForAuth(ctx context.Context, email string) (*genproto.AuthUserResponse, error) {
+	user, ok := f.users[email]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &genproto.AuthUserResponse{Id: user.Id, Status: genproto.UserStatusEnum_ACTIVE}, nil
+}
+
+func register(s *service, email string) (*genproto.CreateUserResponse, error) {
+	return s.CreateUser(context.Background(), &genproto.RegistrationRequest{
+		FirstName:  "Jane",
+		LastName:   "Wanjiru",
+		Email:      email,
+		AuthMethod: &genproto.RegistrationRequest_SsoId{SsoId: "google-oauth2|1234567890"},
+	})
+}
+
+func TestCreateUserNormalizesEmail(t *testing.T) {
+	store := newFakeStore()
+	s := NewService(store, snowflake.New(1), utils.DefaultSearchTermLimits)
+
+	resp, err := register(s, "  Jane.Wanjiru@Example.COM ")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if resp.Email != "jane.wanjiru@example.com" {
+		t.Errorf("response email = %q, want jane.wanjiru@example.com", resp.Email)
+	}
+	if _, ok := store.users["jane.wanjiru@example.com"]; !ok || len(store.users) != 1 {
+		t.Errorf("stored emails = %v, want only jane.wanjiru@example.com", store.users)
+	}
+}
+
+func TestCreateUserRejectsCaseVariants(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  string
+		second string
+	}{
+		{name: "lower then mixed", first: "jane.wanjiru@example.com", second: "Jane.Wanjiru@Example.com"},
+		{name: "mixed then lower", first: "Jane.Wanjiru@Example.com", second: "jane.wanjiru@example.com"},
+		{name: "mixed then upper", first: "Jane.Wanjiru@example.com", second: "JANE.WANJIRU@EXAMPLE.COM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(newFakeStore(), snowflake.New(1), utils.DefaultSearchTermLimits)
+
+			if _, err := register(s, tt.first); err != nil {
+				t.Fatalf("first registration: %v", err)
+			}
+			_, err := register(s, tt.second)
+			if code := status.Code(err); code != codes.AlreadyExists {
+				t.Errorf("second registration: code = %s, want %s: %v", code, codes.AlreadyExists, err)
+			}
+		})
+	}
+}
+
+func TestLoginLookupIgnoresEmailCase(t *testing.T) {
+	store := newFakeStore()
+	s := NewService(store, snowflake.New(1), utils.DefaultSearchTermLimits)
+
+	created, err := register(s, "Jane.Wanjiru@Example.com")
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	for _, email := range []string{"jane.wanjiru@example.com", "JANE.WANJIRU@EXAMPLE.COM", " Jane.Wanjiru@example.COM"} {
+		auth, err := s.GetUserForAuth(context.Background(), &genproto.GetUserForAuthRequest{Email: email})
+		if err != nil {
+			t.Errorf("GetUserForAuth(%q): %v", email, err)
+			continue
+		}
+		if auth.Id != created.Id {
+			t.Errorf("GetUserForAuth(%q) = user %s, want %s", email, auth.Id, created.Id)
+		}
+
+		user, err := s.GetUserByEmail(context.Background(), &genproto.GetUserByEmailRequest{Email: email})
+		if err != nil {
+			t.Errorf("GetUserByEmail(%q): %v", email, err)
+		} else if user.Id != created.Id {
+			t.Errorf("GetUserByEmail(%q) = user %s, want %s", email, user.Id, created.Id)
+		}
+	}
+}
@@ -4,11 +4,15 @@ package store
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/user/internal/types"
 	"github.com/adammwaniki/bebabeba/services/user/proto/genproto"
 	"github.com/go-sql-driver/mysql"
@@ -20,7 +24,8 @@ import (
 // Contains storage logic pertaining to the coreUser
 
 type store struct {
-    db *sql.DB
+    db           *sql.DB
+    queryTimeout time.Duration // cap on each call's database work, see utils.WithQueryTimeout
 }
 
 // Returns a raw *sql.DB for use in migrations
@@ -28,15 +33,31 @@ func NewRawDB(cfg mysql.Config) (*sql.DB, error) {
 	return sql.Open("mysql", cfg.FormatDSN())
 }
 
-func NewStore(dsn string) (*store, error) {
+func NewStore(dsn string, retry utils.DBRetry, pool utils.DBPool) (*store, error) {
   // Ensure conversion of DATETIME columns to Go's time.Time and local time zone
 	dsn += "?parseTime=true&loc=Local"
-	db, err := sql.Open("mysql", dsn)
+	db, err := metrics.OpenDB("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
-  // TODO: Add db.SetMaxOpenConns, db.SetMaxIdleConns, db.SetConnMaxLifetime for production
-	return &store{db: db}, nil
+	pool.Apply(db)
+	// Wait for the database so the service doesn't start up unable to serve
+	if err := utils.WaitForDB(context.Background(), db, retry); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &store{db: db, queryTimeout: utils.DefaultDBQueryTimeout}, nil
+}
+
+// SetQueryTimeout sets how long one store call may spend in the database. 0 leaves calls
+// bounded only by the caller's deadline.
+func (s *store) SetQueryTimeout(timeout time.Duration) {
+	s.queryTimeout = timeout
+}
+
+// Close closes the store's database connections, once the server has stopped using them
+func (s *store) Close() error {
+	return s.db.Close()
 }
 
 const (
@@ -57,6 +78,9 @@ func (s *store) Create(
     hashedPassword *string, // Can be nil for SSO users
     ssoID *string,          // Can be nil for password users
     ) error {
+        ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+        defer cancel()
+
         tx, err := s.db.BeginTx(ctx, nil)
         if err != nil {
           return fmt.Errorf("beginning transaction: %w", err)
@@ -131,13 +155,19 @@ SELECT
   users.status,
   users.terms_accepted_at,
   users.created_at,
-  users.updated_at
+  users.updated_at,
+  users.last_login_at,
+  users.login_count,
+  users.updated_by
 FROM users
 WHERE users.external_id = ?
 LIMIT 1`
 
 // GetByID retrieves a user by their external ID from the database
 func (s *store) GetByID(ctx context.Context, externalID uuid.UUID) (*genproto.GetUserResponse, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
   var user genproto.GetUserResponse
   var (
     dbExternalID    string // For formatted UUID string
@@ -148,6 +178,9 @@ func (s *store) GetByID(ctx context.Context, externalID uuid.UUID) (*genproto.Ge
     termsAcceptedAt time.Time
     createdAt       time.Time
     updatedAt       sql.NullTime // Use sql.NullString for potentially nullable text fields
+    lastLoginAt     sql.NullTime // NULL until the first successful login
+    loginCount      int32
+    updatedBy       sql.NullString // NULL until the first edit
   )
 
   // Query the database rows
@@ -160,6 +193,9 @@ func (s *store) GetByID(ctx context.Context, externalID uuid.UUID) (*genproto.Ge
     &termsAcceptedAt,
     &createdAt,
     &updatedAt,
+    &lastLoginAt,
+    &loginCount,
+    &updatedBy,
   )
   if err != nil {
       if errors.Is(err, sql.ErrNoRows) {
@@ -189,7 +225,15 @@ func (s *store) GetByID(ctx context.Context, externalID uuid.UUID) (*genproto.Ge
 	if updatedAt.Valid {
 		user.UpdatedAt = timestamppb.New(updatedAt.Time)
 	}
-	
+
+	// Login tracking
+	if lastLoginAt.Valid {
+		user.LastLoginAt = timestamppb.New(lastLoginAt.Time)
+	}
+	user.LoginCount = loginCount
+	if updatedBy.Valid {
+		user.UpdatedBy = &updatedBy.String
+	}
 
   return &user, err
 }
@@ -212,13 +256,19 @@ SELECT
   status,
   terms_accepted_at,
   created_at,
-  updated_at
+  updated_at,
+  last_login_at,
+  login_count,
+  updated_by
 FROM users
 WHERE sso_id = ?
 LIMIT 1`
 
 // GetUserBySSOID retrieves a user by their SSO ID from the database.
 func (s *store) GetUserBySSOID(ctx context.Context, ssoID string) (*genproto.GetUserResponse, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	var user genproto.GetUserResponse
 	var (
 		dbExternalID    string
@@ -229,6 +279,9 @@ func (s *store) GetUserBySSOID(ctx context.Context, ssoID string) (*genproto.Get
 		termsAcceptedAt time.Time
 		createdAt       time.Time
 		updatedAt       sql.NullTime // Can be NULL in DB
+		lastLoginAt     sql.NullTime // NULL until the first successful login
+		loginCount      int32
+		updatedBy       sql.NullString // NULL until the first edit
 	)
 
 	// Query the database row using the sso_id.
@@ -241,6 +294,9 @@ func (s *store) GetUserBySSOID(ctx context.Context, ssoID string) (*genproto.Get
 		&termsAcceptedAt,
 		&createdAt,
 		&updatedAt,
+		&lastLoginAt,
+		&loginCount,
+		&updatedBy,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -271,6 +327,116 @@ func (s *store) GetUserBySSOID(ctx context.Context, ssoID string) (*genproto.Get
 		user.UpdatedAt = timestamppb.New(updatedAt.Time)
 	}
 
+	// Login tracking
+	if lastLoginAt.Valid {
+		user.LastLoginAt = timestamppb.New(lastLoginAt.Time)
+	}
+	user.LoginCount = loginCount
+	if updatedBy.Valid {
+		user.UpdatedBy = &updatedBy.String
+	}
+
+	return &user, nil
+}
+
+const getUserByEmailQuery = `
+SELECT
+  LOWER(
+        CONCAT(
+            HEX(SUBSTR(external_id, 1, 4)), '-',
+            HEX(SUBSTR(external_id, 5, 2)), '-',
+            HEX(SUBSTR(external_id, 7, 2)), '-',
+            HEX(SUBSTR(external_id, 9, 2)), '-',
+            HEX(SUBSTR(external_id, 11, 6))
+        )
+    ) AS external_id,
+  first_name,
+  last_name,
+  email,
+  status,
+  terms_accepted_at,
+  created_at,
+  updated_at,
+  last_login_at,
+  login_count,
+  updated_by
+FROM users
+WHERE email = ?
+LIMIT 1`
+
+// GetUserByEmail retrieves a user by email. The email column compares case-insensitively,
+// so any case variant of a stored address matches.
+func (s *store) GetUserByEmail(ctx context.Context, email string) (*genproto.GetUserResponse, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var user genproto.GetUserResponse
+	var (
+		dbExternalID    string
+		dbFirstName     string
+		dbLastName      string
+		dbEmail         string
+		statusStr       string
+		termsAcceptedAt time.Time
+		createdAt       time.Time
+		updatedAt       sql.NullTime // Can be NULL in DB
+		lastLoginAt     sql.NullTime // NULL until the first successful login
+		loginCount      int32
+		updatedBy       sql.NullString // NULL until the first edit
+	)
+
+	// Query the database row using the email.
+	err := s.db.QueryRowContext(ctx, getUserByEmailQuery, email).Scan(
+		&dbExternalID,
+		&dbFirstName,
+		&dbLastName,
+		&dbEmail,
+		&statusStr,
+		&termsAcceptedAt,
+		&createdAt,
+		&updatedAt,
+		&lastLoginAt,
+		&loginCount,
+		&updatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows // Propagate this specific error for service layer to handle
+		}
+		return nil, fmt.Errorf("querying user by email: %w", err)
+	}
+
+	// Populate the GetUserResponse fields.
+	user.Id = dbExternalID
+	user.FirstName = dbFirstName
+	user.LastName = dbLastName
+	user.Email = dbEmail
+
+	// Convert status string from DB to enum.
+	statusVal, ok := genproto.UserStatusEnum_value[statusStr]
+	if !ok {
+		return nil, fmt.Errorf("invalid status value found in DB: %s", statusStr)
+	}
+	user.Status = genproto.UserStatusEnum(statusVal)
+
+	// Convert Go time.Time to Protobuf Timestamp.
+	user.TermsAcceptedAt = timestamppb.New(termsAcceptedAt)
+	user.CreatedAt = timestamppb.New(createdAt)
+
+	// Convert nullable updatedAt to Protobuf Timestamp.
+	if updatedAt.Valid {
+		user.UpdatedAt = timestamppb.New(updatedAt.Time)
+	}
+
+	// Login tracking
+	if lastLoginAt.Valid {
+		user.LastLoginAt = timestamppb.New(lastLoginAt.Time)
+	}
+	user.LoginCount = loginCount
+	if updatedBy.Valid {
+		user.UpdatedBy = &updatedBy.String
+	}
+
 	return &user, nil
 }
 
@@ -292,6 +458,9 @@ WHERE email = ?
 LIMIT 1`
 
 func (s *store) GetUserForAuth(ctx context.Context, email string) (*genproto.AuthUserResponse, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
     var resp genproto.AuthUserResponse
     var dbPasswordHash sql.NullString
     var statusStr string
@@ -340,31 +509,67 @@ SELECT
   status,
   terms_accepted_at,
   created_at,
-  updated_at
+  updated_at,
+  last_login_at,
+  login_count,
+  updated_by
 FROM users
 WHERE (?='' OR status = ?)
   AND (?='' OR CONCAT(first_name, ' ', last_name) LIKE ?)
-  AND (?='' OR created_at > ?)
-ORDER BY created_at DESC
+  AND (?='' OR COALESCE(last_login_at, created_at) < ?)
+  AND (?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+ORDER BY created_at DESC, external_id DESC
 LIMIT ?`
 
+// listUsersBackwardQuery reads the page before a backward cursor, nearest row first
+const listUsersBackwardQuery = `
+SELECT
+  LOWER(
+        CONCAT(
+            HEX(SUBSTR(external_id, 1, 4)), '-',
+            HEX(SUBSTR(external_id, 5, 2)), '-',
+            HEX(SUBSTR(external_id, 7, 2)), '-',
+            HEX(SUBSTR(external_id, 9, 2)), '-',
+            HEX(SUBSTR(external_id, 11, 6))
+        )
+    ) AS external_id,
+  first_name,
+  last_name,
+  email,
+  status,
+  terms_accepted_at,
+  created_at,
+  updated_at,
+  last_login_at,
+  login_count,
+  updated_by
+FROM users
+WHERE (?='' OR status = ?)
+  AND (?='' OR CONCAT(first_name, ' ', last_name) LIKE ?)
+  AND (?='' OR COALESCE(last_login_at, created_at) < ?)
+  AND (?='' OR (created_at >= ? AND (created_at > ? OR external_id > ?)))
+ORDER BY created_at ASC, external_id ASC
+LIMIT ?`
+
+// listUsersCountQuery counts every user matching the listUsersQuery filters, ignoring the page
+const listUsersCountQuery = `
+SELECT COUNT(*)
+FROM users
+WHERE (?='' OR status = ?)
+  AND (?='' OR CONCAT(first_name, ' ', last_name) LIKE ?)
+  AND (?='' OR COALESCE(last_login_at, created_at) < ?)`
+
 // ListUsers retrieves a paginated list of users with optional filtering
-func (s *store) ListUsers(ctx context.Context, pageSize int32, pageToken string, statusFilter *genproto.UserStatusEnum, nameFilter string) ([]*genproto.GetUserResponse, string, error) {
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 50 // Default page size with maximum limit
-	}
+func (s *store) ListUsers(ctx context.Context, pageSize int32, pageToken string, statusFilter *genproto.UserStatusEnum, nameFilter string, inactiveSince *time.Time) ([]*genproto.GetUserResponse, string, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	pageSize = pagesize.ClampTo(pageSize, pagesize.Default(), pagesize.InternalMax())
 
 	// Parse page token to get cursor timestamp
-	var cursorTime time.Time
-	if pageToken != "" {
-		// Decode base64 page token to get timestamp
-		decoded, err := base64.URLEncoding.DecodeString(pageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(pageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", "", 0, err
 	}
 
 	// Prepare filter parameters
@@ -375,29 +580,59 @@ func (s *store) ListUsers(ctx context.Context, pageSize int32, pageToken string,
 
 	namePattern := ""
 	if nameFilter != "" {
-		namePattern = "%" + nameFilter + "%"
+		namePattern = "%" + likeEscaper.Replace(nameFilter) + "%"
 	}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	// Users who have never logged in are measured from their creation date
+	inactiveStr := ""
+	if inactiveSince != nil {
+		inactiveStr = inactiveSince.Format(time.RFC3339Nano)
+	}
+
+	cursorStr, cursorID, err := uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", "", 0, err
+	}
+
+	// Count and page in one read-only transaction so the total matches the page's snapshot
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, "", "", 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, listUsersCountQuery,
+		statusStr, statusStr,
+		namePattern, namePattern,
+		inactiveStr, inactiveStr,
+	).Scan(&total); err != nil {
+		return nil, "", "", 0, fmt.Errorf("counting users: %w", err)
 	}
 
 	// Execute query with filters
-	rows, err := s.db.QueryContext(ctx, listUsersQuery,
-		statusStr, statusStr,           // Status filter (twice for WHERE condition)
-		namePattern, namePattern,       // Name filter (twice for WHERE condition)
-		cursorStr, cursorStr,           // Cursor time filter (twice for WHERE condition)
-		pageSize+1,                     // Fetch one extra to determine if there are more pages
+	query := listUsersQuery
+	if cursor.Backward {
+		query = listUsersBackwardQuery
+	}
+
+	rows, err := tx.QueryContext(ctx, query,
+		statusStr, statusStr,                       // Status filter (twice for WHERE condition)
+		namePattern, namePattern,                   // Name filter (twice for WHERE condition)
+		inactiveStr, inactiveStr,                   // Dormant account filter (twice for WHERE condition)
+		cursorStr, cursorStr, cursorStr, cursorID,  // Keyset cursor on (created_at, external_id)
+		pageSize+1,                                 // Fetch one extra to determine if there are more pages
 	)
 	if err != nil {
-		return nil, "", fmt.Errorf("querying users: %w", err)
+		return nil, "", "", 0, fmt.Errorf("querying users: %w", err)
 	}
 	defer rows.Close()
 
 	var users []*genproto.GetUserResponse
-	var lastCreatedAt time.Time
-
 	for rows.Next() {
 		var user genproto.GetUserResponse
 		var (
@@ -409,6 +644,9 @@ func (s *store) ListUsers(ctx context.Context, pageSize int32, pageToken string,
 			termsAcceptedAt time.Time
 			createdAt       time.Time
 			updatedAt       sql.NullTime
+			lastLoginAt     sql.NullTime
+			loginCount      int32
+			updatedBy       sql.NullString
 		)
 
 		err := rows.Scan(
@@ -420,15 +658,18 @@ func (s *store) ListUsers(ctx context.Context, pageSize int32, pageToken string,
 			&termsAcceptedAt,
 			&createdAt,
 			&updatedAt,
+			&lastLoginAt,
+			&loginCount,
+			&updatedBy,
 		)
 		if err != nil {
-			return nil, "", fmt.Errorf("scanning user row: %w", err)
+			return nil, "", "", 0, fmt.Errorf("scanning user row: %w", err)
 		}
 
 		// Convert status string to enum
 		statusVal, ok := genproto.UserStatusEnum_value[statusStr]
 		if !ok {
-			return nil, "", fmt.Errorf("invalid status value found in DB: %s", statusStr)
+			return nil, "", "", 0, fmt.Errorf("invalid status value found in DB: %s", statusStr)
 		}
 
 		// Populate user response
@@ -443,29 +684,28 @@ func (s *store) ListUsers(ctx context.Context, pageSize int32, pageToken string,
 		if updatedAt.Valid {
 			user.UpdatedAt = timestamppb.New(updatedAt.Time)
 		}
+		if lastLoginAt.Valid {
+			user.LastLoginAt = timestamppb.New(lastLoginAt.Time)
+		}
+		user.LoginCount = loginCount
+		if updatedBy.Valid {
+			user.UpdatedBy = &updatedBy.String
+		}
 
 		users = append(users, &user)
-		lastCreatedAt = createdAt
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, "", fmt.Errorf("iterating user rows: %w", err)
+		return nil, "", "", 0, fmt.Errorf("iterating user rows: %w", err)
 	}
 
-	// Determine next page token
-	var nextPageToken string
-	if int32(len(users)) > pageSize {
-		// Remove the extra user we fetched
-		users = users[:pageSize]
-		// Create next page token from the last user's created_at timestamp
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("creating next page token: %w", err)
-		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
-	}
+	// Trim the extra user we fetched and build the tokens either side from created_at and ID
+	users, nextPageToken, prevPageToken := pagetoken.Paginate(users, pageSize, pagetoken.CreatedAtDesc, cursor,
+		func(u *genproto.GetUserResponse) pagetoken.Cursor {
+			return pagetoken.Cursor{At: u.CreatedAt.AsTime(), ID: u.Id}
+		})
 
-	return users, nextPageToken, nil
+	return users, nextPageToken, prevPageToken, total, nil
 }
 
 const updateUserQuery = `
@@ -475,7 +715,8 @@ SET first_name = CASE WHEN ? THEN ? ELSE first_name END,
     email = CASE WHEN ? THEN ? ELSE email END,
     password_hash = CASE WHEN ? THEN ? ELSE password_hash END,
     sso_id = CASE WHEN ? THEN ? ELSE sso_id END,
-    updated_at = ?
+    updated_at = ?,
+    updated_by = ?
 WHERE external_id = ?`
 
 const getUserForUpdateQuery = `
@@ -495,13 +736,17 @@ SELECT
   status,
   terms_accepted_at,
   created_at,
-  updated_at
+  updated_at,
+  updated_by
 FROM users
 WHERE external_id = ?
 LIMIT 1`
 
 // Update modifies an existing user's information based on the provided field mask
-func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.UserUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.UpdateUserResponse, error) {
+func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.UserUpdateFields, updateMask *fieldmaskpb.FieldMask, actorID string) (*genproto.UpdateUserResponse, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("beginning transaction: %w", err)
@@ -576,6 +821,7 @@ func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.
 		updatePassword, passwordValue,
 		updateSsoID, ssoIDValue,
 		now, // updated_at
+		actorID,
 		externalID.Bytes(),
 	)
 	if err != nil {
@@ -606,6 +852,7 @@ func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.
 		termsAcceptedAt time.Time
 		createdAt       time.Time
 		updatedAt       sql.NullTime
+		updatedBy       sql.NullString
 	)
 
 	err = tx.QueryRowContext(ctx, getUserForUpdateQuery, externalID.Bytes()).Scan(
@@ -617,6 +864,7 @@ func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.
 		&termsAcceptedAt,
 		&createdAt,
 		&updatedAt,
+		&updatedBy,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("fetching updated user data: %w", err)
@@ -643,6 +891,9 @@ func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.
 	if updatedAt.Valid {
 		user.UpdatedAt = timestamppb.New(updatedAt.Time)
 	}
+	if updatedBy.Valid {
+		user.UpdatedBy = &updatedBy.String
+	}
 
 	// Commit the transaction
 	if err = tx.Commit(); err != nil {
@@ -656,11 +907,15 @@ func (s *store) Update(ctx context.Context, externalID uuid.UUID, updates types.
 const softDeleteUserQuery = `
 UPDATE users 
 SET status = 'CLOSED',
-    updated_at = ?
+    updated_at = ?,
+    updated_by = ?
 WHERE external_id = ? AND status != 'CLOSED'`
 
 // Delete performs a soft delete by setting the user status to CLOSED
-func (s *store) Delete(ctx context.Context, externalID uuid.UUID) error {
+func (s *store) Delete(ctx context.Context, externalID uuid.UUID, actorID string) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("beginning transaction: %w", err)
@@ -674,7 +929,7 @@ func (s *store) Delete(ctx context.Context, externalID uuid.UUID) error {
 	now := time.Now()
 
 	// Execute soft delete by updating status to CLOSED
-	result, err := tx.ExecContext(ctx, softDeleteUserQuery, now, externalID.Bytes())
+	result, err := tx.ExecContext(ctx, softDeleteUserQuery, now, actorID, externalID.Bytes())
 	if err != nil {
 		return fmt.Errorf("soft deleting user: %w", err)
 	}
@@ -694,4 +949,58 @@ func (s *store) Delete(ctx context.Context, externalID uuid.UUID) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// updated_at is pinned to its current value so that logging in does not
+// trigger the ON UPDATE clause and look like a profile edit
+const recordLoginQuery = `
+UPDATE users
+SET last_login_at = ?,
+    login_count = login_count + 1,
+    updated_at = updated_at
+WHERE external_id = ?`
+
+// RecordLogin stamps the last login time and increments the login counter for a user
+func (s *store) RecordLogin(ctx context.Context, externalID uuid.UUID, loginAt time.Time) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, recordLoginQuery, loginAt, externalID.Bytes())
+	if err != nil {
+		return fmt.Errorf("recording login: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows // User not found
+	}
+
+	return nil
+}
+
+// uuidCursorArgs expands a page cursor into the arguments of the keyset filter
+//
+//	(?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+//
+// Tokens issued without an ID compare against the nil UUID, which nothing sorts
+// below, so they fall back to paging on the timestamp alone.
+func uuidCursorArgs(cursor pagetoken.Cursor) (string, []byte, error) {
+	if cursor.IsZero() {
+		return "", uuid.Nil.Bytes(), nil
+	}
+	id := uuid.Nil
+	if cursor.ID != "" {
+		parsed, err := uuid.FromString(cursor.ID)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", pagetoken.ErrInvalidToken, err)
+		}
+		id = parsed
+	}
+	return cursor.At.Format(time.RFC3339Nano), id.Bytes(), nil
+}
+
+// likeEscaper stops user-supplied % and _ from acting as LIKE wildcards
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
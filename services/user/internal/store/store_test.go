@@ -0,0 +1,39 @@
+// services/user/internal/store/store_test.go
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+)
+
+func TestClose(t *testing.T) {
+	tests := []struct {
+		name     string
+		closeErr error
+	}{
+		{name: "closes the database"},
+		{name: "returns the close error", closeErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			s := &store{db: db, queryTimeout: utils.DefaultDBQueryTimeout}
+
+			mock.ExpectClose().WillReturnError(tt.closeErr)
+
+			if err := s.Close(); !errors.Is(err, tt.closeErr) {
+				t.Errorf("Close = %v, want %v", err, tt.closeErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -16,10 +16,12 @@ type UserService interface {
     CreateUser(ctx context.Context, user *genproto.RegistrationRequest) (*genproto.CreateUserResponse, error)
     GetUserByID(ctx context.Context, req *genproto.GetUserRequest) (*genproto.GetUserResponse, error)
     GetUserBySSOID(ctx context.Context, req *genproto.GetUserBySSOIDRequest) (*genproto.GetUserResponse, error)
+	GetUserByEmail(ctx context.Context, req *genproto.GetUserByEmailRequest) (*genproto.GetUserResponse, error)
 	GetUserForAuth(ctx context.Context, req *genproto.GetUserForAuthRequest) (*genproto.AuthUserResponse, error)
 	ListUsers(ctx context.Context, req *genproto.ListUsersRequest) (*genproto.ListUsersResponse, error)
 	UpdateUser(ctx context.Context, req *genproto.UpdateUserRequest) (*genproto.UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, req *genproto.DeleteUserRequest) error
+	RecordLogin(ctx context.Context, req *genproto.RecordLoginRequest) error
 }
 
 type UserStore interface {
@@ -33,10 +35,15 @@ type UserStore interface {
 	) error
     GetByID(ctx context.Context, id uuid.UUID) (*genproto.GetUserResponse, error)
     GetUserBySSOID(ctx context.Context, ssoID string) (*genproto.GetUserResponse, error)
+	GetUserByEmail(ctx context.Context, email string) (*genproto.GetUserResponse, error)
 	GetUserForAuth(ctx context.Context, email string) (*genproto.AuthUserResponse, error)
-	ListUsers(ctx context.Context, pageSize int32, pageToken string, statusFilter *genproto.UserStatusEnum, nameFilter string) ([]*genproto.GetUserResponse, string, error)
-	Update(ctx context.Context, externalID uuid.UUID, updates UserUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.UpdateUserResponse, error)
-	Delete(ctx context.Context, externalID uuid.UUID) error
+	ListUsers(ctx context.Context, pageSize int32, pageToken string, statusFilter *genproto.UserStatusEnum, nameFilter string, inactiveSince *time.Time) (users []*genproto.GetUserResponse, nextPageToken, prevPageToken string, total int32, err error)
+	Update(ctx context.Context, externalID uuid.UUID, updates UserUpdateFields, updateMask *fieldmaskpb.FieldMask, actorID string) (*genproto.UpdateUserResponse, error)
+	Delete(ctx context.Context, externalID uuid.UUID, actorID string) error
+	RecordLogin(ctx context.Context, externalID uuid.UUID, loginAt time.Time) error
+
+	// Close releases the database connections on shutdown
+	Close() error
 }
 
 // UserUpdateFields represents the fields that can be updated for a user
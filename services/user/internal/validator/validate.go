@@ -6,7 +6,9 @@ import (
 	"net/mail"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/user/proto/genproto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
@@ -20,6 +22,11 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// FieldViolation reports the rejected field, so the error reaches clients as a structured violation
+func (e ValidationError) FieldViolation() (string, string) {
+	return e.Field, e.Message
+}
+
 // Name normalisation for human readability
 func NormalizeName(name string) string {
 	name = strings.TrimSpace(name)
@@ -46,6 +53,12 @@ func NormalizeName(name string) string {
 	return builder.String()
 }
 
+// NormalizeEmail lowercases and trims an email so that case variants of the same
+// address resolve to a single account
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // Name validation
 func ValidateName(field, name string) error {
 	name = NormalizeName(name)
@@ -148,6 +161,31 @@ func ValidateSSOID(field string, ssoID string) error {
 	return nil
 }
 
+// ValidateSearchTerm checks a free-text filter against the configured length limits.
+// An empty term means no filter and is always accepted.
+func ValidateSearchTerm(field, term string, limits utils.SearchTermLimits) error {
+	length := utf8.RuneCountInString(strings.TrimSpace(term))
+	if length == 0 {
+		return nil
+	}
+
+	if length < limits.MinLength {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at least %d characters", limits.MinLength),
+		}
+	}
+
+	if length > limits.MaxLength {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at most %d characters", limits.MaxLength),
+		}
+	}
+
+	return nil
+}
+
 // ValidatePassword validates the password.
 func ValidatePassword(field string, password string) error {
 	// Password cannot be empty if this method is chosen.
@@ -215,7 +253,7 @@ func ValidateAndNormalizeRegistrationInput(req *genproto.RegistrationRequest) er
     if err := ValidateEmails("email", rawEmail); err != nil {
         return err
     }
-    req.Email = strings.TrimSpace(rawEmail)
+    req.Email = NormalizeEmail(rawEmail)
 
     // Validate authentication method
     authMethod := req.GetAuthMethod()
@@ -246,34 +284,34 @@ func ValidateAndNormalizeRegistrationInput(req *genproto.RegistrationRequest) er
     return nil
 }
 
-// ValidateUserInput validates a UserInput message for update operations
-func ValidateUserInput(userInput *genproto.UserInput, updateMask *fieldmaskpb.FieldMask) error {
+// ValidateAndNormalizeUserInput validates a UserInput message for update operations and
+// normalizes the names and email it carries, as ValidateAndNormalizeRegistrationInput does
+// for registration
+func ValidateAndNormalizeUserInput(userInput *genproto.UserInput, updateMask *fieldmaskpb.FieldMask) error {
 	if userInput == nil {
 		return fmt.Errorf("user input cannot be nil")
 	}
 
-	// If update mask is provided, only validate fields specified in the mask
+	// If update mask is provided, only validate fields specified in the mask.
+	// Masked fields are written as given, so they must not be empty.
 	if updateMask != nil {
 		for _, path := range updateMask.Paths {
 			switch path {
 			case "first_name":
-				if userInput.FirstName != "" {
-					if err := ValidateName("first_name", userInput.FirstName); err != nil {
-						return err
-					}
+				if err := ValidateName("first_name", userInput.FirstName); err != nil {
+					return err
 				}
+				userInput.FirstName = NormalizeName(userInput.FirstName)
 			case "last_name":
-				if userInput.LastName != "" {
-					if err := ValidateName("last_name", userInput.LastName); err != nil {
-						return err
-					}
+				if err := ValidateName("last_name", userInput.LastName); err != nil {
+					return err
 				}
+				userInput.LastName = NormalizeName(userInput.LastName)
 			case "email":
-				if userInput.Email != "" {
-					if err := ValidateEmails("email", userInput.Email); err != nil {
-						return err
-					}
+				if err := ValidateEmails("email", userInput.Email); err != nil {
+					return err
 				}
+				userInput.Email = NormalizeEmail(userInput.Email)
 			case "password":
 				if authMethod := userInput.AuthMethod; authMethod != nil {
 					if passwordAuth, ok := authMethod.(*genproto.UserInput_Password); ok {
@@ -300,18 +338,21 @@ func ValidateUserInput(userInput *genproto.UserInput, updateMask *fieldmaskpb.Fi
 			if err := ValidateName("first_name", userInput.FirstName); err != nil {
 				return err
 			}
+			userInput.FirstName = NormalizeName(userInput.FirstName)
 		}
 		
 		if userInput.LastName != "" {
 			if err := ValidateName("last_name", userInput.LastName); err != nil {
 				return err
 			}
+			userInput.LastName = NormalizeName(userInput.LastName)
 		}
 		
 		if userInput.Email != "" {
 			if err := ValidateEmails("email", userInput.Email); err != nil {
 				return err
 			}
+			userInput.Email = NormalizeEmail(userInput.Email)
 		}
 
 		// Validate authentication method if provided (business logic will handle switching)
@@ -127,6 +127,50 @@ func (x *CreateUserRequest) GetUser() *RegistrationRequest {
 	return nil
 }
 
+type RecordLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordLoginRequest) Reset() {
+	*x = RecordLoginRequest{}
+	mi := &file_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordLoginRequest) ProtoMessage() {}
+
+func (x *RecordLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordLoginRequest.ProtoReflect.Descriptor instead.
+func (*RecordLoginRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecordLoginRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type GetUserBySSOIDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SsoId         string                 `protobuf:"bytes,1,opt,name=sso_id,json=ssoId,proto3" json:"sso_id,omitempty"`
@@ -136,7 +180,7 @@ type GetUserBySSOIDRequest struct {
 
 func (x *GetUserBySSOIDRequest) Reset() {
 	*x = GetUserBySSOIDRequest{}
-	mi := &file_user_proto_msgTypes[1]
+	mi := &file_user_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -148,7 +192,7 @@ func (x *GetUserBySSOIDRequest) String() string {
 func (*GetUserBySSOIDRequest) ProtoMessage() {}
 
 func (x *GetUserBySSOIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[1]
+	mi := &file_user_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +205,7 @@ func (x *GetUserBySSOIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserBySSOIDRequest.ProtoReflect.Descriptor instead.
 func (*GetUserBySSOIDRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{1}
+	return file_user_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetUserBySSOIDRequest) GetSsoId() string {
@@ -171,6 +215,50 @@ func (x *GetUserBySSOIDRequest) GetSsoId() string {
 	return ""
 }
 
+type GetUserByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // matched case-insensitively
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByEmailRequest) Reset() {
+	*x = GetUserByEmailRequest{}
+	mi := &file_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByEmailRequest) ProtoMessage() {}
+
+func (x *GetUserByEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByEmailRequest) Descriptor() ([]byte, []int) {
+	return file_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserByEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
 type GetUserForAuthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -180,7 +268,7 @@ type GetUserForAuthRequest struct {
 
 func (x *GetUserForAuthRequest) Reset() {
 	*x = GetUserForAuthRequest{}
-	mi := &file_user_proto_msgTypes[2]
+	mi := &file_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -192,7 +280,7 @@ func (x *GetUserForAuthRequest) String() string {
 func (*GetUserForAuthRequest) ProtoMessage() {}
 
 func (x *GetUserForAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[2]
+	mi := &file_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,7 +293,7 @@ func (x *GetUserForAuthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserForAuthRequest.ProtoReflect.Descriptor instead.
 func (*GetUserForAuthRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{2}
+	return file_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetUserForAuthRequest) GetEmail() string {
@@ -226,7 +314,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_user_proto_msgTypes[3]
+	mi := &file_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -238,7 +326,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[3]
+	mi := &file_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -251,7 +339,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{3}
+	return file_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateUserRequest) GetUserId() string {
@@ -291,7 +379,7 @@ type RegistrationRequest struct {
 
 func (x *RegistrationRequest) Reset() {
 	*x = RegistrationRequest{}
-	mi := &file_user_proto_msgTypes[4]
+	mi := &file_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -303,7 +391,7 @@ func (x *RegistrationRequest) String() string {
 func (*RegistrationRequest) ProtoMessage() {}
 
 func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[4]
+	mi := &file_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -316,7 +404,7 @@ func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
 func (*RegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{4}
+	return file_user_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RegistrationRequest) GetFirstName() string {
@@ -397,7 +485,7 @@ type UserInput struct {
 
 func (x *UserInput) Reset() {
 	*x = UserInput{}
-	mi := &file_user_proto_msgTypes[5]
+	mi := &file_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -409,7 +497,7 @@ func (x *UserInput) String() string {
 func (*UserInput) ProtoMessage() {}
 
 func (x *UserInput) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[5]
+	mi := &file_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -422,7 +510,7 @@ func (x *UserInput) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserInput.ProtoReflect.Descriptor instead.
 func (*UserInput) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{5}
+	return file_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UserInput) GetFirstName() string {
@@ -503,7 +591,7 @@ type CreateUserResponse struct {
 
 func (x *CreateUserResponse) Reset() {
 	*x = CreateUserResponse{}
-	mi := &file_user_proto_msgTypes[6]
+	mi := &file_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -515,7 +603,7 @@ func (x *CreateUserResponse) String() string {
 func (*CreateUserResponse) ProtoMessage() {}
 
 func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[6]
+	mi := &file_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,7 +616,7 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{6}
+	return file_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *CreateUserResponse) GetId() string {
@@ -590,13 +678,16 @@ type GetUserResponse struct {
 	TermsAcceptedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=terms_accepted_at,json=termsAcceptedAt,proto3" json:"terms_accepted_at,omitempty"`
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	LastLoginAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_login_at,json=lastLoginAt,proto3,oneof" json:"last_login_at,omitempty"` // Unset if the user has never logged in
+	LoginCount      int32                  `protobuf:"varint,11,opt,name=login_count,json=loginCount,proto3" json:"login_count,omitempty"`
+	UpdatedBy       *string                `protobuf:"bytes,12,opt,name=updated_by,json=updatedBy,proto3,oneof" json:"updated_by,omitempty"` // User ID of the last editor, or "system"
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_user_proto_msgTypes[7]
+	mi := &file_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -608,7 +699,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[7]
+	mi := &file_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -621,7 +712,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{7}
+	return file_user_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetUserResponse) GetId() string {
@@ -680,6 +771,27 @@ func (x *GetUserResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetUserResponse) GetLastLoginAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return nil
+}
+
+func (x *GetUserResponse) GetLoginCount() int32 {
+	if x != nil {
+		return x.LoginCount
+	}
+	return 0
+}
+
+func (x *GetUserResponse) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return ""
+}
+
 type AuthUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -691,7 +803,7 @@ type AuthUserResponse struct {
 
 func (x *AuthUserResponse) Reset() {
 	*x = AuthUserResponse{}
-	mi := &file_user_proto_msgTypes[8]
+	mi := &file_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -703,7 +815,7 @@ func (x *AuthUserResponse) String() string {
 func (*AuthUserResponse) ProtoMessage() {}
 
 func (x *AuthUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[8]
+	mi := &file_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,7 +828,7 @@ func (x *AuthUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{8}
+	return file_user_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AuthUserResponse) GetId() string {
@@ -744,13 +856,15 @@ type ListUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Users         []*GetUserResponse     `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
 	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // users matching the filters across all pages
+	PrevPageToken string                 `protobuf:"bytes,4,opt,name=prev_page_token,json=prevPageToken,proto3" json:"prev_page_token,omitempty"` // empty on the first page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_user_proto_msgTypes[9]
+	mi := &file_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -762,7 +876,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[9]
+	mi := &file_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -775,7 +889,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{9}
+	return file_user_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListUsersResponse) GetUsers() []*GetUserResponse {
@@ -792,6 +906,20 @@ func (x *ListUsersResponse) GetNextPageToken() string {
 	return ""
 }
 
+func (x *ListUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListUsersResponse) GetPrevPageToken() string {
+	if x != nil {
+		return x.PrevPageToken
+	}
+	return ""
+}
+
 type UpdateUserResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // external_id
@@ -802,13 +930,14 @@ type UpdateUserResponse struct {
 	TermsAcceptedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=terms_accepted_at,json=termsAcceptedAt,proto3" json:"terms_accepted_at,omitempty"`
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	UpdatedBy       *string                `protobuf:"bytes,10,opt,name=updated_by,json=updatedBy,proto3,oneof" json:"updated_by,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_user_proto_msgTypes[10]
+	mi := &file_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -820,7 +949,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[10]
+	mi := &file_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +962,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{10}
+	return file_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UpdateUserResponse) GetId() string {
@@ -892,6 +1021,13 @@ func (x *UpdateUserResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UpdateUserResponse) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return ""
+}
+
 // ================= Shared Messages =================
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -902,7 +1038,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_user_proto_msgTypes[11]
+	mi := &file_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -914,7 +1050,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[11]
+	mi := &file_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -927,7 +1063,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{11}
+	return file_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetUserRequest) GetUserId() string {
@@ -946,7 +1082,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_user_proto_msgTypes[12]
+	mi := &file_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -958,7 +1094,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[12]
+	mi := &file_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -971,7 +1107,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{12}
+	return file_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteUserRequest) GetUserId() string {
@@ -987,13 +1123,14 @@ type ListUsersRequest struct {
 	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	StatusFilter  *UserStatusEnum        `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=user.UserStatusEnum,oneof" json:"status_filter,omitempty"`
 	NameFilter    *string                `protobuf:"bytes,4,opt,name=name_filter,json=nameFilter,proto3,oneof" json:"name_filter,omitempty"`
+	InactiveSince *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=inactive_since,json=inactiveSince,proto3,oneof" json:"inactive_since,omitempty"` // Users with no login since this time (never-logged-in users count from created_at)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_user_proto_msgTypes[13]
+	mi := &file_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1005,7 +1142,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[13]
+	mi := &file_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,7 +1155,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{13}
+	return file_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListUsersRequest) GetPageSize() int32 {
@@ -1049,6 +1186,13 @@ func (x *ListUsersRequest) GetNameFilter() string {
 	return ""
 }
 
+func (x *ListUsersRequest) GetInactiveSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InactiveSince
+	}
+	return nil
+}
+
 type CoreUserCompliance struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	User              *CreateUserResponse    `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -1061,7 +1205,7 @@ type CoreUserCompliance struct {
 
 func (x *CoreUserCompliance) Reset() {
 	*x = CoreUserCompliance{}
-	mi := &file_user_proto_msgTypes[14]
+	mi := &file_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1073,7 +1217,7 @@ func (x *CoreUserCompliance) String() string {
 func (*CoreUserCompliance) ProtoMessage() {}
 
 func (x *CoreUserCompliance) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[14]
+	mi := &file_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1086,7 +1230,7 @@ func (x *CoreUserCompliance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CoreUserCompliance.ProtoReflect.Descriptor instead.
 func (*CoreUserCompliance) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{14}
+	return file_user_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CoreUserCompliance) GetUser() *CreateUserResponse {
@@ -1127,7 +1271,7 @@ type AddressCompliance struct {
 
 func (x *AddressCompliance) Reset() {
 	*x = AddressCompliance{}
-	mi := &file_user_proto_msgTypes[15]
+	mi := &file_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1139,7 +1283,7 @@ func (x *AddressCompliance) String() string {
 func (*AddressCompliance) ProtoMessage() {}
 
 func (x *AddressCompliance) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[15]
+	mi := &file_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1152,7 +1296,7 @@ func (x *AddressCompliance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddressCompliance.ProtoReflect.Descriptor instead.
 func (*AddressCompliance) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{15}
+	return file_user_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AddressCompliance) GetIsVerified() bool {
@@ -1185,7 +1329,7 @@ type UserConsentHistory struct {
 
 func (x *UserConsentHistory) Reset() {
 	*x = UserConsentHistory{}
-	mi := &file_user_proto_msgTypes[16]
+	mi := &file_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1197,7 +1341,7 @@ func (x *UserConsentHistory) String() string {
 func (*UserConsentHistory) ProtoMessage() {}
 
 func (x *UserConsentHistory) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[16]
+	mi := &file_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1210,7 +1354,7 @@ func (x *UserConsentHistory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserConsentHistory.ProtoReflect.Descriptor instead.
 func (*UserConsentHistory) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{16}
+	return file_user_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UserConsentHistory) GetDataConsentVersion() string {
@@ -1273,7 +1417,7 @@ type AuditInfo struct {
 
 func (x *AuditInfo) Reset() {
 	*x = AuditInfo{}
-	mi := &file_user_proto_msgTypes[17]
+	mi := &file_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1285,7 +1429,7 @@ func (x *AuditInfo) String() string {
 func (*AuditInfo) ProtoMessage() {}
 
 func (x *AuditInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_user_proto_msgTypes[17]
+	mi := &file_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +1442,7 @@ func (x *AuditInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuditInfo.ProtoReflect.Descriptor instead.
 func (*AuditInfo) Descriptor() ([]byte, []int) {
-	return file_user_proto_rawDescGZIP(), []int{17}
+	return file_user_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *AuditInfo) GetCreatedAt() *timestamppb.Timestamp {
@@ -1329,9 +1473,13 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"user.proto\x12\x04user\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\"B\n" +
 	"\x11CreateUserRequest\x12-\n" +
-	"\x04user\x18\x01 \x01(\v2\x19.user.RegistrationRequestR\x04user\".\n" +
+	"\x04user\x18\x01 \x01(\v2\x19.user.RegistrationRequestR\x04user\"-\n" +
+	"\x12RecordLoginRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\".\n" +
 	"\x15GetUserBySSOIDRequest\x12\x15\n" +
 	"\x06sso_id\x18\x01 \x01(\tR\x05ssoId\"-\n" +
+	"\x15GetUserByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"-\n" +
 	"\x15GetUserForAuthRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\"\x8e\x01\n" +
 	"\x11UpdateUserRequest\x12\x17\n" +
@@ -1364,7 +1512,7 @@ const file_user_proto_rawDesc = "" +
 	"\x05email\x18\x05 \x01(\tR\x05email\x12F\n" +
 	"\x11terms_accepted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0ftermsAcceptedAt\x129\n" +
 	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf3\x02\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9e\x04\n" +
 	"\x0fGetUserResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1376,15 +1524,26 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01B\r\n" +
-	"\v_updated_at\"u\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01\x12C\n" +
+	"\rlast_login_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampH\x01R\vlastLoginAt\x88\x01\x01\x12\x1f\n" +
+	"\vlogin_count\x18\v \x01(\x05R\n" +
+	"loginCount\x12\"\n" +
+	"\n" +
+	"updated_by\x18\f \x01(\tH\x02R\tupdatedBy\x88\x01\x01B\r\n" +
+	"\v_updated_atB\x10\n" +
+	"\x0e_last_login_atB\r\n" +
+	"\v_updated_by\"u\n" +
 	"\x10AuthUserResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rpassword_hash\x18\x02 \x01(\tR\fpasswordHash\x12,\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x14.user.UserStatusEnumR\x06status\"h\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.user.UserStatusEnumR\x06status\"\xb1\x01\n" +
 	"\x11ListUsersResponse\x12+\n" +
 	"\x05users\x18\x01 \x03(\v2\x15.user.GetUserResponseR\x05users\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xf6\x02\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12&\n" +
+	"\x0fprev_page_token\x18\x04 \x01(\tR\rprevPageToken\"\xa9\x03\n" +
 	"\x12UpdateUserResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1396,21 +1555,27 @@ const file_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01B\r\n" +
-	"\v_updated_at\")\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"updated_by\x18\n" +
+	" \x01(\tH\x01R\tupdatedBy\x88\x01\x01B\r\n" +
+	"\v_updated_atB\r\n" +
+	"\v_updated_by\")\n" +
 	"\x0eGetUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
 	"\x11DeleteUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xd6\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb1\x02\n" +
 	"\x10ListUsersRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\x12>\n" +
 	"\rstatus_filter\x18\x03 \x01(\x0e2\x14.user.UserStatusEnumH\x00R\fstatusFilter\x88\x01\x01\x12$\n" +
 	"\vname_filter\x18\x04 \x01(\tH\x01R\n" +
-	"nameFilter\x88\x01\x01B\x10\n" +
+	"nameFilter\x88\x01\x01\x12F\n" +
+	"\x0einactive_since\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\rinactiveSince\x88\x01\x01B\x10\n" +
 	"\x0e_status_filterB\x0e\n" +
-	"\f_name_filter\"\xe7\x01\n" +
+	"\f_name_filterB\x11\n" +
+	"\x0f_inactive_since\"\xe7\x01\n" +
 	"\x12CoreUserCompliance\x12,\n" +
 	"\x04user\x18\x01 \x01(\v2\x18.user.CreateUserResponseR\x04user\x122\n" +
 	"\aconsent\x18\x02 \x01(\v2\x18.user.UserConsentHistoryR\aconsent\x12F\n" +
@@ -1448,18 +1613,20 @@ const file_user_proto_rawDesc = "" +
 	"\tSUSPENDED\x10\x02\x12\v\n" +
 	"\aPENDING\x10\x03\x12\n" +
 	"\n" +
-	"\x06CLOSED\x10\x042\xe2\x04\n" +
+	"\x06CLOSED\x10\x042\xe9\x05\n" +
 	"\vUserService\x12?\n" +
 	"\n" +
 	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x18.user.CreateUserResponse\x12:\n" +
 	"\vGetUserByID\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12D\n" +
-	"\x0eGetUserBySSOID\x12\x1b.user.GetUserBySSOIDRequest\x1a\x15.user.GetUserResponse\x12E\n" +
+	"\x0eGetUserBySSOID\x12\x1b.user.GetUserBySSOIDRequest\x1a\x15.user.GetUserResponse\x12D\n" +
+	"\x0eGetUserByEmail\x12\x1b.user.GetUserByEmailRequest\x1a\x15.user.GetUserResponse\x12E\n" +
 	"\x0eGetUserForAuth\x12\x1b.user.GetUserForAuthRequest\x1a\x16.user.AuthUserResponse\x12<\n" +
 	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\x12?\n" +
 	"\n" +
 	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x18.user.UpdateUserResponse\x12=\n" +
 	"\n" +
-	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"DeleteUser\x12\x17.user.DeleteUserRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\vRecordLogin\x12\x18.user.RecordLoginRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
 	"\x14GetUserForCompliance\x12\x14.user.GetUserRequest\x1a\x18.user.CoreUserCompliance\x12C\n" +
 	"\x11GetConsentHistory\x12\x14.user.GetUserRequest\x1a\x18.user.UserConsentHistoryB8Z6github.com/adammwaniki/bebabeba/services/user/genprotob\x06proto3"
 
@@ -1476,85 +1643,93 @@ func file_user_proto_rawDescGZIP() []byte {
 }
 
 var file_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_user_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_user_proto_goTypes = []any{
 	(UserStatusEnum)(0),           // 0: user.UserStatusEnum
 	(*CreateUserRequest)(nil),     // 1: user.CreateUserRequest
-	(*GetUserBySSOIDRequest)(nil), // 2: user.GetUserBySSOIDRequest
-	(*GetUserForAuthRequest)(nil), // 3: user.GetUserForAuthRequest
-	(*UpdateUserRequest)(nil),     // 4: user.UpdateUserRequest
-	(*RegistrationRequest)(nil),   // 5: user.RegistrationRequest
-	(*UserInput)(nil),             // 6: user.UserInput
-	(*CreateUserResponse)(nil),    // 7: user.CreateUserResponse
-	(*GetUserResponse)(nil),       // 8: user.GetUserResponse
-	(*AuthUserResponse)(nil),      // 9: user.AuthUserResponse
-	(*ListUsersResponse)(nil),     // 10: user.ListUsersResponse
-	(*UpdateUserResponse)(nil),    // 11: user.UpdateUserResponse
-	(*GetUserRequest)(nil),        // 12: user.GetUserRequest
-	(*DeleteUserRequest)(nil),     // 13: user.DeleteUserRequest
-	(*ListUsersRequest)(nil),      // 14: user.ListUsersRequest
-	(*CoreUserCompliance)(nil),    // 15: user.CoreUserCompliance
-	(*AddressCompliance)(nil),     // 16: user.AddressCompliance
-	(*UserConsentHistory)(nil),    // 17: user.UserConsentHistory
-	(*AuditInfo)(nil),             // 18: user.AuditInfo
-	(*fieldmaskpb.FieldMask)(nil), // 19: google.protobuf.FieldMask
-	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 21: google.protobuf.Empty
+	(*RecordLoginRequest)(nil),    // 2: user.RecordLoginRequest
+	(*GetUserBySSOIDRequest)(nil), // 3: user.GetUserBySSOIDRequest
+	(*GetUserByEmailRequest)(nil), // 4: user.GetUserByEmailRequest
+	(*GetUserForAuthRequest)(nil), // 5: user.GetUserForAuthRequest
+	(*UpdateUserRequest)(nil),     // 6: user.UpdateUserRequest
+	(*RegistrationRequest)(nil),   // 7: user.RegistrationRequest
+	(*UserInput)(nil),             // 8: user.UserInput
+	(*CreateUserResponse)(nil),    // 9: user.CreateUserResponse
+	(*GetUserResponse)(nil),       // 10: user.GetUserResponse
+	(*AuthUserResponse)(nil),      // 11: user.AuthUserResponse
+	(*ListUsersResponse)(nil),     // 12: user.ListUsersResponse
+	(*UpdateUserResponse)(nil),    // 13: user.UpdateUserResponse
+	(*GetUserRequest)(nil),        // 14: user.GetUserRequest
+	(*DeleteUserRequest)(nil),     // 15: user.DeleteUserRequest
+	(*ListUsersRequest)(nil),      // 16: user.ListUsersRequest
+	(*CoreUserCompliance)(nil),    // 17: user.CoreUserCompliance
+	(*AddressCompliance)(nil),     // 18: user.AddressCompliance
+	(*UserConsentHistory)(nil),    // 19: user.UserConsentHistory
+	(*AuditInfo)(nil),             // 20: user.AuditInfo
+	(*fieldmaskpb.FieldMask)(nil), // 21: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 23: google.protobuf.Empty
 }
 var file_user_proto_depIdxs = []int32{
-	5,  // 0: user.CreateUserRequest.user:type_name -> user.RegistrationRequest
-	6,  // 1: user.UpdateUserRequest.user:type_name -> user.UserInput
-	19, // 2: user.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	7,  // 0: user.CreateUserRequest.user:type_name -> user.RegistrationRequest
+	8,  // 1: user.UpdateUserRequest.user:type_name -> user.UserInput
+	21, // 2: user.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
 	0,  // 3: user.CreateUserResponse.status:type_name -> user.UserStatusEnum
-	20, // 4: user.CreateUserResponse.terms_accepted_at:type_name -> google.protobuf.Timestamp
-	20, // 5: user.CreateUserResponse.created_at:type_name -> google.protobuf.Timestamp
+	22, // 4: user.CreateUserResponse.terms_accepted_at:type_name -> google.protobuf.Timestamp
+	22, // 5: user.CreateUserResponse.created_at:type_name -> google.protobuf.Timestamp
 	0,  // 6: user.GetUserResponse.status:type_name -> user.UserStatusEnum
-	20, // 7: user.GetUserResponse.terms_accepted_at:type_name -> google.protobuf.Timestamp
-	20, // 8: user.GetUserResponse.created_at:type_name -> google.protobuf.Timestamp
-	20, // 9: user.GetUserResponse.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 10: user.AuthUserResponse.status:type_name -> user.UserStatusEnum
-	8,  // 11: user.ListUsersResponse.users:type_name -> user.GetUserResponse
-	0,  // 12: user.UpdateUserResponse.status:type_name -> user.UserStatusEnum
-	20, // 13: user.UpdateUserResponse.terms_accepted_at:type_name -> google.protobuf.Timestamp
-	20, // 14: user.UpdateUserResponse.created_at:type_name -> google.protobuf.Timestamp
-	20, // 15: user.UpdateUserResponse.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 16: user.ListUsersRequest.status_filter:type_name -> user.UserStatusEnum
-	7,  // 17: user.CoreUserCompliance.user:type_name -> user.CreateUserResponse
-	17, // 18: user.CoreUserCompliance.consent:type_name -> user.UserConsentHistory
-	16, // 19: user.CoreUserCompliance.address_validation:type_name -> user.AddressCompliance
-	18, // 20: user.CoreUserCompliance.audits:type_name -> user.AuditInfo
-	20, // 21: user.AddressCompliance.verified_at:type_name -> google.protobuf.Timestamp
-	20, // 22: user.UserConsentHistory.terms_accepted_at:type_name -> google.protobuf.Timestamp
-	20, // 23: user.UserConsentHistory.consent_updated_at:type_name -> google.protobuf.Timestamp
-	20, // 24: user.UserConsentHistory.consent_withdrawn_at:type_name -> google.protobuf.Timestamp
-	20, // 25: user.UserConsentHistory.anonymized_at:type_name -> google.protobuf.Timestamp
-	20, // 26: user.UserConsentHistory.deleted_at:type_name -> google.protobuf.Timestamp
-	20, // 27: user.UserConsentHistory.reactivated_at:type_name -> google.protobuf.Timestamp
-	20, // 28: user.AuditInfo.created_at:type_name -> google.protobuf.Timestamp
-	20, // 29: user.AuditInfo.last_updated:type_name -> google.protobuf.Timestamp
-	1,  // 30: user.UserService.CreateUser:input_type -> user.CreateUserRequest
-	12, // 31: user.UserService.GetUserByID:input_type -> user.GetUserRequest
-	2,  // 32: user.UserService.GetUserBySSOID:input_type -> user.GetUserBySSOIDRequest
-	3,  // 33: user.UserService.GetUserForAuth:input_type -> user.GetUserForAuthRequest
-	14, // 34: user.UserService.ListUsers:input_type -> user.ListUsersRequest
-	4,  // 35: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	13, // 36: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
-	12, // 37: user.UserService.GetUserForCompliance:input_type -> user.GetUserRequest
-	12, // 38: user.UserService.GetConsentHistory:input_type -> user.GetUserRequest
-	7,  // 39: user.UserService.CreateUser:output_type -> user.CreateUserResponse
-	8,  // 40: user.UserService.GetUserByID:output_type -> user.GetUserResponse
-	8,  // 41: user.UserService.GetUserBySSOID:output_type -> user.GetUserResponse
-	9,  // 42: user.UserService.GetUserForAuth:output_type -> user.AuthUserResponse
-	10, // 43: user.UserService.ListUsers:output_type -> user.ListUsersResponse
-	11, // 44: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
-	21, // 45: user.UserService.DeleteUser:output_type -> google.protobuf.Empty
-	15, // 46: user.UserService.GetUserForCompliance:output_type -> user.CoreUserCompliance
-	17, // 47: user.UserService.GetConsentHistory:output_type -> user.UserConsentHistory
-	39, // [39:48] is the sub-list for method output_type
-	30, // [30:39] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	22, // 7: user.GetUserResponse.terms_accepted_at:type_name -> google.protobuf.Timestamp
+	22, // 8: user.GetUserResponse.created_at:type_name -> google.protobuf.Timestamp
+	22, // 9: user.GetUserResponse.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 10: user.GetUserResponse.last_login_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: user.AuthUserResponse.status:type_name -> user.UserStatusEnum
+	10, // 12: user.ListUsersResponse.users:type_name -> user.GetUserResponse
+	0,  // 13: user.UpdateUserResponse.status:type_name -> user.UserStatusEnum
+	22, // 14: user.UpdateUserResponse.terms_accepted_at:type_name -> google.protobuf.Timestamp
+	22, // 15: user.UpdateUserResponse.created_at:type_name -> google.protobuf.Timestamp
+	22, // 16: user.UpdateUserResponse.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 17: user.ListUsersRequest.status_filter:type_name -> user.UserStatusEnum
+	22, // 18: user.ListUsersRequest.inactive_since:type_name -> google.protobuf.Timestamp
+	9,  // 19: user.CoreUserCompliance.user:type_name -> user.CreateUserResponse
+	19, // 20: user.CoreUserCompliance.consent:type_name -> user.UserConsentHistory
+	18, // 21: user.CoreUserCompliance.address_validation:type_name -> user.AddressCompliance
+	20, // 22: user.CoreUserCompliance.audits:type_name -> user.AuditInfo
+	22, // 23: user.AddressCompliance.verified_at:type_name -> google.protobuf.Timestamp
+	22, // 24: user.UserConsentHistory.terms_accepted_at:type_name -> google.protobuf.Timestamp
+	22, // 25: user.UserConsentHistory.consent_updated_at:type_name -> google.protobuf.Timestamp
+	22, // 26: user.UserConsentHistory.consent_withdrawn_at:type_name -> google.protobuf.Timestamp
+	22, // 27: user.UserConsentHistory.anonymized_at:type_name -> google.protobuf.Timestamp
+	22, // 28: user.UserConsentHistory.deleted_at:type_name -> google.protobuf.Timestamp
+	22, // 29: user.UserConsentHistory.reactivated_at:type_name -> google.protobuf.Timestamp
+	22, // 30: user.AuditInfo.created_at:type_name -> google.protobuf.Timestamp
+	22, // 31: user.AuditInfo.last_updated:type_name -> google.protobuf.Timestamp
+	1,  // 32: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	14, // 33: user.UserService.GetUserByID:input_type -> user.GetUserRequest
+	3,  // 34: user.UserService.GetUserBySSOID:input_type -> user.GetUserBySSOIDRequest
+	4,  // 35: user.UserService.GetUserByEmail:input_type -> user.GetUserByEmailRequest
+	5,  // 36: user.UserService.GetUserForAuth:input_type -> user.GetUserForAuthRequest
+	16, // 37: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	6,  // 38: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	15, // 39: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	2,  // 40: user.UserService.RecordLogin:input_type -> user.RecordLoginRequest
+	14, // 41: user.UserService.GetUserForCompliance:input_type -> user.GetUserRequest
+	14, // 42: user.UserService.GetConsentHistory:input_type -> user.GetUserRequest
+	9,  // 43: user.UserService.CreateUser:output_type -> user.CreateUserResponse
+	10, // 44: user.UserService.GetUserByID:output_type -> user.GetUserResponse
+	10, // 45: user.UserService.GetUserBySSOID:output_type -> user.GetUserResponse
+	10, // 46: user.UserService.GetUserByEmail:output_type -> user.GetUserResponse
+	11, // 47: user.UserService.GetUserForAuth:output_type -> user.AuthUserResponse
+	12, // 48: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	13, // 49: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
+	23, // 50: user.UserService.DeleteUser:output_type -> google.protobuf.Empty
+	23, // 51: user.UserService.RecordLogin:output_type -> google.protobuf.Empty
+	17, // 52: user.UserService.GetUserForCompliance:output_type -> user.CoreUserCompliance
+	19, // 53: user.UserService.GetConsentHistory:output_type -> user.UserConsentHistory
+	43, // [43:54] is the sub-list for method output_type
+	32, // [32:43] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_user_proto_init() }
@@ -1562,25 +1737,25 @@ func file_user_proto_init() {
 	if File_user_proto != nil {
 		return
 	}
-	file_user_proto_msgTypes[4].OneofWrappers = []any{
+	file_user_proto_msgTypes[6].OneofWrappers = []any{
 		(*RegistrationRequest_Password)(nil),
 		(*RegistrationRequest_SsoId)(nil),
 	}
-	file_user_proto_msgTypes[5].OneofWrappers = []any{
+	file_user_proto_msgTypes[7].OneofWrappers = []any{
 		(*UserInput_Password)(nil),
 		(*UserInput_SsoId)(nil),
 	}
-	file_user_proto_msgTypes[7].OneofWrappers = []any{}
-	file_user_proto_msgTypes[10].OneofWrappers = []any{}
-	file_user_proto_msgTypes[13].OneofWrappers = []any{}
-	file_user_proto_msgTypes[16].OneofWrappers = []any{}
+	file_user_proto_msgTypes[9].OneofWrappers = []any{}
+	file_user_proto_msgTypes[12].OneofWrappers = []any{}
+	file_user_proto_msgTypes[15].OneofWrappers = []any{}
+	file_user_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_user_proto_rawDesc), len(file_user_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   18,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,10 +25,12 @@ const (
 	UserService_CreateUser_FullMethodName           = "/user.UserService/CreateUser"
 	UserService_GetUserByID_FullMethodName          = "/user.UserService/GetUserByID"
 	UserService_GetUserBySSOID_FullMethodName       = "/user.UserService/GetUserBySSOID"
+	UserService_GetUserByEmail_FullMethodName       = "/user.UserService/GetUserByEmail"
 	UserService_GetUserForAuth_FullMethodName       = "/user.UserService/GetUserForAuth"
 	UserService_ListUsers_FullMethodName            = "/user.UserService/ListUsers"
 	UserService_UpdateUser_FullMethodName           = "/user.UserService/UpdateUser"
 	UserService_DeleteUser_FullMethodName           = "/user.UserService/DeleteUser"
+	UserService_RecordLogin_FullMethodName          = "/user.UserService/RecordLogin"
 	UserService_GetUserForCompliance_FullMethodName = "/user.UserService/GetUserForCompliance"
 	UserService_GetConsentHistory_FullMethodName    = "/user.UserService/GetConsentHistory"
 )
@@ -41,10 +43,12 @@ type UserServiceClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	GetUserByID(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	GetUserBySSOID(ctx context.Context, in *GetUserBySSOIDRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	GetUserByEmail(ctx context.Context, in *GetUserByEmailRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	GetUserForAuth(ctx context.Context, in *GetUserForAuthRequest, opts ...grpc.CallOption) (*AuthUserResponse, error)
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RecordLogin(ctx context.Context, in *RecordLoginRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Compliance endpoints - requires special permissions
 	GetUserForCompliance(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*CoreUserCompliance, error)
 	GetConsentHistory(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*UserConsentHistory, error)
@@ -88,6 +92,16 @@ func (c *userServiceClient) GetUserBySSOID(ctx context.Context, in *GetUserBySSO
 	return out, nil
 }
 
+func (c *userServiceClient) GetUserByEmail(ctx context.Context, in *GetUserByEmailRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetUserForAuth(ctx context.Context, in *GetUserForAuthRequest, opts ...grpc.CallOption) (*AuthUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AuthUserResponse)
@@ -128,6 +142,16 @@ func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserReques
 	return out, nil
 }
 
+func (c *userServiceClient) RecordLogin(ctx context.Context, in *RecordLoginRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RecordLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetUserForCompliance(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*CoreUserCompliance, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CoreUserCompliance)
@@ -156,10 +180,12 @@ type UserServiceServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	GetUserByID(context.Context, *GetUserRequest) (*GetUserResponse, error)
 	GetUserBySSOID(context.Context, *GetUserBySSOIDRequest) (*GetUserResponse, error)
+	GetUserByEmail(context.Context, *GetUserByEmailRequest) (*GetUserResponse, error)
 	GetUserForAuth(context.Context, *GetUserForAuthRequest) (*AuthUserResponse, error)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error)
+	RecordLogin(context.Context, *RecordLoginRequest) (*emptypb.Empty, error)
 	// Compliance endpoints - requires special permissions
 	GetUserForCompliance(context.Context, *GetUserRequest) (*CoreUserCompliance, error)
 	GetConsentHistory(context.Context, *GetUserRequest) (*UserConsentHistory, error)
@@ -182,6 +208,9 @@ func (UnimplementedUserServiceServer) GetUserByID(context.Context, *GetUserReque
 func (UnimplementedUserServiceServer) GetUserBySSOID(context.Context, *GetUserBySSOIDRequest) (*GetUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserBySSOID not implemented")
 }
+func (UnimplementedUserServiceServer) GetUserByEmail(context.Context, *GetUserByEmailRequest) (*GetUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserByEmail not implemented")
+}
 func (UnimplementedUserServiceServer) GetUserForAuth(context.Context, *GetUserForAuthRequest) (*AuthUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserForAuth not implemented")
 }
@@ -194,6 +223,9 @@ func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserReq
 func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedUserServiceServer) RecordLogin(context.Context, *RecordLoginRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordLogin not implemented")
+}
 func (UnimplementedUserServiceServer) GetUserForCompliance(context.Context, *GetUserRequest) (*CoreUserCompliance, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserForCompliance not implemented")
 }
@@ -275,6 +307,24 @@ func _UserService_GetUserBySSOID_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserByEmail(ctx, req.(*GetUserByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetUserForAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserForAuthRequest)
 	if err := dec(in); err != nil {
@@ -347,6 +397,24 @@ func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RecordLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RecordLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RecordLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RecordLogin(ctx, req.(*RecordLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetUserForCompliance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserRequest)
 	if err := dec(in); err != nil {
@@ -402,6 +470,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserBySSOID",
 			Handler:    _UserService_GetUserBySSOID_Handler,
 		},
+		{
+			MethodName: "GetUserByEmail",
+			Handler:    _UserService_GetUserByEmail_Handler,
+		},
 		{
 			MethodName: "GetUserForAuth",
 			Handler:    _UserService_GetUserForAuth_Handler,
@@ -418,6 +490,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _UserService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "RecordLogin",
+			Handler:    _UserService_RecordLogin_Handler,
+		},
 		{
 			MethodName: "GetUserForCompliance",
 			Handler:    _UserService_GetUserForCompliance_Handler,
@@ -5,6 +5,7 @@ import (
 	"context"
 	"log"
 
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
 	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	"google.golang.org/grpc"
@@ -20,8 +21,9 @@ type grpcHandler struct {
 	healthServer *health.Server
 }
 
-// NewGRPCHandler creates and registers the gRPC vehicle service handler
-func NewGRPCHandler(grpcServer *grpc.Server, service types.VehicleService) {
+// NewGRPCHandler creates and registers the gRPC vehicle service handler. It returns the
+// health server, so shutdown can report NOT_SERVING before draining.
+func NewGRPCHandler(grpcServer *grpc.Server, service types.VehicleService) *health.Server {
 	handler := &grpcHandler{
 		service:      service,
 		healthServer: health.NewServer(),
@@ -38,6 +40,7 @@ func NewGRPCHandler(grpcServer *grpc.Server, service types.VehicleService) {
 	)
 
 	log.Println("gRPC Vehicle and Health services registered")
+	return handler.healthServer
 }
 
 // Vehicle CRUD operations
@@ -68,13 +71,39 @@ func (h *grpcHandler) GetVehicle(ctx context.Context, req *genproto.GetVehicleRe
 	return resp, nil
 }
 
+func (h *grpcHandler) GetVehicleByChassisNumber(ctx context.Context, req *genproto.GetVehicleByChassisNumberRequest) (*genproto.GetVehicleResponse, error) {
+	log.Printf("Handling GetVehicleByChassisNumber gRPC request for chassis number: %s", req.ChassisNumber)
+
+	resp, err := h.service.GetVehicleByChassisNumber(ctx, req)
+	if err != nil {
+		log.Printf("GetVehicleByChassisNumber failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetVehicleByChassisNumber successful for vehicle %s", resp.Vehicle.LicensePlate)
+	return resp, nil
+}
+
+func (h *grpcHandler) BatchGetVehicles(ctx context.Context, req *genproto.BatchGetVehiclesRequest) (*genproto.BatchGetVehiclesResponse, error) {
+	log.Printf("Handling BatchGetVehicles gRPC request for %d vehicles", len(req.VehicleIds))
+
+	resp, err := h.service.BatchGetVehicles(ctx, req)
+	if err != nil {
+		log.Printf("BatchGetVehicles failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("BatchGetVehicles successful, found %d vehicles, %d not found", len(resp.Vehicles), len(resp.NotFoundIds))
+	return resp, nil
+}
+
 func (h *grpcHandler) ListVehicles(ctx context.Context, req *genproto.ListVehiclesRequest) (*genproto.ListVehiclesResponse, error) {
 	log.Println("Handling ListVehicles gRPC request")
 	
 	// Validate page size
-	if req.GetPageSize() > 100 {
-		log.Printf("ListVehicles: page size %d exceeds maximum of 100", req.GetPageSize())
-		req.PageSize = 100
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("ListVehicles: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
 	}
 
 	resp, err := h.service.ListVehicles(ctx, req)
@@ -119,9 +148,9 @@ func (h *grpcHandler) GetVehiclesByType(ctx context.Context, req *genproto.GetVe
 	log.Printf("Handling GetVehiclesByType gRPC request for type: %s", req.VehicleTypeId)
 	
 	// Validate page size
-	if req.GetPageSize() > 100 {
-		log.Printf("GetVehiclesByType: page size %d exceeds maximum of 100", req.GetPageSize())
-		req.PageSize = 100
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("GetVehiclesByType: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
 	}
 
 	resp, err := h.service.GetVehiclesByType(ctx, req)
@@ -138,9 +167,9 @@ func (h *grpcHandler) GetAvailableVehicles(ctx context.Context, req *genproto.Ge
 	log.Println("Handling GetAvailableVehicles gRPC request")
 	
 	// Validate page size
-	if req.GetPageSize() > 100 {
-		log.Printf("GetAvailableVehicles: page size %d exceeds maximum of 100", req.GetPageSize())
-		req.PageSize = 100
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("GetAvailableVehicles: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
 	}
 
 	resp, err := h.service.GetAvailableVehicles(ctx, req)
@@ -153,6 +182,44 @@ func (h *grpcHandler) GetAvailableVehicles(ctx context.Context, req *genproto.Ge
 	return resp, nil
 }
 
+func (h *grpcHandler) GetDispatchCandidates(ctx context.Context, req *genproto.GetDispatchCandidatesRequest) (*genproto.ListVehiclesResponse, error) {
+	log.Printf("Handling GetDispatchCandidates gRPC request (min seats %d)", req.GetMinSeats())
+	
+	// Validate page size
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("GetDispatchCandidates: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
+	}
+
+	resp, err := h.service.GetDispatchCandidates(ctx, req)
+	if err != nil {
+		log.Printf("GetDispatchCandidates failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetDispatchCandidates successful, returned %d vehicles", len(resp.Vehicles))
+	return resp, nil
+}
+
+func (h *grpcHandler) ListRecentlyUpdatedVehicles(ctx context.Context, req *genproto.ListRecentlyUpdatedVehiclesRequest) (*genproto.ListVehiclesResponse, error) {
+	log.Printf("Handling ListRecentlyUpdatedVehicles gRPC request")
+	
+	// Validate page size
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("ListRecentlyUpdatedVehicles: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
+	}
+
+	resp, err := h.service.ListRecentlyUpdatedVehicles(ctx, req)
+	if err != nil {
+		log.Printf("ListRecentlyUpdatedVehicles failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("ListRecentlyUpdatedVehicles successful, returned %d vehicles", len(resp.Vehicles))
+	return resp, nil
+}
+
 func (h *grpcHandler) UpdateVehicleStatus(ctx context.Context, req *genproto.UpdateVehicleStatusRequest) (*genproto.UpdateVehicleStatusResponse, error) {
 	log.Printf("Handling UpdateVehicleStatus gRPC request for vehicle %s to status %s", 
 		req.VehicleId, req.Status.String())
@@ -167,6 +234,145 @@ func (h *grpcHandler) UpdateVehicleStatus(ctx context.Context, req *genproto.Upd
 	return resp, nil
 }
 
+func (h *grpcHandler) RecordVehicleMileage(ctx context.Context, req *genproto.RecordVehicleMileageRequest) (*genproto.RecordVehicleMileageResponse, error) {
+	log.Printf("Handling RecordVehicleMileage gRPC request for vehicle %s at %d km", req.VehicleId, req.MileageKm)
+
+	resp, err := h.service.RecordVehicleMileage(ctx, req)
+	if err != nil {
+		log.Printf("RecordVehicleMileage failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("RecordVehicleMileage successful for vehicle %s", resp.Vehicle.LicensePlate)
+	return resp, nil
+}
+
+func (h *grpcHandler) RecordService(ctx context.Context, req *genproto.RecordServiceRequest) (*genproto.RecordServiceResponse, error) {
+	log.Printf("Handling RecordService gRPC request for vehicle %s", req.VehicleId)
+
+	resp, err := h.service.RecordService(ctx, req)
+	if err != nil {
+		log.Printf("RecordService failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("RecordService successful for vehicle %s", resp.Vehicle.LicensePlate)
+	return resp, nil
+}
+
+func (h *grpcHandler) GetVehiclesDueForMaintenance(ctx context.Context, req *genproto.GetVehiclesDueForMaintenanceRequest) (*genproto.GetVehiclesDueForMaintenanceResponse, error) {
+	log.Println("Handling GetVehiclesDueForMaintenance gRPC request")
+
+	resp, err := h.service.GetVehiclesDueForMaintenance(ctx, req)
+	if err != nil {
+		log.Printf("GetVehiclesDueForMaintenance failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetVehiclesDueForMaintenance successful, returned %d vehicles", len(resp.Vehicles))
+	return resp, nil
+}
+
+func (h *grpcHandler) ValidateVehicleStatusChange(ctx context.Context, req *genproto.ValidateVehicleStatusChangeRequest) (*genproto.ValidateVehicleStatusChangeResponse, error) {
+	log.Printf("Handling ValidateVehicleStatusChange gRPC request for vehicle %s to status %s",
+		req.VehicleId, req.Status.String())
+
+	resp, err := h.service.ValidateVehicleStatusChange(ctx, req)
+	if err != nil {
+		log.Printf("ValidateVehicleStatusChange failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("ValidateVehicleStatusChange successful for vehicle %s, allowed: %t", req.VehicleId, resp.Allowed)
+	return resp, nil
+}
+
+func (h *grpcHandler) GetVehicleStatusHistory(ctx context.Context, req *genproto.GetVehicleStatusHistoryRequest) (*genproto.GetVehicleStatusHistoryResponse, error) {
+	log.Printf("Handling GetVehicleStatusHistory gRPC request for vehicle %s", req.VehicleId)
+
+	resp, err := h.service.GetVehicleStatusHistory(ctx, req)
+	if err != nil {
+		log.Printf("GetVehicleStatusHistory failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetVehicleStatusHistory successful, returned %d entries", len(resp.Entries))
+	return resp, nil
+}
+
+func (h *grpcHandler) AssignVehicle(ctx context.Context, req *genproto.AssignVehicleRequest) (*genproto.AssignVehicleResponse, error) {
+	log.Printf("Handling AssignVehicle gRPC request for vehicle %s to driver %s", req.VehicleId, req.DriverId)
+
+	resp, err := h.service.AssignVehicle(ctx, req)
+	if err != nil {
+		log.Printf("AssignVehicle failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("AssignVehicle successful for vehicle %s", resp.Vehicle.LicensePlate)
+	return resp, nil
+}
+
+func (h *grpcHandler) GetDriverAssignment(ctx context.Context, req *genproto.GetDriverAssignmentRequest) (*genproto.GetDriverAssignmentResponse, error) {
+	log.Printf("Handling GetDriverAssignment gRPC request for driver %s", req.DriverId)
+
+	resp, err := h.service.GetDriverAssignment(ctx, req)
+	if err != nil {
+		log.Printf("GetDriverAssignment failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetDriverAssignment successful, driver %s holds vehicle %s", req.DriverId, resp.Vehicle.LicensePlate)
+	return resp, nil
+}
+
+func (h *grpcHandler) FlagDriverAssignment(ctx context.Context, req *genproto.FlagDriverAssignmentRequest) (*genproto.FlagDriverAssignmentResponse, error) {
+	log.Printf("Handling FlagDriverAssignment gRPC request for driver %s", req.DriverId)
+
+	resp, err := h.service.FlagDriverAssignment(ctx, req)
+	if err != nil {
+		log.Printf("FlagDriverAssignment failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("FlagDriverAssignment successful for assignment %s", resp.Assignment.Id)
+	return resp, nil
+}
+
+// Reporting
+
+func (h *grpcHandler) GetFleetUtilization(ctx context.Context, req *genproto.GetFleetUtilizationRequest) (*genproto.GetFleetUtilizationResponse, error) {
+	log.Printf("Handling GetFleetUtilization gRPC request (%s)", req.Granularity.String())
+
+	resp, err := h.service.GetFleetUtilization(ctx, req)
+	if err != nil {
+		log.Printf("GetFleetUtilization failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetFleetUtilization successful, returned %d buckets", len(resp.Buckets))
+	return resp, nil
+}
+
+func (h *grpcHandler) ValidateLicensePlate(ctx context.Context, req *genproto.ValidateLicensePlateRequest) (*genproto.FieldValidationResponse, error) {
+	return h.service.ValidateLicensePlate(ctx, req)
+}
+
+// Maintenance
+
+func (h *grpcHandler) NormalizeLegacyRecords(ctx context.Context, req *genproto.NormalizeLegacyRecordsRequest) (*genproto.NormalizeLegacyRecordsResponse, error) {
+	log.Printf("Handling NormalizeLegacyRecords gRPC request, dry run: %t", req.DryRun)
+
+	resp, err := h.service.NormalizeLegacyRecords(ctx, req)
+	if err != nil {
+		log.Printf("NormalizeLegacyRecords failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("NormalizeLegacyRecords successful, scanned %d, changed %d, failed %d", resp.Scanned, resp.Changed, resp.Failed)
+	return resp, nil
+}
+
 // Vehicle type management
 
 func (h *grpcHandler) CreateVehicleType(ctx context.Context, req *genproto.CreateVehicleTypeRequest) (*genproto.CreateVehicleTypeResponse, error) {
@@ -186,9 +392,9 @@ func (h *grpcHandler) ListVehicleTypes(ctx context.Context, req *genproto.ListVe
 	log.Println("Handling ListVehicleTypes gRPC request")
 	
 	// Validate page size
-	if req.GetPageSize() > 100 {
-		log.Printf("ListVehicleTypes: page size %d exceeds maximum of 100", req.GetPageSize())
-		req.PageSize = 100
+	if limit := pagesize.Max(ctx); req.GetPageSize() > limit {
+		log.Printf("ListVehicleTypes: page size %d exceeds maximum of %d", req.GetPageSize(), limit)
+		req.PageSize = limit
 	}
 
 	resp, err := h.service.ListVehicleTypes(ctx, req)
@@ -199,4 +405,55 @@ func (h *grpcHandler) ListVehicleTypes(ctx context.Context, req *genproto.ListVe
 
 	log.Printf("ListVehicleTypes successful, returned %d types", len(resp.VehicleTypes))
 	return resp, nil
+}
+
+func (h *grpcHandler) GetVehicleType(ctx context.Context, req *genproto.GetVehicleTypeRequest) (*genproto.GetVehicleTypeResponse, error) {
+	log.Printf("Handling GetVehicleType gRPC request for type ID: %s", req.Id)
+
+	resp, err := h.service.GetVehicleType(ctx, req)
+	if err != nil {
+		log.Printf("GetVehicleType failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetVehicleType successful for type ID: %s", req.Id)
+	return resp, nil
+}
+
+func (h *grpcHandler) GetVehicleTypeByName(ctx context.Context, req *genproto.GetVehicleTypeByNameRequest) (*genproto.GetVehicleTypeResponse, error) {
+	log.Printf("Handling GetVehicleTypeByName gRPC request for type: %s", req.Name)
+
+	resp, err := h.service.GetVehicleTypeByName(ctx, req)
+	if err != nil {
+		log.Printf("GetVehicleTypeByName failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("GetVehicleTypeByName successful for type: %s", req.Name)
+	return resp, nil
+}
+
+func (h *grpcHandler) UpdateVehicleType(ctx context.Context, req *genproto.UpdateVehicleTypeRequest) (*genproto.UpdateVehicleTypeResponse, error) {
+	log.Printf("Handling UpdateVehicleType gRPC request for type ID: %s", req.Id)
+
+	resp, err := h.service.UpdateVehicleType(ctx, req)
+	if err != nil {
+		log.Printf("UpdateVehicleType failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("UpdateVehicleType successful for type ID: %s", req.Id)
+	return resp, nil
+}
+
+func (h *grpcHandler) DeleteVehicleType(ctx context.Context, req *genproto.DeleteVehicleTypeRequest) (*emptypb.Empty, error) {
+	log.Printf("Handling DeleteVehicleType gRPC request for type ID: %s", req.Id)
+
+	if err := h.service.DeleteVehicleType(ctx, req); err != nil {
+		log.Printf("DeleteVehicleType failed: %v", err)
+		return nil, err
+	}
+
+	log.Printf("DeleteVehicleType successful for type ID: %s", req.Id)
+	return &emptypb.Empty{}, nil
 }
\ No newline at end of file
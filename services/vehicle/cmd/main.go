@@ -3,32 +3,72 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
 	"time"
 
+	"github.com/adammwaniki/bebabeba/services/common/featureflags"
+	"github.com/adammwaniki/bebabeba/services/common/grpcserver"
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/vehicle/api"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/service"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/store"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
+	"github.com/influxdata/influxdb/v2/pkg/snowflake"
 	_ "github.com/joho/godotenv/autoload"
 	"google.golang.org/grpc"
 )
 
 var (
-	grpcAddr = os.Getenv("VEHICLE_GRPC_ADDR")
+	grpcAddr      = os.Getenv("VEHICLE_GRPC_ADDR")
+	internalToken = os.Getenv("INTERNAL_SERVICE_TOKEN")
+	metricsAddr   = os.Getenv("VEHICLE_METRICS_ADDR") // /metrics listener; unset leaves metrics unexposed
 )
 
 func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run starts the service and serves until a shutdown signal has drained it. Failures are
+// returned rather than fatal, so the deferred closes still run.
+func run() error {
+	// Resolve the snowflake node ID once so a bad NODE_ID stops startup
+	// instead of failing every create request
+	nodeID, err := utils.ResolveSnowflakeNodeID()
+	if err != nil {
+		return fmt.Errorf("snowflake node ID resolution failed: %w", err)
+	}
+
+	// Tracing stays off unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), "vehicle")
+	if err != nil {
+		return fmt.Errorf("tracing initialization failed: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize database store
-	vehicleStore, err := store.NewStore(os.Getenv("TRANSPORT_DB_DSN"))
+	vehicleStore, err := store.NewStore(os.Getenv("TRANSPORT_DB_DSN"), utils.DBRetryFromEnv(), utils.DBPoolFromEnv())
 	if err != nil {
-		log.Fatal("Store initialization failed: ", err)
+		return fmt.Errorf("store initialization failed: %w", err)
 	}
+	defer vehicleStore.Close()
+	vehicleStore.SetPlateCooldown(types.PlateCooldownFromEnv())
+	vehicleStore.SetQueryTimeout(utils.DBQueryTimeoutFromEnv())
+	pagesize.SetLimits(pagesize.LimitsFromEnv())
+	pagetoken.SetSigning(pagetoken.SigningFromEnv())
+	pagetoken.SetClockLeeway(pagetoken.ClockLeewayFromEnv())
 
 	// Initialize service business logic
-	svc := service.NewService(vehicleStore)
+	svc := service.NewService(vehicleStore, snowflake.New(int(nodeID)), featureflags.FromEnv(), utils.SearchTermLimitsFromEnv())
+	svc.SetMaintenanceInterval(types.MaintenanceIntervalFromEnv())
 
 	// Initialize standard vehicle types
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -39,21 +79,25 @@ func main() {
 	}
 
 	// Start gRPC server
-	startGRPCServer(svc)
-}
-
-func startGRPCServer(svc types.VehicleService) {
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
-		log.Fatal("gRPC listener failed: ", err)
+		return fmt.Errorf("gRPC listener failed: %w", err)
 	}
 	defer lis.Close()
 
-	grpcServer := grpc.NewServer()
-	api.NewGRPCHandler(grpcServer, svc)
+	return serveGRPC(lis, svc)
+}
 
-	log.Printf("Starting Vehicle gRPC server on %s", grpcAddr)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatal("gRPC server failed: ", err)
-	}
-}
\ No newline at end of file
+// serveGRPC serves svc on lis until SIGINT or SIGTERM, then drains it
+func serveGRPC(lis net.Listener, svc types.VehicleService) error {
+	// Metrics come first so they time the whole call; callers presenting the
+	// internal token may request larger pages
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		metrics.UnaryServerInterceptor(),
+		pagesize.UnaryServerInterceptor(internalToken),
+	))
+	healthServer := api.NewGRPCHandler(grpcServer, svc)
+	metrics.Serve(metricsAddr)
+
+	return grpcserver.Serve(lis, grpcServer, healthServer, "Vehicle")
+}
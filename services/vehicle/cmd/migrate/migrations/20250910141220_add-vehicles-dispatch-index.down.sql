@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250910141220_add-vehicles-dispatch-index.down.sql
+DROP INDEX idx_vehicles_dispatch ON vehicles;
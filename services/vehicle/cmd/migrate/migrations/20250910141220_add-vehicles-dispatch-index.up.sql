@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250910141220_add-vehicles-dispatch-index.up.sql
+CREATE INDEX idx_vehicles_dispatch ON vehicles (status, vehicle_type_id, seating_capacity, insurance_expiry);
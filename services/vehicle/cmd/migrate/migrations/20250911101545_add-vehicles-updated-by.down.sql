@@ -0,0 +1,3 @@
+-- services/vehicle/cmd/migrate/migrations/20250911101545_add-vehicles-updated-by.down.sql
+ALTER TABLE vehicles
+    DROP COLUMN updated_by;
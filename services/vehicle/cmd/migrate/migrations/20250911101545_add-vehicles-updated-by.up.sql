@@ -0,0 +1,3 @@
+-- services/vehicle/cmd/migrate/migrations/20250911101545_add-vehicles-updated-by.up.sql
+ALTER TABLE vehicles
+    ADD COLUMN updated_by VARCHAR(64) NULL DEFAULT NULL;
@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250912093000_add-vehicles-updated-at-index.down.sql
+DROP INDEX idx_vehicles_updated_at ON vehicles;
@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250912170000_create-vehicle_status_history.down.sql
+DROP TABLE IF EXISTS vehicle_status_history;
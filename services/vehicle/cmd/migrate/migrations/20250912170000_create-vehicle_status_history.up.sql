@@ -0,0 +1,20 @@
+-- services/vehicle/cmd/migrate/migrations/20250912170000_create-vehicle_status_history.up.sql
+-- Vehicle status history table (for utilization reporting and audit trail)
+-- Statuses are VARCHAR rather than ENUM so a transition out of an unrecognized
+-- status (recovered with an admin override) can still be recorded.
+CREATE TABLE IF NOT EXISTS vehicle_status_history (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vehicle_id BINARY(16) NOT NULL,
+    previous_status VARCHAR(32) NOT NULL,
+    new_status VARCHAR(32) NOT NULL,
+    reason TEXT,
+    changed_by VARCHAR(64), -- User ID who made the change, or "system"
+    changed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+    INDEX idx_vehicle_status_history_vehicle (vehicle_id, changed_at),
+    INDEX idx_vehicle_status_history_date (changed_at),
+
+    CONSTRAINT fk_vehicle_status_history_vehicle
+        FOREIGN KEY (vehicle_id) REFERENCES vehicles(external_id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,8 @@
+-- services/vehicle/cmd/migrate/migrations/20250912190000_add-license-plate-tombstones.down.sql
+-- Fails if a plate has been reused since the up migration, those vehicles need resolving first
+ALTER TABLE vehicles
+    ADD UNIQUE INDEX license_plate (license_plate),
+    DROP INDEX uq_vehicles_active_license_plate,
+    DROP COLUMN active_license_plate;
+
+DROP TABLE IF EXISTS license_plate_tombstones;
@@ -0,0 +1,18 @@
+-- services/vehicle/cmd/migrate/migrations/20250912190000_add-license-plate-tombstones.up.sql
+-- Plates of retired vehicles are held here until the reuse cooldown expires
+CREATE TABLE IF NOT EXISTS license_plate_tombstones (
+    license_plate VARCHAR(20) PRIMARY KEY,
+    vehicle_id BINARY(16) NOT NULL, -- the retired vehicle that last held the plate
+    retired_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+
+    INDEX idx_license_plate_tombstones_expires_at (expires_at)
+);
+
+-- A retired vehicle keeps its plate for the record, so uniqueness only applies
+-- to vehicles still in service. Otherwise a retired plate could never be reused.
+ALTER TABLE vehicles
+    ADD COLUMN active_license_plate VARCHAR(20)
+        AS (IF(status = 'RETIRED', NULL, license_plate)) STORED,
+    ADD UNIQUE INDEX uq_vehicles_active_license_plate (active_license_plate),
+    DROP INDEX license_plate;
@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250913110000_create-vehicle_assignments.down.sql
+DROP TABLE IF EXISTS vehicle_assignments;
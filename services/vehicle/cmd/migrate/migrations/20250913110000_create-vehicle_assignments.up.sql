@@ -0,0 +1,20 @@
+-- services/vehicle/cmd/migrate/migrations/20250913110000_create-vehicle_assignments.up.sql
+-- Drivers handed a vehicle. An assignment is open until ended_at is set, and a
+-- vehicle can only have one open assignment at a time.
+CREATE TABLE IF NOT EXISTS vehicle_assignments (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vehicle_id BINARY(16) NOT NULL,
+    driver_id BINARY(16) NOT NULL, -- staff service driver external_id
+    assigned_by VARCHAR(64),       -- User ID who made the assignment, or "system"
+    assigned_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    ended_at DATETIME(6) NULL,
+    open_vehicle_id BINARY(16) AS (IF(ended_at IS NULL, vehicle_id, NULL)) STORED,
+
+    UNIQUE INDEX uq_vehicle_assignments_open_vehicle (open_vehicle_id),
+    INDEX idx_vehicle_assignments_vehicle (vehicle_id, assigned_at),
+    INDEX idx_vehicle_assignments_driver (driver_id, assigned_at),
+
+    CONSTRAINT fk_vehicle_assignments_vehicle
+        FOREIGN KEY (vehicle_id) REFERENCES vehicles(external_id)
+        ON DELETE CASCADE
+);
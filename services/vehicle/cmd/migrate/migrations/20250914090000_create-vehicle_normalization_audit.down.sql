@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250914090000_create-vehicle_normalization_audit.down.sql
+DROP TABLE IF EXISTS vehicle_normalization_audit;
@@ -0,0 +1,17 @@
+-- services/vehicle/cmd/migrate/migrations/20250914090000_create-vehicle_normalization_audit.up.sql
+-- Field values rewritten by NormalizeLegacyRecords, one row per changed field
+CREATE TABLE IF NOT EXISTS vehicle_normalization_audit (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vehicle_id BINARY(16) NOT NULL,
+    field VARCHAR(50) NOT NULL,
+    old_value VARCHAR(255) NOT NULL,
+    new_value VARCHAR(255) NOT NULL,
+    changed_by VARCHAR(64),    -- User ID who ran the job, or "system"
+    changed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+    INDEX idx_vehicle_normalization_audit_vehicle (vehicle_id, changed_at),
+
+    CONSTRAINT fk_vehicle_normalization_audit_vehicle
+        FOREIGN KEY (vehicle_id) REFERENCES vehicles(external_id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- services/vehicle/cmd/migrate/migrations/20250915090000_add-vehicles-chassis-number-unique.down.sql
+ALTER TABLE vehicles
+    DROP INDEX uq_vehicles_chassis_number;
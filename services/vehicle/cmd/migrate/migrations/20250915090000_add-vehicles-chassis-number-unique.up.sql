@@ -0,0 +1,8 @@
+-- services/vehicle/cmd/migrate/migrations/20250915090000_add-vehicles-chassis-number-unique.up.sql
+-- A missing chassis number is stored as NULL, which the unique index lets any number
+-- of vehicles share. Blank strings would collide, so turn any left over into NULL first.
+-- Fails if two vehicles already share a chassis number, those need resolving first.
+UPDATE vehicles SET chassis_number = NULL WHERE TRIM(chassis_number) = '';
+
+ALTER TABLE vehicles
+    ADD UNIQUE INDEX uq_vehicles_chassis_number (chassis_number);
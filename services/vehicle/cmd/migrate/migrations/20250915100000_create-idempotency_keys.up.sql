@@ -0,0 +1,14 @@
+-- services/vehicle/cmd/migrate/migrations/20250915100000_create-idempotency_keys.up.sql
+-- Idempotency keys sent with create requests, mapped to the resource they created.
+-- A key is scoped to one endpoint and one user; rows past expires_at can be reused or purged.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope VARCHAR(64) NOT NULL,             -- endpoint the key was sent to, e.g. CreateVehicle
+    actor_id VARCHAR(64) NOT NULL,          -- User ID who sent the key, or "system"
+    idempotency_key VARCHAR(255) NOT NULL,
+    external_id BINARY(16) NOT NULL,        -- the resource the first request created
+    created_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+
+    PRIMARY KEY (scope, actor_id, idempotency_key),
+    INDEX idx_idempotency_keys_expires_at (expires_at)
+);
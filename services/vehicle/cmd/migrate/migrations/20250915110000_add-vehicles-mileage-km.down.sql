@@ -0,0 +1,3 @@
+-- services/vehicle/cmd/migrate/migrations/20250915110000_add-vehicles-mileage-km.down.sql
+ALTER TABLE vehicles
+    DROP COLUMN mileage_km;
@@ -0,0 +1,5 @@
+-- services/vehicle/cmd/migrate/migrations/20250915110000_add-vehicles-mileage-km.up.sql
+-- Latest odometer reading, NULL until the first one is recorded.
+-- Every reading is kept in vehicle_mileage_readings; this is just the current value.
+ALTER TABLE vehicles
+    ADD COLUMN mileage_km INT UNSIGNED NULL AFTER insurance_expiry;
@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250915120000_create-vehicle_mileage_readings.down.sql
+DROP TABLE IF EXISTS vehicle_mileage_readings;
@@ -0,0 +1,16 @@
+-- services/vehicle/cmd/migrate/migrations/20250915120000_create-vehicle_mileage_readings.up.sql
+-- Odometer readings of each vehicle (for maintenance intervals). Readings never decrease
+-- for a vehicle, so the latest one always matches vehicles.mileage_km.
+CREATE TABLE IF NOT EXISTS vehicle_mileage_readings (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vehicle_id BINARY(16) NOT NULL,
+    mileage_km INT UNSIGNED NOT NULL,
+    recorded_by VARCHAR(64), -- User ID who recorded the reading, or "system"
+    recorded_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+    INDEX idx_vehicle_mileage_readings_vehicle (vehicle_id, recorded_at),
+
+    CONSTRAINT fk_vehicle_mileage_readings_vehicle
+        FOREIGN KEY (vehicle_id) REFERENCES vehicles(external_id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- services/vehicle/cmd/migrate/migrations/20250915130000_add-vehicles-last-service.down.sql
+ALTER TABLE vehicles
+    DROP COLUMN last_service_at,
+    DROP COLUMN last_service_km;
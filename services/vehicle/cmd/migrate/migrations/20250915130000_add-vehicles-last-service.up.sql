@@ -0,0 +1,6 @@
+-- services/vehicle/cmd/migrate/migrations/20250915130000_add-vehicles-last-service.up.sql
+-- Odometer reading and time of the vehicle's latest service, NULL until one is recorded.
+-- Every service is kept in vehicle_service_records; these drive the maintenance-due query.
+ALTER TABLE vehicles
+    ADD COLUMN last_service_km INT UNSIGNED NULL AFTER mileage_km,
+    ADD COLUMN last_service_at DATETIME(6) NULL AFTER last_service_km;
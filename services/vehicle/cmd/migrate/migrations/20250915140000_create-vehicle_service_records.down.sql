@@ -0,0 +1,2 @@
+-- services/vehicle/cmd/migrate/migrations/20250915140000_create-vehicle_service_records.down.sql
+DROP TABLE IF EXISTS vehicle_service_records;
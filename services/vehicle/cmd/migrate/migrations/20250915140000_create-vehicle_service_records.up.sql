@@ -0,0 +1,18 @@
+-- services/vehicle/cmd/migrate/migrations/20250915140000_create-vehicle_service_records.up.sql
+-- Services performed on each vehicle. mileage_km is NULL when the service was recorded
+-- before the vehicle had any odometer reading.
+CREATE TABLE IF NOT EXISTS vehicle_service_records (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    vehicle_id BINARY(16) NOT NULL,
+    mileage_km INT UNSIGNED,
+    serviced_at DATETIME(6) NOT NULL,
+    notes TEXT,
+    recorded_by VARCHAR(64), -- User ID who recorded the service, or "system"
+    recorded_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+
+    INDEX idx_vehicle_service_records_vehicle (vehicle_id, serviced_at),
+
+    CONSTRAINT fk_vehicle_service_records_vehicle
+        FOREIGN KEY (vehicle_id) REFERENCES vehicles(external_id)
+        ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+-- services/vehicle/cmd/migrate/migrations/20250916100000_add-vehicle_assignments-attention.down.sql
+ALTER TABLE vehicle_assignments
+    DROP COLUMN flagged_at,
+    DROP COLUMN attention_reason;
@@ -0,0 +1,6 @@
+-- services/vehicle/cmd/migrate/migrations/20250916100000_add-vehicle_assignments-attention.up.sql
+-- Open assignments a dispatcher has to sort out, such as a driver suspended while still
+-- holding the vehicle. NULL until the assignment is flagged.
+ALTER TABLE vehicle_assignments
+    ADD COLUMN attention_reason VARCHAR(255) NULL AFTER ended_at,
+    ADD COLUMN flagged_at DATETIME(6) NULL AFTER attention_reason;
@@ -3,3 +3,5 @@ module github.com/adammwaniki/bebabeba/services/vehicle
 go 1.24.2
 
 require google.golang.org/protobuf v1.36.8 // indirect
+
+require github.com/DATA-DOG/go-sqlmock v1.5.2
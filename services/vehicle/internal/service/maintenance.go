@@ -0,0 +1,142 @@
+// services/vehicle/internal/service/maintenance.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/validator"
+	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecordService records a service of the vehicle, performed now unless serviced_at says
+// otherwise, at its current mileage unless mileage_km says otherwise
+func (s *service) RecordService(ctx context.Context, req *genproto.RecordServiceRequest) (*genproto.RecordServiceResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle ID is required")
+	}
+
+	if req.MileageKm != nil {
+		if err := validator.ValidateMileage("mileage_km", req.GetMileageKm()); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+	}
+	if err := validator.ValidateOptionalTimestamp("serviced_at", req.ServicedAt); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+	notes := strings.TrimSpace(req.Notes)
+	if err := validator.ValidateServiceNotes("notes", notes); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	vehicleID, err := uuid.FromString(req.VehicleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vehicle ID format: %v", err)
+	}
+
+	now := time.Now()
+	servicedAt := now
+	if req.ServicedAt != nil {
+		servicedAt = req.ServicedAt.AsTime()
+		if servicedAt.After(now) {
+			return nil, grpcerr.InvalidArgument("validation failed",
+				validator.ValidationError{Field: "serviced_at", Message: "cannot be in the future"})
+		}
+	}
+
+	updatedVehicle, err := s.store.RecordService(ctx, vehicleID, types.ServiceRecord{
+		MileageKm:  req.MileageKm,
+		ServicedAt: servicedAt,
+		Notes:      notes,
+	}, actor.FromIncomingContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrVehicleNotFound):
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		case errors.Is(err, types.ErrServiceOutOfOrder):
+			return nil, status.Errorf(codes.FailedPrecondition, "serviced_at is before the vehicle's last recorded service")
+		case errors.Is(err, types.ErrServiceKmDecreased):
+			return nil, status.Errorf(codes.FailedPrecondition, "mileage_km is lower than the vehicle's last recorded service")
+		}
+		return nil, grpcerr.Internal("failed to record service", err)
+	}
+
+	return &genproto.RecordServiceResponse{
+		Vehicle: updatedVehicle,
+	}, nil
+}
+
+// GetVehiclesDueForMaintenance lists the vehicles that have gone the maintenance interval's
+// distance or time since their last service, with how far past the interval each one is
+func (s *service) GetVehiclesDueForMaintenance(ctx context.Context, req *genproto.GetVehiclesDueForMaintenanceRequest) (*genproto.GetVehiclesDueForMaintenanceResponse, error) {
+	if err := validator.ValidateMaintenanceThresholds(req.KmThreshold, req.DaysThreshold); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	interval := s.maintenance
+	if req.KmThreshold != nil {
+		interval.Km = req.GetKmThreshold()
+	}
+	if req.DaysThreshold != nil {
+		interval.Days = req.GetDaysThreshold()
+	}
+
+	params := types.ListVehiclesParams{
+		PageSize:  pagesize.Clamp(ctx, req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+
+	now := time.Now()
+	servicedBefore := now.AddDate(0, 0, -int(interval.Days))
+
+	vehicles, nextPageToken, totalCount, err := s.store.GetVehiclesDueForMaintenance(ctx, interval.Km, servicedBefore, params)
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get vehicles due for maintenance", err)
+	}
+
+	due := make([]*genproto.MaintenanceDueVehicle, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		due = append(due, maintenanceDue(vehicle, interval, now))
+	}
+
+	return &genproto.GetVehiclesDueForMaintenanceResponse{
+		Vehicles:      due,
+		NextPageToken: nextPageToken,
+		TotalCount:    totalCount,
+	}, nil
+}
+
+// maintenanceDue works out how far past the interval vehicle is, measuring a vehicle that
+// was never serviced the way the store's query does: from 0 km and from its registration,
+// or its creation when that's unknown
+func maintenanceDue(vehicle *genproto.Vehicle, interval types.MaintenanceInterval, now time.Time) *genproto.MaintenanceDueVehicle {
+	kmSinceService := max(0, vehicle.GetMileageKm()-vehicle.GetLastServiceKm())
+
+	since := vehicle.GetCreatedAt()
+	if vehicle.LastServiceAt != nil {
+		since = vehicle.LastServiceAt
+	} else if vehicle.RegistrationDate != nil {
+		since = vehicle.RegistrationDate
+	}
+	daysSinceService := int32(now.Sub(since.AsTime()) / (24 * time.Hour))
+
+	return &genproto.MaintenanceDueVehicle{
+		Vehicle:        vehicle,
+		KmSinceService: kmSinceService,
+		KmOverdue:      max(0, kmSinceService-interval.Km),
+		DaysOverdue:    max(0, daysSinceService-interval.Days),
+	}
+}
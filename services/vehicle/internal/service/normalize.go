@@ -0,0 +1,130 @@
+// services/vehicle/internal/service/normalize.go
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/validator"
+	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NormalizeLegacyRecords walks every vehicle in batches and re-applies NormalizeVehicleFields,
+// updating the vehicles whose stored values differ from their normalized form. A vehicle
+// that can't be updated is reported and skipped; the job carries on with the rest.
+func (s *service) NormalizeLegacyRecords(ctx context.Context, req *genproto.NormalizeLegacyRecordsRequest) (*genproto.NormalizeLegacyRecordsResponse, error) {
+	batchSize := req.GetBatchSize()
+	if batchSize <= 0 {
+		batchSize = types.DefaultNormalizeBatchSize
+	}
+	if batchSize > types.MaxNormalizeBatchSize {
+		batchSize = types.MaxNormalizeBatchSize
+	}
+
+	actorID := actor.FromIncomingContext(ctx)
+	resp := &genproto.NormalizeLegacyRecordsResponse{DryRun: req.GetDryRun()}
+
+	var afterID uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		batch, err := s.store.ListNormalizableVehicles(ctx, afterID, batchSize)
+		if err != nil {
+			return nil, grpcerr.Internal("failed to list vehicles", err)
+		}
+
+		for _, vehicle := range batch {
+			resp.Scanned++
+
+			changes := vehicleNormalizationChanges(vehicle)
+			if len(changes) == 0 {
+				continue
+			}
+
+			record := &genproto.NormalizedRecord{Id: vehicle.ExternalID}
+			for _, change := range changes {
+				record.Fields = append(record.Fields, &genproto.NormalizedField{
+					Field:    change.Field,
+					OldValue: change.OldValue,
+					NewValue: change.NewValue,
+				})
+			}
+
+			if !req.GetDryRun() {
+				err := s.store.ApplyVehicleNormalization(ctx, vehicle, changes, actorID)
+				switch {
+				case err == nil:
+				case errors.Is(err, types.ErrDuplicateEntry):
+					record.Error = "normalized value is already used by another vehicle"
+				case errors.Is(err, types.ErrStaleRecord):
+					record.Error = "vehicle was changed while the job ran"
+				default:
+					return nil, status.Errorf(codes.Internal, "failed to normalize vehicle %s: %v", vehicle.ExternalID, err)
+				}
+			}
+
+			if record.Error != "" {
+				resp.Failed++
+			} else {
+				resp.Changed++
+			}
+
+			if len(resp.Records) < types.MaxReportedNormalizations {
+				resp.Records = append(resp.Records, record)
+			} else {
+				resp.RecordsTruncated = true
+			}
+		}
+
+		if int32(len(batch)) < batchSize {
+			return resp, nil
+		}
+		afterID = batch[len(batch)-1].InternalID
+	}
+}
+
+// vehicleNormalizationChanges runs the stored values through the same normalization new
+// vehicles get and returns the fields that come out different
+func vehicleNormalizationChanges(vehicle types.NormalizableVehicle) []types.FieldChange {
+	input := &genproto.VehicleInput{
+		LicensePlate:  vehicle.LicensePlate,
+		Make:          vehicle.Make,
+		Model:         vehicle.Model,
+		Color:         vehicle.Color,
+		EngineNumber:  vehicle.EngineNumber,
+		ChassisNumber: vehicle.ChassisNumber,
+	}
+	validator.NormalizeVehicleFields(input)
+
+	fields := []struct {
+		name       string
+		stored     string
+		normalized string
+	}{
+		{"license_plate", vehicle.LicensePlate, input.LicensePlate},
+		{"make", vehicle.Make, input.Make},
+		{"model", vehicle.Model, input.Model},
+		{"color", vehicle.Color, input.Color},
+		{"engine_number", vehicle.EngineNumber, input.EngineNumber},
+		{"chassis_number", vehicle.ChassisNumber, input.ChassisNumber},
+	}
+
+	var changes []types.FieldChange
+	for _, field := range fields {
+		if field.stored != field.normalized {
+			changes = append(changes, types.FieldChange{
+				Field:    field.name,
+				OldValue: field.stored,
+				NewValue: field.normalized,
+			})
+		}
+	}
+	return changes
+}
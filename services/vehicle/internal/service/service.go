@@ -6,34 +6,110 @@ import (
 	"errors"
 	"fmt"
 	"log"
-
+	"strings"
+	"time"
+
+	"encoding/hex"
+	"github.com/adammwaniki/bebabeba/services/common/actor"
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/featureflags"
+	"github.com/adammwaniki/bebabeba/services/common/grpcerr"
+	"github.com/adammwaniki/bebabeba/services/common/tracing"
 	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/validator"
 	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	"github.com/gofrs/uuid/v5"
 	"github.com/influxdata/influxdb/v2/pkg/snowflake"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type service struct {
-	store types.VehicleStore
+	store        types.VehicleStore
+	ids          *snowflake.Generator
+	flags        *featureflags.Flags
+	searchLimits utils.SearchTermLimits
+	maintenance  types.MaintenanceInterval
 }
 
 // NewService creates a new vehicle service instance
-func NewService(store types.VehicleStore) *service {
-	return &service{store: store}
+func NewService(store types.VehicleStore, ids *snowflake.Generator, flags *featureflags.Flags, searchLimits utils.SearchTermLimits) *service {
+	return &service{store: store, ids: ids, flags: flags, searchLimits: searchLimits, maintenance: types.DefaultMaintenanceInterval}
+}
+
+// SetMaintenanceInterval sets the default distance and time between services after which
+// GetVehiclesDueForMaintenance reports a vehicle as due
+func (s *service) SetMaintenanceInterval(interval types.MaintenanceInterval) {
+	s.maintenance = interval
 }
 
 // Vehicle CRUD operations
 
+// createVehicleScope keeps CreateVehicle's idempotency keys apart from other endpoints'
+const createVehicleScope = "CreateVehicle"
+
 func (s *service) CreateVehicle(ctx context.Context, req *genproto.CreateVehicleRequest) (*genproto.CreateVehicleResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicle.CreateVehicle")
+	defer span.End()
+
 	// Validate the request
 	if err := validator.ValidateCreateVehicleRequest(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	externalID, err := uuid.NewV4()
+	if err != nil {
+		return nil, grpcerr.Internal("failed to generate UUID", err)
+	}
+
+	key := idempotency.FromIncomingContext(ctx)
+	if key == "" {
+		span.SetAttributes(attribute.String("vehicle.id", externalID.String()))
+		return s.createVehicle(ctx, req, externalID)
+	}
+	if err := idempotency.Validate(key); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// The key is claimed before the duplicate checks, so a retry gets the vehicle back
+	// rather than being told its license plate is taken
+	idemKey := idempotency.Key{Scope: createVehicleScope, ActorID: actor.FromIncomingContext(ctx), Value: key}
+	boundID, err := s.store.ClaimIdempotencyKey(ctx, idemKey, externalID, idempotency.TTL)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to claim idempotency key", err)
+	}
+	span.SetAttributes(attribute.String("vehicle.id", boundID.String()))
+
+	if boundID != externalID {
+		vehicle, err := s.store.GetVehicleByID(ctx, boundID)
+		if err != nil {
+			if errors.Is(err, types.ErrVehicleNotFound) {
+				return nil, status.Errorf(codes.Aborted, "a request with this idempotency key is still in progress, or its vehicle was deleted")
+			}
+			return nil, grpcerr.Internal("failed to get vehicle for idempotency key", err)
+		}
+		log.Printf("Replaying CreateVehicle for idempotency key %s: vehicle %s", key, boundID)
+		return &genproto.CreateVehicleResponse{Vehicle: vehicle}, nil
+	}
+
+	resp, err := s.createVehicle(ctx, req, externalID)
+	if err != nil {
+		// Free the key so the client can retry the failed request with it. The request's
+		// context may already be done, which mustn't stop the release.
+		if rerr := s.store.ReleaseIdempotencyKey(context.WithoutCancel(ctx), idemKey, externalID); rerr != nil {
+			log.Printf("Failed to release idempotency key %s: %v", key, rerr)
+		}
+		return nil, err
 	}
+	return resp, nil
+}
 
+// createVehicle creates the vehicle requested under externalID
+func (s *service) createVehicle(ctx context.Context, req *genproto.CreateVehicleRequest, externalID uuid.UUID) (*genproto.CreateVehicleResponse, error) {
 	vehicle := req.Vehicle
 
 	// Verify vehicle type exists
@@ -42,31 +118,23 @@ func (s *service) CreateVehicle(ctx context.Context, req *genproto.CreateVehicle
 		if errors.Is(err, types.ErrVehicleTypeNotFound) {
 			return nil, status.Errorf(codes.InvalidArgument, "vehicle type not found: %s", vehicle.VehicleTypeId)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to validate vehicle type: %v", err)
+		return nil, grpcerr.Internal("failed to validate vehicle type", err)
 	}
 
-	// Check for duplicate license plate
+	// Check for duplicate license plate. Plates of retired vehicles can be reused once their cooldown is over.
 	existing, err := s.store.GetVehicleByLicensePlate(ctx, vehicle.LicensePlate)
 	if err != nil && !errors.Is(err, types.ErrVehicleNotFound) {
-		return nil, status.Errorf(codes.Internal, "failed to check license plate uniqueness: %v", err)
+		return nil, grpcerr.Internal("failed to check license plate uniqueness", err)
 	}
-	if existing != nil {
+	if existing != nil && existing.Status != genproto.VehicleStatus_RETIRED {
 		return nil, status.Errorf(codes.AlreadyExists, "vehicle with license plate %s already exists", vehicle.LicensePlate)
 	}
-
-	// Generate unique IDs
-	nodeID, err := utils.GetSnowflakeNodeID()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get snowflake node ID: %v", err)
+	if err := s.checkPlateTombstone(ctx, vehicle.LicensePlate, req.AdminOverride); err != nil {
+		return nil, err
 	}
 
-	generator := snowflake.New(int(nodeID))
-	internalID := generator.Next()
-
-	externalID, err := uuid.NewV4()
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate UUID: %v", err)
-	}
+	// Generate unique IDs
+	internalID := s.ids.Next()
 
 	// Prepare vehicle data
 	vehicleData := &types.VehicleData{
@@ -99,15 +167,15 @@ func (s *service) CreateVehicle(ctx context.Context, req *genproto.CreateVehicle
 	// Create vehicle in store
 	if err := s.store.CreateVehicle(ctx, internalID, externalID, vehicleData); err != nil {
 		if errors.Is(err, types.ErrDuplicateEntry) {
-			return nil, status.Errorf(codes.AlreadyExists, "vehicle with this license plate already exists")
+			return nil, status.Errorf(codes.AlreadyExists, "vehicle with this license plate or chassis number already exists")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to create vehicle: %v", err)
+		return nil, grpcerr.Internal("failed to create vehicle", err)
 	}
 
 	// Retrieve the created vehicle
 	createdVehicle, err := s.store.GetVehicleByID(ctx, externalID)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to retrieve created vehicle: %v", err)
+		return nil, grpcerr.Internal("failed to retrieve created vehicle", err)
 	}
 
 	return &genproto.CreateVehicleResponse{
@@ -132,7 +200,7 @@ func (s *service) GetVehicle(ctx context.Context, req *genproto.GetVehicleReques
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return nil, status.Errorf(codes.NotFound, "vehicle not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get vehicle: %v", err)
+		return nil, grpcerr.Internal("failed to get vehicle", err)
 	}
 
 	return &genproto.GetVehicleResponse{
@@ -140,16 +208,78 @@ func (s *service) GetVehicle(ctx context.Context, req *genproto.GetVehicleReques
 	}, nil
 }
 
-func (s *service) ListVehicles(ctx context.Context, req *genproto.ListVehiclesRequest) (*genproto.ListVehiclesResponse, error) {
-	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
+// GetVehicleByChassisNumber finds a vehicle from the chassis number on its frame, normalized
+// the same way it was when the vehicle was saved
+func (s *service) GetVehicleByChassisNumber(ctx context.Context, req *genproto.GetVehicleByChassisNumberRequest) (*genproto.GetVehicleResponse, error) {
+	chassisNumber := validator.NormalizeChassisNumber(req.ChassisNumber)
+	if chassisNumber == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "chassis number is required")
+	}
+
+	vehicle, err := s.store.GetVehicleByChassisNumber(ctx, chassisNumber)
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		}
+		return nil, grpcerr.Internal("failed to get vehicle", err)
+	}
+
+	return &genproto.GetVehicleResponse{
+		Vehicle: vehicle,
+	}, nil
+}
+
+// BatchGetVehicles looks up many vehicles at once so callers rendering lists of vehicle
+// IDs don't need a GetVehicle round trip per ID. Unknown IDs are reported rather than
+// failing the call.
+func (s *service) BatchGetVehicles(ctx context.Context, req *genproto.BatchGetVehiclesRequest) (*genproto.BatchGetVehiclesResponse, error) {
+	if len(req.VehicleIds) > types.MaxBatchGetVehicles {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d vehicle IDs can be looked up per request, got %d", types.MaxBatchGetVehicles, len(req.VehicleIds))
+	}
+
+	// Drop blanks and duplicates before building the IN list; the rest must be UUIDs
+	requested := make([]string, 0, len(req.VehicleIds))
+	vehicleIDs := make([]uuid.UUID, 0, len(req.VehicleIds))
+	seen := make(map[string]bool, len(req.VehicleIds))
+	for i, id := range req.VehicleIds {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		vehicleID, err := uuid.FromString(id)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid vehicle ID format at vehicle_ids[%d]: %v", i, err)
+		}
+		requested = append(requested, id)
+		vehicleIDs = append(vehicleIDs, vehicleID)
+	}
+
+	found, err := s.store.GetVehiclesByIDs(ctx, vehicleIDs)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to get vehicles by IDs", err)
+	}
+
+	// The store keys vehicles by their hex ID; answer in the form each ID was asked for
+	resp := &genproto.BatchGetVehiclesResponse{
+		Vehicles: make(map[string]*genproto.Vehicle, len(found)),
 	}
-	if pageSize > 100 {
-		pageSize = 100
+	for i, id := range requested {
+		if vehicle, ok := found[hex.EncodeToString(vehicleIDs[i].Bytes())]; ok {
+			resp.Vehicles[id] = vehicle
+		} else {
+			resp.NotFoundIds = append(resp.NotFoundIds, id)
+		}
 	}
 
+	return resp, nil
+}
+
+func (s *service) ListVehicles(ctx context.Context, req *genproto.ListVehiclesRequest) (*genproto.ListVehiclesResponse, error) {
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
 	// Prepare parameters
 	params := types.ListVehiclesParams{
 		PageSize:  pageSize,
@@ -162,27 +292,55 @@ func (s *service) ListVehicles(ctx context.Context, req *genproto.ListVehiclesRe
 	if req.VehicleTypeFilter != nil && *req.VehicleTypeFilter != "" {
 		params.VehicleTypeFilter = req.VehicleTypeFilter
 	}
-	if req.MakeFilter != nil && *req.MakeFilter != "" {
-		params.MakeFilter = req.MakeFilter
+	if makeFilter := strings.TrimSpace(req.GetMakeFilter()); makeFilter != "" {
+		if err := validator.ValidateSearchTerm("make_filter", makeFilter, s.searchLimits); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+		params.MakeFilter = &makeFilter
+	}
+	if search := strings.TrimSpace(req.GetSearch()); search != "" {
+		if err := validator.ValidateSearchTerm("search", search, s.searchLimits); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+		params.SearchFilter = &search
+	}
+	switch req.GetMakeMatch() {
+	case genproto.MakeMatch_MAKE_MATCH_UNSPECIFIED, genproto.MakeMatch_MAKE_PREFIX:
+		params.MakeMatch = genproto.MakeMatch_MAKE_PREFIX
+	case genproto.MakeMatch_MAKE_EXACT, genproto.MakeMatch_MAKE_CONTAINS:
+		params.MakeMatch = req.GetMakeMatch()
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid make_match: %v", req.GetMakeMatch())
+	}
+
+	sort, err := validator.ValidateVehicleSort(req.GetSortBy(), req.GetSortOrder())
+	if err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
+	params.Sort = sort
 
 	// Get vehicles from store
-	vehicles, nextPageToken, err := s.store.ListVehicles(ctx, params)
+	vehicles, nextPageToken, prevPageToken, totalCount, err := s.store.ListVehicles(ctx, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list vehicles: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list vehicles", err)
 	}
 
 	return &genproto.ListVehiclesResponse{
 		Vehicles:      vehicles,
 		NextPageToken: nextPageToken,
-		TotalCount:    int32(len(vehicles)), // Note: This is just the current page count
+		PrevPageToken: prevPageToken,
+		TotalCount:    totalCount,
 	}, nil
 }
 
 func (s *service) UpdateVehicle(ctx context.Context, req *genproto.UpdateVehicleRequest) (*genproto.UpdateVehicleResponse, error) {
 	// Validate the request
-	if err := validator.ValidateUpdateVehicleRequest(req); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+	warnings, err := validator.ValidateUpdateVehicleRequest(req)
+	if err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Parse vehicle ID
@@ -197,7 +355,7 @@ func (s *service) UpdateVehicle(ctx context.Context, req *genproto.UpdateVehicle
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return nil, status.Errorf(codes.NotFound, "vehicle not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get existing vehicle: %v", err)
+		return nil, grpcerr.Internal("failed to get existing vehicle", err)
 	}
 
 	vehicle := req.Vehicle
@@ -209,7 +367,7 @@ func (s *service) UpdateVehicle(ctx context.Context, req *genproto.UpdateVehicle
 			if errors.Is(err, types.ErrVehicleTypeNotFound) {
 				return nil, status.Errorf(codes.InvalidArgument, "vehicle type not found: %s", vehicle.VehicleTypeId)
 			}
-			return nil, status.Errorf(codes.Internal, "failed to validate vehicle type: %v", err)
+			return nil, grpcerr.Internal("failed to validate vehicle type", err)
 		}
 	}
 
@@ -217,11 +375,14 @@ func (s *service) UpdateVehicle(ctx context.Context, req *genproto.UpdateVehicle
 	if vehicle.LicensePlate != "" && vehicle.LicensePlate != existingVehicle.LicensePlate {
 		existing, err := s.store.GetVehicleByLicensePlate(ctx, vehicle.LicensePlate)
 		if err != nil && !errors.Is(err, types.ErrVehicleNotFound) {
-			return nil, status.Errorf(codes.Internal, "failed to check license plate uniqueness: %v", err)
+			return nil, grpcerr.Internal("failed to check license plate uniqueness", err)
 		}
-		if existing != nil && existing.Id != existingVehicle.Id {
+		if existing != nil && existing.Id != existingVehicle.Id && existing.Status != genproto.VehicleStatus_RETIRED {
 			return nil, status.Errorf(codes.AlreadyExists, "vehicle with license plate %s already exists", vehicle.LicensePlate)
 		}
+		if err := s.checkPlateTombstone(ctx, vehicle.LicensePlate, false); err != nil {
+			return nil, err
+		}
 	}
 
 	// Prepare update fields
@@ -266,19 +427,20 @@ func (s *service) UpdateVehicle(ctx context.Context, req *genproto.UpdateVehicle
 	}
 
 	// Update vehicle in store
-	updatedVehicle, err := s.store.UpdateVehicle(ctx, vehicleID, updates, req.UpdateMask)
+	updatedVehicle, err := s.store.UpdateVehicle(ctx, vehicleID, updates, req.UpdateMask, actor.FromIncomingContext(ctx))
 	if err != nil {
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return nil, status.Errorf(codes.NotFound, "vehicle not found")
 		}
 		if errors.Is(err, types.ErrDuplicateEntry) {
-			return nil, status.Errorf(codes.AlreadyExists, "duplicate license plate")
+			return nil, status.Errorf(codes.AlreadyExists, "duplicate license plate or chassis number")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update vehicle: %v", err)
+		return nil, grpcerr.Internal("failed to update vehicle", err)
 	}
 
 	return &genproto.UpdateVehicleResponse{
-		Vehicle: updatedVehicle,
+		Vehicle:               updatedVehicle,
+		NormalizationWarnings: warnings,
 	}, nil
 }
 
@@ -299,7 +461,7 @@ func (s *service) DeleteVehicle(ctx context.Context, req *genproto.DeleteVehicle
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return status.Errorf(codes.NotFound, "vehicle not found")
 		}
-		return status.Errorf(codes.Internal, "failed to get vehicle: %v", err)
+		return grpcerr.Internal("failed to get vehicle", err)
 	}
 
 	// Business rule: Cannot delete assigned vehicles
@@ -308,11 +470,11 @@ func (s *service) DeleteVehicle(ctx context.Context, req *genproto.DeleteVehicle
 	}
 
 	// Soft delete by setting status to RETIRED
-	if err := s.store.DeleteVehicle(ctx, vehicleID); err != nil {
+	if err := s.store.DeleteVehicle(ctx, vehicleID, actor.FromIncomingContext(ctx)); err != nil {
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return status.Errorf(codes.NotFound, "vehicle not found")
 		}
-		return status.Errorf(codes.Internal, "failed to delete vehicle: %v", err)
+		return grpcerr.Internal("failed to delete vehicle", err)
 	}
 
 	return nil
@@ -331,17 +493,11 @@ func (s *service) GetVehiclesByType(ctx context.Context, req *genproto.GetVehicl
 		if errors.Is(err, types.ErrVehicleTypeNotFound) {
 			return nil, status.Errorf(codes.NotFound, "vehicle type not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to validate vehicle type: %v", err)
+		return nil, grpcerr.Internal("failed to validate vehicle type", err)
 	}
 
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
 
 	params := types.ListVehiclesParams{
 		PageSize:     pageSize,
@@ -349,15 +505,19 @@ func (s *service) GetVehiclesByType(ctx context.Context, req *genproto.GetVehicl
 		StatusFilter: req.StatusFilter,
 	}
 
-	vehicles, nextPageToken, err := s.store.GetVehiclesByType(ctx, req.VehicleTypeId, params)
+	vehicles, nextPageToken, prevPageToken, totalCount, err := s.store.GetVehiclesByType(ctx, req.VehicleTypeId, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get vehicles by type: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get vehicles by type", err)
 	}
 
 	return &genproto.ListVehiclesResponse{
 		Vehicles:      vehicles,
 		NextPageToken: nextPageToken,
-		TotalCount:    int32(len(vehicles)),
+		PrevPageToken: prevPageToken,
+		TotalCount:    totalCount,
 	}, nil
 }
 
@@ -369,33 +529,111 @@ func (s *service) GetAvailableVehicles(ctx context.Context, req *genproto.GetAva
 			if errors.Is(err, types.ErrVehicleTypeNotFound) {
 				return nil, status.Errorf(codes.NotFound, "vehicle type not found")
 			}
-			return nil, status.Errorf(codes.Internal, "failed to validate vehicle type: %v", err)
+			return nil, grpcerr.Internal("failed to validate vehicle type", err)
+		}
+	}
+
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	params := types.ListVehiclesParams{
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
+	}
+
+	vehicles, nextPageToken, totalCount, err := s.store.GetAvailableVehicles(ctx, req.VehicleTypeId, params)
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get available vehicles", err)
+	}
+
+	return &genproto.ListVehiclesResponse{
+		Vehicles:      vehicles,
+		NextPageToken: nextPageToken,
+		TotalCount:    totalCount,
+	}, nil
+}
+
+func (s *service) GetDispatchCandidates(ctx context.Context, req *genproto.GetDispatchCandidatesRequest) (*genproto.ListVehiclesResponse, error) {
+	if err := s.flags.Require(featureflags.DispatchCandidates); err != nil {
+		return nil, err
+	}
+
+	if req.GetMinSeats() < 0 || req.GetMinSeats() > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "min_seats must be between 0 and 100")
+	}
+
+	// Validate vehicle type if provided
+	if req.VehicleTypeId != nil && *req.VehicleTypeId != "" {
+		_, err := s.store.GetVehicleTypeByID(ctx, *req.VehicleTypeId)
+		if err != nil {
+			if errors.Is(err, types.ErrVehicleTypeNotFound) {
+				return nil, status.Errorf(codes.NotFound, "vehicle type not found")
+			}
+			return nil, grpcerr.Internal("failed to validate vehicle type", err)
+		}
+	}
+
+	// Insurance must cover the dispatch date, which is today unless the caller is planning ahead
+	insuranceValidOn := time.Now()
+	if req.InsuranceValidOn != nil {
+		if err := req.InsuranceValidOn.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid insurance_valid_on: %v", err)
 		}
+		insuranceValidOn = req.InsuranceValidOn.AsTime()
 	}
 
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	filter := types.DispatchFilter{
+		VehicleTypeID:    req.VehicleTypeId,
+		MinSeats:         req.GetMinSeats(),
+		InsuranceValidOn: insuranceValidOn,
 	}
-	if pageSize > 100 {
-		pageSize = 100
+	params := types.ListVehiclesParams{
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
 	}
 
+	vehicles, nextPageToken, totalCount, err := s.store.GetDispatchCandidates(ctx, filter, params)
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to get dispatch candidates", err)
+	}
+
+	return &genproto.ListVehiclesResponse{
+		Vehicles:      vehicles,
+		NextPageToken: nextPageToken,
+		TotalCount:    totalCount,
+	}, nil
+}
+
+func (s *service) ListRecentlyUpdatedVehicles(ctx context.Context, req *genproto.ListRecentlyUpdatedVehiclesRequest) (*genproto.ListVehiclesResponse, error) {
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
 	params := types.ListVehiclesParams{
 		PageSize:  pageSize,
 		PageToken: req.GetPageToken(),
 	}
 
-	vehicles, nextPageToken, err := s.store.GetAvailableVehicles(ctx, req.VehicleTypeId, params)
+	vehicles, nextPageToken, totalCount, err := s.store.ListRecentlyUpdatedVehicles(ctx, params)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get available vehicles: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list recently updated vehicles", err)
 	}
 
 	return &genproto.ListVehiclesResponse{
 		Vehicles:      vehicles,
 		NextPageToken: nextPageToken,
-		TotalCount:    int32(len(vehicles)),
+		TotalCount:    totalCount,
 	}, nil
 }
 
@@ -406,7 +644,7 @@ func (s *service) UpdateVehicleStatus(ctx context.Context, req *genproto.UpdateV
 
 	// Validate status
 	if err := validator.ValidateVehicleStatus("status", req.Status); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Parse vehicle ID
@@ -421,33 +659,356 @@ func (s *service) UpdateVehicleStatus(ctx context.Context, req *genproto.UpdateV
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return nil, status.Errorf(codes.NotFound, "vehicle not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get current vehicle: %v", err)
+		return nil, grpcerr.Internal("failed to get current vehicle", err)
 	}
 
 	// Check if status transition is valid
-	if !types.IsValidStatusTransition(currentVehicle.Status, req.Status) {
-		return nil, status.Errorf(codes.InvalidArgument, 
-			"invalid status transition from %s to %s", 
-			currentVehicle.Status.String(), req.Status.String())
+	if blocks := statusChangeBlocks(currentVehicle, req.Status, req.AdminOverride); len(blocks) > 0 {
+		return nil, blocks[0].Err()
+	}
+	if !types.IsKnownStatus(currentVehicle.Status) {
+		log.Printf("WARNING: admin override recovering vehicle %s from unrecognized status %s to %s",
+			req.VehicleId, currentVehicle.Status.String(), req.Status.String())
 	}
 
 	// Update status
-	updatedVehicle, err := s.store.UpdateVehicleStatus(ctx, vehicleID, req.Status)
+	updatedVehicle, err := s.store.UpdateVehicleStatus(ctx, vehicleID, req.Status, strings.TrimSpace(req.Reason), actor.FromIncomingContext(ctx))
 	if err != nil {
 		if errors.Is(err, types.ErrVehicleNotFound) {
 			return nil, status.Errorf(codes.NotFound, "vehicle not found")
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update vehicle status: %v", err)
+		return nil, grpcerr.Internal("failed to update vehicle status", err)
 	}
 
-	log.Printf("Vehicle %s status updated from %s to %s", 
-		req.VehicleId, currentVehicle.Status.String(), req.Status.String())
+	log.Printf("Vehicle %s status updated from %s to %s. Reason: %s", 
+		req.VehicleId, currentVehicle.Status.String(), req.Status.String(), req.Reason)
 
 	return &genproto.UpdateVehicleStatusResponse{
 		Vehicle: updatedVehicle,
 	}, nil
 }
 
+// RecordVehicleMileage records an odometer reading. A reading lower than the vehicle's
+// current mileage is rejected, since odometers don't go backwards; the same reading
+// again is accepted.
+func (s *service) RecordVehicleMileage(ctx context.Context, req *genproto.RecordVehicleMileageRequest) (*genproto.RecordVehicleMileageResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle ID is required")
+	}
+
+	if err := validator.ValidateMileage("mileage_km", req.MileageKm); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	vehicleID, err := uuid.FromString(req.VehicleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vehicle ID format: %v", err)
+	}
+
+	// Check against the current reading first so the error can name it. The store
+	// checks again under the row lock.
+	currentVehicle, err := s.store.GetVehicleByID(ctx, vehicleID)
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		}
+		return nil, grpcerr.Internal("failed to get current vehicle", err)
+	}
+	if currentVehicle.MileageKm != nil && req.MileageKm < *currentVehicle.MileageKm {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"mileage %d km is lower than the last reading of %d km", req.MileageKm, *currentVehicle.MileageKm)
+	}
+
+	updatedVehicle, err := s.store.RecordVehicleMileage(ctx, vehicleID, req.MileageKm, actor.FromIncomingContext(ctx))
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		}
+		if errors.Is(err, types.ErrMileageDecreased) {
+			return nil, status.Errorf(codes.FailedPrecondition, "mileage %d km is lower than the last reading", req.MileageKm)
+		}
+		return nil, grpcerr.Internal("failed to record vehicle mileage", err)
+	}
+
+	return &genproto.RecordVehicleMileageResponse{
+		Vehicle: updatedVehicle,
+	}, nil
+}
+
+func (s *service) ValidateVehicleStatusChange(ctx context.Context, req *genproto.ValidateVehicleStatusChangeRequest) (*genproto.ValidateVehicleStatusChangeResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle ID is required")
+	}
+
+	// Validate status
+	if err := validator.ValidateVehicleStatus("status", req.Status); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	vehicleID, err := uuid.FromString(req.VehicleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vehicle ID format: %v", err)
+	}
+
+	currentVehicle, err := s.store.GetVehicleByID(ctx, vehicleID)
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		}
+		return nil, grpcerr.Internal("failed to get current vehicle", err)
+	}
+
+	blocks := statusChangeBlocks(currentVehicle, req.Status, req.AdminOverride)
+	reasons := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		reasons = append(reasons, block.Message())
+	}
+
+	return &genproto.ValidateVehicleStatusChangeResponse{
+		Allowed:       len(blocks) == 0,
+		Reasons:       reasons,
+		CurrentStatus: currentVehicle.Status,
+	}, nil
+}
+
+// statusChangeBlocks returns everything that stops vehicle moving to the target status,
+// as the errors UpdateVehicleStatus fails with. UpdateVehicleStatus and its dry run
+// ValidateVehicleStatusChange both go through here so they can't disagree.
+//
+// A vehicle whose current status is not part of the rules (legacy or corrupt data)
+// can only be recovered by an admin.
+func statusChangeBlocks(vehicle *genproto.Vehicle, target genproto.VehicleStatus, adminOverride bool) []*status.Status {
+	var blocks []*status.Status
+	if !types.IsKnownStatus(vehicle.Status) {
+		if !adminOverride {
+			blocks = append(blocks, status.Newf(codes.FailedPrecondition,
+				"vehicle has unrecognized status %s; an admin override is required to recover it",
+				vehicle.Status.String()))
+		} else if !types.IsValidRecoveryTransition(vehicle.Status, target) {
+			blocks = append(blocks, status.Newf(codes.InvalidArgument,
+				"vehicle with unrecognized status %s can only be recovered to ACTIVE or MAINTENANCE",
+				vehicle.Status.String()))
+		}
+	} else if !types.IsValidStatusTransition(vehicle.Status, target) {
+		blocks = append(blocks, status.Newf(codes.InvalidArgument,
+			"invalid status transition from %s to %s",
+			vehicle.Status.String(), target.String()))
+	}
+	return blocks
+}
+
+func (s *service) GetVehicleStatusHistory(ctx context.Context, req *genproto.GetVehicleStatusHistoryRequest) (*genproto.GetVehicleStatusHistoryResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle ID is required")
+	}
+
+	vehicleID, err := uuid.FromString(req.VehicleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vehicle ID format: %v", err)
+	}
+
+	// Validate page size
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
+
+	// Distinguish an unknown vehicle from one that has never changed status
+	if _, err := s.store.GetVehicleByID(ctx, vehicleID); err != nil {
+		if errors.Is(err, types.ErrVehicleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		}
+		return nil, grpcerr.Internal("failed to get vehicle", err)
+	}
+
+	entries, nextPageToken, err := s.store.ListVehicleStatusHistory(ctx, vehicleID, pageSize, req.GetPageToken())
+	if err != nil {
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list status history", err)
+	}
+
+	return &genproto.GetVehicleStatusHistoryResponse{
+		Entries:       entries,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// AssignVehicle hands an ACTIVE vehicle to a driver. The driver ID is a staff service
+// driver ID, recorded as given since the vehicle service has no view of drivers.
+func (s *service) AssignVehicle(ctx context.Context, req *genproto.AssignVehicleRequest) (*genproto.AssignVehicleResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "vehicle ID is required")
+	}
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	vehicleID, err := uuid.FromString(req.VehicleId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid vehicle ID format: %v", err)
+	}
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	assignment, err := s.store.CreateAssignment(ctx, vehicleID, driverID, actor.FromIncomingContext(ctx))
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrVehicleNotFound):
+			return nil, status.Errorf(codes.NotFound, "vehicle not found")
+		case errors.Is(err, types.ErrVehicleInUse):
+			if current, lookupErr := s.store.GetActiveAssignmentByVehicle(ctx, vehicleID); lookupErr == nil {
+				return nil, status.Errorf(codes.FailedPrecondition, "vehicle is already assigned to driver %s", current.DriverId)
+			}
+			return nil, status.Errorf(codes.FailedPrecondition, "vehicle is already assigned")
+		case errors.Is(err, types.ErrVehicleNotActive):
+			return nil, status.Errorf(codes.FailedPrecondition, "only ACTIVE vehicles can be assigned: %v", err)
+		}
+		return nil, grpcerr.Internal("failed to assign vehicle", err)
+	}
+
+	vehicle, err := s.store.GetVehicleByID(ctx, vehicleID)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to get assigned vehicle", err)
+	}
+
+	log.Printf("Vehicle %s assigned to driver %s", req.VehicleId, req.DriverId)
+
+	return &genproto.AssignVehicleResponse{
+		Vehicle:    vehicle,
+		Assignment: assignment,
+	}, nil
+}
+
+// GetDriverAssignment returns the vehicle a driver currently holds along with the assignment
+func (s *service) GetDriverAssignment(ctx context.Context, req *genproto.GetDriverAssignmentRequest) (*genproto.GetDriverAssignmentResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	assignment, err := s.store.GetActiveAssignmentByDriver(ctx, driverID)
+	if err != nil {
+		if errors.Is(err, types.ErrAssignmentNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver has no vehicle assigned")
+		}
+		return nil, grpcerr.Internal("failed to get assignment", err)
+	}
+
+	vehicleID, err := uuid.FromString(assignment.VehicleId)
+	if err != nil {
+		return nil, grpcerr.Internal("invalid assigned vehicle ID", err)
+	}
+	vehicle, err := s.store.GetVehicleByID(ctx, vehicleID)
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleNotFound) {
+			return nil, status.Errorf(codes.NotFound, "assigned vehicle not found")
+		}
+		return nil, grpcerr.Internal("failed to get assigned vehicle", err)
+	}
+
+	return &genproto.GetDriverAssignmentResponse{
+		Vehicle:    vehicle,
+		Assignment: assignment,
+	}, nil
+}
+
+// FlagDriverAssignment marks the driver's open assignment for dispatcher attention, e.g.
+// when the driver is suspended while still holding the vehicle
+func (s *service) FlagDriverAssignment(ctx context.Context, req *genproto.FlagDriverAssignmentRequest) (*genproto.FlagDriverAssignmentResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "driver ID is required")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reason is required")
+	}
+	if len(reason) > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "reason must be at most 255 characters")
+	}
+
+	driverID, err := uuid.FromString(req.DriverId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid driver ID format: %v", err)
+	}
+
+	assignment, err := s.store.FlagActiveAssignmentByDriver(ctx, driverID, reason)
+	if err != nil {
+		if errors.Is(err, types.ErrAssignmentNotFound) {
+			return nil, status.Errorf(codes.NotFound, "driver has no vehicle assigned")
+		}
+		return nil, grpcerr.Internal("failed to flag assignment", err)
+	}
+
+	log.Printf("Assignment %s of vehicle %s flagged for dispatch: %s", assignment.Id, assignment.VehicleId, reason)
+
+	return &genproto.FlagDriverAssignmentResponse{Assignment: assignment}, nil
+}
+
+// Reporting
+
+// GetFleetUtilization reports, per day or week, how much of the fleet was ACTIVE, ASSIGNED
+// or in MAINTENANCE, reconstructed from vehicle_status_history
+func (s *service) GetFleetUtilization(ctx context.Context, req *genproto.GetFleetUtilizationRequest) (*genproto.GetFleetUtilizationResponse, error) {
+	if req.From == nil || req.To == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "from and to are required")
+	}
+
+	var bucketSize time.Duration
+	switch req.Granularity {
+	case genproto.UtilizationGranularity_GRANULARITY_UNSPECIFIED, genproto.UtilizationGranularity_GRANULARITY_DAILY:
+		bucketSize = 24 * time.Hour
+	case genproto.UtilizationGranularity_GRANULARITY_WEEKLY:
+		bucketSize = 7 * 24 * time.Hour
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported granularity %s", req.Granularity.String())
+	}
+
+	now := time.Now().UTC()
+	from := bucketStart(req.From.AsTime().UTC(), req.Granularity)
+	to := req.To.AsTime().UTC()
+	if to.After(now) {
+		to = now
+	}
+	if !to.After(from) {
+		return nil, status.Errorf(codes.InvalidArgument, "to must be after from and not before the start of the first bucket")
+	}
+
+	bucketCount := int((to.Sub(from) + bucketSize - 1) / bucketSize)
+	if bucketCount > types.MaxUtilizationBuckets {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"range covers %d buckets, at most %d are allowed", bucketCount, types.MaxUtilizationBuckets)
+	}
+
+	vehicles, changes, err := s.store.GetFleetStatusTimeline(ctx, from, to)
+	if err != nil {
+		return nil, grpcerr.Internal("failed to load fleet status history", err)
+	}
+
+	return &genproto.GetFleetUtilizationResponse{
+		Buckets: fleetUtilization(vehicles, changes, from, to, bucketSize),
+	}, nil
+}
+
+// ValidateLicensePlate runs the CreateVehicle plate checks without touching the database,
+// so clients can give feedback while the plate is typed. It doesn't check the plate is free.
+func (s *service) ValidateLicensePlate(ctx context.Context, req *genproto.ValidateLicensePlateRequest) (*genproto.FieldValidationResponse, error) {
+	normalized := validator.NormalizeLicensePlate(req.GetLicensePlate())
+	return fieldValidationResponse(normalized, validator.ValidateLicensePlate("license_plate", normalized)), nil
+}
+
+func fieldValidationResponse(normalized string, err error) *genproto.FieldValidationResponse {
+	resp := &genproto.FieldValidationResponse{Valid: err == nil, Normalized: normalized, Errors: []string{}}
+	if err != nil {
+		resp.Errors = append(resp.Errors, err.Error())
+	}
+	return resp
+}
+
 // Vehicle type management
 
 func (s *service) CreateVehicleType(ctx context.Context, req *genproto.CreateVehicleTypeRequest) (*genproto.CreateVehicleTypeResponse, error) {
@@ -457,15 +1018,18 @@ func (s *service) CreateVehicleType(ctx context.Context, req *genproto.CreateVeh
 
 	// Validate vehicle type name
 	if err := validator.ValidateVehicleTypeName("name", req.Name); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation failed: %v", err)
+		return nil, grpcerr.InvalidArgument("validation failed", err)
 	}
 
 	// Check if vehicle type already exists
 	existing, err := s.store.GetVehicleTypeByName(ctx, req.Name)
 	if err != nil && !errors.Is(err, types.ErrVehicleTypeNotFound) {
-		return nil, status.Errorf(codes.Internal, "failed to check vehicle type uniqueness: %v", err)
+		return nil, grpcerr.Internal("failed to check vehicle type uniqueness", err)
 	}
 	if existing != nil {
+		if req.EnsureExists {
+			return &genproto.CreateVehicleTypeResponse{VehicleType: existing}, nil
+		}
 		return nil, status.Errorf(codes.AlreadyExists, "vehicle type %s already exists", req.Name)
 	}
 
@@ -473,29 +1037,35 @@ func (s *service) CreateVehicleType(ctx context.Context, req *genproto.CreateVeh
 	vehicleType, err := s.store.CreateVehicleType(ctx, req.Name, req.Description)
 	if err != nil {
 		if errors.Is(err, types.ErrDuplicateEntry) {
+			if req.EnsureExists {
+				// Lost a race with a concurrent create; hand back the winner
+				existing, err := s.store.GetVehicleTypeByName(ctx, req.Name)
+				if err != nil {
+					return nil, grpcerr.Internal("failed to get existing vehicle type", err)
+				}
+				return &genproto.CreateVehicleTypeResponse{VehicleType: existing}, nil
+			}
 			return nil, status.Errorf(codes.AlreadyExists, "vehicle type %s already exists", req.Name)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to create vehicle type: %v", err)
+		return nil, grpcerr.Internal("failed to create vehicle type", err)
 	}
 
 	return &genproto.CreateVehicleTypeResponse{
 		VehicleType: vehicleType,
+		Created:     true,
 	}, nil
 }
 
 func (s *service) ListVehicleTypes(ctx context.Context, req *genproto.ListVehicleTypesRequest) (*genproto.ListVehicleTypesResponse, error) {
 	// Validate page size
-	pageSize := req.GetPageSize()
-	if pageSize <= 0 {
-		pageSize = 50
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
+	pageSize := pagesize.Clamp(ctx, req.GetPageSize())
 
 	vehicleTypes, nextPageToken, err := s.store.ListVehicleTypes(ctx, pageSize, req.GetPageToken())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list vehicle types: %v", err)
+		if errors.Is(err, pagetoken.ErrInvalidToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, grpcerr.Internal("failed to list vehicle types", err)
 	}
 
 	return &genproto.ListVehicleTypesResponse{
@@ -504,20 +1074,118 @@ func (s *service) ListVehicleTypes(ctx context.Context, req *genproto.ListVehicl
 	}, nil
 }
 
-// InitializeStandardVehicleTypes creates the standard vehicle types if they don't exist
+func (s *service) GetVehicleType(ctx context.Context, req *genproto.GetVehicleTypeRequest) (*genproto.GetVehicleTypeResponse, error) {
+	if err := validator.ValidateVehicleTypeID("id", req.Id); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	vehicleType, err := s.store.GetVehicleTypeByID(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleTypeNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle type not found: %s", req.Id)
+		}
+		return nil, grpcerr.Internal("failed to get vehicle type", err)
+	}
+
+	return &genproto.GetVehicleTypeResponse{VehicleType: vehicleType}, nil
+}
+
+func (s *service) GetVehicleTypeByName(ctx context.Context, req *genproto.GetVehicleTypeByNameRequest) (*genproto.GetVehicleTypeResponse, error) {
+	if err := validator.ValidateVehicleTypeName("name", req.Name); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	vehicleType, err := s.store.GetVehicleTypeByName(ctx, req.Name)
+	if err != nil {
+		if errors.Is(err, types.ErrVehicleTypeNotFound) {
+			return nil, status.Errorf(codes.NotFound, "vehicle type not found: %s", req.Name)
+		}
+		return nil, grpcerr.Internal("failed to get vehicle type", err)
+	}
+
+	return &genproto.GetVehicleTypeResponse{VehicleType: vehicleType}, nil
+}
+
+func (s *service) UpdateVehicleType(ctx context.Context, req *genproto.UpdateVehicleTypeRequest) (*genproto.UpdateVehicleTypeResponse, error) {
+	if err := validator.ValidateVehicleTypeID("id", req.Id); err != nil {
+		return nil, grpcerr.InvalidArgument("validation failed", err)
+	}
+	if req.Name == nil && req.Description == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one of name or description is required")
+	}
+	if req.Name != nil {
+		if err := validator.ValidateVehicleTypeName("name", req.GetName()); err != nil {
+			return nil, grpcerr.InvalidArgument("validation failed", err)
+		}
+	}
+
+	vehicleType, err := s.store.UpdateVehicleType(ctx, req.Id, req.Name, req.Description)
+	if err != nil {
+		switch {
+		case errors.Is(err, types.ErrVehicleTypeNotFound):
+			return nil, status.Errorf(codes.NotFound, "vehicle type not found: %s", req.Id)
+		case errors.Is(err, types.ErrDuplicateEntry):
+			return nil, status.Errorf(codes.AlreadyExists, "vehicle type %s already exists", req.GetName())
+		}
+		return nil, grpcerr.Internal("failed to update vehicle type", err)
+	}
+
+	return &genproto.UpdateVehicleTypeResponse{VehicleType: vehicleType}, nil
+}
+
+// DeleteVehicleType deletes a vehicle type. Types still referenced by a vehicle, even a
+// retired one, are refused with FailedPrecondition.
+func (s *service) DeleteVehicleType(ctx context.Context, req *genproto.DeleteVehicleTypeRequest) error {
+	if err := validator.ValidateVehicleTypeID("id", req.Id); err != nil {
+		return grpcerr.InvalidArgument("validation failed", err)
+	}
+
+	if err := s.store.DeleteVehicleType(ctx, req.Id); err != nil {
+		switch {
+		case errors.Is(err, types.ErrVehicleTypeNotFound):
+			return status.Errorf(codes.NotFound, "vehicle type not found: %s", req.Id)
+		case errors.Is(err, types.ErrVehicleTypeInUse):
+			return status.Errorf(codes.FailedPrecondition, "cannot delete vehicle type: %v", err)
+		}
+		return grpcerr.Internal("failed to delete vehicle type", err)
+	}
+
+	return nil
+}
+
+// InitializeStandardVehicleTypes creates the standard vehicle types if they don't exist.
+// Each insert is idempotent, so replicas starting at the same time can both run it.
 func (s *service) InitializeStandardVehicleTypes(ctx context.Context) error {
 	for _, stdType := range types.StandardVehicleTypes {
-		_, err := s.store.GetVehicleTypeByName(ctx, stdType.Name)
-		if errors.Is(err, types.ErrVehicleTypeNotFound) {
-			// Create the standard type
-			_, err := s.store.CreateVehicleType(ctx, stdType.Name, stdType.Description)
-			if err != nil && !errors.Is(err, types.ErrDuplicateEntry) {
-				return fmt.Errorf("failed to create standard vehicle type %s: %w", stdType.Name, err)
-			}
+		created, err := s.store.EnsureVehicleType(ctx, stdType.Name, stdType.Description)
+		if err != nil {
+			return fmt.Errorf("failed to ensure standard vehicle type %s: %w", stdType.Name, err)
+		}
+		if created {
 			log.Printf("Created standard vehicle type: %s", stdType.Name)
-		} else if err != nil {
-			return fmt.Errorf("failed to check vehicle type %s: %w", stdType.Name, err)
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// checkPlateTombstone rejects a plate whose vehicle was retired within the cooldown,
+// so it can't be moved straight onto another vehicle. An admin override lets it through.
+func (s *service) checkPlateTombstone(ctx context.Context, licensePlate string, adminOverride bool) error {
+	tombstone, err := s.store.GetActivePlateTombstone(ctx, licensePlate)
+	if err != nil {
+		if errors.Is(err, types.ErrTombstoneNotFound) {
+			return nil
+		}
+		return grpcerr.Internal("failed to check license plate tombstone", err)
+	}
+
+	if !adminOverride {
+		return status.Errorf(codes.FailedPrecondition,
+			"license plate %s belongs to a vehicle retired on %s and cannot be reused until %s without an admin override",
+			licensePlate, tombstone.RetiredAt.Format("2006-01-02"), tombstone.ExpiresAt.Format("2006-01-02"))
+	}
+
+	log.Printf("WARNING: admin override reusing license plate %s tombstoned until %s (retired vehicle %s)",
+		licensePlate, tombstone.ExpiresAt.Format(time.RFC3339), tombstone.VehicleID)
+	return nil
+}
@@ -0,0 +1,209 @@
+// services/vehicle/internal/service/service_test.go
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
+	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeStore implements the store methods a test needs. Calls to any other method panic
+// on the nil embedded store.
+type fakeStore struct {
+	types.VehicleStore
+	vehicle       *genproto.Vehicle        // returned by GetVehicleByID
+	statusUpdates []genproto.VehicleStatus // statuses written by UpdateVehicleStatus
+}
+
+func (f *fakeStore) GetVehicleByID(ctx context.Context, externalID uuid.UUID) (*genproto.Vehicle, error) {
+	if f.vehicle == nil || f.vehicle.Id != externalID.String() {
+		return nil, types.ErrVehicleNotFound
+	}
+	return f.vehicle, nil
+}
+
+func (f *fakeStore) UpdateVehicleStatus(ctx context.Context, externalID uuid.UUID, newStatus genproto.VehicleStatus, reason, actorID string) (*genproto.Vehicle, error) {
+	f.statusUpdates = append(f.statusUpdates, newStatus)
+	updated := proto.Clone(f.vehicle).(*genproto.Vehicle)
+	updated.Status = newStatus
+	return updated, nil
+}
+
+// ListVehicles decodes the page token the way the real store does, so a token issued
+// for another sort fails
+func (f *fakeStore) ListVehicles(ctx context.Context, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, string, int32, error) {
+	if _, err := pagetoken.Decode(params.PageToken, params.Sort); err != nil {
+		return nil, "", "", 0, err
+	}
+	return nil, "", "", 0, nil
+}
+
+func TestListVehiclesPageTokenSortMismatch(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	yearDesc := pagetoken.Sort{Column: "year", Descending: true}
+
+	tests := []struct {
+		name     string
+		req      *genproto.ListVehiclesRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "token from the default order",
+			req:      &genproto.ListVehiclesRequest{PageToken: pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: at})},
+			wantCode: codes.OK,
+		},
+		{
+			name: "token from the default order used with sort_by",
+			req: &genproto.ListVehiclesRequest{
+				PageToken: pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: at}),
+				SortBy:    "year",
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "token from sort_by used without it",
+			req: &genproto.ListVehiclesRequest{
+				PageToken: pagetoken.Encode(yearDesc, pagetoken.Cursor{At: at, Key: "2019"}),
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "token used with the other sort_order",
+			req: &genproto.ListVehiclesRequest{
+				PageToken: pagetoken.Encode(yearDesc, pagetoken.Cursor{At: at, Key: "2019"}),
+				SortBy:    "year",
+				SortOrder: "asc",
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "garbage token",
+			req:      &genproto.ListVehiclesRequest{PageToken: "garbage"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(&fakeStore{}, nil, nil, utils.DefaultSearchTermLimits)
+
+			_, err := s.ListVehicles(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %s, want %s: %v", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestUpdateVehicleStatusRecovery(t *testing.T) {
+	const vehicleID = "8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90"
+	// The store reads a status it has no name for as STATUS_UNSPECIFIED
+	unknown := genproto.VehicleStatus_STATUS_UNSPECIFIED
+
+	tests := []struct {
+		name          string
+		current       genproto.VehicleStatus
+		target        genproto.VehicleStatus
+		adminOverride bool
+		wantCode      codes.Code
+	}{
+		{name: "unknown status without override", current: unknown, target: genproto.VehicleStatus_ACTIVE, wantCode: codes.FailedPrecondition},
+		{name: "unknown status recovered to active", current: unknown, target: genproto.VehicleStatus_ACTIVE, adminOverride: true, wantCode: codes.OK},
+		{name: "unknown status recovered to maintenance", current: unknown, target: genproto.VehicleStatus_MAINTENANCE, adminOverride: true, wantCode: codes.OK},
+		{name: "unknown status recovered to assigned", current: unknown, target: genproto.VehicleStatus_ASSIGNED, adminOverride: true, wantCode: codes.InvalidArgument},
+		{name: "unknown status recovered to retired", current: unknown, target: genproto.VehicleStatus_RETIRED, adminOverride: true, wantCode: codes.InvalidArgument},
+		{name: "known status ignores override", current: genproto.VehicleStatus_RETIRED, target: genproto.VehicleStatus_ACTIVE, adminOverride: true, wantCode: codes.InvalidArgument},
+		{name: "known status valid transition", current: genproto.VehicleStatus_ACTIVE, target: genproto.VehicleStatus_MAINTENANCE, wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{vehicle: &genproto.Vehicle{Id: vehicleID, Status: tt.current}}
+			s := NewService(store, nil, nil, utils.DefaultSearchTermLimits)
+
+			resp, err := s.UpdateVehicleStatus(context.Background(), &genproto.UpdateVehicleStatusRequest{
+				VehicleId:     vehicleID,
+				Status:        tt.target,
+				AdminOverride: tt.adminOverride,
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("code = %s, want %s: %v", got, tt.wantCode, err)
+			}
+
+			if tt.wantCode != codes.OK {
+				if len(store.statusUpdates) != 0 {
+					t.Errorf("status was written despite the error: %v", store.statusUpdates)
+				}
+				return
+			}
+			if resp.Vehicle.Status != tt.target {
+				t.Errorf("status = %s, want %s", resp.Vehicle.Status, tt.target)
+			}
+			if len(store.statusUpdates) != 1 || store.statusUpdates[0] != tt.target {
+				t.Errorf("status writes = %v, want [%s]", store.statusUpdates, tt.target)
+			}
+		})
+	}
+}
+
+// typeStore keeps vehicle type names like the table's unique key does: the first insert
+// of a name wins and the rest change nothing
+type typeStore struct {
+	types.VehicleStore
+	mu      sync.Mutex
+	created map[string]int
+	calls   int
+}
+
+func (f *typeStore) EnsureVehicleType(ctx context.Context, name, description string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.created[name] > 0 {
+		return false, nil
+	}
+	f.created[name]++
+	return true, nil
+}
+
+func TestInitializeStandardVehicleTypesConcurrent(t *testing.T) {
+	const replicas = 4
+	store := &typeStore{created: make(map[string]int)}
+
+	errs := make(chan error, replicas)
+	var wg sync.WaitGroup
+	for range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := NewService(store, nil, nil, utils.DefaultSearchTermLimits)
+			errs <- s.InitializeStandardVehicleTypes(context.Background())
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("InitializeStandardVehicleTypes: %v", err)
+		}
+	}
+	if want := replicas * len(types.StandardVehicleTypes); store.calls != want {
+		t.Errorf("EnsureVehicleType called %d times, want %d", store.calls, want)
+	}
+	for _, stdType := range types.StandardVehicleTypes {
+		if store.created[stdType.Name] != 1 {
+			t.Errorf("%s created %d times, want once", stdType.Name, store.created[stdType.Name])
+		}
+	}
+}
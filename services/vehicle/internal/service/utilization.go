@@ -0,0 +1,106 @@
+// services/vehicle/internal/service/utilization.go
+package service
+
+import (
+	"time"
+
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
+	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// bucketStart aligns t to the start of its UTC day, or of its week (Monday) for weekly buckets
+func bucketStart(t time.Time, granularity genproto.UtilizationGranularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if granularity == genproto.UtilizationGranularity_GRANULARITY_WEEKLY {
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -daysSinceMonday)
+	}
+	return day
+}
+
+// fleetUtilization splits [from, to) into buckets and works out how long each vehicle
+// spent in each status within them.
+//
+// changes must hold every status change from `from` onwards, oldest first. A vehicle's
+// status at `from` is then the previous_status of its first change, or its current
+// status if it has not changed since; walking its changes forward gives the rest.
+func fleetUtilization(vehicles []types.FleetVehicle, changes []types.VehicleStatusChange, from, to time.Time, bucketSize time.Duration) []*genproto.UtilizationBucket {
+	bucketCount := int((to.Sub(from) + bucketSize - 1) / bucketSize)
+	totals := make([]map[genproto.VehicleStatus]time.Duration, bucketCount)
+	for i := range totals {
+		totals[i] = make(map[genproto.VehicleStatus]time.Duration)
+	}
+
+	changesByVehicle := make(map[string][]types.VehicleStatusChange)
+	for _, change := range changes {
+		changesByVehicle[change.VehicleID] = append(changesByVehicle[change.VehicleID], change)
+	}
+
+	for _, vehicle := range vehicles {
+		history := changesByVehicle[vehicle.ID]
+
+		current := vehicle.Status
+		if len(history) > 0 {
+			current = history[0].PreviousStatus
+		}
+
+		at := from
+		if vehicle.CreatedAt.After(at) {
+			at = vehicle.CreatedAt
+		}
+
+		for _, change := range history {
+			if !change.ChangedAt.Before(to) {
+				break
+			}
+			if change.ChangedAt.After(at) {
+				addStatusSpan(totals, from, bucketSize, at, change.ChangedAt, current)
+				at = change.ChangedAt
+			}
+			current = change.NewStatus
+		}
+		if to.After(at) {
+			addStatusSpan(totals, from, bucketSize, at, to, current)
+		}
+	}
+
+	buckets := make([]*genproto.UtilizationBucket, bucketCount)
+	for i, byStatus := range totals {
+		start := from.Add(time.Duration(i) * bucketSize)
+		end := start.Add(bucketSize)
+		if end.After(to) {
+			end = to
+		}
+		length := float64(end.Sub(start))
+
+		bucket := &genproto.UtilizationBucket{
+			Start:               timestamppb.New(start),
+			ActiveVehicles:      float64(byStatus[genproto.VehicleStatus_ACTIVE]) / length,
+			AssignedVehicles:    float64(byStatus[genproto.VehicleStatus_ASSIGNED]) / length,
+			MaintenanceVehicles: float64(byStatus[genproto.VehicleStatus_MAINTENANCE]) / length,
+		}
+		if operational := bucket.ActiveVehicles + bucket.AssignedVehicles; operational > 0 {
+			bucket.UtilizationPercent = bucket.AssignedVehicles / operational * 100
+		}
+		buckets[i] = bucket
+	}
+
+	return buckets
+}
+
+// addStatusSpan credits the time between start and end to status, split across the buckets it overlaps
+func addStatusSpan(totals []map[genproto.VehicleStatus]time.Duration, from time.Time, bucketSize time.Duration, start, end time.Time, status genproto.VehicleStatus) {
+	for start.Before(end) {
+		i := int(start.Sub(from) / bucketSize)
+		if i >= len(totals) {
+			return
+		}
+		spanEnd := from.Add(time.Duration(i+1) * bucketSize)
+		if end.Before(spanEnd) {
+			spanEnd = end
+		}
+		totals[i][status] += spanEnd.Sub(start)
+		start = spanEnd
+	}
+}
@@ -0,0 +1,112 @@
+// services/vehicle/internal/store/dialect.go
+package store
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Dialect isolates the SQL that differs between database engines.
+// Queries in this package use MySQL-style ? placeholders plus a small set of
+// markers that the dialect expands before the query is sent:
+//
+//	{{uuid_text <column>}}          the UUID column rendered as lowercase hex text
+//	{{add_days <date>, <n>}}        date arithmetic, n days after <date>
+//	{{on_conflict_update <keys>}}   an upsert updating the row that holds <keys>
+//	{{on_conflict_ignore <keys>}}   an upsert leaving the row that holds <keys> untouched
+//	{{inserted <column>}}           in upsert assignments, the value the insert proposed
+type Dialect interface {
+	// DriverName is the database/sql driver the dialect talks to
+	DriverName() string
+	// UUIDText returns an expression rendering a UUID column as lowercase hex
+	UUIDText(column string) string
+	// UUIDArg converts a UUID into the value bound against a UUID column
+	UUIDArg(id uuid.UUID) any
+	// AddDays returns an expression adding days to a date expression
+	AddDays(date, days string) string
+	// OnConflictUpdate returns the clause that turns a conflict on the unique key
+	// columns into an update of the existing row, ahead of its assignments
+	OnConflictUpdate(keys string) string
+	// OnConflictIgnore returns the clause that keeps the existing row on a conflict
+	// on the unique key columns, so the insert affects no rows
+	OnConflictIgnore(keys string) string
+	// Inserted returns an expression for the value the insert proposed for a column,
+	// for use in upsert assignments
+	Inserted(column string) string
+	// IsDuplicateEntry reports whether err is a unique constraint violation
+	IsDuplicateEntry(err error) bool
+	// Rebind rewrites ? placeholders into the engine's bind parameter style
+	Rebind(query string) string
+}
+
+// MySQL is the dialect the vehicle service runs on today
+var MySQL Dialect = mysqlDialect{}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) DriverName() string { return "mysql" }
+
+func (mysqlDialect) UUIDText(column string) string {
+	return fmt.Sprintf("LOWER(HEX(%s))", column)
+}
+
+// UUIDs are stored as BINARY(16)
+func (mysqlDialect) UUIDArg(id uuid.UUID) any { return id.Bytes() }
+
+func (mysqlDialect) AddDays(date, days string) string {
+	return fmt.Sprintf("DATE_ADD(%s, INTERVAL %s DAY)", date, days)
+}
+
+// MySQL finds the conflicting key itself, so the key columns only matter to
+// OnConflictIgnore, which needs a column for its no-op assignment
+func (mysqlDialect) OnConflictUpdate(keys string) string { return "ON DUPLICATE KEY UPDATE" }
+
+func (mysqlDialect) OnConflictIgnore(keys string) string {
+	column := strings.TrimSpace(strings.Split(keys, ",")[0])
+	return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s = %s", column, column)
+}
+
+func (mysqlDialect) Inserted(column string) string {
+	return fmt.Sprintf("VALUES(%s)", column)
+}
+
+func (mysqlDialect) IsDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
+
+func (mysqlDialect) Rebind(query string) string { return query }
+
+var (
+	uuidTextMarker         = regexp.MustCompile(`\{\{uuid_text ([^}]+)\}\}`)
+	addDaysMarker          = regexp.MustCompile(`\{\{add_days ([^,}]+),\s*([^}]+)\}\}`)
+	onConflictUpdateMarker = regexp.MustCompile(`\{\{on_conflict_update ([^}]+)\}\}`)
+	onConflictIgnoreMarker = regexp.MustCompile(`\{\{on_conflict_ignore ([^}]+)\}\}`)
+	insertedMarker         = regexp.MustCompile(`\{\{inserted ([^}]+)\}\}`)
+)
+
+// render expands the dialect markers in a query and rebinds its placeholders
+func render(d Dialect, query string) string {
+	query = uuidTextMarker.ReplaceAllStringFunc(query, func(m string) string {
+		return d.UUIDText(uuidTextMarker.FindStringSubmatch(m)[1])
+	})
+	query = addDaysMarker.ReplaceAllStringFunc(query, func(m string) string {
+		parts := addDaysMarker.FindStringSubmatch(m)
+		return d.AddDays(parts[1], parts[2])
+	})
+	query = onConflictUpdateMarker.ReplaceAllStringFunc(query, func(m string) string {
+		return d.OnConflictUpdate(onConflictUpdateMarker.FindStringSubmatch(m)[1])
+	})
+	query = onConflictIgnoreMarker.ReplaceAllStringFunc(query, func(m string) string {
+		return d.OnConflictIgnore(onConflictIgnoreMarker.FindStringSubmatch(m)[1])
+	})
+	query = insertedMarker.ReplaceAllStringFunc(query, func(m string) string {
+		return d.Inserted(insertedMarker.FindStringSubmatch(m)[1])
+	})
+	return d.Rebind(query)
+}
@@ -0,0 +1,88 @@
+// services/vehicle/internal/store/dialect_test.go
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+// postgresDialect renders the markers the way a PostgreSQL dialect would, to check the
+// queries carry everything a non-MySQL engine needs
+type postgresDialect struct{ mysqlDialect }
+
+func (postgresDialect) OnConflictUpdate(keys string) string {
+	return "ON CONFLICT (" + keys + ") DO UPDATE SET"
+}
+
+func (postgresDialect) OnConflictIgnore(keys string) string {
+	return "ON CONFLICT (" + keys + ") DO NOTHING"
+}
+
+func (postgresDialect) Inserted(column string) string { return "EXCLUDED." + column }
+
+func TestRenderUpserts(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    []string
+	}{
+		{
+			name:    "mysql ignore",
+			dialect: MySQL,
+			query:   ensureVehicleTypeQuery,
+			want:    []string{"ON DUPLICATE KEY UPDATE name = name"},
+		},
+		{
+			name:    "mysql update",
+			dialect: MySQL,
+			query:   claimIdempotencyKeyQuery,
+			want: []string{
+				"ON DUPLICATE KEY UPDATE\n",
+				"external_id = CASE WHEN expires_at <= VALUES(created_at) THEN VALUES(external_id) ELSE external_id END",
+				"expires_at = CASE WHEN expires_at <= VALUES(created_at) THEN VALUES(expires_at) ELSE expires_at END",
+			},
+		},
+		{
+			name:    "mysql tombstone",
+			dialect: MySQL,
+			query:   tombstonePlateQuery,
+			want:    []string{"ON DUPLICATE KEY UPDATE\n", "vehicle_id = VALUES(vehicle_id)", "expires_at = VALUES(expires_at)"},
+		},
+		{
+			name:    "postgres tombstone",
+			dialect: postgresDialect{},
+			query:   tombstonePlateQuery,
+			want:    []string{"ON CONFLICT (license_plate) DO UPDATE SET\n", "retired_at = EXCLUDED.retired_at"},
+		},
+		{
+			name:    "postgres ignore",
+			dialect: postgresDialect{},
+			query:   ensureVehicleTypeQuery,
+			want:    []string{"ON CONFLICT (name) DO NOTHING"},
+		},
+		{
+			name:    "postgres update",
+			dialect: postgresDialect{},
+			query:   claimIdempotencyKeyQuery,
+			want: []string{
+				"ON CONFLICT (scope, actor_id, idempotency_key) DO UPDATE SET\n",
+				"created_at = CASE WHEN expires_at <= EXCLUDED.created_at THEN EXCLUDED.created_at ELSE created_at END",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := render(tt.dialect, tt.query)
+			if strings.Contains(got, "{{") {
+				t.Errorf("unexpanded marker in:\n%s", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("query is missing %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
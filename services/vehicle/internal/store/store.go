@@ -4,11 +4,20 @@ package store
 import (
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/sqlupdate"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
 	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	"github.com/go-sql-driver/mysql"
@@ -18,7 +27,11 @@ import (
 )
 
 type store struct {
-	db *sql.DB
+	db            *sql.DB
+	dialect       Dialect
+	queries       sync.Map      // raw query -> query rendered for the dialect
+	plateCooldown time.Duration // how long a retired vehicle's plate is held back from reuse
+	queryTimeout  time.Duration // cap on each call's database work, see utils.WithQueryTimeout
 }
 
 // Returns a raw *sql.DB for use in migrations
@@ -26,15 +39,53 @@ func NewRawDB(cfg mysql.Config) (*sql.DB, error) {
 	return sql.Open("mysql", cfg.FormatDSN())
 }
 
-// NewStore creates a new vehicle store
-func NewStore(dsn string) (*store, error) {
+// NewStore creates a new vehicle store, waiting up to the retry budget for the database to come up
+func NewStore(dsn string, retry utils.DBRetry, pool utils.DBPool) (*store, error) {
 	// Ensure conversion of DATETIME columns to Go's time.Time and local time zone
 	dsn += "?parseTime=true&loc=Local"
-	db, err := sql.Open("mysql", dsn)
+	db, err := metrics.OpenDB(MySQL.DriverName(), dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
-	return &store{db: db}, nil
+	pool.Apply(db)
+	if err := utils.WaitForDB(context.Background(), db, retry); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return NewStoreWithDialect(db, MySQL), nil
+}
+
+// NewStoreWithDialect creates a vehicle store over an already opened database
+// using the given dialect for engine specific SQL
+func NewStoreWithDialect(db *sql.DB, dialect Dialect) *store {
+	return &store{db: db, dialect: dialect, plateCooldown: types.DefaultPlateCooldown, queryTimeout: utils.DefaultDBQueryTimeout}
+}
+
+// SetPlateCooldown sets how long a retired vehicle's license plate is tombstoned.
+// A cooldown of 0 stops new tombstones from being recorded.
+func (s *store) SetPlateCooldown(cooldown time.Duration) {
+	s.plateCooldown = cooldown
+}
+
+// SetQueryTimeout sets how long one store call may spend in the database. 0 leaves calls
+// bounded only by the caller's deadline.
+func (s *store) SetQueryTimeout(timeout time.Duration) {
+	s.queryTimeout = timeout
+}
+
+// Close closes the store's database connections, once the server has stopped using them
+func (s *store) Close() error {
+	return s.db.Close()
+}
+
+// sql returns the query rendered for the store's dialect, caching the result
+func (s *store) sql(query string) string {
+	if rendered, ok := s.queries.Load(query); ok {
+		return rendered.(string)
+	}
+	rendered := render(s.dialect, query)
+	s.queries.Store(query, rendered)
+	return rendered
 }
 
 // Vehicle Type operations
@@ -44,12 +95,14 @@ INSERT INTO vehicle_types (name, description, created_at)
 VALUES (?, ?, ?)`
 
 func (s *store) CreateVehicleType(ctx context.Context, name, description string) (*genproto.VehicleType, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	now := time.Now()
 	
-	result, err := s.db.ExecContext(ctx, createVehicleTypeQuery, name, description, now)
+	result, err := s.db.ExecContext(ctx, s.sql(createVehicleTypeQuery), name, description, now)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		if s.dialect.IsDuplicateEntry(err) {
 			return nil, types.ErrDuplicateEntry
 		}
 		return nil, fmt.Errorf("failed to create vehicle type: %w", err)
@@ -68,16 +121,45 @@ func (s *store) CreateVehicleType(ctx context.Context, name, description string)
 	}, nil
 }
 
+// Ignoring the conflict turns a duplicate name into a success that affects no rows,
+// rather than an error, so concurrent callers never race on check-then-insert
+const ensureVehicleTypeQuery = `
+INSERT INTO vehicle_types (name, description, created_at) 
+VALUES (?, ?, ?)
+{{on_conflict_ignore name}}`
+
+// EnsureVehicleType creates the vehicle type unless one with the same name exists,
+// reporting whether it was created
+func (s *store) EnsureVehicleType(ctx context.Context, name, description string) (bool, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, s.sql(ensureVehicleTypeQuery), name, description, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to ensure vehicle type: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to check affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 const getVehicleTypeByIDQuery = `
 SELECT id, name, description, created_at 
 FROM vehicle_types 
 WHERE id = ?`
 
 func (s *store) GetVehicleTypeByID(ctx context.Context, typeID string) (*genproto.VehicleType, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	var vehicleType genproto.VehicleType
 	var createdAt time.Time
 	
-	err := s.db.QueryRowContext(ctx, getVehicleTypeByIDQuery, typeID).Scan(
+	err := s.db.QueryRowContext(ctx, s.sql(getVehicleTypeByIDQuery), typeID).Scan(
 		&vehicleType.Id,
 		&vehicleType.Name,
 		&vehicleType.Description,
@@ -100,10 +182,13 @@ FROM vehicle_types
 WHERE name = ?`
 
 func (s *store) GetVehicleTypeByName(ctx context.Context, name string) (*genproto.VehicleType, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	var vehicleType genproto.VehicleType
 	var createdAt time.Time
 	
-	err := s.db.QueryRowContext(ctx, getVehicleTypeByNameQuery, name).Scan(
+	err := s.db.QueryRowContext(ctx, s.sql(getVehicleTypeByNameQuery), name).Scan(
 		&vehicleType.Id,
 		&vehicleType.Name,
 		&vehicleType.Description,
@@ -123,34 +208,29 @@ func (s *store) GetVehicleTypeByName(ctx context.Context, name string) (*genprot
 const listVehicleTypesQuery = `
 SELECT id, name, description, created_at 
 FROM vehicle_types 
-WHERE (?='' OR created_at > ?)
-ORDER BY created_at DESC 
+WHERE (?='' OR (created_at <= ? AND (created_at < ? OR id < ?)))
+ORDER BY created_at DESC, id DESC 
 LIMIT ?`
 
 func (s *store) ListVehicleTypes(ctx context.Context, pageSize int32, pageToken string) ([]*genproto.VehicleType, string, error) {
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 50
-	}
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	pageSize = pagesize.ClampTo(pageSize, pagesize.Default(), pagesize.InternalMax())
 
 	// Parse page token to get cursor timestamp
-	var cursorTime time.Time
-	if pageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(pageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(pageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", err
 	}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	cursorStr, cursorID, err := numericCursorArgs(cursor)
+	if err != nil {
+		return nil, "", err
 	}
 
-	rows, err := s.db.QueryContext(ctx, listVehicleTypesQuery,
-		cursorStr, cursorStr,
+	rows, err := s.db.QueryContext(ctx, s.sql(listVehicleTypesQuery),
+		cursorStr, cursorStr, cursorStr, cursorID,
 		pageSize+1, // Fetch one extra to determine if there are more pages
 	)
 	if err != nil {
@@ -159,8 +239,6 @@ func (s *store) ListVehicleTypes(ctx context.Context, pageSize int32, pageToken
 	defer rows.Close()
 
 	var types []*genproto.VehicleType
-	var lastCreatedAt time.Time
-
 	for rows.Next() {
 		var vehicleType genproto.VehicleType
 		var createdAt time.Time
@@ -177,23 +255,97 @@ func (s *store) ListVehicleTypes(ctx context.Context, pageSize int32, pageToken
 
 		vehicleType.CreatedAt = timestamppb.New(createdAt)
 		types = append(types, &vehicleType)
-		lastCreatedAt = createdAt
 	}
 
 	// Determine next page token
 	var nextPageToken string
 	if int32(len(types)) > pageSize {
 		types = types[:pageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
-		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
+		last := types[len(types)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
 	}
 
 	return types, nextPageToken, nil
 }
 
+const updateVehicleTypeQuery = `
+UPDATE vehicle_types
+SET name = COALESCE(?, name), description = COALESCE(?, description)
+WHERE id = ?`
+
+// UpdateVehicleType sets the name and description of a vehicle type, leaving nil ones as they are
+func (s *store) UpdateVehicleType(ctx context.Context, typeID string, name, description *string) (*genproto.VehicleType, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, s.sql(updateVehicleTypeQuery), name, description, typeID); err != nil {
+		if s.dialect.IsDuplicateEntry(err) {
+			return nil, types.ErrDuplicateEntry
+		}
+		return nil, fmt.Errorf("failed to update vehicle type: %w", err)
+	}
+
+	// MySQL reports unchanged rows as unaffected, so the read is what tells a
+	// missing type from an update that changed nothing
+	return s.GetVehicleTypeByID(ctx, typeID)
+}
+
+const lockVehicleTypeQuery = `
+SELECT id FROM vehicle_types
+WHERE id = ?
+FOR UPDATE`
+
+const countVehiclesOfTypeQuery = `
+SELECT COUNT(*) FROM vehicles
+WHERE vehicle_type_id = ?`
+
+const deleteVehicleTypeQuery = `
+DELETE FROM vehicle_types
+WHERE id = ?`
+
+// DeleteVehicleType deletes a vehicle type no vehicle refers to, retired ones included.
+// The type's row stays locked from the count to the delete, so a vehicle created in
+// between waits on its foreign key check and then fails rather than being orphaned.
+func (s *store) DeleteVehicleType(ctx context.Context, typeID string) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, s.sql(lockVehicleTypeQuery), typeID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.ErrVehicleTypeNotFound
+		}
+		return fmt.Errorf("failed to lock vehicle type: %w", err)
+	}
+
+	var vehicles int
+	if err := tx.QueryRowContext(ctx, s.sql(countVehiclesOfTypeQuery), id).Scan(&vehicles); err != nil {
+		return fmt.Errorf("failed to count vehicles of type: %w", err)
+	}
+	if vehicles > 0 {
+		return fmt.Errorf("%w: %d vehicles", types.ErrVehicleTypeInUse, vehicles)
+	}
+
+	if _, err := tx.ExecContext(ctx, s.sql(deleteVehicleTypeQuery), id); err != nil {
+		return fmt.Errorf("failed to delete vehicle type: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // Vehicle operations
 
 const createVehicleQuery = `
@@ -204,6 +356,9 @@ INSERT INTO vehicles (
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 func (s *store) CreateVehicle(ctx context.Context, internalID uint64, externalID uuid.UUID, vehicle *types.VehicleData) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -237,9 +392,9 @@ func (s *store) CreateVehicle(ctx context.Context, internalID uint64, externalID
 		}
 	}
 
-	_, err = tx.ExecContext(ctx, createVehicleQuery,
+	_, err = tx.ExecContext(ctx, s.sql(createVehicleQuery),
 		internalID,
-		externalID.Bytes(),
+		s.dialect.UUIDArg(externalID),
 		vehicle.VehicleTypeID,
 		vehicle.LicensePlate,
 		vehicle.Make,
@@ -257,8 +412,7 @@ func (s *store) CreateVehicle(ctx context.Context, internalID uint64, externalID
 		now,
 	)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		if s.dialect.IsDuplicateEntry(err) {
 			return types.ErrDuplicateEntry
 		}
 		return fmt.Errorf("failed to insert vehicle: %w", err)
@@ -273,7 +427,7 @@ func (s *store) CreateVehicle(ctx context.Context, internalID uint64, externalID
 
 const getVehicleByIDQuery = `
 SELECT 
-	LOWER(HEX(v.external_id)) as external_id,
+	{{uuid_text v.external_id}} as external_id,
 	v.vehicle_type_id,
 	vt.name as vehicle_type_name,
 	v.license_plate,
@@ -289,14 +443,21 @@ SELECT
 	v.insurance_expiry,
 	v.status,
 	v.created_at,
-	v.updated_at
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
 FROM vehicles v
 INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
 WHERE v.external_id = ?
 LIMIT 1`
 
 func (s *store) GetVehicleByID(ctx context.Context, externalID uuid.UUID) (*genproto.Vehicle, error) {
-	vehicle, err := s.scanVehicle(ctx, getVehicleByIDQuery, externalID.Bytes())
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	vehicle, err := s.scanVehicle(ctx, getVehicleByIDQuery, s.dialect.UUIDArg(externalID))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, types.ErrVehicleNotFound
@@ -306,9 +467,76 @@ func (s *store) GetVehicleByID(ctx context.Context, externalID uuid.UUID) (*genp
 	return vehicle, nil
 }
 
+const getVehiclesByIDsQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.external_id IN (%s)`
+
+// GetVehiclesByIDs fetches the given vehicles in a single query, keyed by vehicle ID
+// (the lowercase hex form in Vehicle.Id). Unknown IDs are absent from the map.
+func (s *store) GetVehiclesByIDs(ctx context.Context, externalIDs []uuid.UUID) (map[string]*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	vehicles := make(map[string]*genproto.Vehicle, len(externalIDs))
+	if len(externalIDs) == 0 {
+		return vehicles, nil
+	}
+
+	placeholders := make([]string, len(externalIDs))
+	args := make([]interface{}, len(externalIDs))
+	for i, id := range externalIDs {
+		placeholders[i] = "?"
+		args[i] = s.dialect.UUIDArg(id)
+	}
+
+	query := fmt.Sprintf(getVehiclesByIDsQuery, strings.Join(placeholders, ", "))
+	rows, err := s.db.QueryContext(ctx, s.sql(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		vehicle, err := s.scanVehicleFromRows(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		vehicles[vehicle.Id] = vehicle
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating vehicles: %w", err)
+	}
+
+	return vehicles, nil
+}
+
 const getVehicleByLicensePlateQuery = `
 SELECT 
-	LOWER(HEX(v.external_id)) as external_id,
+	{{uuid_text v.external_id}} as external_id,
 	v.vehicle_type_id,
 	vt.name as vehicle_type_name,
 	v.license_plate,
@@ -324,13 +552,23 @@ SELECT
 	v.insurance_expiry,
 	v.status,
 	v.created_at,
-	v.updated_at
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
 FROM vehicles v
 INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
 WHERE v.license_plate = ?
+ORDER BY v.status = 'RETIRED', v.created_at DESC
 LIMIT 1`
 
+// GetVehicleByLicensePlate returns the vehicle in service with the plate, or when
+// there is none, the most recently created retired vehicle that held it
 func (s *store) GetVehicleByLicensePlate(ctx context.Context, licensePlate string) (*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
 	vehicle, err := s.scanVehicle(ctx, getVehicleByLicensePlateQuery, licensePlate)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -341,9 +579,123 @@ func (s *store) GetVehicleByLicensePlate(ctx context.Context, licensePlate strin
 	return vehicle, nil
 }
 
+const getVehicleByChassisNumberQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.chassis_number = ?`
+
+// GetVehicleByChassisNumber returns the vehicle with the chassis number, retired or not.
+// The number must already be normalized.
+func (s *store) GetVehicleByChassisNumber(ctx context.Context, chassisNumber string) (*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	vehicle, err := s.scanVehicle(ctx, getVehicleByChassisNumberQuery, chassisNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrVehicleNotFound
+		}
+		return nil, fmt.Errorf("failed to get vehicle by chassis number: %w", err)
+	}
+	return vehicle, nil
+}
+
 const listVehiclesQuery = `
 SELECT 
-	LOWER(HEX(v.external_id)) as external_id,
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE (?='' OR v.status = ?)
+  AND (?='' OR v.vehicle_type_id = ?)
+  AND (?='' OR v.make LIKE ?)
+  AND (?='' OR v.make LIKE ? OR v.model LIKE ? OR v.license_plate LIKE ?)
+  AND (?='' OR (v.created_at <= ? AND (v.created_at < ? OR v.external_id < ?)))
+ORDER BY v.created_at DESC, v.external_id DESC
+LIMIT ?`
+
+// listVehiclesBackwardQuery reads the page before a backward cursor, nearest row first
+const listVehiclesBackwardQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE (?='' OR v.status = ?)
+  AND (?='' OR v.vehicle_type_id = ?)
+  AND (?='' OR v.make LIKE ?)
+  AND (?='' OR v.make LIKE ? OR v.model LIKE ? OR v.license_plate LIKE ?)
+  AND (?='' OR (v.created_at >= ? AND (v.created_at > ? OR v.external_id > ?)))
+ORDER BY v.created_at ASC, v.external_id ASC
+LIMIT ?`
+
+// listVehiclesSortedQuery is listVehiclesQuery for the orders picked with sort_by, keyed on
+// (column, created_at, external_id). %[1]s is the sort column, %[2]s the keyset comparison
+// and %[3]s the direction rows are read in; all three come from vehicleSortColumns and
+// vehicleSortQuery, never from the request.
+const listVehiclesSortedQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
 	v.vehicle_type_id,
 	vt.name as vehicle_type_name,
 	v.license_plate,
@@ -359,31 +711,102 @@ SELECT
 	v.insurance_expiry,
 	v.status,
 	v.created_at,
-	v.updated_at
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
 FROM vehicles v
 INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
 WHERE (?='' OR v.status = ?)
   AND (?='' OR v.vehicle_type_id = ?)
   AND (?='' OR v.make LIKE ?)
-  AND (?='' OR v.created_at > ?)
-ORDER BY v.created_at DESC
+  AND (?='' OR v.make LIKE ? OR v.model LIKE ? OR v.license_plate LIKE ?)
+  AND (?='' OR %[1]s %[2]s ? OR (%[1]s = ? AND (v.created_at %[2]s ? OR (v.created_at = ? AND v.external_id %[2]s ?))))
+ORDER BY %[1]s %[3]s, v.created_at %[3]s, v.external_id %[3]s
 LIMIT ?`
 
-func (s *store) ListVehicles(ctx context.Context, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
+// vehicleSortColumn is how ListVehicles sorts on one of validator.SortableVehicleColumns
+type vehicleSortColumn struct {
+	expr    string                         // the column as it appears in listVehiclesSortedQuery
+	numeric bool                           // the cursor key is bound as an integer
+	key     func(*genproto.Vehicle) string // the row's value, as carried in the page token
+}
+
+var vehicleSortColumns = map[string]vehicleSortColumn{
+	"created_at": {
+		expr: "v.created_at",
+		key:  func(v *genproto.Vehicle) string { return v.CreatedAt.AsTime().Format(time.RFC3339Nano) },
+	},
+	"year": {
+		expr:    "v.year",
+		numeric: true,
+		key:     func(v *genproto.Vehicle) string { return strconv.Itoa(int(v.Year)) },
+	},
+	"make": {
+		expr: "v.make",
+		key:  func(v *genproto.Vehicle) string { return v.Make },
+	},
+	"seating_capacity": {
+		expr:    "v.seating_capacity",
+		numeric: true,
+		key:     func(v *genproto.Vehicle) string { return strconv.Itoa(int(v.SeatingCapacity)) },
+	},
+}
+
+// vehicleSortQuery fills in listVehiclesSortedQuery for a sort and paging direction.
+// A backward cursor reads the other way, nearest row first, like listVehiclesBackwardQuery.
+func vehicleSortQuery(column vehicleSortColumn, sort pagetoken.Sort, backward bool) string {
+	comparison, direction := ">", "ASC"
+	if sort.Descending != backward {
+		comparison, direction = "<", "DESC"
+	}
+	return fmt.Sprintf(listVehiclesSortedQuery, column.expr, comparison, direction)
+}
+
+// sortKeyArg converts the cursor's sort key into the value bound against the sort column
+func (c vehicleSortColumn) sortKeyArg(cursor pagetoken.Cursor) (any, error) {
+	if !c.numeric {
+		return cursor.Key, nil
+	}
+	if cursor.IsZero() {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(cursor.Key, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", pagetoken.ErrInvalidToken, err)
+	}
+	return n, nil
+}
+
+const countVehiclesQuery = `
+SELECT COUNT(*)
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE (?='' OR v.status = ?)
+  AND (?='' OR v.vehicle_type_id = ?)
+  AND (?='' OR v.make LIKE ?)
+  AND (?='' OR v.make LIKE ? OR v.model LIKE ? OR v.license_plate LIKE ?)`
+
+func (s *store) ListVehicles(ctx context.Context, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	sort := params.Sort
+	if sort == (pagetoken.Sort{}) {
+		sort = pagetoken.CreatedAtDesc
+	}
+	sortColumn, ok := vehicleSortColumns[sort.Column]
+	if !ok {
+		return nil, "", "", 0, fmt.Errorf("unsupported vehicle sort %s", sort)
 	}
 
 	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
-		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
-		}
+	cursor, err := pagetoken.Decode(params.PageToken, sort)
+	if err != nil {
+		return nil, "", "", 0, err
 	}
 
 	// Prepare filter parameters
@@ -399,70 +822,93 @@ func (s *store) ListVehicles(ctx context.Context, params types.ListVehiclesParam
 
 	makePattern := ""
 	if params.MakeFilter != nil {
-		makePattern = "%" + *params.MakeFilter + "%"
+		makePattern = makeLikePattern(*params.MakeFilter, params.MakeMatch)
+	}
+
+	searchPattern, platePattern := "", ""
+	if params.SearchFilter != nil {
+		searchPattern = "%" + likeEscaper.Replace(*params.SearchFilter) + "%"
+		// Plates are stored normalized, upper case
+		platePattern = "%" + likeEscaper.Replace(strings.ToUpper(*params.SearchFilter)) + "%"
+	}
+
+	cursorStr, cursorID, err := s.uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", "", 0, err
 	}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", "", 0, err
 	}
+	defer endSnapshot(tx)
 
-	rows, err := s.db.QueryContext(ctx, listVehiclesQuery,
+	var total int32
+	if err := tx.QueryRowContext(ctx, s.sql(countVehiclesQuery),
 		statusStr, statusStr,
 		vehicleTypeStr, vehicleTypeStr,
 		makePattern, makePattern,
-		cursorStr, cursorStr,
-		params.PageSize+1,
-	)
+		searchPattern, searchPattern, searchPattern, platePattern,
+	).Scan(&total); err != nil {
+		return nil, "", "", 0, fmt.Errorf("failed to count vehicles: %w", err)
+	}
+
+	args := []any{
+		statusStr, statusStr,
+		vehicleTypeStr, vehicleTypeStr,
+		makePattern, makePattern,
+		searchPattern, searchPattern, searchPattern, platePattern,
+	}
+
+	var query string
+	switch {
+	case sort == pagetoken.CreatedAtDesc && cursor.Backward:
+		query = listVehiclesBackwardQuery
+		args = append(args, cursorStr, cursorStr, cursorStr, cursorID)
+	case sort == pagetoken.CreatedAtDesc:
+		query = listVehiclesQuery
+		args = append(args, cursorStr, cursorStr, cursorStr, cursorID)
+	default:
+		keyArg, err := sortColumn.sortKeyArg(cursor)
+		if err != nil {
+			return nil, "", "", 0, err
+		}
+		query = vehicleSortQuery(sortColumn, sort, cursor.Backward)
+		args = append(args, cursorStr, keyArg, keyArg, cursorStr, cursorStr, cursorID)
+	}
+	args = append(args, params.PageSize+1)
+
+	rows, err := tx.QueryContext(ctx, s.sql(query), args...)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to list vehicles: %w", err)
+		return nil, "", "", 0, fmt.Errorf("failed to list vehicles: %w", err)
 	}
 	defer rows.Close()
 
 	var vehicles []*genproto.Vehicle
-	var lastCreatedAt time.Time
-
 	for rows.Next() {
 		vehicle, err := s.scanVehicleFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan vehicle: %w", err)
+			return nil, "", "", 0, fmt.Errorf("failed to scan vehicle: %w", err)
 		}
 		vehicles = append(vehicles, vehicle)
-		lastCreatedAt = vehicle.CreatedAt.AsTime()
 	}
 
-	// Determine next page token
-	var nextPageToken string
-	if int32(len(vehicles)) > params.PageSize {
-		vehicles = vehicles[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
-		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
-	}
+	// Trim the extra vehicle we fetched and build the tokens either side
+	vehicles, nextPageToken, prevPageToken := pagetoken.Paginate(vehicles, params.PageSize, sort, cursor,
+		func(v *genproto.Vehicle) pagetoken.Cursor {
+			if sort == pagetoken.CreatedAtDesc {
+				return pagetoken.Cursor{At: v.CreatedAt.AsTime(), ID: v.Id}
+			}
+			return pagetoken.Cursor{At: v.CreatedAt.AsTime(), ID: v.Id, Key: sortColumn.key(v)}
+		})
 
-	return vehicles, nextPageToken, nil
+	return vehicles, nextPageToken, prevPageToken, total, nil
 }
 
-const updateVehicleQuery = `
-UPDATE vehicles 
-SET vehicle_type_id = CASE WHEN ? THEN ? ELSE vehicle_type_id END,
-    license_plate = CASE WHEN ? THEN ? ELSE license_plate END,
-    make = CASE WHEN ? THEN ? ELSE make END,
-    model = CASE WHEN ? THEN ? ELSE model END,
-    year = CASE WHEN ? THEN ? ELSE year END,
-    color = CASE WHEN ? THEN ? ELSE color END,
-    seating_capacity = CASE WHEN ? THEN ? ELSE seating_capacity END,
-    fuel_type = CASE WHEN ? THEN ? ELSE fuel_type END,
-    engine_number = CASE WHEN ? THEN ? ELSE engine_number END,
-    chassis_number = CASE WHEN ? THEN ? ELSE chassis_number END,
-    registration_date = CASE WHEN ? THEN ? ELSE registration_date END,
-    insurance_expiry = CASE WHEN ? THEN ? ELSE insurance_expiry END,
-    updated_at = ?
-WHERE external_id = ?`
+func (s *store) UpdateVehicle(ctx context.Context, externalID uuid.UUID, updates types.VehicleUpdateFields, updateMask *fieldmaskpb.FieldMask, actorID string) (*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
 
-func (s *store) UpdateVehicle(ctx context.Context, externalID uuid.UUID, updates types.VehicleUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.Vehicle, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -581,26 +1027,27 @@ func (s *store) UpdateVehicle(ctx context.Context, externalID uuid.UUID, updates
 		}
 	}
 
+	var set sqlupdate.Set
+	set.Field("vehicle_type_id", updateVehicleTypeID, vehicleTypeID).
+		Field("license_plate", updateLicensePlate, licensePlate).
+		Field("make", updateMake, make).
+		Field("model", updateModel, model).
+		Field("year", updateYear, year).
+		Field("color", updateColor, color).
+		Field("seating_capacity", updateSeatingCapacity, seatingCapacity).
+		Field("fuel_type", updateFuelType, fuelType).
+		Field("engine_number", updateEngineNumber, engineNumber).
+		Field("chassis_number", updateChassisNumber, chassisNumber).
+		Field("registration_date", updateRegistrationDate, registrationDate).
+		Field("insurance_expiry", updateInsuranceExpiry, insuranceExpiry).
+		Value("updated_at", now).
+		Value("updated_by", actorID)
+	query, args := set.Statement("vehicles", "external_id = ?", s.dialect.UUIDArg(externalID))
+
 	// Execute update
-	result, err := tx.ExecContext(ctx, updateVehicleQuery,
-		updateVehicleTypeID, vehicleTypeID,
-		updateLicensePlate, licensePlate,
-		updateMake, make,
-		updateModel, model,
-		updateYear, year,
-		updateColor, color,
-		updateSeatingCapacity, seatingCapacity,
-		updateFuelType, fuelType,
-		updateEngineNumber, engineNumber,
-		updateChassisNumber, chassisNumber,
-		updateRegistrationDate, registrationDate,
-		updateInsuranceExpiry, insuranceExpiry,
-		now,
-		externalID.Bytes(),
-	)
+	result, err := tx.ExecContext(ctx, s.sql(query), args...)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		if s.dialect.IsDuplicateEntry(err) {
 			return nil, types.ErrDuplicateEntry
 		}
 		return nil, fmt.Errorf("failed to update vehicle: %w", err)
@@ -622,67 +1069,857 @@ func (s *store) UpdateVehicle(ctx context.Context, externalID uuid.UUID, updates
 	return s.GetVehicleByID(ctx, externalID)
 }
 
+const lockVehicleStatusQuery = `
+SELECT status FROM vehicles
+WHERE external_id = ?
+FOR UPDATE`
+
 const updateVehicleStatusQuery = `
 UPDATE vehicles 
-SET status = ?, updated_at = ?
+SET status = ?, updated_at = ?, updated_by = ?
 WHERE external_id = ?`
 
-func (s *store) UpdateVehicleStatus(ctx context.Context, externalID uuid.UUID, status genproto.VehicleStatus) (*genproto.Vehicle, error) {
-	result, err := s.db.ExecContext(ctx, updateVehicleStatusQuery,
-		status.String(),
-		time.Now(),
-		externalID.Bytes(),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update vehicle status: %w", err)
-	}
+const insertVehicleStatusHistoryQuery = `
+INSERT INTO vehicle_status_history (vehicle_id, previous_status, new_status, reason, changed_by, changed_at)
+VALUES (?, ?, ?, ?, ?, ?)`
 
-	rowsAffected, err := result.RowsAffected()
+// UpdateVehicleStatus sets the status and records the transition in vehicle_status_history
+// in the same transaction, so the history never disagrees with the vehicle
+func (s *store) UpdateVehicleStatus(ctx context.Context, externalID uuid.UUID, status genproto.VehicleStatus, reason, actorID string) (*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	previousStatus, err := s.lockVehicleStatus(ctx, tx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, s.sql(updateVehicleStatusQuery),
+		status.String(),
+		now,
+		actorID,
+		s.dialect.UUIDArg(externalID),
+	); err != nil {
+		return nil, fmt.Errorf("failed to update vehicle status: %w", err)
+	}
+
+	if err := s.insertStatusHistory(ctx, tx, externalID, previousStatus, status.String(), reason, actorID, now); err != nil {
+		return nil, err
+	}
+
+	if status == genproto.VehicleStatus_RETIRED {
+		if err := s.tombstonePlate(ctx, tx, externalID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetVehicleByID(ctx, externalID)
+}
+
+const lockVehicleMileageQuery = `
+SELECT mileage_km FROM vehicles
+WHERE external_id = ?
+FOR UPDATE`
+
+const updateVehicleMileageQuery = `
+UPDATE vehicles 
+SET mileage_km = ?, updated_at = ?, updated_by = ?
+WHERE external_id = ?`
+
+const insertVehicleMileageReadingQuery = `
+INSERT INTO vehicle_mileage_readings (vehicle_id, mileage_km, recorded_by, recorded_at)
+VALUES (?, ?, ?, ?)`
+
+// RecordVehicleMileage sets the vehicle's mileage and keeps the reading in
+// vehicle_mileage_readings. The current mileage is checked under the row lock, so a
+// reading lower than it fails with ErrMileageDecreased even when readings race.
+func (s *store) RecordVehicleMileage(ctx context.Context, externalID uuid.UUID, mileageKm int32, actorID string) (*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	var current sql.NullInt32
+	err = tx.QueryRowContext(ctx, s.sql(lockVehicleMileageQuery), s.dialect.UUIDArg(externalID)).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrVehicleNotFound
+		}
+		return nil, fmt.Errorf("failed to read vehicle mileage: %w", err)
+	}
+	if current.Valid && mileageKm < current.Int32 {
+		return nil, types.ErrMileageDecreased
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, s.sql(updateVehicleMileageQuery),
+		mileageKm,
+		now,
+		actorID,
+		s.dialect.UUIDArg(externalID),
+	); err != nil {
+		return nil, fmt.Errorf("failed to update vehicle mileage: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, s.sql(insertVehicleMileageReadingQuery),
+		s.dialect.UUIDArg(externalID),
+		mileageKm,
+		actorID,
+		now,
+	); err != nil {
+		return nil, fmt.Errorf("failed to record mileage reading: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetVehicleByID(ctx, externalID)
+}
+
+const lockVehicleServiceQuery = `
+SELECT mileage_km, last_service_km, last_service_at FROM vehicles
+WHERE external_id = ?
+FOR UPDATE`
+
+const updateVehicleLastServiceQuery = `
+UPDATE vehicles 
+SET last_service_km = ?, last_service_at = ?, updated_at = ?, updated_by = ?
+WHERE external_id = ?`
+
+const insertVehicleServiceRecordQuery = `
+INSERT INTO vehicle_service_records (vehicle_id, mileage_km, serviced_at, notes, recorded_by, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?)`
+
+// RecordService keeps the service in vehicle_service_records and makes it the vehicle's
+// last service. Services must be recorded in order: one older than the last service fails
+// with ErrServiceOutOfOrder, and one at a lower reading with ErrServiceKmDecreased. A
+// reading ahead of the vehicle's mileage is recorded as a mileage reading too.
+func (s *store) RecordService(ctx context.Context, externalID uuid.UUID, record types.ServiceRecord, actorID string) (*genproto.Vehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	var mileageKm, lastServiceKm sql.NullInt32
+	var lastServiceAt sql.NullTime
+	err = tx.QueryRowContext(ctx, s.sql(lockVehicleServiceQuery), s.dialect.UUIDArg(externalID)).Scan(
+		&mileageKm,
+		&lastServiceKm,
+		&lastServiceAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrVehicleNotFound
+		}
+		return nil, fmt.Errorf("failed to read vehicle service: %w", err)
+	}
+
+	serviceKm := mileageKm
+	if record.MileageKm != nil {
+		serviceKm = sql.NullInt32{Int32: *record.MileageKm, Valid: true}
+	}
+	if lastServiceAt.Valid && record.ServicedAt.Before(lastServiceAt.Time) {
+		return nil, types.ErrServiceOutOfOrder
+	}
+	if serviceKm.Valid && lastServiceKm.Valid && serviceKm.Int32 < lastServiceKm.Int32 {
+		return nil, types.ErrServiceKmDecreased
+	}
+
+	now := time.Now()
+	if serviceKm.Valid && (!mileageKm.Valid || serviceKm.Int32 > mileageKm.Int32) {
+		if _, err := tx.ExecContext(ctx, s.sql(updateVehicleMileageQuery),
+			serviceKm.Int32,
+			now,
+			actorID,
+			s.dialect.UUIDArg(externalID),
+		); err != nil {
+			return nil, fmt.Errorf("failed to update vehicle mileage: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, s.sql(insertVehicleMileageReadingQuery),
+			s.dialect.UUIDArg(externalID),
+			serviceKm.Int32,
+			actorID,
+			now,
+		); err != nil {
+			return nil, fmt.Errorf("failed to record mileage reading: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, s.sql(updateVehicleLastServiceQuery),
+		serviceKm,
+		record.ServicedAt,
+		now,
+		actorID,
+		s.dialect.UUIDArg(externalID),
+	); err != nil {
+		return nil, fmt.Errorf("failed to update vehicle last service: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, s.sql(insertVehicleServiceRecordQuery),
+		s.dialect.UUIDArg(externalID),
+		serviceKm,
+		record.ServicedAt,
+		sql.NullString{String: record.Notes, Valid: record.Notes != ""},
+		actorID,
+		now,
+	); err != nil {
+		return nil, fmt.Errorf("failed to record service: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetVehicleByID(ctx, externalID)
+}
+
+const deleteVehicleQuery = `
+UPDATE vehicles 
+SET status = 'RETIRED', updated_at = ?, updated_by = ?
+WHERE external_id = ?`
+
+// DeleteVehicle retires the vehicle. Already retired vehicles are reported as not found.
+func (s *store) DeleteVehicle(ctx context.Context, externalID uuid.UUID, actorID string) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	previousStatus, err := s.lockVehicleStatus(ctx, tx, externalID)
+	if err != nil {
+		return err
+	}
+	if previousStatus == genproto.VehicleStatus_RETIRED.String() {
+		return types.ErrVehicleNotFound
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, s.sql(deleteVehicleQuery),
+		now,
+		actorID,
+		s.dialect.UUIDArg(externalID),
+	); err != nil {
+		return fmt.Errorf("failed to delete vehicle: %w", err)
+	}
+
+	if err := s.insertStatusHistory(ctx, tx, externalID, previousStatus, genproto.VehicleStatus_RETIRED.String(), "", actorID, now); err != nil {
+		return err
+	}
+
+	if err := s.tombstonePlate(ctx, tx, externalID, now); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// lockVehicleStatus reads the current status, locking the row until the transaction ends
+func (s *store) lockVehicleStatus(ctx context.Context, tx *sql.Tx, externalID uuid.UUID) (string, error) {
+	var status string
+	err := tx.QueryRowContext(ctx, s.sql(lockVehicleStatusQuery), s.dialect.UUIDArg(externalID)).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", types.ErrVehicleNotFound
+		}
+		return "", fmt.Errorf("failed to read vehicle status: %w", err)
+	}
+	return status, nil
+}
+
+func (s *store) insertStatusHistory(ctx context.Context, tx *sql.Tx, externalID uuid.UUID, previousStatus, newStatus, reason, actorID string, changedAt time.Time) error {
+	_, err := tx.ExecContext(ctx, s.sql(insertVehicleStatusHistoryQuery),
+		s.dialect.UUIDArg(externalID),
+		previousStatus,
+		newStatus,
+		sql.NullString{String: reason, Valid: reason != ""},
+		actorID,
+		changedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record status history: %w", err)
+	}
+	return nil
+}
+
+const tombstonePlateQuery = `
+INSERT INTO license_plate_tombstones (license_plate, vehicle_id, retired_at, expires_at)
+SELECT license_plate, external_id, ?, ? FROM vehicles
+WHERE external_id = ?
+{{on_conflict_update license_plate}}
+	vehicle_id = {{inserted vehicle_id}},
+	retired_at = {{inserted retired_at}},
+	expires_at = {{inserted expires_at}}`
+
+// tombstonePlate holds the retired vehicle's plate back from reuse for the cooldown
+func (s *store) tombstonePlate(ctx context.Context, tx *sql.Tx, externalID uuid.UUID, retiredAt time.Time) error {
+	if s.plateCooldown <= 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, s.sql(tombstonePlateQuery),
+		retiredAt,
+		retiredAt.Add(s.plateCooldown),
+		s.dialect.UUIDArg(externalID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to tombstone license plate: %w", err)
+	}
+	return nil
+}
+
+const getActivePlateTombstoneQuery = `
+SELECT license_plate, {{uuid_text vehicle_id}}, retired_at, expires_at
+FROM license_plate_tombstones
+WHERE license_plate = ? AND expires_at > ?`
+
+// GetActivePlateTombstone returns the tombstone holding back the plate, if it hasn't expired yet
+func (s *store) GetActivePlateTombstone(ctx context.Context, licensePlate string) (*types.PlateTombstone, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var tombstone types.PlateTombstone
+	err := s.db.QueryRowContext(ctx, s.sql(getActivePlateTombstoneQuery), licensePlate, time.Now()).Scan(
+		&tombstone.LicensePlate,
+		&tombstone.VehicleID,
+		&tombstone.RetiredAt,
+		&tombstone.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrTombstoneNotFound
+		}
+		return nil, fmt.Errorf("failed to get license plate tombstone: %w", err)
+	}
+	return &tombstone, nil
+}
+
+// Idempotency
+
+const purgeExpiredIdempotencyKeysQuery = `
+DELETE FROM idempotency_keys
+WHERE expires_at <= ?
+ORDER BY expires_at
+LIMIT 100`
+
+// An expired key is taken over by the new claim; a live one keeps its resource.
+// expires_at is assigned last since MySQL applies the assignments in order.
+const claimIdempotencyKeyQuery = `
+INSERT INTO idempotency_keys (scope, actor_id, idempotency_key, external_id, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
+{{on_conflict_update scope, actor_id, idempotency_key}}
+	external_id = CASE WHEN expires_at <= {{inserted created_at}} THEN {{inserted external_id}} ELSE external_id END,
+	created_at = CASE WHEN expires_at <= {{inserted created_at}} THEN {{inserted created_at}} ELSE created_at END,
+	expires_at = CASE WHEN expires_at <= {{inserted created_at}} THEN {{inserted expires_at}} ELSE expires_at END`
+
+const getIdempotencyKeyQuery = `
+SELECT {{uuid_text external_id}}
+FROM idempotency_keys
+WHERE scope = ? AND actor_id = ? AND idempotency_key = ?`
+
+// ClaimIdempotencyKey binds key to externalID for ttl, unless the key is already bound
+// and hasn't expired. It returns the ID the key is bound to afterwards; anything other
+// than externalID means an earlier request claimed the key first.
+func (s *store) ClaimIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID, ttl time.Duration) (uuid.UUID, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	now := time.Now()
+
+	// Expired keys are cleared a few at a time as new ones are claimed, so the table
+	// stays close to the keys still live
+	if _, err := s.db.ExecContext(ctx, s.sql(purgeExpiredIdempotencyKeysQuery), now); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+
+	_, err := s.db.ExecContext(ctx, s.sql(claimIdempotencyKeyQuery),
+		key.Scope,
+		key.ActorID,
+		key.Value,
+		s.dialect.UUIDArg(externalID),
+		now,
+		now.Add(ttl),
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	var boundID string
+	err = s.db.QueryRowContext(ctx, s.sql(getIdempotencyKeyQuery), key.Scope, key.ActorID, key.Value).Scan(&boundID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to read idempotency key: %w", err)
+	}
+	return uuid.FromString(boundID)
+}
+
+const releaseIdempotencyKeyQuery = `
+DELETE FROM idempotency_keys
+WHERE scope = ? AND actor_id = ? AND idempotency_key = ? AND external_id = ?`
+
+// ReleaseIdempotencyKey frees a key claimed for externalID, for when the request that
+// claimed it failed. A key since taken over by another claim is left alone.
+func (s *store) ReleaseIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	_, err := s.db.ExecContext(ctx, s.sql(releaseIdempotencyKeyQuery),
+		key.Scope,
+		key.ActorID,
+		key.Value,
+		s.dialect.UUIDArg(externalID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Driver assignment
+
+const insertVehicleAssignmentQuery = `
+INSERT INTO vehicle_assignments (vehicle_id, driver_id, assigned_by, assigned_at)
+VALUES (?, ?, ?, ?)`
+
+// CreateAssignment hands an ACTIVE vehicle to the driver and moves it to ASSIGNED, recording
+// the transition in vehicle_status_history. A vehicle that is already ASSIGNED is reported as
+// in use; any other status is not active.
+func (s *store) CreateAssignment(ctx context.Context, vehicleID, driverID uuid.UUID, actorID string) (*genproto.VehicleAssignment, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	previousStatus, err := s.lockVehicleStatus(ctx, tx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	switch previousStatus {
+	case genproto.VehicleStatus_ACTIVE.String():
+	case genproto.VehicleStatus_ASSIGNED.String():
+		return nil, types.ErrVehicleInUse
+	default:
+		return nil, fmt.Errorf("%w: vehicle is %s", types.ErrVehicleNotActive, previousStatus)
+	}
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, s.sql(insertVehicleAssignmentQuery),
+		s.dialect.UUIDArg(vehicleID),
+		s.dialect.UUIDArg(driverID),
+		actorID,
+		now,
+	)
+	if err != nil {
+		if s.dialect.IsDuplicateEntry(err) {
+			return nil, types.ErrVehicleInUse
+		}
+		return nil, fmt.Errorf("failed to create assignment: %w", err)
+	}
+	assignmentID, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment ID: %w", err)
+	}
+
+	assigned := genproto.VehicleStatus_ASSIGNED.String()
+	if _, err := tx.ExecContext(ctx, s.sql(updateVehicleStatusQuery),
+		assigned,
+		now,
+		actorID,
+		s.dialect.UUIDArg(vehicleID),
+	); err != nil {
+		return nil, fmt.Errorf("failed to update vehicle status: %w", err)
+	}
+
+	reason := "assigned to driver " + driverID.String()
+	if err := s.insertStatusHistory(ctx, tx, vehicleID, previousStatus, assigned, reason, actorID, now); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &genproto.VehicleAssignment{
+		Id:         strconv.FormatInt(assignmentID, 10),
+		VehicleId:  vehicleID.String(),
+		DriverId:   driverID.String(),
+		AssignedBy: actorID,
+		AssignedAt: timestamppb.New(now),
+	}, nil
+}
+
+const assignmentColumns = `id, {{uuid_text vehicle_id}}, {{uuid_text driver_id}}, assigned_by, assigned_at,
+       attention_reason, flagged_at`
+
+const getActiveAssignmentByVehicleQuery = `
+SELECT ` + assignmentColumns + `
+FROM vehicle_assignments
+WHERE vehicle_id = ? AND ended_at IS NULL`
+
+// GetActiveAssignmentByVehicle returns the vehicle's open assignment
+func (s *store) GetActiveAssignmentByVehicle(ctx context.Context, vehicleID uuid.UUID) (*genproto.VehicleAssignment, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return scanAssignment(s.db.QueryRowContext(ctx, s.sql(getActiveAssignmentByVehicleQuery), s.dialect.UUIDArg(vehicleID)))
+}
+
+const getActiveAssignmentByDriverQuery = `
+SELECT ` + assignmentColumns + `
+FROM vehicle_assignments
+WHERE driver_id = ? AND ended_at IS NULL
+ORDER BY assigned_at DESC, id DESC
+LIMIT 1`
+
+// GetActiveAssignmentByDriver returns the driver's open assignment. Nothing stops a
+// driver holding several vehicles, so the most recent one is taken as current.
+func (s *store) GetActiveAssignmentByDriver(ctx context.Context, driverID uuid.UUID) (*genproto.VehicleAssignment, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return scanAssignment(s.db.QueryRowContext(ctx, s.sql(getActiveAssignmentByDriverQuery), s.dialect.UUIDArg(driverID)))
+}
+
+// scanAssignment reads a row selected with assignmentColumns
+func scanAssignment(row *sql.Row) (*genproto.VehicleAssignment, error) {
+	var assignment genproto.VehicleAssignment
+	var assignedBy, attentionReason sql.NullString
+	var assignedAt time.Time
+	var flaggedAt sql.NullTime
+	err := row.Scan(
+		&assignment.Id,
+		&assignment.VehicleId,
+		&assignment.DriverId,
+		&assignedBy,
+		&assignedAt,
+		&attentionReason,
+		&flaggedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, types.ErrAssignmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get active assignment: %w", err)
+	}
+	assignment.AssignedBy = assignedBy.String
+	assignment.AssignedAt = timestamppb.New(assignedAt)
+	assignment.AttentionReason = attentionReason.String
+	if flaggedAt.Valid {
+		assignment.FlaggedAt = timestamppb.New(flaggedAt.Time)
+	}
+	return &assignment, nil
+}
+
+const flagActiveAssignmentsByDriverQuery = `
+UPDATE vehicle_assignments
+SET attention_reason = ?, flagged_at = ?
+WHERE driver_id = ? AND ended_at IS NULL`
+
+// FlagActiveAssignmentByDriver marks the driver's open assignments for dispatcher attention
+// and returns the current one
+func (s *store) FlagActiveAssignmentByDriver(ctx context.Context, driverID uuid.UUID, reason string) (*genproto.VehicleAssignment, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, s.sql(flagActiveAssignmentsByDriverQuery), reason, time.Now(), s.dialect.UUIDArg(driverID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to flag assignment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return nil, types.ErrAssignmentNotFound
+	}
+
+	return scanAssignment(s.db.QueryRowContext(ctx, s.sql(getActiveAssignmentByDriverQuery), s.dialect.UUIDArg(driverID)))
+}
+
+// Specialized queries
+
+func (s *store) GetVehiclesByType(ctx context.Context, vehicleTypeID string, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.VehicleTypeFilter = &vehicleTypeID
+	return s.ListVehicles(ctx, params)
+}
+
+const getAvailableVehiclesQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.status = 'ACTIVE'
+  AND (?='' OR v.vehicle_type_id = ?)
+  AND (?='' OR (v.created_at <= ? AND (v.created_at < ? OR v.external_id < ?)))
+ORDER BY v.created_at DESC, v.external_id DESC
+LIMIT ?`
+
+const countAvailableVehiclesQuery = `
+SELECT COUNT(*)
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.status = 'ACTIVE'
+  AND (?='' OR v.vehicle_type_id = ?)`
+
+func (s *store) GetAvailableVehicles(ctx context.Context, vehicleTypeID *string, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	vehicleTypeStr := ""
+	if vehicleTypeID != nil {
+		vehicleTypeStr = *vehicleTypeID
+	}
+
+	cursorStr, cursorID, err := s.uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, s.sql(countAvailableVehiclesQuery),
+		vehicleTypeStr, vehicleTypeStr,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count available vehicles: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, s.sql(getAvailableVehiclesQuery),
+		vehicleTypeStr, vehicleTypeStr,
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
+	)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to get available vehicles: %w", err)
+	}
+	defer rows.Close()
+
+	var vehicles []*genproto.Vehicle
+	for rows.Next() {
+		vehicle, err := s.scanVehicleFromRows(rows)
+		if err != nil {
+			return nil, "", 0, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		vehicles = append(vehicles, vehicle)
+	}
+
+	// Determine next page token
+	var nextPageToken string
+	if int32(len(vehicles)) > params.PageSize {
+		vehicles = vehicles[:params.PageSize]
+		last := vehicles[len(vehicles)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
+	}
+
+	return vehicles, nextPageToken, total, nil
+}
+
+// Vehicles handed to a driver move to ASSIGNED, so restricting to ACTIVE
+// already excludes anything currently out on an assignment.
+const getDispatchCandidatesQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.status = 'ACTIVE'
+  AND (?='' OR v.vehicle_type_id = ?)
+  AND v.seating_capacity >= ?
+  AND v.insurance_expiry IS NOT NULL
+  AND v.insurance_expiry >= ?
+  AND (?='' OR (v.created_at <= ? AND (v.created_at < ? OR v.external_id < ?)))
+ORDER BY v.created_at DESC, v.external_id DESC
+LIMIT ?`
+
+const countDispatchCandidatesQuery = `
+SELECT COUNT(*)
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.status = 'ACTIVE'
+  AND (?='' OR v.vehicle_type_id = ?)
+  AND v.seating_capacity >= ?
+  AND v.insurance_expiry IS NOT NULL
+  AND v.insurance_expiry >= ?`
+
+func (s *store) GetDispatchCandidates(ctx context.Context, filter types.DispatchFilter, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check affected rows: %w", err)
+		return nil, "", 0, err
 	}
-	if rowsAffected == 0 {
-		return nil, types.ErrVehicleNotFound
+
+	vehicleTypeStr := ""
+	if filter.VehicleTypeID != nil {
+		vehicleTypeStr = *filter.VehicleTypeID
 	}
 
-	return s.GetVehicleByID(ctx, externalID)
-}
+	cursorStr, cursorID, err := s.uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
 
-const deleteVehicleQuery = `
-UPDATE vehicles 
-SET status = 'RETIRED', updated_at = ?
-WHERE external_id = ? AND status != 'RETIRED'`
+	insuranceValidOn := filter.InsuranceValidOn.Format("2006-01-02")
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
 
-func (s *store) DeleteVehicle(ctx context.Context, externalID uuid.UUID) error {
-	result, err := s.db.ExecContext(ctx, deleteVehicleQuery,
-		time.Now(),
-		externalID.Bytes(),
+	var total int32
+	if err := tx.QueryRowContext(ctx, s.sql(countDispatchCandidatesQuery),
+		vehicleTypeStr, vehicleTypeStr,
+		filter.MinSeats,
+		insuranceValidOn,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count dispatch candidates: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, s.sql(getDispatchCandidatesQuery),
+		vehicleTypeStr, vehicleTypeStr,
+		filter.MinSeats,
+		insuranceValidOn,
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to delete vehicle: %w", err)
+		return nil, "", 0, fmt.Errorf("failed to get dispatch candidates: %w", err)
 	}
+	defer rows.Close()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to check affected rows: %w", err)
+	var vehicles []*genproto.Vehicle
+	for rows.Next() {
+		vehicle, err := s.scanVehicleFromRows(rows)
+		if err != nil {
+			return nil, "", 0, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		vehicles = append(vehicles, vehicle)
 	}
-	if rowsAffected == 0 {
-		return types.ErrVehicleNotFound
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to iterate dispatch candidates: %w", err)
 	}
 
-	return nil
-}
-
-// Specialized queries
+	// Determine next page token from the last row we actually return
+	var nextPageToken string
+	if int32(len(vehicles)) > params.PageSize {
+		vehicles = vehicles[:params.PageSize]
+		last := vehicles[len(vehicles)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
+	}
 
-func (s *store) GetVehiclesByType(ctx context.Context, vehicleTypeID string, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, error) {
-	params.VehicleTypeFilter = &vehicleTypeID
-	return s.ListVehicles(ctx, params)
+	return vehicles, nextPageToken, total, nil
 }
 
-const getAvailableVehiclesQuery = `
+const listRecentlyUpdatedVehiclesQuery = `
 SELECT 
-	LOWER(HEX(v.external_id)) as external_id,
+	{{uuid_text v.external_id}} as external_id,
 	v.vehicle_type_id,
 	vt.name as vehicle_type_name,
 	v.license_plate,
@@ -698,89 +1935,499 @@ SELECT
 	v.insurance_expiry,
 	v.status,
 	v.created_at,
-	v.updated_at
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
 FROM vehicles v
 INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
-WHERE v.status = 'ACTIVE'
-  AND (?='' OR v.vehicle_type_id = ?)
-  AND (?='' OR v.created_at > ?)
-ORDER BY v.created_at DESC
+WHERE v.updated_at IS NOT NULL
+  AND (?='' OR (v.updated_at <= ? AND (v.updated_at < ? OR v.external_id < ?)))
+ORDER BY v.updated_at DESC, v.external_id DESC
 LIMIT ?`
 
-func (s *store) GetAvailableVehicles(ctx context.Context, vehicleTypeID *string, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, error) {
-	if params.PageSize <= 0 || params.PageSize > 100 {
-		params.PageSize = 50
-	}
+const countRecentlyUpdatedVehiclesQuery = `
+SELECT COUNT(*)
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id
+WHERE v.updated_at IS NOT NULL`
+
+// ListRecentlyUpdatedVehicles pages through vehicles by updated_at, newest first.
+// Vehicles that were never modified have a NULL updated_at and are left out.
+func (s *store) ListRecentlyUpdatedVehicles(ctx context.Context, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
 
 	// Parse page token
-	var cursorTime time.Time
-	if params.PageToken != "" {
-		decoded, err := base64.URLEncoding.DecodeString(params.PageToken)
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.UpdatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	cursorStr, cursorID, err := s.uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, s.sql(countRecentlyUpdatedVehiclesQuery)).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count recently updated vehicles: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, s.sql(listRecentlyUpdatedVehiclesQuery),
+		cursorStr, cursorStr, cursorStr, cursorID,
+		params.PageSize+1,
+	)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("failed to list recently updated vehicles: %w", err)
+	}
+	defer rows.Close()
+
+	var vehicles []*genproto.Vehicle
+	for rows.Next() {
+		vehicle, err := s.scanVehicleFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("invalid page token: %w", err)
-		}
-		if err := cursorTime.UnmarshalText(decoded); err != nil {
-			return nil, "", fmt.Errorf("invalid page token format: %w", err)
+			return nil, "", 0, fmt.Errorf("failed to scan vehicle: %w", err)
 		}
+		vehicles = append(vehicles, vehicle)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to iterate recently updated vehicles: %w", err)
 	}
 
-	vehicleTypeStr := ""
-	if vehicleTypeID != nil {
-		vehicleTypeStr = *vehicleTypeID
+	// The cursor is the updated_at of the last row we actually return
+	var nextPageToken string
+	if int32(len(vehicles)) > params.PageSize {
+		vehicles = vehicles[:params.PageSize]
+		last := vehicles[len(vehicles)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.UpdatedAtDesc, pagetoken.Cursor{At: last.UpdatedAt.AsTime(), ID: last.Id})
+	}
+
+	return vehicles, nextPageToken, total, nil
+}
+
+// The distance is compared as signed so a reading below the last service's can't
+// underflow the unsigned columns. A vehicle never serviced counts from 0 km and from its
+// registration, or its creation when the registration date is unknown.
+const maintenanceDueCondition = `
+WHERE v.status <> 'RETIRED'
+  AND (CAST(COALESCE(v.mileage_km, 0) AS SIGNED) - CAST(COALESCE(v.last_service_km, 0) AS SIGNED) >= ?
+       OR COALESCE(v.last_service_at, v.registration_date, v.created_at) <= ?)`
+
+const getVehiclesDueForMaintenanceQuery = `
+SELECT 
+	{{uuid_text v.external_id}} as external_id,
+	v.vehicle_type_id,
+	vt.name as vehicle_type_name,
+	v.license_plate,
+	v.make,
+	v.model,
+	v.year,
+	v.color,
+	v.seating_capacity,
+	v.fuel_type,
+	v.engine_number,
+	v.chassis_number,
+	v.registration_date,
+	v.insurance_expiry,
+	v.status,
+	v.created_at,
+	v.updated_at,
+	v.updated_by,
+	v.mileage_km,
+	v.last_service_km,
+	v.last_service_at
+FROM vehicles v
+INNER JOIN vehicle_types vt ON v.vehicle_type_id = vt.id` + maintenanceDueCondition + `
+  AND (?='' OR (v.created_at <= ? AND (v.created_at < ? OR v.external_id < ?)))
+ORDER BY v.created_at DESC, v.external_id DESC
+LIMIT ?`
+
+const countVehiclesDueForMaintenanceQuery = `
+SELECT COUNT(*)
+FROM vehicles v` + maintenanceDueCondition
+
+// GetVehiclesDueForMaintenance pages through the vehicles, other than retired ones, that
+// have covered at least kmThreshold since their last service or were last serviced at or
+// before servicedBefore, newest first
+func (s *store) GetVehiclesDueForMaintenance(ctx context.Context, kmThreshold int32, servicedBefore time.Time, params types.ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	params.PageSize = pagesize.ClampTo(params.PageSize, pagesize.Default(), pagesize.InternalMax())
+
+	// Parse page token
+	cursor, err := pagetoken.Decode(params.PageToken, pagetoken.CreatedAtDesc)
+	if err != nil {
+		return nil, "", 0, err
 	}
 
-	cursorStr := ""
-	if !cursorTime.IsZero() {
-		cursorStr = cursorTime.Format(time.RFC3339Nano)
+	cursorStr, cursorID, err := s.uuidCursorArgs(cursor)
+	if err != nil {
+		return nil, "", 0, err
 	}
 
-	rows, err := s.db.QueryContext(ctx, getAvailableVehiclesQuery,
-		vehicleTypeStr, vehicleTypeStr,
-		cursorStr, cursorStr,
+	tx, err := s.beginSnapshot(ctx)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer endSnapshot(tx)
+
+	var total int32
+	if err := tx.QueryRowContext(ctx, s.sql(countVehiclesDueForMaintenanceQuery),
+		kmThreshold, servicedBefore,
+	).Scan(&total); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to count vehicles due for maintenance: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, s.sql(getVehiclesDueForMaintenanceQuery),
+		kmThreshold, servicedBefore,
+		cursorStr, cursorStr, cursorStr, cursorID,
 		params.PageSize+1,
 	)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get available vehicles: %w", err)
+		return nil, "", 0, fmt.Errorf("failed to get vehicles due for maintenance: %w", err)
 	}
 	defer rows.Close()
 
 	var vehicles []*genproto.Vehicle
-	var lastCreatedAt time.Time
-
 	for rows.Next() {
 		vehicle, err := s.scanVehicleFromRows(rows)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to scan vehicle: %w", err)
+			return nil, "", 0, fmt.Errorf("failed to scan vehicle: %w", err)
 		}
 		vehicles = append(vehicles, vehicle)
-		lastCreatedAt = vehicle.CreatedAt.AsTime()
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, fmt.Errorf("failed to iterate vehicles due for maintenance: %w", err)
 	}
 
 	// Determine next page token
 	var nextPageToken string
 	if int32(len(vehicles)) > params.PageSize {
 		vehicles = vehicles[:params.PageSize]
-		tokenBytes, err := lastCreatedAt.MarshalText()
-		if err != nil {
-			return nil, "", fmt.Errorf("failed to create next page token: %w", err)
+		last := vehicles[len(vehicles)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.CreatedAtDesc, pagetoken.Cursor{At: last.CreatedAt.AsTime(), ID: last.Id})
+	}
+
+	return vehicles, nextPageToken, total, nil
+}
+
+const listVehicleStatusHistoryQuery = `
+SELECT id, {{uuid_text vehicle_id}}, previous_status, new_status, reason, changed_by, changed_at
+FROM vehicle_status_history
+WHERE vehicle_id = ?
+AND (?='' OR (changed_at <= ? AND (changed_at < ? OR id < ?)))
+ORDER BY changed_at DESC, id DESC
+LIMIT ?`
+
+// ListVehicleStatusHistory pages through a vehicle's status transitions, most recent first
+func (s *store) ListVehicleStatusHistory(ctx context.Context, externalID uuid.UUID, pageSize int32, pageToken string) ([]*genproto.VehicleStatusHistoryEntry, string, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	cursor, err := pagetoken.Decode(pageToken, pagetoken.ChangedAtDesc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursorStr, cursorID, err := numericCursorArgs(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.sql(listVehicleStatusHistoryQuery),
+		s.dialect.UUIDArg(externalID),
+		cursorStr, cursorStr, cursorStr, cursorID,
+		pageSize+1, // Fetch one extra to determine if there are more pages
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list status history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*genproto.VehicleStatusHistoryEntry
+	for rows.Next() {
+		var entry genproto.VehicleStatusHistoryEntry
+		var previousStatus, newStatus string
+		var reason, changedBy sql.NullString
+		var changedAt time.Time
+
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.VehicleId,
+			&previousStatus,
+			&newStatus,
+			&reason,
+			&changedBy,
+			&changedAt,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan status history: %w", err)
+		}
+
+		entry.PreviousStatus = genproto.VehicleStatus(genproto.VehicleStatus_value[previousStatus])
+		entry.NewStatus = genproto.VehicleStatus(genproto.VehicleStatus_value[newStatus])
+		entry.Reason = reason.String
+		entry.ChangedBy = changedBy.String
+		entry.ChangedAt = timestamppb.New(changedAt)
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to iterate status history: %w", err)
+	}
+
+	// Determine next page token
+	var nextPageToken string
+	if int32(len(entries)) > pageSize {
+		entries = entries[:pageSize]
+		last := entries[len(entries)-1]
+		nextPageToken = pagetoken.Encode(pagetoken.ChangedAtDesc, pagetoken.Cursor{At: last.ChangedAt.AsTime(), ID: last.Id})
+	}
+
+	return entries, nextPageToken, nil
+}
+
+// Reporting
+
+const listFleetVehiclesQuery = `
+SELECT {{uuid_text external_id}}, status, created_at
+FROM vehicles
+WHERE created_at < ?`
+
+const listStatusChangesSinceQuery = `
+SELECT {{uuid_text vehicle_id}}, previous_status, new_status, changed_at
+FROM vehicle_status_history
+WHERE changed_at >= ?
+ORDER BY changed_at, id`
+
+// GetFleetStatusTimeline returns every vehicle created before until, with its current
+// status, and every status change from since onwards (including changes after until,
+// which tell what status a vehicle held before them), oldest first
+func (s *store) GetFleetStatusTimeline(ctx context.Context, since, until time.Time) ([]types.FleetVehicle, []types.VehicleStatusChange, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, s.sql(listFleetVehiclesQuery), until)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query vehicles: %w", err)
+	}
+	defer rows.Close()
+
+	var vehicles []types.FleetVehicle
+	for rows.Next() {
+		var v types.FleetVehicle
+		var status string
+		if err := rows.Scan(&v.ID, &status, &v.CreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		v.Status = genproto.VehicleStatus(genproto.VehicleStatus_value[status])
+		vehicles = append(vehicles, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate vehicles: %w", err)
+	}
+
+	changeRows, err := s.db.QueryContext(ctx, s.sql(listStatusChangesSinceQuery), since)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer changeRows.Close()
+
+	var changes []types.VehicleStatusChange
+	for changeRows.Next() {
+		var c types.VehicleStatusChange
+		var previous, next string
+		if err := changeRows.Scan(&c.VehicleID, &previous, &next, &c.ChangedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		c.PreviousStatus = genproto.VehicleStatus(genproto.VehicleStatus_value[previous])
+		c.NewStatus = genproto.VehicleStatus(genproto.VehicleStatus_value[next])
+		changes = append(changes, c)
+	}
+	if err := changeRows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate status history: %w", err)
+	}
+
+	return vehicles, changes, nil
+}
+
+const listNormalizableVehiclesQuery = `
+SELECT internal_id, {{uuid_text external_id}}, license_plate, make, model, color, engine_number, chassis_number
+FROM vehicles
+WHERE internal_id > ?
+ORDER BY internal_id
+LIMIT ?`
+
+// ListNormalizableVehicles returns the next batch of vehicles after afterInternalID, in
+// internal ID order, with the stored values of the fields normalization rewrites
+func (s *store) ListNormalizableVehicles(ctx context.Context, afterInternalID uint64, limit int32) ([]types.NormalizableVehicle, error) {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, s.sql(listNormalizableVehiclesQuery), afterInternalID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vehicles: %w", err)
+	}
+	defer rows.Close()
+
+	var vehicles []types.NormalizableVehicle
+	for rows.Next() {
+		var v types.NormalizableVehicle
+		var engineNumber, chassisNumber sql.NullString
+		if err := rows.Scan(
+			&v.InternalID,
+			&v.ExternalID,
+			&v.LicensePlate,
+			&v.Make,
+			&v.Model,
+			&v.Color,
+			&engineNumber,
+			&chassisNumber,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan vehicle: %w", err)
+		}
+		v.EngineNumber = engineNumber.String
+		v.ChassisNumber = chassisNumber.String
+		vehicles = append(vehicles, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate vehicles: %w", err)
+	}
+
+	return vehicles, nil
+}
+
+// The stored values are matched again so a vehicle edited since it was read is left alone
+const applyVehicleNormalizationQuery = `
+UPDATE vehicles
+SET license_plate = ?, make = ?, model = ?, color = ?, engine_number = ?, chassis_number = ?,
+	updated_at = ?, updated_by = ?
+WHERE internal_id = ?
+  AND license_plate = ? AND make = ? AND model = ? AND color = ?
+  AND COALESCE(engine_number, '') = ? AND COALESCE(chassis_number, '') = ?`
+
+const insertVehicleNormalizationAuditQuery = `
+INSERT INTO vehicle_normalization_audit (vehicle_id, field, old_value, new_value, changed_by, changed_at)
+VALUES (?, ?, ?, ?, ?, ?)`
+
+// ApplyVehicleNormalization writes the changed fields of one vehicle and records each
+// change in vehicle_normalization_audit, in one transaction. It fails with ErrStaleRecord
+// if the vehicle no longer holds the values it was read with, and ErrDuplicateEntry if a
+// normalized value collides with another vehicle's.
+func (s *store) ApplyVehicleNormalization(ctx context.Context, vehicle types.NormalizableVehicle, changes []types.FieldChange, actorID string) error {
+	ctx, cancel := utils.WithQueryTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	externalID, err := uuid.FromString(vehicle.ExternalID)
+	if err != nil {
+		return fmt.Errorf("invalid vehicle ID %q: %w", vehicle.ExternalID, err)
+	}
+
+	normalized := vehicle
+	for _, change := range changes {
+		switch change.Field {
+		case "license_plate":
+			normalized.LicensePlate = change.NewValue
+		case "make":
+			normalized.Make = change.NewValue
+		case "model":
+			normalized.Model = change.NewValue
+		case "color":
+			normalized.Color = change.NewValue
+		case "engine_number":
+			normalized.EngineNumber = change.NewValue
+		case "chassis_number":
+			normalized.ChassisNumber = change.NewValue
+		default:
+			return fmt.Errorf("unknown normalized field %q", change.Field)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			fmt.Printf("rollback failed: %v\n", rerr)
+		}
+	}()
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, s.sql(applyVehicleNormalizationQuery),
+		normalized.LicensePlate,
+		normalized.Make,
+		normalized.Model,
+		normalized.Color,
+		sql.NullString{String: normalized.EngineNumber, Valid: normalized.EngineNumber != ""},
+		sql.NullString{String: normalized.ChassisNumber, Valid: normalized.ChassisNumber != ""},
+		now,
+		actorID,
+		vehicle.InternalID,
+		vehicle.LicensePlate,
+		vehicle.Make,
+		vehicle.Model,
+		vehicle.Color,
+		vehicle.EngineNumber,
+		vehicle.ChassisNumber,
+	)
+	if err != nil {
+		if s.dialect.IsDuplicateEntry(err) {
+			return types.ErrDuplicateEntry
+		}
+		return fmt.Errorf("failed to normalize vehicle: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return types.ErrStaleRecord
+	}
+
+	for _, change := range changes {
+		if _, err := tx.ExecContext(ctx, s.sql(insertVehicleNormalizationAuditQuery),
+			s.dialect.UUIDArg(externalID),
+			change.Field,
+			change.OldValue,
+			change.NewValue,
+			actorID,
+			now,
+		); err != nil {
+			return fmt.Errorf("failed to record normalization: %w", err)
 		}
-		nextPageToken = base64.URLEncoding.EncodeToString(tokenBytes)
 	}
 
-	return vehicles, nextPageToken, nil
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 // Helper functions
 
 func (s *store) scanVehicle(ctx context.Context, query string, args ...interface{}) (*genproto.Vehicle, error) {
-	row := s.db.QueryRowContext(ctx, query, args...)
+	row := s.db.QueryRowContext(ctx, s.sql(query), args...)
 	return s.scanVehicleFromRow(row)
 }
 
 func (s *store) scanVehicleFromRow(row *sql.Row) (*genproto.Vehicle, error) {
 	var vehicle genproto.Vehicle
 	var statusStr, fuelTypeStr string
-	var engineNumber, chassisNumber sql.NullString
+	var engineNumber, chassisNumber, updatedBy sql.NullString
+	var mileageKm, lastServiceKm sql.NullInt32
+	var lastServiceAt sql.NullTime
 	var registrationDate, insuranceExpiry sql.NullTime
 	var createdAt, updatedAt time.Time
 
@@ -802,18 +2449,27 @@ func (s *store) scanVehicleFromRow(row *sql.Row) (*genproto.Vehicle, error) {
 		&statusStr,
 		&createdAt,
 		&updatedAt,
+		&updatedBy,
+		&mileageKm,
+		&lastServiceKm,
+		&lastServiceAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.populateVehicle(&vehicle, statusStr, fuelTypeStr, engineNumber, chassisNumber, registrationDate, insuranceExpiry, createdAt, updatedAt)
+	vehicle.MileageKm = nullInt32Ptr(mileageKm)
+	vehicle.LastServiceKm = nullInt32Ptr(lastServiceKm)
+	vehicle.LastServiceAt = nullTimestamp(lastServiceAt)
+	return s.populateVehicle(&vehicle, statusStr, fuelTypeStr, engineNumber, chassisNumber, updatedBy, registrationDate, insuranceExpiry, createdAt, updatedAt)
 }
 
 func (s *store) scanVehicleFromRows(rows *sql.Rows) (*genproto.Vehicle, error) {
 	var vehicle genproto.Vehicle
 	var statusStr, fuelTypeStr string
-	var engineNumber, chassisNumber sql.NullString
+	var engineNumber, chassisNumber, updatedBy sql.NullString
+	var mileageKm, lastServiceKm sql.NullInt32
+	var lastServiceAt sql.NullTime
 	var registrationDate, insuranceExpiry sql.NullTime
 	var createdAt, updatedAt time.Time
 
@@ -835,19 +2491,28 @@ func (s *store) scanVehicleFromRows(rows *sql.Rows) (*genproto.Vehicle, error) {
 		&statusStr,
 		&createdAt,
 		&updatedAt,
+		&updatedBy,
+		&mileageKm,
+		&lastServiceKm,
+		&lastServiceAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.populateVehicle(&vehicle, statusStr, fuelTypeStr, engineNumber, chassisNumber, registrationDate, insuranceExpiry, createdAt, updatedAt)
+	vehicle.MileageKm = nullInt32Ptr(mileageKm)
+	vehicle.LastServiceKm = nullInt32Ptr(lastServiceKm)
+	vehicle.LastServiceAt = nullTimestamp(lastServiceAt)
+	return s.populateVehicle(&vehicle, statusStr, fuelTypeStr, engineNumber, chassisNumber, updatedBy, registrationDate, insuranceExpiry, createdAt, updatedAt)
 }
 
-func (s *store) populateVehicle(vehicle *genproto.Vehicle, statusStr, fuelTypeStr string, engineNumber, chassisNumber sql.NullString, registrationDate, insuranceExpiry sql.NullTime, createdAt, updatedAt time.Time) (*genproto.Vehicle, error) {
+func (s *store) populateVehicle(vehicle *genproto.Vehicle, statusStr, fuelTypeStr string, engineNumber, chassisNumber, updatedBy sql.NullString, registrationDate, insuranceExpiry sql.NullTime, createdAt, updatedAt time.Time) (*genproto.Vehicle, error) {
 	// Convert status string to enum
+	// An unknown value is surfaced as STATUS_UNSPECIFIED rather than failing the read,
+	// otherwise the vehicle could never be loaded to fix it
 	statusVal, ok := genproto.VehicleStatus_value[statusStr]
 	if !ok {
-		return nil, fmt.Errorf("invalid status value: %s", statusStr)
+		log.Printf("Warning: vehicle %s has unrecognized status %q", vehicle.Id, statusStr)
 	}
 	vehicle.Status = genproto.VehicleStatus(statusVal)
 
@@ -871,10 +2536,101 @@ func (s *store) populateVehicle(vehicle *genproto.Vehicle, statusStr, fuelTypeSt
 	if insuranceExpiry.Valid {
 		vehicle.InsuranceExpiry = timestamppb.New(insuranceExpiry.Time)
 	}
+	if updatedBy.Valid {
+		vehicle.UpdatedBy = &updatedBy.String
+	}
 
 	// Set timestamps
 	vehicle.CreatedAt = timestamppb.New(createdAt)
 	vehicle.UpdatedAt = timestamppb.New(updatedAt)
 
 	return vehicle, nil
-}
\ No newline at end of file
+}
+
+// nullInt32Ptr returns a pointer to the value, or nil for NULL
+func nullInt32Ptr(n sql.NullInt32) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
+
+// nullTimestamp converts the value to a timestamp, or nil for NULL
+func nullTimestamp(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+// beginSnapshot opens a read-only transaction for a listing, so its total count and
+// its page are read from the same snapshot
+func (s *store) beginSnapshot(ctx context.Context) (*sql.Tx, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// endSnapshot releases a transaction from beginSnapshot; nothing was written, so it is rolled back
+func endSnapshot(tx *sql.Tx) {
+	if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+		fmt.Printf("rollback failed: %v\n", rerr)
+	}
+}
+
+// uuidCursorArgs expands a page cursor into the arguments of the keyset filter
+//
+//	(?='' OR (created_at <= ? AND (created_at < ? OR external_id < ?)))
+//
+// Tokens issued without an ID compare against the nil UUID, which nothing sorts
+// below, so they fall back to paging on the timestamp alone.
+func (s *store) uuidCursorArgs(cursor pagetoken.Cursor) (string, any, error) {
+	if cursor.IsZero() {
+		return "", s.dialect.UUIDArg(uuid.Nil), nil
+	}
+	id := uuid.Nil
+	if cursor.ID != "" {
+		parsed, err := uuid.FromString(cursor.ID)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", pagetoken.ErrInvalidToken, err)
+		}
+		id = parsed
+	}
+	return cursor.At.Format(time.RFC3339Nano), s.dialect.UUIDArg(id), nil
+}
+
+// numericCursorArgs is uuidCursorArgs for tables keyed by a numeric ID
+func numericCursorArgs(cursor pagetoken.Cursor) (string, int64, error) {
+	if cursor.IsZero() {
+		return "", 0, nil
+	}
+	var id int64
+	if cursor.ID != "" {
+		parsed, err := strconv.ParseInt(cursor.ID, 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("%w: %v", pagetoken.ErrInvalidToken, err)
+		}
+		id = parsed
+	}
+	return cursor.At.Format(time.RFC3339Nano), id, nil
+}
+
+// makeLikePattern builds the LIKE pattern for a make filter. Exact and prefix
+// patterns have no leading wildcard so MySQL can range-scan idx_vehicles_make;
+// contains needs a full scan and is only used when explicitly requested.
+func makeLikePattern(make string, match genproto.MakeMatch) string {
+	escaped := likeEscaper.Replace(make)
+	switch match {
+	case genproto.MakeMatch_MAKE_EXACT:
+		return escaped
+	case genproto.MakeMatch_MAKE_CONTAINS:
+		return "%" + escaped + "%"
+	default:
+		return escaped + "%"
+	}
+}
+
+// likeEscaper stops user-supplied % and _ from acting as LIKE wildcards
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,485 @@
+// services/vehicle/internal/store/store_test.go
+package store
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/adammwaniki/bebabeba/services/common/metrics"
+	"github.com/adammwaniki/bebabeba/services/common/pagesize"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/vehicle/internal/types"
+	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"github.com/gofrs/uuid/v5"
+)
+
+// newMockStore returns a store over a sqlmock database opened the way NewStore opens the
+// real one, through metrics.OpenDB, so the driver wrapping is the same as in production
+func newMockStore(t *testing.T) (*store, sqlmock.Sqlmock) {
+	t.Helper()
+	dsn := fmt.Sprintf("sqlmock_%s", t.Name())
+	mockDB, mock, err := sqlmock.NewWithDSN(dsn)
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	t.Cleanup(func() { mockDB.Close() })
+
+	db, err := metrics.OpenDB("sqlmock", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	return NewStoreWithDialect(db, MySQL), mock
+}
+
+func TestQueryTimeout(t *testing.T) {
+	s, mock := newMockStore(t)
+	s.SetQueryTimeout(50 * time.Millisecond)
+
+	// The query blocks far longer than the store's timeout
+	mock.ExpectQuery("SELECT id, name, description, created_at").
+		WillDelayFor(5 * time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at"}))
+
+	start := time.Now()
+	_, err := s.GetVehicleTypeByID(context.Background(), "1")
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("query returned after %s, want about 50ms", elapsed)
+	}
+}
+
+func TestQueryTimeoutLeavesHeadroomBeforeCallerDeadline(t *testing.T) {
+	s, mock := newMockStore(t)
+	s.SetQueryTimeout(time.Minute)
+
+	mock.ExpectQuery("SELECT id, name, description, created_at").
+		WillDelayFor(5 * time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at"}))
+
+	// The caller's deadline is far shorter than the store's own timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	_, err := s.GetVehicleTypeByID(ctx, "1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if ctx.Err() != nil {
+		t.Error("store gave up after the caller's deadline, want before it")
+	}
+}
+
+// vehicleColumns are the columns the vehicle list queries select, in scan order
+var vehicleColumns = []string{
+	"external_id", "vehicle_type_id", "vehicle_type_name", "license_plate", "make", "model", "year",
+	"color", "seating_capacity", "fuel_type", "engine_number", "chassis_number", "registration_date",
+	"insurance_expiry", "status", "created_at", "updated_at", "updated_by", "mileage_km",
+	"last_service_km", "last_service_at",
+}
+
+func TestListVehiclesQueryArgs(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	atStr := at.Format(time.RFC3339Nano)
+	id := uuid.Must(uuid.FromString("8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90"))
+	noFilters := []driver.Value{"", "", "", "", "", "", "", "", "", ""}
+
+	tests := []struct {
+		name      string
+		sort      pagetoken.Sort
+		cursor    pagetoken.Cursor
+		wantQuery string // a fragment of the query that picks out the variant
+		wantArgs  []driver.Value
+	}{
+		{
+			name:      "first page",
+			sort:      pagetoken.CreatedAtDesc,
+			wantQuery: "(v.created_at <= ? AND (v.created_at < ? OR v.external_id < ?)))\nORDER BY v.created_at DESC, v.external_id DESC",
+			wantArgs:  []driver.Value{"", "", "", uuid.Nil.Bytes()},
+		},
+		{
+			name:      "created_at forward",
+			sort:      pagetoken.CreatedAtDesc,
+			cursor:    pagetoken.Cursor{At: at, ID: id.String()},
+			wantQuery: "(v.created_at <= ? AND (v.created_at < ? OR v.external_id < ?)))\nORDER BY v.created_at DESC, v.external_id DESC",
+			wantArgs:  []driver.Value{atStr, atStr, atStr, id.Bytes()},
+		},
+		{
+			name:      "created_at backward",
+			sort:      pagetoken.CreatedAtDesc,
+			cursor:    pagetoken.Cursor{At: at, ID: id.String(), Backward: true},
+			wantQuery: "(v.created_at >= ? AND (v.created_at > ? OR v.external_id > ?)))\nORDER BY v.created_at ASC, v.external_id ASC",
+			wantArgs:  []driver.Value{atStr, atStr, atStr, id.Bytes()},
+		},
+		{
+			name:      "token issued without an ID",
+			sort:      pagetoken.CreatedAtDesc,
+			cursor:    pagetoken.Cursor{At: at},
+			wantQuery: "ORDER BY v.created_at DESC, v.external_id DESC",
+			wantArgs:  []driver.Value{atStr, atStr, atStr, uuid.Nil.Bytes()},
+		},
+		{
+			name:      "year descending forward",
+			sort:      pagetoken.Sort{Column: "year", Descending: true},
+			cursor:    pagetoken.Cursor{At: at, ID: id.String(), Key: "2019"},
+			wantQuery: "v.year < ? OR (v.year = ? AND (v.created_at < ? OR (v.created_at = ? AND v.external_id < ?))))\nORDER BY v.year DESC, v.created_at DESC, v.external_id DESC",
+			wantArgs:  []driver.Value{atStr, int64(2019), int64(2019), atStr, atStr, id.Bytes()},
+		},
+		{
+			name:      "year descending backward",
+			sort:      pagetoken.Sort{Column: "year", Descending: true},
+			cursor:    pagetoken.Cursor{At: at, ID: id.String(), Key: "2019", Backward: true},
+			wantQuery: "v.year > ? OR (v.year = ? AND (v.created_at > ? OR (v.created_at = ? AND v.external_id > ?))))\nORDER BY v.year ASC, v.created_at ASC, v.external_id ASC",
+			wantArgs:  []driver.Value{atStr, int64(2019), int64(2019), atStr, atStr, id.Bytes()},
+		},
+		{
+			name:      "make ascending forward",
+			sort:      pagetoken.Sort{Column: "make"},
+			cursor:    pagetoken.Cursor{At: at, ID: id.String(), Key: "Toyota"},
+			wantQuery: "v.make > ? OR (v.make = ? AND (v.created_at > ? OR (v.created_at = ? AND v.external_id > ?))))\nORDER BY v.make ASC, v.created_at ASC, v.external_id ASC",
+			wantArgs:  []driver.Value{atStr, "Toyota", "Toyota", atStr, atStr, id.Bytes()},
+		},
+		{
+			name:      "make ascending backward",
+			sort:      pagetoken.Sort{Column: "make"},
+			cursor:    pagetoken.Cursor{At: at, ID: id.String(), Key: "Toyota", Backward: true},
+			wantQuery: "v.make < ? OR (v.make = ? AND (v.created_at < ? OR (v.created_at = ? AND v.external_id < ?))))\nORDER BY v.make DESC, v.created_at DESC, v.external_id DESC",
+			wantArgs:  []driver.Value{atStr, "Toyota", "Toyota", atStr, atStr, id.Bytes()},
+		},
+		{
+			name:      "seating capacity first page",
+			sort:      pagetoken.Sort{Column: "seating_capacity", Descending: true},
+			wantQuery: "ORDER BY v.seating_capacity DESC, v.created_at DESC, v.external_id DESC",
+			wantArgs:  []driver.Value{"", int64(0), int64(0), "", "", uuid.Nil.Bytes()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockStore(t)
+
+			token := ""
+			if !tt.cursor.IsZero() {
+				token = pagetoken.Encode(tt.sort, tt.cursor)
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+				WithArgs(noFilters...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			args := append(append(append([]driver.Value{}, noFilters...), tt.wantArgs...), int64(11))
+			mock.ExpectQuery(regexp.QuoteMeta(tt.wantQuery)).
+				WithArgs(args...).
+				WillReturnRows(sqlmock.NewRows(vehicleColumns))
+			mock.ExpectRollback()
+
+			_, _, _, _, err := s.ListVehicles(context.Background(), types.ListVehiclesParams{
+				PageSize:  10,
+				PageToken: token,
+				Sort:      tt.sort,
+			})
+			if err != nil {
+				t.Fatalf("ListVehicles: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestListVehicleTypesQueryArgs(t *testing.T) {
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	atStr := at.Format(time.RFC3339Nano)
+
+	tests := []struct {
+		name     string
+		cursor   pagetoken.Cursor
+		wantArgs []driver.Value
+	}{
+		{name: "first page", wantArgs: []driver.Value{"", "", "", int64(0), int64(11)}},
+		{name: "with cursor", cursor: pagetoken.Cursor{At: at, ID: "42"}, wantArgs: []driver.Value{atStr, atStr, atStr, int64(42), int64(11)}},
+		{name: "token issued without an ID", cursor: pagetoken.Cursor{At: at}, wantArgs: []driver.Value{atStr, atStr, atStr, int64(0), int64(11)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockStore(t)
+
+			token := ""
+			if !tt.cursor.IsZero() {
+				token = pagetoken.Encode(pagetoken.CreatedAtDesc, tt.cursor)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta("FROM vehicle_types")).
+				WithArgs(tt.wantArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at"}))
+
+			if _, _, err := s.ListVehicleTypes(context.Background(), 10, token); err != nil {
+				t.Fatalf("ListVehicleTypes: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestListVehiclesPageSizeBackstop(t *testing.T) {
+	tests := []struct {
+		requested int32
+		wantLimit int64
+	}{
+		{requested: 0, wantLimit: int64(pagesize.Default()) + 1},
+		{requested: 10, wantLimit: 11},
+		{requested: 1_000_000, wantLimit: int64(pagesize.InternalMax()) + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.requested), func(t *testing.T) {
+			s, mock := newMockStore(t)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			args := make([]driver.Value, 0, 15)
+			for range 14 {
+				args = append(args, sqlmock.AnyArg())
+			}
+			mock.ExpectQuery(regexp.QuoteMeta("LIMIT ?")).
+				WithArgs(append(args, tt.wantLimit)...).
+				WillReturnRows(sqlmock.NewRows(vehicleColumns))
+			mock.ExpectRollback()
+
+			if _, _, _, _, err := s.ListVehicles(context.Background(), types.ListVehiclesParams{PageSize: tt.requested}); err != nil {
+				t.Fatalf("ListVehicles: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	tests := []struct {
+		name     string
+		closeErr error
+	}{
+		{name: "closes the database"},
+		{name: "returns the close error", closeErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to open sqlmock: %v", err)
+			}
+			s := NewStoreWithDialect(db, MySQL)
+
+			mock.ExpectClose().WillReturnError(tt.closeErr)
+
+			if err := s.Close(); !errors.Is(err, tt.closeErr) {
+				t.Errorf("Close = %v, want %v", err, tt.closeErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGetVehicleByIDUnknownStatus(t *testing.T) {
+	s, mock := newMockStore(t)
+	id := uuid.Must(uuid.FromString("8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90"))
+	now := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	// A status written by some other build, with no name in this one
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE v.external_id = ?")).
+		WithArgs(id.Bytes()).
+		WillReturnRows(sqlmock.NewRows(vehicleColumns).AddRow(
+			"8f0c5b9e2a514c1f9a3e6f2d1b7c4e90", "1", "matatu", "KDA123A", "Toyota", "Hiace", 2019,
+			"White", 14, "DIESEL", nil, nil, nil,
+			nil, "SCRAPPED", now, now, nil, nil,
+			nil, nil,
+		))
+
+	// The vehicle still loads, so an admin can recover it
+	vehicle, err := s.GetVehicleByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetVehicleByID: %v", err)
+	}
+	if vehicle.Status != genproto.VehicleStatus_STATUS_UNSPECIFIED {
+		t.Errorf("status = %s, want STATUS_UNSPECIFIED", vehicle.Status)
+	}
+}
+
+func TestEnsureVehicleTypeConcurrent(t *testing.T) {
+	const replicas = 4
+	s, mock := newMockStore(t)
+	mock.MatchExpectationsInOrder(false)
+
+	// Replicas starting together all run the insert for every type. The unique key on
+	// name lets one of them create it; for the others the no-op update affects no rows.
+	for _, stdType := range types.StandardVehicleTypes {
+		for i := range replicas {
+			var rowsAffected int64
+			if i == 0 {
+				rowsAffected = 1
+			}
+			mock.ExpectExec(regexp.QuoteMeta(render(MySQL, ensureVehicleTypeQuery))).
+				WithArgs(stdType.Name, stdType.Description, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		}
+	}
+
+	var mu sync.Mutex
+	created := make(map[string]int)
+	errs := make(chan error, replicas)
+	var wg sync.WaitGroup
+	for range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, stdType := range types.StandardVehicleTypes {
+				ok, err := s.EnsureVehicleType(context.Background(), stdType.Name, stdType.Description)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if ok {
+					mu.Lock()
+					created[stdType.Name]++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("EnsureVehicleType: %v", err)
+	}
+	for _, stdType := range types.StandardVehicleTypes {
+		if created[stdType.Name] != 1 {
+			t.Errorf("%s reported created %d times, want once", stdType.Name, created[stdType.Name])
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFlagActiveAssignmentByDriver(t *testing.T) {
+	driverID := uuid.Must(uuid.FromString("3d6f0a8e-4b2c-4e1d-9f7a-5c8b2e0d1a6f"))
+	assignedAt := time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC)
+	flaggedAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	const reason = "driver SUSPENDED while holding the vehicle"
+
+	t.Run("flagged", func(t *testing.T) {
+		s, mock := newMockStore(t)
+		mock.ExpectExec(regexp.QuoteMeta("SET attention_reason = ?, flagged_at = ? WHERE driver_id = ? AND ended_at IS NULL")).
+			WithArgs(reason, sqlmock.AnyArg(), driverID.Bytes()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta("WHERE driver_id = ? AND ended_at IS NULL")).
+			WithArgs(driverID.Bytes()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "vehicle_id", "driver_id", "assigned_by", "assigned_at", "attention_reason", "flagged_at"}).
+				AddRow("7", "8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90", driverID.String(), "dispatcher-1", assignedAt, reason, flaggedAt))
+
+		assignment, err := s.FlagActiveAssignmentByDriver(context.Background(), driverID, reason)
+		if err != nil {
+			t.Fatalf("FlagActiveAssignmentByDriver: %v", err)
+		}
+		if assignment.Id != "7" || assignment.AttentionReason != reason || !assignment.FlaggedAt.AsTime().Equal(flaggedAt) {
+			t.Errorf("assignment = %v, want assignment 7 flagged at %s", assignment, flaggedAt)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("no open assignment", func(t *testing.T) {
+		s, mock := newMockStore(t)
+		mock.ExpectExec(regexp.QuoteMeta("SET attention_reason = ?")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		if _, err := s.FlagActiveAssignmentByDriver(context.Background(), driverID, reason); !errors.Is(err, types.ErrAssignmentNotFound) {
+			t.Errorf("err = %v, want ErrAssignmentNotFound", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestUpdateVehicleStatusWritesHistory(t *testing.T) {
+	id := uuid.Must(uuid.FromString("8f0c5b9e-2a51-4c1f-9a3e-6f2d1b7c4e90"))
+	now := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	const reason = "brake pads due for replacement"
+
+	tests := []struct {
+		name       string
+		historyErr error
+	}{
+		{name: "history written"},
+		// Without its history row the status change is rolled back
+		{name: "history insert fails", historyErr: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newMockStore(t)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT status FROM vehicles WHERE external_id = ? FOR UPDATE")).
+				WithArgs(id.Bytes()).
+				WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ACTIVE"))
+			mock.ExpectExec(regexp.QuoteMeta("SET status = ?, updated_at = ?, updated_by = ?")).
+				WithArgs("MAINTENANCE", sqlmock.AnyArg(), "user-1", id.Bytes()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			history := mock.ExpectExec(regexp.QuoteMeta("INSERT INTO vehicle_status_history")).
+				WithArgs(id.Bytes(), "ACTIVE", "MAINTENANCE", reason, "user-1", sqlmock.AnyArg())
+			if tt.historyErr != nil {
+				history.WillReturnError(tt.historyErr)
+				mock.ExpectRollback()
+			} else {
+				history.WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectQuery(regexp.QuoteMeta("WHERE v.external_id = ?")).
+					WithArgs(id.Bytes()).
+					WillReturnRows(sqlmock.NewRows(vehicleColumns).AddRow(
+						"8f0c5b9e2a514c1f9a3e6f2d1b7c4e90", "1", "matatu", "KDA123A", "Toyota", "Hiace", 2019,
+						"White", 14, "DIESEL", nil, nil, nil,
+						nil, "MAINTENANCE", now, now, nil, nil,
+						nil, nil,
+					))
+			}
+
+			vehicle, err := s.UpdateVehicleStatus(context.Background(), id, genproto.VehicleStatus_MAINTENANCE, reason, "user-1")
+			if tt.historyErr != nil {
+				if !errors.Is(err, tt.historyErr) {
+					t.Errorf("err = %v, want %v", err, tt.historyErr)
+				}
+			} else if err != nil {
+				t.Fatalf("UpdateVehicleStatus: %v", err)
+			} else if vehicle.Status != genproto.VehicleStatus_MAINTENANCE {
+				t.Errorf("status = %s, want MAINTENANCE", vehicle.Status)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
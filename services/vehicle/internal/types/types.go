@@ -4,7 +4,13 @@ package types
 import (
 	"context"
 	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/adammwaniki/bebabeba/services/common/idempotency"
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
 	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
 	"github.com/gofrs/uuid/v5"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -15,6 +21,8 @@ type VehicleService interface {
 	// Vehicle CRUD operations
 	CreateVehicle(ctx context.Context, req *genproto.CreateVehicleRequest) (*genproto.CreateVehicleResponse, error)
 	GetVehicle(ctx context.Context, req *genproto.GetVehicleRequest) (*genproto.GetVehicleResponse, error)
+	BatchGetVehicles(ctx context.Context, req *genproto.BatchGetVehiclesRequest) (*genproto.BatchGetVehiclesResponse, error)
+	GetVehicleByChassisNumber(ctx context.Context, req *genproto.GetVehicleByChassisNumberRequest) (*genproto.GetVehicleResponse, error)
 	ListVehicles(ctx context.Context, req *genproto.ListVehiclesRequest) (*genproto.ListVehiclesResponse, error)
 	UpdateVehicle(ctx context.Context, req *genproto.UpdateVehicleRequest) (*genproto.UpdateVehicleResponse, error)
 	DeleteVehicle(ctx context.Context, req *genproto.DeleteVehicleRequest) error
@@ -22,11 +30,36 @@ type VehicleService interface {
 	// Specialized queries
 	GetVehiclesByType(ctx context.Context, req *genproto.GetVehiclesByTypeRequest) (*genproto.ListVehiclesResponse, error)
 	GetAvailableVehicles(ctx context.Context, req *genproto.GetAvailableVehiclesRequest) (*genproto.ListVehiclesResponse, error)
+	GetDispatchCandidates(ctx context.Context, req *genproto.GetDispatchCandidatesRequest) (*genproto.ListVehiclesResponse, error)
+	ListRecentlyUpdatedVehicles(ctx context.Context, req *genproto.ListRecentlyUpdatedVehiclesRequest) (*genproto.ListVehiclesResponse, error)
 	UpdateVehicleStatus(ctx context.Context, req *genproto.UpdateVehicleStatusRequest) (*genproto.UpdateVehicleStatusResponse, error)
+	RecordVehicleMileage(ctx context.Context, req *genproto.RecordVehicleMileageRequest) (*genproto.RecordVehicleMileageResponse, error)
+	RecordService(ctx context.Context, req *genproto.RecordServiceRequest) (*genproto.RecordServiceResponse, error)
+	GetVehiclesDueForMaintenance(ctx context.Context, req *genproto.GetVehiclesDueForMaintenanceRequest) (*genproto.GetVehiclesDueForMaintenanceResponse, error)
+	ValidateVehicleStatusChange(ctx context.Context, req *genproto.ValidateVehicleStatusChangeRequest) (*genproto.ValidateVehicleStatusChangeResponse, error)
+	GetVehicleStatusHistory(ctx context.Context, req *genproto.GetVehicleStatusHistoryRequest) (*genproto.GetVehicleStatusHistoryResponse, error)
+
+	// Driver assignment
+	AssignVehicle(ctx context.Context, req *genproto.AssignVehicleRequest) (*genproto.AssignVehicleResponse, error)
+	GetDriverAssignment(ctx context.Context, req *genproto.GetDriverAssignmentRequest) (*genproto.GetDriverAssignmentResponse, error)
+	FlagDriverAssignment(ctx context.Context, req *genproto.FlagDriverAssignmentRequest) (*genproto.FlagDriverAssignmentResponse, error)
+
+	// Reporting
+	GetFleetUtilization(ctx context.Context, req *genproto.GetFleetUtilizationRequest) (*genproto.GetFleetUtilizationResponse, error)
+
+	// Format checks
+	ValidateLicensePlate(ctx context.Context, req *genproto.ValidateLicensePlateRequest) (*genproto.FieldValidationResponse, error)
+
+	// Maintenance
+	NormalizeLegacyRecords(ctx context.Context, req *genproto.NormalizeLegacyRecordsRequest) (*genproto.NormalizeLegacyRecordsResponse, error)
 
 	// Vehicle type management
 	CreateVehicleType(ctx context.Context, req *genproto.CreateVehicleTypeRequest) (*genproto.CreateVehicleTypeResponse, error)
 	ListVehicleTypes(ctx context.Context, req *genproto.ListVehicleTypesRequest) (*genproto.ListVehicleTypesResponse, error)
+	GetVehicleType(ctx context.Context, req *genproto.GetVehicleTypeRequest) (*genproto.GetVehicleTypeResponse, error)
+	GetVehicleTypeByName(ctx context.Context, req *genproto.GetVehicleTypeByNameRequest) (*genproto.GetVehicleTypeResponse, error)
+	UpdateVehicleType(ctx context.Context, req *genproto.UpdateVehicleTypeRequest) (*genproto.UpdateVehicleTypeResponse, error)
+	DeleteVehicleType(ctx context.Context, req *genproto.DeleteVehicleTypeRequest) error
 }
 
 // Data store interface
@@ -34,21 +67,54 @@ type VehicleStore interface {
 	// Vehicle CRUD
 	CreateVehicle(ctx context.Context, internalID uint64, externalID uuid.UUID, vehicle *VehicleData) error
 	GetVehicleByID(ctx context.Context, externalID uuid.UUID) (*genproto.Vehicle, error)
+	GetVehiclesByIDs(ctx context.Context, externalIDs []uuid.UUID) (map[string]*genproto.Vehicle, error)
 	GetVehicleByLicensePlate(ctx context.Context, licensePlate string) (*genproto.Vehicle, error)
-	ListVehicles(ctx context.Context, params ListVehiclesParams) ([]*genproto.Vehicle, string, error)
-	UpdateVehicle(ctx context.Context, externalID uuid.UUID, updates VehicleUpdateFields, updateMask *fieldmaskpb.FieldMask) (*genproto.Vehicle, error)
-	DeleteVehicle(ctx context.Context, externalID uuid.UUID) error
+	GetVehicleByChassisNumber(ctx context.Context, chassisNumber string) (*genproto.Vehicle, error)
+	// Listings return a page, the next page token, and the number of rows matching the filters across all pages
+	ListVehicles(ctx context.Context, params ListVehiclesParams) (vehicles []*genproto.Vehicle, nextPageToken, prevPageToken string, total int32, err error)
+	UpdateVehicle(ctx context.Context, externalID uuid.UUID, updates VehicleUpdateFields, updateMask *fieldmaskpb.FieldMask, actorID string) (*genproto.Vehicle, error)
+	DeleteVehicle(ctx context.Context, externalID uuid.UUID, actorID string) error
 
 	// Specialized queries
-	GetVehiclesByType(ctx context.Context, vehicleTypeID string, params ListVehiclesParams) ([]*genproto.Vehicle, string, error)
-	GetAvailableVehicles(ctx context.Context, vehicleTypeID *string, params ListVehiclesParams) ([]*genproto.Vehicle, string, error)
-	UpdateVehicleStatus(ctx context.Context, externalID uuid.UUID, status genproto.VehicleStatus) (*genproto.Vehicle, error)
+	GetVehiclesByType(ctx context.Context, vehicleTypeID string, params ListVehiclesParams) (vehicles []*genproto.Vehicle, nextPageToken, prevPageToken string, total int32, err error)
+	GetAvailableVehicles(ctx context.Context, vehicleTypeID *string, params ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error)
+	GetDispatchCandidates(ctx context.Context, filter DispatchFilter, params ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error)
+	ListRecentlyUpdatedVehicles(ctx context.Context, params ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error)
+	UpdateVehicleStatus(ctx context.Context, externalID uuid.UUID, status genproto.VehicleStatus, reason, actorID string) (*genproto.Vehicle, error)
+	RecordVehicleMileage(ctx context.Context, externalID uuid.UUID, mileageKm int32, actorID string) (*genproto.Vehicle, error)
+	RecordService(ctx context.Context, externalID uuid.UUID, record ServiceRecord, actorID string) (*genproto.Vehicle, error)
+	GetVehiclesDueForMaintenance(ctx context.Context, kmThreshold int32, servicedBefore time.Time, params ListVehiclesParams) ([]*genproto.Vehicle, string, int32, error)
+	ListVehicleStatusHistory(ctx context.Context, externalID uuid.UUID, pageSize int32, pageToken string) ([]*genproto.VehicleStatusHistoryEntry, string, error)
+	GetActivePlateTombstone(ctx context.Context, licensePlate string) (*PlateTombstone, error)
+
+	// Driver assignment
+	CreateAssignment(ctx context.Context, vehicleID, driverID uuid.UUID, actorID string) (*genproto.VehicleAssignment, error)
+	GetActiveAssignmentByVehicle(ctx context.Context, vehicleID uuid.UUID) (*genproto.VehicleAssignment, error)
+	GetActiveAssignmentByDriver(ctx context.Context, driverID uuid.UUID) (*genproto.VehicleAssignment, error)
+	FlagActiveAssignmentByDriver(ctx context.Context, driverID uuid.UUID, reason string) (*genproto.VehicleAssignment, error)
+
+	// Reporting
+	GetFleetStatusTimeline(ctx context.Context, since, until time.Time) ([]FleetVehicle, []VehicleStatusChange, error)
+
+	// Idempotency
+	ClaimIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID, ttl time.Duration) (uuid.UUID, error)
+	ReleaseIdempotencyKey(ctx context.Context, key idempotency.Key, externalID uuid.UUID) error
+
+	// Maintenance
+	ListNormalizableVehicles(ctx context.Context, afterInternalID uint64, limit int32) ([]NormalizableVehicle, error)
+	ApplyVehicleNormalization(ctx context.Context, vehicle NormalizableVehicle, changes []FieldChange, actorID string) error
 
 	// Vehicle type management
 	CreateVehicleType(ctx context.Context, name, description string) (*genproto.VehicleType, error)
+	EnsureVehicleType(ctx context.Context, name, description string) (bool, error)
 	GetVehicleTypeByID(ctx context.Context, typeID string) (*genproto.VehicleType, error)
 	GetVehicleTypeByName(ctx context.Context, name string) (*genproto.VehicleType, error)
 	ListVehicleTypes(ctx context.Context, pageSize int32, pageToken string) ([]*genproto.VehicleType, string, error)
+	UpdateVehicleType(ctx context.Context, typeID string, name, description *string) (*genproto.VehicleType, error)
+	DeleteVehicleType(ctx context.Context, typeID string) error
+
+	// Close releases the database connections on shutdown
+	Close() error
 }
 
 // VehicleData represents the data needed to create a vehicle
@@ -67,7 +133,9 @@ type VehicleData struct {
 	InsuranceExpiry  *string // ISO date string, optional
 }
 
-// VehicleUpdateFields represents fields that can be updated
+// VehicleUpdateFields represents fields that can be updated.
+// When an update mask is supplied, a nil pointer on a masked optional field
+// clears the column rather than leaving it untouched.
 type VehicleUpdateFields struct {
 	VehicleTypeID    *string
 	LicensePlate     *string
@@ -90,15 +158,135 @@ type ListVehiclesParams struct {
 	StatusFilter     *genproto.VehicleStatus
 	VehicleTypeFilter *string
 	MakeFilter       *string
+	MakeMatch        genproto.MakeMatch
+	SearchFilter     *string        // matched anywhere in make, model or license plate
+	Sort             pagetoken.Sort // zero means created_at descending
+}
+
+// DispatchFilter holds the predicates dispatch applies when picking a vehicle
+type DispatchFilter struct {
+	VehicleTypeID    *string
+	MinSeats         int32
+	InsuranceValidOn time.Time
+}
+
+// FleetVehicle is the part of a vehicle that utilization reporting needs
+type FleetVehicle struct {
+	ID        string
+	Status    genproto.VehicleStatus // current status
+	CreatedAt time.Time
+}
+
+// VehicleStatusChange is one row of vehicle_status_history
+type VehicleStatusChange struct {
+	VehicleID      string
+	PreviousStatus genproto.VehicleStatus
+	NewStatus      genproto.VehicleStatus
+	ChangedAt      time.Time
+}
+
+// PlateTombstone holds a retired vehicle's license plate back from reuse until ExpiresAt
+type PlateTombstone struct {
+	LicensePlate string
+	VehicleID    string // the retired vehicle that last held the plate
+	RetiredAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// DefaultPlateCooldown is how long a retired plate stays tombstoned when
+// LICENSE_PLATE_COOLDOWN_DAYS is not set
+const DefaultPlateCooldown = 90 * 24 * time.Hour
+
+// PlateCooldownFromEnv reads the retired plate cooldown in days from LICENSE_PLATE_COOLDOWN_DAYS,
+// falling back to the default for unset or invalid values. 0 turns tombstoning off.
+func PlateCooldownFromEnv() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("LICENSE_PLATE_COOLDOWN_DAYS")); err == nil && n >= 0 {
+		return time.Duration(n) * 24 * time.Hour
+	}
+	return DefaultPlateCooldown
+}
+
+// ServiceRecord is one service of a vehicle
+type ServiceRecord struct {
+	// MileageKm is the odometer reading at the service. Nil means the vehicle's current
+	// mileage, which is itself unset until a reading has been recorded.
+	MileageKm  *int32
+	ServicedAt time.Time
+	Notes      string
 }
 
+// MaintenanceInterval is how far or how long a vehicle may go between services before
+// it is due for maintenance
+type MaintenanceInterval struct {
+	Km   int32
+	Days int32
+}
+
+// DefaultMaintenanceInterval is used for any part of the interval not configured in the
+// environment
+var DefaultMaintenanceInterval = MaintenanceInterval{Km: 10000, Days: 180}
+
+// MaintenanceIntervalFromEnv reads the interval from MAINTENANCE_INTERVAL_KM and
+// MAINTENANCE_INTERVAL_DAYS, falling back to the default for unset or invalid values
+func MaintenanceIntervalFromEnv() MaintenanceInterval {
+	interval := DefaultMaintenanceInterval
+	if n, err := strconv.ParseInt(os.Getenv("MAINTENANCE_INTERVAL_KM"), 10, 32); err == nil && n > 0 {
+		interval.Km = int32(n)
+	}
+	if n, err := strconv.ParseInt(os.Getenv("MAINTENANCE_INTERVAL_DAYS"), 10, 32); err == nil && n > 0 {
+		interval.Days = int32(n)
+	}
+	return interval
+}
+
+// NormalizableVehicle holds the stored values of the vehicle fields that normalization rewrites
+type NormalizableVehicle struct {
+	InternalID    uint64
+	ExternalID    string
+	LicensePlate  string
+	Make          string
+	Model         string
+	Color         string
+	EngineNumber  string
+	ChassisNumber string
+}
+
+// FieldChange is one field NormalizeLegacyRecords rewrote, as recorded in the audit table
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// Legacy record normalization runs in batches of DefaultNormalizeBatchSize rows unless the
+// caller asks otherwise, and reports at most MaxReportedNormalizations changed records
+const (
+	DefaultNormalizeBatchSize = 200
+	MaxNormalizeBatchSize     = 1000
+	MaxReportedNormalizations = 500
+)
+
+// MaxBatchGetVehicles caps how many vehicles a single BatchGetVehicles call may look up
+const MaxBatchGetVehicles = 100
+
+// MaxUtilizationBuckets bounds a single GetFleetUtilization call, e.g. a year of daily buckets
+const MaxUtilizationBuckets = 366
+
 // Error types
 var (
 	ErrVehicleNotFound     = errors.New("vehicle not found")
 	ErrDuplicateEntry      = errors.New("duplicate entry")
 	ErrVehicleTypeNotFound = errors.New("vehicle type not found")
+	ErrVehicleTypeInUse    = errors.New("vehicle type is used by vehicles")
 	ErrInvalidStatus       = errors.New("invalid status transition")
 	ErrVehicleInUse        = errors.New("vehicle is currently in use")
+	ErrTombstoneNotFound   = errors.New("license plate tombstone not found")
+	ErrVehicleNotActive    = errors.New("vehicle is not active")
+	ErrAssignmentNotFound  = errors.New("vehicle assignment not found")
+	ErrStaleRecord         = errors.New("record changed since it was read")
+	ErrMileageDecreased    = errors.New("mileage is lower than the last reading")
+	ErrServiceOutOfOrder   = errors.New("service is older than the last recorded service")
+	ErrServiceKmDecreased  = errors.New("service mileage is lower than the last service's")
 )
 
 // Vehicle status transition rules
@@ -121,6 +309,28 @@ var ValidStatusTransitions = map[genproto.VehicleStatus][]genproto.VehicleStatus
 	},
 }
 
+// RecoveryStatuses are the states a vehicle with an unrecognized status may be
+// moved to under an admin override
+var RecoveryStatuses = []genproto.VehicleStatus{
+	genproto.VehicleStatus_ACTIVE,
+	genproto.VehicleStatus_MAINTENANCE,
+}
+
+// IsKnownStatus reports whether the status takes part in the transition rules
+func IsKnownStatus(status genproto.VehicleStatus) bool {
+	_, exists := ValidStatusTransitions[status]
+	return exists
+}
+
+// IsValidRecoveryTransition checks if a vehicle stuck in an unrecognized status
+// may be moved to the target. Known statuses must go through IsValidStatusTransition.
+func IsValidRecoveryTransition(from, to genproto.VehicleStatus) bool {
+	if IsKnownStatus(from) {
+		return false
+	}
+	return slices.Contains(RecoveryStatuses, to)
+}
+
 // IsValidStatusTransition checks if a status transition is allowed
 func IsValidStatusTransition(from, to genproto.VehicleStatus) bool {
 	allowedTransitions, exists := ValidStatusTransitions[from]
@@ -0,0 +1,67 @@
+// services/vehicle/internal/types/types_test.go
+package types
+
+import (
+	"testing"
+
+	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+)
+
+func TestStatusTransitions(t *testing.T) {
+	const (
+		unspecified = genproto.VehicleStatus_STATUS_UNSPECIFIED
+		active      = genproto.VehicleStatus_ACTIVE
+		assigned    = genproto.VehicleStatus_ASSIGNED
+		maintenance = genproto.VehicleStatus_MAINTENANCE
+		retired     = genproto.VehicleStatus_RETIRED
+	)
+	// A value written by a newer or older build, that this one has no name for
+	unknown := genproto.VehicleStatus(99)
+
+	tests := []struct {
+		name         string
+		from, to     genproto.VehicleStatus
+		wantValid    bool
+		wantRecovery bool
+	}{
+		{name: "active to maintenance", from: active, to: maintenance, wantValid: true},
+		{name: "maintenance to active", from: maintenance, to: active, wantValid: true},
+		{name: "assigned to retired", from: assigned, to: retired},
+		{name: "retired to active", from: retired, to: active},
+
+		// Unknown statuses never pass the normal rules, and only recover to the listed states
+		{name: "unspecified to active", from: unspecified, to: active, wantRecovery: true},
+		{name: "unspecified to maintenance", from: unspecified, to: maintenance, wantRecovery: true},
+		{name: "unspecified to assigned", from: unspecified, to: assigned},
+		{name: "unspecified to retired", from: unspecified, to: retired},
+		{name: "unknown to active", from: unknown, to: active, wantRecovery: true},
+		{name: "unknown to retired", from: unknown, to: retired},
+
+		// Recovery is no way around the rules for a known status
+		{name: "retired recovery to active", from: retired, to: active},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidStatusTransition(tt.from, tt.to); got != tt.wantValid {
+				t.Errorf("IsValidStatusTransition = %t, want %t", got, tt.wantValid)
+			}
+			if got := IsValidRecoveryTransition(tt.from, tt.to); got != tt.wantRecovery {
+				t.Errorf("IsValidRecoveryTransition = %t, want %t", got, tt.wantRecovery)
+			}
+		})
+	}
+}
+
+func TestIsKnownStatus(t *testing.T) {
+	for status := range genproto.VehicleStatus_name {
+		s := genproto.VehicleStatus(status)
+		want := s != genproto.VehicleStatus_STATUS_UNSPECIFIED
+		if got := IsKnownStatus(s); got != want {
+			t.Errorf("IsKnownStatus(%s) = %t, want %t", s, got, want)
+		}
+	}
+	if IsKnownStatus(genproto.VehicleStatus(99)) {
+		t.Error("IsKnownStatus(99) = true, want false")
+	}
+}
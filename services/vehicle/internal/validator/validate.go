@@ -4,12 +4,18 @@ package validator
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/adammwaniki/bebabeba/services/common/pagetoken"
+	"github.com/adammwaniki/bebabeba/services/common/utils"
 	"github.com/adammwaniki/bebabeba/services/vehicle/proto/genproto"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ValidationError struct {
@@ -21,6 +27,11 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// FieldViolation reports the rejected field, so the error reaches clients as a structured violation
+func (e ValidationError) FieldViolation() (string, string) {
+	return e.Field, e.Message
+}
+
 // Kenyan license plate patterns
 var (
 	// Standard format: KAA 123A or KAA 123AB
@@ -59,6 +70,11 @@ func ValidateLicensePlate(field, licensePlate string) error {
 	}
 }
 
+// NormalizeChassisNumber trims and uppercases a chassis number, the form it is stored in
+func NormalizeChassisNumber(chassisNumber string) string {
+	return strings.ToUpper(strings.TrimSpace(chassisNumber))
+}
+
 // NormalizeLicensePlate standardizes license plate format
 func NormalizeLicensePlate(licensePlate string) string {
 	// Convert to uppercase and normalize spacing
@@ -105,6 +121,72 @@ func ValidateVehicleMake(field, make string) error {
 	return nil
 }
 
+// ValidateSearchTerm checks a free-text filter against the configured length limits.
+// An empty term means no filter and is always accepted.
+func ValidateSearchTerm(field, term string, limits utils.SearchTermLimits) error {
+	length := utf8.RuneCountInString(strings.TrimSpace(term))
+	if length == 0 {
+		return nil
+	}
+
+	if length < limits.MinLength {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at least %d characters", limits.MinLength),
+		}
+	}
+
+	if length > limits.MaxLength {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at most %d characters", limits.MaxLength),
+		}
+	}
+
+	return nil
+}
+
+// SortableVehicleColumns are the columns ListVehicles can sort by with sort_by:
+//
+//	created_at        when the vehicle was added (the default)
+//	year              manufacturing year
+//	make              manufacturer name, in the database collation's order
+//	seating_capacity  passenger capacity
+//
+// The store maps each name to its own SQL, so nothing from the request is ever
+// written into a query. Ties are broken by created_at, then vehicle ID.
+var SortableVehicleColumns = []string{"created_at", "year", "make", "seating_capacity"}
+
+// ValidateVehicleSort checks sort_by and sort_order against SortableVehicleColumns and
+// returns the order they pick. An empty sort_by means created_at and an empty
+// sort_order means descending.
+func ValidateVehicleSort(sortBy, sortOrder string) (pagetoken.Sort, error) {
+	sort := pagetoken.Sort{Column: "created_at", Descending: true}
+
+	if sortBy != "" {
+		if !slices.Contains(SortableVehicleColumns, sortBy) {
+			return pagetoken.Sort{}, ValidationError{
+				Field:   "sort_by",
+				Message: fmt.Sprintf("must be one of %s", strings.Join(SortableVehicleColumns, ", ")),
+			}
+		}
+		sort.Column = sortBy
+	}
+
+	switch strings.ToLower(sortOrder) {
+	case "", "desc":
+	case "asc":
+		sort.Descending = false
+	default:
+		return pagetoken.Sort{}, ValidationError{
+			Field:   "sort_order",
+			Message: "must be asc or desc",
+		}
+	}
+
+	return sort, nil
+}
+
 // ValidateVehicleModel validates vehicle model
 func ValidateVehicleModel(field, model string) error {
 	model = strings.TrimSpace(model)
@@ -164,6 +246,58 @@ func ValidateSeatingCapacity(field string, capacity int32) error {
 	return nil
 }
 
+// maxMileageKm is far beyond any real odometer, to catch readings with extra digits
+const maxMileageKm = 10_000_000
+
+// ValidateMileage validates an odometer reading in kilometres
+func ValidateMileage(field string, mileageKm int32) error {
+	if mileageKm < 0 || mileageKm > maxMileageKm {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be between 0 and %d", maxMileageKm),
+		}
+	}
+
+	return nil
+}
+
+// maxServiceNotesLength bounds the free-text notes kept with a service record
+const maxServiceNotesLength = 1000
+
+// ValidateServiceNotes validates the notes recorded with a service
+func ValidateServiceNotes(field, notes string) error {
+	if utf8.RuneCountInString(notes) > maxServiceNotesLength {
+		return ValidationError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at most %d characters", maxServiceNotesLength),
+		}
+	}
+
+	return nil
+}
+
+// maxMaintenanceIntervalDays bounds a maintenance interval given in days
+const maxMaintenanceIntervalDays = 3650
+
+// ValidateMaintenanceThresholds validates maintenance interval overrides; unset ones
+// are left to the configured interval
+func ValidateMaintenanceThresholds(kmThreshold, daysThreshold *int32) error {
+	if kmThreshold != nil && (*kmThreshold < 1 || *kmThreshold > maxMileageKm) {
+		return ValidationError{
+			Field:   "km_threshold",
+			Message: fmt.Sprintf("must be between 1 and %d", maxMileageKm),
+		}
+	}
+	if daysThreshold != nil && (*daysThreshold < 1 || *daysThreshold > maxMaintenanceIntervalDays) {
+		return ValidationError{
+			Field:   "days_threshold",
+			Message: fmt.Sprintf("must be between 1 and %d", maxMaintenanceIntervalDays),
+		}
+	}
+
+	return nil
+}
+
 // ValidateColor validates vehicle color
 func ValidateColor(field, color string) error {
 	color = strings.TrimSpace(color)
@@ -330,7 +464,7 @@ func NormalizeVehicleFields(input *genproto.VehicleInput) {
 	input.Model = strings.TrimSpace(input.Model)
 	input.Color = strings.TrimSpace(input.Color)
 	input.EngineNumber = strings.ToUpper(strings.TrimSpace(input.EngineNumber))
-	input.ChassisNumber = strings.ToUpper(strings.TrimSpace(input.ChassisNumber))
+	input.ChassisNumber = NormalizeChassisNumber(input.ChassisNumber)
 	input.VehicleTypeId = strings.TrimSpace(input.VehicleTypeId)
 }
 
@@ -400,6 +534,13 @@ func ValidateCreateVehicleRequest(req *genproto.CreateVehicleRequest) error {
 	}
 
 	// Validate dates if provided
+	if err := ValidateOptionalTimestamp("registration_date", vehicle.RegistrationDate); err != nil {
+		return err
+	}
+	if err := ValidateOptionalTimestamp("insurance_expiry", vehicle.InsuranceExpiry); err != nil {
+		return err
+	}
+
 	if vehicle.RegistrationDate != nil {
 		regDate := vehicle.RegistrationDate.AsTime()
 		now := time.Now()
@@ -437,96 +578,135 @@ func ValidateCreateVehicleRequest(req *genproto.CreateVehicleRequest) error {
 	return nil
 }
 
-// ValidateUpdateVehicleRequest validates vehicle update request
-func ValidateUpdateVehicleRequest(req *genproto.UpdateVehicleRequest) error {
+// ValidateUpdateVehicleRequest validates vehicle update request. It also returns a warning
+// for every submitted value that normalization changed, for the update response.
+func ValidateUpdateVehicleRequest(req *genproto.UpdateVehicleRequest) ([]*genproto.NormalizationWarning, error) {
 	if req == nil {
-		return ValidationError{Field: "request", Message: "cannot be nil"}
+		return nil, ValidationError{Field: "request", Message: "cannot be nil"}
 	}
 
 	if req.VehicleId == "" {
-		return ValidationError{Field: "vehicle_id", Message: "cannot be empty"}
+		return nil, ValidationError{Field: "vehicle_id", Message: "cannot be empty"}
 	}
 
 	if req.Vehicle == nil {
-		return ValidationError{Field: "vehicle", Message: "cannot be nil"}
+		return nil, ValidationError{Field: "vehicle", Message: "cannot be nil"}
 	}
 
-	// Normalize fields first
+	// Normalize fields first, keeping what the client sent for the warnings
+	submitted := proto.Clone(req.Vehicle).(*genproto.VehicleInput)
 	NormalizeVehicleFields(req.Vehicle)
 
 	vehicle := req.Vehicle
 
 	// If update mask is provided, only validate specified fields
 	if req.UpdateMask != nil {
-		return validateMaskedFields(vehicle, req.UpdateMask)
+		if err := validateMaskedFields(vehicle, req.UpdateMask); err != nil {
+			return nil, err
+		}
+	} else if err := validateAllProvidedFields(vehicle); err != nil {
+		// If no mask, validate all non-empty fields
+		return nil, err
 	}
 
-	// If no mask, validate all non-empty fields
-	return validateAllProvidedFields(vehicle)
+	return vehicleNormalizationWarnings(submitted, vehicle, req.UpdateMask), nil
+}
+
+// vehicleNormalizationWarnings compares the text fields NormalizeVehicleFields rewrites.
+// Empty values and fields outside the update mask are skipped since nothing is stored for them.
+func vehicleNormalizationWarnings(submitted, normalized *genproto.VehicleInput, mask *fieldmaskpb.FieldMask) []*genproto.NormalizationWarning {
+	fields := []struct {
+		name, submitted, stored string
+	}{
+		{"vehicle_type_id", submitted.VehicleTypeId, normalized.VehicleTypeId},
+		{"license_plate", submitted.LicensePlate, normalized.LicensePlate},
+		{"make", submitted.Make, normalized.Make},
+		{"model", submitted.Model, normalized.Model},
+		{"color", submitted.Color, normalized.Color},
+		{"engine_number", submitted.EngineNumber, normalized.EngineNumber},
+		{"chassis_number", submitted.ChassisNumber, normalized.ChassisNumber},
+	}
+
+	warnings := []*genproto.NormalizationWarning{}
+	for _, f := range fields {
+		if f.submitted == "" || f.submitted == f.stored {
+			continue
+		}
+		if mask != nil && !slices.Contains(mask.Paths, f.name) {
+			continue
+		}
+		warnings = append(warnings, &genproto.NormalizationWarning{
+			Field:     f.name,
+			Submitted: f.submitted,
+			Stored:    f.stored,
+		})
+	}
+	return warnings
 }
 
-// validateMaskedFields validates only fields specified in the update mask
+// validateMaskedFields validates only fields specified in the update mask.
+// With a mask the client is explicit about what it is changing, so an empty
+// value for a masked field means "clear it". That is only allowed for the
+// nullable columns; required fields must carry a value.
 func validateMaskedFields(vehicle *genproto.VehicleInput, mask *fieldmaskpb.FieldMask) error {
 	for _, path := range mask.Paths {
 		switch path {
 		case "vehicle_type_id":
-			if vehicle.VehicleTypeId != "" {
-				if err := ValidateVehicleTypeID("vehicle_type_id", vehicle.VehicleTypeId); err != nil {
-					return err
-				}
+			if err := ValidateVehicleTypeID("vehicle_type_id", vehicle.VehicleTypeId); err != nil {
+				return err
 			}
 		case "license_plate":
-			if vehicle.LicensePlate != "" {
-				if err := ValidateLicensePlate("license_plate", vehicle.LicensePlate); err != nil {
-					return err
-				}
+			if err := ValidateLicensePlate("license_plate", vehicle.LicensePlate); err != nil {
+				return err
 			}
 		case "make":
-			if vehicle.Make != "" {
-				if err := ValidateVehicleMake("make", vehicle.Make); err != nil {
-					return err
-				}
+			if err := ValidateVehicleMake("make", vehicle.Make); err != nil {
+				return err
 			}
 		case "model":
-			if vehicle.Model != "" {
-				if err := ValidateVehicleModel("model", vehicle.Model); err != nil {
-					return err
-				}
+			if err := ValidateVehicleModel("model", vehicle.Model); err != nil {
+				return err
 			}
 		case "year":
-			if vehicle.Year != 0 {
-				if err := ValidateVehicleYear("year", vehicle.Year); err != nil {
-					return err
-				}
+			if err := ValidateVehicleYear("year", vehicle.Year); err != nil {
+				return err
 			}
 		case "color":
-			if vehicle.Color != "" {
-				if err := ValidateColor("color", vehicle.Color); err != nil {
-					return err
-				}
+			if err := ValidateColor("color", vehicle.Color); err != nil {
+				return err
 			}
 		case "seating_capacity":
-			if vehicle.SeatingCapacity != 0 {
-				if err := ValidateSeatingCapacity("seating_capacity", vehicle.SeatingCapacity); err != nil {
-					return err
-				}
+			if err := ValidateSeatingCapacity("seating_capacity", vehicle.SeatingCapacity); err != nil {
+				return err
 			}
 		case "fuel_type":
-			if vehicle.FuelType != genproto.FuelType_FUEL_UNSPECIFIED {
-				// Fuel type validation is implicit - enum constraint handles it
+			if vehicle.FuelType == genproto.FuelType_FUEL_UNSPECIFIED {
+				return ValidationError{Field: "fuel_type", Message: "must be specified"}
 			}
 		case "engine_number":
+			// Optional column - empty clears it
 			if vehicle.EngineNumber != "" {
 				if err := ValidateEngineNumber("engine_number", vehicle.EngineNumber); err != nil {
 					return err
 				}
 			}
 		case "chassis_number":
+			// Optional column - empty clears it
 			if vehicle.ChassisNumber != "" {
 				if err := ValidateChassisNumber("chassis_number", vehicle.ChassisNumber); err != nil {
 					return err
 				}
 			}
+		case "registration_date":
+			// Optional date - omitting the timestamp clears it
+			if err := ValidateOptionalTimestamp("registration_date", vehicle.RegistrationDate); err != nil {
+				return err
+			}
+		case "insurance_expiry":
+			// Optional date - omitting the timestamp clears it
+			if err := ValidateOptionalTimestamp("insurance_expiry", vehicle.InsuranceExpiry); err != nil {
+				return err
+			}
 		default:
 			return ValidationError{
 				Field:   "update_mask",
@@ -594,6 +774,30 @@ func validateAllProvidedFields(vehicle *genproto.VehicleInput) error {
 		}
 	}
 
+	if err := ValidateOptionalTimestamp("registration_date", vehicle.RegistrationDate); err != nil {
+		return err
+	}
+
+	if err := ValidateOptionalTimestamp("insurance_expiry", vehicle.InsuranceExpiry); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateOptionalTimestamp checks a date the client chose to send. Leaving the field out
+// is fine, but a zero timestamp (1970-01-01, what many clients emit for "unset") is rejected
+// so it isn't stored as a real date. Other historical dates pass through unchanged.
+func ValidateOptionalTimestamp(field string, ts *timestamppb.Timestamp) error {
+	if ts == nil {
+		return nil
+	}
+	if ts.GetSeconds() == 0 && ts.GetNanos() == 0 {
+		return ValidationError{Field: field, Message: "cannot be the zero timestamp; omit the field to leave it unset"}
+	}
+	if err := ts.CheckValid(); err != nil {
+		return ValidationError{Field: field, Message: "is not a valid timestamp"}
+	}
 	return nil
 }
 
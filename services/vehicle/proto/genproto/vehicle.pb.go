@@ -137,6 +137,109 @@ func (FuelType) EnumDescriptor() ([]byte, []int) {
 	return file_vehicle_proto_rawDescGZIP(), []int{1}
 }
 
+// How make_filter is matched. Exact and prefix can use the make index; contains
+// has a leading wildcard and scans the whole table, so only ask for it when needed.
+type MakeMatch int32
+
+const (
+	MakeMatch_MAKE_MATCH_UNSPECIFIED MakeMatch = 0 // treated as MAKE_PREFIX
+	MakeMatch_MAKE_EXACT             MakeMatch = 1
+	MakeMatch_MAKE_PREFIX            MakeMatch = 2
+	MakeMatch_MAKE_CONTAINS          MakeMatch = 3
+)
+
+// Enum value maps for MakeMatch.
+var (
+	MakeMatch_name = map[int32]string{
+		0: "MAKE_MATCH_UNSPECIFIED",
+		1: "MAKE_EXACT",
+		2: "MAKE_PREFIX",
+		3: "MAKE_CONTAINS",
+	}
+	MakeMatch_value = map[string]int32{
+		"MAKE_MATCH_UNSPECIFIED": 0,
+		"MAKE_EXACT":             1,
+		"MAKE_PREFIX":            2,
+		"MAKE_CONTAINS":          3,
+	}
+)
+
+func (x MakeMatch) Enum() *MakeMatch {
+	p := new(MakeMatch)
+	*p = x
+	return p
+}
+
+func (x MakeMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MakeMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_vehicle_proto_enumTypes[2].Descriptor()
+}
+
+func (MakeMatch) Type() protoreflect.EnumType {
+	return &file_vehicle_proto_enumTypes[2]
+}
+
+func (x MakeMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MakeMatch.Descriptor instead.
+func (MakeMatch) EnumDescriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{2}
+}
+
+type UtilizationGranularity int32
+
+const (
+	UtilizationGranularity_GRANULARITY_UNSPECIFIED UtilizationGranularity = 0 // treated as GRANULARITY_DAILY
+	UtilizationGranularity_GRANULARITY_DAILY       UtilizationGranularity = 1
+	UtilizationGranularity_GRANULARITY_WEEKLY      UtilizationGranularity = 2 // weeks start on Monday
+)
+
+// Enum value maps for UtilizationGranularity.
+var (
+	UtilizationGranularity_name = map[int32]string{
+		0: "GRANULARITY_UNSPECIFIED",
+		1: "GRANULARITY_DAILY",
+		2: "GRANULARITY_WEEKLY",
+	}
+	UtilizationGranularity_value = map[string]int32{
+		"GRANULARITY_UNSPECIFIED": 0,
+		"GRANULARITY_DAILY":       1,
+		"GRANULARITY_WEEKLY":      2,
+	}
+)
+
+func (x UtilizationGranularity) Enum() *UtilizationGranularity {
+	p := new(UtilizationGranularity)
+	*p = x
+	return p
+}
+
+func (x UtilizationGranularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UtilizationGranularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_vehicle_proto_enumTypes[3].Descriptor()
+}
+
+func (UtilizationGranularity) Type() protoreflect.EnumType {
+	return &file_vehicle_proto_enumTypes[3]
+}
+
+func (x UtilizationGranularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UtilizationGranularity.Descriptor instead.
+func (UtilizationGranularity) EnumDescriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{3}
+}
+
 // ================= Vehicle Type Messages =================
 type VehicleType struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -210,6 +313,7 @@ type CreateVehicleTypeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	EnsureExists  bool                   `protobuf:"varint,3,opt,name=ensure_exists,json=ensureExists,proto3" json:"ensure_exists,omitempty"` // return the existing type instead of AlreadyExists
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -258,9 +362,17 @@ func (x *CreateVehicleTypeRequest) GetDescription() string {
 	return ""
 }
 
+func (x *CreateVehicleTypeRequest) GetEnsureExists() bool {
+	if x != nil {
+		return x.EnsureExists
+	}
+	return false
+}
+
 type CreateVehicleTypeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VehicleType   *VehicleType           `protobuf:"bytes,1,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	Created       bool                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"` // false when ensure_exists returned an existing type
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -302,6 +414,13 @@ func (x *CreateVehicleTypeResponse) GetVehicleType() *VehicleType {
 	return nil
 }
 
+func (x *CreateVehicleTypeResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
 type ListVehicleTypesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
@@ -406,44 +525,27 @@ func (x *ListVehicleTypesResponse) GetNextPageToken() string {
 	return ""
 }
 
-// ================= Core Vehicle Messages =================
-type Vehicle struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                    // external_id
-	VehicleTypeId    string                 `protobuf:"bytes,2,opt,name=vehicle_type_id,json=vehicleTypeId,proto3" json:"vehicle_type_id,omitempty"`       // reference to vehicle_type
-	VehicleTypeName  string                 `protobuf:"bytes,3,opt,name=vehicle_type_name,json=vehicleTypeName,proto3" json:"vehicle_type_name,omitempty"` // for convenience
-	LicensePlate     string                 `protobuf:"bytes,4,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
-	Make             string                 `protobuf:"bytes,5,opt,name=make,proto3" json:"make,omitempty"`
-	Model            string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
-	Year             int32                  `protobuf:"varint,7,opt,name=year,proto3" json:"year,omitempty"`
-	Color            string                 `protobuf:"bytes,8,opt,name=color,proto3" json:"color,omitempty"`
-	SeatingCapacity  int32                  `protobuf:"varint,9,opt,name=seating_capacity,json=seatingCapacity,proto3" json:"seating_capacity,omitempty"`
-	FuelType         FuelType               `protobuf:"varint,10,opt,name=fuel_type,json=fuelType,proto3,enum=vehicle.FuelType" json:"fuel_type,omitempty"`
-	EngineNumber     string                 `protobuf:"bytes,11,opt,name=engine_number,json=engineNumber,proto3" json:"engine_number,omitempty"`
-	ChassisNumber    string                 `protobuf:"bytes,12,opt,name=chassis_number,json=chassisNumber,proto3" json:"chassis_number,omitempty"`
-	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
-	InsuranceExpiry  *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=insurance_expiry,json=insuranceExpiry,proto3" json:"insurance_expiry,omitempty"`
-	Status           VehicleStatus          `protobuf:"varint,15,opt,name=status,proto3,enum=vehicle.VehicleStatus" json:"status,omitempty"`
-	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type GetVehicleTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Vehicle) Reset() {
-	*x = Vehicle{}
+func (x *GetVehicleTypeRequest) Reset() {
+	*x = GetVehicleTypeRequest{}
 	mi := &file_vehicle_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Vehicle) String() string {
+func (x *GetVehicleTypeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Vehicle) ProtoMessage() {}
+func (*GetVehicleTypeRequest) ProtoMessage() {}
 
-func (x *Vehicle) ProtoReflect() protoreflect.Message {
+func (x *GetVehicleTypeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_vehicle_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -455,152 +557,189 @@ func (x *Vehicle) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
-func (*Vehicle) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetVehicleTypeRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleTypeRequest) Descriptor() ([]byte, []int) {
 	return file_vehicle_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Vehicle) GetId() string {
+func (x *GetVehicleTypeRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Vehicle) GetVehicleTypeId() string {
-	if x != nil {
-		return x.VehicleTypeId
-	}
-	return ""
+type GetVehicleTypeByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // exact match
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Vehicle) GetVehicleTypeName() string {
-	if x != nil {
-		return x.VehicleTypeName
-	}
-	return ""
+func (x *GetVehicleTypeByNameRequest) Reset() {
+	*x = GetVehicleTypeByNameRequest{}
+	mi := &file_vehicle_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Vehicle) GetLicensePlate() string {
-	if x != nil {
-		return x.LicensePlate
-	}
-	return ""
+func (x *GetVehicleTypeByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Vehicle) GetMake() string {
-	if x != nil {
-		return x.Make
-	}
-	return ""
-}
+func (*GetVehicleTypeByNameRequest) ProtoMessage() {}
 
-func (x *Vehicle) GetModel() string {
+func (x *GetVehicleTypeByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[6]
 	if x != nil {
-		return x.Model
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Vehicle) GetYear() int32 {
-	if x != nil {
-		return x.Year
-	}
-	return 0
+// Deprecated: Use GetVehicleTypeByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleTypeByNameRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Vehicle) GetColor() string {
+func (x *GetVehicleTypeByNameRequest) GetName() string {
 	if x != nil {
-		return x.Color
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Vehicle) GetSeatingCapacity() int32 {
-	if x != nil {
-		return x.SeatingCapacity
-	}
-	return 0
+type GetVehicleTypeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleType   *VehicleType           `protobuf:"bytes,1,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Vehicle) GetFuelType() FuelType {
-	if x != nil {
-		return x.FuelType
-	}
-	return FuelType_FUEL_UNSPECIFIED
+func (x *GetVehicleTypeResponse) Reset() {
+	*x = GetVehicleTypeResponse{}
+	mi := &file_vehicle_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Vehicle) GetEngineNumber() string {
-	if x != nil {
-		return x.EngineNumber
-	}
-	return ""
+func (x *GetVehicleTypeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Vehicle) GetChassisNumber() string {
+func (*GetVehicleTypeResponse) ProtoMessage() {}
+
+func (x *GetVehicleTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[7]
 	if x != nil {
-		return x.ChassisNumber
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Vehicle) GetRegistrationDate() *timestamppb.Timestamp {
+// Deprecated: Use GetVehicleTypeResponse.ProtoReflect.Descriptor instead.
+func (*GetVehicleTypeResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetVehicleTypeResponse) GetVehicleType() *VehicleType {
 	if x != nil {
-		return x.RegistrationDate
+		return x.VehicleType
 	}
 	return nil
 }
 
-func (x *Vehicle) GetInsuranceExpiry() *timestamppb.Timestamp {
+// Unset fields are left as they are
+type UpdateVehicleTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleTypeRequest) Reset() {
+	*x = UpdateVehicleTypeRequest{}
+	mi := &file_vehicle_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleTypeRequest) ProtoMessage() {}
+
+func (x *UpdateVehicleTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[8]
 	if x != nil {
-		return x.InsuranceExpiry
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Vehicle) GetStatus() VehicleStatus {
+// Deprecated: Use UpdateVehicleTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleTypeRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateVehicleTypeRequest) GetId() string {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
-	return VehicleStatus_STATUS_UNSPECIFIED
+	return ""
 }
 
-func (x *Vehicle) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
+func (x *UpdateVehicleTypeRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *Vehicle) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
+func (x *UpdateVehicleTypeRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
-	return nil
+	return ""
 }
 
-type CreateVehicleRequest struct {
+type UpdateVehicleTypeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vehicle       *VehicleInput          `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	VehicleType   *VehicleType           `protobuf:"bytes,1,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateVehicleRequest) Reset() {
-	*x = CreateVehicleRequest{}
-	mi := &file_vehicle_proto_msgTypes[6]
+func (x *UpdateVehicleTypeResponse) Reset() {
+	*x = UpdateVehicleTypeResponse{}
+	mi := &file_vehicle_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVehicleRequest) String() string {
+func (x *UpdateVehicleTypeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVehicleRequest) ProtoMessage() {}
+func (*UpdateVehicleTypeResponse) ProtoMessage() {}
 
-func (x *CreateVehicleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[6]
+func (x *UpdateVehicleTypeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,51 +750,2294 @@ func (x *CreateVehicleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVehicleRequest.ProtoReflect.Descriptor instead.
-func (*CreateVehicleRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UpdateVehicleTypeResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleTypeResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateVehicleRequest) GetVehicle() *VehicleInput {
+func (x *UpdateVehicleTypeResponse) GetVehicleType() *VehicleType {
 	if x != nil {
-		return x.Vehicle
+		return x.VehicleType
 	}
 	return nil
 }
 
-type VehicleInput struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	VehicleTypeId    string                 `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3" json:"vehicle_type_id,omitempty"`
-	LicensePlate     string                 `protobuf:"bytes,2,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
-	Make             string                 `protobuf:"bytes,3,opt,name=make,proto3" json:"make,omitempty"`
-	Model            string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
-	Year             int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
-	Color            string                 `protobuf:"bytes,6,opt,name=color,proto3" json:"color,omitempty"`
-	SeatingCapacity  int32                  `protobuf:"varint,7,opt,name=seating_capacity,json=seatingCapacity,proto3" json:"seating_capacity,omitempty"`
-	FuelType         FuelType               `protobuf:"varint,8,opt,name=fuel_type,json=fuelType,proto3,enum=vehicle.FuelType" json:"fuel_type,omitempty"`
-	EngineNumber     string                 `protobuf:"bytes,9,opt,name=engine_number,json=engineNumber,proto3" json:"engine_number,omitempty"`
-	ChassisNumber    string                 `protobuf:"bytes,10,opt,name=chassis_number,json=chassisNumber,proto3" json:"chassis_number,omitempty"`
-	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
-	InsuranceExpiry  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=insurance_expiry,json=insuranceExpiry,proto3" json:"insurance_expiry,omitempty"`
+type DeleteVehicleTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVehicleTypeRequest) Reset() {
+	*x = DeleteVehicleTypeRequest{}
+	mi := &file_vehicle_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVehicleTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVehicleTypeRequest) ProtoMessage() {}
+
+func (x *DeleteVehicleTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVehicleTypeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVehicleTypeRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteVehicleTypeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ================= Core Vehicle Messages =================
+type Vehicle struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                    // external_id
+	VehicleTypeId    string                 `protobuf:"bytes,2,opt,name=vehicle_type_id,json=vehicleTypeId,proto3" json:"vehicle_type_id,omitempty"`       // reference to vehicle_type
+	VehicleTypeName  string                 `protobuf:"bytes,3,opt,name=vehicle_type_name,json=vehicleTypeName,proto3" json:"vehicle_type_name,omitempty"` // for convenience
+	LicensePlate     string                 `protobuf:"bytes,4,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	Make             string                 `protobuf:"bytes,5,opt,name=make,proto3" json:"make,omitempty"`
+	Model            string                 `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
+	Year             int32                  `protobuf:"varint,7,opt,name=year,proto3" json:"year,omitempty"`
+	Color            string                 `protobuf:"bytes,8,opt,name=color,proto3" json:"color,omitempty"`
+	SeatingCapacity  int32                  `protobuf:"varint,9,opt,name=seating_capacity,json=seatingCapacity,proto3" json:"seating_capacity,omitempty"`
+	FuelType         FuelType               `protobuf:"varint,10,opt,name=fuel_type,json=fuelType,proto3,enum=vehicle.FuelType" json:"fuel_type,omitempty"`
+	EngineNumber     string                 `protobuf:"bytes,11,opt,name=engine_number,json=engineNumber,proto3" json:"engine_number,omitempty"`
+	ChassisNumber    string                 `protobuf:"bytes,12,opt,name=chassis_number,json=chassisNumber,proto3" json:"chassis_number,omitempty"`
+	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
+	InsuranceExpiry  *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=insurance_expiry,json=insuranceExpiry,proto3" json:"insurance_expiry,omitempty"`
+	Status           VehicleStatus          `protobuf:"varint,15,opt,name=status,proto3,enum=vehicle.VehicleStatus" json:"status,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	UpdatedBy        *string                `protobuf:"bytes,18,opt,name=updated_by,json=updatedBy,proto3,oneof" json:"updated_by,omitempty"`                // user ID of the last editor, or "system"
+	MileageKm        *int32                 `protobuf:"varint,19,opt,name=mileage_km,json=mileageKm,proto3,oneof" json:"mileage_km,omitempty"`               // latest odometer reading, unset until one is recorded
+	LastServiceKm    *int32                 `protobuf:"varint,20,opt,name=last_service_km,json=lastServiceKm,proto3,oneof" json:"last_service_km,omitempty"` // odometer reading at the latest service, if known
+	LastServiceAt    *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=last_service_at,json=lastServiceAt,proto3,oneof" json:"last_service_at,omitempty"`  // unset until a service is recorded
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *VehicleInput) Reset() {
-	*x = VehicleInput{}
-	mi := &file_vehicle_proto_msgTypes[7]
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	mi := &file_vehicle_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Vehicle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vehicle) GetVehicleTypeId() string {
+	if x != nil {
+		return x.VehicleTypeId
+	}
+	return ""
+}
+
+func (x *Vehicle) GetVehicleTypeName() string {
+	if x != nil {
+		return x.VehicleTypeName
+	}
+	return ""
+}
+
+func (x *Vehicle) GetLicensePlate() string {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return ""
+}
+
+func (x *Vehicle) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *Vehicle) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Vehicle) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Vehicle) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Vehicle) GetSeatingCapacity() int32 {
+	if x != nil {
+		return x.SeatingCapacity
+	}
+	return 0
+}
+
+func (x *Vehicle) GetFuelType() FuelType {
+	if x != nil {
+		return x.FuelType
+	}
+	return FuelType_FUEL_UNSPECIFIED
+}
+
+func (x *Vehicle) GetEngineNumber() string {
+	if x != nil {
+		return x.EngineNumber
+	}
+	return ""
+}
+
+func (x *Vehicle) GetChassisNumber() string {
+	if x != nil {
+		return x.ChassisNumber
+	}
+	return ""
+}
+
+func (x *Vehicle) GetRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationDate
+	}
+	return nil
+}
+
+func (x *Vehicle) GetInsuranceExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsuranceExpiry
+	}
+	return nil
+}
+
+func (x *Vehicle) GetStatus() VehicleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+func (x *Vehicle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Vehicle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Vehicle) GetUpdatedBy() string {
+	if x != nil && x.UpdatedBy != nil {
+		return *x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Vehicle) GetMileageKm() int32 {
+	if x != nil && x.MileageKm != nil {
+		return *x.MileageKm
+	}
+	return 0
+}
+
+func (x *Vehicle) GetLastServiceKm() int32 {
+	if x != nil && x.LastServiceKm != nil {
+		return *x.LastServiceKm
+	}
+	return 0
+}
+
+func (x *Vehicle) GetLastServiceAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastServiceAt
+	}
+	return nil
+}
+
+type CreateVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *VehicleInput          `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	AdminOverride bool                   `protobuf:"varint,2,opt,name=admin_override,json=adminOverride,proto3" json:"admin_override,omitempty"` // allows reusing the plate of a recently retired vehicle
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVehicleRequest) Reset() {
+	*x = CreateVehicleRequest{}
+	mi := &file_vehicle_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehicleRequest) ProtoMessage() {}
+
+func (x *CreateVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehicleRequest.ProtoReflect.Descriptor instead.
+func (*CreateVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateVehicleRequest) GetVehicle() *VehicleInput {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *CreateVehicleRequest) GetAdminOverride() bool {
+	if x != nil {
+		return x.AdminOverride
+	}
+	return false
+}
+
+type VehicleInput struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VehicleTypeId    string                 `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3" json:"vehicle_type_id,omitempty"`
+	LicensePlate     string                 `protobuf:"bytes,2,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	Make             string                 `protobuf:"bytes,3,opt,name=make,proto3" json:"make,omitempty"`
+	Model            string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Year             int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
+	Color            string                 `protobuf:"bytes,6,opt,name=color,proto3" json:"color,omitempty"`
+	SeatingCapacity  int32                  `protobuf:"varint,7,opt,name=seating_capacity,json=seatingCapacity,proto3" json:"seating_capacity,omitempty"`
+	FuelType         FuelType               `protobuf:"varint,8,opt,name=fuel_type,json=fuelType,proto3,enum=vehicle.FuelType" json:"fuel_type,omitempty"`
+	EngineNumber     string                 `protobuf:"bytes,9,opt,name=engine_number,json=engineNumber,proto3" json:"engine_number,omitempty"`
+	ChassisNumber    string                 `protobuf:"bytes,10,opt,name=chassis_number,json=chassisNumber,proto3" json:"chassis_number,omitempty"`
+	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
+	InsuranceExpiry  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=insurance_expiry,json=insuranceExpiry,proto3" json:"insurance_expiry,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *VehicleInput) Reset() {
+	*x = VehicleInput{}
+	mi := &file_vehicle_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleInput) ProtoMessage() {}
+
+func (x *VehicleInput) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleInput.ProtoReflect.Descriptor instead.
+func (*VehicleInput) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VehicleInput) GetVehicleTypeId() string {
+	if x != nil {
+		return x.VehicleTypeId
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetLicensePlate() string {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *VehicleInput) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetSeatingCapacity() int32 {
+	if x != nil {
+		return x.SeatingCapacity
+	}
+	return 0
+}
+
+func (x *VehicleInput) GetFuelType() FuelType {
+	if x != nil {
+		return x.FuelType
+	}
+	return FuelType_FUEL_UNSPECIFIED
+}
+
+func (x *VehicleInput) GetEngineNumber() string {
+	if x != nil {
+		return x.EngineNumber
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetChassisNumber() string {
+	if x != nil {
+		return x.ChassisNumber
+	}
+	return ""
+}
+
+func (x *VehicleInput) GetRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationDate
+	}
+	return nil
+}
+
+func (x *VehicleInput) GetInsuranceExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsuranceExpiry
+	}
+	return nil
+}
+
+type CreateVehicleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateVehicleResponse) Reset() {
+	*x = CreateVehicleResponse{}
+	mi := &file_vehicle_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehicleResponse) ProtoMessage() {}
+
+func (x *CreateVehicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehicleResponse.ProtoReflect.Descriptor instead.
+func (*CreateVehicleResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateVehicleResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+type GetVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehicleRequest) Reset() {
+	*x = GetVehicleRequest{}
+	mi := &file_vehicle_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleRequest) ProtoMessage() {}
+
+func (x *GetVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetVehicleRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+type GetVehicleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehicleResponse) Reset() {
+	*x = GetVehicleResponse{}
+	mi := &file_vehicle_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleResponse) ProtoMessage() {}
+
+func (x *GetVehicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleResponse.ProtoReflect.Descriptor instead.
+func (*GetVehicleResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetVehicleResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+// Looks a vehicle up by its chassis number, which is unique across all vehicles.
+// The number is matched case-insensitively and ignoring surrounding whitespace.
+type GetVehicleByChassisNumberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChassisNumber string                 `protobuf:"bytes,1,opt,name=chassis_number,json=chassisNumber,proto3" json:"chassis_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehicleByChassisNumberRequest) Reset() {
+	*x = GetVehicleByChassisNumberRequest{}
+	mi := &file_vehicle_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehicleByChassisNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleByChassisNumberRequest) ProtoMessage() {}
+
+func (x *GetVehicleByChassisNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleByChassisNumberRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleByChassisNumberRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetVehicleByChassisNumberRequest) GetChassisNumber() string {
+	if x != nil {
+		return x.ChassisNumber
+	}
+	return ""
+}
+
+// Looks up many vehicles in one round trip. Unknown IDs don't fail the call;
+// they are listed in not_found_ids.
+type BatchGetVehiclesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleIds    []string               `protobuf:"bytes,1,rep,name=vehicle_ids,json=vehicleIds,proto3" json:"vehicle_ids,omitempty"` // at most 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetVehiclesRequest) Reset() {
+	*x = BatchGetVehiclesRequest{}
+	mi := &file_vehicle_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetVehiclesRequest) ProtoMessage() {}
+
+func (x *BatchGetVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BatchGetVehiclesRequest) GetVehicleIds() []string {
+	if x != nil {
+		return x.VehicleIds
+	}
+	return nil
+}
+
+type BatchGetVehiclesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles      map[string]*Vehicle    `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // keyed by vehicle_id as requested
+	NotFoundIds   []string               `protobuf:"bytes,2,rep,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetVehiclesResponse) Reset() {
+	*x = BatchGetVehiclesResponse{}
+	mi := &file_vehicle_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetVehiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetVehiclesResponse) ProtoMessage() {}
+
+func (x *BatchGetVehiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetVehiclesResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetVehiclesResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchGetVehiclesResponse) GetVehicles() map[string]*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *BatchGetVehiclesResponse) GetNotFoundIds() []string {
+	if x != nil {
+		return x.NotFoundIds
+	}
+	return nil
+}
+
+type ListVehiclesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PageSize          int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	StatusFilter      *VehicleStatus         `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=vehicle.VehicleStatus,oneof" json:"status_filter,omitempty"`
+	VehicleTypeFilter *string                `protobuf:"bytes,4,opt,name=vehicle_type_filter,json=vehicleTypeFilter,proto3,oneof" json:"vehicle_type_filter,omitempty"`
+	MakeFilter        *string                `protobuf:"bytes,5,opt,name=make_filter,json=makeFilter,proto3,oneof" json:"make_filter,omitempty"`
+	MakeMatch         MakeMatch              `protobuf:"varint,6,opt,name=make_match,json=makeMatch,proto3,enum=vehicle.MakeMatch" json:"make_match,omitempty"`
+	SortBy            string                 `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`          // created_at (default), year, make or seating_capacity
+	SortOrder         string                 `protobuf:"bytes,8,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"` // asc or desc (default); ties break on created_at, then ID
+	Search            *string                `protobuf:"bytes,9,opt,name=search,proto3,oneof" json:"search,omitempty"`                  // matched anywhere in make, model or license plate
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListVehiclesRequest) Reset() {
+	*x = ListVehiclesRequest{}
+	mi := &file_vehicle_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesRequest) ProtoMessage() {}
+
+func (x *ListVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*ListVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListVehiclesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListVehiclesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetStatusFilter() VehicleStatus {
+	if x != nil && x.StatusFilter != nil {
+		return *x.StatusFilter
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+func (x *ListVehiclesRequest) GetVehicleTypeFilter() string {
+	if x != nil && x.VehicleTypeFilter != nil {
+		return *x.VehicleTypeFilter
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetMakeFilter() string {
+	if x != nil && x.MakeFilter != nil {
+		return *x.MakeFilter
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetMakeMatch() MakeMatch {
+	if x != nil {
+		return x.MakeMatch
+	}
+	return MakeMatch_MAKE_MATCH_UNSPECIFIED
+}
+
+func (x *ListVehiclesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetSearch() string {
+	if x != nil && x.Search != nil {
+		return *x.Search
+	}
+	return ""
+}
+
+type ListVehiclesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles      []*Vehicle             `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`           // vehicles matching the filters across all pages
+	PrevPageToken string                 `protobuf:"bytes,4,opt,name=prev_page_token,json=prevPageToken,proto3" json:"prev_page_token,omitempty"` // set by ListVehicles and GetVehiclesByType past the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVehiclesResponse) Reset() {
+	*x = ListVehiclesResponse{}
+	mi := &file_vehicle_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVehiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesResponse) ProtoMessage() {}
+
+func (x *ListVehiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesResponse.ProtoReflect.Descriptor instead.
+func (*ListVehiclesResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListVehiclesResponse) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *ListVehiclesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListVehiclesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListVehiclesResponse) GetPrevPageToken() string {
+	if x != nil {
+		return x.PrevPageToken
+	}
+	return ""
+}
+
+type UpdateVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	Vehicle       *VehicleInput          `protobuf:"bytes,2,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"` // optional fields masked but left empty are cleared
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleRequest) Reset() {
+	*x = UpdateVehicleRequest{}
+	mi := &file_vehicle_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleRequest) ProtoMessage() {}
+
+func (x *UpdateVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVehicleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateVehicleRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *UpdateVehicleRequest) GetVehicle() *VehicleInput {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *UpdateVehicleRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// normalization_warnings lists submitted values that were stored in a different
+// form (e.g. a license plate upper-cased and re-spaced) so clients can resync.
+type UpdateVehicleResponse struct {
+	state                 protoimpl.MessageState  `protogen:"open.v1"`
+	Vehicle               *Vehicle                `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	NormalizationWarnings []*NormalizationWarning `protobuf:"bytes,2,rep,name=normalization_warnings,json=normalizationWarnings,proto3" json:"normalization_warnings,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleResponse) Reset() {
+	*x = UpdateVehicleResponse{}
+	mi := &file_vehicle_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleResponse) ProtoMessage() {}
+
+func (x *UpdateVehicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVehicleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateVehicleResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *UpdateVehicleResponse) GetNormalizationWarnings() []*NormalizationWarning {
+	if x != nil {
+		return x.NormalizationWarnings
+	}
+	return nil
+}
+
+type NormalizationWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`         // proto field name, e.g. license_plate
+	Submitted     string                 `protobuf:"bytes,2,opt,name=submitted,proto3" json:"submitted,omitempty"` // value as sent by the client
+	Stored        string                 `protobuf:"bytes,3,opt,name=stored,proto3" json:"stored,omitempty"`       // value after normalization
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NormalizationWarning) Reset() {
+	*x = NormalizationWarning{}
+	mi := &file_vehicle_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NormalizationWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NormalizationWarning) ProtoMessage() {}
+
+func (x *NormalizationWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NormalizationWarning.ProtoReflect.Descriptor instead.
+func (*NormalizationWarning) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NormalizationWarning) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *NormalizationWarning) GetSubmitted() string {
+	if x != nil {
+		return x.Submitted
+	}
+	return ""
+}
+
+func (x *NormalizationWarning) GetStored() string {
+	if x != nil {
+		return x.Stored
+	}
+	return ""
+}
+
+type DeleteVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVehicleRequest) Reset() {
+	*x = DeleteVehicleRequest{}
+	mi := &file_vehicle_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVehicleRequest) ProtoMessage() {}
+
+func (x *DeleteVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVehicleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteVehicleRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+type GetVehiclesByTypeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleTypeId string                 `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3" json:"vehicle_type_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	StatusFilter  *VehicleStatus         `protobuf:"varint,4,opt,name=status_filter,json=statusFilter,proto3,enum=vehicle.VehicleStatus,oneof" json:"status_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehiclesByTypeRequest) Reset() {
+	*x = GetVehiclesByTypeRequest{}
+	mi := &file_vehicle_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehiclesByTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesByTypeRequest) ProtoMessage() {}
+
+func (x *GetVehiclesByTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesByTypeRequest.ProtoReflect.Descriptor instead.
+func (*GetVehiclesByTypeRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetVehiclesByTypeRequest) GetVehicleTypeId() string {
+	if x != nil {
+		return x.VehicleTypeId
+	}
+	return ""
+}
+
+func (x *GetVehiclesByTypeRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetVehiclesByTypeRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetVehiclesByTypeRequest) GetStatusFilter() VehicleStatus {
+	if x != nil && x.StatusFilter != nil {
+		return *x.StatusFilter
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+type GetAvailableVehiclesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleTypeId *string                `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3,oneof" json:"vehicle_type_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableVehiclesRequest) Reset() {
+	*x = GetAvailableVehiclesRequest{}
+	mi := &file_vehicle_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableVehiclesRequest) ProtoMessage() {}
+
+func (x *GetAvailableVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetAvailableVehiclesRequest) GetVehicleTypeId() string {
+	if x != nil && x.VehicleTypeId != nil {
+		return *x.VehicleTypeId
+	}
+	return ""
+}
+
+func (x *GetAvailableVehiclesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAvailableVehiclesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Active vehicles of a type that can seat the party and are insured on the given date
+type GetDispatchCandidatesRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VehicleTypeId    *string                `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3,oneof" json:"vehicle_type_id,omitempty"`
+	MinSeats         int32                  `protobuf:"varint,2,opt,name=min_seats,json=minSeats,proto3" json:"min_seats,omitempty"`
+	InsuranceValidOn *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=insurance_valid_on,json=insuranceValidOn,proto3,oneof" json:"insurance_valid_on,omitempty"` // defaults to today
+	PageSize         int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken        string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetDispatchCandidatesRequest) Reset() {
+	*x = GetDispatchCandidatesRequest{}
+	mi := &file_vehicle_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDispatchCandidatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDispatchCandidatesRequest) ProtoMessage() {}
+
+func (x *GetDispatchCandidatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDispatchCandidatesRequest.ProtoReflect.Descriptor instead.
+func (*GetDispatchCandidatesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetDispatchCandidatesRequest) GetVehicleTypeId() string {
+	if x != nil && x.VehicleTypeId != nil {
+		return *x.VehicleTypeId
+	}
+	return ""
+}
+
+func (x *GetDispatchCandidatesRequest) GetMinSeats() int32 {
+	if x != nil {
+		return x.MinSeats
+	}
+	return 0
+}
+
+func (x *GetDispatchCandidatesRequest) GetInsuranceValidOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsuranceValidOn
+	}
+	return nil
+}
+
+func (x *GetDispatchCandidatesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetDispatchCandidatesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Vehicles that have been modified since creation, most recently updated first
+type ListRecentlyUpdatedVehiclesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // keyed on updated_at, not interchangeable with ListVehicles tokens
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecentlyUpdatedVehiclesRequest) Reset() {
+	*x = ListRecentlyUpdatedVehiclesRequest{}
+	mi := &file_vehicle_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecentlyUpdatedVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecentlyUpdatedVehiclesRequest) ProtoMessage() {}
+
+func (x *ListRecentlyUpdatedVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecentlyUpdatedVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*ListRecentlyUpdatedVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListRecentlyUpdatedVehiclesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRecentlyUpdatedVehiclesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type UpdateVehicleStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	Status        VehicleStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=vehicle.VehicleStatus" json:"status,omitempty"`
+	AdminOverride bool                   `protobuf:"varint,3,opt,name=admin_override,json=adminOverride,proto3" json:"admin_override,omitempty"` // allows recovering a vehicle whose current status is unrecognized
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                                     // optional, stored in the status history
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleStatusRequest) Reset() {
+	*x = UpdateVehicleStatusRequest{}
+	mi := &file_vehicle_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleStatusRequest) ProtoMessage() {}
+
+func (x *UpdateVehicleStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVehicleStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleStatusRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateVehicleStatusRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *UpdateVehicleStatusRequest) GetStatus() VehicleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateVehicleStatusRequest) GetAdminOverride() bool {
+	if x != nil {
+		return x.AdminOverride
+	}
+	return false
+}
+
+func (x *UpdateVehicleStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateVehicleStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleStatusResponse) Reset() {
+	*x = UpdateVehicleStatusResponse{}
+	mi := &file_vehicle_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleStatusResponse) ProtoMessage() {}
+
+func (x *UpdateVehicleStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVehicleStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleStatusResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateVehicleStatusResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+// Records an odometer reading. Readings lower than the vehicle's current mileage are
+// rejected with FAILED_PRECONDITION, since odometers don't go backwards.
+type RecordVehicleMileageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	MileageKm     int32                  `protobuf:"varint,2,opt,name=mileage_km,json=mileageKm,proto3" json:"mileage_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordVehicleMileageRequest) Reset() {
+	*x = RecordVehicleMileageRequest{}
+	mi := &file_vehicle_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordVehicleMileageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordVehicleMileageRequest) ProtoMessage() {}
+
+func (x *RecordVehicleMileageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordVehicleMileageRequest.ProtoReflect.Descriptor instead.
+func (*RecordVehicleMileageRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecordVehicleMileageRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *RecordVehicleMileageRequest) GetMileageKm() int32 {
+	if x != nil {
+		return x.MileageKm
+	}
+	return 0
+}
+
+type RecordVehicleMileageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordVehicleMileageResponse) Reset() {
+	*x = RecordVehicleMileageResponse{}
+	mi := &file_vehicle_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordVehicleMileageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordVehicleMileageResponse) ProtoMessage() {}
+
+func (x *RecordVehicleMileageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordVehicleMileageResponse.ProtoReflect.Descriptor instead.
+func (*RecordVehicleMileageResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RecordVehicleMileageResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+// Records a service of the vehicle. A service older than the last recorded one, or at a
+// lower reading than it, is rejected with FAILED_PRECONDITION. A reading ahead of the
+// vehicle's mileage is also recorded as a new mileage reading.
+type RecordServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	MileageKm     *int32                 `protobuf:"varint,2,opt,name=mileage_km,json=mileageKm,proto3,oneof" json:"mileage_km,omitempty"`   // defaults to the vehicle's current mileage
+	ServicedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=serviced_at,json=servicedAt,proto3,oneof" json:"serviced_at,omitempty"` // defaults to now
+	Notes         string                 `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordServiceRequest) Reset() {
+	*x = RecordServiceRequest{}
+	mi := &file_vehicle_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordServiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceRequest) ProtoMessage() {}
+
+func (x *RecordServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RecordServiceRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *RecordServiceRequest) GetMileageKm() int32 {
+	if x != nil && x.MileageKm != nil {
+		return *x.MileageKm
+	}
+	return 0
+}
+
+func (x *RecordServiceRequest) GetServicedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ServicedAt
+	}
+	return nil
+}
+
+func (x *RecordServiceRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type RecordServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordServiceResponse) Reset() {
+	*x = RecordServiceResponse{}
+	mi := &file_vehicle_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceResponse) ProtoMessage() {}
+
+func (x *RecordServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecordServiceResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+// Vehicles, other than retired ones, that have covered km_threshold since their last
+// service or haven't been serviced in days_threshold days. A vehicle never serviced is
+// measured from 0 km and from its registration date, or its creation when that's unset.
+// Thresholds default to the service's configured maintenance interval.
+type GetVehiclesDueForMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	KmThreshold   *int32                 `protobuf:"varint,3,opt,name=km_threshold,json=kmThreshold,proto3,oneof" json:"km_threshold,omitempty"`
+	DaysThreshold *int32                 `protobuf:"varint,4,opt,name=days_threshold,json=daysThreshold,proto3,oneof" json:"days_threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehiclesDueForMaintenanceRequest) Reset() {
+	*x = GetVehiclesDueForMaintenanceRequest{}
+	mi := &file_vehicle_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehiclesDueForMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesDueForMaintenanceRequest) ProtoMessage() {}
+
+func (x *GetVehiclesDueForMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesDueForMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*GetVehiclesDueForMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetVehiclesDueForMaintenanceRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetVehiclesDueForMaintenanceRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetVehiclesDueForMaintenanceRequest) GetKmThreshold() int32 {
+	if x != nil && x.KmThreshold != nil {
+		return *x.KmThreshold
+	}
+	return 0
+}
+
+func (x *GetVehiclesDueForMaintenanceRequest) GetDaysThreshold() int32 {
+	if x != nil && x.DaysThreshold != nil {
+		return *x.DaysThreshold
+	}
+	return 0
+}
+
+type MaintenanceDueVehicle struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle        *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	KmSinceService int32                  `protobuf:"varint,2,opt,name=km_since_service,json=kmSinceService,proto3" json:"km_since_service,omitempty"`
+	KmOverdue      int32                  `protobuf:"varint,3,opt,name=km_overdue,json=kmOverdue,proto3" json:"km_overdue,omitempty"`       // distance past km_threshold, 0 when due by date only
+	DaysOverdue    int32                  `protobuf:"varint,4,opt,name=days_overdue,json=daysOverdue,proto3" json:"days_overdue,omitempty"` // days past days_threshold, 0 when due by mileage only
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MaintenanceDueVehicle) Reset() {
+	*x = MaintenanceDueVehicle{}
+	mi := &file_vehicle_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceDueVehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceDueVehicle) ProtoMessage() {}
+
+func (x *MaintenanceDueVehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceDueVehicle.ProtoReflect.Descriptor instead.
+func (*MaintenanceDueVehicle) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *MaintenanceDueVehicle) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *MaintenanceDueVehicle) GetKmSinceService() int32 {
+	if x != nil {
+		return x.KmSinceService
+	}
+	return 0
+}
+
+func (x *MaintenanceDueVehicle) GetKmOverdue() int32 {
+	if x != nil {
+		return x.KmOverdue
+	}
+	return 0
+}
+
+func (x *MaintenanceDueVehicle) GetDaysOverdue() int32 {
+	if x != nil {
+		return x.DaysOverdue
+	}
+	return 0
+}
+
+type GetVehiclesDueForMaintenanceResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Vehicles      []*MaintenanceDueVehicle `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	NextPageToken string                   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                    `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehiclesDueForMaintenanceResponse) Reset() {
+	*x = GetVehiclesDueForMaintenanceResponse{}
+	mi := &file_vehicle_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehiclesDueForMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesDueForMaintenanceResponse) ProtoMessage() {}
+
+func (x *GetVehiclesDueForMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesDueForMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*GetVehiclesDueForMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetVehiclesDueForMaintenanceResponse) GetVehicles() []*MaintenanceDueVehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *GetVehiclesDueForMaintenanceResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetVehiclesDueForMaintenanceResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Dry run of UpdateVehicleStatus: reports whether the change would be allowed
+// without making it
+type ValidateVehicleStatusChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	Status        VehicleStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=vehicle.VehicleStatus" json:"status,omitempty"`
+	AdminOverride bool                   `protobuf:"varint,3,opt,name=admin_override,json=adminOverride,proto3" json:"admin_override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateVehicleStatusChangeRequest) Reset() {
+	*x = ValidateVehicleStatusChangeRequest{}
+	mi := &file_vehicle_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateVehicleStatusChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateVehicleStatusChangeRequest) ProtoMessage() {}
+
+func (x *ValidateVehicleStatusChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateVehicleStatusChangeRequest.ProtoReflect.Descriptor instead.
+func (*ValidateVehicleStatusChangeRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ValidateVehicleStatusChangeRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *ValidateVehicleStatusChangeRequest) GetStatus() VehicleStatus {
+	if x != nil {
+		return x.Status
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+func (x *ValidateVehicleStatusChangeRequest) GetAdminOverride() bool {
+	if x != nil {
+		return x.AdminOverride
+	}
+	return false
+}
+
+type ValidateVehicleStatusChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Reasons       []string               `protobuf:"bytes,2,rep,name=reasons,proto3" json:"reasons,omitempty"` // why the change is blocked; empty when allowed
+	CurrentStatus VehicleStatus          `protobuf:"varint,3,opt,name=current_status,json=currentStatus,proto3,enum=vehicle.VehicleStatus" json:"current_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateVehicleStatusChangeResponse) Reset() {
+	*x = ValidateVehicleStatusChangeResponse{}
+	mi := &file_vehicle_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateVehicleStatusChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateVehicleStatusChangeResponse) ProtoMessage() {}
+
+func (x *ValidateVehicleStatusChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateVehicleStatusChangeResponse.ProtoReflect.Descriptor instead.
+func (*ValidateVehicleStatusChangeResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ValidateVehicleStatusChangeResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *ValidateVehicleStatusChangeResponse) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ValidateVehicleStatusChangeResponse) GetCurrentStatus() VehicleStatus {
+	if x != nil {
+		return x.CurrentStatus
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+// Hands an ACTIVE vehicle to a driver, moving it to ASSIGNED
+type AssignVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"` // staff service driver ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignVehicleRequest) Reset() {
+	*x = AssignVehicleRequest{}
+	mi := &file_vehicle_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignVehicleRequest) ProtoMessage() {}
+
+func (x *AssignVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignVehicleRequest.ProtoReflect.Descriptor instead.
+func (*AssignVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AssignVehicleRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *AssignVehicleRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type AssignVehicleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	Assignment    *VehicleAssignment     `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignVehicleResponse) Reset() {
+	*x = AssignVehicleResponse{}
+	mi := &file_vehicle_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignVehicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignVehicleResponse) ProtoMessage() {}
+
+func (x *AssignVehicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignVehicleResponse.ProtoReflect.Descriptor instead.
+func (*AssignVehicleResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AssignVehicleResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *AssignVehicleResponse) GetAssignment() *VehicleAssignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+// A driver's hold on a vehicle. It stays open until the vehicle is handed back.
+type VehicleAssignment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VehicleId  string                 `protobuf:"bytes,2,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	DriverId   string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	AssignedBy string                 `protobuf:"bytes,4,opt,name=assigned_by,json=assignedBy,proto3" json:"assigned_by,omitempty"`
+	AssignedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	// Set when the assignment needs a dispatcher, e.g. its driver was suspended while
+	// still holding the vehicle. Empty/unset otherwise.
+	AttentionReason string                 `protobuf:"bytes,6,opt,name=attention_reason,json=attentionReason,proto3" json:"attention_reason,omitempty"`
+	FlaggedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=flagged_at,json=flaggedAt,proto3" json:"flagged_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VehicleAssignment) Reset() {
+	*x = VehicleAssignment{}
+	mi := &file_vehicle_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleAssignment) ProtoMessage() {}
+
+func (x *VehicleAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleAssignment.ProtoReflect.Descriptor instead.
+func (*VehicleAssignment) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *VehicleAssignment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VehicleAssignment) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *VehicleAssignment) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *VehicleAssignment) GetAssignedBy() string {
+	if x != nil {
+		return x.AssignedBy
+	}
+	return ""
+}
+
+func (x *VehicleAssignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *VehicleAssignment) GetAttentionReason() string {
+	if x != nil {
+		return x.AttentionReason
+	}
+	return ""
+}
+
+func (x *VehicleAssignment) GetFlaggedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FlaggedAt
+	}
+	return nil
+}
+
+// The vehicle a driver currently holds. NOT_FOUND when the driver has no open assignment.
+type GetDriverAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"` // staff service driver ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverAssignmentRequest) Reset() {
+	*x = GetDriverAssignmentRequest{}
+	mi := &file_vehicle_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverAssignmentRequest) ProtoMessage() {}
+
+func (x *GetDriverAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetDriverAssignmentRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type GetDriverAssignmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	Assignment    *VehicleAssignment     `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverAssignmentResponse) Reset() {
+	*x = GetDriverAssignmentResponse{}
+	mi := &file_vehicle_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VehicleInput) String() string {
+func (x *GetDriverAssignmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VehicleInput) ProtoMessage() {}
+func (*GetDriverAssignmentResponse) ProtoMessage() {}
 
-func (x *VehicleInput) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[7]
+func (x *GetDriverAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,117 +3048,153 @@ func (x *VehicleInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VehicleInput.ProtoReflect.Descriptor instead.
-func (*VehicleInput) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetDriverAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *VehicleInput) GetVehicleTypeId() string {
+func (x *GetDriverAssignmentResponse) GetVehicle() *Vehicle {
 	if x != nil {
-		return x.VehicleTypeId
+		return x.Vehicle
 	}
-	return ""
+	return nil
 }
 
-func (x *VehicleInput) GetLicensePlate() string {
+func (x *GetDriverAssignmentResponse) GetAssignment() *VehicleAssignment {
 	if x != nil {
-		return x.LicensePlate
+		return x.Assignment
 	}
-	return ""
+	return nil
 }
 
-func (x *VehicleInput) GetMake() string {
-	if x != nil {
-		return x.Make
-	}
-	return ""
+// Marks the driver's open assignment for dispatcher attention. NOT_FOUND when the
+// driver has no open assignment.
+type FlagDriverAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"` // staff service driver ID
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VehicleInput) GetModel() string {
-	if x != nil {
-		return x.Model
-	}
-	return ""
+func (x *FlagDriverAssignmentRequest) Reset() {
+	*x = FlagDriverAssignmentRequest{}
+	mi := &file_vehicle_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *VehicleInput) GetYear() int32 {
-	if x != nil {
-		return x.Year
-	}
-	return 0
+func (x *FlagDriverAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *VehicleInput) GetColor() string {
-	if x != nil {
-		return x.Color
-	}
-	return ""
-}
+func (*FlagDriverAssignmentRequest) ProtoMessage() {}
 
-func (x *VehicleInput) GetSeatingCapacity() int32 {
+func (x *FlagDriverAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[46]
 	if x != nil {
-		return x.SeatingCapacity
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *VehicleInput) GetFuelType() FuelType {
-	if x != nil {
-		return x.FuelType
-	}
-	return FuelType_FUEL_UNSPECIFIED
+// Deprecated: Use FlagDriverAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*FlagDriverAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *VehicleInput) GetEngineNumber() string {
+func (x *FlagDriverAssignmentRequest) GetDriverId() string {
 	if x != nil {
-		return x.EngineNumber
+		return x.DriverId
 	}
 	return ""
 }
 
-func (x *VehicleInput) GetChassisNumber() string {
+func (x *FlagDriverAssignmentRequest) GetReason() string {
 	if x != nil {
-		return x.ChassisNumber
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *VehicleInput) GetRegistrationDate() *timestamppb.Timestamp {
+type FlagDriverAssignmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignment    *VehicleAssignment     `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagDriverAssignmentResponse) Reset() {
+	*x = FlagDriverAssignmentResponse{}
+	mi := &file_vehicle_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagDriverAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagDriverAssignmentResponse) ProtoMessage() {}
+
+func (x *FlagDriverAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[47]
 	if x != nil {
-		return x.RegistrationDate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *VehicleInput) GetInsuranceExpiry() *timestamppb.Timestamp {
+// Deprecated: Use FlagDriverAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*FlagDriverAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *FlagDriverAssignmentResponse) GetAssignment() *VehicleAssignment {
 	if x != nil {
-		return x.InsuranceExpiry
+		return x.Assignment
 	}
 	return nil
 }
 
-type CreateVehicleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateVehicleResponse) Reset() {
-	*x = CreateVehicleResponse{}
-	mi := &file_vehicle_proto_msgTypes[8]
+// One status transition. A previous_status of STATUS_UNSPECIFIED marks a
+// transition out of an unrecognized status.
+type VehicleStatusHistoryEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VehicleId      string                 `protobuf:"bytes,2,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	PreviousStatus VehicleStatus          `protobuf:"varint,3,opt,name=previous_status,json=previousStatus,proto3,enum=vehicle.VehicleStatus" json:"previous_status,omitempty"`
+	NewStatus      VehicleStatus          `protobuf:"varint,4,opt,name=new_status,json=newStatus,proto3,enum=vehicle.VehicleStatus" json:"new_status,omitempty"`
+	Reason         string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedBy      string                 `protobuf:"bytes,6,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"` // user ID, or "system"
+	ChangedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VehicleStatusHistoryEntry) Reset() {
+	*x = VehicleStatusHistoryEntry{}
+	mi := &file_vehicle_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateVehicleResponse) String() string {
+func (x *VehicleStatusHistoryEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVehicleResponse) ProtoMessage() {}
+func (*VehicleStatusHistoryEntry) ProtoMessage() {}
 
-func (x *CreateVehicleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[8]
+func (x *VehicleStatusHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,40 +3205,85 @@ func (x *CreateVehicleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVehicleResponse.ProtoReflect.Descriptor instead.
-func (*CreateVehicleResponse) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use VehicleStatusHistoryEntry.ProtoReflect.Descriptor instead.
+func (*VehicleStatusHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *CreateVehicleResponse) GetVehicle() *Vehicle {
+func (x *VehicleStatusHistoryEntry) GetId() string {
 	if x != nil {
-		return x.Vehicle
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VehicleStatusHistoryEntry) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *VehicleStatusHistoryEntry) GetPreviousStatus() VehicleStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+func (x *VehicleStatusHistoryEntry) GetNewStatus() VehicleStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return VehicleStatus_STATUS_UNSPECIFIED
+}
+
+func (x *VehicleStatusHistoryEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VehicleStatusHistoryEntry) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *VehicleStatusHistoryEntry) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
 	}
 	return nil
 }
 
-type GetVehicleRequest struct {
+// Status transitions of a vehicle, most recent first
+type GetVehicleStatusHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVehicleRequest) Reset() {
-	*x = GetVehicleRequest{}
-	mi := &file_vehicle_proto_msgTypes[9]
+func (x *GetVehicleStatusHistoryRequest) Reset() {
+	*x = GetVehicleStatusHistoryRequest{}
+	mi := &file_vehicle_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVehicleRequest) String() string {
+func (x *GetVehicleStatusHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVehicleRequest) ProtoMessage() {}
+func (*GetVehicleStatusHistoryRequest) ProtoMessage() {}
 
-func (x *GetVehicleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[9]
+func (x *GetVehicleStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -831,40 +3294,55 @@ func (x *GetVehicleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVehicleRequest.ProtoReflect.Descriptor instead.
-func (*GetVehicleRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetVehicleStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *GetVehicleRequest) GetVehicleId() string {
+func (x *GetVehicleStatusHistoryRequest) GetVehicleId() string {
 	if x != nil {
 		return x.VehicleId
 	}
 	return ""
 }
 
-type GetVehicleResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+func (x *GetVehicleStatusHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetVehicleStatusHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetVehicleStatusHistoryResponse struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Entries       []*VehicleStatusHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                       `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVehicleResponse) Reset() {
-	*x = GetVehicleResponse{}
-	mi := &file_vehicle_proto_msgTypes[10]
+func (x *GetVehicleStatusHistoryResponse) Reset() {
+	*x = GetVehicleStatusHistoryResponse{}
+	mi := &file_vehicle_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVehicleResponse) String() string {
+func (x *GetVehicleStatusHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVehicleResponse) ProtoMessage() {}
+func (*GetVehicleStatusHistoryResponse) ProtoMessage() {}
 
-func (x *GetVehicleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[10]
+func (x *GetVehicleStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -875,44 +3353,51 @@ func (x *GetVehicleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVehicleResponse.ProtoReflect.Descriptor instead.
-func (*GetVehicleResponse) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use GetVehicleStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetVehicleStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *GetVehicleResponse) GetVehicle() *Vehicle {
+func (x *GetVehicleStatusHistoryResponse) GetEntries() []*VehicleStatusHistoryEntry {
 	if x != nil {
-		return x.Vehicle
+		return x.Entries
 	}
 	return nil
 }
 
-type ListVehiclesRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	PageSize          int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken         string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	StatusFilter      *VehicleStatus         `protobuf:"varint,3,opt,name=status_filter,json=statusFilter,proto3,enum=vehicle.VehicleStatus,oneof" json:"status_filter,omitempty"`
-	VehicleTypeFilter *string                `protobuf:"bytes,4,opt,name=vehicle_type_filter,json=vehicleTypeFilter,proto3,oneof" json:"vehicle_type_filter,omitempty"`
-	MakeFilter        *string                `protobuf:"bytes,5,opt,name=make_filter,json=makeFilter,proto3,oneof" json:"make_filter,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *GetVehicleStatusHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
 }
 
-func (x *ListVehiclesRequest) Reset() {
-	*x = ListVehiclesRequest{}
-	mi := &file_vehicle_proto_msgTypes[11]
+// Buckets are aligned to UTC days (or Monday-started weeks) and cover [from, to).
+// to is capped at the current time, so the last bucket may be partial.
+type GetFleetUtilizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Granularity   UtilizationGranularity `protobuf:"varint,3,opt,name=granularity,proto3,enum=vehicle.UtilizationGranularity" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFleetUtilizationRequest) Reset() {
+	*x = GetFleetUtilizationRequest{}
+	mi := &file_vehicle_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListVehiclesRequest) String() string {
+func (x *GetFleetUtilizationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVehiclesRequest) ProtoMessage() {}
+func (*GetFleetUtilizationRequest) ProtoMessage() {}
 
-func (x *ListVehiclesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[11]
+func (x *GetFleetUtilizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -923,70 +3408,60 @@ func (x *ListVehiclesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVehiclesRequest.ProtoReflect.Descriptor instead.
-func (*ListVehiclesRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetFleetUtilizationRequest.ProtoReflect.Descriptor instead.
+func (*GetFleetUtilizationRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListVehiclesRequest) GetPageSize() int32 {
+func (x *GetFleetUtilizationRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PageSize
+		return x.From
 	}
-	return 0
+	return nil
 }
 
-func (x *ListVehiclesRequest) GetPageToken() string {
+func (x *GetFleetUtilizationRequest) GetTo() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PageToken
-	}
-	return ""
-}
-
-func (x *ListVehiclesRequest) GetStatusFilter() VehicleStatus {
-	if x != nil && x.StatusFilter != nil {
-		return *x.StatusFilter
-	}
-	return VehicleStatus_STATUS_UNSPECIFIED
-}
-
-func (x *ListVehiclesRequest) GetVehicleTypeFilter() string {
-	if x != nil && x.VehicleTypeFilter != nil {
-		return *x.VehicleTypeFilter
+		return x.To
 	}
-	return ""
+	return nil
 }
 
-func (x *ListVehiclesRequest) GetMakeFilter() string {
-	if x != nil && x.MakeFilter != nil {
-		return *x.MakeFilter
+func (x *GetFleetUtilizationRequest) GetGranularity() UtilizationGranularity {
+	if x != nil {
+		return x.Granularity
 	}
-	return ""
+	return UtilizationGranularity_GRANULARITY_UNSPECIFIED
 }
 
-type ListVehiclesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vehicles      []*Vehicle             `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Vehicle counts are time-weighted averages over the bucket, e.g. a vehicle
+// ASSIGNED for half a day contributes 0.5 to assigned_vehicles for that day
+type UtilizationBucket struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Start               *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	ActiveVehicles      float64                `protobuf:"fixed64,2,opt,name=active_vehicles,json=activeVehicles,proto3" json:"active_vehicles,omitempty"`
+	AssignedVehicles    float64                `protobuf:"fixed64,3,opt,name=assigned_vehicles,json=assignedVehicles,proto3" json:"assigned_vehicles,omitempty"`
+	MaintenanceVehicles float64                `protobuf:"fixed64,4,opt,name=maintenance_vehicles,json=maintenanceVehicles,proto3" json:"maintenance_vehicles,omitempty"`
+	UtilizationPercent  float64                `protobuf:"fixed64,5,opt,name=utilization_percent,json=utilizationPercent,proto3" json:"utilization_percent,omitempty"` // assigned / (active + assigned), 0 when neither
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *ListVehiclesResponse) Reset() {
-	*x = ListVehiclesResponse{}
-	mi := &file_vehicle_proto_msgTypes[12]
+func (x *UtilizationBucket) Reset() {
+	*x = UtilizationBucket{}
+	mi := &file_vehicle_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListVehiclesResponse) String() string {
+func (x *UtilizationBucket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVehiclesResponse) ProtoMessage() {}
+func (*UtilizationBucket) ProtoMessage() {}
 
-func (x *ListVehiclesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[12]
+func (x *UtilizationBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,56 +3472,68 @@ func (x *ListVehiclesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVehiclesResponse.ProtoReflect.Descriptor instead.
-func (*ListVehiclesResponse) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UtilizationBucket.ProtoReflect.Descriptor instead.
+func (*UtilizationBucket) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *ListVehiclesResponse) GetVehicles() []*Vehicle {
+func (x *UtilizationBucket) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Vehicles
+		return x.Start
 	}
 	return nil
 }
 
-func (x *ListVehiclesResponse) GetNextPageToken() string {
+func (x *UtilizationBucket) GetActiveVehicles() float64 {
 	if x != nil {
-		return x.NextPageToken
+		return x.ActiveVehicles
 	}
-	return ""
+	return 0
 }
 
-func (x *ListVehiclesResponse) GetTotalCount() int32 {
+func (x *UtilizationBucket) GetAssignedVehicles() float64 {
 	if x != nil {
-		return x.TotalCount
+		return x.AssignedVehicles
 	}
 	return 0
 }
 
-type UpdateVehicleRequest struct {
+func (x *UtilizationBucket) GetMaintenanceVehicles() float64 {
+	if x != nil {
+		return x.MaintenanceVehicles
+	}
+	return 0
+}
+
+func (x *UtilizationBucket) GetUtilizationPercent() float64 {
+	if x != nil {
+		return x.UtilizationPercent
+	}
+	return 0
+}
+
+type GetFleetUtilizationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
-	Vehicle       *VehicleInput          `protobuf:"bytes,2,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Buckets       []*UtilizationBucket   `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateVehicleRequest) Reset() {
-	*x = UpdateVehicleRequest{}
-	mi := &file_vehicle_proto_msgTypes[13]
+func (x *GetFleetUtilizationResponse) Reset() {
+	*x = GetFleetUtilizationResponse{}
+	mi := &file_vehicle_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateVehicleRequest) String() string {
+func (x *GetFleetUtilizationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateVehicleRequest) ProtoMessage() {}
+func (*GetFleetUtilizationResponse) ProtoMessage() {}
 
-func (x *UpdateVehicleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[13]
+func (x *GetFleetUtilizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,54 +3544,40 @@ func (x *UpdateVehicleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateVehicleRequest.ProtoReflect.Descriptor instead.
-func (*UpdateVehicleRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *UpdateVehicleRequest) GetVehicleId() string {
-	if x != nil {
-		return x.VehicleId
-	}
-	return ""
-}
-
-func (x *UpdateVehicleRequest) GetVehicle() *VehicleInput {
-	if x != nil {
-		return x.Vehicle
-	}
-	return nil
+// Deprecated: Use GetFleetUtilizationResponse.ProtoReflect.Descriptor instead.
+func (*GetFleetUtilizationResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *UpdateVehicleRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *GetFleetUtilizationResponse) GetBuckets() []*UtilizationBucket {
 	if x != nil {
-		return x.UpdateMask
+		return x.Buckets
 	}
 	return nil
 }
 
-type UpdateVehicleResponse struct {
+type ValidateLicensePlateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	LicensePlate  string                 `protobuf:"bytes,1,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateVehicleResponse) Reset() {
-	*x = UpdateVehicleResponse{}
-	mi := &file_vehicle_proto_msgTypes[14]
+func (x *ValidateLicensePlateRequest) Reset() {
+	*x = ValidateLicensePlateRequest{}
+	mi := &file_vehicle_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateVehicleResponse) String() string {
+func (x *ValidateLicensePlateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateVehicleResponse) ProtoMessage() {}
+func (*ValidateLicensePlateRequest) ProtoMessage() {}
 
-func (x *UpdateVehicleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[14]
+func (x *ValidateLicensePlateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1115,40 +3588,43 @@ func (x *UpdateVehicleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateVehicleResponse.ProtoReflect.Descriptor instead.
-func (*UpdateVehicleResponse) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ValidateLicensePlateRequest.ProtoReflect.Descriptor instead.
+func (*ValidateLicensePlateRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *UpdateVehicleResponse) GetVehicle() *Vehicle {
+func (x *ValidateLicensePlateRequest) GetLicensePlate() string {
 	if x != nil {
-		return x.Vehicle
+		return x.LicensePlate
 	}
-	return nil
+	return ""
 }
 
-type DeleteVehicleRequest struct {
+// Result of a format check. Invalid input is reported here rather than as an error.
+type FieldValidationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Normalized    string                 `protobuf:"bytes,2,opt,name=normalized,proto3" json:"normalized,omitempty"` // value as it would be stored
+	Errors        []string               `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`         // empty when valid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteVehicleRequest) Reset() {
-	*x = DeleteVehicleRequest{}
-	mi := &file_vehicle_proto_msgTypes[15]
+func (x *FieldValidationResponse) Reset() {
+	*x = FieldValidationResponse{}
+	mi := &file_vehicle_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteVehicleRequest) String() string {
+func (x *FieldValidationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteVehicleRequest) ProtoMessage() {}
+func (*FieldValidationResponse) ProtoMessage() {}
 
-func (x *DeleteVehicleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[15]
+func (x *FieldValidationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1159,43 +3635,57 @@ func (x *DeleteVehicleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteVehicleRequest.ProtoReflect.Descriptor instead.
-func (*DeleteVehicleRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use FieldValidationResponse.ProtoReflect.Descriptor instead.
+func (*FieldValidationResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *DeleteVehicleRequest) GetVehicleId() string {
+func (x *FieldValidationResponse) GetValid() bool {
 	if x != nil {
-		return x.VehicleId
+		return x.Valid
+	}
+	return false
+}
+
+func (x *FieldValidationResponse) GetNormalized() string {
+	if x != nil {
+		return x.Normalized
 	}
 	return ""
 }
 
-type GetVehiclesByTypeRequest struct {
+func (x *FieldValidationResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// Re-applies the current normalization rules to every stored vehicle, for records
+// saved before the rules tightened. Vehicles are read in batches of batch_size.
+type NormalizeLegacyRecordsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VehicleTypeId string                 `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3" json:"vehicle_type_id,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	StatusFilter  *VehicleStatus         `protobuf:"varint,4,opt,name=status_filter,json=statusFilter,proto3,enum=vehicle.VehicleStatus,oneof" json:"status_filter,omitempty"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`          // report what would change without writing anything
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // default 200, at most 1000
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetVehiclesByTypeRequest) Reset() {
-	*x = GetVehiclesByTypeRequest{}
-	mi := &file_vehicle_proto_msgTypes[16]
+func (x *NormalizeLegacyRecordsRequest) Reset() {
+	*x = NormalizeLegacyRecordsRequest{}
+	mi := &file_vehicle_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVehiclesByTypeRequest) String() string {
+func (x *NormalizeLegacyRecordsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVehiclesByTypeRequest) ProtoMessage() {}
+func (*NormalizeLegacyRecordsRequest) ProtoMessage() {}
 
-func (x *GetVehiclesByTypeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[16]
+func (x *NormalizeLegacyRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,63 +3696,49 @@ func (x *GetVehiclesByTypeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVehiclesByTypeRequest.ProtoReflect.Descriptor instead.
-func (*GetVehiclesByTypeRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use NormalizeLegacyRecordsRequest.ProtoReflect.Descriptor instead.
+func (*NormalizeLegacyRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetVehiclesByTypeRequest) GetVehicleTypeId() string {
+func (x *NormalizeLegacyRecordsRequest) GetDryRun() bool {
 	if x != nil {
-		return x.VehicleTypeId
+		return x.DryRun
 	}
-	return ""
+	return false
 }
 
-func (x *GetVehiclesByTypeRequest) GetPageSize() int32 {
+func (x *NormalizeLegacyRecordsRequest) GetBatchSize() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.BatchSize
 	}
 	return 0
 }
 
-func (x *GetVehiclesByTypeRequest) GetPageToken() string {
-	if x != nil {
-		return x.PageToken
-	}
-	return ""
-}
-
-func (x *GetVehiclesByTypeRequest) GetStatusFilter() VehicleStatus {
-	if x != nil && x.StatusFilter != nil {
-		return *x.StatusFilter
-	}
-	return VehicleStatus_STATUS_UNSPECIFIED
-}
-
-type GetAvailableVehiclesRequest struct {
+type NormalizedField struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VehicleTypeId *string                `protobuf:"bytes,1,opt,name=vehicle_type_id,json=vehicleTypeId,proto3,oneof" json:"vehicle_type_id,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAvailableVehiclesRequest) Reset() {
-	*x = GetAvailableVehiclesRequest{}
-	mi := &file_vehicle_proto_msgTypes[17]
+func (x *NormalizedField) Reset() {
+	*x = NormalizedField{}
+	mi := &file_vehicle_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAvailableVehiclesRequest) String() string {
+func (x *NormalizedField) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAvailableVehiclesRequest) ProtoMessage() {}
+func (*NormalizedField) ProtoMessage() {}
 
-func (x *GetAvailableVehiclesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[17]
+func (x *NormalizedField) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1273,55 +3749,56 @@ func (x *GetAvailableVehiclesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAvailableVehiclesRequest.ProtoReflect.Descriptor instead.
-func (*GetAvailableVehiclesRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use NormalizedField.ProtoReflect.Descriptor instead.
+func (*NormalizedField) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *GetAvailableVehiclesRequest) GetVehicleTypeId() string {
-	if x != nil && x.VehicleTypeId != nil {
-		return *x.VehicleTypeId
+func (x *NormalizedField) GetField() string {
+	if x != nil {
+		return x.Field
 	}
 	return ""
 }
 
-func (x *GetAvailableVehiclesRequest) GetPageSize() int32 {
+func (x *NormalizedField) GetOldValue() string {
 	if x != nil {
-		return x.PageSize
+		return x.OldValue
 	}
-	return 0
+	return ""
 }
 
-func (x *GetAvailableVehiclesRequest) GetPageToken() string {
+func (x *NormalizedField) GetNewValue() string {
 	if x != nil {
-		return x.PageToken
+		return x.NewValue
 	}
 	return ""
 }
 
-type UpdateVehicleStatusRequest struct {
+type NormalizedRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
-	Status        VehicleStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=vehicle.VehicleStatus" json:"status,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fields        []*NormalizedField     `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // set when the record could not be updated, e.g. its normalized plate is taken
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateVehicleStatusRequest) Reset() {
-	*x = UpdateVehicleStatusRequest{}
-	mi := &file_vehicle_proto_msgTypes[18]
+func (x *NormalizedRecord) Reset() {
+	*x = NormalizedRecord{}
+	mi := &file_vehicle_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateVehicleStatusRequest) String() string {
+func (x *NormalizedRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateVehicleStatusRequest) ProtoMessage() {}
+func (*NormalizedRecord) ProtoMessage() {}
 
-func (x *UpdateVehicleStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[18]
+func (x *NormalizedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1332,47 +3809,59 @@ func (x *UpdateVehicleStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateVehicleStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateVehicleStatusRequest) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use NormalizedRecord.ProtoReflect.Descriptor instead.
+func (*NormalizedRecord) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *UpdateVehicleStatusRequest) GetVehicleId() string {
+func (x *NormalizedRecord) GetId() string {
 	if x != nil {
-		return x.VehicleId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateVehicleStatusRequest) GetStatus() VehicleStatus {
+func (x *NormalizedRecord) GetFields() []*NormalizedField {
 	if x != nil {
-		return x.Status
+		return x.Fields
 	}
-	return VehicleStatus_STATUS_UNSPECIFIED
+	return nil
 }
 
-type UpdateVehicleStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *NormalizedRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x *UpdateVehicleStatusResponse) Reset() {
-	*x = UpdateVehicleStatusResponse{}
-	mi := &file_vehicle_proto_msgTypes[19]
+type NormalizeLegacyRecordsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DryRun           bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Scanned          int32                  `protobuf:"varint,2,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Changed          int32                  `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"` // records updated, or that would be on a dry run
+	Failed           int32                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	Records          []*NormalizedRecord    `protobuf:"bytes,5,rep,name=records,proto3" json:"records,omitempty"`                                            // changed and failed records, at most 500
+	RecordsTruncated bool                   `protobuf:"varint,6,opt,name=records_truncated,json=recordsTruncated,proto3" json:"records_truncated,omitempty"` // more records changed than are listed
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NormalizeLegacyRecordsResponse) Reset() {
+	*x = NormalizeLegacyRecordsResponse{}
+	mi := &file_vehicle_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateVehicleStatusResponse) String() string {
+func (x *NormalizeLegacyRecordsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateVehicleStatusResponse) ProtoMessage() {}
+func (*NormalizeLegacyRecordsResponse) ProtoMessage() {}
 
-func (x *UpdateVehicleStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_vehicle_proto_msgTypes[19]
+func (x *NormalizeLegacyRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicle_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1383,18 +3872,53 @@ func (x *UpdateVehicleStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateVehicleStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateVehicleStatusResponse) Descriptor() ([]byte, []int) {
-	return file_vehicle_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use NormalizeLegacyRecordsResponse.ProtoReflect.Descriptor instead.
+func (*NormalizeLegacyRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_vehicle_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *UpdateVehicleStatusResponse) GetVehicle() *Vehicle {
+func (x *NormalizeLegacyRecordsResponse) GetDryRun() bool {
 	if x != nil {
-		return x.Vehicle
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *NormalizeLegacyRecordsResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *NormalizeLegacyRecordsResponse) GetChanged() int32 {
+	if x != nil {
+		return x.Changed
+	}
+	return 0
+}
+
+func (x *NormalizeLegacyRecordsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *NormalizeLegacyRecordsResponse) GetRecords() []*NormalizedRecord {
+	if x != nil {
+		return x.Records
 	}
 	return nil
 }
 
+func (x *NormalizeLegacyRecordsResponse) GetRecordsTruncated() bool {
+	if x != nil {
+		return x.RecordsTruncated
+	}
+	return false
+}
+
 var File_vehicle_proto protoreflect.FileDescriptor
 
 const file_vehicle_proto_rawDesc = "" +
@@ -1405,19 +3929,37 @@ const file_vehicle_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x129\n" +
 	"\n" +
-	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"P\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"u\n" +
 	"\x18CreateVehicleTypeRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\"T\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12#\n" +
+	"\rensure_exists\x18\x03 \x01(\bR\fensureExists\"n\n" +
 	"\x19CreateVehicleTypeResponse\x127\n" +
-	"\fvehicle_type\x18\x01 \x01(\v2\x14.vehicle.VehicleTypeR\vvehicleType\"U\n" +
+	"\fvehicle_type\x18\x01 \x01(\v2\x14.vehicle.VehicleTypeR\vvehicleType\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"U\n" +
 	"\x17ListVehicleTypesRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x02 \x01(\tR\tpageToken\"}\n" +
 	"\x18ListVehicleTypesResponse\x129\n" +
 	"\rvehicle_types\x18\x01 \x03(\v2\x14.vehicle.VehicleTypeR\fvehicleTypes\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd7\x05\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"'\n" +
+	"\x15GetVehicleTypeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
+	"\x1bGetVehicleTypeByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Q\n" +
+	"\x16GetVehicleTypeResponse\x127\n" +
+	"\fvehicle_type\x18\x01 \x01(\v2\x14.vehicle.VehicleTypeR\vvehicleType\"\x83\x01\n" +
+	"\x18UpdateVehicleTypeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_description\"T\n" +
+	"\x19UpdateVehicleTypeResponse\x127\n" +
+	"\fvehicle_type\x18\x01 \x01(\v2\x14.vehicle.VehicleTypeR\vvehicleType\"*\n" +
+	"\x18DeleteVehicleTypeRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xdb\a\n" +
 	"\aVehicle\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
 	"\x0fvehicle_type_id\x18\x02 \x01(\tR\rvehicleTypeId\x12*\n" +
@@ -1438,10 +3980,21 @@ const file_vehicle_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
 	"\n" +
-	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01B\r\n" +
-	"\v_updated_at\"G\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"updated_by\x18\x12 \x01(\tH\x01R\tupdatedBy\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"mileage_km\x18\x13 \x01(\x05H\x02R\tmileageKm\x88\x01\x01\x12+\n" +
+	"\x0flast_service_km\x18\x14 \x01(\x05H\x03R\rlastServiceKm\x88\x01\x01\x12G\n" +
+	"\x0flast_service_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\rlastServiceAt\x88\x01\x01B\r\n" +
+	"\v_updated_atB\r\n" +
+	"\v_updated_byB\r\n" +
+	"\v_mileage_kmB\x12\n" +
+	"\x10_last_service_kmB\x12\n" +
+	"\x10_last_service_at\"n\n" +
 	"\x14CreateVehicleRequest\x12/\n" +
-	"\avehicle\x18\x01 \x01(\v2\x15.vehicle.VehicleInputR\avehicle\"\xe6\x03\n" +
+	"\avehicle\x18\x01 \x01(\v2\x15.vehicle.VehicleInputR\avehicle\x12%\n" +
+	"\x0eadmin_override\x18\x02 \x01(\bR\radminOverride\"\xe6\x03\n" +
 	"\fVehicleInput\x12&\n" +
 	"\x0fvehicle_type_id\x18\x01 \x01(\tR\rvehicleTypeId\x12#\n" +
 	"\rlicense_plate\x18\x02 \x01(\tR\flicensePlate\x12\x12\n" +
@@ -1462,7 +4015,18 @@ const file_vehicle_proto_rawDesc = "" +
 	"\n" +
 	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\"@\n" +
 	"\x12GetVehicleResponse\x12*\n" +
-	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"\xa8\x02\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"I\n" +
+	" GetVehicleByChassisNumberRequest\x12%\n" +
+	"\x0echassis_number\x18\x01 \x01(\tR\rchassisNumber\":\n" +
+	"\x17BatchGetVehiclesRequest\x12\x1f\n" +
+	"\vvehicle_ids\x18\x01 \x03(\tR\n" +
+	"vehicleIds\"\xda\x01\n" +
+	"\x18BatchGetVehiclesResponse\x12K\n" +
+	"\bvehicles\x18\x01 \x03(\v2/.vehicle.BatchGetVehiclesResponse.VehiclesEntryR\bvehicles\x12\"\n" +
+	"\rnot_found_ids\x18\x02 \x03(\tR\vnotFoundIds\x1aM\n" +
+	"\rVehiclesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.vehicle.VehicleR\x05value:\x028\x01\"\xbb\x03\n" +
 	"\x13ListVehiclesRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
@@ -1470,23 +4034,36 @@ const file_vehicle_proto_rawDesc = "" +
 	"\rstatus_filter\x18\x03 \x01(\x0e2\x16.vehicle.VehicleStatusH\x00R\fstatusFilter\x88\x01\x01\x123\n" +
 	"\x13vehicle_type_filter\x18\x04 \x01(\tH\x01R\x11vehicleTypeFilter\x88\x01\x01\x12$\n" +
 	"\vmake_filter\x18\x05 \x01(\tH\x02R\n" +
-	"makeFilter\x88\x01\x01B\x10\n" +
+	"makeFilter\x88\x01\x01\x121\n" +
+	"\n" +
+	"make_match\x18\x06 \x01(\x0e2\x12.vehicle.MakeMatchR\tmakeMatch\x12\x17\n" +
+	"\asort_by\x18\a \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\b \x01(\tR\tsortOrder\x12\x1b\n" +
+	"\x06search\x18\t \x01(\tH\x03R\x06search\x88\x01\x01B\x10\n" +
 	"\x0e_status_filterB\x16\n" +
 	"\x14_vehicle_type_filterB\x0e\n" +
-	"\f_make_filter\"\x8d\x01\n" +
+	"\f_make_filterB\t\n" +
+	"\a_search\"\xb5\x01\n" +
 	"\x14ListVehiclesResponse\x12,\n" +
 	"\bvehicles\x18\x01 \x03(\v2\x10.vehicle.VehicleR\bvehicles\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"\xa3\x01\n" +
+	"totalCount\x12&\n" +
+	"\x0fprev_page_token\x18\x04 \x01(\tR\rprevPageToken\"\xa3\x01\n" +
 	"\x14UpdateVehicleRequest\x12\x1d\n" +
 	"\n" +
 	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12/\n" +
 	"\avehicle\x18\x02 \x01(\v2\x15.vehicle.VehicleInputR\avehicle\x12;\n" +
 	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\"C\n" +
+	"updateMask\"\x99\x01\n" +
 	"\x15UpdateVehicleResponse\x12*\n" +
-	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"5\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\x12T\n" +
+	"\x16normalization_warnings\x18\x02 \x03(\v2\x1d.vehicle.NormalizationWarningR\x15normalizationWarnings\"b\n" +
+	"\x14NormalizationWarning\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1c\n" +
+	"\tsubmitted\x18\x02 \x01(\tR\tsubmitted\x12\x16\n" +
+	"\x06stored\x18\x03 \x01(\tR\x06stored\"5\n" +
 	"\x14DeleteVehicleRequest\x12\x1d\n" +
 	"\n" +
 	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\"\xd2\x01\n" +
@@ -1502,13 +4079,170 @@ const file_vehicle_proto_rawDesc = "" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
 	"page_token\x18\x03 \x01(\tR\tpageTokenB\x12\n" +
-	"\x10_vehicle_type_id\"k\n" +
+	"\x10_vehicle_type_id\"\x9e\x02\n" +
+	"\x1cGetDispatchCandidatesRequest\x12+\n" +
+	"\x0fvehicle_type_id\x18\x01 \x01(\tH\x00R\rvehicleTypeId\x88\x01\x01\x12\x1b\n" +
+	"\tmin_seats\x18\x02 \x01(\x05R\bminSeats\x12M\n" +
+	"\x12insurance_valid_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x10insuranceValidOn\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageTokenB\x12\n" +
+	"\x10_vehicle_type_idB\x15\n" +
+	"\x13_insurance_valid_on\"`\n" +
+	"\"ListRecentlyUpdatedVehiclesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\xaa\x01\n" +
 	"\x1aUpdateVehicleStatusRequest\x12\x1d\n" +
 	"\n" +
 	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12.\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x16.vehicle.VehicleStatusR\x06status\"I\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.vehicle.VehicleStatusR\x06status\x12%\n" +
+	"\x0eadmin_override\x18\x03 \x01(\bR\radminOverride\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"I\n" +
 	"\x1bUpdateVehicleStatusResponse\x12*\n" +
-	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle*_\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"[\n" +
+	"\x1bRecordVehicleMileageRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12\x1d\n" +
+	"\n" +
+	"mileage_km\x18\x02 \x01(\x05R\tmileageKm\"J\n" +
+	"\x1cRecordVehicleMileageResponse\x12*\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"\xd0\x01\n" +
+	"\x14RecordServiceRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12\"\n" +
+	"\n" +
+	"mileage_km\x18\x02 \x01(\x05H\x00R\tmileageKm\x88\x01\x01\x12@\n" +
+	"\vserviced_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"servicedAt\x88\x01\x01\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notesB\r\n" +
+	"\v_mileage_kmB\x0e\n" +
+	"\f_serviced_at\"C\n" +
+	"\x15RecordServiceResponse\x12*\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"\xd9\x01\n" +
+	"#GetVehiclesDueForMaintenanceRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12&\n" +
+	"\fkm_threshold\x18\x03 \x01(\x05H\x00R\vkmThreshold\x88\x01\x01\x12*\n" +
+	"\x0edays_threshold\x18\x04 \x01(\x05H\x01R\rdaysThreshold\x88\x01\x01B\x0f\n" +
+	"\r_km_thresholdB\x11\n" +
+	"\x0f_days_threshold\"\xaf\x01\n" +
+	"\x15MaintenanceDueVehicle\x12*\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\x12(\n" +
+	"\x10km_since_service\x18\x02 \x01(\x05R\x0ekmSinceService\x12\x1d\n" +
+	"\n" +
+	"km_overdue\x18\x03 \x01(\x05R\tkmOverdue\x12!\n" +
+	"\fdays_overdue\x18\x04 \x01(\x05R\vdaysOverdue\"\xab\x01\n" +
+	"$GetVehiclesDueForMaintenanceResponse\x12:\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x1e.vehicle.MaintenanceDueVehicleR\bvehicles\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"\x9a\x01\n" +
+	"\"ValidateVehicleStatusChangeRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.vehicle.VehicleStatusR\x06status\x12%\n" +
+	"\x0eadmin_override\x18\x03 \x01(\bR\radminOverride\"\x98\x01\n" +
+	"#ValidateVehicleStatusChangeResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\x12\x18\n" +
+	"\areasons\x18\x02 \x03(\tR\areasons\x12=\n" +
+	"\x0ecurrent_status\x18\x03 \x01(\x0e2\x16.vehicle.VehicleStatusR\rcurrentStatus\"R\n" +
+	"\x14AssignVehicleRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\"\x7f\n" +
+	"\x15AssignVehicleResponse\x12*\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\x12:\n" +
+	"\n" +
+	"assignment\x18\x02 \x01(\v2\x1a.vehicle.VehicleAssignmentR\n" +
+	"assignment\"\xa3\x02\n" +
+	"\x11VehicleAssignment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x02 \x01(\tR\tvehicleId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x1f\n" +
+	"\vassigned_by\x18\x04 \x01(\tR\n" +
+	"assignedBy\x12;\n" +
+	"\vassigned_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12)\n" +
+	"\x10attention_reason\x18\x06 \x01(\tR\x0fattentionReason\x129\n" +
+	"\n" +
+	"flagged_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tflaggedAt\"9\n" +
+	"\x1aGetDriverAssignmentRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"\x85\x01\n" +
+	"\x1bGetDriverAssignmentResponse\x12*\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\x12:\n" +
+	"\n" +
+	"assignment\x18\x02 \x01(\v2\x1a.vehicle.VehicleAssignmentR\n" +
+	"assignment\"R\n" +
+	"\x1bFlagDriverAssignmentRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"Z\n" +
+	"\x1cFlagDriverAssignmentResponse\x12:\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2\x1a.vehicle.VehicleAssignmentR\n" +
+	"assignment\"\xb4\x02\n" +
+	"\x19VehicleStatusHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x02 \x01(\tR\tvehicleId\x12?\n" +
+	"\x0fprevious_status\x18\x03 \x01(\x0e2\x16.vehicle.VehicleStatusR\x0epreviousStatus\x125\n" +
+	"\n" +
+	"new_status\x18\x04 \x01(\x0e2\x16.vehicle.VehicleStatusR\tnewStatus\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x06 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"{\n" +
+	"\x1eGetVehicleStatusHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x87\x01\n" +
+	"\x1fGetVehicleStatusHistoryResponse\x12<\n" +
+	"\aentries\x18\x01 \x03(\v2\".vehicle.VehicleStatusHistoryEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbb\x01\n" +
+	"\x1aGetFleetUtilizationRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12A\n" +
+	"\vgranularity\x18\x03 \x01(\x0e2\x1f.vehicle.UtilizationGranularityR\vgranularity\"\xff\x01\n" +
+	"\x11UtilizationBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12'\n" +
+	"\x0factive_vehicles\x18\x02 \x01(\x01R\x0eactiveVehicles\x12+\n" +
+	"\x11assigned_vehicles\x18\x03 \x01(\x01R\x10assignedVehicles\x121\n" +
+	"\x14maintenance_vehicles\x18\x04 \x01(\x01R\x13maintenanceVehicles\x12/\n" +
+	"\x13utilization_percent\x18\x05 \x01(\x01R\x12utilizationPercent\"S\n" +
+	"\x1bGetFleetUtilizationResponse\x124\n" +
+	"\abuckets\x18\x01 \x03(\v2\x1a.vehicle.UtilizationBucketR\abuckets\"B\n" +
+	"\x1bValidateLicensePlateRequest\x12#\n" +
+	"\rlicense_plate\x18\x01 \x01(\tR\flicensePlate\"g\n" +
+	"\x17FieldValidationResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x1e\n" +
+	"\n" +
+	"normalized\x18\x02 \x01(\tR\n" +
+	"normalized\x12\x16\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors\"W\n" +
+	"\x1dNormalizeLegacyRecordsRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\"a\n" +
+	"\x0fNormalizedField\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"j\n" +
+	"\x10NormalizedRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x06fields\x18\x02 \x03(\v2\x18.vehicle.NormalizedFieldR\x06fields\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xe7\x01\n" +
+	"\x1eNormalizeLegacyRecordsResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x18\n" +
+	"\ascanned\x18\x02 \x01(\x05R\ascanned\x12\x18\n" +
+	"\achanged\x18\x03 \x01(\x05R\achanged\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x123\n" +
+	"\arecords\x18\x05 \x03(\v2\x19.vehicle.NormalizedRecordR\arecords\x12+\n" +
+	"\x11records_truncated\x18\x06 \x01(\bR\x10recordsTruncated*_\n" +
 	"\rVehicleStatus\x12\x16\n" +
 	"\x12STATUS_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
@@ -1524,19 +4258,48 @@ const file_vehicle_proto_rawDesc = "" +
 	"\x06DIESEL\x10\x02\x12\f\n" +
 	"\bELECTRIC\x10\x03\x12\n" +
 	"\n" +
-	"\x06HYBRID\x10\x042\xd7\x06\n" +
+	"\x06HYBRID\x10\x04*[\n" +
+	"\tMakeMatch\x12\x1a\n" +
+	"\x16MAKE_MATCH_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"MAKE_EXACT\x10\x01\x12\x0f\n" +
+	"\vMAKE_PREFIX\x10\x02\x12\x11\n" +
+	"\rMAKE_CONTAINS\x10\x03*d\n" +
+	"\x16UtilizationGranularity\x12\x1b\n" +
+	"\x17GRANULARITY_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11GRANULARITY_DAILY\x10\x01\x12\x16\n" +
+	"\x12GRANULARITY_WEEKLY\x10\x022\x9b\x15\n" +
 	"\x0eVehicleService\x12N\n" +
 	"\rCreateVehicle\x12\x1d.vehicle.CreateVehicleRequest\x1a\x1e.vehicle.CreateVehicleResponse\x12E\n" +
 	"\n" +
-	"GetVehicle\x12\x1a.vehicle.GetVehicleRequest\x1a\x1b.vehicle.GetVehicleResponse\x12K\n" +
+	"GetVehicle\x12\x1a.vehicle.GetVehicleRequest\x1a\x1b.vehicle.GetVehicleResponse\x12W\n" +
+	"\x10BatchGetVehicles\x12 .vehicle.BatchGetVehiclesRequest\x1a!.vehicle.BatchGetVehiclesResponse\x12c\n" +
+	"\x19GetVehicleByChassisNumber\x12).vehicle.GetVehicleByChassisNumberRequest\x1a\x1b.vehicle.GetVehicleResponse\x12K\n" +
 	"\fListVehicles\x12\x1c.vehicle.ListVehiclesRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12N\n" +
 	"\rUpdateVehicle\x12\x1d.vehicle.UpdateVehicleRequest\x1a\x1e.vehicle.UpdateVehicleResponse\x12F\n" +
 	"\rDeleteVehicle\x12\x1d.vehicle.DeleteVehicleRequest\x1a\x16.google.protobuf.Empty\x12U\n" +
 	"\x11GetVehiclesByType\x12!.vehicle.GetVehiclesByTypeRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12[\n" +
-	"\x14GetAvailableVehicles\x12$.vehicle.GetAvailableVehiclesRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12`\n" +
-	"\x13UpdateVehicleStatus\x12#.vehicle.UpdateVehicleStatusRequest\x1a$.vehicle.UpdateVehicleStatusResponse\x12Z\n" +
+	"\x14GetAvailableVehicles\x12$.vehicle.GetAvailableVehiclesRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12]\n" +
+	"\x15GetDispatchCandidates\x12%.vehicle.GetDispatchCandidatesRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12i\n" +
+	"\x1bListRecentlyUpdatedVehicles\x12+.vehicle.ListRecentlyUpdatedVehiclesRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12`\n" +
+	"\x13UpdateVehicleStatus\x12#.vehicle.UpdateVehicleStatusRequest\x1a$.vehicle.UpdateVehicleStatusResponse\x12x\n" +
+	"\x1bValidateVehicleStatusChange\x12+.vehicle.ValidateVehicleStatusChangeRequest\x1a,.vehicle.ValidateVehicleStatusChangeResponse\x12l\n" +
+	"\x17GetVehicleStatusHistory\x12'.vehicle.GetVehicleStatusHistoryRequest\x1a(.vehicle.GetVehicleStatusHistoryResponse\x12c\n" +
+	"\x14RecordVehicleMileage\x12$.vehicle.RecordVehicleMileageRequest\x1a%.vehicle.RecordVehicleMileageResponse\x12N\n" +
+	"\rRecordService\x12\x1d.vehicle.RecordServiceRequest\x1a\x1e.vehicle.RecordServiceResponse\x12{\n" +
+	"\x1cGetVehiclesDueForMaintenance\x12,.vehicle.GetVehiclesDueForMaintenanceRequest\x1a-.vehicle.GetVehiclesDueForMaintenanceResponse\x12N\n" +
+	"\rAssignVehicle\x12\x1d.vehicle.AssignVehicleRequest\x1a\x1e.vehicle.AssignVehicleResponse\x12`\n" +
+	"\x13GetDriverAssignment\x12#.vehicle.GetDriverAssignmentRequest\x1a$.vehicle.GetDriverAssignmentResponse\x12c\n" +
+	"\x14FlagDriverAssignment\x12$.vehicle.FlagDriverAssignmentRequest\x1a%.vehicle.FlagDriverAssignmentResponse\x12`\n" +
+	"\x13GetFleetUtilization\x12#.vehicle.GetFleetUtilizationRequest\x1a$.vehicle.GetFleetUtilizationResponse\x12^\n" +
+	"\x14ValidateLicensePlate\x12$.vehicle.ValidateLicensePlateRequest\x1a .vehicle.FieldValidationResponse\x12i\n" +
+	"\x16NormalizeLegacyRecords\x12&.vehicle.NormalizeLegacyRecordsRequest\x1a'.vehicle.NormalizeLegacyRecordsResponse\x12Z\n" +
 	"\x11CreateVehicleType\x12!.vehicle.CreateVehicleTypeRequest\x1a\".vehicle.CreateVehicleTypeResponse\x12W\n" +
-	"\x10ListVehicleTypes\x12 .vehicle.ListVehicleTypesRequest\x1a!.vehicle.ListVehicleTypesResponseB;Z9github.com/adammwaniki/bebabeba/services/vehicle/genprotob\x06proto3"
+	"\x10ListVehicleTypes\x12 .vehicle.ListVehicleTypesRequest\x1a!.vehicle.ListVehicleTypesResponse\x12Q\n" +
+	"\x0eGetVehicleType\x12\x1e.vehicle.GetVehicleTypeRequest\x1a\x1f.vehicle.GetVehicleTypeResponse\x12]\n" +
+	"\x14GetVehicleTypeByName\x12$.vehicle.GetVehicleTypeByNameRequest\x1a\x1f.vehicle.GetVehicleTypeResponse\x12Z\n" +
+	"\x11UpdateVehicleType\x12!.vehicle.UpdateVehicleTypeRequest\x1a\".vehicle.UpdateVehicleTypeResponse\x12N\n" +
+	"\x11DeleteVehicleType\x12!.vehicle.DeleteVehicleTypeRequest\x1a\x16.google.protobuf.EmptyB;Z9github.com/adammwaniki/bebabeba/services/vehicle/genprotob\x06proto3"
 
 var (
 	file_vehicle_proto_rawDescOnce sync.Once
@@ -1550,84 +4313,198 @@ func file_vehicle_proto_rawDescGZIP() []byte {
 	return file_vehicle_proto_rawDescData
 }
 
-var file_vehicle_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_vehicle_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_vehicle_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_vehicle_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_vehicle_proto_goTypes = []any{
-	(VehicleStatus)(0),                  // 0: vehicle.VehicleStatus
-	(FuelType)(0),                       // 1: vehicle.FuelType
-	(*VehicleType)(nil),                 // 2: vehicle.VehicleType
-	(*CreateVehicleTypeRequest)(nil),    // 3: vehicle.CreateVehicleTypeRequest
-	(*CreateVehicleTypeResponse)(nil),   // 4: vehicle.CreateVehicleTypeResponse
-	(*ListVehicleTypesRequest)(nil),     // 5: vehicle.ListVehicleTypesRequest
-	(*ListVehicleTypesResponse)(nil),    // 6: vehicle.ListVehicleTypesResponse
-	(*Vehicle)(nil),                     // 7: vehicle.Vehicle
-	(*CreateVehicleRequest)(nil),        // 8: vehicle.CreateVehicleRequest
-	(*VehicleInput)(nil),                // 9: vehicle.VehicleInput
-	(*CreateVehicleResponse)(nil),       // 10: vehicle.CreateVehicleResponse
-	(*GetVehicleRequest)(nil),           // 11: vehicle.GetVehicleRequest
-	(*GetVehicleResponse)(nil),          // 12: vehicle.GetVehicleResponse
-	(*ListVehiclesRequest)(nil),         // 13: vehicle.ListVehiclesRequest
-	(*ListVehiclesResponse)(nil),        // 14: vehicle.ListVehiclesResponse
-	(*UpdateVehicleRequest)(nil),        // 15: vehicle.UpdateVehicleRequest
-	(*UpdateVehicleResponse)(nil),       // 16: vehicle.UpdateVehicleResponse
-	(*DeleteVehicleRequest)(nil),        // 17: vehicle.DeleteVehicleRequest
-	(*GetVehiclesByTypeRequest)(nil),    // 18: vehicle.GetVehiclesByTypeRequest
-	(*GetAvailableVehiclesRequest)(nil), // 19: vehicle.GetAvailableVehiclesRequest
-	(*UpdateVehicleStatusRequest)(nil),  // 20: vehicle.UpdateVehicleStatusRequest
-	(*UpdateVehicleStatusResponse)(nil), // 21: vehicle.UpdateVehicleStatusResponse
-	(*timestamppb.Timestamp)(nil),       // 22: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),       // 23: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),               // 24: google.protobuf.Empty
+	(VehicleStatus)(0),                           // 0: vehicle.VehicleStatus
+	(FuelType)(0),                                // 1: vehicle.FuelType
+	(MakeMatch)(0),                               // 2: vehicle.MakeMatch
+	(UtilizationGranularity)(0),                  // 3: vehicle.UtilizationGranularity
+	(*VehicleType)(nil),                          // 4: vehicle.VehicleType
+	(*CreateVehicleTypeRequest)(nil),             // 5: vehicle.CreateVehicleTypeRequest
+	(*CreateVehicleTypeResponse)(nil),            // 6: vehicle.CreateVehicleTypeResponse
+	(*ListVehicleTypesRequest)(nil),              // 7: vehicle.ListVehicleTypesRequest
+	(*ListVehicleTypesResponse)(nil),             // 8: vehicle.ListVehicleTypesResponse
+	(*GetVehicleTypeRequest)(nil),                // 9: vehicle.GetVehicleTypeRequest
+	(*GetVehicleTypeByNameRequest)(nil),          // 10: vehicle.GetVehicleTypeByNameRequest
+	(*GetVehicleTypeResponse)(nil),               // 11: vehicle.GetVehicleTypeResponse
+	(*UpdateVehicleTypeRequest)(nil),             // 12: vehicle.UpdateVehicleTypeRequest
+	(*UpdateVehicleTypeResponse)(nil),            // 13: vehicle.UpdateVehicleTypeResponse
+	(*DeleteVehicleTypeRequest)(nil),             // 14: vehicle.DeleteVehicleTypeRequest
+	(*Vehicle)(nil),                              // 15: vehicle.Vehicle
+	(*CreateVehicleRequest)(nil),                 // 16: vehicle.CreateVehicleRequest
+	(*VehicleInput)(nil),                         // 17: vehicle.VehicleInput
+	(*CreateVehicleResponse)(nil),                // 18: vehicle.CreateVehicleResponse
+	(*GetVehicleRequest)(nil),                    // 19: vehicle.GetVehicleRequest
+	(*GetVehicleResponse)(nil),                   // 20: vehicle.GetVehicleResponse
+	(*GetVehicleByChassisNumberRequest)(nil),     // 21: vehicle.GetVehicleByChassisNumberRequest
+	(*BatchGetVehiclesRequest)(nil),              // 22: vehicle.BatchGetVehiclesRequest
+	(*BatchGetVehiclesResponse)(nil),             // 23: vehicle.BatchGetVehiclesResponse
+	(*ListVehiclesRequest)(nil),                  // 24: vehicle.ListVehiclesRequest
+	(*ListVehiclesResponse)(nil),                 // 25: vehicle.ListVehiclesResponse
+	(*UpdateVehicleRequest)(nil),                 // 26: vehicle.UpdateVehicleRequest
+	(*UpdateVehicleResponse)(nil),                // 27: vehicle.UpdateVehicleResponse
+	(*NormalizationWarning)(nil),                 // 28: vehicle.NormalizationWarning
+	(*DeleteVehicleRequest)(nil),                 // 29: vehicle.DeleteVehicleRequest
+	(*GetVehiclesByTypeRequest)(nil),             // 30: vehicle.GetVehiclesByTypeRequest
+	(*GetAvailableVehiclesRequest)(nil),          // 31: vehicle.GetAvailableVehiclesRequest
+	(*GetDispatchCandidatesRequest)(nil),         // 32: vehicle.GetDispatchCandidatesRequest
+	(*ListRecentlyUpdatedVehiclesRequest)(nil),   // 33: vehicle.ListRecentlyUpdatedVehiclesRequest
+	(*UpdateVehicleStatusRequest)(nil),           // 34: vehicle.UpdateVehicleStatusRequest
+	(*UpdateVehicleStatusResponse)(nil),          // 35: vehicle.UpdateVehicleStatusResponse
+	(*RecordVehicleMileageRequest)(nil),          // 36: vehicle.RecordVehicleMileageRequest
+	(*RecordVehicleMileageResponse)(nil),         // 37: vehicle.RecordVehicleMileageResponse
+	(*RecordServiceRequest)(nil),                 // 38: vehicle.RecordServiceRequest
+	(*RecordServiceResponse)(nil),                // 39: vehicle.RecordServiceResponse
+	(*GetVehiclesDueForMaintenanceRequest)(nil),  // 40: vehicle.GetVehiclesDueForMaintenanceRequest
+	(*MaintenanceDueVehicle)(nil),                // 41: vehicle.MaintenanceDueVehicle
+	(*GetVehiclesDueForMaintenanceResponse)(nil), // 42: vehicle.GetVehiclesDueForMaintenanceResponse
+	(*ValidateVehicleStatusChangeRequest)(nil),   // 43: vehicle.ValidateVehicleStatusChangeRequest
+	(*ValidateVehicleStatusChangeResponse)(nil),  // 44: vehicle.ValidateVehicleStatusChangeResponse
+	(*AssignVehicleRequest)(nil),                 // 45: vehicle.AssignVehicleRequest
+	(*AssignVehicleResponse)(nil),                // 46: vehicle.AssignVehicleResponse
+	(*VehicleAssignment)(nil),                    // 47: vehicle.VehicleAssignment
+	(*GetDriverAssignmentRequest)(nil),           // 48: vehicle.GetDriverAssignmentRequest
+	(*GetDriverAssignmentResponse)(nil),          // 49: vehicle.GetDriverAssignmentResponse
+	(*FlagDriverAssignmentRequest)(nil),          // 50: vehicle.FlagDriverAssignmentRequest
+	(*FlagDriverAssignmentResponse)(nil),         // 51: vehicle.FlagDriverAssignmentResponse
+	(*VehicleStatusHistoryEntry)(nil),            // 52: vehicle.VehicleStatusHistoryEntry
+	(*GetVehicleStatusHistoryRequest)(nil),       // 53: vehicle.GetVehicleStatusHistoryRequest
+	(*GetVehicleStatusHistoryResponse)(nil),      // 54: vehicle.GetVehicleStatusHistoryResponse
+	(*GetFleetUtilizationRequest)(nil),           // 55: vehicle.GetFleetUtilizationRequest
+	(*UtilizationBucket)(nil),                    // 56: vehicle.UtilizationBucket
+	(*GetFleetUtilizationResponse)(nil),          // 57: vehicle.GetFleetUtilizationResponse
+	(*ValidateLicensePlateRequest)(nil),          // 58: vehicle.ValidateLicensePlateRequest
+	(*FieldValidationResponse)(nil),              // 59: vehicle.FieldValidationResponse
+	(*NormalizeLegacyRecordsRequest)(nil),        // 60: vehicle.NormalizeLegacyRecordsRequest
+	(*NormalizedField)(nil),                      // 61: vehicle.NormalizedField
+	(*NormalizedRecord)(nil),                     // 62: vehicle.NormalizedRecord
+	(*NormalizeLegacyRecordsResponse)(nil),       // 63: vehicle.NormalizeLegacyRecordsResponse
+	nil,                                          // 64: vehicle.BatchGetVehiclesResponse.VehiclesEntry
+	(*timestamppb.Timestamp)(nil),                // 65: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),                // 66: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                        // 67: google.protobuf.Empty
 }
 var file_vehicle_proto_depIdxs = []int32{
-	22, // 0: vehicle.VehicleType.created_at:type_name -> google.protobuf.Timestamp
-	2,  // 1: vehicle.CreateVehicleTypeResponse.vehicle_type:type_name -> vehicle.VehicleType
-	2,  // 2: vehicle.ListVehicleTypesResponse.vehicle_types:type_name -> vehicle.VehicleType
-	1,  // 3: vehicle.Vehicle.fuel_type:type_name -> vehicle.FuelType
-	22, // 4: vehicle.Vehicle.registration_date:type_name -> google.protobuf.Timestamp
-	22, // 5: vehicle.Vehicle.insurance_expiry:type_name -> google.protobuf.Timestamp
-	0,  // 6: vehicle.Vehicle.status:type_name -> vehicle.VehicleStatus
-	22, // 7: vehicle.Vehicle.created_at:type_name -> google.protobuf.Timestamp
-	22, // 8: vehicle.Vehicle.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 9: vehicle.CreateVehicleRequest.vehicle:type_name -> vehicle.VehicleInput
-	1,  // 10: vehicle.VehicleInput.fuel_type:type_name -> vehicle.FuelType
-	22, // 11: vehicle.VehicleInput.registration_date:type_name -> google.protobuf.Timestamp
-	22, // 12: vehicle.VehicleInput.insurance_expiry:type_name -> google.protobuf.Timestamp
-	7,  // 13: vehicle.CreateVehicleResponse.vehicle:type_name -> vehicle.Vehicle
-	7,  // 14: vehicle.GetVehicleResponse.vehicle:type_name -> vehicle.Vehicle
-	0,  // 15: vehicle.ListVehiclesRequest.status_filter:type_name -> vehicle.VehicleStatus
-	7,  // 16: vehicle.ListVehiclesResponse.vehicles:type_name -> vehicle.Vehicle
-	9,  // 17: vehicle.UpdateVehicleRequest.vehicle:type_name -> vehicle.VehicleInput
-	23, // 18: vehicle.UpdateVehicleRequest.update_mask:type_name -> google.protobuf.FieldMask
-	7,  // 19: vehicle.UpdateVehicleResponse.vehicle:type_name -> vehicle.Vehicle
-	0,  // 20: vehicle.GetVehiclesByTypeRequest.status_filter:type_name -> vehicle.VehicleStatus
-	0,  // 21: vehicle.UpdateVehicleStatusRequest.status:type_name -> vehicle.VehicleStatus
-	7,  // 22: vehicle.UpdateVehicleStatusResponse.vehicle:type_name -> vehicle.Vehicle
-	8,  // 23: vehicle.VehicleService.CreateVehicle:input_type -> vehicle.CreateVehicleRequest
-	11, // 24: vehicle.VehicleService.GetVehicle:input_type -> vehicle.GetVehicleRequest
-	13, // 25: vehicle.VehicleService.ListVehicles:input_type -> vehicle.ListVehiclesRequest
-	15, // 26: vehicle.VehicleService.UpdateVehicle:input_type -> vehicle.UpdateVehicleRequest
-	17, // 27: vehicle.VehicleService.DeleteVehicle:input_type -> vehicle.DeleteVehicleRequest
-	18, // 28: vehicle.VehicleService.GetVehiclesByType:input_type -> vehicle.GetVehiclesByTypeRequest
-	19, // 29: vehicle.VehicleService.GetAvailableVehicles:input_type -> vehicle.GetAvailableVehiclesRequest
-	20, // 30: vehicle.VehicleService.UpdateVehicleStatus:input_type -> vehicle.UpdateVehicleStatusRequest
-	3,  // 31: vehicle.VehicleService.CreateVehicleType:input_type -> vehicle.CreateVehicleTypeRequest
-	5,  // 32: vehicle.VehicleService.ListVehicleTypes:input_type -> vehicle.ListVehicleTypesRequest
-	10, // 33: vehicle.VehicleService.CreateVehicle:output_type -> vehicle.CreateVehicleResponse
-	12, // 34: vehicle.VehicleService.GetVehicle:output_type -> vehicle.GetVehicleResponse
-	14, // 35: vehicle.VehicleService.ListVehicles:output_type -> vehicle.ListVehiclesResponse
-	16, // 36: vehicle.VehicleService.UpdateVehicle:output_type -> vehicle.UpdateVehicleResponse
-	24, // 37: vehicle.VehicleService.DeleteVehicle:output_type -> google.protobuf.Empty
-	14, // 38: vehicle.VehicleService.GetVehiclesByType:output_type -> vehicle.ListVehiclesResponse
-	14, // 39: vehicle.VehicleService.GetAvailableVehicles:output_type -> vehicle.ListVehiclesResponse
-	21, // 40: vehicle.VehicleService.UpdateVehicleStatus:output_type -> vehicle.UpdateVehicleStatusResponse
-	4,  // 41: vehicle.VehicleService.CreateVehicleType:output_type -> vehicle.CreateVehicleTypeResponse
-	6,  // 42: vehicle.VehicleService.ListVehicleTypes:output_type -> vehicle.ListVehicleTypesResponse
-	33, // [33:43] is the sub-list for method output_type
-	23, // [23:33] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	65, // 0: vehicle.VehicleType.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 1: vehicle.CreateVehicleTypeResponse.vehicle_type:type_name -> vehicle.VehicleType
+	4,  // 2: vehicle.ListVehicleTypesResponse.vehicle_types:type_name -> vehicle.VehicleType
+	4,  // 3: vehicle.GetVehicleTypeResponse.vehicle_type:type_name -> vehicle.VehicleType
+	4,  // 4: vehicle.UpdateVehicleTypeResponse.vehicle_type:type_name -> vehicle.VehicleType
+	1,  // 5: vehicle.Vehicle.fuel_type:type_name -> vehicle.FuelType
+	65, // 6: vehicle.Vehicle.registration_date:type_name -> google.protobuf.Timestamp
+	65, // 7: vehicle.Vehicle.insurance_expiry:type_name -> google.protobuf.Timestamp
+	0,  // 8: vehicle.Vehicle.status:type_name -> vehicle.VehicleStatus
+	65, // 9: vehicle.Vehicle.created_at:type_name -> google.protobuf.Timestamp
+	65, // 10: vehicle.Vehicle.updated_at:type_name -> google.protobuf.Timestamp
+	65, // 11: vehicle.Vehicle.last_service_at:type_name -> google.protobuf.Timestamp
+	17, // 12: vehicle.CreateVehicleRequest.vehicle:type_name -> vehicle.VehicleInput
+	1,  // 13: vehicle.VehicleInput.fuel_type:type_name -> vehicle.FuelType
+	65, // 14: vehicle.VehicleInput.registration_date:type_name -> google.protobuf.Timestamp
+	65, // 15: vehicle.VehicleInput.insurance_expiry:type_name -> google.protobuf.Timestamp
+	15, // 16: vehicle.CreateVehicleResponse.vehicle:type_name -> vehicle.Vehicle
+	15, // 17: vehicle.GetVehicleResponse.vehicle:type_name -> vehicle.Vehicle
+	64, // 18: vehicle.BatchGetVehiclesResponse.vehicles:type_name -> vehicle.BatchGetVehiclesResponse.VehiclesEntry
+	0,  // 19: vehicle.ListVehiclesRequest.status_filter:type_name -> vehicle.VehicleStatus
+	2,  // 20: vehicle.ListVehiclesRequest.make_match:type_name -> vehicle.MakeMatch
+	15, // 21: vehicle.ListVehiclesResponse.vehicles:type_name -> vehicle.Vehicle
+	17, // 22: vehicle.UpdateVehicleRequest.vehicle:type_name -> vehicle.VehicleInput
+	66, // 23: vehicle.UpdateVehicleRequest.update_mask:type_name -> google.protobuf.FieldMask
+	15, // 24: vehicle.UpdateVehicleResponse.vehicle:type_name -> vehicle.Vehicle
+	28, // 25: vehicle.UpdateVehicleResponse.normalization_warnings:type_name -> vehicle.NormalizationWarning
+	0,  // 26: vehicle.GetVehiclesByTypeRequest.status_filter:type_name -> vehicle.VehicleStatus
+	65, // 27: vehicle.GetDispatchCandidatesRequest.insurance_valid_on:type_name -> google.protobuf.Timestamp
+	0,  // 28: vehicle.UpdateVehicleStatusRequest.status:type_name -> vehicle.VehicleStatus
+	15, // 29: vehicle.UpdateVehicleStatusResponse.vehicle:type_name -> vehicle.Vehicle
+	15, // 30: vehicle.RecordVehicleMileageResponse.vehicle:type_name -> vehicle.Vehicle
+	65, // 31: vehicle.RecordServiceRequest.serviced_at:type_name -> google.protobuf.Timestamp
+	15, // 32: vehicle.RecordServiceResponse.vehicle:type_name -> vehicle.Vehicle
+	15, // 33: vehicle.MaintenanceDueVehicle.vehicle:type_name -> vehicle.Vehicle
+	41, // 34: vehicle.GetVehiclesDueForMaintenanceResponse.vehicles:type_name -> vehicle.MaintenanceDueVehicle
+	0,  // 35: vehicle.ValidateVehicleStatusChangeRequest.status:type_name -> vehicle.VehicleStatus
+	0,  // 36: vehicle.ValidateVehicleStatusChangeResponse.current_status:type_name -> vehicle.VehicleStatus
+	15, // 37: vehicle.AssignVehicleResponse.vehicle:type_name -> vehicle.Vehicle
+	47, // 38: vehicle.AssignVehicleResponse.assignment:type_name -> vehicle.VehicleAssignment
+	65, // 39: vehicle.VehicleAssignment.assigned_at:type_name -> google.protobuf.Timestamp
+	65, // 40: vehicle.VehicleAssignment.flagged_at:type_name -> google.protobuf.Timestamp
+	15, // 41: vehicle.GetDriverAssignmentResponse.vehicle:type_name -> vehicle.Vehicle
+	47, // 42: vehicle.GetDriverAssignmentResponse.assignment:type_name -> vehicle.VehicleAssignment
+	47, // 43: vehicle.FlagDriverAssignmentResponse.assignment:type_name -> vehicle.VehicleAssignment
+	0,  // 44: vehicle.VehicleStatusHistoryEntry.previous_status:type_name -> vehicle.VehicleStatus
+	0,  // 45: vehicle.VehicleStatusHistoryEntry.new_status:type_name -> vehicle.VehicleStatus
+	65, // 46: vehicle.VehicleStatusHistoryEntry.changed_at:type_name -> google.protobuf.Timestamp
+	52, // 47: vehicle.GetVehicleStatusHistoryResponse.entries:type_name -> vehicle.VehicleStatusHistoryEntry
+	65, // 48: vehicle.GetFleetUtilizationRequest.from:type_name -> google.protobuf.Timestamp
+	65, // 49: vehicle.GetFleetUtilizationRequest.to:type_name -> google.protobuf.Timestamp
+	3,  // 50: vehicle.GetFleetUtilizationRequest.granularity:type_name -> vehicle.UtilizationGranularity
+	65, // 51: vehicle.UtilizationBucket.start:type_name -> google.protobuf.Timestamp
+	56, // 52: vehicle.GetFleetUtilizationResponse.buckets:type_name -> vehicle.UtilizationBucket
+	61, // 53: vehicle.NormalizedRecord.fields:type_name -> vehicle.NormalizedField
+	62, // 54: vehicle.NormalizeLegacyRecordsResponse.records:type_name -> vehicle.NormalizedRecord
+	15, // 55: vehicle.BatchGetVehiclesResponse.VehiclesEntry.value:type_name -> vehicle.Vehicle
+	16, // 56: vehicle.VehicleService.CreateVehicle:input_type -> vehicle.CreateVehicleRequest
+	19, // 57: vehicle.VehicleService.GetVehicle:input_type -> vehicle.GetVehicleRequest
+	22, // 58: vehicle.VehicleService.BatchGetVehicles:input_type -> vehicle.BatchGetVehiclesRequest
+	21, // 59: vehicle.VehicleService.GetVehicleByChassisNumber:input_type -> vehicle.GetVehicleByChassisNumberRequest
+	24, // 60: vehicle.VehicleService.ListVehicles:input_type -> vehicle.ListVehiclesRequest
+	26, // 61: vehicle.VehicleService.UpdateVehicle:input_type -> vehicle.UpdateVehicleRequest
+	29, // 62: vehicle.VehicleService.DeleteVehicle:input_type -> vehicle.DeleteVehicleRequest
+	30, // 63: vehicle.VehicleService.GetVehiclesByType:input_type -> vehicle.GetVehiclesByTypeRequest
+	31, // 64: vehicle.VehicleService.GetAvailableVehicles:input_type -> vehicle.GetAvailableVehiclesRequest
+	32, // 65: vehicle.VehicleService.GetDispatchCandidates:input_type -> vehicle.GetDispatchCandidatesRequest
+	33, // 66: vehicle.VehicleService.ListRecentlyUpdatedVehicles:input_type -> vehicle.ListRecentlyUpdatedVehiclesRequest
+	34, // 67: vehicle.VehicleService.UpdateVehicleStatus:input_type -> vehicle.UpdateVehicleStatusRequest
+	43, // 68: vehicle.VehicleService.ValidateVehicleStatusChange:input_type -> vehicle.ValidateVehicleStatusChangeRequest
+	53, // 69: vehicle.VehicleService.GetVehicleStatusHistory:input_type -> vehicle.GetVehicleStatusHistoryRequest
+	36, // 70: vehicle.VehicleService.RecordVehicleMileage:input_type -> vehicle.RecordVehicleMileageRequest
+	38, // 71: vehicle.VehicleService.RecordService:input_type -> vehicle.RecordServiceRequest
+	40, // 72: vehicle.VehicleService.GetVehiclesDueForMaintenance:input_type -> vehicle.GetVehiclesDueForMaintenanceRequest
+	45, // 73: vehicle.VehicleService.AssignVehicle:input_type -> vehicle.AssignVehicleRequest
+	48, // 74: vehicle.VehicleService.GetDriverAssignment:input_type -> vehicle.GetDriverAssignmentRequest
+	50, // 75: vehicle.VehicleService.FlagDriverAssignment:input_type -> vehicle.FlagDriverAssignmentRequest
+	55, // 76: vehicle.VehicleService.GetFleetUtilization:input_type -> vehicle.GetFleetUtilizationRequest
+	58, // 77: vehicle.VehicleService.ValidateLicensePlate:input_type -> vehicle.ValidateLicensePlateRequest
+	60, // 78: vehicle.VehicleService.NormalizeLegacyRecords:input_type -> vehicle.NormalizeLegacyRecordsRequest
+	5,  // 79: vehicle.VehicleService.CreateVehicleType:input_type -> vehicle.CreateVehicleTypeRequest
+	7,  // 80: vehicle.VehicleService.ListVehicleTypes:input_type -> vehicle.ListVehicleTypesRequest
+	9,  // 81: vehicle.VehicleService.GetVehicleType:input_type -> vehicle.GetVehicleTypeRequest
+	10, // 82: vehicle.VehicleService.GetVehicleTypeByName:input_type -> vehicle.GetVehicleTypeByNameRequest
+	12, // 83: vehicle.VehicleService.UpdateVehicleType:input_type -> vehicle.UpdateVehicleTypeRequest
+	14, // 84: vehicle.VehicleService.DeleteVehicleType:input_type -> vehicle.DeleteVehicleTypeRequest
+	18, // 85: vehicle.VehicleService.CreateVehicle:output_type -> vehicle.CreateVehicleResponse
+	20, // 86: vehicle.VehicleService.GetVehicle:output_type -> vehicle.GetVehicleResponse
+	23, // 87: vehicle.VehicleService.BatchGetVehicles:output_type -> vehicle.BatchGetVehiclesResponse
+	20, // 88: vehicle.VehicleService.GetVehicleByChassisNumber:output_type -> vehicle.GetVehicleResponse
+	25, // 89: vehicle.VehicleService.ListVehicles:output_type -> vehicle.ListVehiclesResponse
+	27, // 90: vehicle.VehicleService.UpdateVehicle:output_type -> vehicle.UpdateVehicleResponse
+	67, // 91: vehicle.VehicleService.DeleteVehicle:output_type -> google.protobuf.Empty
+	25, // 92: vehicle.VehicleService.GetVehiclesByType:output_type -> vehicle.ListVehiclesResponse
+	25, // 93: vehicle.VehicleService.GetAvailableVehicles:output_type -> vehicle.ListVehiclesResponse
+	25, // 94: vehicle.VehicleService.GetDispatchCandidates:output_type -> vehicle.ListVehiclesResponse
+	25, // 95: vehicle.VehicleService.ListRecentlyUpdatedVehicles:output_type -> vehicle.ListVehiclesResponse
+	35, // 96: vehicle.VehicleService.UpdateVehicleStatus:output_type -> vehicle.UpdateVehicleStatusResponse
+	44, // 97: vehicle.VehicleService.ValidateVehicleStatusChange:output_type -> vehicle.ValidateVehicleStatusChangeResponse
+	54, // 98: vehicle.VehicleService.GetVehicleStatusHistory:output_type -> vehicle.GetVehicleStatusHistoryResponse
+	37, // 99: vehicle.VehicleService.RecordVehicleMileage:output_type -> vehicle.RecordVehicleMileageResponse
+	39, // 100: vehicle.VehicleService.RecordService:output_type -> vehicle.RecordServiceResponse
+	42, // 101: vehicle.VehicleService.GetVehiclesDueForMaintenance:output_type -> vehicle.GetVehiclesDueForMaintenanceResponse
+	46, // 102: vehicle.VehicleService.AssignVehicle:output_type -> vehicle.AssignVehicleResponse
+	49, // 103: vehicle.VehicleService.GetDriverAssignment:output_type -> vehicle.GetDriverAssignmentResponse
+	51, // 104: vehicle.VehicleService.FlagDriverAssignment:output_type -> vehicle.FlagDriverAssignmentResponse
+	57, // 105: vehicle.VehicleService.GetFleetUtilization:output_type -> vehicle.GetFleetUtilizationResponse
+	59, // 106: vehicle.VehicleService.ValidateLicensePlate:output_type -> vehicle.FieldValidationResponse
+	63, // 107: vehicle.VehicleService.NormalizeLegacyRecords:output_type -> vehicle.NormalizeLegacyRecordsResponse
+	6,  // 108: vehicle.VehicleService.CreateVehicleType:output_type -> vehicle.CreateVehicleTypeResponse
+	8,  // 109: vehicle.VehicleService.ListVehicleTypes:output_type -> vehicle.ListVehicleTypesResponse
+	11, // 110: vehicle.VehicleService.GetVehicleType:output_type -> vehicle.GetVehicleTypeResponse
+	11, // 111: vehicle.VehicleService.GetVehicleTypeByName:output_type -> vehicle.GetVehicleTypeResponse
+	13, // 112: vehicle.VehicleService.UpdateVehicleType:output_type -> vehicle.UpdateVehicleTypeResponse
+	67, // 113: vehicle.VehicleService.DeleteVehicleType:output_type -> google.protobuf.Empty
+	85, // [85:114] is the sub-list for method output_type
+	56, // [56:85] is the sub-list for method input_type
+	56, // [56:56] is the sub-list for extension type_name
+	56, // [56:56] is the sub-list for extension extendee
+	0,  // [0:56] is the sub-list for field type_name
 }
 
 func init() { file_vehicle_proto_init() }
@@ -1635,17 +4512,21 @@ func file_vehicle_proto_init() {
 	if File_vehicle_proto != nil {
 		return
 	}
-	file_vehicle_proto_msgTypes[5].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[8].OneofWrappers = []any{}
 	file_vehicle_proto_msgTypes[11].OneofWrappers = []any{}
-	file_vehicle_proto_msgTypes[16].OneofWrappers = []any{}
-	file_vehicle_proto_msgTypes[17].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[20].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[26].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[27].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[28].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[34].OneofWrappers = []any{}
+	file_vehicle_proto_msgTypes[36].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vehicle_proto_rawDesc), len(file_vehicle_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   20,
+			NumEnums:      4,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
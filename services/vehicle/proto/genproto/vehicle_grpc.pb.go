@@ -22,16 +22,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VehicleService_CreateVehicle_FullMethodName        = "/vehicle.VehicleService/CreateVehicle"
-	VehicleService_GetVehicle_FullMethodName           = "/vehicle.VehicleService/GetVehicle"
-	VehicleService_ListVehicles_FullMethodName         = "/vehicle.VehicleService/ListVehicles"
-	VehicleService_UpdateVehicle_FullMethodName        = "/vehicle.VehicleService/UpdateVehicle"
-	VehicleService_DeleteVehicle_FullMethodName        = "/vehicle.VehicleService/DeleteVehicle"
-	VehicleService_GetVehiclesByType_FullMethodName    = "/vehicle.VehicleService/GetVehiclesByType"
-	VehicleService_GetAvailableVehicles_FullMethodName = "/vehicle.VehicleService/GetAvailableVehicles"
-	VehicleService_UpdateVehicleStatus_FullMethodName  = "/vehicle.VehicleService/UpdateVehicleStatus"
-	VehicleService_CreateVehicleType_FullMethodName    = "/vehicle.VehicleService/CreateVehicleType"
-	VehicleService_ListVehicleTypes_FullMethodName     = "/vehicle.VehicleService/ListVehicleTypes"
+	VehicleService_CreateVehicle_FullMethodName                = "/vehicle.VehicleService/CreateVehicle"
+	VehicleService_GetVehicle_FullMethodName                   = "/vehicle.VehicleService/GetVehicle"
+	VehicleService_BatchGetVehicles_FullMethodName             = "/vehicle.VehicleService/BatchGetVehicles"
+	VehicleService_GetVehicleByChassisNumber_FullMethodName    = "/vehicle.VehicleService/GetVehicleByChassisNumber"
+	VehicleService_ListVehicles_FullMethodName                 = "/vehicle.VehicleService/ListVehicles"
+	VehicleService_UpdateVehicle_FullMethodName                = "/vehicle.VehicleService/UpdateVehicle"
+	VehicleService_DeleteVehicle_FullMethodName                = "/vehicle.VehicleService/DeleteVehicle"
+	VehicleService_GetVehiclesByType_FullMethodName            = "/vehicle.VehicleService/GetVehiclesByType"
+	VehicleService_GetAvailableVehicles_FullMethodName         = "/vehicle.VehicleService/GetAvailableVehicles"
+	VehicleService_GetDispatchCandidates_FullMethodName        = "/vehicle.VehicleService/GetDispatchCandidates"
+	VehicleService_ListRecentlyUpdatedVehicles_FullMethodName  = "/vehicle.VehicleService/ListRecentlyUpdatedVehicles"
+	VehicleService_UpdateVehicleStatus_FullMethodName          = "/vehicle.VehicleService/UpdateVehicleStatus"
+	VehicleService_ValidateVehicleStatusChange_FullMethodName  = "/vehicle.VehicleService/ValidateVehicleStatusChange"
+	VehicleService_GetVehicleStatusHistory_FullMethodName      = "/vehicle.VehicleService/GetVehicleStatusHistory"
+	VehicleService_RecordVehicleMileage_FullMethodName         = "/vehicle.VehicleService/RecordVehicleMileage"
+	VehicleService_RecordService_FullMethodName                = "/vehicle.VehicleService/RecordService"
+	VehicleService_GetVehiclesDueForMaintenance_FullMethodName = "/vehicle.VehicleService/GetVehiclesDueForMaintenance"
+	VehicleService_AssignVehicle_FullMethodName                = "/vehicle.VehicleService/AssignVehicle"
+	VehicleService_GetDriverAssignment_FullMethodName          = "/vehicle.VehicleService/GetDriverAssignment"
+	VehicleService_FlagDriverAssignment_FullMethodName         = "/vehicle.VehicleService/FlagDriverAssignment"
+	VehicleService_GetFleetUtilization_FullMethodName          = "/vehicle.VehicleService/GetFleetUtilization"
+	VehicleService_ValidateLicensePlate_FullMethodName         = "/vehicle.VehicleService/ValidateLicensePlate"
+	VehicleService_NormalizeLegacyRecords_FullMethodName       = "/vehicle.VehicleService/NormalizeLegacyRecords"
+	VehicleService_CreateVehicleType_FullMethodName            = "/vehicle.VehicleService/CreateVehicleType"
+	VehicleService_ListVehicleTypes_FullMethodName             = "/vehicle.VehicleService/ListVehicleTypes"
+	VehicleService_GetVehicleType_FullMethodName               = "/vehicle.VehicleService/GetVehicleType"
+	VehicleService_GetVehicleTypeByName_FullMethodName         = "/vehicle.VehicleService/GetVehicleTypeByName"
+	VehicleService_UpdateVehicleType_FullMethodName            = "/vehicle.VehicleService/UpdateVehicleType"
+	VehicleService_DeleteVehicleType_FullMethodName            = "/vehicle.VehicleService/DeleteVehicleType"
 )
 
 // VehicleServiceClient is the client API for VehicleService service.
@@ -41,16 +60,40 @@ type VehicleServiceClient interface {
 	// Basic CRUD operations
 	CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*CreateVehicleResponse, error)
 	GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*GetVehicleResponse, error)
+	BatchGetVehicles(ctx context.Context, in *BatchGetVehiclesRequest, opts ...grpc.CallOption) (*BatchGetVehiclesResponse, error)
+	GetVehicleByChassisNumber(ctx context.Context, in *GetVehicleByChassisNumberRequest, opts ...grpc.CallOption) (*GetVehicleResponse, error)
 	ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error)
 	UpdateVehicle(ctx context.Context, in *UpdateVehicleRequest, opts ...grpc.CallOption) (*UpdateVehicleResponse, error)
 	DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Specialized queries
 	GetVehiclesByType(ctx context.Context, in *GetVehiclesByTypeRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error)
 	GetAvailableVehicles(ctx context.Context, in *GetAvailableVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error)
+	GetDispatchCandidates(ctx context.Context, in *GetDispatchCandidatesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error)
+	ListRecentlyUpdatedVehicles(ctx context.Context, in *ListRecentlyUpdatedVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error)
 	UpdateVehicleStatus(ctx context.Context, in *UpdateVehicleStatusRequest, opts ...grpc.CallOption) (*UpdateVehicleStatusResponse, error)
+	ValidateVehicleStatusChange(ctx context.Context, in *ValidateVehicleStatusChangeRequest, opts ...grpc.CallOption) (*ValidateVehicleStatusChangeResponse, error)
+	GetVehicleStatusHistory(ctx context.Context, in *GetVehicleStatusHistoryRequest, opts ...grpc.CallOption) (*GetVehicleStatusHistoryResponse, error)
+	RecordVehicleMileage(ctx context.Context, in *RecordVehicleMileageRequest, opts ...grpc.CallOption) (*RecordVehicleMileageResponse, error)
+	// Maintenance scheduling
+	RecordService(ctx context.Context, in *RecordServiceRequest, opts ...grpc.CallOption) (*RecordServiceResponse, error)
+	GetVehiclesDueForMaintenance(ctx context.Context, in *GetVehiclesDueForMaintenanceRequest, opts ...grpc.CallOption) (*GetVehiclesDueForMaintenanceResponse, error)
+	// Driver assignment
+	AssignVehicle(ctx context.Context, in *AssignVehicleRequest, opts ...grpc.CallOption) (*AssignVehicleResponse, error)
+	GetDriverAssignment(ctx context.Context, in *GetDriverAssignmentRequest, opts ...grpc.CallOption) (*GetDriverAssignmentResponse, error)
+	FlagDriverAssignment(ctx context.Context, in *FlagDriverAssignmentRequest, opts ...grpc.CallOption) (*FlagDriverAssignmentResponse, error)
+	// Reporting
+	GetFleetUtilization(ctx context.Context, in *GetFleetUtilizationRequest, opts ...grpc.CallOption) (*GetFleetUtilizationResponse, error)
+	// Format checks, no database access
+	ValidateLicensePlate(ctx context.Context, in *ValidateLicensePlateRequest, opts ...grpc.CallOption) (*FieldValidationResponse, error)
+	// Maintenance, admin only
+	NormalizeLegacyRecords(ctx context.Context, in *NormalizeLegacyRecordsRequest, opts ...grpc.CallOption) (*NormalizeLegacyRecordsResponse, error)
 	// Vehicle type management
 	CreateVehicleType(ctx context.Context, in *CreateVehicleTypeRequest, opts ...grpc.CallOption) (*CreateVehicleTypeResponse, error)
 	ListVehicleTypes(ctx context.Context, in *ListVehicleTypesRequest, opts ...grpc.CallOption) (*ListVehicleTypesResponse, error)
+	GetVehicleType(ctx context.Context, in *GetVehicleTypeRequest, opts ...grpc.CallOption) (*GetVehicleTypeResponse, error)
+	GetVehicleTypeByName(ctx context.Context, in *GetVehicleTypeByNameRequest, opts ...grpc.CallOption) (*GetVehicleTypeResponse, error)
+	UpdateVehicleType(ctx context.Context, in *UpdateVehicleTypeRequest, opts ...grpc.CallOption) (*UpdateVehicleTypeResponse, error)
+	DeleteVehicleType(ctx context.Context, in *DeleteVehicleTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type vehicleServiceClient struct {
@@ -81,6 +124,26 @@ func (c *vehicleServiceClient) GetVehicle(ctx context.Context, in *GetVehicleReq
 	return out, nil
 }
 
+func (c *vehicleServiceClient) BatchGetVehicles(ctx context.Context, in *BatchGetVehiclesRequest, opts ...grpc.CallOption) (*BatchGetVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetVehiclesResponse)
+	err := c.cc.Invoke(ctx, VehicleService_BatchGetVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetVehicleByChassisNumber(ctx context.Context, in *GetVehicleByChassisNumberRequest, opts ...grpc.CallOption) (*GetVehicleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehicleResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicleByChassisNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vehicleServiceClient) ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListVehiclesResponse)
@@ -131,6 +194,26 @@ func (c *vehicleServiceClient) GetAvailableVehicles(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *vehicleServiceClient) GetDispatchCandidates(ctx context.Context, in *GetDispatchCandidatesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVehiclesResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetDispatchCandidates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) ListRecentlyUpdatedVehicles(ctx context.Context, in *ListRecentlyUpdatedVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVehiclesResponse)
+	err := c.cc.Invoke(ctx, VehicleService_ListRecentlyUpdatedVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vehicleServiceClient) UpdateVehicleStatus(ctx context.Context, in *UpdateVehicleStatusRequest, opts ...grpc.CallOption) (*UpdateVehicleStatusResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateVehicleStatusResponse)
@@ -141,6 +224,116 @@ func (c *vehicleServiceClient) UpdateVehicleStatus(ctx context.Context, in *Upda
 	return out, nil
 }
 
+func (c *vehicleServiceClient) ValidateVehicleStatusChange(ctx context.Context, in *ValidateVehicleStatusChangeRequest, opts ...grpc.CallOption) (*ValidateVehicleStatusChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateVehicleStatusChangeResponse)
+	err := c.cc.Invoke(ctx, VehicleService_ValidateVehicleStatusChange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetVehicleStatusHistory(ctx context.Context, in *GetVehicleStatusHistoryRequest, opts ...grpc.CallOption) (*GetVehicleStatusHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehicleStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicleStatusHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) RecordVehicleMileage(ctx context.Context, in *RecordVehicleMileageRequest, opts ...grpc.CallOption) (*RecordVehicleMileageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordVehicleMileageResponse)
+	err := c.cc.Invoke(ctx, VehicleService_RecordVehicleMileage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) RecordService(ctx context.Context, in *RecordServiceRequest, opts ...grpc.CallOption) (*RecordServiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordServiceResponse)
+	err := c.cc.Invoke(ctx, VehicleService_RecordService_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetVehiclesDueForMaintenance(ctx context.Context, in *GetVehiclesDueForMaintenanceRequest, opts ...grpc.CallOption) (*GetVehiclesDueForMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehiclesDueForMaintenanceResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehiclesDueForMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) AssignVehicle(ctx context.Context, in *AssignVehicleRequest, opts ...grpc.CallOption) (*AssignVehicleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignVehicleResponse)
+	err := c.cc.Invoke(ctx, VehicleService_AssignVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetDriverAssignment(ctx context.Context, in *GetDriverAssignmentRequest, opts ...grpc.CallOption) (*GetDriverAssignmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverAssignmentResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetDriverAssignment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) FlagDriverAssignment(ctx context.Context, in *FlagDriverAssignmentRequest, opts ...grpc.CallOption) (*FlagDriverAssignmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlagDriverAssignmentResponse)
+	err := c.cc.Invoke(ctx, VehicleService_FlagDriverAssignment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetFleetUtilization(ctx context.Context, in *GetFleetUtilizationRequest, opts ...grpc.CallOption) (*GetFleetUtilizationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFleetUtilizationResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetFleetUtilization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) ValidateLicensePlate(ctx context.Context, in *ValidateLicensePlateRequest, opts ...grpc.CallOption) (*FieldValidationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FieldValidationResponse)
+	err := c.cc.Invoke(ctx, VehicleService_ValidateLicensePlate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) NormalizeLegacyRecords(ctx context.Context, in *NormalizeLegacyRecordsRequest, opts ...grpc.CallOption) (*NormalizeLegacyRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NormalizeLegacyRecordsResponse)
+	err := c.cc.Invoke(ctx, VehicleService_NormalizeLegacyRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vehicleServiceClient) CreateVehicleType(ctx context.Context, in *CreateVehicleTypeRequest, opts ...grpc.CallOption) (*CreateVehicleTypeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateVehicleTypeResponse)
@@ -161,6 +354,46 @@ func (c *vehicleServiceClient) ListVehicleTypes(ctx context.Context, in *ListVeh
 	return out, nil
 }
 
+func (c *vehicleServiceClient) GetVehicleType(ctx context.Context, in *GetVehicleTypeRequest, opts ...grpc.CallOption) (*GetVehicleTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehicleTypeResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicleType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetVehicleTypeByName(ctx context.Context, in *GetVehicleTypeByNameRequest, opts ...grpc.CallOption) (*GetVehicleTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehicleTypeResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicleTypeByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) UpdateVehicleType(ctx context.Context, in *UpdateVehicleTypeRequest, opts ...grpc.CallOption) (*UpdateVehicleTypeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateVehicleTypeResponse)
+	err := c.cc.Invoke(ctx, VehicleService_UpdateVehicleType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) DeleteVehicleType(ctx context.Context, in *DeleteVehicleTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, VehicleService_DeleteVehicleType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VehicleServiceServer is the server API for VehicleService service.
 // All implementations must embed UnimplementedVehicleServiceServer
 // for forward compatibility.
@@ -168,16 +401,40 @@ type VehicleServiceServer interface {
 	// Basic CRUD operations
 	CreateVehicle(context.Context, *CreateVehicleRequest) (*CreateVehicleResponse, error)
 	GetVehicle(context.Context, *GetVehicleRequest) (*GetVehicleResponse, error)
+	BatchGetVehicles(context.Context, *BatchGetVehiclesRequest) (*BatchGetVehiclesResponse, error)
+	GetVehicleByChassisNumber(context.Context, *GetVehicleByChassisNumberRequest) (*GetVehicleResponse, error)
 	ListVehicles(context.Context, *ListVehiclesRequest) (*ListVehiclesResponse, error)
 	UpdateVehicle(context.Context, *UpdateVehicleRequest) (*UpdateVehicleResponse, error)
 	DeleteVehicle(context.Context, *DeleteVehicleRequest) (*emptypb.Empty, error)
 	// Specialized queries
 	GetVehiclesByType(context.Context, *GetVehiclesByTypeRequest) (*ListVehiclesResponse, error)
 	GetAvailableVehicles(context.Context, *GetAvailableVehiclesRequest) (*ListVehiclesResponse, error)
+	GetDispatchCandidates(context.Context, *GetDispatchCandidatesRequest) (*ListVehiclesResponse, error)
+	ListRecentlyUpdatedVehicles(context.Context, *ListRecentlyUpdatedVehiclesRequest) (*ListVehiclesResponse, error)
 	UpdateVehicleStatus(context.Context, *UpdateVehicleStatusRequest) (*UpdateVehicleStatusResponse, error)
+	ValidateVehicleStatusChange(context.Context, *ValidateVehicleStatusChangeRequest) (*ValidateVehicleStatusChangeResponse, error)
+	GetVehicleStatusHistory(context.Context, *GetVehicleStatusHistoryRequest) (*GetVehicleStatusHistoryResponse, error)
+	RecordVehicleMileage(context.Context, *RecordVehicleMileageRequest) (*RecordVehicleMileageResponse, error)
+	// Maintenance scheduling
+	RecordService(context.Context, *RecordServiceRequest) (*RecordServiceResponse, error)
+	GetVehiclesDueForMaintenance(context.Context, *GetVehiclesDueForMaintenanceRequest) (*GetVehiclesDueForMaintenanceResponse, error)
+	// Driver assignment
+	AssignVehicle(context.Context, *AssignVehicleRequest) (*AssignVehicleResponse, error)
+	GetDriverAssignment(context.Context, *GetDriverAssignmentRequest) (*GetDriverAssignmentResponse, error)
+	FlagDriverAssignment(context.Context, *FlagDriverAssignmentRequest) (*FlagDriverAssignmentResponse, error)
+	// Reporting
+	GetFleetUtilization(context.Context, *GetFleetUtilizationRequest) (*GetFleetUtilizationResponse, error)
+	// Format checks, no database access
+	ValidateLicensePlate(context.Context, *ValidateLicensePlateRequest) (*FieldValidationResponse, error)
+	// Maintenance, admin only
+	NormalizeLegacyRecords(context.Context, *NormalizeLegacyRecordsRequest) (*NormalizeLegacyRecordsResponse, error)
 	// Vehicle type management
 	CreateVehicleType(context.Context, *CreateVehicleTypeRequest) (*CreateVehicleTypeResponse, error)
 	ListVehicleTypes(context.Context, *ListVehicleTypesRequest) (*ListVehicleTypesResponse, error)
+	GetVehicleType(context.Context, *GetVehicleTypeRequest) (*GetVehicleTypeResponse, error)
+	GetVehicleTypeByName(context.Context, *GetVehicleTypeByNameRequest) (*GetVehicleTypeResponse, error)
+	UpdateVehicleType(context.Context, *UpdateVehicleTypeRequest) (*UpdateVehicleTypeResponse, error)
+	DeleteVehicleType(context.Context, *DeleteVehicleTypeRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedVehicleServiceServer()
 }
 
@@ -194,6 +451,12 @@ func (UnimplementedVehicleServiceServer) CreateVehicle(context.Context, *CreateV
 func (UnimplementedVehicleServiceServer) GetVehicle(context.Context, *GetVehicleRequest) (*GetVehicleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVehicle not implemented")
 }
+func (UnimplementedVehicleServiceServer) BatchGetVehicles(context.Context, *BatchGetVehiclesRequest) (*BatchGetVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetVehicles not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehicleByChassisNumber(context.Context, *GetVehicleByChassisNumberRequest) (*GetVehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicleByChassisNumber not implemented")
+}
 func (UnimplementedVehicleServiceServer) ListVehicles(context.Context, *ListVehiclesRequest) (*ListVehiclesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVehicles not implemented")
 }
@@ -209,15 +472,66 @@ func (UnimplementedVehicleServiceServer) GetVehiclesByType(context.Context, *Get
 func (UnimplementedVehicleServiceServer) GetAvailableVehicles(context.Context, *GetAvailableVehiclesRequest) (*ListVehiclesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableVehicles not implemented")
 }
+func (UnimplementedVehicleServiceServer) GetDispatchCandidates(context.Context, *GetDispatchCandidatesRequest) (*ListVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispatchCandidates not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListRecentlyUpdatedVehicles(context.Context, *ListRecentlyUpdatedVehiclesRequest) (*ListVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentlyUpdatedVehicles not implemented")
+}
 func (UnimplementedVehicleServiceServer) UpdateVehicleStatus(context.Context, *UpdateVehicleStatusRequest) (*UpdateVehicleStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateVehicleStatus not implemented")
 }
+func (UnimplementedVehicleServiceServer) ValidateVehicleStatusChange(context.Context, *ValidateVehicleStatusChangeRequest) (*ValidateVehicleStatusChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateVehicleStatusChange not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehicleStatusHistory(context.Context, *GetVehicleStatusHistoryRequest) (*GetVehicleStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicleStatusHistory not implemented")
+}
+func (UnimplementedVehicleServiceServer) RecordVehicleMileage(context.Context, *RecordVehicleMileageRequest) (*RecordVehicleMileageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordVehicleMileage not implemented")
+}
+func (UnimplementedVehicleServiceServer) RecordService(context.Context, *RecordServiceRequest) (*RecordServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordService not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehiclesDueForMaintenance(context.Context, *GetVehiclesDueForMaintenanceRequest) (*GetVehiclesDueForMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehiclesDueForMaintenance not implemented")
+}
+func (UnimplementedVehicleServiceServer) AssignVehicle(context.Context, *AssignVehicleRequest) (*AssignVehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetDriverAssignment(context.Context, *GetDriverAssignmentRequest) (*GetDriverAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverAssignment not implemented")
+}
+func (UnimplementedVehicleServiceServer) FlagDriverAssignment(context.Context, *FlagDriverAssignmentRequest) (*FlagDriverAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlagDriverAssignment not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetFleetUtilization(context.Context, *GetFleetUtilizationRequest) (*GetFleetUtilizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFleetUtilization not implemented")
+}
+func (UnimplementedVehicleServiceServer) ValidateLicensePlate(context.Context, *ValidateLicensePlateRequest) (*FieldValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateLicensePlate not implemented")
+}
+func (UnimplementedVehicleServiceServer) NormalizeLegacyRecords(context.Context, *NormalizeLegacyRecordsRequest) (*NormalizeLegacyRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalizeLegacyRecords not implemented")
+}
 func (UnimplementedVehicleServiceServer) CreateVehicleType(context.Context, *CreateVehicleTypeRequest) (*CreateVehicleTypeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicleType not implemented")
 }
 func (UnimplementedVehicleServiceServer) ListVehicleTypes(context.Context, *ListVehicleTypesRequest) (*ListVehicleTypesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVehicleTypes not implemented")
 }
+func (UnimplementedVehicleServiceServer) GetVehicleType(context.Context, *GetVehicleTypeRequest) (*GetVehicleTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicleType not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehicleTypeByName(context.Context, *GetVehicleTypeByNameRequest) (*GetVehicleTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicleTypeByName not implemented")
+}
+func (UnimplementedVehicleServiceServer) UpdateVehicleType(context.Context, *UpdateVehicleTypeRequest) (*UpdateVehicleTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVehicleType not implemented")
+}
+func (UnimplementedVehicleServiceServer) DeleteVehicleType(context.Context, *DeleteVehicleTypeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVehicleType not implemented")
+}
 func (UnimplementedVehicleServiceServer) mustEmbedUnimplementedVehicleServiceServer() {}
 func (UnimplementedVehicleServiceServer) testEmbeddedByValue()                        {}
 
@@ -275,6 +589,42 @@ func _VehicleService_GetVehicle_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VehicleService_BatchGetVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).BatchGetVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_BatchGetVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).BatchGetVehicles(ctx, req.(*BatchGetVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetVehicleByChassisNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleByChassisNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicleByChassisNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicleByChassisNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicleByChassisNumber(ctx, req.(*GetVehicleByChassisNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VehicleService_ListVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListVehiclesRequest)
 	if err := dec(in); err != nil {
@@ -365,6 +715,42 @@ func _VehicleService_GetAvailableVehicles_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VehicleService_GetDispatchCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDispatchCandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetDispatchCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetDispatchCandidates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetDispatchCandidates(ctx, req.(*GetDispatchCandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_ListRecentlyUpdatedVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentlyUpdatedVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).ListRecentlyUpdatedVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_ListRecentlyUpdatedVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).ListRecentlyUpdatedVehicles(ctx, req.(*ListRecentlyUpdatedVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VehicleService_UpdateVehicleStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateVehicleStatusRequest)
 	if err := dec(in); err != nil {
@@ -383,6 +769,204 @@ func _VehicleService_UpdateVehicleStatus_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VehicleService_ValidateVehicleStatusChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateVehicleStatusChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).ValidateVehicleStatusChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_ValidateVehicleStatusChange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).ValidateVehicleStatusChange(ctx, req.(*ValidateVehicleStatusChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetVehicleStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicleStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicleStatusHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicleStatusHistory(ctx, req.(*GetVehicleStatusHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_RecordVehicleMileage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordVehicleMileageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).RecordVehicleMileage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_RecordVehicleMileage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).RecordVehicleMileage(ctx, req.(*RecordVehicleMileageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_RecordService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).RecordService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_RecordService_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).RecordService(ctx, req.(*RecordServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetVehiclesDueForMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehiclesDueForMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehiclesDueForMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehiclesDueForMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehiclesDueForMaintenance(ctx, req.(*GetVehiclesDueForMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_AssignVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).AssignVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_AssignVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).AssignVehicle(ctx, req.(*AssignVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetDriverAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetDriverAssignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetDriverAssignment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetDriverAssignment(ctx, req.(*GetDriverAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_FlagDriverAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlagDriverAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).FlagDriverAssignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_FlagDriverAssignment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).FlagDriverAssignment(ctx, req.(*FlagDriverAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetFleetUtilization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFleetUtilizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetFleetUtilization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetFleetUtilization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetFleetUtilization(ctx, req.(*GetFleetUtilizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_ValidateLicensePlate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateLicensePlateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).ValidateLicensePlate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_ValidateLicensePlate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).ValidateLicensePlate(ctx, req.(*ValidateLicensePlateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_NormalizeLegacyRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormalizeLegacyRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).NormalizeLegacyRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_NormalizeLegacyRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).NormalizeLegacyRecords(ctx, req.(*NormalizeLegacyRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VehicleService_CreateVehicleType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateVehicleTypeRequest)
 	if err := dec(in); err != nil {
@@ -419,6 +1003,78 @@ func _VehicleService_ListVehicleTypes_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VehicleService_GetVehicleType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicleType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicleType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicleType(ctx, req.(*GetVehicleTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetVehicleTypeByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleTypeByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicleTypeByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicleTypeByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicleTypeByName(ctx, req.(*GetVehicleTypeByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_UpdateVehicleType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVehicleTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).UpdateVehicleType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_UpdateVehicleType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).UpdateVehicleType(ctx, req.(*UpdateVehicleTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_DeleteVehicleType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVehicleTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).DeleteVehicleType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_DeleteVehicleType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).DeleteVehicleType(ctx, req.(*DeleteVehicleTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VehicleService_ServiceDesc is the grpc.ServiceDesc for VehicleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -434,6 +1090,14 @@ var VehicleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVehicle",
 			Handler:    _VehicleService_GetVehicle_Handler,
 		},
+		{
+			MethodName: "BatchGetVehicles",
+			Handler:    _VehicleService_BatchGetVehicles_Handler,
+		},
+		{
+			MethodName: "GetVehicleByChassisNumber",
+			Handler:    _VehicleService_GetVehicleByChassisNumber_Handler,
+		},
 		{
 			MethodName: "ListVehicles",
 			Handler:    _VehicleService_ListVehicles_Handler,
@@ -454,10 +1118,62 @@ var VehicleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailableVehicles",
 			Handler:    _VehicleService_GetAvailableVehicles_Handler,
 		},
+		{
+			MethodName: "GetDispatchCandidates",
+			Handler:    _VehicleService_GetDispatchCandidates_Handler,
+		},
+		{
+			MethodName: "ListRecentlyUpdatedVehicles",
+			Handler:    _VehicleService_ListRecentlyUpdatedVehicles_Handler,
+		},
 		{
 			MethodName: "UpdateVehicleStatus",
 			Handler:    _VehicleService_UpdateVehicleStatus_Handler,
 		},
+		{
+			MethodName: "ValidateVehicleStatusChange",
+			Handler:    _VehicleService_ValidateVehicleStatusChange_Handler,
+		},
+		{
+			MethodName: "GetVehicleStatusHistory",
+			Handler:    _VehicleService_GetVehicleStatusHistory_Handler,
+		},
+		{
+			MethodName: "RecordVehicleMileage",
+			Handler:    _VehicleService_RecordVehicleMileage_Handler,
+		},
+		{
+			MethodName: "RecordService",
+			Handler:    _VehicleService_RecordService_Handler,
+		},
+		{
+			MethodName: "GetVehiclesDueForMaintenance",
+			Handler:    _VehicleService_GetVehiclesDueForMaintenance_Handler,
+		},
+		{
+			MethodName: "AssignVehicle",
+			Handler:    _VehicleService_AssignVehicle_Handler,
+		},
+		{
+			MethodName: "GetDriverAssignment",
+			Handler:    _VehicleService_GetDriverAssignment_Handler,
+		},
+		{
+			MethodName: "FlagDriverAssignment",
+			Handler:    _VehicleService_FlagDriverAssignment_Handler,
+		},
+		{
+			MethodName: "GetFleetUtilization",
+			Handler:    _VehicleService_GetFleetUtilization_Handler,
+		},
+		{
+			MethodName: "ValidateLicensePlate",
+			Handler:    _VehicleService_ValidateLicensePlate_Handler,
+		},
+		{
+			MethodName: "NormalizeLegacyRecords",
+			Handler:    _VehicleService_NormalizeLegacyRecords_Handler,
+		},
 		{
 			MethodName: "CreateVehicleType",
 			Handler:    _VehicleService_CreateVehicleType_Handler,
@@ -466,6 +1182,22 @@ var VehicleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListVehicleTypes",
 			Handler:    _VehicleService_ListVehicleTypes_Handler,
 		},
+		{
+			MethodName: "GetVehicleType",
+			Handler:    _VehicleService_GetVehicleType_Handler,
+		},
+		{
+			MethodName: "GetVehicleTypeByName",
+			Handler:    _VehicleService_GetVehicleTypeByName_Handler,
+		},
+		{
+			MethodName: "UpdateVehicleType",
+			Handler:    _VehicleService_UpdateVehicleType_Handler,
+		},
+		{
+			MethodName: "DeleteVehicleType",
+			Handler:    _VehicleService_DeleteVehicleType_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "vehicle.proto",